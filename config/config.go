@@ -19,11 +19,102 @@ func setDefaults() {
 	// Session expiry and cleanup interval are given in hours
 	viper.SetDefault("auth.session_expiry", 24)
 	viper.SetDefault("auth.session_cleanup_interval", 1)
+	// Sessions of admins acting as another user expire after the given minutes
+	viper.SetDefault("auth.impersonation_expiry", 30)
+	// Failed logins per account or client IP until further attempts are locked out, durations are given in seconds
+	viper.SetDefault("auth.lockout_threshold", 5)
+	viper.SetDefault("auth.lockout_ip_threshold", 20)
+	viper.SetDefault("auth.lockout_duration", 30)
+	viper.SetDefault("auth.lockout_max_duration", 3600)
+	// Registration mode is one of open, disabled, invite, domain or approval, the first user can always register.
+	// Domains are separated by spaces and only used in the domain mode
+	viper.SetDefault("auth.registration_mode", "open")
+	viper.SetDefault("auth.registration_domains", "")
+	// Hash ID of the algorithm for new passwords, existing ones are rehashed on login
+	viper.SetDefault("auth.password_hash", "argon2id")
+	// LDAP directory used for authentication, users are created on their first login.
+	// The user filter gets the escaped email for %s, members of the admin group become admins if it is set
+	viper.SetDefault("auth.ldap.enabled", false)
+	viper.SetDefault("auth.ldap.url", "ldap://localhost:389")
+	viper.SetDefault("auth.ldap.start_tls", false)
+	viper.SetDefault("auth.ldap.insecure_skip_verify", false)
+	viper.SetDefault("auth.ldap.bind_dn", "")
+	viper.SetDefault("auth.ldap.bind_password", "")
+	viper.SetDefault("auth.ldap.base_dn", "")
+	viper.SetDefault("auth.ldap.user_filter", "(&(objectClass=inetOrgPerson)(mail=%s))")
+	viper.SetDefault("auth.ldap.attr_email", "mail")
+	viper.SetDefault("auth.ldap.attr_first_name", "givenName")
+	viper.SetDefault("auth.ldap.attr_last_name", "sn")
+	viper.SetDefault("auth.ldap.admin_group_dn", "")
+	viper.SetDefault("auth.ldap.group_member_attribute", "member")
+	// Allow local users to login if the LDAP directory doesn't know them or is unavailable
+	viper.SetDefault("auth.ldap.local_fallback", true)
+	// OpenID Connect provider used for single sign-on, the redirect URL has to be registered at the provider.
+	// Users become admins if the admin claim is true, equals the admin value or is a list containing it
+	viper.SetDefault("auth.oidc.enabled", false)
+	viper.SetDefault("auth.oidc.issuer_url", "")
+	viper.SetDefault("auth.oidc.client_id", "")
+	viper.SetDefault("auth.oidc.client_secret", "")
+	viper.SetDefault("auth.oidc.redirect_url", "http://localhost:8080/oidc/callback")
+	viper.SetDefault("auth.oidc.scopes", "openid profile email")
+	viper.SetDefault("auth.oidc.claim_first_name", "given_name")
+	viper.SetDefault("auth.oidc.claim_last_name", "family_name")
+	viper.SetDefault("auth.oidc.claim_admin", "")
+	viper.SetDefault("auth.oidc.admin_value", "")
 
+	// Audit entries are deleted after the given days, 0 keeps them forever
+	viper.SetDefault("audit.retention_days", 365)
+
+	// Stores the content of files in "local" directories below fs.base_directory or in "s3" for a bucket of an S3-compatible object storage like MinIO,
+	// "memory" keeps them in memory only which loses all files on shutdown and is meant for tests and demo instances
+	viper.SetDefault("fs.storage", "local")
 	viper.SetDefault("fs.base_directory", "data")
+	viper.SetDefault("fs.s3.endpoint", "http://localhost:9000")
+	viper.SetDefault("fs.s3.region", "us-east-1")
+	viper.SetDefault("fs.s3.bucket", "freecloud")
+	// All keys start with the prefix, so the bucket can also hold other data
+	viper.SetDefault("fs.s3.prefix", "")
+	viper.SetDefault("fs.s3.access_key_id", "")
+	viper.SetDefault("fs.s3.secret_access_key", "")
+	// Encrypts the content of the files of users with keys of the users wrapped by the master key.
+	// The base64 encoded master key is taken from the config or generated into the key file on the first start, losing it loses all files.
+	// Files stored before the encryption was enabled stay readable and can be encrypted by admins
+	viper.SetDefault("fs.encryption.enabled", false)
+	viper.SetDefault("fs.encryption.master_key", "")
+	viper.SetDefault("fs.encryption.key_file", "encryption_key")
+	// Stores the content of files of users once by its SHA-256 hash in the blob directory below the storage, so identical files take no extra space.
+	// It cannot be combined with the encryption, blobs no file references anymore are deleted every collect_interval hours, 0 disables it.
+	// The references of the files to their blobs are authenticated with the key generated into the key file on the first start, losing it loses all files
+	viper.SetDefault("fs.deduplication.enabled", false)
+	viper.SetDefault("fs.deduplication.blob_directory", "blobs")
+	viper.SetDefault("fs.deduplication.key_file", "deduplication_key")
+	viper.SetDefault("fs.deduplication.collect_interval", 24)
+	// SHA-256 checksums are recorded for all files, MD5 ones can be recorded besides them for legacy tools and S3 clients comparing ETags
+	viper.SetDefault("fs.checksums_md5", false)
+	// The stored files are hashed again every scrub.interval hours to find damaged content, reading at most rate_limit MB per second.
+	// Damaged files are moved into the quarantine_directory below the storage if quarantine is enabled, otherwise and when they are deduplicated they are only reported
+	viper.SetDefault("fs.scrub.interval", 168)
+	viper.SetDefault("fs.scrub.rate_limit", 10)
+	viper.SetDefault("fs.scrub.quarantine", false)
+	viper.SetDefault("fs.scrub.quarantine_directory", "quarantine")
 	viper.SetDefault("fs.avatar_directory", "avatars")
 	viper.SetDefault("fs.tmp_clear_interval", 6)
 	viper.SetDefault("fs.tmp_data_expiry", 24)
+	// Changes for sync clients are deleted after the given days, 0 keeps them forever
+	viper.SetDefault("fs.changes_retention_days", 90)
+
+	// Serves the files of the users via WebDAV below /webdav if enabled, clients login with app passwords
+	viper.SetDefault("webdav.enabled", false)
+	// Serves the files of the users via SFTP, clients login with their email and password or with SSH keys they added.
+	// The host key is generated at the given path on the first start
+	viper.SetDefault("sftp.enabled", false)
+	viper.SetDefault("sftp.address", ":2022")
+	viper.SetDefault("sftp.host_key", "sftp_host_key")
+	// Serves the files of the users via an S3-compatible API below /s3 if enabled, their top-level folders are the buckets.
+	// Clients sign requests with access keys whose secrets are derived from the key file generated on the first start
+	viper.SetDefault("s3.enabled", false)
+	viper.SetDefault("s3.region", "us-east-1")
+	viper.SetDefault("s3.key_file", "s3_key")
 
 	viper.SetDefault("db.type", "sqlite3")
 	viper.SetDefault("db.host", "")
@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	auditAPI "github.com/freecloudio/server/restapi/operations/audit"
+	"github.com/freecloudio/server/utils"
+)
+
+func AuditGetEntriesHandler(params auditAPI.GetAuditEntriesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionAuditRead)
+	if err != nil {
+		return auditAPI.NewGetAuditEntriesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	filter := getAuditFilter(params.Action, params.ActorID, params.TargetUserID, params.Since, params.Until)
+	auditEntries, total, err := manager.GetAuditManager().GetEntries(filter, *params.Page, *params.PerPage)
+	if err != nil {
+		return auditAPI.NewGetAuditEntriesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return auditAPI.NewGetAuditEntriesOK().WithPayload(&models.AuditEntryList{Entries: auditEntries, Total: total})
+}
+
+func AuditExportEntriesHandler(params auditAPI.ExportAuditEntriesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionAuditRead)
+	if err != nil {
+		return auditAPI.NewExportAuditEntriesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	filter := getAuditFilter(params.Action, params.ActorID, params.TargetUserID, params.Since, params.Until)
+	exportPath, err := manager.GetAuditManager().ExportEntries(principal.User, filter)
+	if err != nil {
+		return auditAPI.NewExportAuditEntriesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return auditAPI.NewExportAuditEntriesOK().WithPayload(&models.Path{Path: exportPath})
+}
+
+// getAuditFilter converts the optional query parameters of the audit operations to a filter
+func getAuditFilter(action *string, actorID, targetUserID, since, until *int64) *models.AuditFilter {
+	filter := &models.AuditFilter{}
+	if action != nil {
+		filter.Action = *action
+	}
+	if actorID != nil {
+		filter.ActorID = *actorID
+	}
+	if targetUserID != nil {
+		filter.TargetUserID = *targetUserID
+	}
+	if since != nil {
+		filter.Since = *since
+	}
+	if until != nil {
+		filter.Until = *until
+	}
+	return filter
+}
+
+// recordAudit stores an event caused by the request of the principal in the audit log, in impersonation sessions the admin is the actor
+func recordAudit(r *http.Request, principal *models.Principal, action string, targetUserID int64, target string) {
+	actorID := principal.User.ID
+	if principal.Impersonator != nil {
+		actorID = principal.Impersonator.ID
+	}
+	manager.GetAuditManager().Record(action, actorID, targetUserID, target, getClientIP(r))
+}
+
+// getFileOwnerID returns the ID of the owner of the file at path, which differs from the user in shared folders.
+// Files which do not exist yet belong to the owner of their folder, the user is returned if neither can be found.
+func getFileOwnerID(user *models.User, path string) int64 {
+	fileInfo, err := manager.GetFileManager().GetFileInfo(user, path, false)
+	if err != nil {
+		folderPath, _ := utils.SplitPath(path)
+		if fileInfo, err = manager.GetFileManager().GetFileInfo(user, folderPath, false); err != nil {
+			return user.ID
+		}
+	}
+	return fileInfo.OwnerID
+}
@@ -1,6 +1,10 @@
 package controller
 
 import (
+	"fmt"
+	"net"
+	"net/http"
+
 	"github.com/freecloudio/server/restapi/fcerrors"
 
 	"github.com/go-openapi/runtime/middleware"
@@ -9,14 +13,28 @@ import (
 	"github.com/freecloudio/server/manager"
 	"github.com/freecloudio/server/models"
 	authAPI "github.com/freecloudio/server/restapi/operations/auth"
+	fileAPI "github.com/freecloudio/server/restapi/operations/file"
 	userAPI "github.com/freecloudio/server/restapi/operations/user"
 )
 
+// avatarCacheControl lets clients cache avatars privately for an hour before revalidating them
+const avatarCacheControl = "private, max-age=3600"
+
 func AuthSignupHandler(params authAPI.SignupParams) middleware.Responder {
-	session, err := manager.GetAuthManager().CreateUser(params.User)
+	inviteCode := ""
+	if params.InviteCode != nil {
+		inviteCode = *params.InviteCode
+	}
+
+	session, err := manager.GetAuthManager().CreateUser(params.User, inviteCode)
 	if err != nil {
 		return authAPI.NewSignupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	manager.GetAuditManager().Record(manager.AuditSignup, params.User.ID, params.User.ID, params.User.Email, getClientIP(params.HTTPRequest))
+	// Without a session the user has to be approved first
+	if session == nil {
+		return authAPI.NewSignupAccepted()
+	}
 
 	return authAPI.NewSignupOK().WithPayload(&models.Token{Token: session.GetSessionString()})
 }
@@ -25,14 +43,44 @@ func AuthLoginHandler(params authAPI.LoginParams) middleware.Responder {
 	email := params.Credentials.Email
 	password := params.Credentials.Password
 
-	session, err := manager.GetAuthManager().LoginUser(email, password)
+	clientIP := getClientIP(params.HTTPRequest)
+
+	session, err := manager.GetAuthManager().LoginUser(email, password, clientIP)
 	if err != nil {
+		// Failed logins might not belong to any user, so the given email is recorded instead
+		manager.GetAuditManager().Record(manager.AuditLoginFailed, 0, 0, email, clientIP)
+	}
+	if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.TooManyLoginAttempts {
+		retryAfter := manager.GetAuthManager().GetLoginRetryAfter(email, clientIP)
+		return authAPI.NewLoginTooManyRequests().WithRetryAfter(retryAfter).WithPayload(fcerrors.GetAPIError(err))
+	} else if err != nil {
 		return authAPI.NewLoginDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	manager.GetAuditManager().Record(manager.AuditLogin, session.UserID, session.UserID, "password", clientIP)
 
 	return authAPI.NewSignupOK().WithPayload(&models.Token{Token: session.GetSessionString()})
 }
 
+func AuthOIDCAuthorizeHandler(params authAPI.OidcAuthorizeParams) middleware.Responder {
+	authURL, err := manager.GetAuthManager().GetOIDCAuthorizationURL()
+	if err != nil {
+		return authAPI.NewOidcAuthorizeDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return authAPI.NewOidcAuthorizeOK().WithPayload(&models.AuthRedirect{URL: authURL})
+}
+
+func AuthOIDCLoginHandler(params authAPI.OidcLoginParams) middleware.Responder {
+	session, err := manager.GetAuthManager().LoginOIDCUser(params.Callback.Code, params.Callback.State)
+	if err != nil {
+		manager.GetAuditManager().Record(manager.AuditLoginFailed, 0, 0, "oidc", getClientIP(params.HTTPRequest))
+		return authAPI.NewOidcLoginDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	manager.GetAuditManager().Record(manager.AuditLogin, session.UserID, session.UserID, "oidc", getClientIP(params.HTTPRequest))
+
+	return authAPI.NewOidcLoginOK().WithPayload(&models.Token{Token: session.GetSessionString()})
+}
+
 func AuthLogoutHandler(params authAPI.LogoutParams, principal *models.Principal) middleware.Responder {
 	session, _ := models.ParseSessionString(principal.Token.Token)
 	err := manager.GetAuthManager().DeleteSession(session)
@@ -49,28 +97,366 @@ func AuthGetCurrentUserHandler(params userAPI.GetCurrentUserParams, principal *m
 }
 
 func AuthGetUserByIDHandler(params userAPI.GetUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserRead)
+	if err != nil {
+		return userAPI.NewGetUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
 	user, err := manager.GetAuthManager().GetUserByID(params.ID)
 	if err != nil {
 		return userAPI.NewGetUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	err = manager.GetPolicyManager().ResolvePermissions(user)
+	if err != nil {
+		return userAPI.NewGetUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
 
 	return userAPI.NewGetUserByIDOK().WithPayload(user)
 }
 
 func AuthDeleteCurrentUserHandler(params userAPI.DeleteCurrentUserParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetAuthManager().DeleteUser(principal.User.ID)
+	err := manager.GetAuthManager().DeleteCurrentUser(principal.User.ID, params.RetainFiles)
 	if err != nil {
 		return userAPI.NewDeleteCurrentUserDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserDelete, principal.User.ID, "")
 
 	return userAPI.NewDeleteCurrentUserOK()
 }
 
 func AuthDeleteUserByIDHandler(params userAPI.DeleteUserByIDParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetAuthManager().DeleteUser(params.ID)
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
 	if err != nil {
 		return userAPI.NewDeleteUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
 
+	err = manager.GetAuthManager().DeleteUser(params.ID)
+	if err != nil {
+		return userAPI.NewDeleteUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserDelete, params.ID, "")
+
 	return userAPI.NewDeleteUserByIDOK()
 }
+
+func AuthUnlockUserByIDHandler(params userAPI.UnlockUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewUnlockUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetAuthManager().UnlockUser(params.ID)
+	if err != nil {
+		return userAPI.NewUnlockUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserUnlock, params.ID, "")
+
+	return userAPI.NewUnlockUserByIDOK()
+}
+
+func AuthApproveUserByIDHandler(params userAPI.ApproveUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewApproveUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetAuthManager().ApproveUser(params.ID)
+	if err != nil {
+		return userAPI.NewApproveUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserApprove, params.ID, "")
+
+	return userAPI.NewApproveUserByIDOK()
+}
+
+func AuthUploadAvatarHandler(params userAPI.UploadAvatarParams, principal *models.Principal) middleware.Responder {
+	defer params.Upfile.Close()
+	err := manager.GetAuthManager().SetUserAvatar(principal.User.ID, params.Upfile)
+	if err != nil {
+		return userAPI.NewUploadAvatarDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewUploadAvatarOK()
+}
+
+func AuthGetAvatarByIDHandler(params userAPI.GetAvatarByIDParams, principal *models.Principal) middleware.Responder {
+	avatar, err := manager.GetAuthManager().GetUserAvatar(params.ID, int(*params.Size))
+	if err != nil {
+		return userAPI.NewGetAvatarByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	stat, err := avatar.Stat()
+	if err != nil {
+		avatar.Close()
+		return userAPI.NewGetAvatarByIDDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+	}
+	eTag := fmt.Sprintf(`"%d-%d-%d"`, params.ID, *params.Size, stat.ModTime().UnixNano())
+	lastModified := stat.ModTime().UTC().Format(http.TimeFormat)
+
+	if params.IfNoneMatch != nil && *params.IfNoneMatch == eTag {
+		avatar.Close()
+		return userAPI.NewGetAvatarByIDNotModified().WithCacheControl(avatarCacheControl).WithETag(eTag).WithLastModified(lastModified)
+	}
+
+	return userAPI.NewGetAvatarByIDOK().WithCacheControl(avatarCacheControl).WithETag(eTag).WithLastModified(lastModified).WithPayload(avatar)
+}
+
+func AuthDeleteAvatarHandler(params userAPI.DeleteAvatarParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetAuthManager().DeleteUserAvatar(principal.User.ID)
+	if err != nil {
+		return userAPI.NewDeleteAvatarDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewDeleteAvatarOK()
+}
+
+func AuthRequestDataExportHandler(params userAPI.RequestDataExportParams, principal *models.Principal) middleware.Responder {
+	dataExport, err := manager.GetExportManager().RequestDataExport(principal.User)
+	if err != nil {
+		return userAPI.NewRequestDataExportDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewRequestDataExportAccepted().WithPayload(dataExport)
+}
+
+func AuthGetDataExportHandler(params userAPI.GetDataExportParams, principal *models.Principal) middleware.Responder {
+	dataExport, err := manager.GetExportManager().GetDataExport(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewGetDataExportDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	if dataExport.Path != "" {
+		downloadURL, err := (&fileAPI.DownloadFileURL{Path: dataExport.Path}).Build()
+		if err != nil {
+			return userAPI.NewGetDataExportDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+		}
+		dataExport.DownloadURL = downloadURL.String()
+	}
+
+	return userAPI.NewGetDataExportOK().WithPayload(dataExport)
+}
+
+func AuthGetAppPasswordsHandler(params userAPI.GetAppPasswordsParams, principal *models.Principal) middleware.Responder {
+	appPasswords, err := manager.GetAuthManager().GetAppPasswords(principal.User.ID)
+	if err != nil {
+		return userAPI.NewGetAppPasswordsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewGetAppPasswordsOK().WithPayload(&models.AppPasswordList{AppPasswords: appPasswords})
+}
+
+func AuthCreateAppPasswordHandler(params userAPI.CreateAppPasswordParams, principal *models.Principal) middleware.Responder {
+	appPassword, err := manager.GetAuthManager().CreateAppPassword(principal.User.ID, params.AppPasswordRequest.Name)
+	if err != nil {
+		return userAPI.NewCreateAppPasswordDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditAppPasswordCreate, principal.User.ID, fmt.Sprintf("app password %d %s", appPassword.ID, appPassword.Name))
+
+	return userAPI.NewCreateAppPasswordOK().WithPayload(appPassword)
+}
+
+func AuthDeleteAppPasswordHandler(params userAPI.DeleteAppPasswordParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetAuthManager().DeleteAppPassword(principal.User.ID, params.ID)
+	if err != nil {
+		return userAPI.NewDeleteAppPasswordDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditAppPasswordRevoke, principal.User.ID, fmt.Sprintf("app password %d", params.ID))
+
+	return userAPI.NewDeleteAppPasswordOK()
+}
+
+func AuthGetSSHKeysHandler(params userAPI.GetSSHKeysParams, principal *models.Principal) middleware.Responder {
+	sshKeys, err := manager.GetAuthManager().GetSSHKeys(principal.User.ID)
+	if err != nil {
+		return userAPI.NewGetSSHKeysDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewGetSSHKeysOK().WithPayload(&models.SSHKeyList{SSHKeys: sshKeys})
+}
+
+func AuthAddSSHKeyHandler(params userAPI.AddSSHKeyParams, principal *models.Principal) middleware.Responder {
+	sshKey, err := manager.GetAuthManager().AddSSHKey(principal.User.ID, params.SSHKeyRequest.Name, params.SSHKeyRequest.PublicKey)
+	if err != nil {
+		return userAPI.NewAddSSHKeyDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditSSHKeyAdd, principal.User.ID, fmt.Sprintf("ssh key %d %s", sshKey.ID, sshKey.Fingerprint))
+
+	return userAPI.NewAddSSHKeyOK().WithPayload(sshKey)
+}
+
+func AuthDeleteSSHKeyHandler(params userAPI.DeleteSSHKeyParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetAuthManager().DeleteSSHKey(principal.User.ID, params.ID)
+	if err != nil {
+		return userAPI.NewDeleteSSHKeyDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditSSHKeyRemove, principal.User.ID, fmt.Sprintf("ssh key %d", params.ID))
+
+	return userAPI.NewDeleteSSHKeyOK()
+}
+
+func AuthGetAccessKeysHandler(params userAPI.GetAccessKeysParams, principal *models.Principal) middleware.Responder {
+	accessKeys, err := manager.GetAuthManager().GetAccessKeys(principal.User.ID)
+	if err != nil {
+		return userAPI.NewGetAccessKeysDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewGetAccessKeysOK().WithPayload(&models.AccessKeyList{AccessKeys: accessKeys})
+}
+
+func AuthCreateAccessKeyHandler(params userAPI.CreateAccessKeyParams, principal *models.Principal) middleware.Responder {
+	accessKey, err := manager.GetAuthManager().CreateAccessKey(principal.User.ID, params.AccessKeyRequest.Name)
+	if err != nil {
+		return userAPI.NewCreateAccessKeyDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditAccessKeyCreate, principal.User.ID, fmt.Sprintf("access key %d %s", accessKey.ID, accessKey.AccessKeyID))
+
+	return userAPI.NewCreateAccessKeyOK().WithPayload(accessKey)
+}
+
+func AuthDeleteAccessKeyHandler(params userAPI.DeleteAccessKeyParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetAuthManager().DeleteAccessKey(principal.User.ID, params.ID)
+	if err != nil {
+		return userAPI.NewDeleteAccessKeyDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditAccessKeyRevoke, principal.User.ID, fmt.Sprintf("access key %d", params.ID))
+
+	return userAPI.NewDeleteAccessKeyOK()
+}
+
+func AuthGetUsersHandler(params userAPI.GetUsersParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserRead)
+	if err != nil {
+		return userAPI.NewGetUsersDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	var search string
+	if params.Search != nil {
+		search = *params.Search
+	}
+
+	users, total, err := manager.GetAuthManager().GetUserList(search, *params.SortBy, *params.Descending, *params.Page, *params.PerPage)
+	if err != nil {
+		return userAPI.NewGetUsersDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewGetUsersOK().WithPayload(&models.UserList{Total: total, Users: users})
+}
+
+func AuthDisableUserByIDHandler(params userAPI.DisableUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewDisableUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetAuthManager().DisableUser(params.ID, principal.User.ID)
+	if err != nil {
+		return userAPI.NewDisableUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserDisable, params.ID, "")
+
+	return userAPI.NewDisableUserByIDOK()
+}
+
+func AuthEnableUserByIDHandler(params userAPI.EnableUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewEnableUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetAuthManager().EnableUser(params.ID)
+	if err != nil {
+		return userAPI.NewEnableUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserEnable, params.ID, "")
+
+	return userAPI.NewEnableUserByIDOK()
+}
+
+func AuthTransferUserFilesHandler(params userAPI.TransferUserFilesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewTransferUserFilesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	folderInfo, err := manager.GetAuthManager().TransferUserFiles(params.ID, params.FileTransferRequest.RecipientID)
+	if err != nil {
+		return userAPI.NewTransferUserFilesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserTransfer, params.ID, fmt.Sprintf("to user %d", params.FileTransferRequest.RecipientID))
+
+	return userAPI.NewTransferUserFilesOK().WithPayload(folderInfo)
+}
+
+func AuthImpersonateUserByIDHandler(params userAPI.ImpersonateUserByIDParams, principal *models.Principal) middleware.Responder {
+	// Impersonations cannot be chained, the audit log has to show the actual admin
+	if principal.Impersonator != nil {
+		err := fcerrors.New(fcerrors.Forbidden)
+		return userAPI.NewImpersonateUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserImpersonate)
+	if err != nil {
+		return userAPI.NewImpersonateUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	err = manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return userAPI.NewImpersonateUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	session, err := manager.GetAuthManager().ImpersonateUser(principal.User.ID, params.ID, params.ImpersonationRequest.AllowWrite, getClientIP(params.HTTPRequest))
+	if err != nil {
+		return userAPI.NewImpersonateUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return userAPI.NewImpersonateUserByIDOK().WithPayload(&models.Token{Token: session.GetSessionString()})
+}
+
+func AuthCreateInviteCodeHandler(params authAPI.CreateInviteCodeParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserManage)
+	if err != nil {
+		return authAPI.NewCreateInviteCodeDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	inviteCode, err := manager.GetAuthManager().CreateInviteCode(params.InviteCodeRequest, principal.User.ID)
+	if err != nil {
+		return authAPI.NewCreateInviteCodeDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return authAPI.NewCreateInviteCodeOK().WithPayload(inviteCode)
+}
+
+func AuthGetInviteCodesHandler(params authAPI.GetInviteCodesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserManage)
+	if err != nil {
+		return authAPI.NewGetInviteCodesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	inviteCodes, err := manager.GetAuthManager().GetAllInviteCodes()
+	if err != nil {
+		return authAPI.NewGetInviteCodesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return authAPI.NewGetInviteCodesOK().WithPayload(&models.InviteCodeList{InviteCodes: inviteCodes})
+}
+
+func AuthRevokeInviteCodeHandler(params authAPI.RevokeInviteCodeParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionUserManage)
+	if err != nil {
+		return authAPI.NewRevokeInviteCodeDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetAuthManager().RevokeInviteCode(params.ID)
+	if err != nil {
+		return authAPI.NewRevokeInviteCodeDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return authAPI.NewRevokeInviteCodeOK()
+}
+
+// getClientIP returns the address of the client which sent the request without the port
+func getClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
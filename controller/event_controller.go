@@ -0,0 +1,158 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	fileAPI "github.com/freecloudio/server/restapi/operations/file"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	log "gopkg.in/clog.v1"
+)
+
+const (
+	eventBatchLimit   = 500
+	eventPingInterval = 30 * time.Second
+	eventRetryMillis  = 5000
+)
+
+// FileGetEventsHandler streams the changes the user can see as server-sent events until the client disconnects or the session ends
+func FileGetEventsHandler(params fileAPI.GetFileEventsParams, principal *models.Principal) middleware.Responder {
+	cursor := int64(-1)
+	if params.LastEventID != nil {
+		cursor = *params.LastEventID
+	}
+
+	// Subscribe before getting the first changes, so no change recorded in between is missed
+	notifications, unsubscribe := manager.GetChangeManager().Subscribe()
+	changeList, err := manager.GetChangeManager().GetChanges(principal.User, cursor, eventBatchLimit)
+	if err != nil {
+		unsubscribe()
+		return fileAPI.NewGetFileEventsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer unsubscribe()
+		streamFileEvents(rw, params.HTTPRequest, principal, notifications, changeList, cursor)
+	})
+}
+
+func streamFileEvents(rw http.ResponseWriter, r *http.Request, principal *models.Principal, notifications <-chan struct{}, changeList *models.FileChangeList, cursor int64) {
+	controller := http.NewResponseController(rw)
+	// The stream would be cut off by the write timeout of the server otherwise, dead connections are detected by failing pings
+	if err := controller.SetWriteDeadline(time.Time{}); err != nil {
+		log.Trace("Could not remove write deadline of event stream, it ends with the write timeout: %v", err)
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	rw.Header().Set("X-Accel-Buffering", "no")
+	rw.WriteHeader(http.StatusOK)
+	fmt.Fprintf(rw, "retry: %d\n\n", eventRetryMillis)
+
+	event := "ready"
+	if changeList.Reset && cursor >= 0 {
+		event = "reset"
+	}
+	if changeList.Reset {
+		cursor = changeList.Cursor
+	}
+	err := writeEvent(rw, strconv.FormatInt(cursor, 10), event, map[string]int64{"cursor": cursor})
+
+	ticker := time.NewTicker(eventPingInterval)
+	defer ticker.Stop()
+	for err == nil {
+		err = writeChangeEvents(rw, principal.User, changeList)
+		if err == nil {
+			err = controller.Flush()
+		}
+		if err != nil {
+			break
+		}
+
+		if !changeList.HasMore && !waitForChanges(rw, controller, r, principal, notifications, ticker) {
+			return
+		}
+		changeList, err = manager.GetChangeManager().GetChanges(principal.User, changeList.Cursor, eventBatchLimit)
+		if err == nil && changeList.Reset {
+			err = writeEvent(rw, strconv.FormatInt(changeList.Cursor, 10), "reset", map[string]int64{"cursor": changeList.Cursor})
+		}
+	}
+	log.Trace("Ending event stream of user %d: %v", principal.User.ID, err)
+}
+
+// waitForChanges blocks until changes have been recorded and pings the client meanwhile.
+// It returns false if the stream has to end as the client disconnected, the server shuts down or the session of the user is not valid anymore.
+func waitForChanges(rw http.ResponseWriter, controller *http.ResponseController, r *http.Request, principal *models.Principal, notifications <-chan struct{}, ticker *time.Ticker) bool {
+	for {
+		select {
+		case <-r.Context().Done():
+			return false
+		case _, ok := <-notifications:
+			return ok
+		case <-ticker.C:
+			if _, err := ValidateToken(principal.Token.Token, []string{"user"}); err != nil {
+				return false
+			}
+			_, err := io.WriteString(rw, ": ping\n\n")
+			if err == nil {
+				err = controller.Flush()
+			}
+			if err != nil {
+				log.Trace("Ending event stream of user %d: %v", principal.User.ID, err)
+				return false
+			}
+		}
+	}
+}
+
+// writeChangeEvents writes an event per change and, if files of the user changed, one with the storage he uses now
+func writeChangeEvents(w io.Writer, user *models.User, changeList *models.FileChangeList) (err error) {
+	ownFilesChanged := false
+	for _, change := range changeList.Changes {
+		event := "change"
+		if change.Action == manager.ChangeShared || change.Action == manager.ChangeUnshared {
+			event = "share"
+		} else if change.OwnerID == user.ID {
+			ownFilesChanged = true
+		}
+
+		err = writeEvent(w, strconv.FormatInt(change.ID, 10), event, change)
+		if err != nil {
+			return
+		}
+	}
+
+	if !ownFilesChanged {
+		return
+	}
+	storageUsed, err := manager.GetFileManager().GetStorageUsedByUsers([]int64{user.ID})
+	if err != nil {
+		log.Error(0, "Could not get storage used by user %d for event: %v", user.ID, err)
+		return nil
+	}
+	return writeEvent(w, "", "quota", map[string]int64{"storageUsed": storageUsed[user.ID]})
+}
+
+// writeEvent writes a server-sent event with the data encoded as JSON, an empty id keeps the last one of the client
+func writeEvent(w io.Writer, id, event string, data interface{}) (err error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return
+	}
+	if id != "" {
+		_, err = fmt.Fprintf(w, "id: %s\n", id)
+		if err != nil {
+			return
+		}
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, payload)
+	return
+}
@@ -1,14 +1,27 @@
 package controller
 
 import (
+	"compress/gzip"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net/http"
+	"path"
 
 	"github.com/freecloudio/server/manager"
 	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
 	fileAPI "github.com/freecloudio/server/restapi/operations/file"
+	"github.com/freecloudio/server/utils"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 )
 
+// downloadMimeTypes are the types files are downloaded as in the order of the spec, downloads are gzipped unless only uncompressed content is accepted
+var downloadMimeTypes = []string{"application/gzip", runtime.DefaultMime}
+
 func FileGetPathInfoHandler(params fileAPI.GetPathInfoParams, principal *models.Principal) middleware.Responder {
 	pathInfo, err := manager.GetFileManager().GetPathInfo(principal.User, params.Path)
 	if err != nil {
@@ -19,33 +32,187 @@ func FileGetPathInfoHandler(params fileAPI.GetPathInfoParams, principal *models.
 }
 
 func FileCreateHandler(params fileAPI.CreateFileParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+	if err != nil {
+		return fileAPI.NewCreateFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
 	fileInfo, err := manager.GetFileManager().CreateFile(principal.User, params.CreateFileRequest.FullPath, params.CreateFileRequest.IsDir)
 	if err != nil {
 		return fileAPI.NewCreateFileDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
 	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditFileCreate, fileInfo.OwnerID, params.CreateFileRequest.FullPath)
 
 	return fileAPI.NewCreateFileOK().WithPayload(fileInfo)
 }
 
 func FileDeleteHandler(params fileAPI.DeleteFileParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetFileManager().DeleteFile(principal.User, params.Path)
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
 	if err != nil {
-		return fileAPI.NewCreateFileDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+		return fileAPI.NewDeleteFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	// Look up the owner before the file is gone, files in folders shared with the user belong to the sharing user
+	fileInfo, err := manager.GetFileManager().GetFileInfo(principal.User, params.Path, true)
+	if err != nil {
+		err = wrapFileError(err)
+		return fileAPI.NewDeleteFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetFileManager().DeleteFile(principal.User, params.Path)
+	if err != nil {
+		err = wrapFileError(err)
+		return fileAPI.NewDeleteFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditFileDelete, fileInfo.OwnerID, params.Path)
 
 	return fileAPI.NewDeleteFileOK()
 }
 
+func FileUpdateHandler(params fileAPI.UpdateFileParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+	if err != nil {
+		return fileAPI.NewUpdateFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	fileInfo, err := manager.GetFileManager().UpdateFile(principal.User, params.Path, params.FileInfoUpdate)
+	if err != nil {
+		err = wrapFileError(err)
+		return fileAPI.NewUpdateFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	// Only starring or unstarring the file is no move
+	oldPath := utils.ConvertToSlash(params.Path, false)
+	newPath := utils.ConvertToSlash(path.Join(fileInfo.Path, fileInfo.Name), false)
+	if newPath != oldPath {
+		recordAudit(params.HTTPRequest, principal, manager.AuditFileMove, fileInfo.OwnerID, oldPath+" -> "+newPath)
+	}
+
+	return fileAPI.NewUpdateFileOK()
+}
+
+func FileDownloadHandler(params fileAPI.DownloadFileParams, principal *models.Principal) middleware.Responder {
+	file, fileInfo, err := manager.GetFileManager().OpenFile(principal.User, params.Path)
+	if err != nil {
+		err = wrapFileError(err)
+		return fileAPI.NewDownloadFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditFileDownload, fileInfo.OwnerID, params.Path)
+
+	return downloadFileResponse(params.HTTPRequest, fileInfo, file)
+}
+
+// downloadFileResponse streams the file gzipped unless only uncompressed content is accepted.
+// The checksums in the headers describe the stored content, so the digest is only sent and the ETag is only strong if it is not compressed.
+// The router picks any of the types if the client accepts several, so the type is negotiated and the content is written here.
+func downloadFileResponse(request *http.Request, fileInfo *models.FileInfo, file io.ReadCloser) middleware.Responder {
+	response := fileAPI.NewDownloadFileOK().WithPayload(file)
+	contentType, producer := downloadMimeTypes[0], gzipProducer
+	if middleware.NegotiateContentType(request, downloadMimeTypes, "") == runtime.DefaultMime {
+		contentType, producer = runtime.DefaultMime, runtime.ByteStreamProducer()
+		response.WithETag(manager.FileETag(fileInfo)).WithDigest(manager.FileDigest(fileInfo))
+	} else {
+		response.WithETag("W/" + manager.FileETag(fileInfo))
+	}
+
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, _ runtime.Producer) {
+		defer file.Close()
+		rw.Header().Set(runtime.HeaderContentType, contentType)
+		response.WriteResponse(rw, producer)
+	})
+}
+
+// gzipProducer compresses the content of a download while it is streamed to the client
+var gzipProducer runtime.Producer = runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+	gzipWriter := gzip.NewWriter(w)
+	if _, err := io.Copy(gzipWriter, data.(io.Reader)); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+})
+
+func FileUploadHandler(params fileAPI.UploadFileParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+	if err != nil {
+		return fileAPI.NewUploadFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	if params.Upfile == nil {
+		return fileAPI.NewUploadFileDefault(http.StatusBadRequest).WithPayload(&models.Error{Message: "No file uploaded"})
+	}
+	defer params.Upfile.Close()
+
+	expected := manager.Checksums{}
+	if params.Sha256 != nil && !isHexChecksum(*params.Sha256, sha256.Size) || params.Md5 != nil && !isHexChecksum(*params.Md5, md5.Size) {
+		err = fcerrors.New(fcerrors.InvalidChecksum)
+		return fileAPI.NewUploadFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	if params.Sha256 != nil {
+		expected.SHA256 = *params.Sha256
+	}
+	if params.Md5 != nil {
+		expected.MD5 = *params.Md5
+	}
+
+	fileInfo, err := manager.GetFileManager().WriteVerifiedFile(principal.User, params.Path, params.Upfile, expected)
+	if err == manager.ErrChecksumMismatch {
+		err = fcerrors.New(fcerrors.ChecksumMismatch)
+		return fileAPI.NewUploadFileDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	} else if err != nil {
+		return fileAPI.NewUploadFileDefault(http.StatusBadRequest).WithPayload(&models.Error{Message: err.Error()})
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditFileCreate, fileInfo.OwnerID, params.Path)
+
+	return fileAPI.NewUploadFileOK().WithPayload(fileInfo)
+}
+
+// wrapFileError converts the errors of file operations to ones with the matching status code
+func wrapFileError(err error) error {
+	switch err {
+	case manager.ErrFileNotFound, manager.ErrFileNotExist:
+		return fcerrors.New(fcerrors.FileNotFound)
+	case manager.ErrFileExists:
+		return fcerrors.New(fcerrors.FileExists)
+	case manager.ErrForbiddenPathName:
+		return fcerrors.New(fcerrors.InvalidFileName)
+	case manager.ErrInvalidMoveTarget, manager.ErrSharedIntoShared:
+		return fcerrors.New(fcerrors.InvalidMoveTarget)
+	case manager.ErrOpenFolder:
+		return fcerrors.New(fcerrors.DownloadFolder)
+	case manager.ErrReadOnlyMount, manager.ErrMountPoint:
+		return fcerrors.New(fcerrors.FileReadOnly)
+	}
+	if _, ok := err.(*fcerrors.FCError); ok {
+		return err
+	}
+	return fcerrors.Wrap(err, fcerrors.Filesystem)
+}
+
+// isHexChecksum returns whether the value is a hex encoded checksum of the given size in bytes
+func isHexChecksum(value string, size int) bool {
+	sum, err := hex.DecodeString(value)
+	return err == nil && len(sum) == size
+}
+
 func FileRescanCurrentUserHandler(params fileAPI.RescanCurrentUserParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetFileManager().ScanUserFolderForChanges(principal.User)
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+	if err != nil {
+		return fileAPI.NewRescanCurrentUserDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetFileManager().ScanUserFolderForChanges(principal.User)
 	if err != nil {
-		return fileAPI.NewRescanCurrentUserDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+		err = wrapFileError(err)
+		return fileAPI.NewRescanCurrentUserDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
 
 	return fileAPI.NewRescanCurrentUserOK()
 }
 
 func FileRescanUserByIDHandler(params fileAPI.RescanUserByIDParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().AuthorizeUserManagement(principal.User, params.ID)
+	if err != nil {
+		return fileAPI.NewRescanUserByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
 	user, err := manager.GetAuthManager().GetUserByID(params.ID)
 	if err != nil {
 		return fileAPI.NewRescanUserByIDDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
@@ -68,20 +235,68 @@ func FileGetStarredFileInfosHandler(params fileAPI.GetStarredFileInfosParams, pr
 	return fileAPI.NewGetStarredFileInfosOK().WithPayload(&models.FileList{Files: fileInfos})
 }
 
+func FileGetActivitiesHandler(params fileAPI.GetActivitiesParams, principal *models.Principal) middleware.Responder {
+	var since, until int64
+	if params.Since != nil {
+		since = *params.Since
+	}
+	if params.Until != nil {
+		until = *params.Until
+	}
+
+	activities, total, err := manager.GetActivityManager().GetActivities(principal.User, *params.Folder, since, until, *params.Page, *params.PerPage)
+	if err != nil {
+		return fileAPI.NewGetActivitiesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return fileAPI.NewGetActivitiesOK().WithPayload(&models.ActivityList{Activities: activities, Total: total})
+}
+
+func FileGetChangesHandler(params fileAPI.GetFileChangesParams, principal *models.Principal) middleware.Responder {
+	cursor := int64(-1)
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	changeList, err := manager.GetChangeManager().GetChanges(principal.User, cursor, *params.Limit)
+	if err != nil {
+		return fileAPI.NewGetFileChangesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return fileAPI.NewGetFileChangesOK().WithPayload(changeList)
+}
+
 func FileZipFilesHandler(params fileAPI.ZipFilesParams, principal *models.Principal) middleware.Responder {
+	// The zip file is stored in the temp folder of the user until it is downloaded
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+	if err != nil {
+		return fileAPI.NewZipFilesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
 	zipPath, err := manager.GetFileManager().ZipFiles(principal.User, params.Paths.Paths)
 	if err != nil {
-		return fileAPI.NewZipFilesDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+		err = wrapFileError(err)
+		return fileAPI.NewZipFilesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
 
 	return fileAPI.NewZipFilesOK().WithPayload(&models.Path{Path: zipPath})
 }
 
 func FileShareFilesHandler(params fileAPI.ShareFilesParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetFileManager().ShareFiles(principal.User, params.ShareRequest.Users, params.ShareRequest.Paths)
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileShare)
+	if err != nil {
+		return fileAPI.NewShareFilesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetFileManager().ShareFiles(principal.User, params.ShareRequest.Users, params.ShareRequest.Paths)
 	if err != nil {
 		return fileAPI.NewShareFilesDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
 	}
+	for _, path := range params.ShareRequest.Paths {
+		for _, userID := range params.ShareRequest.Users {
+			recordAudit(params.HTTPRequest, principal, manager.AuditShareCreate, userID, path)
+		}
+	}
 
 	return fileAPI.NewShareFilesOK()
 }
@@ -96,10 +311,17 @@ func FileGetShareEntryByIDHandler(params fileAPI.GetShareEntryByIDParams, princi
 }
 
 func FileDeleteShareEntryByIDHandler(params fileAPI.DeleteShareEntryByIDParams, principal *models.Principal) middleware.Responder {
-	err := manager.GetFileManager().DeleteShareEntryByID(params.ShareID, principal.User)
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileShare)
+	if err != nil {
+		return fileAPI.NewDeleteShareEntryByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	shareEntry, err := manager.GetFileManager().DeleteShareEntryByID(params.ShareID, principal.User)
 	if err != nil {
-		return fileAPI.NewDeleteShareEntryByIDDefault(http.StatusInternalServerError).WithPayload(&models.Error{Message: err.Error()})
+		err = wrapFileError(err)
+		return fileAPI.NewDeleteShareEntryByIDDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
 	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditShareRevoke, shareEntry.SharedWithID, fmt.Sprintf("share %d of file %d", shareEntry.ID, shareEntry.FileID))
 
 	return fileAPI.NewDeleteShareEntryByIDOK()
 }
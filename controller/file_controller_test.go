@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/go-openapi/runtime"
+)
+
+func testDownloadFile(accept, content string, fileInfo *models.FileInfo) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/file/download?path=/a.txt", nil)
+	if accept != "" {
+		request.Header.Set("Accept", accept)
+	}
+	recorder := httptest.NewRecorder()
+	downloadFileResponse(request, fileInfo, ioutil.NopCloser(strings.NewReader(content))).WriteResponse(recorder, runtime.ByteStreamProducer())
+	return recorder
+}
+
+func TestDownloadFileResponse(t *testing.T) {
+	content := "content of the file"
+	sum := sha256.Sum256([]byte(content))
+	fileInfo := &models.FileInfo{Name: "a.txt", Size: int64(len(content)), Sha256: hex.EncodeToString(sum[:])}
+
+	recorder := testDownloadFile(runtime.DefaultMime, content, fileInfo)
+	body := recorder.Body.Bytes()
+	if contentType := recorder.Header().Get("Content-Type"); contentType != runtime.DefaultMime || string(body) != content {
+		t.Errorf("Expected uncompressed content but got %s: %q", contentType, body)
+	}
+	digest := recorder.Header().Get("Digest")
+	decoded, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(digest, "SHA-256="))
+	if bodySum := sha256.Sum256(body); !strings.HasPrefix(digest, "SHA-256=") || err != nil || string(decoded) != string(bodySum[:]) {
+		t.Errorf("Expected SHA-256 digest of the sent content but got: %s, %v", digest, err)
+	}
+	if eTag := recorder.Header().Get("ETag"); eTag != `"`+fileInfo.Sha256+`"` {
+		t.Errorf("Expected strong ETag for uncompressed download but got: %s", eTag)
+	}
+
+	// Clients accepting any type get the gzipped content as before
+	for _, accept := range []string{"", "*/*", "application/gzip", "application/gzip, application/octet-stream"} {
+		recorder = testDownloadFile(accept, content, fileInfo)
+		if contentType := recorder.Header().Get("Content-Type"); contentType != "application/gzip" {
+			t.Errorf("Expected gzipped download with Accept %q but got: %s", accept, contentType)
+		}
+		gzipReader, err := gzip.NewReader(recorder.Body)
+		if err != nil {
+			t.Fatalf("Failed to decode gzipped download with Accept %q: %v", accept, err)
+		}
+		if body, err = ioutil.ReadAll(gzipReader); err != nil || string(body) != content {
+			t.Errorf("Expected gzipped content with Accept %q but got: %q, %v", accept, body, err)
+		}
+		if digest = recorder.Header().Get("Digest"); digest != "" {
+			t.Errorf("Expected no digest for gzipped download with Accept %q but got: %s", accept, digest)
+		}
+		if eTag := recorder.Header().Get("ETag"); eTag != `W/"`+fileInfo.Sha256+`"` {
+			t.Errorf("Expected weak ETag for gzipped download with Accept %q but got: %s", accept, eTag)
+		}
+	}
+}
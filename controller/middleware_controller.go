@@ -32,17 +32,35 @@ func ValidateToken(token string, scopes []string) (principal *models.Principal,
 			return nil, errors.New(http.StatusUnauthorized, "Token could not be parsed")
 		}
 
-		valid := manager.GetAuthManager().ValidateSession(session)
+		storedSession, valid := manager.GetAuthManager().GetValidSession(session)
 		if !valid {
 			return nil, errors.New(http.StatusUnauthorized, "No valid session")
 		}
 
 		principal.User, err = manager.GetAuthManager().GetUserByID(session.UserID)
 		if err != nil {
-			return nil, errors.New(http.StatusInternalServerError, err.Error())
+			return nil, errors.New(http.StatusInternalServerError, "%s", err.Error())
+		}
+		if principal.User.Disabled {
+			return nil, errors.New(http.StatusForbidden, "User is disabled")
+		}
+
+		if storedSession.ImpersonatorID != 0 {
+			principal.Impersonator, err = manager.GetAuthManager().GetUserByID(storedSession.ImpersonatorID)
+			// The impersonation ends as soon as the impersonator is deleted, disabled or loses the permission
+			if err != nil || principal.Impersonator.Disabled || manager.GetPolicyManager().Authorize(principal.Impersonator, manager.PermissionUserImpersonate) != nil {
+				return nil, errors.New(http.StatusUnauthorized, "No valid session")
+			}
+			principal.ReadOnly = storedSession.ReadOnly
 		}
 
-		if isUserScope(scopes) || (isAdminScope(scopes) && principal.User.IsAdmin) {
+		err = manager.GetPolicyManager().ResolvePermissions(principal.User)
+		if err != nil {
+			return nil, errors.New(http.StatusInternalServerError, "%s", err.Error())
+		}
+
+		// Fine-grained permissions are checked by the controllers, the admin scope only requires any administrative permission
+		if isUserScope(scopes) || (isAdminScope(scopes) && manager.GetPolicyManager().HasAdministrativePermission(principal.User)) {
 			return
 		} else {
 			return nil, errors.New(http.StatusForbidden, "Insufficient privileges")
@@ -52,6 +70,24 @@ func ValidateToken(token string, scopes []string) (principal *models.Principal,
 	return
 }
 
+// AuthorizeRequest records every request of an impersonation session in the audit log and rejects changes in read-only ones.
+// Logging out is always allowed, so the impersonation can be ended.
+func AuthorizeRequest(r *http.Request, principal interface{}) error {
+	p, ok := principal.(*models.Principal)
+	if !ok || p.Impersonator == nil {
+		return nil
+	}
+
+	target := r.Method + " " + r.URL.RequestURI()
+	if p.ReadOnly && !isReadOnlyRequest(r) {
+		manager.GetAuditManager().Record(manager.AuditImpersonationDenied, p.Impersonator.ID, p.User.ID, target, getClientIP(r))
+		return errors.New(http.StatusForbidden, "Impersonation session is read-only")
+	}
+
+	manager.GetAuditManager().Record(manager.AuditImpersonationRequest, p.Impersonator.ID, p.User.ID, target, getClientIP(r))
+	return nil
+}
+
 // StatusRecordingResponseWriter is a wrapper around a http.ResponseWriter, which allows us to
 // read the status code that has been returned. This is useful for logging.
 type StatusRecordingResponseWriter struct {
@@ -80,6 +116,11 @@ func (w *StatusRecordingResponseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Unwrap returns the wrapped http.ResponseWriter, so a http.ResponseController can flush it and change its deadlines
+func (w *StatusRecordingResponseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
 // LoggingMiddleware logs incoming requests and their responses
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -115,3 +156,11 @@ func isAdminScope(scopes []string) bool {
 
 	return adminScope
 }
+
+func isReadOnlyRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND":
+		return true
+	}
+	return strings.HasSuffix(r.URL.Path, "/auth/logout")
+}
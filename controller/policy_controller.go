@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/freecloudio/server/restapi/fcerrors"
+
+	"github.com/go-openapi/runtime/middleware"
+
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	policyAPI "github.com/freecloudio/server/restapi/operations/policy"
+)
+
+func PolicyGetRolesHandler(params policyAPI.GetRolesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewGetRolesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return policyAPI.NewGetRolesOK().WithPayload(&models.RoleList{Roles: manager.GetPolicyManager().GetRoles()})
+}
+
+func PolicySetUserRolesHandler(params policyAPI.SetUserRolesParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewSetUserRolesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	roles, err := manager.GetPolicyManager().SetUserRoles(params.ID, params.RoleAssignment.Roles)
+	if err != nil {
+		return policyAPI.NewSetUserRolesDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditUserRoles, params.ID, strings.Join(roles, " "))
+
+	return policyAPI.NewSetUserRolesOK().WithPayload(&models.RoleAssignment{Roles: roles})
+}
+
+func PolicyGetGroupsHandler(params policyAPI.GetGroupsParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewGetGroupsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	groups, err := manager.GetPolicyManager().GetGroups()
+	if err != nil {
+		return policyAPI.NewGetGroupsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return policyAPI.NewGetGroupsOK().WithPayload(&models.GroupList{Groups: groups})
+}
+
+func PolicyCreateGroupHandler(params policyAPI.CreateGroupParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewCreateGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	group, err := manager.GetPolicyManager().CreateGroup(params.Group)
+	if err != nil {
+		return policyAPI.NewCreateGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditGroupCreate, 0, fmt.Sprintf("group %d", group.ID))
+
+	return policyAPI.NewCreateGroupOK().WithPayload(group)
+}
+
+func PolicyUpdateGroupHandler(params policyAPI.UpdateGroupParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewUpdateGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	group, err := manager.GetPolicyManager().UpdateGroup(params.ID, params.Group)
+	if err != nil {
+		return policyAPI.NewUpdateGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditGroupUpdate, 0, fmt.Sprintf("group %d", group.ID))
+
+	return policyAPI.NewUpdateGroupOK().WithPayload(group)
+}
+
+func PolicyDeleteGroupHandler(params policyAPI.DeleteGroupParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionRoleManage)
+	if err != nil {
+		return policyAPI.NewDeleteGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetPolicyManager().DeleteGroup(params.ID)
+	if err != nil {
+		return policyAPI.NewDeleteGroupDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditGroupDelete, 0, fmt.Sprintf("group %d", params.ID))
+
+	return policyAPI.NewDeleteGroupOK()
+}
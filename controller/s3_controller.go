@@ -0,0 +1,707 @@
+package controller
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"hash"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freecloudio/server/config"
+	"github.com/freecloudio/server/crypt"
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/go-openapi/errors"
+	log "gopkg.in/clog.v1"
+)
+
+// S3Prefix is the path the files of the users are served under via the S3 API, buckets are addressed path-style below it
+const S3Prefix = "/s3"
+
+const (
+	s3TimeFormat      = "2006-01-02T15:04:05.000Z"
+	s3MaxKeys         = 1000
+	s3MaxRequestBytes = 2 << 20
+)
+
+// s3Error is an error response of the S3 API with its code clients act on
+type s3Error struct {
+	XMLName  xml.Name `xml:"Error"`
+	Code     string
+	Message  string
+	Resource string
+	Status   int `xml:"-"`
+}
+
+func (err *s3Error) Error() string {
+	return err.Code + ": " + err.Message
+}
+
+func newS3Error(status int, code, message string) *s3Error {
+	return &s3Error{Code: code, Message: message, Status: status}
+}
+
+var (
+	errS3BadDigest        = newS3Error(http.StatusBadRequest, "BadDigest", "The Content-MD5 you specified did not match what we received.")
+	errS3InvalidDigest    = newS3Error(http.StatusBadRequest, "InvalidDigest", "The Content-MD5 you specified is not valid.")
+	errS3MalformedXML     = newS3Error(http.StatusBadRequest, "MalformedXML", "The XML you provided was not well-formed or did not validate against our published schema.")
+	errS3NotImplemented   = newS3Error(http.StatusNotImplemented, "NotImplemented", "A header or query you provided implies functionality that is not implemented.")
+	errS3MethodNotAllowed = newS3Error(http.StatusMethodNotAllowed, "MethodNotAllowed", "The specified method is not allowed against this resource.")
+)
+
+// s3Request is an authenticated request of the S3 API for the bucket and key in its path
+type s3Request struct {
+	w         http.ResponseWriter
+	r         *http.Request
+	principal *models.Principal
+	body      io.Reader
+	bucket    string
+	key       string
+}
+
+// S3Middleware serves requests below the S3 prefix with the files of the authenticated user and passes all others on.
+// Clients sign their requests with AWS signature version 4 using one of the access keys of the user.
+func S3Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != S3Prefix && !strings.HasPrefix(r.URL.Path, S3Prefix+"/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, body, err := authenticateS3Request(r)
+		if err == nil && !isReadOnlyRequest(r) {
+			err = manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+		}
+		if err == nil {
+			err = AuthorizeRequest(r, principal)
+		}
+		if err != nil {
+			writeS3Error(w, r, err)
+			return
+		}
+
+		req := &s3Request{w: w, r: r, principal: principal, body: body}
+		req.bucket, req.key = splitS3Path(strings.TrimPrefix(r.URL.Path, S3Prefix))
+		switch {
+		case req.bucket == "":
+			err = req.serveService()
+		case req.key == "":
+			err = req.serveBucket()
+		default:
+			err = req.serveObject()
+		}
+		if err != nil {
+			writeS3Error(w, r, err)
+		}
+	})
+}
+
+// authenticateS3Request verifies the signature of the request with the secret of its access key.
+// Returns the user owning the key and the body, which fails at its end if it does not match the signed payload.
+func authenticateS3Request(r *http.Request) (*models.Principal, io.Reader, error) {
+	auth, err := crypt.ParseSigV4(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var secretKey string
+	var verifyErr error
+	clientIP := getClientIP(r)
+	user, err := manager.GetAuthManager().LoginAccessKey(auth.AccessKeyID, func(secretAccessKey string) error {
+		secretKey = secretAccessKey
+		verifyErr = auth.Verify(r, secretAccessKey, time.Now())
+		return verifyErr
+	}, clientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.BadCredentials {
+		manager.GetAuditManager().Record(manager.AuditLoginFailed, 0, 0, "s3 key "+auth.AccessKeyID, clientIP)
+		if verifyErr != nil {
+			return nil, nil, verifyErr
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = manager.GetPolicyManager().ResolvePermissions(user)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &models.Principal{User: user}, crypt.NewSigV4PayloadReader(r.Body, auth, secretKey), nil
+}
+
+// splitS3Path splits the path after the prefix into the bucket and the key of the object, which keeps a trailing slash
+func splitS3Path(s3Path string) (bucket, key string) {
+	s3Path = strings.TrimPrefix(s3Path, "/")
+	if idx := strings.Index(s3Path, "/"); idx >= 0 {
+		return s3Path[:idx], s3Path[idx+1:]
+	}
+	return s3Path, ""
+}
+
+func (req *s3Request) serveService() error {
+	if req.r.Method != http.MethodGet {
+		return errS3MethodNotAllowed
+	}
+	buckets, err := manager.GetFileManager().ListS3Buckets(req.principal.User)
+	if err != nil {
+		return err
+	}
+
+	result := &s3ListAllMyBucketsResult{Owner: s3Owner(req.principal.User)}
+	for _, bucket := range buckets {
+		result.Buckets = append(result.Buckets, s3Bucket{Name: bucket.Name, CreationDate: s3Time(bucket.LastChanged)})
+	}
+	return req.writeXML(http.StatusOK, result)
+}
+
+func (req *s3Request) serveBucket() error {
+	query := req.r.URL.Query()
+	switch req.r.Method {
+	case http.MethodGet:
+		if _, ok := query["location"]; ok {
+			return req.getBucketLocation()
+		} else if _, ok := query["uploads"]; ok {
+			return errS3NotImplemented
+		}
+		return req.listObjects()
+	case http.MethodHead:
+		_, err := manager.GetFileManager().GetS3Bucket(req.principal.User, req.bucket)
+		return err
+	case http.MethodPost:
+		if _, ok := query["delete"]; ok {
+			return req.deleteObjects()
+		}
+	}
+	return errS3NotImplemented
+}
+
+func (req *s3Request) serveObject() error {
+	query := req.r.URL.Query()
+	_, hasUploadID := query["uploadId"]
+	switch req.r.Method {
+	case http.MethodGet, http.MethodHead:
+		if hasUploadID {
+			return errS3NotImplemented
+		}
+		return req.getObject()
+	case http.MethodPut:
+		if req.r.Header.Get("X-Amz-Copy-Source") != "" {
+			return errS3NotImplemented
+		} else if hasUploadID {
+			return req.uploadPart()
+		}
+		return req.putObject()
+	case http.MethodPost:
+		if _, ok := query["uploads"]; ok {
+			return req.createMultipartUpload()
+		} else if hasUploadID {
+			return req.completeMultipartUpload()
+		}
+	case http.MethodDelete:
+		if hasUploadID {
+			return req.abortMultipartUpload()
+		}
+		return req.deleteObject()
+	}
+	return errS3NotImplemented
+}
+
+func (req *s3Request) getBucketLocation() error {
+	if _, err := manager.GetFileManager().GetS3Bucket(req.principal.User, req.bucket); err != nil {
+		return err
+	}
+	// Buckets in the default region have no location constraint
+	location := &s3LocationConstraint{}
+	if region := config.GetString("s3.region"); region != "us-east-1" {
+		location.Region = region
+	}
+	return req.writeXML(http.StatusOK, location)
+}
+
+// listObjects serves version 1 and 2 of listing objects, both continue after the last key of the previous page
+func (req *s3Request) listObjects() error {
+	query := req.r.URL.Query()
+	maxKeys := s3MaxKeys
+	if maxKeysParam := query.Get("max-keys"); maxKeysParam != "" {
+		parsedMaxKeys, err := strconv.Atoi(maxKeysParam)
+		if err != nil || parsedMaxKeys < 0 {
+			return newS3Error(http.StatusBadRequest, "InvalidArgument", "Provided max-keys not an integer or within integer range")
+		}
+		if parsedMaxKeys < maxKeys {
+			maxKeys = parsedMaxKeys
+		}
+	}
+
+	result := &s3ListBucketResult{
+		Name:      req.bucket,
+		Prefix:    query.Get("prefix"),
+		Delimiter: query.Get("delimiter"),
+		MaxKeys:   maxKeys,
+	}
+	isV2 := query.Get("list-type") == "2"
+	startAfter := query.Get("marker")
+	if isV2 {
+		result.StartAfter = query.Get("start-after")
+		startAfter = result.StartAfter
+		if token := query.Get("continuation-token"); token != "" {
+			tokenKey, err := base64.RawURLEncoding.DecodeString(token)
+			if err != nil {
+				return newS3Error(http.StatusBadRequest, "InvalidArgument", "The continuation token provided is incorrect")
+			}
+			result.ContinuationToken = token
+			if string(tokenKey) > startAfter {
+				startAfter = string(tokenKey)
+			}
+		}
+	} else {
+		result.Marker = &startAfter
+	}
+
+	list, err := manager.GetFileManager().ListS3Objects(req.principal.User, req.bucket, result.Prefix, result.Delimiter, startAfter, maxKeys)
+	if err != nil {
+		return err
+	}
+
+	result.IsTruncated = list.IsTruncated
+	if list.IsTruncated && isV2 {
+		result.NextContinuationToken = base64.RawURLEncoding.EncodeToString([]byte(list.NextStartAfter))
+	} else if list.IsTruncated {
+		result.NextMarker = list.NextStartAfter
+	}
+	for _, object := range list.Objects {
+		result.Contents = append(result.Contents, s3Object{
+			Key:          object.Key,
+			LastModified: s3Time(object.FileInfo.LastChanged),
+			ETag:         s3ETag(object.FileInfo),
+			Size:         object.FileInfo.Size,
+			StorageClass: "STANDARD",
+		})
+	}
+	for _, prefix := range list.CommonPrefixes {
+		result.CommonPrefixes = append(result.CommonPrefixes, s3CommonPrefix{Prefix: prefix})
+	}
+	if isV2 {
+		keyCount := len(result.Contents) + len(result.CommonPrefixes)
+		result.KeyCount = &keyCount
+	}
+	if query.Get("encoding-type") == "url" {
+		result.encodeURL()
+	}
+	return req.writeXML(http.StatusOK, result)
+}
+
+// getObject serves GET and HEAD of objects including ranges and conditional requests, folders are served as empty objects
+func (req *s3Request) getObject() error {
+	file, fileInfo, err := manager.GetFileManager().OpenS3Object(req.principal.User, req.bucket, req.key)
+	if err != nil {
+		return err
+	}
+	var content io.ReadSeeker = bytes.NewReader(nil)
+	if file != nil {
+		defer file.Close()
+		content = file
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(req.key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	req.w.Header().Set("Content-Type", contentType)
+	req.w.Header().Set("ETag", s3ETag(fileInfo))
+	req.w.Header().Set("Accept-Ranges", "bytes")
+	http.ServeContent(req.w, req.r, "", time.Unix(fileInfo.LastChanged, 0), content)
+	return nil
+}
+
+func (req *s3Request) putObject() error {
+	body, err := req.verifiedBody()
+	if err != nil {
+		return err
+	}
+	fileInfo, err := manager.GetFileManager().PutS3Object(req.principal.User, req.bucket, req.key, body)
+	if err != nil {
+		return err
+	}
+	recordAudit(req.r, req.principal, manager.AuditFileCreate, fileInfo.OwnerID, "/"+req.bucket+"/"+req.key)
+
+	req.w.Header().Set("ETag", s3ETag(fileInfo))
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *s3Request) deleteObject() error {
+	target := "/" + req.bucket + "/" + req.key
+	ownerID := getFileOwnerID(req.principal.User, target)
+	err := manager.GetFileManager().DeleteS3Object(req.principal.User, req.bucket, req.key)
+	if err != nil {
+		return err
+	}
+	recordAudit(req.r, req.principal, manager.AuditFileDelete, ownerID, target)
+
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// deleteObjects deletes all objects of the request one after another and reports each failure
+func (req *s3Request) deleteObjects() error {
+	var request s3DeleteRequest
+	err := req.readXML(&request)
+	if err != nil {
+		return err
+	}
+	if len(request.Objects) > s3MaxKeys {
+		return errS3MalformedXML
+	}
+
+	result := &s3DeleteResult{}
+	for _, object := range request.Objects {
+		target := "/" + req.bucket + "/" + object.Key
+		ownerID := getFileOwnerID(req.principal.User, target)
+		err = manager.GetFileManager().DeleteS3Object(req.principal.User, req.bucket, object.Key)
+		if err != nil {
+			s3Err := toS3Error(err)
+			result.Errors = append(result.Errors, s3DeleteError{Key: object.Key, Code: s3Err.Code, Message: s3Err.Message})
+			continue
+		}
+		recordAudit(req.r, req.principal, manager.AuditFileDelete, ownerID, target)
+		if !request.Quiet {
+			result.Deleted = append(result.Deleted, s3DeletedObject{Key: object.Key})
+		}
+	}
+	return req.writeXML(http.StatusOK, result)
+}
+
+func (req *s3Request) createMultipartUpload() error {
+	uploadID, err := manager.GetFileManager().CreateS3MultipartUpload(req.principal.User, req.bucket, req.key)
+	if err != nil {
+		return err
+	}
+	return req.writeXML(http.StatusOK, &s3InitiateMultipartUploadResult{Bucket: req.bucket, Key: req.key, UploadID: uploadID})
+}
+
+func (req *s3Request) uploadPart() error {
+	query := req.r.URL.Query()
+	partNumber, err := strconv.Atoi(query.Get("partNumber"))
+	if err != nil || partNumber < 1 || partNumber > manager.S3MaxPartNumber {
+		return newS3Error(http.StatusBadRequest, "InvalidArgument", fmt.Sprintf("Part number must be an integer between 1 and %d, inclusive", manager.S3MaxPartNumber))
+	}
+	body, err := req.verifiedBody()
+	if err != nil {
+		return err
+	}
+	etag, err := manager.GetFileManager().UploadS3Part(req.principal.User, req.bucket, req.key, query.Get("uploadId"), partNumber, body)
+	if err != nil {
+		return err
+	}
+
+	req.w.Header().Set("ETag", `"`+etag+`"`)
+	req.w.WriteHeader(http.StatusOK)
+	return nil
+}
+
+func (req *s3Request) completeMultipartUpload() error {
+	var request s3CompleteMultipartUpload
+	err := req.readXML(&request)
+	if err != nil {
+		return err
+	}
+	parts := make([]*manager.S3CompletedPart, 0, len(request.Parts))
+	for _, part := range request.Parts {
+		parts = append(parts, &manager.S3CompletedPart{PartNumber: part.PartNumber, ETag: part.ETag})
+	}
+
+	fileInfo, err := manager.GetFileManager().CompleteS3MultipartUpload(req.principal.User, req.bucket, req.key, req.r.URL.Query().Get("uploadId"), parts)
+	if err != nil {
+		return err
+	}
+	recordAudit(req.r, req.principal, manager.AuditFileCreate, fileInfo.OwnerID, "/"+req.bucket+"/"+req.key)
+
+	return req.writeXML(http.StatusOK, &s3CompleteMultipartUploadResult{
+		Location: S3Prefix + "/" + req.bucket + "/" + req.key,
+		Bucket:   req.bucket,
+		Key:      req.key,
+		ETag:     s3ETag(fileInfo),
+	})
+}
+
+func (req *s3Request) abortMultipartUpload() error {
+	err := manager.GetFileManager().AbortS3MultipartUpload(req.principal.User, req.bucket, req.key, req.r.URL.Query().Get("uploadId"))
+	if err != nil {
+		return err
+	}
+	req.w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// verifiedBody returns the body which fails at its end if it does not match the Content-MD5 header of the request
+func (req *s3Request) verifiedBody() (io.Reader, error) {
+	contentMD5 := req.r.Header.Get("Content-MD5")
+	if contentMD5 == "" {
+		return req.body, nil
+	}
+	expectedMD5, err := base64.StdEncoding.DecodeString(contentMD5)
+	if err != nil || len(expectedMD5) != md5.Size {
+		return nil, errS3InvalidDigest
+	}
+	return &s3MD5Reader{reader: req.body, hash: md5.New(), expectedMD5: expectedMD5}, nil
+}
+
+func (req *s3Request) readXML(v interface{}) error {
+	body, err := req.verifiedBody()
+	if err != nil {
+		return err
+	}
+	err = xml.NewDecoder(io.LimitReader(body, s3MaxRequestBytes)).Decode(v)
+	if _, ok := err.(*xml.SyntaxError); ok || err == io.EOF || err == io.ErrUnexpectedEOF {
+		return errS3MalformedXML
+	}
+	return err
+}
+
+func (req *s3Request) writeXML(status int, v interface{}) error {
+	req.w.Header().Set("Content-Type", "application/xml")
+	req.w.WriteHeader(status)
+	io.WriteString(req.w, xml.Header)
+	// The status has already been sent, so failures can only be logged
+	if err := xml.NewEncoder(req.w).Encode(v); err != nil {
+		log.Trace("Writing S3 response to %s failed: %v", req.r.URL.Path, err)
+	}
+	return nil
+}
+
+// toS3Error converts errors to the error codes of S3, errors of the signature are only known after verifying it
+func toS3Error(err error) *s3Error {
+	switch err {
+	case crypt.ErrSigV4Missing:
+		return newS3Error(http.StatusForbidden, "AccessDenied", "Anonymous access is not supported, requests have to be signed with an access key.")
+	case crypt.ErrSigV4Malformed:
+		return newS3Error(http.StatusBadRequest, "AuthorizationHeaderMalformed", "The authorization header or query is malformed.")
+	case crypt.ErrSigV4Expired:
+		return newS3Error(http.StatusForbidden, "RequestTimeTooSkewed", "The difference between the request time and the current time is too large or the request has expired.")
+	case crypt.ErrSigV4Mismatch:
+		return newS3Error(http.StatusForbidden, "SignatureDoesNotMatch", "The request signature we calculated does not match the signature you provided.")
+	case crypt.ErrSigV4PayloadMismatch:
+		return newS3Error(http.StatusBadRequest, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.")
+	case manager.ErrS3NoSuchBucket:
+		return newS3Error(http.StatusNotFound, "NoSuchBucket", "The specified bucket does not exist.")
+	case manager.ErrFileNotFound:
+		return newS3Error(http.StatusNotFound, "NoSuchKey", "The specified key does not exist.")
+	case manager.ErrS3NoSuchUpload:
+		return newS3Error(http.StatusNotFound, "NoSuchUpload", "The specified multipart upload does not exist.")
+	case manager.ErrS3InvalidPart:
+		return newS3Error(http.StatusBadRequest, "InvalidPart", "One or more of the specified parts could not be found or its entity tag did not match.")
+	case manager.ErrS3InvalidPartOrder:
+		return newS3Error(http.StatusBadRequest, "InvalidPartOrder", "The list of parts was not in ascending order.")
+	case manager.ErrS3InvalidKey, manager.ErrFileExists, manager.ErrForbiddenPathName, manager.ErrInvalidMoveTarget:
+		return newS3Error(http.StatusBadRequest, "InvalidArgument", "The key cannot be stored as path: "+err.Error())
+	case manager.ErrReadOnlyMount, manager.ErrMountPoint:
+		return newS3Error(http.StatusForbidden, "AccessDenied", "The key is in a read-only mount or is a mount point.")
+	}
+
+	switch typedErr := err.(type) {
+	case *s3Error:
+		return typedErr
+	case *fcerrors.FCError:
+		switch typedErr.Code {
+		case fcerrors.BadCredentials, fcerrors.MissingCredentials:
+			return newS3Error(http.StatusForbidden, "InvalidAccessKeyId", "The access key ID you provided does not exist in our records.")
+		case fcerrors.TooManyLoginAttempts:
+			return newS3Error(http.StatusServiceUnavailable, "SlowDown", "Too many failed requests, please reduce your request rate.")
+		}
+		if typedErr.Code.StatusCode == http.StatusForbidden || typedErr.Code.StatusCode == http.StatusUnauthorized {
+			return newS3Error(http.StatusForbidden, "AccessDenied", typedErr.Error())
+		}
+	case errors.Error:
+		if typedErr.Code() == http.StatusForbidden || typedErr.Code() == http.StatusUnauthorized {
+			return newS3Error(http.StatusForbidden, "AccessDenied", typedErr.Error())
+		}
+	}
+	log.Error(0, "S3 request failed: %v", err)
+	return newS3Error(http.StatusInternalServerError, "InternalError", "We encountered an internal error. Please try again.")
+}
+
+// writeS3Error writes the error as XML document, responses to HEAD requests only get its status
+func writeS3Error(w http.ResponseWriter, r *http.Request, err error) {
+	s3Err := *toS3Error(err)
+	s3Err.Resource = r.URL.Path
+	if r.Method == http.MethodHead {
+		w.WriteHeader(s3Err.Status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(s3Err.Status)
+	io.WriteString(w, xml.Header)
+	xml.NewEncoder(w).Encode(&s3Err)
+}
+
+// s3ETag is the MD5 of the content like S3 uses for single part uploads if it is recorded,
+// otherwise it is derived from the stored info like for WebDAV, so it changes whenever the content of the file does
+func s3ETag(fileInfo *models.FileInfo) string {
+	if fileInfo.Md5 != "" {
+		return `"` + fileInfo.Md5 + `"`
+	}
+	return fmt.Sprintf(`"%x%x"`, fileInfo.LastChanged, fileInfo.Size)
+}
+
+func s3Time(timestamp int64) string {
+	return time.Unix(timestamp, 0).UTC().Format(s3TimeFormat)
+}
+
+func s3Owner(user *models.User) *s3OwnerInfo {
+	return &s3OwnerInfo{ID: strconv.FormatInt(user.ID, 10), DisplayName: user.Email}
+}
+
+// s3URLEncode percent-encodes everything except unreserved characters and slashes for listings with the url encoding type
+func s3URLEncode(value string) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if 'A' <= b && b <= 'Z' || 'a' <= b && b <= 'z' || '0' <= b && b <= '9' || strings.IndexByte("-_.~/", b) >= 0 {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+// s3MD5Reader fails at the end of the body if it does not match the MD5 hash the client sent for it
+type s3MD5Reader struct {
+	reader      io.Reader
+	hash        hash.Hash
+	expectedMD5 []byte
+}
+
+func (r *s3MD5Reader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.expectedMD5) {
+		return n, errS3BadDigest
+	}
+	return n, err
+}
+
+type s3OwnerInfo struct {
+	ID          string
+	DisplayName string
+}
+
+type s3Bucket struct {
+	Name         string
+	CreationDate string
+}
+
+type s3ListAllMyBucketsResult struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListAllMyBucketsResult"`
+	Owner   *s3OwnerInfo
+	Buckets []s3Bucket `xml:"Buckets>Bucket"`
+}
+
+type s3LocationConstraint struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
+	Region  string   `xml:",chardata"`
+}
+
+type s3Object struct {
+	Key          string
+	LastModified string
+	ETag         string
+	Size         int64
+	StorageClass string
+}
+
+type s3CommonPrefix struct {
+	Prefix string
+}
+
+type s3ListBucketResult struct {
+	XMLName               xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string
+	Prefix                string
+	Delimiter             string  `xml:",omitempty"`
+	Marker                *string `xml:",omitempty"`
+	NextMarker            string  `xml:",omitempty"`
+	StartAfter            string  `xml:",omitempty"`
+	ContinuationToken     string  `xml:",omitempty"`
+	NextContinuationToken string  `xml:",omitempty"`
+	KeyCount              *int    `xml:",omitempty"`
+	MaxKeys               int
+	EncodingType          string `xml:",omitempty"`
+	IsTruncated           bool
+	Contents              []s3Object
+	CommonPrefixes        []s3CommonPrefix
+}
+
+// encodeURL encodes all keys and prefixes of the listing, clients ask for this as XML cannot contain all characters keys can
+func (result *s3ListBucketResult) encodeURL() {
+	result.EncodingType = "url"
+	result.Prefix = s3URLEncode(result.Prefix)
+	result.Delimiter = s3URLEncode(result.Delimiter)
+	result.StartAfter = s3URLEncode(result.StartAfter)
+	result.NextMarker = s3URLEncode(result.NextMarker)
+	if result.Marker != nil {
+		marker := s3URLEncode(*result.Marker)
+		result.Marker = &marker
+	}
+	for i := range result.Contents {
+		result.Contents[i].Key = s3URLEncode(result.Contents[i].Key)
+	}
+	for i := range result.CommonPrefixes {
+		result.CommonPrefixes[i].Prefix = s3URLEncode(result.CommonPrefixes[i].Prefix)
+	}
+}
+
+type s3DeleteRequest struct {
+	XMLName xml.Name `xml:"Delete"`
+	Quiet   bool
+	Objects []struct {
+		Key string
+	} `xml:"Object"`
+}
+
+type s3DeletedObject struct {
+	Key string
+}
+
+type s3DeleteError struct {
+	Key     string
+	Code    string
+	Message string
+}
+
+type s3DeleteResult struct {
+	XMLName xml.Name          `xml:"http://s3.amazonaws.com/doc/2006-03-01/ DeleteResult"`
+	Deleted []s3DeletedObject `xml:"Deleted"`
+	Errors  []s3DeleteError   `xml:"Error"`
+}
+
+type s3InitiateMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ InitiateMultipartUploadResult"`
+	Bucket   string
+	Key      string
+	UploadID string `xml:"UploadId"`
+}
+
+type s3CompleteMultipartUpload struct {
+	XMLName xml.Name `xml:"CompleteMultipartUpload"`
+	Parts   []struct {
+		PartNumber int
+		ETag       string
+	} `xml:"Part"`
+}
+
+type s3CompleteMultipartUploadResult struct {
+	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CompleteMultipartUploadResult"`
+	Location string
+	Bucket   string
+	Key      string
+	ETag     string
+}
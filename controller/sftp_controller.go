@@ -0,0 +1,287 @@
+package controller
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	log "gopkg.in/clog.v1"
+)
+
+const (
+	sftpUserIDExtension     = "user-id"
+	sftpAuthMethodExtension = "auth-method"
+	sftpSSHKeyIDExtension   = "ssh-key-id"
+)
+
+// SFTPServer serves the files of the users via SFTP, they login with their email and password or one of their SSH keys
+type SFTPServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+	conns    map[net.Conn]struct{}
+	lock     sync.Mutex
+	closed   bool
+}
+
+// StartSFTPServer listens on the address and serves SFTP clients in the background until the server is closed.
+// The host key is read from hostKeyPath and generated there if the file does not exist yet.
+func StartSFTPServer(address, hostKeyPath string) (*SFTPServer, error) {
+	hostKey, err := loadSFTPHostKey(hostKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	srv := &SFTPServer{conns: make(map[net.Conn]struct{})}
+	srv.config = &ssh.ServerConfig{
+		PasswordCallback:  srv.authenticatePassword,
+		PublicKeyCallback: srv.authenticatePublicKey,
+	}
+	srv.config.AddHostKey(hostKey)
+
+	srv.listener, err = net.Listen("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	log.Info("Serving SFTP at %s with host key %s", srv.listener.Addr(), ssh.FingerprintSHA256(hostKey.PublicKey()))
+	go srv.serve()
+	return srv, nil
+}
+
+// Addr returns the address the server listens on
+func (srv *SFTPServer) Addr() net.Addr {
+	return srv.listener.Addr()
+}
+
+// Close stops accepting clients and closes the connections of all connected ones
+func (srv *SFTPServer) Close() error {
+	srv.lock.Lock()
+	defer srv.lock.Unlock()
+	srv.closed = true
+	for conn := range srv.conns {
+		conn.Close()
+	}
+	return srv.listener.Close()
+}
+
+func (srv *SFTPServer) serve() {
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			srv.lock.Lock()
+			closed := srv.closed
+			srv.lock.Unlock()
+			if !closed {
+				log.Error(0, "Accepting SFTP connection failed: %v", err)
+			}
+			return
+		}
+		go srv.handleConn(conn)
+	}
+}
+
+func (srv *SFTPServer) handleConn(conn net.Conn) {
+	srv.lock.Lock()
+	if srv.closed {
+		srv.lock.Unlock()
+		conn.Close()
+		return
+	}
+	srv.conns[conn] = struct{}{}
+	srv.lock.Unlock()
+	defer func() {
+		srv.lock.Lock()
+		delete(srv.conns, conn)
+		srv.lock.Unlock()
+		conn.Close()
+	}()
+
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, srv.config)
+	if err != nil {
+		log.Trace("SSH handshake with %s failed: %v", conn.RemoteAddr(), err)
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	clientIP := getRemoteIP(conn.RemoteAddr())
+	userID, _ := strconv.ParseInt(sshConn.Permissions.Extensions[sftpUserIDExtension], 10, 64)
+	user, err := manager.GetAuthManager().GetUserByID(userID)
+	if err == nil {
+		err = manager.GetPolicyManager().ResolvePermissions(user)
+	}
+	if err != nil {
+		log.Error(0, "Could not get user %d of SFTP connection: %v", userID, err)
+		return
+	}
+	if sshKeyID, ok := sshConn.Permissions.Extensions[sftpSSHKeyIDExtension]; ok {
+		id, _ := strconv.ParseInt(sshKeyID, 10, 64)
+		manager.GetAuthManager().MarkSSHKeyUsed(id)
+	}
+	manager.GetAuditManager().Record(manager.AuditLogin, user.ID, user.ID, sshConn.Permissions.Extensions[sftpAuthMethodExtension], clientIP)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			log.Trace("Accepting SSH channel of %s failed: %v", clientIP, err)
+			continue
+		}
+		go srv.handleSession(channel, channelRequests, user, clientIP)
+	}
+}
+
+// handleSession serves SFTP once the client asks for the subsystem, shells and commands are refused
+func (srv *SFTPServer) handleSession(channel ssh.Channel, requests <-chan *ssh.Request, user *models.User, clientIP string) {
+	defer channel.Close()
+	for req := range requests {
+		// The payload of subsystem requests is the length prefixed name of the subsystem
+		isSFTP := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+		req.Reply(isSFTP, nil)
+		if !isSFTP {
+			continue
+		}
+
+		readOnly := manager.GetPolicyManager().Authorize(user, manager.PermissionFileWrite) != nil
+		handlers := manager.GetFileManager().GetSFTPHandlers(user, readOnly)
+		auditor := &sftpAuditor{handlers: handlers, user: user, clientIP: clientIP}
+		handlers.FilePut = auditor
+		handlers.FileCmd = auditor
+
+		server := sftp.NewRequestServer(channel, handlers)
+		err := server.Serve()
+		if err != nil && err != io.EOF {
+			log.Trace("SFTP session of user %d ended: %v", user.ID, err)
+		}
+		server.Close()
+		return
+	}
+}
+
+func (srv *SFTPServer) authenticatePassword(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	clientIP := getRemoteIP(conn.RemoteAddr())
+	user, err := manager.GetAuthManager().AuthenticateUser(conn.User(), string(password), clientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.BadCredentials {
+		manager.GetAuditManager().Record(manager.AuditLoginFailed, 0, 0, conn.User(), clientIP)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sftpPermissions(user, "sftp password"), nil
+}
+
+// authenticatePublicKey is also called for keys the client only offers, the SSH package verifies the signature afterwards
+func (srv *SFTPServer) authenticatePublicKey(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+	user, sshKey, err := manager.GetAuthManager().LoginSSHKey(conn.User(), key, getRemoteIP(conn.RemoteAddr()))
+	if err != nil {
+		return nil, err
+	}
+	// The client only offered the key yet, so it is marked as used after the handshake proved that the client owns it
+	permissions := sftpPermissions(user, "sftp key "+ssh.FingerprintSHA256(key))
+	permissions.Extensions[sftpSSHKeyIDExtension] = strconv.FormatInt(sshKey.ID, 10)
+	return permissions, nil
+}
+
+// sftpPermissions passes the authenticated user to the connection as SSH permissions
+func sftpPermissions(user *models.User, authMethod string) *ssh.Permissions {
+	return &ssh.Permissions{Extensions: map[string]string{
+		sftpUserIDExtension:     strconv.FormatInt(user.ID, 10),
+		sftpAuthMethodExtension: authMethod,
+	}}
+}
+
+// loadSFTPHostKey reads the private host key or generates an Ed25519 key if there is no file at the path yet
+func loadSFTPHostKey(path string) (ssh.Signer, error) {
+	keyData, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		_, privateKey, genErr := ed25519.GenerateKey(rand.Reader)
+		if genErr != nil {
+			return nil, genErr
+		}
+		block, marshalErr := ssh.MarshalPrivateKey(privateKey, "freecloud sftp host key")
+		if marshalErr != nil {
+			return nil, marshalErr
+		}
+		keyData = pem.EncodeToMemory(block)
+		err = ioutil.WriteFile(path, keyData, 0600)
+		if err == nil {
+			log.Info("Generated SFTP host key at %s", path)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read host key %s: %v", path, err)
+	}
+	return ssh.ParsePrivateKey(keyData)
+}
+
+func getRemoteIP(addr net.Addr) string {
+	host, _, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return addr.String()
+	}
+	return host
+}
+
+// sftpAuditor records changes of files done via SFTP for their owners like the API does
+type sftpAuditor struct {
+	handlers sftp.Handlers
+	user     *models.User
+	clientIP string
+}
+
+// Filewrite records opening files for writing, as SFTP has no request for the end of an upload besides closing the file
+func (a *sftpAuditor) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	writer, err := a.handlers.FilePut.Filewrite(r)
+	if err == nil {
+		a.record(manager.AuditFileCreate, getFileOwnerID(a.user, r.Filepath), r.Filepath)
+	}
+	return writer, err
+}
+
+// Filecmd resolves the owner of removed and renamed files before they are changed
+func (a *sftpAuditor) Filecmd(r *sftp.Request) error {
+	var ownerID int64
+	if r.Method == "Rmdir" || r.Method == "Remove" || r.Method == "Rename" {
+		ownerID = getFileOwnerID(a.user, r.Filepath)
+	}
+	err := a.handlers.FileCmd.Filecmd(r)
+	if err != nil {
+		return err
+	}
+	switch r.Method {
+	case "Mkdir":
+		a.record(manager.AuditFileCreate, getFileOwnerID(a.user, r.Filepath), r.Filepath)
+	case "Rmdir", "Remove":
+		a.record(manager.AuditFileDelete, ownerID, r.Filepath)
+	case "Rename":
+		a.record(manager.AuditFileMove, ownerID, r.Filepath+" -> "+r.Target)
+	}
+	return nil
+}
+
+func (a *sftpAuditor) PosixRename(r *sftp.Request) error {
+	ownerID := getFileOwnerID(a.user, r.Filepath)
+	err := a.handlers.FileCmd.(sftp.PosixRenameFileCmder).PosixRename(r)
+	if err == nil {
+		a.record(manager.AuditFileMove, ownerID, r.Filepath+" -> "+r.Target)
+	}
+	return err
+}
+
+func (a *sftpAuditor) record(action string, ownerID int64, target string) {
+	manager.GetAuditManager().Record(action, a.user.ID, ownerID, target, a.clientIP)
+}
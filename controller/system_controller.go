@@ -1,15 +1,23 @@
 package controller
 
 import (
+	"fmt"
+
 	"github.com/freecloudio/server/restapi/fcerrors"
 
 	"github.com/go-openapi/runtime/middleware"
 
 	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
 	systemAPI "github.com/freecloudio/server/restapi/operations/system"
 )
 
-func SystemStatsHandler() middleware.Responder {
+func SystemStatsHandler(params systemAPI.GetSystemStatsParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionSystemRead)
+	if err != nil {
+		return systemAPI.NewGetSystemStatsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
 	stats, err := manager.GetSystemManager().GetSystemStats()
 	if err != nil {
 		return systemAPI.NewGetSystemStatsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
@@ -17,3 +25,134 @@ func SystemStatsHandler() middleware.Responder {
 
 	return systemAPI.NewGetSystemStatsOK().WithPayload(stats)
 }
+
+func SystemGetMountsHandler(params systemAPI.GetMountsParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionMountManage)
+	if err != nil {
+		return systemAPI.NewGetMountsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	mounts, err := manager.GetMountManager().GetMounts()
+	if err != nil {
+		return systemAPI.NewGetMountsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return systemAPI.NewGetMountsOK().WithPayload(&models.ExternalMountList{Mounts: mounts})
+}
+
+func SystemCreateMountHandler(params systemAPI.CreateMountParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionMountManage)
+	if err != nil {
+		return systemAPI.NewCreateMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	mount, err := manager.GetMountManager().CreateMount(params.Mount)
+	if err != nil {
+		return systemAPI.NewCreateMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditMountCreate, mount.UserID, fmt.Sprintf("mount %d of %s at %s", mount.ID, mount.Type, mount.Path))
+
+	return systemAPI.NewCreateMountOK().WithPayload(mount)
+}
+
+func SystemUpdateMountHandler(params systemAPI.UpdateMountParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionMountManage)
+	if err != nil {
+		return systemAPI.NewUpdateMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	mount, err := manager.GetMountManager().UpdateMount(params.ID, params.Mount)
+	if err != nil {
+		return systemAPI.NewUpdateMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditMountUpdate, mount.UserID, fmt.Sprintf("mount %d of %s at %s", mount.ID, mount.Type, mount.Path))
+
+	return systemAPI.NewUpdateMountOK().WithPayload(mount)
+}
+
+func SystemDeleteMountHandler(params systemAPI.DeleteMountParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionMountManage)
+	if err != nil {
+		return systemAPI.NewDeleteMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	err = manager.GetMountManager().DeleteMount(params.ID)
+	if err != nil {
+		return systemAPI.NewDeleteMountDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditMountDelete, 0, fmt.Sprintf("mount %d", params.ID))
+
+	return systemAPI.NewDeleteMountOK()
+}
+
+func SystemEncryptStorageHandler(params systemAPI.EncryptStorageParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionStorageManage)
+	if err != nil {
+		return systemAPI.NewEncryptStorageDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	encryptionMgr := manager.GetEncryptionManager()
+	if encryptionMgr == nil {
+		err = fcerrors.New(fcerrors.EncryptionDisabled)
+		return systemAPI.NewEncryptStorageDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	count, err := encryptionMgr.EncryptExistingFiles()
+	if err != nil {
+		return systemAPI.NewEncryptStorageDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditStorageEncrypt, 0, fmt.Sprintf("%d files", count))
+
+	return systemAPI.NewEncryptStorageOK().WithPayload(&models.EncryptionResult{EncryptedFiles: count})
+}
+
+// SystemCollectBlobsHandler deletes the blobs of deduplicated content which are not referenced by any file anymore
+func SystemCollectBlobsHandler(params systemAPI.CollectBlobsParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionStorageManage)
+	if err != nil {
+		return systemAPI.NewCollectBlobsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	deduplicationMgr := manager.GetDeduplicationManager()
+	if deduplicationMgr == nil {
+		err = fcerrors.New(fcerrors.DeduplicationDisabled)
+		return systemAPI.NewCollectBlobsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	count, size, err := deduplicationMgr.CollectGarbage()
+	if err != nil {
+		return systemAPI.NewCollectBlobsDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditStorageCollect, 0, fmt.Sprintf("%d blobs, %d bytes", count, size))
+
+	return systemAPI.NewCollectBlobsOK().WithPayload(&models.BlobCollectionResult{DeletedBlobs: count, FreedBytes: size})
+}
+
+// SystemGetScrubReportHandler returns the report of the latest integrity scrub of the stored files
+func SystemGetScrubReportHandler(params systemAPI.GetScrubReportParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionSystemRead)
+	if err != nil {
+		return systemAPI.NewGetScrubReportDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	report, err := manager.GetScrubManager().GetLatestReport()
+	if err != nil {
+		return systemAPI.NewGetScrubReportDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	return systemAPI.NewGetScrubReportOK().WithPayload(report)
+}
+
+// SystemStartScrubHandler starts an integrity scrub of the stored files in the background
+func SystemStartScrubHandler(params systemAPI.StartScrubParams, principal *models.Principal) middleware.Responder {
+	err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionStorageManage)
+	if err != nil {
+		return systemAPI.NewStartScrubDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+
+	report, err := manager.GetScrubManager().StartScrub()
+	if err != nil {
+		return systemAPI.NewStartScrubDefault(fcerrors.GetStatusCode(err)).WithPayload(fcerrors.GetAPIError(err))
+	}
+	recordAudit(params.HTTPRequest, principal, manager.AuditStorageScrub, 0, fmt.Sprintf("report %d", report.ID))
+
+	return systemAPI.NewStartScrubAccepted().WithPayload(report)
+}
@@ -0,0 +1,226 @@
+package controller
+
+import (
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/freecloudio/server/manager"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/go-openapi/errors"
+	"golang.org/x/net/webdav"
+	log "gopkg.in/clog.v1"
+)
+
+// WebDAVPrefix is the path the files of the users are served under via WebDAV
+const WebDAVPrefix = "/webdav"
+
+const webdavRealm = `Basic realm="freecloud", charset="UTF-8"`
+
+var (
+	webdavLockSystems     = make(map[int64]webdav.LockSystem)
+	webdavLockSystemsLock sync.Mutex
+)
+
+// WebDAVMiddleware serves requests below the WebDAV prefix with the files of the authenticated user and passes all others on.
+// Clients authenticate with HTTP Basic using their email and an app password or with a bearer token of a session.
+func WebDAVMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != WebDAVPrefix && !strings.HasPrefix(r.URL.Path, WebDAVPrefix+"/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		principal, err := authenticateWebDAVRequest(r)
+		if err != nil {
+			writeWebDAVError(w, r, err)
+			return
+		}
+		err = authorizeWebDAVRequest(r, principal)
+		if err != nil {
+			writeWebDAVError(w, r, err)
+			return
+		}
+
+		handler := &webdav.Handler{
+			Prefix:     WebDAVPrefix,
+			FileSystem: manager.GetFileManager().GetWebDAVFileSystem(principal.User),
+			LockSystem: getWebDAVLockSystem(principal.User.ID),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					log.Trace("WebDAV %s to %s of user %d failed: %v", r.Method, r.URL.Path, principal.User.ID, err)
+				}
+			},
+		}
+		switch r.Method {
+		case http.MethodPut:
+			checksums, err := parseWebDAVChecksums(r)
+			if err != nil {
+				writeWebDAVError(w, r, err)
+				return
+			}
+			r = r.WithContext(manager.WithExpectedChecksums(r.Context(), checksums))
+		case http.MethodGet, http.MethodHead:
+			// Clients verify downloads with the checksums recorded for the content
+			if fileInfo, err := manager.GetFileManager().GetFileInfo(principal.User, strings.TrimPrefix(r.URL.Path, WebDAVPrefix), false); err == nil {
+				if digest := manager.FileDigest(fileInfo); digest != "" {
+					w.Header().Set("Digest", digest)
+				}
+			}
+		}
+
+		// Files which are deleted or moved away are resolved before, so their owner can be audited
+		var ownerID int64
+		if r.Method == http.MethodDelete || r.Method == "MOVE" {
+			ownerID = getFileOwnerID(principal.User, strings.TrimPrefix(r.URL.Path, WebDAVPrefix))
+		}
+
+		srw := NewStatusRecordingResponseWriter(w)
+		handler.ServeHTTP(srw, r)
+		if srw.Status() < http.StatusMultipleChoices {
+			recordWebDAVAudit(r, principal, ownerID)
+		}
+	})
+}
+
+// authenticateWebDAVRequest returns the principal for the bearer token or the email and app password of the request
+func authenticateWebDAVRequest(r *http.Request) (*models.Principal, error) {
+	if authorization := r.Header.Get("Authorization"); strings.HasPrefix(authorization, "Bearer ") {
+		return ValidateToken(strings.TrimPrefix(authorization, "Bearer "), []string{"user"})
+	}
+
+	email, password, ok := r.BasicAuth()
+	if !ok {
+		return nil, errors.New(http.StatusUnauthorized, "Missing credentials")
+	}
+	clientIP := getClientIP(r)
+	user, err := manager.GetAuthManager().LoginAppPassword(email, password, clientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.MissingCredentials {
+		return nil, errors.New(http.StatusUnauthorized, "Missing credentials")
+	} else if ok && fcErr.Code == fcerrors.BadCredentials {
+		manager.GetAuditManager().Record(manager.AuditLoginFailed, 0, 0, email, clientIP)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = manager.GetPolicyManager().ResolvePermissions(user)
+	if err != nil {
+		return nil, err
+	}
+	return &models.Principal{User: user}, nil
+}
+
+// authorizeWebDAVRequest checks that the user may change files for all methods except reading ones.
+// Listing whole trees at once is refused like RFC 4918 allows, so clients have to walk them folder by folder.
+// A missing depth means infinity for PROPFIND, so it has to be given explicitly.
+func authorizeWebDAVRequest(r *http.Request, principal *models.Principal) error {
+	if depth := r.Header.Get("Depth"); r.Method == "PROPFIND" && (depth == "" || strings.EqualFold(depth, "infinity")) {
+		return errors.New(http.StatusForbidden, "Depth infinity is not supported")
+	}
+	if !isReadOnlyRequest(r) {
+		err := manager.GetPolicyManager().Authorize(principal.User, manager.PermissionFileWrite)
+		if err != nil {
+			return err
+		}
+	}
+	return AuthorizeRequest(r, principal)
+}
+
+// getWebDAVLockSystem returns the locks of the user, they are kept in memory and get lost on restarts
+func getWebDAVLockSystem(userID int64) webdav.LockSystem {
+	webdavLockSystemsLock.Lock()
+	defer webdavLockSystemsLock.Unlock()
+	lockSystem, ok := webdavLockSystems[userID]
+	if !ok {
+		lockSystem = webdav.NewMemLS()
+		webdavLockSystems[userID] = lockSystem
+	}
+	return lockSystem
+}
+
+// recordWebDAVAudit records successful changes of files for their owners like the API does.
+// The owner of deleted and moved files has to be resolved before they are changed, the owner of created ones is resolved here.
+func recordWebDAVAudit(r *http.Request, principal *models.Principal, ownerID int64) {
+	path := strings.TrimPrefix(r.URL.Path, WebDAVPrefix)
+	switch r.Method {
+	case http.MethodPut, "MKCOL":
+		recordAudit(r, principal, manager.AuditFileCreate, getFileOwnerID(principal.User, path), path)
+	case http.MethodDelete:
+		recordAudit(r, principal, manager.AuditFileDelete, ownerID, path)
+	case "COPY", "MOVE":
+		destination := r.Header.Get("Destination")
+		if destinationURL, err := url.Parse(destination); err == nil {
+			destination = strings.TrimPrefix(destinationURL.Path, WebDAVPrefix)
+		}
+		action := manager.AuditFileMove
+		if r.Method == "COPY" {
+			action = manager.AuditFileCreate
+			ownerID = getFileOwnerID(principal.User, destination)
+		}
+		recordAudit(r, principal, action, ownerID, path+" -> "+destination)
+	}
+}
+
+// parseWebDAVChecksums returns the checksums a client expects for uploaded content from the Digest header of RFC 3230 and the Content-MD5 header.
+// Unsupported digest algorithms are ignored, as clients may offer several of them.
+func parseWebDAVChecksums(r *http.Request) (checksums manager.Checksums, err error) {
+	values := []string{}
+	for _, digest := range strings.Split(r.Header.Get("Digest"), ",") {
+		if digest = strings.TrimSpace(digest); digest != "" {
+			values = append(values, digest)
+		}
+	}
+	if contentMD5 := r.Header.Get("Content-MD5"); contentMD5 != "" {
+		values = append(values, "MD5="+contentMD5)
+	}
+
+	for _, value := range values {
+		parts := strings.SplitN(value, "=", 2)
+		if len(parts) != 2 {
+			return checksums, fcerrors.New(fcerrors.InvalidChecksum)
+		}
+		var checksum *string
+		var size int
+		switch strings.ToUpper(parts[0]) {
+		case "SHA-256":
+			checksum, size = &checksums.SHA256, sha256.Size
+		case "MD5":
+			checksum, size = &checksums.MD5, md5.Size
+		default:
+			continue
+		}
+		sum, decodeErr := base64.StdEncoding.DecodeString(strings.TrimSpace(parts[1]))
+		if decodeErr != nil || len(sum) != size {
+			return checksums, fcerrors.New(fcerrors.InvalidChecksum)
+		}
+		*checksum = hex.EncodeToString(sum)
+	}
+	return
+}
+
+// writeWebDAVError writes the status of the error, clients are asked for their credentials if they are missing or wrong
+func writeWebDAVError(w http.ResponseWriter, r *http.Request, err error) {
+	status := http.StatusInternalServerError
+	if fcErr, ok := err.(*fcerrors.FCError); ok {
+		status = fcErr.Code.StatusCode
+	} else if apiErr, ok := err.(errors.Error); ok {
+		status = int(apiErr.Code())
+	}
+
+	switch status {
+	case http.StatusUnauthorized:
+		w.Header().Set("WWW-Authenticate", webdavRealm)
+	case http.StatusTooManyRequests:
+		email, _, _ := r.BasicAuth()
+		w.Header().Set("Retry-After", strconv.FormatInt(manager.GetAuthManager().GetLoginRetryAfter(email, getClientIP(r)), 10))
+	}
+	http.Error(w, err.Error(), status)
+}
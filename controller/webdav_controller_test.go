@@ -0,0 +1,33 @@
+package controller
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/go-openapi/errors"
+)
+
+func TestAuthorizeWebDAVPropfind(t *testing.T) {
+	principal := &models.Principal{User: &models.User{ID: 1}}
+
+	for _, depth := range []string{"", "infinity", "Infinity"} {
+		r := httptest.NewRequest("PROPFIND", WebDAVPrefix+"/docs", nil)
+		if depth != "" {
+			r.Header.Set("Depth", depth)
+		}
+		err := authorizeWebDAVRequest(r, principal)
+		if apiErr, ok := err.(errors.Error); !ok || apiErr.Code() != http.StatusForbidden {
+			t.Errorf("Expected PROPFIND with Depth %q to be forbidden but got: %v", depth, err)
+		}
+	}
+
+	for _, depth := range []string{"0", "1"} {
+		r := httptest.NewRequest("PROPFIND", WebDAVPrefix+"/docs", nil)
+		r.Header.Set("Depth", depth)
+		if err := authorizeWebDAVRequest(r, principal); err != nil {
+			t.Errorf("Expected PROPFIND with Depth %q to be allowed but got: %v", depth, err)
+		}
+	}
+}
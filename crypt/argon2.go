@@ -0,0 +1,100 @@
+package crypt
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/argon2"
+
+	"github.com/freecloudio/server/utils"
+)
+
+const (
+	recommendedArgon2Time    = 1
+	recommendedArgon2Memory  = 64 * 1024 // KiB
+	recommendedArgon2Threads = 4
+
+	argon2HashLength = 32
+
+	Argon2idHashID = "argon2id"
+)
+
+var ErrInvalidArgon2Stub = errors.New("auth: invalid argon2 stub")
+
+func init() {
+	RegisterHasher(Argon2idHashID, argon2idHasher{})
+}
+
+// argon2idHasher stores hashes in the PHC string format: $argon2id$v=19$m=<memory>,t=<time>,p=<threads>$<salt>$<hash>
+type argon2idHasher struct{}
+
+func (argon2idHasher) Hash(plaintext string) (hash string, err error) {
+	saltb := []byte(utils.RandomString(saltLength))
+	hashb := argon2.IDKey([]byte(plaintext), saltb, recommendedArgon2Time, recommendedArgon2Memory, recommendedArgon2Threads, argon2HashLength)
+
+	hashs := base64.RawStdEncoding.EncodeToString(hashb)
+	salts := base64.RawStdEncoding.EncodeToString(saltb)
+
+	return fmt.Sprintf("$%s$v=%d$m=%d,t=%d,p=%d$%s$%s", Argon2idHashID, argon2.Version, recommendedArgon2Memory, recommendedArgon2Time, recommendedArgon2Threads, salts, hashs), nil
+}
+
+func (argon2idHasher) Validate(plaintext, hashed string) (valid bool, err error) {
+	salt, oldHash, _, memory, time, threads, err := parseArgon2Stub(hashed)
+	if err != nil {
+		err = errors.Wrap(err, "could not get the password stub")
+		return
+	}
+
+	hash := argon2.IDKey([]byte(plaintext), salt, time, memory, threads, uint32(len(oldHash)))
+	valid = subtle.ConstantTimeCompare(hash, oldHash) == 1
+	return
+}
+
+func (argon2idHasher) NeedsRehash(hashed string) bool {
+	_, hash, version, memory, time, threads, err := parseArgon2Stub(hashed)
+	if err != nil {
+		return true
+	}
+	return version != argon2.Version || memory != recommendedArgon2Memory || time != recommendedArgon2Time ||
+		threads != recommendedArgon2Threads || len(hash) != argon2HashLength
+}
+
+func parseArgon2Stub(password string) (salt, hash []byte, version int, memory, time uint32, threads uint8, err error) {
+	// strip the leading $, then split into id, version, parameters, salt and hash
+	parts := strings.Split(password, "$")
+	if len(parts) != 6 || parts[0] != "" || parts[1] != Argon2idHashID {
+		err = ErrInvalidArgon2Stub
+		return
+	}
+
+	_, err = fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil {
+		err = errors.Wrap(err, "could not parse argon2 version")
+		return
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads)
+	if err != nil {
+		err = errors.Wrap(err, "could not parse argon2 parameters")
+		return
+	}
+
+	salt, err = base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		err = errors.Wrap(err, "could not parse argon2 salt")
+		return
+	}
+
+	hash, err = base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		err = errors.Wrap(err, "could not parse argon2 hash")
+		return
+	}
+	if len(hash) == 0 {
+		err = ErrInvalidArgon2Stub
+	}
+	return
+}
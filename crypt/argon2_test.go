@@ -0,0 +1,46 @@
+package crypt
+
+import (
+	"testing"
+)
+
+func TestParseArgon2Stub(t *testing.T) {
+	type data struct {
+		Name     string
+		Password string
+		Error    error
+	}
+
+	var testdata = []data{
+		{"empty password", "", ErrInvalidArgon2Stub},
+		{"only preemble", "$argon2id$", ErrInvalidArgon2Stub},
+		{"wrong preemble", "$argon2i$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZA", ErrInvalidArgon2Stub},
+		{"missing parts", "$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0", ErrInvalidArgon2Stub},
+		{"empty hash", "$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$", ErrInvalidArgon2Stub},
+		{"valid password", "$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZA", nil},
+	}
+
+	for _, td := range testdata {
+		_, _, _, _, _, _, err := parseArgon2Stub(td.Password)
+		if err != td.Error {
+			t.Errorf("Expected %v for %s, got: %v", td.Error, td.Name, err)
+		}
+	}
+}
+
+func TestArgon2NeedsRehash(t *testing.T) {
+	var l = map[string]bool{
+		"$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZFZhbGlkUGFzc3dvcmQxMjM0NTY": false,
+		"$argon2id$v=19$m=32768,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZFZhbGlkUGFzc3dvcmQxMjM0NTY": true,
+		"$argon2id$v=19$m=65536,t=3,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZFZhbGlkUGFzc3dvcmQxMjM0NTY": true,
+		"$argon2id$v=16$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZFZhbGlkUGFzc3dvcmQxMjM0NTY": true,
+		"$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZA":                          true,
+		"$argon2id$invalid": true,
+	}
+
+	for input, expOutput := range l {
+		if output := (argon2idHasher{}).NeedsRehash(input); output != expOutput {
+			t.Errorf("Expected needs rehash '%v' for '%s' but got: '%v'", expOutput, input, output)
+		}
+	}
+}
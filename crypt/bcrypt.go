@@ -0,0 +1,32 @@
+package crypt
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+func init() {
+	// bcrypt is only supported for validating passwords of imported users
+	for _, hashID := range []string{"2a", "2b", "2y"} {
+		RegisterHasher(hashID, bcryptHasher{})
+	}
+}
+
+type bcryptHasher struct{}
+
+func (bcryptHasher) Hash(plaintext string) (hash string, err error) {
+	return "", ErrHashingUnsupported
+}
+
+func (bcryptHasher) Validate(plaintext, hashed string) (valid bool, err error) {
+	err = bcrypt.CompareHashAndPassword([]byte(hashed), []byte(plaintext))
+	if err == bcrypt.ErrMismatchedHashAndPassword {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (bcryptHasher) NeedsRehash(hashed string) bool {
+	return true
+}
@@ -0,0 +1,177 @@
+package crypt
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// EncryptionKeySize is the size in bytes of master, user and data keys
+	EncryptionKeySize = 32
+	// EncryptionChunkSize is the size of the plaintext chunks which are encrypted and authenticated separately
+	EncryptionChunkSize = 64 * 1024
+	// EncryptionHeaderSize is the size of the header in front of the encrypted chunks
+	EncryptionHeaderSize = encryptionMagicSize + wrappedKeySize
+	// EncryptionTagSize is the size every chunk grows by when it is encrypted
+	EncryptionTagSize = 16
+
+	encryptionMagicSize = 4
+	encryptionNonceSize = 12
+	wrappedKeySize      = encryptionNonceSize + EncryptionKeySize + EncryptionTagSize
+	encryptedChunkSize  = EncryptionChunkSize + EncryptionTagSize
+)
+
+// encryptionMagic starts every encrypted file, so files stored before encryption was enabled can be told apart
+var encryptionMagic = []byte("FCE1")
+
+var (
+	ErrDecryptionFailed = errors.New("crypt: data or key is corrupt")
+	ErrInvalidKey       = errors.New("crypt: key has the wrong size")
+)
+
+// GenerateKey returns a new random key of EncryptionKeySize
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, EncryptionKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, errors.Wrap(err, "could not generate key")
+	}
+	return key, nil
+}
+
+// WrapKey encrypts the key with the key encryption key, e.g. a user key with the master key
+func WrapKey(kek, key []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, encryptionNonceSize, wrappedKeySize)
+	if _, err = rand.Read(nonce); err != nil {
+		return nil, errors.Wrap(err, "could not generate nonce")
+	}
+	return aead.Seal(nonce, nonce, key, nil), nil
+}
+
+// UnwrapKey decrypts a key wrapped by WrapKey, ErrDecryptionFailed is returned if the key encryption key is wrong
+func UnwrapKey(kek, wrapped []byte) ([]byte, error) {
+	aead, err := newAEAD(kek)
+	if err != nil {
+		return nil, err
+	}
+	if len(wrapped) != wrappedKeySize {
+		return nil, ErrDecryptionFailed
+	}
+	key, err := aead.Open(nil, wrapped[:encryptionNonceSize], wrapped[encryptionNonceSize:], nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return key, nil
+}
+
+// IsEncrypted returns whether the content starting with header has been encrypted
+func IsEncrypted(header []byte) bool {
+	return bytes.HasPrefix(header, encryptionMagic)
+}
+
+// NewEncryptionHeader generates a data key for a new file and returns it with the header containing it wrapped by the user key
+func NewEncryptionHeader(userKey []byte) (header, dataKey []byte, err error) {
+	dataKey, err = GenerateKey()
+	if err != nil {
+		return
+	}
+	wrapped, err := WrapKey(userKey, dataKey)
+	if err != nil {
+		return
+	}
+	header = append(append(make([]byte, 0, EncryptionHeaderSize), encryptionMagic...), wrapped...)
+	return
+}
+
+// ParseEncryptionHeader returns the data key of a file from its header
+func ParseEncryptionHeader(userKey, header []byte) ([]byte, error) {
+	if len(header) < EncryptionHeaderSize || !IsEncrypted(header) {
+		return nil, ErrDecryptionFailed
+	}
+	return UnwrapKey(userKey, header[encryptionMagicSize:EncryptionHeaderSize])
+}
+
+// EncryptedSize returns the size of the encrypted content including the header for the size of the plaintext
+func EncryptedSize(plaintextSize int64) int64 {
+	chunks := plaintextSize / EncryptionChunkSize
+	if plaintextSize%EncryptionChunkSize != 0 || plaintextSize == 0 {
+		chunks++
+	}
+	return int64(EncryptionHeaderSize) + plaintextSize + chunks*EncryptionTagSize
+}
+
+// PlaintextSize returns the size of the plaintext for the size of the encrypted content including the header
+func PlaintextSize(encryptedSize int64) (int64, error) {
+	size := encryptedSize - int64(EncryptionHeaderSize)
+	chunks, rest := size/encryptedChunkSize, size%encryptedChunkSize
+	if size < EncryptionTagSize || rest > 0 && rest < EncryptionTagSize {
+		return 0, ErrDecryptionFailed
+	}
+	if rest == 0 {
+		return chunks * EncryptionChunkSize, nil
+	}
+	return chunks*EncryptionChunkSize + rest - EncryptionTagSize, nil
+}
+
+// EncryptedChunkOffset returns the offset of the chunk with the index in the encrypted content including the header
+func EncryptedChunkOffset(index int64) int64 {
+	return int64(EncryptionHeaderSize) + index*encryptedChunkSize
+}
+
+// ChunkCipher encrypts and decrypts the chunks of one file with its data key.
+// The nonce of a chunk is derived from its index and whether it is the final one, so chunks cannot be reordered or cut off.
+// As every file gets a new data key, chunks must never be encrypted twice with different content.
+type ChunkCipher struct {
+	aead cipher.AEAD
+}
+
+// NewChunkCipher creates a ChunkCipher for the data key
+func NewChunkCipher(dataKey []byte) (*ChunkCipher, error) {
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	return &ChunkCipher{aead: aead}, nil
+}
+
+// Seal appends the encrypted chunk to dst
+func (c *ChunkCipher) Seal(dst, plaintext []byte, index int64, final bool) []byte {
+	return c.aead.Seal(dst, chunkNonce(index, final), plaintext, nil)
+}
+
+// Open appends the decrypted chunk to dst, ErrDecryptionFailed is returned if it has been modified
+func (c *ChunkCipher) Open(dst, ciphertext []byte, index int64, final bool) ([]byte, error) {
+	plaintext, err := c.aead.Open(dst, chunkNonce(index, final), ciphertext, nil)
+	if err != nil {
+		return nil, ErrDecryptionFailed
+	}
+	return plaintext, nil
+}
+
+func chunkNonce(index int64, final bool) []byte {
+	nonce := make([]byte, encryptionNonceSize)
+	binary.BigEndian.PutUint64(nonce, uint64(index))
+	if final {
+		nonce[encryptionNonceSize-1] = 1
+	}
+	return nonce
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, ErrInvalidKey
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
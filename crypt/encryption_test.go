@@ -0,0 +1,86 @@
+package crypt
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWrapKey(t *testing.T) {
+	kek, _ := GenerateKey()
+	key, _ := GenerateKey()
+
+	wrapped, err := WrapKey(kek, key)
+	if err != nil {
+		t.Fatalf("Failed to wrap key: %v", err)
+	}
+	unwrapped, err := UnwrapKey(kek, wrapped)
+	if err != nil || !bytes.Equal(unwrapped, key) {
+		t.Errorf("Expected the wrapped key to be unwrapped but got: %x, %v", unwrapped, err)
+	}
+
+	otherKek, _ := GenerateKey()
+	if _, err = UnwrapKey(otherKek, wrapped); err != ErrDecryptionFailed {
+		t.Errorf("Expected decryption failure for wrong key encryption key but got: %v", err)
+	}
+	if _, err = WrapKey([]byte("short"), key); err != ErrInvalidKey {
+		t.Errorf("Expected invalid key error for short key encryption key but got: %v", err)
+	}
+}
+
+func TestEncryptionHeader(t *testing.T) {
+	userKey, _ := GenerateKey()
+	header, dataKey, err := NewEncryptionHeader(userKey)
+	if err != nil || len(header) != EncryptionHeaderSize || !IsEncrypted(header) {
+		t.Fatalf("Failed to create encryption header: %x, %v", header, err)
+	}
+	parsedKey, err := ParseEncryptionHeader(userKey, header)
+	if err != nil || !bytes.Equal(parsedKey, dataKey) {
+		t.Errorf("Expected the data key to be parsed from the header but got: %x, %v", parsedKey, err)
+	}
+	if IsEncrypted([]byte("plain text")) {
+		t.Errorf("Expected plaintext not to be detected as encrypted")
+	}
+}
+
+func TestEncryptedSize(t *testing.T) {
+	for _, size := range []int64{0, 1, EncryptionChunkSize - 1, EncryptionChunkSize, EncryptionChunkSize + 1, 3 * EncryptionChunkSize} {
+		plaintextSize, err := PlaintextSize(EncryptedSize(size))
+		if err != nil || plaintextSize != size {
+			t.Errorf("Expected plaintext size %d from encrypted size but got: %d, %v", size, plaintextSize, err)
+		}
+	}
+	if _, err := PlaintextSize(int64(EncryptionHeaderSize) + 5); err != ErrDecryptionFailed {
+		t.Errorf("Expected decryption failure for truncated content but got: %v", err)
+	}
+}
+
+func TestChunkCipher(t *testing.T) {
+	dataKey, _ := GenerateKey()
+	chunkCipher, err := NewChunkCipher(dataKey)
+	if err != nil {
+		t.Fatalf("Failed to create chunk cipher: %v", err)
+	}
+
+	sealed := chunkCipher.Seal(nil, []byte("chunk"), 3, true)
+	opened, err := chunkCipher.Open(nil, sealed, 3, true)
+	if err != nil || string(opened) != "chunk" {
+		t.Errorf("Expected the sealed chunk to be opened but got: %s, %v", opened, err)
+	}
+
+	invalids := map[string]struct {
+		index int64
+		final bool
+	}{
+		"other index": {2, true},
+		"not final":   {3, false},
+	}
+	for name, invalid := range invalids {
+		if _, err = chunkCipher.Open(nil, sealed, invalid.index, invalid.final); err != ErrDecryptionFailed {
+			t.Errorf("Expected decryption failure for chunk with %s but got: %v", name, err)
+		}
+	}
+	sealed[0] ^= 1
+	if _, err = chunkCipher.Open(nil, sealed, 3, true); err != ErrDecryptionFailed {
+		t.Errorf("Expected decryption failure for modified chunk but got: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+package crypt
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Hasher hashes and validates passwords of one algorithm
+type Hasher interface {
+	// Hash returns the hash of the plaintext including a stub with the hash ID and all parameters
+	Hash(plaintext string) (hash string, err error)
+	// Validate checks whether plaintext matches the given hash
+	Validate(plaintext, hashed string) (valid bool, err error)
+	// NeedsRehash returns whether the given hash was created with outdated parameters
+	NeedsRehash(hashed string) bool
+}
+
+var (
+	ErrUnknownHashID      = errors.New("auth: unknown password hash id")
+	ErrHashingUnsupported = errors.New("auth: hasher only supports validating passwords")
+)
+
+var (
+	hashers       = map[string]Hasher{}
+	defaultHashID = Argon2idHashID
+)
+
+// RegisterHasher makes a hasher available for hashes starting with $<hashID>$
+func RegisterHasher(hashID string, hasher Hasher) {
+	hashers[hashID] = hasher
+}
+
+// SetDefaultHasher sets the hasher used for new passwords, existing passwords of other hashers get rehashed on login
+func SetDefaultHasher(hashID string) error {
+	if _, ok := hashers[hashID]; !ok {
+		return errors.Wrapf(ErrUnknownHashID, "could not set default hasher %s", hashID)
+	}
+	defaultHashID = hashID
+	return nil
+}
+
+// HashPassword hashes the plaintext with the default hasher
+func HashPassword(plaintext string) (hash string, err error) {
+	return hashers[defaultHashID].Hash(plaintext)
+}
+
+// ValidatePassword validates the plaintext with the hasher the hash was created with.
+// needsRehash reports whether a valid password should be hashed again with the current default hasher and parameters.
+func ValidatePassword(plaintext, hashed string) (valid, needsRehash bool, err error) {
+	hashID := getHashID(hashed)
+	hasher, ok := hashers[hashID]
+	if !ok {
+		err = ErrUnknownHashID
+		return
+	}
+
+	valid, err = hasher.Validate(plaintext, hashed)
+	if err != nil || !valid {
+		return
+	}
+
+	needsRehash = hashID != defaultHashID || hasher.NeedsRehash(hashed)
+	return
+}
+
+// getHashID returns the hash ID of a hash in the form of $<hashID>$...
+func getHashID(hashed string) string {
+	if !strings.HasPrefix(hashed, "$") {
+		return ""
+	}
+	parts := strings.SplitN(hashed[1:], "$", 2)
+	if len(parts) < 2 {
+		return ""
+	}
+	return parts[0]
+}
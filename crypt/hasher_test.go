@@ -0,0 +1,89 @@
+package crypt
+
+import (
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGetHashID(t *testing.T) {
+	var l = map[string]string{
+		"$s1$16384$8$1$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZA0K":                "s1",
+		"$argon2id$v=19$m=65536,t=1,p=4$VmFsaWRTYWx0$VmFsaWRQYXNzd29yZA": "argon2id",
+		"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy":   "2a",
+		"plaintext": "",
+		"$s1":       "",
+		"":          "",
+	}
+
+	for input, expOutput := range l {
+		if output := getHashID(input); output != expOutput {
+			t.Errorf("Expected hash ID '%s' for '%s' but got: '%s'", expOutput, input, output)
+		}
+	}
+}
+
+func TestSetDefaultHasher(t *testing.T) {
+	defer SetDefaultHasher(Argon2idHashID)
+
+	err := SetDefaultHasher("unknown")
+	if err == nil {
+		t.Error("Setting an unknown default hasher succeeded")
+	}
+
+	err = SetDefaultHasher(ScryptHashID)
+	if err != nil {
+		t.Errorf("Failed to set scrypt as default hasher: %v", err)
+	}
+	hash, err := HashPassword("testpassword")
+	if err != nil {
+		t.Errorf("Got error while hashing password: %v", err)
+	}
+	if getHashID(hash) != ScryptHashID {
+		t.Errorf("Password not hashed with default hasher: %s", hash)
+	}
+}
+
+func TestValidatePassword(t *testing.T) {
+	argon2Hash, err := HashPassword("h4x0r!")
+	if err != nil {
+		t.Fatalf("Got error while hashing password with argon2: %v", err)
+	}
+	scryptHash, err := HashScrypt("h4x0r!")
+	if err != nil {
+		t.Fatalf("Got error while hashing password with scrypt: %v", err)
+	}
+	bcryptHash, err := bcrypt.GenerateFromPassword([]byte("h4x0r!"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Got error while hashing password with bcrypt: %v", err)
+	}
+
+	type data struct {
+		Name        string
+		Password    string
+		Hash        string
+		Valid       bool
+		NeedsRehash bool
+		Error       error
+	}
+
+	var testdata = []data{
+		{"argon2 valid", "h4x0r!", argon2Hash, true, false, nil},
+		{"argon2 wrong password", "wrongPassword", argon2Hash, false, false, nil},
+		{"scrypt valid", "h4x0r!", scryptHash, true, true, nil},
+		{"scrypt wrong password", "wrongPassword", scryptHash, false, false, nil},
+		{"bcrypt valid", "h4x0r!", string(bcryptHash), true, true, nil},
+		{"bcrypt wrong password", "wrongPassword", string(bcryptHash), false, false, nil},
+		{"unknown hash", "h4x0r!", "$unknown$h4x0r!", false, false, ErrUnknownHashID},
+	}
+
+	for _, td := range testdata {
+		valid, needsRehash, err := ValidatePassword(td.Password, td.Hash)
+		if err != td.Error {
+			t.Errorf("Expected error %v for %s, got: %v", td.Error, td.Name, err)
+		}
+		if valid != td.Valid || needsRehash != td.NeedsRehash {
+			t.Errorf("Expected valid %v and needs rehash %v for %s, got: %v and %v", td.Valid, td.NeedsRehash, td.Name, valid, needsRehash)
+		}
+	}
+}
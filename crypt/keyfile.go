@@ -0,0 +1,31 @@
+package crypt
+
+import (
+	"crypto/rand"
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+var ErrInvalidKeyFile = errors.New("crypt: key file has the wrong size")
+
+// LoadOrCreateKeyFile reads a secret key of the given size in bytes from the path.
+// If there is no file at the path yet, a random key is generated and stored there readable only by the owner.
+func LoadOrCreateKeyFile(path string, size int) ([]byte, error) {
+	key, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		key = make([]byte, size)
+		if _, err = rand.Read(key); err != nil {
+			return nil, errors.Wrap(err, "could not generate key")
+		}
+		err = ioutil.WriteFile(path, key, 0600)
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read key file %s", path)
+	}
+	if len(key) != size {
+		return nil, errors.Wrapf(ErrInvalidKeyFile, "expected %d bytes in %s but got %d", size, path, len(key))
+	}
+	return key, nil
+}
@@ -0,0 +1,31 @@
+package crypt
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestLoadOrCreateKeyFile(t *testing.T) {
+	path := "keyfileTest.key"
+	os.Remove(path)
+	defer os.Remove(path)
+
+	key, err := LoadOrCreateKeyFile(path, 32)
+	if err != nil || len(key) != 32 {
+		t.Fatalf("Failed to create key file: %d bytes, %v", len(key), err)
+	}
+	readKey, err := LoadOrCreateKeyFile(path, 32)
+	if err != nil || !bytes.Equal(key, readKey) {
+		t.Errorf("Expected the created key to be read back but got: %x, %v", readKey, err)
+	}
+
+	ioutil.WriteFile(path, []byte("short"), 0600)
+	_, err = LoadOrCreateKeyFile(path, 32)
+	if errors.Cause(err) != ErrInvalidKeyFile {
+		t.Errorf("Expected invalid key file error for key of wrong size but got: %v", err)
+	}
+}
@@ -26,6 +26,28 @@ const (
 
 var ErrInvalidScryptStub = errors.New("auth: invalid scrypt stub")
 
+func init() {
+	RegisterHasher(ScryptHashID, scryptHasher{})
+}
+
+type scryptHasher struct{}
+
+func (scryptHasher) Hash(plaintext string) (hash string, err error) {
+	return HashScrypt(plaintext)
+}
+
+func (scryptHasher) Validate(plaintext, hashed string) (valid bool, err error) {
+	return ValidateScryptPassword(plaintext, hashed)
+}
+
+func (scryptHasher) NeedsRehash(hashed string) bool {
+	_, hash, N, r, p, err := parseScryptStub(hashed)
+	if err != nil {
+		return true
+	}
+	return N != recommendedN || r != recommendedr || p != recommendedp || len(hash) != scryptHashLength
+}
+
 func HashScrypt(plaintext string) (hash string, err error) {
 	passwordb := []byte(plaintext)
 	saltb := []byte(utils.RandomString(saltLength))
@@ -0,0 +1,371 @@
+package crypt
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Values of the payload hash which are not a hash of the body
+const (
+	SigV4UnsignedPayload  = "UNSIGNED-PAYLOAD"
+	SigV4StreamingPayload = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+)
+
+const (
+	sigV4Algorithm      = "AWS4-HMAC-SHA256"
+	sigV4TimeFormat     = "20060102T150405Z"
+	sigV4MaxSkew        = 15 * time.Minute
+	sigV4MaxExpiry      = 7 * 24 * time.Hour
+	sigV4MaxChunkHeader = 4096
+	emptySHA256         = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+var (
+	ErrSigV4Missing         = errors.New("sigv4: request is not signed")
+	ErrSigV4Malformed       = errors.New("sigv4: malformed authorization")
+	ErrSigV4Expired         = errors.New("sigv4: request time is too skewed or has expired")
+	ErrSigV4Mismatch        = errors.New("sigv4: signature does not match")
+	ErrSigV4PayloadMismatch = errors.New("sigv4: payload does not match its signed hash")
+)
+
+// SigV4Auth is the authorization of a request signed with AWS signature version 4 either in its headers or in its query
+type SigV4Auth struct {
+	AccessKeyID   string
+	Date          string // Day of the scope as YYYYMMDD
+	Region        string
+	Service       string
+	Time          time.Time
+	SignedHeaders []string
+	Signature     string
+	// PayloadHash is the hex encoded SHA-256 of the body or one of the values for unsigned and streamed payloads
+	PayloadHash string
+	Presigned   bool
+	Expires     time.Duration
+}
+
+// ParseSigV4 reads the authorization of the request from its Authorization header or from the query of a presigned URL
+func ParseSigV4(r *http.Request) (*SigV4Auth, error) {
+	if authorization := r.Header.Get("Authorization"); authorization != "" {
+		return parseSigV4Header(r, authorization)
+	}
+	if r.URL.Query().Get("X-Amz-Algorithm") != "" {
+		return parseSigV4Query(r.URL.Query())
+	}
+	return nil, ErrSigV4Missing
+}
+
+func parseSigV4Header(r *http.Request, authorization string) (*SigV4Auth, error) {
+	if !strings.HasPrefix(authorization, sigV4Algorithm+" ") {
+		return nil, ErrSigV4Malformed
+	}
+	auth := &SigV4Auth{PayloadHash: r.Header.Get("X-Amz-Content-Sha256")}
+	var credential, signedHeaders string
+	for _, field := range strings.Split(strings.TrimPrefix(authorization, sigV4Algorithm+" "), ",") {
+		keyValue := strings.SplitN(strings.TrimSpace(field), "=", 2)
+		if len(keyValue) != 2 {
+			return nil, ErrSigV4Malformed
+		}
+		switch keyValue[0] {
+		case "Credential":
+			credential = keyValue[1]
+		case "SignedHeaders":
+			signedHeaders = keyValue[1]
+		case "Signature":
+			auth.Signature = keyValue[1]
+		}
+	}
+	// S3 requires the payload hash header, its value is part of the canonical request
+	if auth.PayloadHash == "" {
+		return nil, ErrSigV4Malformed
+	}
+
+	amzDate := r.Header.Get("X-Amz-Date")
+	if amzDate == "" {
+		amzDate = r.Header.Get("Date")
+	}
+	err := auth.parseFields(credential, signedHeaders, amzDate)
+	if err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+func parseSigV4Query(query url.Values) (*SigV4Auth, error) {
+	if query.Get("X-Amz-Algorithm") != sigV4Algorithm {
+		return nil, ErrSigV4Malformed
+	}
+	auth := &SigV4Auth{
+		Signature:   query.Get("X-Amz-Signature"),
+		PayloadHash: SigV4UnsignedPayload,
+		Presigned:   true,
+	}
+	expires, err := strconv.Atoi(query.Get("X-Amz-Expires"))
+	if err != nil || expires < 0 || time.Duration(expires)*time.Second > sigV4MaxExpiry {
+		return nil, ErrSigV4Malformed
+	}
+	auth.Expires = time.Duration(expires) * time.Second
+
+	err = auth.parseFields(query.Get("X-Amz-Credential"), query.Get("X-Amz-SignedHeaders"), query.Get("X-Amz-Date"))
+	if err != nil {
+		return nil, err
+	}
+	return auth, nil
+}
+
+// parseFields parses the credential scope, the list of signed headers and the time of signing shared by both kinds of authorization
+func (auth *SigV4Auth) parseFields(credential, signedHeaders, amzDate string) (err error) {
+	scope := strings.Split(credential, "/")
+	if len(scope) != 5 || scope[0] == "" || scope[4] != "aws4_request" {
+		return ErrSigV4Malformed
+	}
+	auth.AccessKeyID, auth.Date, auth.Region, auth.Service = scope[0], scope[1], scope[2], scope[3]
+
+	if signedHeaders == "" || auth.Signature == "" {
+		return ErrSigV4Malformed
+	}
+	auth.SignedHeaders = strings.Split(signedHeaders, ";")
+
+	auth.Time, err = time.Parse(sigV4TimeFormat, amzDate)
+	if err != nil || auth.Time.Format("20060102") != auth.Date {
+		return ErrSigV4Malformed
+	}
+	return nil
+}
+
+// Scope returns the credential scope the signing key is derived for
+func (auth *SigV4Auth) Scope() string {
+	return strings.Join([]string{auth.Date, auth.Region, auth.Service, "aws4_request"}, "/")
+}
+
+// Verify checks that the request has been signed at most 15 minutes ago or is presigned and not expired yet,
+// and that its signature has been calculated with the secret key
+func (auth *SigV4Auth) Verify(r *http.Request, secretKey string, now time.Time) error {
+	if auth.Presigned {
+		if now.Before(auth.Time.Add(-sigV4MaxSkew)) || now.After(auth.Time.Add(auth.Expires)) {
+			return ErrSigV4Expired
+		}
+	} else if now.Sub(auth.Time) > sigV4MaxSkew || auth.Time.Sub(now) > sigV4MaxSkew {
+		return ErrSigV4Expired
+	}
+
+	signature := auth.sign(secretKey, auth.stringToSign(canonicalSigV4Request(r, auth)))
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(auth.Signature)) != 1 {
+		return ErrSigV4Mismatch
+	}
+	return nil
+}
+
+// SignSigV4 signs the request in its headers like S3 clients do, the body is sent unsigned.
+// Besides the host all x-amz-* headers and Content-MD5 are signed as S3 requires.
+func SignSigV4(r *http.Request, accessKeyID, secretKey, region string, now time.Time) {
+	now = now.UTC()
+	r.Header.Set("X-Amz-Date", now.Format(sigV4TimeFormat))
+	r.Header.Set("X-Amz-Content-Sha256", SigV4UnsignedPayload)
+	signedHeaders := []string{"host"}
+	for name := range r.Header {
+		name = strings.ToLower(name)
+		if strings.HasPrefix(name, "x-amz-") || name == "content-md5" {
+			signedHeaders = append(signedHeaders, name)
+		}
+	}
+	sort.Strings(signedHeaders)
+	auth := &SigV4Auth{
+		AccessKeyID:   accessKeyID,
+		Date:          now.Format("20060102"),
+		Region:        region,
+		Service:       "s3",
+		Time:          now,
+		SignedHeaders: signedHeaders,
+		PayloadHash:   SigV4UnsignedPayload,
+	}
+	auth.Signature = auth.sign(secretKey, auth.stringToSign(canonicalSigV4Request(r, auth)))
+	r.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		sigV4Algorithm, accessKeyID, auth.Scope(), strings.Join(auth.SignedHeaders, ";"), auth.Signature))
+}
+
+func (auth *SigV4Auth) stringToSign(canonicalRequest string) string {
+	return strings.Join([]string{sigV4Algorithm, auth.Time.Format(sigV4TimeFormat), auth.Scope(), hashSHA256Hex([]byte(canonicalRequest))}, "\n")
+}
+
+// sign derives the signing key for the scope from the secret key and returns the hex encoded signature of the string
+func (auth *SigV4Auth) sign(secretKey, stringToSign string) string {
+	key := []byte("AWS4" + secretKey)
+	for _, part := range []string{auth.Date, auth.Region, auth.Service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+// canonicalSigV4Request builds the canonical form of the request which is signed, S3 encodes its path only once
+func canonicalSigV4Request(r *http.Request, auth *SigV4Auth) string {
+	query := r.URL.Query()
+	query.Del("X-Amz-Signature")
+	var queryParts []string
+	for key, values := range query {
+		for _, value := range values {
+			queryParts = append(queryParts, sigV4Encode(key, true)+"="+sigV4Encode(value, true))
+		}
+	}
+	sort.Strings(queryParts)
+
+	var headers bytes.Buffer
+	for _, name := range auth.SignedHeaders {
+		var values []string
+		for _, value := range r.Header[http.CanonicalHeaderKey(name)] {
+			values = append(values, strings.Join(strings.Fields(value), " "))
+		}
+		value := strings.Join(values, ",")
+		// Servers get these headers as fields of the request only
+		if name == "host" {
+			value = r.Host
+		} else if name == "content-length" && value == "" {
+			value = strconv.FormatInt(r.ContentLength, 10)
+		}
+		headers.WriteString(name + ":" + value + "\n")
+	}
+
+	path := r.URL.Path
+	if path == "" {
+		path = "/"
+	}
+	return strings.Join([]string{
+		r.Method,
+		sigV4Encode(path, false),
+		strings.Join(queryParts, "&"),
+		headers.String(),
+		strings.Join(auth.SignedHeaders, ";"),
+		auth.PayloadHash,
+	}, "\n")
+}
+
+// sigV4Encode percent-encodes everything except unreserved characters and optionally slashes
+func sigV4Encode(value string, encodeSlash bool) string {
+	var encoded strings.Builder
+	for _, b := range []byte(value) {
+		if b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b == '.' || b == '_' || b == '~' || b == '/' && !encodeSlash {
+			encoded.WriteByte(b)
+		} else {
+			fmt.Fprintf(&encoded, "%%%02X", b)
+		}
+	}
+	return encoded.String()
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+func hashSHA256Hex(data []byte) string {
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:])
+}
+
+// NewSigV4PayloadReader returns a reader for the body which fails at its end if the body does not match the signed payload hash.
+// Streamed payloads are decoded and each of their chunks is verified, unsigned payloads are read as they are.
+func NewSigV4PayloadReader(body io.Reader, auth *SigV4Auth, secretKey string) io.Reader {
+	switch auth.PayloadHash {
+	case SigV4UnsignedPayload:
+		return body
+	case SigV4StreamingPayload:
+		return &sigV4ChunkedReader{reader: bufio.NewReader(body), auth: auth, secretKey: secretKey, previousSignature: auth.Signature}
+	}
+	return &sigV4HashingReader{reader: body, hash: sha256.New(), expected: auth.PayloadHash}
+}
+
+type sigV4HashingReader struct {
+	reader   io.Reader
+	hash     hash.Hash
+	expected string
+}
+
+func (r *sigV4HashingReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && hex.EncodeToString(r.hash.Sum(nil)) != r.expected {
+		return n, ErrSigV4PayloadMismatch
+	}
+	return n, err
+}
+
+// sigV4ChunkedReader decodes aws-chunked bodies whose chunks are each signed with the signature of the previous chunk as seed
+type sigV4ChunkedReader struct {
+	reader            *bufio.Reader
+	auth              *SigV4Auth
+	secretKey         string
+	previousSignature string
+	chunk             []byte
+	done              bool
+}
+
+func (r *sigV4ChunkedReader) Read(p []byte) (int, error) {
+	for len(r.chunk) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		err := r.readChunk()
+		if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.chunk)
+	r.chunk = r.chunk[n:]
+	return n, nil
+}
+
+// readChunk reads and verifies the next chunk, the last one is empty
+func (r *sigV4ChunkedReader) readChunk() error {
+	header, err := r.reader.ReadSlice('\n')
+	if err == bufio.ErrBufferFull || len(header) > sigV4MaxChunkHeader {
+		return ErrSigV4Malformed
+	} else if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	} else if err != nil {
+		return err
+	}
+
+	parts := strings.SplitN(strings.TrimSpace(string(header)), ";chunk-signature=", 2)
+	if len(parts) != 2 {
+		return ErrSigV4Malformed
+	}
+	size, err := strconv.ParseInt(parts[0], 16, 64)
+	if err != nil || size < 0 || size > 16<<20 {
+		return ErrSigV4Malformed
+	}
+
+	r.chunk = make([]byte, size+2)
+	_, err = io.ReadFull(r.reader, r.chunk)
+	if err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	if !bytes.HasSuffix(r.chunk, []byte("\r\n")) {
+		return ErrSigV4Malformed
+	}
+	r.chunk = r.chunk[:size]
+
+	stringToSign := strings.Join([]string{sigV4Algorithm + "-PAYLOAD", r.auth.Time.Format(sigV4TimeFormat), r.auth.Scope(),
+		r.previousSignature, emptySHA256, hashSHA256Hex(r.chunk)}, "\n")
+	signature := r.auth.sign(r.secretKey, stringToSign)
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(parts[1])) != 1 {
+		return ErrSigV4PayloadMismatch
+	}
+	r.previousSignature = signature
+	r.done = size == 0
+	return nil
+}
@@ -0,0 +1,144 @@
+package manager
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Actions of activities in the files of users
+const (
+	ActivityCreated = "created"
+	ActivityChanged = "changed"
+	ActivityMoved   = "moved"
+	ActivityDeleted = "deleted"
+)
+
+const activityMaxPerPage = 200
+
+// ActivityManager keeps track of what changed in the files of users, so they and those they share with can follow it
+type ActivityManager struct {
+	activityRep *repository.ActivityRepository
+}
+
+var activityManager *ActivityManager
+
+// CreateActivityManager creates a new singleton ActivityManager which can be used immediately
+func CreateActivityManager(activityRep *repository.ActivityRepository) *ActivityManager {
+	if activityManager != nil {
+		return activityManager
+	}
+
+	activityManager = &ActivityManager{
+		activityRep: activityRep,
+	}
+	return activityManager
+}
+
+// GetActivityManager returns the singleton instance of the ActivityManager
+func GetActivityManager() *ActivityManager {
+	return activityManager
+}
+
+// Record stores an activity on a file as stored for its owner, oldPath is the full path of a moved file before moving it.
+// Failures are only logged, a missing activity must not break the changes to the files.
+func (mgr *ActivityManager) Record(action string, actorID int64, fileInfo *models.FileInfo, oldPath string) {
+	err := mgr.activityRep.Create(&models.Activity{
+		Action:  action,
+		ActorID: actorID,
+		OwnerID: fileInfo.OwnerID,
+		Path:    getFullPath(fileInfo),
+		OldPath: oldPath,
+		IsDir:   fileInfo.IsDir,
+	})
+	if err != nil {
+		log.Error(0, "Could not record %s of %s%s by %d: %v", action, fileInfo.Path, fileInfo.Name, actorID, err)
+	}
+}
+
+// GetActivities returns a page of the activities within the folder of the user, newest first, together with their total count.
+// The folder may be shared with the user and folders shared with him below it are included. Paths are returned as the user sees them,
+// so paths outside of what the user can see, e.g. where a file has been moved to by the sharing user, are left empty.
+func (mgr *ActivityManager) GetActivities(user *models.User, folder string, since, until, page, perPage int64) (activities []*models.Activity, total int64, err error) {
+	if page < 1 || perPage < 1 || since < 0 || until < 0 || (until != 0 && since >= until) {
+		return nil, 0, fcerrors.New(fcerrors.InvalidActivityQuery)
+	}
+	if perPage > activityMaxPerPage {
+		perPage = activityMaxPerPage
+	}
+
+	views, err := GetFileManager().getVisibleSubtrees(user, folder)
+	if err != nil {
+		return nil, 0, fcerrors.Wrap(err, fcerrors.FileNotFound)
+	}
+	scopes := make([]*models.SubtreeScope, len(views))
+	for it, view := range views {
+		scopes[it] = view.scope
+	}
+
+	activities, total, err = mgr.activityRep.GetPage(scopes, since, until, (page-1)*perPage, perPage)
+	if err != nil {
+		return nil, 0, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	for _, activity := range activities {
+		activity.Path, activity.OldPath = toViewPaths(views, activity.OwnerID, activity.Path, activity.OldPath)
+	}
+	return
+}
+
+// DeleteActivitiesForOwner deletes all activities in the files of the user
+func (mgr *ActivityManager) DeleteActivitiesForOwner(userID int64) error {
+	return fcerrors.Wrap(mgr.activityRep.DeleteAllForOwner(userID), fcerrors.Database)
+}
+
+// recordActivity stores an activity if the ActivityManager has been created
+func recordActivity(action string, actorID int64, fileInfo *models.FileInfo, oldPath string) {
+	if activityMgr := GetActivityManager(); activityMgr != nil {
+		activityMgr.Record(action, actorID, fileInfo, oldPath)
+	}
+}
+
+// subtreeView maps a subtree of the files of an owner to the path a user sees it at
+type subtreeView struct {
+	scope    *models.SubtreeScope
+	viewPath string
+}
+
+// toViewPath converts a full path within the files of the owner to the one the user sees, it is empty if the path is outside of the subtree
+func (view *subtreeView) toViewPath(path string) string {
+	if path == "" || !isWithinPath(path, view.scope.Path) {
+		return ""
+	}
+	return utils.ConvertToSlash(filepath.Join(view.viewPath, strings.TrimPrefix(path, view.scope.Path)), false)
+}
+
+// toViewPaths converts the full paths of a file of the owner before and after a change to the ones the user sees in the first matching view.
+// Both are empty if none of the views contains them.
+func toViewPaths(views []*subtreeView, ownerID int64, path, oldPath string) (string, string) {
+	for _, view := range views {
+		if ownerID != view.scope.OwnerID {
+			continue
+		}
+		viewPath, viewOldPath := view.toViewPath(path), view.toViewPath(oldPath)
+		if viewPath != "" || viewOldPath != "" {
+			return viewPath, viewOldPath
+		}
+	}
+	return "", ""
+}
+
+// getFullPath returns the path of the file including its name
+func getFullPath(fileInfo *models.FileInfo) string {
+	return utils.ConvertToSlash(filepath.Join(fileInfo.Path, fileInfo.Name), false)
+}
+
+// isWithinPath returns whether the full path is the folder itself or below it
+func isWithinPath(path, folder string) bool {
+	return path == folder || strings.HasPrefix(path, strings.TrimSuffix(folder, "/")+"/")
+}
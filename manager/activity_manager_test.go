@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+func testActivityCleanup(authMgr *AuthManager) {
+	activityManager = nil
+	testAuthCleanup(authMgr)
+}
+
+func testActivitySetup() (*AuthManager, *ActivityManager) {
+	authMgr := testAuthSetup()
+	activityRep, _ := repository.CreateActivityRepository()
+	return authMgr, CreateActivityManager(activityRep)
+}
+
+func testActivityPaths(activities []*models.Activity) (paths []string) {
+	for _, activity := range activities {
+		paths = append(paths, activity.Action+" "+activity.OldPath+" "+activity.Path)
+	}
+	return
+}
+
+func TestActivities(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testActivitySetup()
+	defer testActivityCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	fileMgr := GetFileManager()
+	fileMgr.CreateFile(testAuthUser, "/docs", true)
+	fileMgr.CreateFile(testAuthUser, "/docs/a.txt", false)
+	fileMgr.CreateFile(testAuthUser, "/other.txt", false)
+	fileMgr.ShareFile(testAuthUser, testAuthUserAdmin, "/docs")
+	newName := "shared"
+	fileMgr.UpdateFile(testAuthUserAdmin, "/docs", &models.FileInfoUpdate{Name: &newName})
+	fileMgr.CreateFile(testAuthUserAdmin, "/shared/b.txt", false)
+	newPath := "/docs"
+	fileMgr.UpdateFile(testAuthUser, "/other.txt", &models.FileInfoUpdate{Path: &newPath})
+	fileMgr.DeleteFile(testAuthUser, "/docs/a.txt")
+	fileMgr.ExportUserData(testAuthUser, nil)
+
+	activities, total, err := mgr.GetActivities(testAuthUser, "/", 0, 0, 1, 10)
+	if err != nil {
+		t.Fatalf("Failed to get activities: %v", err)
+	}
+	if total != 6 || activities[0].Action != ActivityDeleted || activities[2].ActorID != testAuthUserAdmin.ID || activities[5].Path != "/docs" {
+		t.Errorf("Expected all 6 activities in files of owner without temp files but got: %v", testActivityPaths(activities))
+	}
+
+	activities, total, _ = mgr.GetActivities(testAuthUserAdmin, "/", 0, 0, 1, 10)
+	expPaths := []string{
+		"deleted  /shared/a.txt",
+		"moved  /shared/other.txt",
+		"created  /shared/b.txt",
+		"moved /docs /shared",
+		"created  /shared/a.txt",
+		"created  /shared",
+	}
+	if total != int64(len(expPaths)) || len(activities) != len(expPaths) {
+		t.Fatalf("Expected %v for share recipient but got: %v", expPaths, testActivityPaths(activities))
+	}
+	for it, path := range testActivityPaths(activities) {
+		if path != expPaths[it] {
+			t.Errorf("Expected activity '%s' for share recipient but got '%s'", expPaths[it], path)
+		}
+	}
+
+	activities, total, _ = mgr.GetActivities(testAuthUserAdmin, "/shared", 0, 0, 2, 2)
+	if total != 5 || len(activities) != 2 || activities[0].Path != "/shared/b.txt" {
+		t.Errorf("Expected second page of activities within shared folder but got: %v, %v", testActivityPaths(activities), total)
+	}
+
+	_, _, err = mgr.GetActivities(testAuthUser, "/unknown", 0, 0, 1, 10)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.FileNotFound {
+		t.Errorf("Expected 'file not found' for activities of unknown folder but got: %v", err)
+	}
+	_, _, err = mgr.GetActivities(testAuthUser, "/", 10, 5, 1, 10)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidActivityQuery {
+		t.Errorf("Expected 'invalid activity query' for empty time range but got: %v", err)
+	}
+
+	authMgr.DeleteUser(testAuthUser.ID)
+	activities, total, _ = mgr.GetActivities(testAuthUserAdmin, "/", 0, 0, 1, 10)
+	if total != 1 || activities[0].Action != ActivityMoved {
+		t.Errorf("Expected only own activities of share recipient after deleting owner but got: %v", testActivityPaths(activities))
+	}
+}
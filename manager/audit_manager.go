@@ -0,0 +1,210 @@
+package manager
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Actions recorded in the audit log, the part before the dot is their category
+const (
+	AuditLogin       = "auth.login"
+	AuditLoginFailed = "auth.login_failed"
+	AuditSignup      = "auth.signup"
+
+	AuditAppPasswordCreate = "app_password.create"
+	AuditAppPasswordRevoke = "app_password.revoke"
+
+	AuditSSHKeyAdd    = "ssh_key.add"
+	AuditSSHKeyRemove = "ssh_key.remove"
+
+	AuditAccessKeyCreate = "access_key.create"
+	AuditAccessKeyRevoke = "access_key.revoke"
+
+	AuditUserApprove  = "user.approve"
+	AuditUserDisable  = "user.disable"
+	AuditUserEnable   = "user.enable"
+	AuditUserUnlock   = "user.unlock"
+	AuditUserDelete   = "user.delete"
+	AuditUserRoles    = "user.roles"
+	AuditUserTransfer = "user.transfer"
+
+	AuditGroupCreate = "group.create"
+	AuditGroupUpdate = "group.update"
+	AuditGroupDelete = "group.delete"
+
+	AuditMountCreate = "mount.create"
+	AuditMountUpdate = "mount.update"
+	AuditMountDelete = "mount.delete"
+
+	AuditStorageEncrypt = "storage.encrypt"
+	AuditStorageCollect = "storage.collect"
+	AuditStorageScrub   = "storage.scrub"
+
+	AuditFileCreate   = "file.create"
+	AuditFileDelete   = "file.delete"
+	AuditFileMove     = "file.move"
+	AuditFileDownload = "file.download"
+
+	AuditShareCreate = "share.create"
+	AuditShareRevoke = "share.revoke"
+
+	AuditImpersonationStart   = "impersonation.start"
+	AuditImpersonationRequest = "impersonation.request"
+	AuditImpersonationDenied  = "impersonation.denied"
+)
+
+const (
+	auditMaxPerPage    = 500
+	auditExportBatch   = 1000
+	auditCleanupHours  = 24
+	auditSecondsPerDay = 24 * 60 * 60
+)
+
+// AuditManager records security relevant events in the persistent audit log
+type AuditManager struct {
+	auditEntryRep *repository.AuditEntryRepository
+	retentionDays int
+	done          chan struct{}
+}
+
+var auditManager *AuditManager
+
+// CreateAuditManager creates a new singleton AuditManager which can be used immediately.
+// Entries older than retentionDays are deleted regularly, with 0 they are kept forever.
+func CreateAuditManager(auditEntryRep *repository.AuditEntryRepository, retentionDays int) *AuditManager {
+	if auditManager != nil {
+		return auditManager
+	}
+
+	auditManager = &AuditManager{
+		auditEntryRep: auditEntryRep,
+		retentionDays: retentionDays,
+		done:          make(chan struct{}),
+	}
+	if retentionDays > 0 {
+		go auditManager.cleanupExpiredEntriesRoutine()
+	}
+	return auditManager
+}
+
+// GetAuditManager returns the singleton instance of the AuditManager
+func GetAuditManager() *AuditManager {
+	return auditManager
+}
+
+// Close is used to end running tasks
+func (mgr *AuditManager) Close() {
+	close(mgr.done)
+}
+
+func (mgr *AuditManager) cleanupExpiredEntriesRoutine() {
+	log.Trace("Audit entries will be kept for %v days", mgr.retentionDays)
+	mgr.cleanupExpiredEntries()
+	ticker := time.NewTicker(time.Hour * auditCleanupHours)
+	for {
+		select {
+		case <-mgr.done:
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			log.Trace("Cleaning expired audit entries")
+			mgr.cleanupExpiredEntries()
+		}
+	}
+}
+
+// cleanupExpiredEntries deletes all entries which are older than the retention period
+func (mgr *AuditManager) cleanupExpiredEntries() {
+	mgr.auditEntryRep.DeleteOlderThan(utils.GetTimestampNow() - int64(mgr.retentionDays)*auditSecondsPerDay)
+}
+
+// Record stores an entry in the audit log.
+// Failures are only logged, an audit log which cannot be written must not break the audited operation.
+func (mgr *AuditManager) Record(action string, actorID, targetUserID int64, target, ip string) {
+	err := mgr.auditEntryRep.Create(&models.AuditEntry{
+		Action:       action,
+		ActorID:      actorID,
+		TargetUserID: targetUserID,
+		Target:       target,
+		IP:           ip,
+	})
+	if err != nil {
+		log.Error(0, "Could not record %s of %d for %d in audit log: %v", action, actorID, targetUserID, err)
+	}
+}
+
+// GetEntriesForUser returns all audit entries concerning the user
+func (mgr *AuditManager) GetEntriesForUser(userID int64) ([]*models.AuditEntry, error) {
+	auditEntries, err := mgr.auditEntryRep.GetForTargetUser(userID)
+	return auditEntries, fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// GetEntries returns a page of the audit entries matching the filter, newest first, together with the total count of matching entries
+func (mgr *AuditManager) GetEntries(filter *models.AuditFilter, page, perPage int64) (auditEntries []*models.AuditEntry, total int64, err error) {
+	if page < 1 || perPage < 1 || !isValidAuditFilter(filter) {
+		return nil, 0, fcerrors.New(fcerrors.InvalidAuditQuery)
+	}
+	if perPage > auditMaxPerPage {
+		perPage = auditMaxPerPage
+	}
+
+	auditEntries, total, err = mgr.auditEntryRep.GetPage(filter, (page-1)*perPage, perPage)
+	if err != nil {
+		return nil, 0, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return
+}
+
+// ExportEntries writes all audit entries matching the filter as JSON Lines in the order they were recorded into the temp folder of the user
+func (mgr *AuditManager) ExportEntries(user *models.User, filter *models.AuditFilter) (exportPath string, err error) {
+	if !isValidAuditFilter(filter) {
+		return "", fcerrors.New(fcerrors.InvalidAuditQuery)
+	}
+
+	exportPath, err = GetFileManager().WriteTmpFile(user, time.Now().Format("audit_log_20060102_150405.jsonl"), func(writer io.Writer) error {
+		encoder := json.NewEncoder(writer)
+		var lastID int64
+		for {
+			auditEntries, err := mgr.auditEntryRep.GetBatchAfter(filter, lastID, auditExportBatch)
+			if err != nil {
+				return fcerrors.Wrap(err, fcerrors.Database)
+			}
+			for _, auditEntry := range auditEntries {
+				err = encoder.Encode(auditEntry)
+				if err != nil {
+					return fcerrors.Wrap(err, fcerrors.Filesystem)
+				}
+			}
+			if len(auditEntries) < auditExportBatch {
+				return nil
+			}
+			lastID = auditEntries[len(auditEntries)-1].ID
+		}
+	})
+	if err != nil {
+		log.Error(0, "Could not export audit log for %d: %v", user.ID, err)
+		if _, ok := err.(*fcerrors.FCError); !ok {
+			err = fcerrors.Wrap(err, fcerrors.Filesystem)
+		}
+		return "", err
+	}
+	return
+}
+
+func isValidAuditFilter(filter *models.AuditFilter) bool {
+	return filter.Since >= 0 && filter.Until >= 0 && (filter.Until == 0 || filter.Since < filter.Until)
+}
+
+// recordAudit stores an entry in the audit log if the AuditManager has been created
+func recordAudit(action string, actorID, targetUserID int64, target, ip string) {
+	if auditMgr := GetAuditManager(); auditMgr != nil {
+		auditMgr.Record(action, actorID, targetUserID, target, ip)
+	}
+}
@@ -0,0 +1,112 @@
+package manager
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+func testAuditCleanup(authMgr *AuthManager, mgr *AuditManager) {
+	mgr.Close()
+	auditManager = nil
+	testAuthCleanup(authMgr)
+}
+
+func testAuditSetup(retentionDays int) (*AuthManager, *AuditManager) {
+	authMgr := testAuthSetup()
+	auditEntryRep, _ := repository.CreateAuditEntryRepository()
+	return authMgr, CreateAuditManager(auditEntryRep, retentionDays)
+}
+
+func TestAuditLog(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testAuditSetup(0)
+	defer testAuditCleanup(authMgr, mgr)
+	testAuthInsert(authMgr)
+
+	mgr.Record(AuditLogin, testAuthUser.ID, testAuthUser.ID, "", testAuthClientIP)
+	mgr.Record(AuditFileCreate, testAuthUser.ID, testAuthUser.ID, "/doc.txt", testAuthClientIP)
+	mgr.Record(AuditFileDelete, testAuthUser.ID, testAuthUser.ID, "/doc.txt", testAuthClientIP)
+	mgr.Record(AuditUserDisable, testAuthUserAdmin.ID, testAuthUser.ID, "", testAuthClientIP)
+
+	auditEntries, total, err := mgr.GetEntries(&models.AuditFilter{Action: "file"}, 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to get audit entries: %v", err)
+	}
+	if total != 2 || len(auditEntries) != 1 || auditEntries[0].Action != AuditFileDelete || auditEntries[0].IP != testAuthClientIP {
+		t.Errorf("Expected newest of 2 file entries but got: %v, %v", auditEntries, total)
+	}
+
+	invalids := map[string]*models.AuditFilter{
+		"negative start":   {Since: -1},
+		"empty time range": {Since: 10, Until: 10},
+	}
+	for name, filter := range invalids {
+		_, _, err = mgr.GetEntries(filter, 1, 10)
+		if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidAuditQuery {
+			t.Errorf("Expected 'invalid audit query' for %s but got: %v", name, err)
+		}
+	}
+	_, _, err = mgr.GetEntries(&models.AuditFilter{}, 0, 10)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidAuditQuery {
+		t.Errorf("Expected 'invalid audit query' for page 0 but got: %v", err)
+	}
+
+	exportPath, err := mgr.ExportEntries(testAuthUserAdmin, &models.AuditFilter{TargetUserID: testAuthUser.ID})
+	if err != nil {
+		t.Fatalf("Failed to export audit entries: %v", err)
+	}
+	file, err := os.Open(filepath.Join(testAuthDataFolder, GetFileManager().getUserPath(testAuthUserAdmin), exportPath))
+	if err != nil {
+		t.Fatalf("Failed to open exported audit log at %s: %v", exportPath, err)
+	}
+	defer file.Close()
+	var actions []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		auditEntry := &models.AuditEntry{}
+		err = json.Unmarshal(scanner.Bytes(), auditEntry)
+		if err != nil {
+			t.Fatalf("Expected one audit entry per line but got %s: %v", scanner.Text(), err)
+		}
+		actions = append(actions, auditEntry.Action)
+	}
+	if len(actions) != 4 || actions[0] != AuditLogin || actions[3] != AuditUserDisable {
+		t.Errorf("Expected all entries of user in recorded order but got: %v", actions)
+	}
+	if !GetFileManager().TmpFileExists(testAuthUserAdmin, exportPath) {
+		t.Errorf("Expected export to be stored in temp folder but got: %s", exportPath)
+	}
+}
+
+func TestAuditRetention(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	// The cleanup is called directly instead of running it in the background
+	authMgr, mgr := testAuditSetup(0)
+	defer testAuditCleanup(authMgr, mgr)
+	mgr.retentionDays = 30
+
+	mgr.Record(AuditLogin, 1, 1, "", testAuthClientIP)
+	mgr.cleanupExpiredEntries()
+	_, total, _ := mgr.GetEntries(&models.AuditFilter{}, 1, 10)
+	if total != 1 {
+		t.Errorf("Expected recent entry to be retained but got %d entries", total)
+	}
+
+	mgr.retentionDays = -1
+	mgr.cleanupExpiredEntries()
+	_, total, _ = mgr.GetEntries(&models.AuditFilter{}, 1, 10)
+	if total != 0 {
+		t.Errorf("Expected expired entry to be deleted but got %d entries", total)
+	}
+}
@@ -1,6 +1,14 @@
 package manager
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	log "gopkg.in/clog.v1"
@@ -10,25 +18,137 @@ import (
 	"github.com/freecloudio/server/repository"
 	"github.com/freecloudio/server/restapi/fcerrors"
 	"github.com/freecloudio/server/utils"
+	"golang.org/x/crypto/ssh"
 )
 
 const (
 	sessionTokenLength = 32 // characters
+
+	accessKeyIDPrefix = "FC"
+	accessKeyIDLength = 18 // characters after the prefix
+
+	loginAttemptAccountPrefix = "account:"
+	loginAttemptIPPrefix      = "ip:"
+
+	// AuthProviderLDAP marks users which are authenticated against the LDAP directory
+	AuthProviderLDAP = "ldap"
+	// AuthProviderOIDC marks users which are authenticated by the OpenID Connect provider
+	AuthProviderOIDC = "oidc"
+
+	// RegistrationOpen allows anyone to sign up
+	RegistrationOpen = "open"
+	// RegistrationDisabled only allows the first user to sign up
+	RegistrationDisabled = "disabled"
+	// RegistrationInvite requires a valid invite code for signing up
+	RegistrationInvite = "invite"
+	// RegistrationDomain only allows emails of the allowlisted domains to sign up
+	RegistrationDomain = "domain"
+	// RegistrationApproval allows anyone to sign up, but users can't login until an admin approved them
+	RegistrationApproval = "approval"
+
+	inviteCodeLength = 16 // characters
+
+	appPasswordLength = 32 // characters
+
+	userListMaxPerPage = 100
+
+	oidcStateLength    = 32  // characters
+	oidcVerifierLength = 64  // characters, PKCE requires 43 to 128
+	oidcRequestExpiry  = 600 // seconds
 )
 
+// ldapAuthenticator is implemented by the LDAPRepository
+type ldapAuthenticator interface {
+	Authenticate(email, password string) (*models.User, error)
+	HasAdminGroup() bool
+}
+
+// oidcAuthenticator is implemented by the OIDCRepository
+type oidcAuthenticator interface {
+	AuthCodeURL(state, nonce, codeVerifier string) (string, error)
+	Exchange(code, codeVerifier, nonce string) (*models.User, error)
+	HasAdminClaim() bool
+}
+
+// oidcAuthRequest holds the secrets of a login started at the OpenID Connect provider until the user returns
+type oidcAuthRequest struct {
+	nonce        string
+	codeVerifier string
+	expiresAt    int64
+}
+
+// LockoutPolicy configures how failed logins are throttled.
+// A threshold of zero or less disables the lockout for that subject, durations are in seconds.
+type LockoutPolicy struct {
+	AccountThreshold int
+	IPThreshold      int
+	BaseDuration     int
+	MaxDuration      int
+}
+
+// RegistrationPolicy configures who is allowed to sign up, the first user can always sign up and becomes an admin.
+// Domains are only used in the domain mode.
+type RegistrationPolicy struct {
+	Mode    string
+	Domains []string
+}
+
+// userListSortColumns maps the attributes users can be sorted by to their columns
+var userListSortColumns = map[string]string{
+	"email":       "email",
+	"firstName":   "first_name",
+	"lastName":    "last_name",
+	"created":     "created",
+	"lastSession": "last_session",
+}
+
 // AuthManager has methods for authenticating users.
 type AuthManager struct {
 	sessionRep             *repository.SessionRepository
 	userRep                *repository.UserRepository
+	loginAttemptRep        *repository.LoginAttemptRepository
+	inviteCodeRep          *repository.InviteCodeRepository
+	appPasswordRep         *repository.AppPasswordRepository
+	sshKeyRep              *repository.SSHKeyRepository
+	accessKeyRep           *repository.AccessKeyRepository
+	accessKeyMasterKey     []byte
+	ldapRep                ldapAuthenticator
+	ldapLocalFallback      bool
+	oidcRep                oidcAuthenticator
+	oidcRequests           map[string]*oidcAuthRequest
+	oidcMutex              sync.Mutex
+	signupMutex            sync.Mutex
 	sessionExpiry          int
 	sessionCleanupInterval int
+	impersonationExpiry    int
+	lockoutPolicy          LockoutPolicy
+	loginLocks             subjectLocks
+	registrationPolicy     RegistrationPolicy
 	done                   chan struct{}
 }
 
 var authManager *AuthManager
 
-// CreateAuthManager creates a new singleton AuthManager which can be used immediately, sessionExpiry and sessionCleanupInterval are in hours
-func CreateAuthManager(sessionRep *repository.SessionRepository, userRep *repository.UserRepository, sessionExpiry, sessionCleanupInterval int) *AuthManager {
+// AuthConfig holds the optional backends and the settings of the AuthManager.
+// SessionExpiry and SessionCleanupInterval are in hours, sessions of admins acting as another user expire after ImpersonationExpiry minutes.
+type AuthConfig struct {
+	// The secrets of S3 access keys are derived from AccessKeyMasterKey, so changing it invalidates all access keys.
+	// Without it the S3 API is disabled and access keys can neither be created nor used.
+	AccessKeyMasterKey []byte
+	// If LDAPRep is nil only local users can login, otherwise LDAPLocalFallback decides whether local users can still login
+	LDAPRep           *repository.LDAPRepository
+	LDAPLocalFallback bool
+	// Logins with OpenID Connect are only possible if OIDCRep is set
+	OIDCRep                *repository.OIDCRepository
+	SessionExpiry          int
+	SessionCleanupInterval int
+	ImpersonationExpiry    int
+	LockoutPolicy          LockoutPolicy
+	RegistrationPolicy     RegistrationPolicy
+}
+
+// CreateAuthManager creates a new singleton AuthManager which can be used immediately
+func CreateAuthManager(sessionRep *repository.SessionRepository, userRep *repository.UserRepository, loginAttemptRep *repository.LoginAttemptRepository, inviteCodeRep *repository.InviteCodeRepository, appPasswordRep *repository.AppPasswordRepository, sshKeyRep *repository.SSHKeyRepository, accessKeyRep *repository.AccessKeyRepository, authConfig AuthConfig) *AuthManager {
 	if authManager != nil {
 		return authManager
 	}
@@ -36,10 +156,28 @@ func CreateAuthManager(sessionRep *repository.SessionRepository, userRep *reposi
 	authManager = &AuthManager{
 		sessionRep:             sessionRep,
 		userRep:                userRep,
-		sessionExpiry:          sessionExpiry,
-		sessionCleanupInterval: sessionCleanupInterval,
+		loginAttemptRep:        loginAttemptRep,
+		inviteCodeRep:          inviteCodeRep,
+		appPasswordRep:         appPasswordRep,
+		sshKeyRep:              sshKeyRep,
+		accessKeyRep:           accessKeyRep,
+		accessKeyMasterKey:     authConfig.AccessKeyMasterKey,
+		sessionExpiry:          authConfig.SessionExpiry,
+		sessionCleanupInterval: authConfig.SessionCleanupInterval,
+		impersonationExpiry:    authConfig.ImpersonationExpiry,
+		lockoutPolicy:          authConfig.LockoutPolicy,
+		registrationPolicy:     authConfig.RegistrationPolicy,
 		done:                   make(chan struct{}),
 	}
+	// Only assign set repositories to keep the interfaces nil otherwise
+	if authConfig.LDAPRep != nil {
+		authManager.ldapRep = authConfig.LDAPRep
+		authManager.ldapLocalFallback = authConfig.LDAPLocalFallback
+	}
+	if authConfig.OIDCRep != nil {
+		authManager.oidcRep = authConfig.OIDCRep
+		authManager.oidcRequests = make(map[string]*oidcAuthRequest)
+	}
 	go authManager.cleanupExpiredSessionsRoutine()
 	return authManager
 }
@@ -57,6 +195,7 @@ func (mgr *AuthManager) Close() {
 func (mgr *AuthManager) cleanupExpiredSessionsRoutine() {
 	log.Trace("Session cleaner will run every %v hours", mgr.sessionCleanupInterval)
 	mgr.sessionRep.DeleteExpired()
+	mgr.cleanupLoginAttempts()
 	ticker := time.NewTicker(time.Hour * time.Duration(mgr.sessionCleanupInterval))
 	for {
 		select {
@@ -65,13 +204,20 @@ func (mgr *AuthManager) cleanupExpiredSessionsRoutine() {
 		case <-ticker.C:
 			log.Trace("Cleaning expired sessions")
 			mgr.sessionRep.DeleteExpired()
+			mgr.cleanupLoginAttempts()
 		}
 	}
 }
 
+// cleanupLoginAttempts forgets failed logins which are too old to influence any lockout
+func (mgr *AuthManager) cleanupLoginAttempts() {
+	mgr.loginAttemptRep.DeleteOlderThan(utils.GetTimestampNow() - int64(mgr.lockoutPolicy.MaxDuration))
+}
+
 // CreateUser validates a new user's data, hashes his password and then stores them.
-// Also, a new session is returned for the given user.
-func (mgr *AuthManager) CreateUser(user *models.User) (session *models.Session, err error) {
+// Depending on the registration policy an invite code is required or the user has to be approved before he can login.
+// A new session is returned for the given user unless he awaits approval.
+func (mgr *AuthManager) CreateUser(user *models.User, inviteCode string) (session *models.Session, err error) {
 	if !utils.ValidateEmail(user.Email) ||
 		!utils.ValidatePassword(user.Password) ||
 		!utils.ValidateFirstName(user.FirstName) ||
@@ -89,44 +235,204 @@ func (mgr *AuthManager) CreateUser(user *models.User) (session *models.Session,
 		return nil, fcerrors.New(fcerrors.UserExists)
 	}
 
-	user.Password, err = crypt.HashScrypt(user.Password)
+	user.IsAdmin = false
+	user.PendingApproval = false
+	user.AuthProvider = ""
+	user.AuthSubject = ""
+
+	user.Password, err = crypt.HashPassword(user.Password)
 	if err != nil {
 		log.Error(0, "Password hashing failed: %v", err)
 		return nil, fcerrors.Wrap(err, fcerrors.HashingFailed)
 	}
-	user.IsAdmin = false
+
+	err = mgr.storeNewUser(user, inviteCode)
+	if err != nil {
+		return
+	}
+
+	err = GetFileManager().ScanUserFolderForChanges(user)
+	if err != nil {
+		log.Error(0, "Failed to scan folder for new user: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+
+	if user.PendingApproval {
+		log.Info("User %s awaits approval", user.Email)
+		return nil, nil
+	}
+
+	// Now, create a session for the user
+	return mgr.createUserSession(user.ID)
+}
+
+// storeNewUser saves a new user after checking the registration policy.
+// The first user ever becomes an admin, so he can always register to set up the system.
+// Signups are serialized, thus only one user can take this place and the policy applies to everyone else.
+func (mgr *AuthManager) storeNewUser(user *models.User, inviteCode string) (err error) {
+	mgr.signupMutex.Lock()
+	defer mgr.signupMutex.Unlock()
+
+	userCount, err := mgr.userRep.TotalCount()
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	firstUser := userCount == 0
+	if !firstUser {
+		err = mgr.checkRegistrationPolicy(user, inviteCode)
+		if err != nil {
+			return
+		}
+	}
 
 	// Save the user. This also fills their ID
 	err = mgr.userRep.Create(user)
 	if err != nil {
 		log.Error(0, "Creating user failed: %v", err)
-		return nil, fcerrors.Wrap(err, fcerrors.Database)
+		if !firstUser && mgr.registrationPolicy.Mode == RegistrationInvite {
+			mgr.inviteCodeRep.Release(inviteCode)
+		}
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	if !firstUser {
+		return
 	}
 
-	// If this is the first user (ID 1) they will become an admin
-	if user.ID == 1 {
-		log.Trace("Making first user an admin")
-		user.IsAdmin = true
-		err = mgr.userRep.Update(user)
+	// IDs are never reused, so an empty table after all users have been deleted doesn't hand out admin rights again
+	if user.ID != 1 {
+		err = mgr.checkRegistrationPolicy(user, inviteCode)
 		if err != nil {
-			log.Error(0, "Could not make first user an admin: %v", err)
-			// Since a system without an admin won't properly work, bail out
-			return nil, fcerrors.Wrap(err, fcerrors.Database)
+			if delErr := mgr.userRep.Delete(user.ID); delErr != nil {
+				log.Error(0, "Could not remove rejected user %s: %v", user.Email, delErr)
+			}
+			return
+		}
+		if !user.PendingApproval {
+			return
+		}
+	} else {
+		user.IsAdmin = true
+	}
+
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Error(0, "Could not update new user %s: %v", user.Email, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	if user.IsAdmin {
+		log.Trace("Made first user an admin")
+	}
+	return
+}
+
+// checkRegistrationPolicy verifies whether the user is allowed to sign up and marks him as pending if approval is required.
+// Invite codes are redeemed right away, thus this has to be the last check before creating the user and the use has to be released if that fails.
+func (mgr *AuthManager) checkRegistrationPolicy(user *models.User, inviteCode string) error {
+	switch mgr.registrationPolicy.Mode {
+	case RegistrationOpen:
+		return nil
+	case RegistrationApproval:
+		user.PendingApproval = true
+		return nil
+	case RegistrationDomain:
+		domain := user.Email[strings.LastIndex(user.Email, "@")+1:]
+		for _, allowedDomain := range mgr.registrationPolicy.Domains {
+			if strings.EqualFold(domain, allowedDomain) {
+				return nil
+			}
+		}
+		log.Warn("Rejected registration of %s due to its email domain", user.Email)
+		return fcerrors.New(fcerrors.EmailDomainNotAllowed)
+	case RegistrationInvite:
+		if inviteCode == "" {
+			return fcerrors.New(fcerrors.InvalidInviteCode)
 		}
+		err := mgr.inviteCodeRep.Use(inviteCode)
+		if err == repository.ErrInviteCodeUnusable {
+			log.Warn("Rejected registration of %s due to an invalid invite code", user.Email)
+			return fcerrors.New(fcerrors.InvalidInviteCode)
+		}
+		return fcerrors.Wrap(err, fcerrors.Database)
+	case RegistrationDisabled:
+	default:
+		log.Error(0, "Unknown registration mode %s, rejecting registration", mgr.registrationPolicy.Mode)
+	}
+	return fcerrors.New(fcerrors.RegistrationDisabled)
+}
+
+// ApproveUser allows an user who signed up in approval mode to login
+func (mgr *AuthManager) ApproveUser(userID int64) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil || !user.PendingApproval {
+		return
 	}
 
-	err = GetFileManager().ScanUserFolderForChanges(user)
+	user.PendingApproval = false
+	err = mgr.userRep.Update(user)
 	if err != nil {
-		log.Error(0, "Failed to scan folder for new user: %v", err)
-		return nil, fcerrors.Wrap(err, fcerrors.Filesystem)
+		log.Error(0, "Could not approve user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	log.Info("Approved user %s", user.Email)
+	return
+}
+
+// CreateInviteCode creates a new random invite code, zero for maxUses and expiresAt means unlimited
+func (mgr *AuthManager) CreateInviteCode(request *models.InviteCodeRequest, creatorID int64) (*models.InviteCode, error) {
+	if request.MaxUses < 0 || request.ExpiresAt < 0 || (request.ExpiresAt > 0 && request.ExpiresAt <= utils.GetTimestampNow()) {
+		return nil, fcerrors.New(fcerrors.InvalidInviteCodeData)
 	}
 
-	// Now, create a session for the user
+	code, err := utils.SecureRandomString(inviteCodeLength)
+	if err != nil {
+		log.Error(0, "Could not generate invite code: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Internal)
+	}
+
+	inviteCode := &models.InviteCode{
+		Code:      code,
+		CreatedBy: creatorID,
+		MaxUses:   request.MaxUses,
+		ExpiresAt: request.ExpiresAt,
+	}
+	err = mgr.inviteCodeRep.Create(inviteCode)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return inviteCode, nil
+}
+
+// GetAllInviteCodes returns all invite codes including expired and used up ones
+func (mgr *AuthManager) GetAllInviteCodes() ([]*models.InviteCode, error) {
+	inviteCodes, err := mgr.inviteCodeRep.GetAll()
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return inviteCodes, nil
+}
+
+// RevokeInviteCode deletes an invite code so it can't be used anymore
+func (mgr *AuthManager) RevokeInviteCode(inviteCodeID int64) error {
+	err := mgr.inviteCodeRep.Delete(inviteCodeID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.InviteCodeNotFound)
+	}
+	return fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// LoginUser verifies the user's credentials and then returns a new session
+func (mgr *AuthManager) LoginUser(email, password, clientIP string) (*models.Session, error) {
+	user, err := mgr.AuthenticateUser(email, password, clientIP)
+	if err != nil {
+		return nil, err
+	}
 	return mgr.createUserSession(user.ID)
 }
 
-// LoginUser verifies the user's credentials and then returns a new session.
-func (mgr *AuthManager) LoginUser(email string, password string) (*models.Session, error) {
+// AuthenticateUser verifies the user's credentials and returns the user without creating a session, e.g. for the SFTP server.
+// Failed logins are counted per account and per client IP, exceeding the configured thresholds locks out further attempts.
+// If LDAP is configured, the directory is asked first and local users are only checked if the fallback is enabled.
+func (mgr *AuthManager) AuthenticateUser(email, password, clientIP string) (*models.User, error) {
 	// First, do some sanity checks so we can reduce calls to the credentials provider with obviously wrong data.
 	if !utils.ValidateEmail(email) || !utils.ValidatePassword(password) {
 		return nil, fcerrors.New(fcerrors.MissingCredentials)
@@ -134,9 +440,36 @@ func (mgr *AuthManager) LoginUser(email string, password string) (*models.Sessio
 
 	email = utils.ConvertToCleanEmail(email)
 
+	// Accounts are tracked by email regardless of whether they exist, so a lockout does not reveal any users
+	unlock := mgr.lockLogin(email, clientIP)
+	defer unlock()
+	if mgr.GetLoginRetryAfter(email, clientIP) > 0 {
+		log.Warn("Rejected login for %s from %s due to too many failed attempts", email, clientIP)
+		return nil, fcerrors.New(fcerrors.TooManyLoginAttempts)
+	}
+
+	if mgr.ldapRep != nil {
+		user, err := mgr.loginLDAPUser(email, password)
+		if err == nil {
+			mgr.loginAttemptRep.Delete(loginAttemptAccountPrefix + email)
+			return user, nil
+		}
+		fcErr, _ := err.(*fcerrors.FCError)
+		badCredentials := fcErr != nil && fcErr.Code == fcerrors.BadCredentials
+		unavailable := fcErr != nil && fcErr.Code == fcerrors.LDAPUnavailable
+		if !mgr.ldapLocalFallback || !(badCredentials || unavailable) {
+			if badCredentials {
+				mgr.registerFailedLogin(email, clientIP)
+			}
+			return nil, err
+		}
+	}
+
 	user, err := mgr.userRep.GetByEmail(email)
-	if repository.IsRecordNotFoundError(err) {
-		log.Warn("User not found by email %s", email)
+	// Users of external providers have no local password and thus can't login locally
+	if repository.IsRecordNotFoundError(err) || (err == nil && user.AuthProvider != "") {
+		log.Warn("Local user not found by email %s", email)
+		mgr.registerFailedLogin(email, clientIP)
 		// we intentionally don't tell the user whether the error was due to bad credentials or the user being nonexistant
 		return nil, fcerrors.New(fcerrors.BadCredentials)
 	} else if err != nil {
@@ -144,16 +477,598 @@ func (mgr *AuthManager) LoginUser(email string, password string) (*models.Sessio
 		return nil, fcerrors.Wrap(err, fcerrors.Database)
 	}
 
-	valid, err := crypt.ValidateScryptPassword(password, user.Password)
+	valid, needsRehash, err := crypt.ValidatePassword(password, user.Password)
 	if err != nil {
 		log.Error(0, "Password verification failed for user %s: %v", user.Email, err)
 		return nil, fcerrors.Wrap(err, fcerrors.HashingFailed)
 	}
 	if valid {
-		return mgr.createUserSession(user.ID)
+		if user.PendingApproval {
+			log.Warn("Rejected login of user %s awaiting approval", email)
+			return nil, fcerrors.New(fcerrors.UserPendingApproval)
+		}
+		if user.Disabled {
+			log.Warn("Rejected login of disabled user %s", email)
+			return nil, fcerrors.New(fcerrors.UserDisabled)
+		}
+		if needsRehash {
+			mgr.rehashPassword(user, password)
+		}
+		mgr.loginAttemptRep.Delete(loginAttemptAccountPrefix + email)
+		return user, nil
 	}
 
-	return &models.Session{}, fcerrors.New(fcerrors.BadCredentials)
+	mgr.registerFailedLogin(email, clientIP)
+	return nil, fcerrors.New(fcerrors.BadCredentials)
+}
+
+// loginLDAPUser authenticates the user against the LDAP directory and provisions a local user on the first login
+func (mgr *AuthManager) loginLDAPUser(email, password string) (*models.User, error) {
+	ldapUser, err := mgr.ldapRep.Authenticate(email, password)
+	if err == repository.ErrLDAPInvalidCredentials {
+		log.Warn("LDAP authentication failed for %s", email)
+		return nil, fcerrors.New(fcerrors.BadCredentials)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.LDAPUnavailable)
+	}
+
+	return mgr.provisionExternalUser(ldapUser, AuthProviderLDAP, mgr.ldapRep.HasAdminGroup())
+}
+
+// GetOIDCAuthorizationURL starts a login with OpenID Connect and returns the URL of the provider the user has to be sent to
+func (mgr *AuthManager) GetOIDCAuthorizationURL() (string, error) {
+	if mgr.oidcRep == nil {
+		return "", fcerrors.New(fcerrors.OIDCDisabled)
+	}
+
+	state, errState := utils.SecureRandomString(oidcStateLength)
+	nonce, errNonce := utils.SecureRandomString(oidcStateLength)
+	codeVerifier, errVerifier := utils.SecureRandomString(oidcVerifierLength)
+	if errState != nil || errNonce != nil || errVerifier != nil {
+		log.Error(0, "Could not generate secrets for OIDC login")
+		return "", fcerrors.New(fcerrors.Internal)
+	}
+
+	authURL, err := mgr.oidcRep.AuthCodeURL(state, nonce, codeVerifier)
+	if err != nil {
+		return "", fcerrors.Wrap(err, fcerrors.OIDCUnavailable)
+	}
+
+	mgr.oidcMutex.Lock()
+	defer mgr.oidcMutex.Unlock()
+	now := utils.GetTimestampNow()
+	for pendingState, request := range mgr.oidcRequests {
+		if request.expiresAt < now {
+			delete(mgr.oidcRequests, pendingState)
+		}
+	}
+	mgr.oidcRequests[state] = &oidcAuthRequest{nonce: nonce, codeVerifier: codeVerifier, expiresAt: now + oidcRequestExpiry}
+
+	return authURL, nil
+}
+
+// LoginOIDCUser finishes a login with OpenID Connect by redeeming the authorization code for the given state.
+// The user is provisioned on the first login and a new session is returned.
+func (mgr *AuthManager) LoginOIDCUser(code, state string) (*models.Session, error) {
+	if mgr.oidcRep == nil {
+		return nil, fcerrors.New(fcerrors.OIDCDisabled)
+	}
+
+	// Every state can only be used once
+	mgr.oidcMutex.Lock()
+	request, ok := mgr.oidcRequests[state]
+	delete(mgr.oidcRequests, state)
+	mgr.oidcMutex.Unlock()
+	if !ok || request.expiresAt < utils.GetTimestampNow() {
+		log.Warn("Rejected OIDC login with unknown or expired state")
+		return nil, fcerrors.New(fcerrors.OIDCLoginFailed)
+	}
+
+	oidcUser, err := mgr.oidcRep.Exchange(code, request.codeVerifier, request.nonce)
+	if err == repository.ErrOIDCInvalidToken || err == repository.ErrOIDCUnverifiedEmail {
+		return nil, fcerrors.Wrap(err, fcerrors.OIDCLoginFailed)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.OIDCUnavailable)
+	}
+
+	user, err := mgr.provisionExternalUser(oidcUser, AuthProviderOIDC, mgr.oidcRep.HasAdminClaim())
+	if err != nil {
+		return nil, err
+	}
+	return mgr.createUserSession(user.ID)
+}
+
+// provisionExternalUser creates or updates the local user for an user authenticated by an external provider.
+// Users are found by their subject at the provider if it has one, otherwise existing users with the same email are linked to the provider.
+// The admin status is only taken over if syncAdmin is set.
+func (mgr *AuthManager) provisionExternalUser(extUser *models.User, provider string, syncAdmin bool) (user *models.User, err error) {
+	email := utils.ConvertToCleanEmail(extUser.Email)
+	if extUser.AuthSubject != "" {
+		user, err = mgr.userRep.GetByAuthSubject(provider, extUser.AuthSubject)
+	}
+	if extUser.AuthSubject == "" || repository.IsRecordNotFoundError(err) {
+		user, err = mgr.userRep.GetByEmail(email)
+		// Another account of the provider using the same email must not take over the user
+		if err == nil && user.AuthProvider == provider && user.AuthSubject != "" && user.AuthSubject != extUser.AuthSubject {
+			log.Warn("Rejected %s user %s as the email is already linked to another subject", provider, email)
+			return nil, fcerrors.New(fcerrors.UserExists)
+		}
+	}
+	if err != nil && !repository.IsRecordNotFoundError(err) {
+		log.Error(0, "Could not get %s user %s: %v", provider, email, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if err != nil {
+		user = &models.User{
+			Email:        email,
+			FirstName:    extUser.FirstName,
+			LastName:     extUser.LastName,
+			IsAdmin:      syncAdmin && extUser.IsAdmin,
+			AuthProvider: provider,
+			AuthSubject:  extUser.AuthSubject,
+		}
+		err = mgr.userRep.Create(user)
+		if err != nil {
+			log.Error(0, "Creating %s user %s failed: %v", provider, email, err)
+			return nil, fcerrors.Wrap(err, fcerrors.Database)
+		}
+		log.Info("Provisioned new %s user %s", provider, email)
+
+		err = GetFileManager().ScanUserFolderForChanges(user)
+		if err != nil {
+			log.Error(0, "Failed to scan folder for new user: %v", err)
+			return nil, fcerrors.Wrap(err, fcerrors.Filesystem)
+		}
+		return user, nil
+	}
+
+	if user.AuthProvider != provider {
+		log.Info("Linking user %s to %s", email, provider)
+		user.AuthProvider = provider
+		user.Password = ""
+	}
+	user.AuthSubject = extUser.AuthSubject
+	if user.PendingApproval {
+		log.Warn("Rejected %s login of user %s awaiting approval", provider, email)
+		return nil, fcerrors.New(fcerrors.UserPendingApproval)
+	}
+	if user.Disabled {
+		log.Warn("Rejected %s login of disabled user %s", provider, email)
+		return nil, fcerrors.New(fcerrors.UserDisabled)
+	}
+	if extUser.FirstName != "" {
+		user.FirstName = extUser.FirstName
+	}
+	if extUser.LastName != "" {
+		user.LastName = extUser.LastName
+	}
+	if syncAdmin {
+		user.IsAdmin = extUser.IsAdmin
+	}
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Error(0, "Updating %s user %s failed: %v", provider, email, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return user, nil
+}
+
+// rehashPassword upgrades the stored password hash of an user to the current algorithm and parameters.
+// Failures are not fatal as the old hash stays valid.
+func (mgr *AuthManager) rehashPassword(user *models.User, password string) {
+	hash, err := crypt.HashPassword(password)
+	if err != nil {
+		log.Warn("Could not rehash password for user %s: %v", user.Email, err)
+		return
+	}
+
+	user.Password = hash
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Warn("Could not store rehashed password for user %s: %v", user.Email, err)
+		return
+	}
+	log.Trace("Rehashed outdated password for user %s", user.Email)
+}
+
+// CreateAppPassword creates a new random password for the user which can be used by clients instead of his own password.
+// The password is only contained in the returned app password, afterwards only its hash is known.
+func (mgr *AuthManager) CreateAppPassword(userID int64, name string) (*models.AppPassword, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fcerrors.New(fcerrors.InvalidAppPasswordData)
+	}
+
+	password, err := utils.SecureRandomString(appPasswordLength)
+	if err != nil {
+		log.Error(0, "Could not generate app password: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Internal)
+	}
+
+	appPassword := &models.AppPassword{
+		UserID:   userID,
+		Name:     name,
+		Password: hashAppPassword(password),
+	}
+	err = mgr.appPasswordRep.Create(appPassword)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	appPassword.Password = password
+	return appPassword, nil
+}
+
+// GetAppPasswords returns all app passwords of the user without their password hashes
+func (mgr *AuthManager) GetAppPasswords(userID int64) ([]*models.AppPassword, error) {
+	appPasswords, err := mgr.appPasswordRep.GetAllForUser(userID)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	for _, appPassword := range appPasswords {
+		appPassword.Password = ""
+	}
+	return appPasswords, nil
+}
+
+// DeleteAppPassword revokes an app password of the user
+func (mgr *AuthManager) DeleteAppPassword(userID, appPasswordID int64) error {
+	err := mgr.appPasswordRep.Delete(appPasswordID, userID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.AppPasswordNotFound)
+	}
+	return fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// LoginAppPassword verifies the email and one of the app passwords of the user and returns the user.
+// Failed attempts count towards the same lockout as failed logins with the password of the user.
+// App passwords also work for users of external providers as they are independent of the password of the user.
+func (mgr *AuthManager) LoginAppPassword(email, password, clientIP string) (*models.User, error) {
+	if !utils.ValidateEmail(email) || password == "" {
+		return nil, fcerrors.New(fcerrors.MissingCredentials)
+	}
+
+	email = utils.ConvertToCleanEmail(email)
+	unlock := mgr.lockLogin(email, clientIP)
+	defer unlock()
+	if mgr.GetLoginRetryAfter(email, clientIP) > 0 {
+		log.Warn("Rejected app password login for %s from %s due to too many failed attempts", email, clientIP)
+		return nil, fcerrors.New(fcerrors.TooManyLoginAttempts)
+	}
+
+	user, err := mgr.userRep.GetByEmail(email)
+	if err != nil && !repository.IsRecordNotFoundError(err) {
+		log.Error(0, "Could not get user via email %s: %v", email, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	var appPassword *models.AppPassword
+	if err == nil {
+		appPassword, err = mgr.appPasswordRep.GetForUserByPassword(user.ID, hashAppPassword(password))
+	}
+	if repository.IsRecordNotFoundError(err) {
+		mgr.registerFailedLogin(email, clientIP)
+		return nil, fcerrors.New(fcerrors.BadCredentials)
+	} else if err != nil {
+		log.Error(0, "Could not get app password of user %s: %v", email, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if user.PendingApproval {
+		return nil, fcerrors.New(fcerrors.UserPendingApproval)
+	}
+	if user.Disabled {
+		return nil, fcerrors.New(fcerrors.UserDisabled)
+	}
+
+	mgr.appPasswordRep.UpdateLastUsed(appPassword.ID)
+	mgr.loginAttemptRep.Delete(loginAttemptAccountPrefix + email)
+	return user, nil
+}
+
+// hashAppPassword hashes an app password for storing and looking it up.
+// App passwords are long random strings, so unlike passwords of users they need no slow salted hash
+// and can be checked on every request of clients sending them each time.
+func hashAppPassword(password string) string {
+	hash := sha256.Sum256([]byte(password))
+	return hex.EncodeToString(hash[:])
+}
+
+// AddSSHKey stores a public key in the format of authorized_keys files the user can log in to the SFTP server with.
+// Without a name the comment of the key is used.
+func (mgr *AuthManager) AddSSHKey(userID int64, name, authorizedKey string) (*models.SSHKey, error) {
+	publicKey, comment, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.InvalidSSHKey)
+	}
+	fingerprint := ssh.FingerprintSHA256(publicKey)
+	_, err = mgr.sshKeyRep.GetForUserByFingerprint(userID, fingerprint)
+	if err == nil {
+		return nil, fcerrors.New(fcerrors.SSHKeyExists)
+	} else if !repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = comment
+	}
+	sshKey := &models.SSHKey{
+		UserID:      userID,
+		Name:        name,
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint: fingerprint,
+	}
+	err = mgr.sshKeyRep.Create(sshKey)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return sshKey, nil
+}
+
+// GetSSHKeys returns all SSH public keys of the user
+func (mgr *AuthManager) GetSSHKeys(userID int64) ([]*models.SSHKey, error) {
+	sshKeys, err := mgr.sshKeyRep.GetAllForUser(userID)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return sshKeys, nil
+}
+
+// DeleteSSHKey removes an SSH public key of the user
+func (mgr *AuthManager) DeleteSSHKey(userID, sshKeyID int64) error {
+	err := mgr.sshKeyRep.Delete(sshKeyID, userID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.SSHKeyNotFound)
+	}
+	return fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// LoginSSHKey returns the user with the email and his matching key if the public key is one of his keys.
+// The caller has to verify that the client owns the private key and then mark the key as used.
+// Clients try all of their keys one after another, so unknown keys don't count towards the lockout,
+// but locked out accounts are rejected nevertheless.
+func (mgr *AuthManager) LoginSSHKey(email string, publicKey ssh.PublicKey, clientIP string) (*models.User, *models.SSHKey, error) {
+	if !utils.ValidateEmail(email) || publicKey == nil {
+		return nil, nil, fcerrors.New(fcerrors.MissingCredentials)
+	}
+
+	email = utils.ConvertToCleanEmail(email)
+	if mgr.GetLoginRetryAfter(email, clientIP) > 0 {
+		log.Warn("Rejected ssh key login for %s from %s due to too many failed attempts", email, clientIP)
+		return nil, nil, fcerrors.New(fcerrors.TooManyLoginAttempts)
+	}
+
+	user, err := mgr.userRep.GetByEmail(email)
+	if err != nil && !repository.IsRecordNotFoundError(err) {
+		log.Error(0, "Could not get user via email %s: %v", email, err)
+		return nil, nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	var sshKey *models.SSHKey
+	if err == nil {
+		sshKey, err = mgr.sshKeyRep.GetForUserByFingerprint(user.ID, ssh.FingerprintSHA256(publicKey))
+	}
+	if repository.IsRecordNotFoundError(err) {
+		return nil, nil, fcerrors.New(fcerrors.BadCredentials)
+	} else if err != nil {
+		log.Error(0, "Could not get ssh key of user %s: %v", email, err)
+		return nil, nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if user.PendingApproval {
+		return nil, nil, fcerrors.New(fcerrors.UserPendingApproval)
+	}
+	if user.Disabled {
+		return nil, nil, fcerrors.New(fcerrors.UserDisabled)
+	}
+
+	return user, sshKey, nil
+}
+
+// MarkSSHKeyUsed sets the time the ssh key has been used last to now after the client proved to own it
+func (mgr *AuthManager) MarkSSHKeyUsed(sshKeyID int64) {
+	err := mgr.sshKeyRep.UpdateLastUsed(sshKeyID)
+	if err != nil {
+		log.Error(0, "Could not update last usage of ssh key %d: %v", sshKeyID, err)
+	}
+}
+
+// CreateAccessKey creates a new key S3 clients can use to access the files of the user.
+// The secret is derived from the access key ID and only contained in the returned access key.
+func (mgr *AuthManager) CreateAccessKey(userID int64, name string) (*models.AccessKey, error) {
+	if mgr.accessKeyMasterKey == nil {
+		return nil, fcerrors.New(fcerrors.AccessKeysDisabled)
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, fcerrors.New(fcerrors.InvalidAccessKeyData)
+	}
+
+	keyID, err := utils.SecureRandomString(accessKeyIDLength)
+	if err != nil {
+		log.Error(0, "Could not generate access key: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Internal)
+	}
+
+	accessKey := &models.AccessKey{
+		UserID:      userID,
+		Name:        name,
+		AccessKeyID: accessKeyIDPrefix + strings.ToUpper(keyID),
+	}
+	err = mgr.accessKeyRep.Create(accessKey)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	accessKey.SecretAccessKey = mgr.deriveSecretAccessKey(accessKey.AccessKeyID)
+	return accessKey, nil
+}
+
+// GetAccessKeys returns all access keys of the user without their secrets
+func (mgr *AuthManager) GetAccessKeys(userID int64) ([]*models.AccessKey, error) {
+	accessKeys, err := mgr.accessKeyRep.GetAllForUser(userID)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return accessKeys, nil
+}
+
+// DeleteAccessKey revokes an access key of the user
+func (mgr *AuthManager) DeleteAccessKey(userID, accessKeyID int64) error {
+	err := mgr.accessKeyRep.Delete(accessKeyID, userID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.AccessKeyNotFound)
+	}
+	return fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// LoginAccessKey returns the owner of the access key if verify accepts the secret of the key.
+// S3 clients sign every request instead of sending the secret, so verify checks the signature of the request with it.
+// Failed verifications count towards the same lockout as failed logins with the password of the owner.
+func (mgr *AuthManager) LoginAccessKey(accessKeyID string, verify func(secretAccessKey string) error, clientIP string) (*models.User, error) {
+	if accessKeyID == "" {
+		return nil, fcerrors.New(fcerrors.MissingCredentials)
+	}
+	if mgr.accessKeyMasterKey == nil {
+		return nil, fcerrors.New(fcerrors.AccessKeysDisabled)
+	}
+
+	accessKey, err := mgr.accessKeyRep.GetByAccessKeyID(accessKeyID)
+	if repository.IsRecordNotFoundError(err) {
+		if clientIP != "" {
+			mgr.registerFailedAttempt(loginAttemptIPPrefix+clientIP, mgr.lockoutPolicy.IPThreshold)
+		}
+		return nil, fcerrors.New(fcerrors.BadCredentials)
+	} else if err != nil {
+		log.Error(0, "Could not get access key %s: %v", accessKeyID, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	user, err := mgr.userRep.GetByID(accessKey.UserID)
+	if err != nil {
+		log.Error(0, "Could not get owner %d of access key %s: %v", accessKey.UserID, accessKeyID, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	unlock := mgr.lockLogin(user.Email, clientIP)
+	defer unlock()
+	if mgr.GetLoginRetryAfter(user.Email, clientIP) > 0 {
+		log.Warn("Rejected access key login for %s from %s due to too many failed attempts", user.Email, clientIP)
+		return nil, fcerrors.New(fcerrors.TooManyLoginAttempts)
+	}
+	err = verify(mgr.deriveSecretAccessKey(accessKeyID))
+	if err != nil {
+		mgr.registerFailedLogin(user.Email, clientIP)
+		return nil, fcerrors.Wrap(err, fcerrors.BadCredentials)
+	}
+
+	if user.PendingApproval {
+		return nil, fcerrors.New(fcerrors.UserPendingApproval)
+	}
+	if user.Disabled {
+		return nil, fcerrors.New(fcerrors.UserDisabled)
+	}
+
+	mgr.accessKeyRep.UpdateLastUsed(accessKey.ID)
+	mgr.loginAttemptRep.Delete(loginAttemptAccountPrefix + user.Email)
+	return user, nil
+}
+
+// deriveSecretAccessKey returns the secret of an access key, which is a MAC of its ID under the master key.
+// This way the secrets need not be stored while the server can still compute them to verify signatures.
+func (mgr *AuthManager) deriveSecretAccessKey(accessKeyID string) string {
+	mac := hmac.New(sha256.New, mgr.accessKeyMasterKey)
+	mac.Write([]byte(accessKeyID))
+	return hex.EncodeToString(mac.Sum(nil)[:20])
+}
+
+// GetLoginRetryAfter returns the seconds until a login for the given email from the given client IP will be accepted again
+func (mgr *AuthManager) GetLoginRetryAfter(email, clientIP string) (retryAfter int64) {
+	now := utils.GetTimestampNow()
+	for _, subject := range loginAttemptSubjects(email, clientIP) {
+		attempt, err := mgr.loginAttemptRep.GetBySubject(subject)
+		if err != nil {
+			if !repository.IsRecordNotFoundError(err) {
+				log.Error(0, "Could not get login attempts for %s: %v", subject, err)
+			}
+			continue
+		}
+		if attempt.LockedUntil-now > retryAfter {
+			retryAfter = attempt.LockedUntil - now
+		}
+	}
+	return
+}
+
+// lockLogin serializes the logins for the given email and from the given client IP until the returned function is called.
+// Otherwise concurrent logins could all pass the lockout check and try more passwords than the threshold allows.
+func (mgr *AuthManager) lockLogin(email, clientIP string) (unlock func()) {
+	return mgr.loginLocks.lock(loginAttemptSubjects(email, clientIP))
+}
+
+// loginAttemptSubjects returns the subjects failed logins for the given email from the given client IP are counted for
+func loginAttemptSubjects(email, clientIP string) []string {
+	subjects := []string{loginAttemptAccountPrefix + utils.ConvertToCleanEmail(email)}
+	if clientIP != "" {
+		subjects = append(subjects, loginAttemptIPPrefix+clientIP)
+	}
+	return subjects
+}
+
+// UnlockUser removes a lockout caused by failed logins from an user
+func (mgr *AuthManager) UnlockUser(userID int64) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+
+	err = mgr.loginAttemptRep.Delete(loginAttemptAccountPrefix + user.Email)
+	if err != nil {
+		log.Error(0, "Could not unlock user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return
+}
+
+func (mgr *AuthManager) registerFailedLogin(email, clientIP string) {
+	mgr.registerFailedAttempt(loginAttemptAccountPrefix+email, mgr.lockoutPolicy.AccountThreshold)
+	if clientIP != "" {
+		mgr.registerFailedAttempt(loginAttemptIPPrefix+clientIP, mgr.lockoutPolicy.IPThreshold)
+	}
+}
+
+// registerFailedAttempt counts a failed login for the subject and locks it once the threshold is reached.
+// Every further failure doubles the lockout duration up to the configured maximum.
+func (mgr *AuthManager) registerFailedAttempt(subject string, threshold int) {
+	if threshold <= 0 {
+		return
+	}
+
+	// Start counting from scratch if the last failure is long gone
+	now := utils.GetTimestampNow()
+	attempt, err := mgr.loginAttemptRep.AddFailure(subject, now, now-int64(mgr.lockoutPolicy.MaxDuration))
+	if err != nil {
+		return
+	}
+
+	if attempt.Failures >= threshold {
+		lockedUntil := now + mgr.lockoutDuration(attempt.Failures-threshold)
+		log.Warn("Locked out %s for %d seconds after %d failed logins", subject, lockedUntil-now, attempt.Failures)
+		mgr.loginAttemptRep.SetLockedUntil(subject, lockedUntil)
+	}
+}
+
+// lockoutDuration returns the exponential backoff in seconds for the given amount of failures above the threshold
+func (mgr *AuthManager) lockoutDuration(exceeded int) int64 {
+	maxDuration := int64(mgr.lockoutPolicy.MaxDuration)
+	duration := int64(mgr.lockoutPolicy.BaseDuration)
+	for i := 0; i < exceeded && duration < maxDuration; i++ {
+		duration *= 2
+	}
+	if duration > maxDuration {
+		duration = maxDuration
+	}
+	return duration
 }
 
 // DeleteUser deletes a user from db and his files depending on the settings
@@ -164,12 +1079,27 @@ func (mgr *AuthManager) DeleteUser(userID int64) (err error) {
 		return
 	}
 
+	// Mounted storages are unmounted regardless of the retention, their files do not belong to the user
+	if mountMgr := GetMountManager(); mountMgr != nil {
+		mountErr := mountMgr.RemoveUser(userID)
+		if mountErr != nil { // Ignore errors regarding deleting mounts as they are not mounted anymore
+			log.Warn("Could not delete mounts of user %d: %v", userID, mountErr)
+		}
+	}
+
 	if !user.RetainFilesAfterDeletion {
 		err = GetFileManager().DeleteUserFiles(user)
 		if err != nil {
 			log.Error(0, "Failed to delete files for to be deleted user: %v", err)
 			return
 		}
+
+		if encryptionMgr := GetEncryptionManager(); encryptionMgr != nil {
+			keyErr := encryptionMgr.DeleteUserKey(userID)
+			if keyErr != nil { // Ignore errors regarding deleting the key as the files it encrypted are deleted
+				log.Warn("Could not delete encryption key of user %d: %v", userID, keyErr)
+			}
+		}
 	}
 
 	err = mgr.userRep.Delete(userID)
@@ -188,9 +1118,100 @@ func (mgr *AuthManager) DeleteUser(userID int64) (err error) {
 		log.Warn("Could not delete all sessions for user %d: %v", userID, err)
 	}
 
+	appPasswordErr := mgr.appPasswordRep.DeleteAllForUser(userID)
+	if appPasswordErr != nil { // Ignore errors regarding deleting app passwords as the user cannot login anymore
+		log.Warn("Could not delete app passwords of user %d: %v", userID, appPasswordErr)
+	}
+	sshKeyErr := mgr.sshKeyRep.DeleteAllForUser(userID)
+	if sshKeyErr != nil { // Ignore errors regarding deleting ssh keys as the user cannot login anymore
+		log.Warn("Could not delete ssh keys of user %d: %v", userID, sshKeyErr)
+	}
+	accessKeyErr := mgr.accessKeyRep.DeleteAllForUser(userID)
+	if accessKeyErr != nil { // Ignore errors regarding deleting access keys as the user cannot login anymore
+		log.Warn("Could not delete access keys of user %d: %v", userID, accessKeyErr)
+	}
+
+	if exportMgr := GetExportManager(); exportMgr != nil {
+		exportErr := exportMgr.DeleteDataExportsForUser(userID)
+		if exportErr != nil { // Ignore errors regarding deleting data exports as they cannot be accessed anymore
+			log.Warn("Could not delete data exports of user %d: %v", userID, exportErr)
+		}
+	}
+
+	if policyMgr := GetPolicyManager(); policyMgr != nil {
+		policyErr := policyMgr.DeleteBindingsForUser(userID)
+		if policyErr != nil { // Ignore errors regarding deleting roles and memberships as they cannot be used anymore
+			log.Warn("Could not delete roles and group memberships of user %d: %v", userID, policyErr)
+		}
+	}
+
+	if activityMgr := GetActivityManager(); activityMgr != nil && !user.RetainFilesAfterDeletion {
+		activityErr := activityMgr.DeleteActivitiesForOwner(userID)
+		if activityErr != nil { // Ignore errors regarding deleting activities as the files are gone anyway
+			log.Warn("Could not delete activities in files of user %d: %v", userID, activityErr)
+		}
+	}
+
+	if user.HasAvatar {
+		avatarErr := GetFileManager().DeleteAvatarForUser(userID)
+		if avatarErr != nil { // Ignore errors regarding deleting the avatar as it cannot be accessed anymore
+			log.Warn("Could not delete avatar of user %d: %v", userID, avatarErr)
+		}
+	}
+
+	return
+}
+
+// TransferUserFiles moves all files of a deleted or disabled user into a new folder of the recipient, who becomes their owner.
+// Returns the folder containing the transferred files.
+func (mgr *AuthManager) TransferUserFiles(fromUserID, toUserID int64) (folderInfo *models.FileInfo, err error) {
+	folderName := fmt.Sprintf("Files of user %d", fromUserID)
+	fromUser, err := mgr.userRep.GetByID(fromUserID)
+	if err == nil {
+		if !fromUser.Disabled {
+			return nil, fcerrors.New(fcerrors.TransferFromActiveUser)
+		}
+		if utils.ValidatePath(fromUser.Email) {
+			folderName = "Files of " + fromUser.Email
+		}
+	} else if !repository.IsRecordNotFoundError(err) {
+		log.Error(0, "Could not get user %d to transfer files from: %v", fromUserID, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	toUser, err := mgr.GetUserByID(toUserID)
+	if err != nil {
+		return
+	}
+	if toUserID == fromUserID || toUser.Disabled || toUser.PendingApproval {
+		return nil, fcerrors.New(fcerrors.InvalidTransferRecipient)
+	}
+
+	folderInfo, err = GetFileManager().TransferUserFiles(fromUserID, toUser, folderName)
+	if err == ErrFileNotFound {
+		return nil, fcerrors.New(fcerrors.UserFilesNotFound)
+	} else if err != nil {
+		log.Error(0, "Could not transfer files of user %d to %s: %v", fromUserID, toUser.Email, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+
+	log.Info("Transferred files of user %d to %s", fromUserID, toUser.Email)
 	return
 }
 
+// DeleteCurrentUser deletes the user after he confirmed whether his files are retained.
+// retainFiles has to match the setting of the user, so the user cannot delete his account assuming the wrong outcome for his files.
+func (mgr *AuthManager) DeleteCurrentUser(userID int64, retainFiles bool) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+	if user.RetainFilesAfterDeletion != retainFiles {
+		return fcerrors.New(fcerrors.RetentionNotConfirmed)
+	}
+	return mgr.DeleteUser(userID)
+}
+
 // GetAllUsers returns all existing users with masked out password
 func (mgr *AuthManager) GetAllUsers() ([]*models.User, error) {
 	users, err := mgr.userRep.GetAll()
@@ -201,6 +1222,144 @@ func (mgr *AuthManager) GetAllUsers() ([]*models.User, error) {
 	return users, nil
 }
 
+// GetUserList returns a page of users whose name or email contain the search term together with the total count of matching users.
+// Users have their storage used filled and their password masked out.
+func (mgr *AuthManager) GetUserList(search, sortBy string, descending bool, page, perPage int64) (users []*models.User, total int64, err error) {
+	sortColumn, ok := userListSortColumns[sortBy]
+	if !ok || page < 1 || perPage < 1 {
+		return nil, 0, fcerrors.New(fcerrors.InvalidUserListQuery)
+	}
+	if perPage > userListMaxPerPage {
+		perPage = userListMaxPerPage
+	}
+
+	users, total, err = mgr.userRep.GetPage(search, sortColumn, descending, (page-1)*perPage, perPage)
+	if err != nil {
+		return nil, 0, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	userIDs := make([]int64, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	storageUsed, err := GetFileManager().GetStorageUsedByUsers(userIDs)
+	if err != nil {
+		return nil, 0, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	for _, user := range users {
+		user.StorageUsed = storageUsed[user.ID]
+		user.Password = ""
+	}
+	return
+}
+
+// DisableUser prevents an user from logging in and ends all his sessions, adminID is the admin disabling the user
+func (mgr *AuthManager) DisableUser(userID, adminID int64) (err error) {
+	if userID == adminID {
+		return fcerrors.New(fcerrors.DisableSelf)
+	}
+	return mgr.setUserDisabled(userID, true)
+}
+
+// EnableUser allows a disabled user to login again
+func (mgr *AuthManager) EnableUser(userID int64) (err error) {
+	return mgr.setUserDisabled(userID, false)
+}
+
+func (mgr *AuthManager) setUserDisabled(userID int64, disabled bool) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+
+	user.Disabled = disabled
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Error(0, "Could not set disabled status of user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if disabled {
+		err = mgr.sessionRep.DeleteAllForUser(userID)
+		if err != nil {
+			log.Error(0, "Could not delete all sessions for disabled user %d: %v", userID, err)
+			return fcerrors.Wrap(err, fcerrors.Database)
+		}
+	}
+	log.Info("Set disabled status of user %s to %v", user.Email, disabled)
+	return
+}
+
+// SetUserAvatar decodes the image of reader and stores it as avatar of the user
+func (mgr *AuthManager) SetUserAvatar(userID int64, reader io.Reader) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+
+	err = GetFileManager().SaveAvatarForUser(userID, reader)
+	if err == utils.ErrUnsupportedImage || err == utils.ErrImageTooLarge {
+		return fcerrors.NewMsg(fcerrors.InvalidAvatar, err.Error())
+	} else if err != nil {
+		log.Error(0, "Could not save avatar of user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+
+	user.HasAvatar = true
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Error(0, "Could not set avatar flag of user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return
+}
+
+// GetUserAvatar returns the avatar of the user with the given edge length in pixels, the caller has to close it
+func (mgr *AuthManager) GetUserAvatar(userID int64, size int) (avatar repository.StorageFile, err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+	if !user.HasAvatar {
+		return nil, fcerrors.New(fcerrors.AvatarNotFound)
+	}
+
+	avatar, err = GetFileManager().GetAvatarForUser(userID, size)
+	if err == ErrInvalidAvatarSize {
+		return nil, fcerrors.New(fcerrors.InvalidAvatarSize)
+	} else if os.IsNotExist(err) {
+		return nil, fcerrors.New(fcerrors.AvatarNotFound)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+	return
+}
+
+// DeleteUserAvatar removes the avatar of the user
+func (mgr *AuthManager) DeleteUserAvatar(userID int64) (err error) {
+	user, err := mgr.GetUserByID(userID)
+	if err != nil {
+		return
+	}
+	if !user.HasAvatar {
+		return fcerrors.New(fcerrors.AvatarNotFound)
+	}
+
+	err = GetFileManager().DeleteAvatarForUser(userID)
+	if err != nil {
+		log.Error(0, "Could not delete avatar of user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+
+	user.HasAvatar = false
+	err = mgr.userRep.Update(user)
+	if err != nil {
+		log.Error(0, "Could not reset avatar flag of user %d: %v", userID, err)
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return
+}
+
 // GetUserByID returns a user by ID
 func (mgr *AuthManager) GetUserByID(userID int64) (*models.User, error) {
 	user, err := mgr.userRep.GetByID(userID)
@@ -252,17 +1411,66 @@ func (mgr *AuthManager) createUserSession(userID int64) (*models.Session, error)
 	return session, nil
 }
 
-// ValidateSession checks if the session is valid.
-func (mgr *AuthManager) ValidateSession(sess *models.Session) (valid bool) {
+// ImpersonateUser creates a session in which the impersonator acts as the target user to see what he sees.
+// The session expires after the impersonation expiry and is read-only unless allowWrite is set.
+// Checking whether the impersonator may act as the target is up to the caller.
+func (mgr *AuthManager) ImpersonateUser(impersonatorID, targetID int64, allowWrite bool, clientIP string) (*models.Session, error) {
+	if impersonatorID == targetID {
+		return nil, fcerrors.New(fcerrors.InvalidImpersonationTarget)
+	}
+	target, err := mgr.GetUserByID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Disabled || target.PendingApproval {
+		return nil, fcerrors.New(fcerrors.InvalidImpersonationTarget)
+	}
+
+	token, err := utils.SecureRandomString(sessionTokenLength)
+	if err != nil {
+		log.Error(0, "Could not generate impersonation token: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Internal)
+	}
+
+	// The last session of the target is not updated as he did not login himself
+	session := &models.Session{
+		UserID:         targetID,
+		Token:          token,
+		ExpiresAt:      time.Now().UTC().Add(time.Minute * time.Duration(mgr.impersonationExpiry)).Unix(),
+		ImpersonatorID: impersonatorID,
+		ReadOnly:       !allowWrite,
+	}
+	err = mgr.sessionRep.Create(session)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	mode := "read-only"
+	if allowWrite {
+		mode = "read-write"
+	}
+	log.Info("User %d started a %s impersonation of user %d", impersonatorID, mode, targetID)
+	recordAudit(AuditImpersonationStart, impersonatorID, targetID, mode, clientIP)
+	return session, nil
+}
+
+// GetValidSession returns the stored session matching the given one, which carries the impersonation flags, if it is valid
+func (mgr *AuthManager) GetValidSession(sess *models.Session) (storedSession *models.Session, valid bool) {
 	storedSession, err := mgr.sessionRep.GetByToken(sess.Token)
 	if err != nil {
 		log.Warn("Could not read session via token, assuming invalid session")
-		return false
+		return nil, false
 	}
 	if storedSession.UserID == sess.UserID && storedSession.ExpiresAt > time.Now().UTC().Unix() {
-		return true
+		return storedSession, true
 	}
-	return false
+	return nil, false
+}
+
+// ValidateSession checks if the session is valid.
+func (mgr *AuthManager) ValidateSession(sess *models.Session) (valid bool) {
+	_, valid = mgr.GetValidSession(sess)
+	return
 }
 
 // DeleteSession removes the session from the session provider
@@ -270,6 +1478,12 @@ func (mgr *AuthManager) DeleteSession(session *models.Session) (err error) {
 	return fcerrors.Wrap(mgr.sessionRep.Delete(session), fcerrors.Database)
 }
 
+// GetSessionsForUser returns all sessions of the user
+func (mgr *AuthManager) GetSessionsForUser(userID int64) ([]*models.Session, error) {
+	sessions, err := mgr.sessionRep.GetAllForUser(userID)
+	return sessions, fcerrors.Wrap(err, fcerrors.Database)
+}
+
 // GetSessionCount return the count of active sessions
 func (mgr *AuthManager) GetSessionCount() (int, error) {
 	count, err := mgr.sessionRep.Count()
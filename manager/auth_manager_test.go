@@ -1,15 +1,30 @@
 package manager
 
 import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
 	"os"
+	"path/filepath"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/freecloudio/server/config"
+	"github.com/freecloudio/server/crypt"
 	"github.com/freecloudio/server/restapi/fcerrors"
 
 	"github.com/freecloudio/server/models"
 
 	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/utils"
+	"golang.org/x/crypto/ssh"
 )
 
 var testAuthSetupFailed = false
@@ -19,50 +34,88 @@ var testAuthUserAdminPW = "12345678"
 var testAuthUserAdmin = &models.User{FirstName: "Admin", LastName: "User", Email: "admin.user@email.com", IsAdmin: true, Password: testAuthUserAdminPW}
 var testAuthUserPW = "87654321"
 var testAuthUser = &models.User{FirstName: "User", LastName: "User", Email: "user.user@email.com", IsAdmin: false, Password: testAuthUserPW}
+var testAuthLockoutPolicy = LockoutPolicy{AccountThreshold: 3, IPThreshold: 5, BaseDuration: 60, MaxDuration: 600}
+var testAuthClientIP = "192.0.2.1"
+var testAuthRegistrationPolicy = RegistrationPolicy{Mode: RegistrationOpen}
+var testAuthConfig = AuthConfig{
+	AccessKeyMasterKey:     testAuthAccessKeyMasterKey,
+	SessionExpiry:          24,
+	SessionCleanupInterval: 1,
+	ImpersonationExpiry:    30,
+	LockoutPolicy:          testAuthLockoutPolicy,
+	RegistrationPolicy:     testAuthRegistrationPolicy,
+}
+var testAuthAccessKeyMasterKey = []byte("0123456789abcdef0123456789abcdef")
 
 func testAuthCleanup(mgr *AuthManager) {
 	if mgr != nil {
 		mgr.Close()
 	}
 	authManager = nil
+	if fileManager != nil {
+		fileManager.storageRep.Close()
+		fileManager = nil
+	}
 	os.Remove(testAuthDBName)
 	os.RemoveAll(testAuthDataFolder)
 	testAuthUserAdmin.Password = testAuthUserAdminPW
 	testAuthUser.Password = testAuthUserPW
 }
 
-func testAuthReq() (sessionRep *repository.SessionRepository, userRep *repository.UserRepository) {
+func testAuthReq() (sessionRep *repository.SessionRepository, userRep *repository.UserRepository, loginAttemptRep *repository.LoginAttemptRepository, inviteCodeRep *repository.InviteCodeRepository, appPasswordRep *repository.AppPasswordRepository, sshKeyRep *repository.SSHKeyRepository, accessKeyRep *repository.AccessKeyRepository) {
 	testAuthCleanup(nil)
 	repository.InitDatabaseConnection("", "", "", "", 0, testAuthDBName)
 	sessionRep, _ = repository.CreateSessionRepository()
 	userRep, _ = repository.CreateUserRepository()
+	loginAttemptRep, _ = repository.CreateLoginAttemptRepository()
+	inviteCodeRep, _ = repository.CreateInviteCodeRepository()
+	appPasswordRep, _ = repository.CreateAppPasswordRepository()
+	sshKeyRep, _ = repository.CreateSSHKeyRepository()
+	accessKeyRep, _ = repository.CreateAccessKeyRepository()
 	return
 }
 
 func testAuthSetup() *AuthManager {
-	sessionRep, userRep := testAuthReq()
-	mgr := CreateAuthManager(sessionRep, userRep, 24, 1)
+	return testAuthSetupWithStorage(nil)
+}
+
+// testAuthSetupWithStorage sets up the managers with the files stored in storageRep or below testAuthDataFolder if it is nil
+func testAuthSetupWithStorage(storageRep repository.StorageRepository) *AuthManager {
+	sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep := testAuthReq()
+	mgr := CreateAuthManager(sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep, testAuthConfig)
 	shareRep, _ := repository.CreateShareEntryRepository()
 	fileInfoRep, _ := repository.CreateFileInfoRepository()
-	fileSystemRep, _ := repository.CreateFileSystemRepository(testAuthDataFolder, ".tmp", 1, 1)
-	CreateFileManager(fileSystemRep, fileInfoRep, shareRep, ".tmp")
+	starRep, _ := repository.CreateStarRepository()
+	if storageRep == nil {
+		storageRep, _ = repository.CreateFileSystemRepository(testAuthDataFolder, ".tmp", 1, 1)
+	}
+	CreateFileManager(storageRep, fileInfoRep, shareRep, starRep, ".tmp")
 	return mgr
 }
 
 func testAuthInsert(mgr *AuthManager) {
-	mgr.CreateUser(testAuthUserAdmin)
-	mgr.CreateUser(testAuthUser)
+	mgr.CreateUser(testAuthUserAdmin, "")
+	mgr.CreateUser(testAuthUser, "")
 }
 
 func TestCreateAuthManager(t *testing.T) {
-	sessionRep, userRep := testAuthReq()
+	sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep := testAuthReq()
 
-	mgr := CreateAuthManager(sessionRep, userRep, 24, 1)
+	mgr := CreateAuthManager(sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep, testAuthConfig)
 	expMgr := &AuthManager{
 		sessionRep:             sessionRep,
 		userRep:                userRep,
+		loginAttemptRep:        loginAttemptRep,
+		inviteCodeRep:          inviteCodeRep,
+		appPasswordRep:         appPasswordRep,
+		sshKeyRep:              sshKeyRep,
+		accessKeyRep:           accessKeyRep,
+		accessKeyMasterKey:     testAuthAccessKeyMasterKey,
 		sessionExpiry:          24,
 		sessionCleanupInterval: 1,
+		impersonationExpiry:    30,
+		lockoutPolicy:          testAuthLockoutPolicy,
+		registrationPolicy:     testAuthRegistrationPolicy,
 	}
 	mgr.Close()
 	mgr.done = nil
@@ -82,9 +135,9 @@ func TestGetAuthManager(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
 	}
-	sessionRep, userRep := testAuthReq()
+	sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep := testAuthReq()
 
-	mgr := CreateAuthManager(sessionRep, userRep, 24, 1)
+	mgr := CreateAuthManager(sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep, testAuthConfig)
 	mgrGet := GetAuthManager()
 
 	if !reflect.DeepEqual(mgr, mgrGet) {
@@ -101,14 +154,14 @@ func TestCreateUser(t *testing.T) {
 	mgr := testAuthSetup()
 	defer testAuthCleanup(mgr)
 
-	sess, err := mgr.CreateUser(testAuthUserAdmin)
+	sess, err := mgr.CreateUser(testAuthUserAdmin, "")
 	if err != nil {
 		t.Errorf("Failed to create admin user: %v", err)
 	}
 	if sess.UserID != testAuthUserAdmin.ID {
 		t.Errorf("Returned session for created admin user not for created user: %v != %v", sess.UserID, testAuthUserAdmin.ID)
 	}
-	sess, err = mgr.CreateUser(testAuthUser)
+	sess, err = mgr.CreateUser(testAuthUser, "")
 	if err != nil {
 		t.Errorf("Failed to create user: %v", err)
 	}
@@ -116,7 +169,7 @@ func TestCreateUser(t *testing.T) {
 		t.Errorf("Returned session for created user not for created user: %v != %v", sess.UserID, testAuthUser.ID)
 	}
 
-	_, err = mgr.CreateUser(testAuthUser)
+	_, err = mgr.CreateUser(testAuthUser, "")
 	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserExists {
 		t.Errorf("Creating already existing user succeeded or error is unequal to 'user exists': %v", err)
 	}
@@ -126,6 +179,472 @@ func TestCreateUser(t *testing.T) {
 	}
 }
 
+func testAuthNewUser(email string) *models.User {
+	return &models.User{FirstName: "New", LastName: "User", Email: email, Password: testAuthUserPW}
+}
+
+func TestRegistrationModes(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	// The first user can always register and becomes an admin
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationDisabled}
+	_, err := mgr.CreateUser(testAuthUserAdmin, "")
+	if err != nil || !testAuthUserAdmin.IsAdmin {
+		t.Fatalf("Failed to create first user as admin in disabled mode: %v", err)
+	}
+
+	_, err = mgr.CreateUser(testAuthNewUser("disabled@email.com"), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.RegistrationDisabled {
+		t.Errorf("Registration in disabled mode succeeded or error is not 'registration disabled': %v", err)
+	}
+
+	mgr.registrationPolicy = RegistrationPolicy{Mode: "unknown"}
+	_, err = mgr.CreateUser(testAuthNewUser("unknown@email.com"), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.RegistrationDisabled {
+		t.Errorf("Registration in unknown mode succeeded or error is not 'registration disabled': %v", err)
+	}
+
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationDomain, Domains: []string{"allowed.com"}}
+	_, err = mgr.CreateUser(testAuthNewUser("user@Allowed.com"), "")
+	if err != nil {
+		t.Errorf("Failed to register user with allowed domain: %v", err)
+	}
+	_, err = mgr.CreateUser(testAuthNewUser("user@allowed.com.evil.com"), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.EmailDomainNotAllowed {
+		t.Errorf("Registration with other domain succeeded or error is not 'email domain not allowed': %v", err)
+	}
+
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationInvite}
+	inviteCode, _ := mgr.CreateInviteCode(&models.InviteCodeRequest{MaxUses: 1}, testAuthUserAdmin.ID)
+	_, err = mgr.CreateUser(testAuthNewUser("noinvite@email.com"), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidInviteCode {
+		t.Errorf("Registration without invite code succeeded or error is not 'invalid invite code': %v", err)
+	}
+	_, err = mgr.CreateUser(testAuthNewUser("invited@email.com"), inviteCode.Code)
+	if err != nil {
+		t.Errorf("Failed to register user with invite code: %v", err)
+	}
+	_, err = mgr.CreateUser(testAuthNewUser("invited2@email.com"), inviteCode.Code)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidInviteCode {
+		t.Errorf("Registration with used up invite code succeeded or error is not 'invalid invite code': %v", err)
+	}
+
+	// Users created in approval mode can't login before being approved
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationApproval}
+	pendingUser := testAuthNewUser("pending@email.com")
+	pendingUser.PendingApproval = false
+	pendingUser.IsAdmin = true
+	sess, err := mgr.CreateUser(pendingUser, "")
+	if err != nil || sess != nil {
+		t.Fatalf("Failed to register user in approval mode or session was created: %v", err)
+	}
+	if !pendingUser.PendingApproval || pendingUser.IsAdmin {
+		t.Errorf("Registered user is not pending or became an admin: %v", pendingUser)
+	}
+	_, err = mgr.LoginUser(pendingUser.Email, testAuthUserPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserPendingApproval {
+		t.Errorf("Login of pending user succeeded or error is not 'user pending approval': %v", err)
+	}
+
+	err = mgr.ApproveUser(pendingUser.ID)
+	if err != nil {
+		t.Errorf("Failed to approve user: %v", err)
+	}
+	_, err = mgr.LoginUser(pendingUser.Email, testAuthUserPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login approved user: %v", err)
+	}
+
+	err = mgr.ApproveUser(9999)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
+		t.Errorf("Approving user with non existing id succeeded or error is not 'user not found': %v", err)
+	}
+}
+
+func TestFirstUserSignup(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	// Only one of several users signing up at once becomes the admin
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationDisabled}
+	users := make([]*models.User, 5)
+	errs := make([]error, len(users))
+	var wg sync.WaitGroup
+	for i := range users {
+		users[i] = testAuthNewUser(fmt.Sprintf("first%d@email.com", i))
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = mgr.CreateUser(users[i], "")
+		}(i)
+	}
+	wg.Wait()
+	admins := 0
+	for i, user := range users {
+		if errs[i] == nil && user.IsAdmin {
+			admins++
+		} else if errs[i] == nil || errs[i].(*fcerrors.FCError).Code != fcerrors.RegistrationDisabled {
+			t.Errorf("Concurrent signup of %s succeeded or error is not 'registration disabled': %v", user.Email, errs[i])
+		}
+	}
+	if admins != 1 {
+		t.Fatalf("Expected exactly one user to become admin, got %d", admins)
+	}
+
+	// After all users have been deleted the registration policy still applies
+	for _, user := range users {
+		if user.ID > 0 {
+			mgr.DeleteUser(user.ID)
+		}
+	}
+	if count, _ := mgr.userRep.TotalCount(); count != 0 {
+		t.Fatalf("Expected no users to be left, got %d", count)
+	}
+	_, err := mgr.CreateUser(testAuthNewUser("late@email.com"), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.RegistrationDisabled {
+		t.Errorf("Signup after deleting all users succeeded or error is not 'registration disabled': %v", err)
+	}
+	if count, _ := mgr.userRep.TotalCount(); count != 0 {
+		t.Errorf("Expected rejected user to be removed, got %d users", count)
+	}
+
+	mgr.registrationPolicy = RegistrationPolicy{Mode: RegistrationOpen}
+	lateUser := testAuthNewUser("late@email.com")
+	_, err = mgr.CreateUser(lateUser, "")
+	if err != nil || lateUser.IsAdmin {
+		t.Errorf("Failed to sign up in open mode after deleting all users or user became an admin: %v", err)
+	}
+}
+
+func TestInviteCodes(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	invalidRequests := []*models.InviteCodeRequest{
+		{MaxUses: -1},
+		{ExpiresAt: -1},
+		{ExpiresAt: 1000},
+	}
+	for _, request := range invalidRequests {
+		_, err := mgr.CreateInviteCode(request, 1)
+		if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidInviteCodeData {
+			t.Errorf("Creating invite code for %v succeeded or error is not 'invalid invite code data': %v", request, err)
+		}
+	}
+
+	inviteCode0, err := mgr.CreateInviteCode(&models.InviteCodeRequest{MaxUses: 5}, 1)
+	if err != nil {
+		t.Fatalf("Failed to create invite code: %v", err)
+	}
+	inviteCode1, _ := mgr.CreateInviteCode(&models.InviteCodeRequest{ExpiresAt: utils.GetTimestampNow() + 3600}, 1)
+	if len(inviteCode0.Code) != inviteCodeLength || inviteCode0.Code == inviteCode1.Code {
+		t.Errorf("Invite codes are not unique random codes: %s, %s", inviteCode0.Code, inviteCode1.Code)
+	}
+
+	inviteCodes, err := mgr.GetAllInviteCodes()
+	if err != nil || len(inviteCodes) != 2 {
+		t.Errorf("Failed to get all invite codes: %v, %v", inviteCodes, err)
+	}
+
+	err = mgr.RevokeInviteCode(inviteCode0.ID)
+	if err != nil {
+		t.Errorf("Failed to revoke invite code: %v", err)
+	}
+	err = mgr.RevokeInviteCode(inviteCode0.ID)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InviteCodeNotFound {
+		t.Errorf("Revoking revoked invite code succeeded or error is not 'invite code not found': %v", err)
+	}
+}
+
+func TestAppPasswords(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	_, err := mgr.CreateAppPassword(testAuthUser.ID, " ")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidAppPasswordData {
+		t.Errorf("Creating app password without name succeeded or error is not 'invalid app password data': %v", err)
+	}
+
+	appPassword0, err := mgr.CreateAppPassword(testAuthUser.ID, "laptop")
+	if err != nil {
+		t.Fatalf("Failed to create app password: %v", err)
+	}
+	appPassword1, _ := mgr.CreateAppPassword(testAuthUser.ID, "phone")
+	if len(appPassword0.Password) != appPasswordLength || appPassword0.Password == appPassword1.Password {
+		t.Errorf("App passwords are not unique random passwords: %s, %s", appPassword0.Password, appPassword1.Password)
+	}
+
+	appPasswords, err := mgr.GetAppPasswords(testAuthUser.ID)
+	if err != nil || len(appPasswords) != 2 {
+		t.Fatalf("Failed to get app passwords: %v, %v", appPasswords, err)
+	}
+	if appPasswords[0].Password != "" || appPasswords[0].Name != "laptop" {
+		t.Errorf("Listed app password contains its hash or has the wrong name: %v", appPasswords[0])
+	}
+
+	user, err := mgr.LoginAppPassword(testAuthUser.Email, appPassword1.Password, testAuthClientIP)
+	if err != nil || user.ID != testAuthUser.ID {
+		t.Errorf("Failed to login with app password: %v, %v", user, err)
+	}
+	appPasswords, _ = mgr.GetAppPasswords(testAuthUser.ID)
+	if appPasswords[1].LastUsed == 0 {
+		t.Errorf("Expected last usage of app password to be set after login")
+	}
+
+	_, err = mgr.LoginAppPassword(testAuthUserAdmin.Email, appPassword1.Password, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with app password of another user succeeded or error is not 'bad credentials': %v", err)
+	}
+	_, err = mgr.LoginAppPassword(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with password of user as app password succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	err = mgr.DeleteAppPassword(testAuthUserAdmin.ID, appPassword0.ID)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.AppPasswordNotFound {
+		t.Errorf("Revoking app password of another user succeeded or error is not 'app password not found': %v", err)
+	}
+	err = mgr.DeleteAppPassword(testAuthUser.ID, appPassword0.ID)
+	if err != nil {
+		t.Errorf("Failed to revoke app password: %v", err)
+	}
+	_, err = mgr.LoginAppPassword(testAuthUser.Email, appPassword0.Password, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with revoked app password succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	err = mgr.DeleteUser(testAuthUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	appPasswords, _ = mgr.GetAppPasswords(testAuthUser.ID)
+	if len(appPasswords) != 0 {
+		t.Errorf("Expected app passwords to be deleted with the user, got %d", len(appPasswords))
+	}
+}
+
+func testAuthSSHKey(t *testing.T) ssh.PublicKey {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("Failed to convert key: %v", err)
+	}
+	return sshPublicKey
+}
+
+func TestSSHKeys(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	_, err := mgr.AddSSHKey(testAuthUser.ID, "laptop", "ssh-ed25519 invalid")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidSSHKey {
+		t.Errorf("Adding invalid ssh key succeeded or error is not 'invalid ssh key': %v", err)
+	}
+
+	publicKey0, publicKey1 := testAuthSSHKey(t), testAuthSSHKey(t)
+	authorizedKey0 := strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey0))) + " user@laptop"
+	sshKey0, err := mgr.AddSSHKey(testAuthUser.ID, "", authorizedKey0)
+	if err != nil {
+		t.Fatalf("Failed to add ssh key: %v", err)
+	}
+	if sshKey0.Name != "user@laptop" || sshKey0.Fingerprint != ssh.FingerprintSHA256(publicKey0) {
+		t.Errorf("Expected comment as name and fingerprint of key: %v", sshKey0)
+	}
+	_, err = mgr.AddSSHKey(testAuthUser.ID, "again", authorizedKey0)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.SSHKeyExists {
+		t.Errorf("Adding ssh key twice succeeded or error is not 'ssh key exists': %v", err)
+	}
+	_, err = mgr.AddSSHKey(testAuthUser.ID, "phone", string(ssh.MarshalAuthorizedKey(publicKey1)))
+	if err != nil {
+		t.Fatalf("Failed to add second ssh key: %v", err)
+	}
+
+	sshKeys, err := mgr.GetSSHKeys(testAuthUser.ID)
+	if err != nil || len(sshKeys) != 2 || sshKeys[1].Name != "phone" {
+		t.Fatalf("Failed to get ssh keys: %v, %v", sshKeys, err)
+	}
+
+	user, sshKey, err := mgr.LoginSSHKey(testAuthUser.Email, publicKey0, testAuthClientIP)
+	if err != nil || user.ID != testAuthUser.ID || sshKey.ID != sshKey0.ID {
+		t.Errorf("Failed to login with ssh key: %v, %v, %v", user, sshKey, err)
+	}
+	sshKeys, _ = mgr.GetSSHKeys(testAuthUser.ID)
+	if sshKeys[0].LastUsed != 0 {
+		t.Errorf("Expected last usage of ssh key not to be set before the client proved to own it")
+	}
+	mgr.MarkSSHKeyUsed(sshKey.ID)
+	sshKeys, _ = mgr.GetSSHKeys(testAuthUser.ID)
+	if sshKeys[0].LastUsed == 0 {
+		t.Errorf("Expected last usage of ssh key to be set after login")
+	}
+	_, _, err = mgr.LoginSSHKey(testAuthUserAdmin.Email, publicKey0, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with ssh key of another user succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	err = mgr.DeleteSSHKey(testAuthUserAdmin.ID, sshKey0.ID)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.SSHKeyNotFound {
+		t.Errorf("Removing ssh key of another user succeeded or error is not 'ssh key not found': %v", err)
+	}
+	err = mgr.DeleteSSHKey(testAuthUser.ID, sshKey0.ID)
+	if err != nil {
+		t.Errorf("Failed to remove ssh key: %v", err)
+	}
+	_, _, err = mgr.LoginSSHKey(testAuthUser.Email, publicKey0, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with removed ssh key succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	err = mgr.DeleteUser(testAuthUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	sshKeys, _ = mgr.GetSSHKeys(testAuthUser.ID)
+	if len(sshKeys) != 0 {
+		t.Errorf("Expected ssh keys to be deleted with the user, got %d", len(sshKeys))
+	}
+}
+
+func TestAccessKeys(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	mgr.accessKeyMasterKey = nil
+	_, err := mgr.CreateAccessKey(testAuthUser.ID, "disabled")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.AccessKeysDisabled {
+		t.Errorf("Creating access key without master key succeeded or error is not 'access keys disabled': %v", err)
+	}
+	mgr.accessKeyMasterKey = testAuthAccessKeyMasterKey
+
+	_, err = mgr.CreateAccessKey(testAuthUser.ID, " ")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.InvalidAccessKeyData {
+		t.Errorf("Creating access key without name succeeded or error is not 'invalid access key data': %v", err)
+	}
+
+	accessKey0, err := mgr.CreateAccessKey(testAuthUser.ID, "backup")
+	if err != nil {
+		t.Fatalf("Failed to create access key: %v", err)
+	}
+	accessKey1, err := mgr.CreateAccessKey(testAuthUser.ID, "rclone")
+	if err != nil {
+		t.Fatalf("Failed to create second access key: %v", err)
+	}
+	if !strings.HasPrefix(accessKey0.AccessKeyID, accessKeyIDPrefix) || accessKey0.AccessKeyID == accessKey1.AccessKeyID || accessKey0.SecretAccessKey == accessKey1.SecretAccessKey {
+		t.Errorf("Expected distinct access keys with secrets: %v, %v", accessKey0, accessKey1)
+	}
+
+	accessKeys, err := mgr.GetAccessKeys(testAuthUser.ID)
+	if err != nil || len(accessKeys) != 2 || accessKeys[1].Name != "rclone" || accessKeys[0].SecretAccessKey != "" {
+		t.Fatalf("Failed to get access keys without their secrets: %v, %v", accessKeys, err)
+	}
+
+	verifySecret := func(secretAccessKey string) func(string) error {
+		return func(derived string) error {
+			if derived != secretAccessKey {
+				return errors.New("signature mismatch")
+			}
+			return nil
+		}
+	}
+	user, err := mgr.LoginAccessKey(accessKey0.AccessKeyID, verifySecret(accessKey0.SecretAccessKey), testAuthClientIP)
+	if err != nil || user.ID != testAuthUser.ID {
+		t.Errorf("Failed to login with access key: %v, %v", user, err)
+	}
+	accessKeys, _ = mgr.GetAccessKeys(testAuthUser.ID)
+	if accessKeys[0].LastUsed == 0 {
+		t.Errorf("Expected last usage of access key to be set after login")
+	}
+	_, err = mgr.LoginAccessKey(accessKey0.AccessKeyID, verifySecret(accessKey1.SecretAccessKey), testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with secret of another access key succeeded or error is not 'bad credentials': %v", err)
+	}
+	_, err = mgr.LoginAccessKey("FCUNKNOWN", verifySecret(accessKey0.SecretAccessKey), testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with unknown access key succeeded or error is not 'bad credentials': %v", err)
+	}
+	for i := 1; i < testAuthLockoutPolicy.AccountThreshold; i++ {
+		mgr.LoginAccessKey(accessKey1.AccessKeyID, verifySecret("wrong"), testAuthClientIP)
+	}
+	_, err = mgr.LoginAccessKey(accessKey1.AccessKeyID, verifySecret(accessKey1.SecretAccessKey), testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.TooManyLoginAttempts {
+		t.Errorf("Login with access key of locked out user succeeded or error is not 'too many login attempts': %v", err)
+	}
+	mgr.UnlockUser(testAuthUser.ID)
+
+	err = mgr.DeleteAccessKey(testAuthUserAdmin.ID, accessKey0.ID)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.AccessKeyNotFound {
+		t.Errorf("Revoking access key of another user succeeded or error is not 'access key not found': %v", err)
+	}
+	err = mgr.DeleteAccessKey(testAuthUser.ID, accessKey0.ID)
+	if err != nil {
+		t.Errorf("Failed to revoke access key: %v", err)
+	}
+	_, err = mgr.LoginAccessKey(accessKey0.AccessKeyID, verifySecret(accessKey0.SecretAccessKey), "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login with revoked access key succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	err = mgr.DeleteUser(testAuthUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete user: %v", err)
+	}
+	accessKeys, _ = mgr.GetAccessKeys(testAuthUser.ID)
+	if len(accessKeys) != 0 {
+		t.Errorf("Expected access keys to be deleted with the user, got %d", len(accessKeys))
+	}
+}
+
+func TestAuthenticateUser(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	user, err := mgr.AuthenticateUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err != nil || user.ID != testAuthUser.ID {
+		t.Errorf("Failed to authenticate user: %v, %v", user, err)
+	}
+	sessions, _ := mgr.GetSessionsForUser(testAuthUser.ID)
+	if len(sessions) != 1 {
+		t.Errorf("Expected authenticating to create no session in addition to the one of the signup, got %d", len(sessions))
+	}
+	_, err = mgr.AuthenticateUser(testAuthUser.Email, "wrongPassword", testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Authenticating with wrong password succeeded or error is not 'bad credentials': %v", err)
+	}
+}
+
 func TestUserLogin(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
@@ -135,7 +654,7 @@ func TestUserLogin(t *testing.T) {
 
 	testAuthInsert(mgr)
 
-	sess, err := mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW)
+	sess, err := mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
 	if err != nil {
 		t.Errorf("Failed to verify and get new session for admin user: %v", err)
 	}
@@ -143,13 +662,13 @@ func TestUserLogin(t *testing.T) {
 		t.Errorf("New verified session is not for correct user: %v != %v", sess.UserID, testAuthUserAdmin.ID)
 	}
 
-	_, err = mgr.LoginUser(testAuthUser.Email, "wrongPassword")
+	_, err = mgr.LoginUser(testAuthUser.Email, "wrongPassword", testAuthClientIP)
 	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
 		t.Errorf("Verifying and creating session with wrong user credentials succeeded or error is not 'bad credentials': %v", err)
 	}
 }
 
-func TestGetUserByID(t *testing.T) {
+func TestUserLoginRehash(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
 	}
@@ -158,24 +677,26 @@ func TestGetUserByID(t *testing.T) {
 
 	testAuthInsert(mgr)
 
-	readBackUser, err := mgr.GetUserByID(testAuthUserAdmin.ID)
+	user, _ := mgr.userRep.GetByID(testAuthUser.ID)
+	user.Password, _ = crypt.HashScrypt(testAuthUserPW)
+	mgr.userRep.Update(user)
+
+	_, err := mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
 	if err != nil {
-		t.Errorf("Failed to get admin user by ID: %v", err)
+		t.Errorf("Failed to login user with outdated password hash: %v", err)
 	}
-	readBackUser.LastSession = 0
-	readBackUser.Updated = 0
-	testAuthUserAdmin.Updated = 0
-	if !reflect.DeepEqual(readBackUser, testAuthUserAdmin) {
-		t.Errorf("Read back admin user by ID and admin user not deeply equal: %v != %v", readBackUser, testAuthUserAdmin)
+	user, _ = mgr.userRep.GetByID(testAuthUser.ID)
+	if !strings.HasPrefix(user.Password, "$"+crypt.Argon2idHashID+"$") {
+		t.Errorf("Outdated password hash has not been rehashed on login: %s", user.Password)
 	}
 
-	_, err = mgr.GetUserByID(9999)
-	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
-		t.Errorf("Getting user with non existing id succeeded or error is not 'user not found': %v", err)
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login user with rehashed password: %v", err)
 	}
 }
 
-func TestGetUserByMail(t *testing.T) {
+func TestLoginLockout(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
 	}
@@ -184,24 +705,45 @@ func TestGetUserByMail(t *testing.T) {
 
 	testAuthInsert(mgr)
 
-	readBackUser, err := mgr.GetUserByEmail(testAuthUser.Email)
-	if err != nil {
-		t.Errorf("Failed to get user by email: %v", err)
+	for i := 0; i < testAuthLockoutPolicy.AccountThreshold; i++ {
+		mgr.LoginUser(testAuthUser.Email, "wrongPassword", testAuthClientIP)
 	}
-	readBackUser.LastSession = 0
-	readBackUser.Updated = 0
-	testAuthUser.Updated = 0
-	if !reflect.DeepEqual(readBackUser, testAuthUser) {
-		t.Errorf("Read back user by email and user not deeply equal: %v != %v", readBackUser, testAuthUser)
+	_, err := mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.TooManyLoginAttempts {
+		t.Errorf("Login for locked out user succeeded or error is not 'too many login attempts': %v", err)
+	}
+	retryAfter := mgr.GetLoginRetryAfter(testAuthUser.Email, testAuthClientIP)
+	if retryAfter <= 0 || retryAfter > int64(testAuthLockoutPolicy.BaseDuration) {
+		t.Errorf("Retry after for locked out user not within base duration: %d", retryAfter)
 	}
 
-	_, err = mgr.GetUserByEmail("not@existing.com")
-	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
-		t.Errorf("Getting user with non existing email succeeded or error is not 'user not found': %v", err)
+	// Nonexistent accounts are locked out the same way to not reveal which emails exist
+	for i := 0; i < testAuthLockoutPolicy.AccountThreshold; i++ {
+		mgr.LoginUser("not@existing.com", "wrongPassword", "")
+	}
+	_, err = mgr.LoginUser("not@existing.com", "wrongPassword", "")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.TooManyLoginAttempts {
+		t.Errorf("Login for locked out nonexistent user is not 'too many login attempts': %v", err)
+	}
+
+	// The client IP has reached its threshold by now as well
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login admin from client IP below threshold: %v", err)
+	}
+	mgr.LoginUser(testAuthUserAdmin.Email, "wrongPassword", testAuthClientIP)
+	mgr.LoginUser(testAuthUserAdmin.Email, "wrongPassword", testAuthClientIP)
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.TooManyLoginAttempts {
+		t.Errorf("Login from locked out client IP succeeded or error is not 'too many login attempts': %v", err)
+	}
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, "")
+	if err != nil {
+		t.Errorf("Failed to login admin from other client: %v", err)
 	}
 }
 
-func TestGetAllUsers(t *testing.T) {
+func TestConcurrentLoginLockout(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
 	}
@@ -210,11 +752,435 @@ func TestGetAllUsers(t *testing.T) {
 
 	testAuthInsert(mgr)
 
-	users, err := mgr.GetAllUsers()
-	if err != nil {
-		t.Errorf("Failed to get all users: %v", err)
+	// Concurrent logins can't try more passwords than the threshold allows
+	errs := make([]error, 3*testAuthLockoutPolicy.AccountThreshold)
+	var wg sync.WaitGroup
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = mgr.LoginUser(testAuthUser.Email, "wrongPassword", "")
+		}(i)
 	}
-	if len(users) != 2 {
+	wg.Wait()
+	tried := 0
+	for _, err := range errs {
+		if err.(*fcerrors.FCError).Code == fcerrors.BadCredentials {
+			tried++
+		}
+	}
+	if tried != testAuthLockoutPolicy.AccountThreshold {
+		t.Errorf("Expected %d concurrent logins to check the password, got %d", testAuthLockoutPolicy.AccountThreshold, tried)
+	}
+	attempt, err := mgr.loginAttemptRep.GetBySubject(loginAttemptAccountPrefix + testAuthUser.Email)
+	if err != nil || attempt.Failures != testAuthLockoutPolicy.AccountThreshold {
+		t.Errorf("Expected %d counted failures: %v, %v", testAuthLockoutPolicy.AccountThreshold, attempt, err)
+	}
+}
+
+func TestLockoutDuration(t *testing.T) {
+	mgr := &AuthManager{lockoutPolicy: testAuthLockoutPolicy}
+	var l = map[int]int64{
+		0:   60,
+		1:   120,
+		2:   240,
+		3:   480,
+		4:   600,
+		100: 600,
+	}
+
+	for input, expOutput := range l {
+		if output := mgr.lockoutDuration(input); output != expOutput {
+			t.Errorf("Expected lockout duration '%d' for '%d' exceeded attempts but got: '%d'", expOutput, input, output)
+		}
+	}
+}
+
+func TestUnlockUser(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	for i := 0; i < testAuthLockoutPolicy.AccountThreshold; i++ {
+		mgr.LoginUser(testAuthUser.Email, "wrongPassword", "")
+	}
+	err := mgr.UnlockUser(testAuthUser.ID)
+	if err != nil {
+		t.Errorf("Failed to unlock user: %v", err)
+	}
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, "")
+	if err != nil {
+		t.Errorf("Failed to login unlocked user: %v", err)
+	}
+
+	err = mgr.UnlockUser(9999)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
+		t.Errorf("Unlocking user with non existing id succeeded or error is not 'user not found': %v", err)
+	}
+}
+
+// testLDAPDirectory stubs the LDAPRepository with a fixed set of users sharing one password
+type testLDAPDirectory struct {
+	users       map[string]*models.User
+	password    string
+	adminGroup  bool
+	unavailable bool
+}
+
+func (dir *testLDAPDirectory) Authenticate(email, password string) (*models.User, error) {
+	if dir.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	user, ok := dir.users[email]
+	if !ok || password != dir.password {
+		return nil, repository.ErrLDAPInvalidCredentials
+	}
+	return &models.User{Email: user.Email, FirstName: user.FirstName, LastName: user.LastName, IsAdmin: user.IsAdmin}, nil
+}
+
+func (dir *testLDAPDirectory) HasAdminGroup() bool {
+	return dir.adminGroup
+}
+
+func testAuthLDAPDirectory() *testLDAPDirectory {
+	return &testLDAPDirectory{
+		users: map[string]*models.User{
+			"ldap.admin@email.com": {Email: "ldap.admin@email.com", FirstName: "LDAP", LastName: "Admin", IsAdmin: true},
+			testAuthUser.Email:     {Email: testAuthUser.Email, FirstName: "Directory", LastName: "User"},
+		},
+		password:   "ldapPassword",
+		adminGroup: true,
+	}
+}
+
+func TestLDAPUserLogin(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	dir := testAuthLDAPDirectory()
+	mgr.ldapRep = dir
+
+	// First login provisions the user with the admin status of the directory
+	sess, err := mgr.LoginUser("ldap.admin@email.com", dir.password, testAuthClientIP)
+	if err != nil {
+		t.Fatalf("Failed to login new ldap user: %v", err)
+	}
+	user, err := mgr.GetUserByID(sess.UserID)
+	if err != nil {
+		t.Fatalf("Failed to get provisioned ldap user: %v", err)
+	}
+	if user.Email != "ldap.admin@email.com" || user.AuthProvider != AuthProviderLDAP || !user.IsAdmin || user.Password != "" {
+		t.Errorf("Provisioned ldap user does not match directory: %v", user)
+	}
+
+	// Second login reuses the provisioned user
+	sess, err = mgr.LoginUser("ldap.admin@email.com", dir.password, testAuthClientIP)
+	if err != nil || sess.UserID != user.ID {
+		t.Errorf("Failed to login provisioned ldap user again: %v", err)
+	}
+
+	// Existing local users are linked by email
+	sess, err = mgr.LoginUser(testAuthUser.Email, dir.password, testAuthClientIP)
+	if err != nil || sess.UserID != testAuthUser.ID {
+		t.Fatalf("Failed to login linked ldap user: %v", err)
+	}
+	user, _ = mgr.GetUserByID(testAuthUser.ID)
+	if user.AuthProvider != AuthProviderLDAP || user.FirstName != "Directory" || user.Password != "" {
+		t.Errorf("Local user has not been linked to ldap: %v", user)
+	}
+
+	// Linked users can't login with their old local password anymore
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Login of linked user with local password succeeded or error is not 'bad credentials': %v", err)
+	}
+}
+
+func TestLDAPUserLoginFallback(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	dir := testAuthLDAPDirectory()
+	mgr.ldapRep = dir
+
+	mgr.ldapLocalFallback = false
+	_, err := mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
+		t.Errorf("Local login without fallback succeeded or error is not 'bad credentials': %v", err)
+	}
+
+	mgr.ldapLocalFallback = true
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login local user with fallback: %v", err)
+	}
+
+	dir.unavailable = true
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login local user with fallback while ldap is unavailable: %v", err)
+	}
+
+	mgr.ldapLocalFallback = false
+	_, err = mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.LDAPUnavailable {
+		t.Errorf("Login without fallback while ldap is unavailable succeeded or error is not 'ldap unavailable': %v", err)
+	}
+}
+
+func TestLDAPUserAdminSync(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	dir := testAuthLDAPDirectory()
+	mgr.ldapRep = dir
+
+	// Without an admin group the local admin status is kept
+	dir.adminGroup = false
+	dir.users[testAuthUserAdmin.Email] = &models.User{Email: testAuthUserAdmin.Email}
+	sess, err := mgr.LoginUser(testAuthUserAdmin.Email, dir.password, testAuthClientIP)
+	if err != nil {
+		t.Fatalf("Failed to login linked admin: %v", err)
+	}
+	user, _ := mgr.GetUserByID(sess.UserID)
+	if !user.IsAdmin || user.FirstName != testAuthUserAdmin.FirstName {
+		t.Errorf("Admin status or name of user without admin group changed: %v", user)
+	}
+
+	dir.adminGroup = true
+	mgr.LoginUser(testAuthUserAdmin.Email, dir.password, testAuthClientIP)
+	user, _ = mgr.GetUserByID(sess.UserID)
+	if user.IsAdmin {
+		t.Errorf("Admin status has not been removed for user outside of admin group")
+	}
+}
+
+// testOIDCProvider stubs the OIDCRepository, codes map to the users they log in
+type testOIDCProvider struct {
+	codes        map[string]*models.User
+	nonce        string
+	codeVerifier string
+	adminClaim   bool
+	unavailable  bool
+}
+
+func (idp *testOIDCProvider) AuthCodeURL(state, nonce, codeVerifier string) (string, error) {
+	if idp.unavailable {
+		return "", errors.New("connection refused")
+	}
+	idp.nonce = nonce
+	idp.codeVerifier = codeVerifier
+	return "https://idp.example.com/authorize?state=" + state, nil
+}
+
+func (idp *testOIDCProvider) Exchange(code, codeVerifier, nonce string) (*models.User, error) {
+	if idp.unavailable {
+		return nil, errors.New("connection refused")
+	}
+	user, ok := idp.codes[code]
+	if !ok || codeVerifier != idp.codeVerifier || nonce != idp.nonce {
+		return nil, repository.ErrOIDCInvalidToken
+	}
+	return &models.User{Email: user.Email, FirstName: user.FirstName, LastName: user.LastName, IsAdmin: user.IsAdmin, AuthSubject: user.AuthSubject}, nil
+}
+
+func (idp *testOIDCProvider) HasAdminClaim() bool {
+	return idp.adminClaim
+}
+
+func testAuthOIDCProvider(mgr *AuthManager) *testOIDCProvider {
+	idp := &testOIDCProvider{
+		codes: map[string]*models.User{
+			"newUser":    {Email: "oidc.user@email.com", FirstName: "OIDC", LastName: "User", IsAdmin: true, AuthSubject: "subject0"},
+			"localUser":  {Email: testAuthUser.Email, FirstName: "Provider", LastName: "User", AuthSubject: "subject1"},
+			"otherEmail": {Email: "changed@email.com", AuthSubject: "subject1"},
+			"takeover":   {Email: testAuthUser.Email, AuthSubject: "subject2"},
+		},
+		adminClaim: true,
+	}
+	mgr.oidcRep = idp
+	mgr.oidcRequests = make(map[string]*oidcAuthRequest)
+	return idp
+}
+
+func testAuthOIDCState(t *testing.T, mgr *AuthManager) string {
+	authURL, err := mgr.GetOIDCAuthorizationURL()
+	if err != nil {
+		t.Fatalf("Failed to get oidc authorization url: %v", err)
+	}
+	return authURL[strings.Index(authURL, "state=")+len("state="):]
+}
+
+func TestOIDCUserLogin(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	testAuthOIDCProvider(mgr)
+
+	// First login provisions the user with the admin status of the provider
+	sess, err := mgr.LoginOIDCUser("newUser", testAuthOIDCState(t, mgr))
+	if err != nil {
+		t.Fatalf("Failed to login new oidc user: %v", err)
+	}
+	user, _ := mgr.GetUserByID(sess.UserID)
+	if user.Email != "oidc.user@email.com" || user.AuthProvider != AuthProviderOIDC || user.AuthSubject != "subject0" || !user.IsAdmin {
+		t.Errorf("Provisioned oidc user does not match provider: %v", user)
+	}
+
+	// Existing local users are linked by email
+	sess, err = mgr.LoginOIDCUser("localUser", testAuthOIDCState(t, mgr))
+	if err != nil || sess.UserID != testAuthUser.ID {
+		t.Fatalf("Failed to login linked oidc user: %v", err)
+	}
+	user, _ = mgr.GetUserByID(testAuthUser.ID)
+	if user.AuthProvider != AuthProviderOIDC || user.AuthSubject != "subject1" || user.FirstName != "Provider" || user.Password != "" {
+		t.Errorf("Local user has not been linked to oidc: %v", user)
+	}
+
+	// Linked users are found by subject even if the email at the provider changed
+	sess, err = mgr.LoginOIDCUser("otherEmail", testAuthOIDCState(t, mgr))
+	if err != nil || sess.UserID != testAuthUser.ID {
+		t.Errorf("Failed to login linked oidc user by subject: %v", err)
+	}
+
+	// Other subjects with the email of a linked user are rejected
+	_, err = mgr.LoginOIDCUser("takeover", testAuthOIDCState(t, mgr))
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserExists {
+		t.Errorf("Login of other subject with linked email succeeded or error is not 'user exists': %v", err)
+	}
+}
+
+func TestOIDCUserLoginState(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	_, err := mgr.GetOIDCAuthorizationURL()
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCDisabled {
+		t.Errorf("Getting oidc authorization url without provider succeeded or error is not 'oidc disabled': %v", err)
+	}
+
+	idp := testAuthOIDCProvider(mgr)
+	state := testAuthOIDCState(t, mgr)
+
+	_, err = mgr.LoginOIDCUser("newUser", "unknownState")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCLoginFailed {
+		t.Errorf("Login with unknown state succeeded or error is not 'oidc login failed': %v", err)
+	}
+
+	_, err = mgr.LoginOIDCUser("invalidCode", state)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCLoginFailed {
+		t.Errorf("Login with invalid code succeeded or error is not 'oidc login failed': %v", err)
+	}
+
+	// The state has been used up by the failed login
+	_, err = mgr.LoginOIDCUser("newUser", state)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCLoginFailed {
+		t.Errorf("Login with reused state succeeded or error is not 'oidc login failed': %v", err)
+	}
+
+	state = testAuthOIDCState(t, mgr)
+	mgr.oidcRequests[state].expiresAt = 0
+	_, err = mgr.LoginOIDCUser("newUser", state)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCLoginFailed {
+		t.Errorf("Login with expired state succeeded or error is not 'oidc login failed': %v", err)
+	}
+
+	idp.unavailable = true
+	_, err = mgr.GetOIDCAuthorizationURL()
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.OIDCUnavailable {
+		t.Errorf("Getting oidc authorization url from unavailable provider succeeded or error is not 'oidc unavailable': %v", err)
+	}
+}
+
+func TestGetUserByID(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	readBackUser, err := mgr.GetUserByID(testAuthUserAdmin.ID)
+	if err != nil {
+		t.Errorf("Failed to get admin user by ID: %v", err)
+	}
+	readBackUser.LastSession = 0
+	readBackUser.Updated = 0
+	testAuthUserAdmin.Updated = 0
+	if !reflect.DeepEqual(readBackUser, testAuthUserAdmin) {
+		t.Errorf("Read back admin user by ID and admin user not deeply equal: %v != %v", readBackUser, testAuthUserAdmin)
+	}
+
+	_, err = mgr.GetUserByID(9999)
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
+		t.Errorf("Getting user with non existing id succeeded or error is not 'user not found': %v", err)
+	}
+}
+
+func TestGetUserByMail(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	readBackUser, err := mgr.GetUserByEmail(testAuthUser.Email)
+	if err != nil {
+		t.Errorf("Failed to get user by email: %v", err)
+	}
+	readBackUser.LastSession = 0
+	readBackUser.Updated = 0
+	testAuthUser.Updated = 0
+	if !reflect.DeepEqual(readBackUser, testAuthUser) {
+		t.Errorf("Read back user by email and user not deeply equal: %v != %v", readBackUser, testAuthUser)
+	}
+
+	_, err = mgr.GetUserByEmail("not@existing.com")
+	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
+		t.Errorf("Getting user with non existing email succeeded or error is not 'user not found': %v", err)
+	}
+}
+
+func TestGetAllUsers(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	users, err := mgr.GetAllUsers()
+	if err != nil {
+		t.Errorf("Failed to get all users: %v", err)
+	}
+	if len(users) != 2 {
 		t.Errorf("Lenght of all users unequal to two: %d", len(users))
 	}
 	for _, user := range users {
@@ -234,6 +1200,289 @@ func TestGetAllUsers(t *testing.T) {
 	}
 }
 
+func TestGetUserList(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	mgr.CreateUser(&models.User{FirstName: "Third", LastName: "User", Email: "third.user@email.com", Password: testAuthUserPW}, "")
+
+	users, total, err := mgr.GetUserList("", "email", false, 1, 2)
+	if err != nil {
+		t.Fatalf("Failed to get user list: %v", err)
+	}
+	if total != 3 || len(users) != 2 {
+		t.Errorf("Expected first page with two of three users, but got %d of %d", len(users), total)
+	}
+	if len(users) == 2 && (users[0].Email != testAuthUserAdmin.Email || users[1].Email != "third.user@email.com") {
+		t.Errorf("Expected users sorted by email, but got %s and %s", users[0].Email, users[1].Email)
+	}
+	for _, user := range users {
+		if user.Password != "" {
+			t.Errorf("Expected password of listed user %s to be masked", user.Email)
+		}
+	}
+
+	users, total, err = mgr.GetUserList("USER.user", "lastName", true, 1, 25)
+	if err != nil {
+		t.Fatalf("Failed to search user list: %v", err)
+	}
+	if total != 1 || len(users) != 1 || users[0].ID != testAuthUser.ID {
+		t.Errorf("Expected only user for search term, but got %d users", total)
+	}
+
+	users, _, err = mgr.GetUserList("", "email", false, 2, 2)
+	if err != nil || len(users) != 1 {
+		t.Errorf("Expected one user on second page, but got %d: %v", len(users), err)
+	}
+
+	queries := map[string]struct {
+		sortBy string
+		page   int64
+	}{
+		"unknown sort attribute": {"password", 1},
+		"page zero":              {"email", 0},
+	}
+	for name, query := range queries {
+		_, _, err = mgr.GetUserList("", query.sortBy, false, query.page, 25)
+		if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidUserListQuery {
+			t.Errorf("Expected 'invalid user list query' for %s but got: %v", name, err)
+		}
+	}
+}
+
+func TestDisableUser(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	sess, _ := mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+
+	err := mgr.DisableUser(testAuthUserAdmin.ID, testAuthUserAdmin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.DisableSelf {
+		t.Errorf("Expected 'disable self' when admin disables himself but got: %v", err)
+	}
+
+	err = mgr.DisableUser(testAuthUser.ID, testAuthUserAdmin.ID)
+	if err != nil {
+		t.Fatalf("Failed to disable user: %v", err)
+	}
+	if mgr.ValidateSession(sess) {
+		t.Error("Expected session of disabled user to be invalid")
+	}
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserDisabled {
+		t.Errorf("Expected 'user disabled' on login of disabled user but got: %v", err)
+	}
+
+	err = mgr.EnableUser(testAuthUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to enable user: %v", err)
+	}
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+	if err != nil {
+		t.Errorf("Failed to login enabled user: %v", err)
+	}
+
+	err = mgr.DisableUser(9999, testAuthUserAdmin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserNotFound {
+		t.Errorf("Expected 'user not found' on disabling non existing user but got: %v", err)
+	}
+}
+
+func TestImpersonateUser(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+	auditEntryRep, _ := repository.CreateAuditEntryRepository()
+	auditMgr := CreateAuditManager(auditEntryRep, 0)
+	defer func() { auditManager = nil }()
+
+	testAuthInsert(mgr)
+	user, _ := mgr.userRep.GetByID(testAuthUser.ID)
+	user.LastSession = 1
+	mgr.userRep.Update(user)
+
+	sess, err := mgr.ImpersonateUser(testAuthUserAdmin.ID, testAuthUser.ID, false, testAuthClientIP)
+	if err != nil {
+		t.Fatalf("Failed to impersonate user: %v", err)
+	}
+	storedSession, valid := mgr.GetValidSession(sess)
+	if !valid || storedSession.ImpersonatorID != testAuthUserAdmin.ID || !storedSession.ReadOnly {
+		t.Errorf("Expected valid read-only session impersonated by admin but got: %v, %v", storedSession, valid)
+	}
+	if maxExpiry := time.Now().UTC().Add(30 * time.Minute).Unix(); storedSession.ExpiresAt > maxExpiry {
+		t.Errorf("Expected impersonation session to expire within 30 minutes but expires at %d", storedSession.ExpiresAt)
+	}
+	user, _ = mgr.GetUserByID(testAuthUser.ID)
+	if user.LastSession != 1 {
+		t.Errorf("Expected last session of impersonated user to be unchanged but got %d", user.LastSession)
+	}
+
+	sess, _ = mgr.ImpersonateUser(testAuthUserAdmin.ID, testAuthUser.ID, true, testAuthClientIP)
+	storedSession, _ = mgr.GetValidSession(sess)
+	if storedSession.ReadOnly {
+		t.Error("Expected impersonation session allowing writes to not be read-only")
+	}
+
+	auditEntries, _ := auditMgr.GetEntriesForUser(testAuthUser.ID)
+	if len(auditEntries) != 2 || auditEntries[0].Action != AuditImpersonationStart || auditEntries[0].ActorID != testAuthUserAdmin.ID || auditEntries[0].Target != "read-only" || auditEntries[0].IP != testAuthClientIP {
+		t.Errorf("Expected started impersonations in audit log but got: %v", auditEntries)
+	}
+
+	_, err = mgr.ImpersonateUser(testAuthUserAdmin.ID, testAuthUserAdmin.ID, false, testAuthClientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidImpersonationTarget {
+		t.Errorf("Expected 'invalid impersonation target' when admin impersonates himself but got: %v", err)
+	}
+	mgr.DisableUser(testAuthUser.ID, testAuthUserAdmin.ID)
+	if mgr.ValidateSession(sess) {
+		t.Error("Expected impersonation session to end when the user is disabled")
+	}
+	_, err = mgr.ImpersonateUser(testAuthUserAdmin.ID, testAuthUser.ID, false, testAuthClientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidImpersonationTarget {
+		t.Errorf("Expected 'invalid impersonation target' for disabled user but got: %v", err)
+	}
+	_, err = mgr.ImpersonateUser(testAuthUserAdmin.ID, 9999, false, testAuthClientIP)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserNotFound {
+		t.Errorf("Expected 'user not found' for impersonating non existing user but got: %v", err)
+	}
+}
+
+func TestTransferUserFiles(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+	fileMgr := GetFileManager()
+	fileMgr.CreateFile(testAuthUser, "/dir", true)
+	fileMgr.CreateFile(testAuthUser, "/dir/file", false)
+	fileMgr.CreateFile(testAuthUser, "/shared", false)
+	err := fileMgr.ShareFile(testAuthUser, testAuthUserAdmin, "/shared")
+	if err != nil {
+		t.Fatalf("Failed to share file with admin: %v", err)
+	}
+
+	_, err = mgr.TransferUserFiles(testAuthUser.ID, testAuthUserAdmin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.TransferFromActiveUser {
+		t.Errorf("Expected 'transfer from active user' for transfer from active user but got: %v", err)
+	}
+
+	mgr.DisableUser(testAuthUser.ID, testAuthUserAdmin.ID)
+	_, err = mgr.TransferUserFiles(testAuthUser.ID, testAuthUser.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidTransferRecipient {
+		t.Errorf("Expected 'invalid transfer recipient' for transfer to same user but got: %v", err)
+	}
+
+	folderInfo, err := mgr.TransferUserFiles(testAuthUser.ID, testAuthUserAdmin.ID)
+	if err != nil {
+		t.Fatalf("Failed to transfer files of disabled user: %v", err)
+	}
+	expFolderName := "Files of " + testAuthUser.Email
+	if folderInfo.Name != expFolderName || folderInfo.Path != "/" || folderInfo.OwnerID != testAuthUserAdmin.ID {
+		t.Errorf("Expected folder %s in root of admin but got %s%s of %d", expFolderName, folderInfo.Path, folderInfo.Name, folderInfo.OwnerID)
+	}
+
+	for _, path := range []string{"/dir/file", "/shared"} {
+		fileInfo, err := fileMgr.GetFileInfo(testAuthUserAdmin, "/"+expFolderName+path, false)
+		if err != nil {
+			t.Errorf("Failed to get transferred file %s: %v", path, err)
+			continue
+		}
+		if fileInfo.OwnerID != testAuthUserAdmin.ID {
+			t.Errorf("Expected transferred file %s to be owned by admin but is owned by %d", path, fileInfo.OwnerID)
+		}
+		if _, err = os.Stat(filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUserAdmin), expFolderName, path)); err != nil {
+			t.Errorf("Expected transferred file %s on disk: %v", path, err)
+		}
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/shared", false); err == nil {
+		t.Error("Expected share of transferred file with admin to be removed")
+	}
+	if _, err = os.Stat(filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUser))); !os.IsNotExist(err) {
+		t.Errorf("Expected folder of previous owner to be moved away: %v", err)
+	}
+
+	_, err = mgr.TransferUserFiles(testAuthUser.ID, testAuthUserAdmin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserFilesNotFound {
+		t.Errorf("Expected 'user files not found' for second transfer but got: %v", err)
+	}
+	_, err = mgr.TransferUserFiles(9999, testAuthUserAdmin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserFilesNotFound {
+		t.Errorf("Expected 'user files not found' for transfer from non existing user but got: %v", err)
+	}
+}
+
+func TestUserAvatar(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	config.Init()
+	mgr := testAuthSetup()
+	defer testAuthCleanup(mgr)
+
+	testAuthInsert(mgr)
+
+	_, err := mgr.GetUserAvatar(testAuthUser.ID, 128)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.AvatarNotFound {
+		t.Errorf("Expected 'avatar not found' before upload but got: %v", err)
+	}
+	err = mgr.SetUserAvatar(testAuthUser.ID, strings.NewReader("not an image"))
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidAvatar {
+		t.Errorf("Expected 'invalid avatar' for text upload but got: %v", err)
+	}
+
+	var buf bytes.Buffer
+	png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 200, 100)))
+	err = mgr.SetUserAvatar(testAuthUser.ID, &buf)
+	if err != nil {
+		t.Fatalf("Failed to set avatar: %v", err)
+	}
+	if user, _ := mgr.GetUserByID(testAuthUser.ID); !user.HasAvatar {
+		t.Error("Expected user to have an avatar after upload")
+	}
+
+	for _, size := range AvatarSizes {
+		avatar, err := mgr.GetUserAvatar(testAuthUser.ID, size)
+		if err != nil {
+			t.Errorf("Failed to get avatar of size %d: %v", size, err)
+			continue
+		}
+		avatarConfig, err := png.DecodeConfig(avatar)
+		avatar.Close()
+		if err != nil || avatarConfig.Width != size || avatarConfig.Height != size {
+			t.Errorf("Expected PNG avatar of %dx%d but got %dx%d: %v", size, size, avatarConfig.Width, avatarConfig.Height, err)
+		}
+	}
+	_, err = mgr.GetUserAvatar(testAuthUser.ID, 100)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidAvatarSize {
+		t.Errorf("Expected 'invalid avatar size' for unsupported size but got: %v", err)
+	}
+
+	err = mgr.DeleteUserAvatar(testAuthUser.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete avatar: %v", err)
+	}
+	_, err = mgr.GetUserAvatar(testAuthUser.ID, 128)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.AvatarNotFound {
+		t.Errorf("Expected 'avatar not found' after deletion but got: %v", err)
+	}
+	err = mgr.DeleteUserAvatar(testAuthUser.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.AvatarNotFound {
+		t.Errorf("Expected 'avatar not found' when deleting missing avatar but got: %v", err)
+	}
+}
+
 func TestDeleteUser(t *testing.T) {
 	if testAuthSetupFailed {
 		t.Skip("Skip due to failed setup")
@@ -242,7 +1491,7 @@ func TestDeleteUser(t *testing.T) {
 	defer testAuthCleanup(mgr)
 
 	testAuthInsert(mgr)
-	sess, _ := mgr.LoginUser(testAuthUser.Email, testAuthUserPW)
+	sess, _ := mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
 
 	err := mgr.DeleteUser(testAuthUser.ID)
 	if err != nil {
@@ -252,7 +1501,7 @@ func TestDeleteUser(t *testing.T) {
 	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.UserNotFound {
 		t.Errorf("Getting deleted user was successfull or error is unequal to 'user not found': %v", err)
 	}
-	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW)
+	_, err = mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
 	if err == nil || err.(*fcerrors.FCError).Code != fcerrors.BadCredentials {
 		t.Errorf("Creating new session for deleted user succeeded or error is unequal to 'bad credentials': %v", err)
 	}
@@ -270,7 +1519,7 @@ func TestValidateSession(t *testing.T) {
 	defer testAuthCleanup(mgr)
 
 	testAuthInsert(mgr)
-	sess, _ := mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW)
+	sess, _ := mgr.LoginUser(testAuthUserAdmin.Email, testAuthUserAdminPW, testAuthClientIP)
 
 	res := mgr.ValidateSession(sess)
 	if !res {
@@ -306,7 +1555,7 @@ func TestGetSessionCount(t *testing.T) {
 	if count != 2 {
 		t.Errorf("Session count unequal to two: %d", count)
 	}
-	mgr.LoginUser(testAuthUser.Email, testAuthUserPW)
+	mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
 	count, err = mgr.GetSessionCount()
 	if err != nil {
 		t.Errorf("Failed to get session count after new session: %v", err)
@@ -329,7 +1578,7 @@ func TestDeleteSession(t *testing.T) {
 	if count != 2 {
 		t.Errorf("Session count unequal to two: %d", count)
 	}
-	sess, _ := mgr.LoginUser(testAuthUser.Email, testAuthUserPW)
+	sess, _ := mgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
 	count, _ = mgr.GetSessionCount()
 	if count != 3 {
 		t.Errorf("Session count unequal to three after new session: %d", count)
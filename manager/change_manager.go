@@ -0,0 +1,216 @@
+package manager
+
+import (
+	"sync"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Actions of changes which only appear in the change log, the others are the same as for activities
+const (
+	ChangeShared   = "shared"
+	ChangeUnshared = "unshared"
+)
+
+const (
+	changeMaxLimit      = 1000
+	changeCleanupHours  = 24
+	changeSecondsPerDay = 24 * 60 * 60
+)
+
+// ChangeManager keeps a monotonic log of all changes to the files of users, so sync clients can fetch only what changed since their last sync
+type ChangeManager struct {
+	fileChangeRep *repository.FileChangeRepository
+	retentionDays int
+	done          chan struct{}
+
+	subscribers     map[chan struct{}]bool
+	subscribersLock sync.Mutex
+}
+
+var changeManager *ChangeManager
+
+// CreateChangeManager creates a new singleton ChangeManager which can be used immediately.
+// Changes older than retentionDays are deleted regularly, with 0 they are kept forever.
+func CreateChangeManager(fileChangeRep *repository.FileChangeRepository, retentionDays int) *ChangeManager {
+	if changeManager != nil {
+		return changeManager
+	}
+
+	changeManager = &ChangeManager{
+		fileChangeRep: fileChangeRep,
+		retentionDays: retentionDays,
+		done:          make(chan struct{}),
+		subscribers:   make(map[chan struct{}]bool),
+	}
+	if retentionDays > 0 {
+		go changeManager.cleanupExpiredChangesRoutine()
+	}
+	return changeManager
+}
+
+// GetChangeManager returns the singleton instance of the ChangeManager
+func GetChangeManager() *ChangeManager {
+	return changeManager
+}
+
+// Close is used to end running tasks
+func (mgr *ChangeManager) Close() {
+	close(mgr.done)
+}
+
+func (mgr *ChangeManager) cleanupExpiredChangesRoutine() {
+	log.Trace("File changes will be kept for %v days", mgr.retentionDays)
+	mgr.cleanupExpiredChanges()
+	ticker := time.NewTicker(time.Hour * changeCleanupHours)
+	for {
+		select {
+		case <-mgr.done:
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			log.Trace("Cleaning expired file changes")
+			mgr.cleanupExpiredChanges()
+		}
+	}
+}
+
+// cleanupExpiredChanges deletes all changes which are older than the retention period
+func (mgr *ChangeManager) cleanupExpiredChanges() {
+	mgr.fileChangeRep.DeleteOlderThan(utils.GetTimestampNow() - int64(mgr.retentionDays)*changeSecondsPerDay)
+}
+
+// Record stores a change of a file as stored for its owner, oldPath is the full path of a moved file before moving it.
+// Failures are only logged, a missing change must not break the changes to the files.
+func (mgr *ChangeManager) Record(action string, fileInfo *models.FileInfo, oldPath string) {
+	err := mgr.fileChangeRep.Create(&models.FileChange{
+		Action:      action,
+		OwnerID:     fileInfo.OwnerID,
+		Path:        getFullPath(fileInfo),
+		OldPath:     oldPath,
+		IsDir:       fileInfo.IsDir,
+		Size:        fileInfo.Size,
+		LastChanged: fileInfo.LastChanged,
+	})
+	if err != nil {
+		log.Error(0, "Could not record change %s of %s%s: %v", action, fileInfo.Path, fileInfo.Name, err)
+		return
+	}
+	mgr.notifySubscribers()
+}
+
+// Subscribe returns a channel which receives a notification after changes have been recorded and a function to end the subscription.
+// The channel is closed if all subscriptions are ended by EndSubscriptions.
+// Notifications are not sent per change and not per user, subscribers have to get the changes they can see themselves.
+func (mgr *ChangeManager) Subscribe() (notifications <-chan struct{}, unsubscribe func()) {
+	notify := make(chan struct{}, 1)
+	mgr.subscribersLock.Lock()
+	mgr.subscribers[notify] = true
+	mgr.subscribersLock.Unlock()
+
+	unsubscribe = func() {
+		mgr.subscribersLock.Lock()
+		delete(mgr.subscribers, notify)
+		mgr.subscribersLock.Unlock()
+	}
+	return notify, unsubscribe
+}
+
+// EndSubscriptions closes the channels of all subscribers, so they stop waiting for changes, e.g. when the server shuts down
+func (mgr *ChangeManager) EndSubscriptions() {
+	mgr.subscribersLock.Lock()
+	defer mgr.subscribersLock.Unlock()
+	for notify := range mgr.subscribers {
+		close(notify)
+		delete(mgr.subscribers, notify)
+	}
+}
+
+// notifySubscribers notifies all subscribers without blocking, a subscriber with a pending notification does not need another one
+func (mgr *ChangeManager) notifySubscribers() {
+	mgr.subscribersLock.Lock()
+	defer mgr.subscribersLock.Unlock()
+	for notify := range mgr.subscribers {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// GetChanges returns up to limit changes the user can see after the cursor, oldest first, and the cursor to continue with.
+// Without a cursor, i.e. a negative one, or with a cursor which is unknown or whose following changes have already been deleted
+// only a reset with the current cursor is returned. The client then has to list all files and continue with that cursor.
+// Paths are returned as the user sees them, moves from or to outside of what he can see are returned as creations or deletions.
+// A shared file appearing is returned as a single change, its content has to be listed by the client.
+func (mgr *ChangeManager) GetChanges(user *models.User, cursor, limit int64) (changeList *models.FileChangeList, err error) {
+	if limit < 1 {
+		return nil, fcerrors.New(fcerrors.InvalidChangeQuery)
+	}
+	if limit > changeMaxLimit {
+		limit = changeMaxLimit
+	}
+
+	minID, maxID, err := mgr.fileChangeRep.GetIDRange()
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	// Deleting expired changes keeps the newest one, so there can only be missing changes below the lowest ID
+	if cursor < 0 || cursor > maxID || cursor < minID-1 {
+		return &models.FileChangeList{Changes: []*models.FileChange{}, Cursor: maxID, Reset: true}, nil
+	}
+
+	views, err := GetFileManager().getVisibleSubtrees(user, "/")
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.FileNotFound)
+	}
+	scopes := make([]*models.SubtreeScope, len(views))
+	for it, view := range views {
+		scopes[it] = view.scope
+	}
+
+	changes, err := mgr.fileChangeRep.GetAfter(scopes, cursor, limit+1)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	changeList = &models.FileChangeList{Changes: []*models.FileChange{}, Cursor: maxID}
+	if int64(len(changes)) > limit {
+		changes = changes[:limit]
+		changeList.HasMore = true
+	}
+	if len(changes) > 0 && (changeList.HasMore || changes[len(changes)-1].ID > maxID) {
+		changeList.Cursor = changes[len(changes)-1].ID
+	}
+
+	for _, change := range changes {
+		change.Path, change.OldPath = toViewPaths(views, change.OwnerID, change.Path, change.OldPath)
+		if change.Action == ActivityMoved && change.OldPath == "" {
+			change.Action = ActivityCreated
+		} else if change.Action == ActivityMoved && change.Path == "" {
+			change.Action, change.Path, change.OldPath = ActivityDeleted, change.OldPath, ""
+		}
+		if change.Path != "" {
+			changeList.Changes = append(changeList.Changes, change)
+		}
+	}
+	return
+}
+
+// recordChange stores a change if the ChangeManager has been created
+func recordChange(action string, fileInfo *models.FileInfo, oldPath string) {
+	if changeMgr := GetChangeManager(); changeMgr != nil {
+		changeMgr.Record(action, fileInfo, oldPath)
+	}
+}
+
+// recordFileChange records a change of a file as activity and in the change log
+func recordFileChange(action string, actorID int64, fileInfo *models.FileInfo, oldPath string) {
+	recordActivity(action, actorID, fileInfo, oldPath)
+	recordChange(action, fileInfo, oldPath)
+}
@@ -0,0 +1,153 @@
+package manager
+
+import (
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+)
+
+func testChangeCleanup(authMgr *AuthManager) {
+	changeManager = nil
+	testAuthCleanup(authMgr)
+}
+
+func testChangeSetup() (*AuthManager, *ChangeManager) {
+	authMgr := testAuthSetup()
+	fileChangeRep, _ := repository.CreateFileChangeRepository()
+	return authMgr, CreateChangeManager(fileChangeRep, 0)
+}
+
+func testChangePaths(changeList *models.FileChangeList) (paths []string) {
+	for _, change := range changeList.Changes {
+		paths = append(paths, change.Action+" "+change.OldPath+" "+change.Path)
+	}
+	return
+}
+
+func testChangeExpect(t *testing.T, changeList *models.FileChangeList, expPaths []string, desc string) {
+	paths := testChangePaths(changeList)
+	if len(paths) != len(expPaths) {
+		t.Errorf("Expected changes %v %s but got: %v", expPaths, desc, paths)
+		return
+	}
+	for it, path := range paths {
+		if path != expPaths[it] {
+			t.Errorf("Expected change '%s' %s but got '%s'", expPaths[it], desc, path)
+		}
+	}
+}
+
+func TestFileChanges(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testChangeSetup()
+	defer testChangeCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	start, err := mgr.GetChanges(testAuthUser, -1, 10)
+	if err != nil || !start.Reset || len(start.Changes) != 0 {
+		t.Fatalf("Expected reset without cursor but got: %v, %v", start, err)
+	}
+
+	fileMgr := GetFileManager()
+	fileMgr.CreateFile(testAuthUser, "/docs", true)
+	fileMgr.CreateFile(testAuthUser, "/docs/a.txt", false)
+	fileMgr.CreateFile(testAuthUser, "/other.txt", false)
+	fileMgr.ShareFile(testAuthUser, testAuthUserAdmin, "/docs")
+
+	userChanges, _ := mgr.GetChanges(testAuthUser, start.Cursor, 2)
+	if !userChanges.HasMore || userChanges.Reset {
+		t.Errorf("Expected more changes for owner after first page but got: %v", userChanges)
+	}
+	testChangeExpect(t, userChanges, []string{"created  /docs", "created  /docs/a.txt"}, "on first page of owner")
+	userChanges, _ = mgr.GetChanges(testAuthUser, userChanges.Cursor, 2)
+	if userChanges.HasMore {
+		t.Errorf("Expected no more changes for owner after second page but got: %v", userChanges)
+	}
+	testChangeExpect(t, userChanges, []string{"created  /other.txt"}, "on second page of owner")
+
+	adminChanges, _ := mgr.GetChanges(testAuthUserAdmin, start.Cursor, 10)
+	testChangeExpect(t, adminChanges, []string{"created  /docs", "created  /docs/a.txt", "shared  /docs"}, "for share recipient")
+
+	rootPath, docsPath := "/", "/docs"
+	fileMgr.UpdateFile(testAuthUser, "/docs/a.txt", &models.FileInfoUpdate{Path: &rootPath})
+	fileMgr.UpdateFile(testAuthUser, "/other.txt", &models.FileInfoUpdate{Path: &docsPath})
+	mountInfo, _ := fileMgr.fileInfoRep.GetByPath(testAuthUserAdmin.ID, "/", "docs")
+	fileMgr.DeleteShareEntryByID(mountInfo.ShareID, testAuthUser)
+	if _, err = fileMgr.DeleteShareEntryByID(mountInfo.ShareID, testAuthUser); err == nil || err.(*fcerrors.FCError).Code != fcerrors.ShareNotFound {
+		t.Errorf("Expected ShareNotFound for revoking a revoked share but got: %v", err)
+	}
+
+	userChanges, _ = mgr.GetChanges(testAuthUser, userChanges.Cursor, 10)
+	testChangeExpect(t, userChanges, []string{"moved /docs/a.txt /a.txt", "moved /other.txt /docs/other.txt"}, "for moves of owner")
+	adminChanges, _ = mgr.GetChanges(testAuthUserAdmin, adminChanges.Cursor, 10)
+	testChangeExpect(t, adminChanges, []string{"unshared  /docs"}, "for share recipient after revoking the share")
+	if _, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/docs", false); err == nil {
+		t.Errorf("Expected shared folder to be removed from recipient after revoking the share")
+	}
+	adminChanges, _ = mgr.GetChanges(testAuthUserAdmin, start.Cursor, 10)
+	testChangeExpect(t, adminChanges, []string{"shared  /docs", "unshared  /docs"}, "for former share recipient")
+
+	changeList, _ := mgr.GetChanges(testAuthUser, userChanges.Cursor+100, 10)
+	if !changeList.Reset || changeList.Cursor != userChanges.Cursor {
+		t.Errorf("Expected reset to current cursor for unknown cursor but got: %v", changeList)
+	}
+
+	mgr.fileChangeRep.DeleteOlderThan(utils.GetTimestampNow() + 1)
+	changeList, _ = mgr.GetChanges(testAuthUser, start.Cursor, 10)
+	if !changeList.Reset {
+		t.Errorf("Expected reset for cursor of deleted changes but got: %v", changeList)
+	}
+	changeList, _ = mgr.GetChanges(testAuthUser, userChanges.Cursor, 10)
+	if changeList.Reset || len(changeList.Changes) != 0 {
+		t.Errorf("Expected no changes for current cursor after deleting old changes but got: %v", changeList)
+	}
+
+	_, err = mgr.GetChanges(testAuthUser, 0, 0)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidChangeQuery {
+		t.Errorf("Expected 'invalid change query' for limit of 0 but got: %v", err)
+	}
+}
+
+func TestChangeSubscriptions(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testChangeSetup()
+	defer testChangeCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	notifications, unsubscribe := mgr.Subscribe()
+	otherNotifications, _ := mgr.Subscribe()
+	GetFileManager().CreateFile(testAuthUser, "/a.txt", false)
+	GetFileManager().CreateFile(testAuthUser, "/b.txt", false)
+
+	select {
+	case <-notifications:
+	default:
+		t.Errorf("Expected notification after recording changes")
+	}
+	select {
+	case <-notifications:
+		t.Errorf("Expected only one pending notification for multiple changes")
+	default:
+	}
+
+	unsubscribe()
+	GetFileManager().CreateFile(testAuthUser, "/c.txt", false)
+	select {
+	case <-notifications:
+		t.Errorf("Expected no notification after unsubscribing")
+	default:
+	}
+
+	mgr.EndSubscriptions()
+	<-otherNotifications
+	if _, ok := <-otherNotifications; ok {
+		t.Errorf("Expected notification channel to be closed after ending all subscriptions")
+	}
+}
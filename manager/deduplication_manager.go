@@ -0,0 +1,74 @@
+package manager
+
+import (
+	"time"
+
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	log "gopkg.in/clog.v1"
+)
+
+// DeduplicationManager manages the blobs storing the content of the files of users once, it only exists if the deduplication is enabled
+type DeduplicationManager struct {
+	storageRep *repository.BlobStorageRepository
+	// collectInterval is the interval in hours in which unreferenced blobs are deleted, 0 only deletes them on request
+	collectInterval int
+	done            chan struct{}
+}
+
+var deduplicationManager *DeduplicationManager
+
+// CreateDeduplicationManager creates a new singleton DeduplicationManager for the storage deduplicating the files of users.
+// Unreferenced blobs are deleted every collectInterval hours if it is greater than 0.
+func CreateDeduplicationManager(storageRep *repository.BlobStorageRepository, collectInterval int) *DeduplicationManager {
+	if deduplicationManager != nil {
+		return deduplicationManager
+	}
+
+	deduplicationManager = &DeduplicationManager{
+		storageRep:      storageRep,
+		collectInterval: collectInterval,
+		done:            make(chan struct{}),
+	}
+	if collectInterval > 0 {
+		go deduplicationManager.collectGarbageRoutine()
+	}
+	return deduplicationManager
+}
+
+// GetDeduplicationManager returns the singleton instance of the DeduplicationManager, it is nil if the deduplication is not enabled
+func GetDeduplicationManager() *DeduplicationManager {
+	return deduplicationManager
+}
+
+// Close is used to end running tasks
+func (mgr *DeduplicationManager) Close() {
+	close(mgr.done)
+}
+
+func (mgr *DeduplicationManager) collectGarbageRoutine() {
+	log.Trace("Unreferenced blobs will be deleted every %v hours", mgr.collectInterval)
+	ticker := time.NewTicker(time.Hour * time.Duration(mgr.collectInterval))
+	for {
+		select {
+		case <-mgr.done:
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			mgr.CollectGarbage()
+		}
+	}
+}
+
+// CollectGarbage deletes all blobs which are not referenced by any file anymore and returns their count and the sum of their sizes
+func (mgr *DeduplicationManager) CollectGarbage() (count, size int64, err error) {
+	log.Trace("Deleting unreferenced blobs")
+	count, size, err = mgr.storageRep.CollectGarbage()
+	if err != nil {
+		return count, size, fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+	if count > 0 {
+		log.Info("Deleted %d unreferenced blobs freeing %d bytes", count, size)
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package manager
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/repository"
+)
+
+var testDeduplicationBlobRep *repository.BlobRepository
+
+func testDeduplicationCleanup(authMgr *AuthManager) {
+	if deduplicationManager != nil {
+		deduplicationManager.Close()
+		deduplicationManager = nil
+	}
+	testAuthCleanup(authMgr)
+}
+
+// testDeduplicationSetup sets up the managers with the files deduplicated below testAuthDataFolder
+func testDeduplicationSetup() (*AuthManager, *DeduplicationManager) {
+	testAuthCleanup(nil)
+	repository.InitDatabaseConnection("", "", "", "", 0, testAuthDBName)
+	testDeduplicationBlobRep, _ = repository.CreateBlobRepository()
+	baseRep, _ := repository.CreateFileSystemRepository(testAuthDataFolder, ".tmp", 1, 1)
+	storageRep, _ := repository.CreateBlobStorageRepository(baseRep, testDeduplicationBlobRep, []byte("deduplication test key"), "blobs", ".tmp")
+	authMgr := testAuthSetupWithStorage(storageRep)
+	testAuthInsert(authMgr)
+	return authMgr, CreateDeduplicationManager(storageRep, 0)
+}
+
+func TestDeduplicatedFiles(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testDeduplicationSetup()
+	defer testDeduplicationCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	content := strings.Repeat("artifact", 1000)
+	fileMgr.CreateFile(testAuthUser, "/builds", true)
+	fileInfo, err := fileMgr.WriteFile(testAuthUser, "/builds/a.bin", strings.NewReader(content))
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if fileInfo.Size != int64(len(content)) || fileInfo.BlobHash == "" {
+		t.Errorf("Expected info of written file with size of the content and blob hash: %v", fileInfo)
+	}
+	otherInfo, _ := fileMgr.WriteFile(testAuthUserAdmin, "/b.bin", strings.NewReader(content))
+	if otherInfo.BlobHash != fileInfo.BlobHash {
+		t.Errorf("Expected files with identical content to reference the same blob: %s != %s", otherInfo.BlobHash, fileInfo.BlobHash)
+	}
+
+	rootInfo, _ := fileMgr.GetFileInfo(testAuthUser, "/", false)
+	err = fileMgr.copyFile(testAuthUser, fileInfo, "c.bin", rootInfo)
+	if err != nil {
+		t.Fatalf("Failed to copy file: %v", err)
+	}
+	copyInfo, _ := fileMgr.GetFileInfo(testAuthUser, "/c.bin", false)
+	if copyInfo.BlobHash != fileInfo.BlobHash || copyInfo.Size != fileInfo.Size {
+		t.Errorf("Expected copy to reference the same blob: %v", copyInfo)
+	}
+	if count, size, _ := testDeduplicationBlobRep.Count(); count != 1 || size != int64(len(content)) {
+		t.Errorf("Expected identical content to be stored once, got %d blobs with %d bytes", count, size)
+	}
+
+	err = fileMgr.ScanUserFolderForChanges(testAuthUser)
+	if err != nil {
+		t.Fatalf("Failed to rescan files: %v", err)
+	}
+	if copyInfo, _ = fileMgr.GetFileInfo(testAuthUser, "/c.bin", false); copyInfo.BlobHash != fileInfo.BlobHash || copyInfo.Size != fileInfo.Size {
+		t.Errorf("Expected rescan to keep size and blob hash: %v", copyInfo)
+	}
+
+	file, _, err := fileMgr.OpenFile(testAuthUser, "/c.bin")
+	if err != nil {
+		t.Fatalf("Failed to open copied file: %v", err)
+	}
+	read, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(read) != content {
+		t.Errorf("Content of copied file unequal to written content")
+	}
+
+	fileMgr.DeleteFile(testAuthUser, "/builds")
+	fileMgr.DeleteFile(testAuthUser, "/c.bin")
+	if count, _, _ := mgr.CollectGarbage(); count != 0 {
+		t.Errorf("Expected blob still referenced by another user not to be deleted, got %d", count)
+	}
+	fileMgr.DeleteFile(testAuthUserAdmin, "/b.bin")
+	if count, size, err := mgr.CollectGarbage(); count != 1 || size != int64(len(content)) || err != nil {
+		t.Errorf("Expected unreferenced blob to be deleted, got %d, %d, %v", count, size, err)
+	}
+}
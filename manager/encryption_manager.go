@@ -0,0 +1,59 @@
+package manager
+
+import (
+	"sync"
+
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	log "gopkg.in/clog.v1"
+)
+
+// EncryptionManager manages the encryption at rest of the files of users, it only exists if the encryption is enabled
+type EncryptionManager struct {
+	storageRep *repository.EncryptedStorageRepository
+
+	// encryptLock makes sure existing files are only encrypted by one run at a time
+	encryptLock sync.Mutex
+}
+
+var encryptionManager *EncryptionManager
+
+// CreateEncryptionManager creates a new singleton EncryptionManager for the storage encrypting the files of users
+func CreateEncryptionManager(storageRep *repository.EncryptedStorageRepository) *EncryptionManager {
+	if encryptionManager != nil {
+		return encryptionManager
+	}
+
+	encryptionManager = &EncryptionManager{
+		storageRep: storageRep,
+	}
+	return encryptionManager
+}
+
+// GetEncryptionManager returns the singleton instance of the EncryptionManager, it is nil if the encryption is not enabled
+func GetEncryptionManager() *EncryptionManager {
+	return encryptionManager
+}
+
+// EncryptExistingFiles encrypts all files which have been stored before the encryption was enabled and returns their count
+func (mgr *EncryptionManager) EncryptExistingFiles() (int64, error) {
+	mgr.encryptLock.Lock()
+	defer mgr.encryptLock.Unlock()
+
+	log.Info("Encrypting existing files")
+	count, err := mgr.storageRep.EncryptExisting("/")
+	if err != nil {
+		return count, fcerrors.Wrap(err, fcerrors.Filesystem)
+	}
+	log.Info("Encrypted %d existing files", count)
+	return count, nil
+}
+
+// DeleteUserKey deletes the key of a deleted user, so his files cannot be decrypted from backups anymore
+func (mgr *EncryptionManager) DeleteUserKey(userID int64) error {
+	err := mgr.storageRep.DeleteUserKey(userID)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package manager
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/crypt"
+	"github.com/freecloudio/server/repository"
+)
+
+var testEncryptionMasterKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testEncryptionCleanup(authMgr *AuthManager) {
+	encryptionManager = nil
+	testAuthCleanup(authMgr)
+}
+
+// testEncryptionSetup sets up the managers with the files encrypted below testAuthDataFolder
+func testEncryptionSetup() (*AuthManager, *EncryptionManager) {
+	testAuthCleanup(nil)
+	repository.InitDatabaseConnection("", "", "", "", 0, testAuthDBName)
+	userKeyRep, _ := repository.CreateUserKeyRepository()
+	baseRep, _ := repository.CreateFileSystemRepository(testAuthDataFolder, ".tmp", 1, 1)
+	storageRep, _ := repository.CreateEncryptedStorageRepository(baseRep, userKeyRep, testEncryptionMasterKey, ".tmp")
+	authMgr := testAuthSetupWithStorage(storageRep)
+	testAuthInsert(authMgr)
+	return authMgr, CreateEncryptionManager(storageRep)
+}
+
+func testEncryptionReadStored(userID int64, path string) string {
+	content, _ := ioutil.ReadFile(filepath.Join(testAuthDataFolder, filepath.FromSlash(GetFileManager().getUserPathWithID(userID)), path))
+	return string(content)
+}
+
+func TestEncryptedFiles(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, _ := testEncryptionSetup()
+	defer testEncryptionCleanup(authMgr)
+	mgr := GetFileManager()
+
+	mgr.CreateFile(testAuthUser, "/docs", true)
+	fileInfo, err := mgr.WriteFile(testAuthUser, "/docs/a.txt", strings.NewReader("secret content"))
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if fileInfo.Size != 14 {
+		t.Errorf("Expected size of the content for written file but got: %d", fileInfo.Size)
+	}
+	if stored := testEncryptionReadStored(testAuthUser.ID, "docs/a.txt"); !crypt.IsEncrypted([]byte(stored)) || strings.Contains(stored, "secret") {
+		t.Errorf("Expected written file to be stored encrypted")
+	}
+
+	file, _, err := mgr.OpenFile(testAuthUser, "/docs/a.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "secret content" {
+		t.Errorf("Expected decrypted content but got: %s", content)
+	}
+
+	err = mgr.ScanUserFolderForChanges(testAuthUser)
+	if err != nil {
+		t.Fatalf("Failed to rescan files: %v", err)
+	}
+	if fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/docs/a.txt", false); fileInfo.Size != 14 {
+		t.Errorf("Expected size of the content after rescan but got: %d", fileInfo.Size)
+	}
+
+	mgr.ShareFile(testAuthUser, testAuthUserAdmin, "/docs")
+	file, _, err = mgr.OpenFile(testAuthUserAdmin, "/docs/a.txt")
+	if err != nil {
+		t.Fatalf("Failed to open shared file: %v", err)
+	}
+	content, _ = ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "secret content" {
+		t.Errorf("Expected decrypted content of shared file but got: %s", content)
+	}
+	mgr.WriteFile(testAuthUser, "/docs/b.txt", strings.NewReader("second"))
+
+	zipPath, err := mgr.ZipFiles(testAuthUser, []string{"/docs"})
+	if err != nil {
+		t.Fatalf("Failed to zip folder: %v", err)
+	}
+	file, fileInfo, err = mgr.OpenFile(testAuthUser, zipPath)
+	if err != nil {
+		t.Fatalf("Failed to open zip: %v", err)
+	}
+	defer file.Close()
+	zipReader, err := zip.NewReader(file, fileInfo.Size)
+	if err != nil || len(zipReader.File) != 2 {
+		t.Fatalf("Expected zip containing both files: %v", err)
+	}
+	zipFile, _ := zipReader.File[0].Open()
+	content, _ = ioutil.ReadAll(zipFile)
+	zipFile.Close()
+	if string(content) != "secret content" {
+		t.Errorf("Expected decrypted content in zip but got: %s", content)
+	}
+}
+
+func TestEncryptExistingFiles(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testEncryptionSetup()
+	defer testEncryptionCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	path := filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUser), "plain.txt")
+	ioutil.WriteFile(path, []byte("plain"), 0644)
+	fileMgr.ScanUserFolderForChanges(testAuthUser)
+	fileMgr.WriteFile(testAuthUser, "/encrypted.txt", strings.NewReader("encrypted"))
+
+	count, err := mgr.EncryptExistingFiles()
+	if err != nil || count != 1 {
+		t.Fatalf("Expected one existing file to be encrypted: %d, %v", count, err)
+	}
+	if stored := testEncryptionReadStored(testAuthUser.ID, "plain.txt"); !crypt.IsEncrypted([]byte(stored)) {
+		t.Errorf("Expected existing file to be stored encrypted but got: %s", stored)
+	}
+	file, fileInfo, err := fileMgr.OpenFile(testAuthUser, "/plain.txt")
+	if err != nil {
+		t.Fatalf("Failed to open encrypted file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "plain" || fileInfo.Size != 5 {
+		t.Errorf("Expected content of encrypted existing file but got: %s, %v", content, fileInfo)
+	}
+
+	if count, _ = mgr.EncryptExistingFiles(); count != 0 {
+		t.Errorf("Expected no files to be encrypted again but got: %d", count)
+	}
+}
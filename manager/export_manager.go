@@ -0,0 +1,145 @@
+package manager
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// ExportManager builds exports of all personal data stored about a user in the background
+type ExportManager struct {
+	dataExportRep *repository.DataExportRepository
+}
+
+var exportManager *ExportManager
+
+// CreateExportManager creates a new singleton ExportManager which can be used immediately.
+// Exports left unfinished by a previous run are marked as failed.
+func CreateExportManager(dataExportRep *repository.DataExportRepository) *ExportManager {
+	if exportManager != nil {
+		return exportManager
+	}
+
+	err := dataExportRep.FailUnfinished()
+	if err != nil {
+		log.Warn("Could not mark unfinished data exports as failed: %v", err)
+	}
+
+	exportManager = &ExportManager{
+		dataExportRep: dataExportRep,
+	}
+	return exportManager
+}
+
+// GetExportManager returns the singleton instance of the ExportManager
+func GetExportManager() *ExportManager {
+	return exportManager
+}
+
+// RequestDataExport starts building an export for the user in the background.
+// If an export of the user is still pending or running, that one is returned instead of starting another one.
+func (mgr *ExportManager) RequestDataExport(user *models.User) (dataExport *models.DataExport, err error) {
+	dataExport, err = mgr.dataExportRep.GetUnfinishedForUser(user.ID)
+	if err == nil {
+		return
+	} else if !repository.IsRecordNotFoundError(err) {
+		log.Error(0, "Could not get unfinished data export of user %d: %v", user.ID, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	dataExport = &models.DataExport{UserID: user.ID, Status: repository.DataExportPending}
+	err = mgr.dataExportRep.Create(dataExport)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	job := *dataExport
+	go mgr.runDataExport(&job, user)
+	return
+}
+
+// GetDataExport returns a data export of the user, finished exports whose file has been cleaned up are reported as expired
+func (mgr *ExportManager) GetDataExport(user *models.User, dataExportID int64) (*models.DataExport, error) {
+	dataExport, err := mgr.dataExportRep.GetByIDForUser(dataExportID, user.ID)
+	if repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.New(fcerrors.DataExportNotFound)
+	} else if err != nil {
+		log.Error(0, "Could not get data export %d of user %d: %v", dataExportID, user.ID, err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if dataExport.Status == repository.DataExportFinished && !GetFileManager().TmpFileExists(user, dataExport.Path) {
+		dataExport.Status = repository.DataExportExpired
+		dataExport.Path = ""
+	}
+	return dataExport, nil
+}
+
+// DeleteDataExportsForUser removes all data exports of a deleted user
+func (mgr *ExportManager) DeleteDataExportsForUser(userID int64) error {
+	return mgr.dataExportRep.DeleteAllForUser(userID)
+}
+
+func (mgr *ExportManager) runDataExport(dataExport *models.DataExport, user *models.User) {
+	dataExport.Status = repository.DataExportRunning
+	err := mgr.dataExportRep.Update(dataExport)
+	if err != nil {
+		return
+	}
+
+	path, err := mgr.buildDataExport(user)
+	if err != nil {
+		log.Error(0, "Data export %d of user %d failed: %v", dataExport.ID, user.ID, err)
+		dataExport.Status = repository.DataExportFailed
+	} else {
+		log.Info("Finished data export %d of user %d", dataExport.ID, user.ID)
+		dataExport.Status = repository.DataExportFinished
+		dataExport.Path = utils.ConvertToSlash(path, false)
+	}
+	dataExport.Finished = utils.GetTimestampNow()
+	mgr.dataExportRep.Update(dataExport)
+}
+
+func (mgr *ExportManager) buildDataExport(user *models.User) (path string, err error) {
+	profile, err := GetAuthManager().GetUserByID(user.ID)
+	if err != nil {
+		return
+	}
+	profile.Password = ""
+
+	sessions, err := GetAuthManager().GetSessionsForUser(user.ID)
+	if err != nil {
+		return
+	}
+	// Tokens are credentials, exporting them would allow anyone with the export to take over the sessions
+	for _, session := range sessions {
+		session.Token = ""
+	}
+
+	fileMgr := GetFileManager()
+	sharedByUser, err := fileMgr.ListSharedFilesForUser(user)
+	if err != nil {
+		return
+	}
+	sharedWithUser, err := fileMgr.ListFilesSharedWithUser(user)
+	if err != nil {
+		return
+	}
+	starred, err := fileMgr.GetStarredFileInfosForUser(user)
+	if err != nil {
+		return
+	}
+
+	documents := map[string]interface{}{
+		"profile.json":  profile,
+		"sessions.json": sessions,
+		"shares.json": map[string][]*models.FileInfo{
+			"sharedByMe":   sharedByUser,
+			"sharedWithMe": sharedWithUser,
+		},
+		"stars.json": starred,
+	}
+	return fileMgr.ExportUserData(profile, documents)
+}
@@ -0,0 +1,130 @@
+package manager
+
+import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+func testExportCleanup(mgr *AuthManager) {
+	exportManager = nil
+	testAuthCleanup(mgr)
+}
+
+func testExportSetup() (*AuthManager, *ExportManager) {
+	authMgr := testAuthSetup()
+	dataExportRep, _ := repository.CreateDataExportRepository()
+	return authMgr, CreateExportManager(dataExportRep)
+}
+
+func testExportWait(t *testing.T, mgr *ExportManager, user *models.User, dataExportID int64) *models.DataExport {
+	for it := 0; it < 100; it++ {
+		dataExport, err := mgr.GetDataExport(user, dataExportID)
+		if err != nil {
+			t.Fatalf("Failed to get data export: %v", err)
+		}
+		if dataExport.Status != repository.DataExportPending && dataExport.Status != repository.DataExportRunning {
+			return dataExport
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatal("Data export did not finish in time")
+	return nil
+}
+
+func TestDataExport(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testExportSetup()
+	defer testExportCleanup(authMgr)
+
+	testAuthInsert(authMgr)
+	fileMgr := GetFileManager()
+	fileMgr.CreateFile(testAuthUser, "/doc.txt", false)
+	fileMgr.ShareFile(testAuthUser, testAuthUserAdmin, "/doc.txt")
+	authMgr.LoginUser(testAuthUser.Email, testAuthUserPW, testAuthClientIP)
+
+	dataExport, err := mgr.RequestDataExport(testAuthUser)
+	if err != nil {
+		t.Fatalf("Failed to request data export: %v", err)
+	}
+	dataExport = testExportWait(t, mgr, testAuthUser, dataExport.ID)
+	if dataExport.Status != repository.DataExportFinished || dataExport.Finished == 0 {
+		t.Fatalf("Expected finished data export but got status %s", dataExport.Status)
+	}
+
+	zipReader, err := zip.OpenReader(filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUser), dataExport.Path))
+	if err != nil {
+		t.Fatalf("Failed to open data export at %s: %v", dataExport.Path, err)
+	}
+	var names []string
+	for _, zipFile := range zipReader.File {
+		names = append(names, zipFile.Name)
+		if zipFile.Name != "profile.json" {
+			continue
+		}
+		reader, _ := zipFile.Open()
+		profile, _ := ioutil.ReadAll(reader)
+		reader.Close()
+		if !strings.Contains(string(profile), testAuthUser.Email) || strings.Contains(string(profile), `"password"`) {
+			t.Errorf("Expected profile with email and without password but got: %s", profile)
+		}
+	}
+	zipReader.Close()
+	sort.Strings(names)
+	expNames := "files/doc.txt profile.json sessions.json shares.json stars.json"
+	if strings.Join(names, " ") != expNames {
+		t.Errorf("Expected files '%s' in data export but got '%s'", expNames, strings.Join(names, " "))
+	}
+
+	_, err = mgr.GetDataExport(testAuthUserAdmin, dataExport.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.DataExportNotFound {
+		t.Errorf("Expected 'data export not found' for data export of other user but got: %v", err)
+	}
+
+	os.Remove(filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUser), dataExport.Path))
+	dataExport, err = mgr.GetDataExport(testAuthUser, dataExport.ID)
+	if err != nil || dataExport.Status != repository.DataExportExpired || dataExport.Path != "" {
+		t.Errorf("Expected expired data export without path after cleanup but got: %v, %v", dataExport, err)
+	}
+}
+
+func TestDeleteCurrentUser(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testExportSetup()
+	defer testExportCleanup(authMgr)
+
+	testAuthInsert(authMgr)
+	dataExport, _ := mgr.RequestDataExport(testAuthUser)
+	testExportWait(t, mgr, testAuthUser, dataExport.ID)
+
+	err := authMgr.DeleteCurrentUser(testAuthUser.ID, true)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.RetentionNotConfirmed {
+		t.Errorf("Expected 'retention not confirmed' for differing retention choice but got: %v", err)
+	}
+
+	err = authMgr.DeleteCurrentUser(testAuthUser.ID, false)
+	if err != nil {
+		t.Fatalf("Failed to delete current user: %v", err)
+	}
+	_, err = authMgr.GetUserByID(testAuthUser.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserNotFound {
+		t.Errorf("Expected 'user not found' for deleted user but got: %v", err)
+	}
+	_, err = mgr.GetDataExport(testAuthUser, dataExport.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.DataExportNotFound {
+		t.Errorf("Expected 'data export not found' for data export of deleted user but got: %v", err)
+	}
+}
@@ -0,0 +1,142 @@
+package manager
+
+import (
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"strings"
+
+	"github.com/freecloudio/server/config"
+	"github.com/freecloudio/server/models"
+	log "gopkg.in/clog.v1"
+)
+
+// ErrChecksumMismatch indicates uploaded content not matching the checksum the client expected, the upload is rejected then
+var ErrChecksumMismatch = errors.New("vfs: Checksum of the uploaded content does not match")
+
+// Checksums are the hex encoded checksums of the content of a file, empty ones are unknown or not expected
+type Checksums struct {
+	SHA256 string
+	MD5    string
+}
+
+// expectedChecksumsKey is the context key for the checksums clients of file servers expect for their uploads
+type expectedChecksumsKey struct{}
+
+// WithExpectedChecksums returns a context of a request whose uploads are rejected if they do not match the checksums
+func WithExpectedChecksums(ctx context.Context, checksums Checksums) context.Context {
+	return context.WithValue(ctx, expectedChecksumsKey{}, checksums)
+}
+
+func getExpectedChecksums(ctx context.Context) Checksums {
+	checksums, _ := ctx.Value(expectedChecksumsKey{}).(Checksums)
+	return checksums
+}
+
+// matches returns whether the computed checksums match all expected ones, case is ignored as clients use both for hex
+func (checksums Checksums) matches(expected Checksums) bool {
+	return (expected.SHA256 == "" || strings.EqualFold(expected.SHA256, checksums.SHA256)) &&
+		(expected.MD5 == "" || strings.EqualFold(expected.MD5, checksums.MD5))
+}
+
+// set stores the checksums in the info of the file
+func (checksums Checksums) set(fileInfo *models.FileInfo) {
+	fileInfo.Sha256 = checksums.SHA256
+	fileInfo.Md5 = checksums.MD5
+}
+
+// isMD5Enabled returns whether MD5 checksums are computed besides SHA-256 ones for legacy tools
+func isMD5Enabled() bool {
+	return config.GetBool("fs.checksums_md5")
+}
+
+// computeChecksums reads the whole content of the file at the path in the storage for computing its checksums
+func (mgr *FileManager) computeChecksums(storagePath string) (checksums Checksums, err error) {
+	// Deduplicated content has been hashed when it was stored already
+	if fileInfo, infoErr := mgr.storageRep.GetInfo("", storagePath); infoErr == nil && fileInfo.BlobHash != "" && !isMD5Enabled() {
+		checksums.SHA256 = fileInfo.BlobHash
+		return
+	}
+
+	file, err := mgr.storageRep.OpenFile(storagePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	return hashContent(file)
+}
+
+// hashContent computes the checksums of the content read from the reader
+func hashContent(reader io.Reader) (checksums Checksums, err error) {
+	sha256Hash := sha256.New()
+	var md5Hash hash.Hash
+	writer := io.Writer(sha256Hash)
+	if isMD5Enabled() {
+		md5Hash = md5.New()
+		writer = io.MultiWriter(sha256Hash, md5Hash)
+	}
+	_, err = io.Copy(writer, reader)
+	if err != nil {
+		return
+	}
+
+	checksums.SHA256 = hex.EncodeToString(sha256Hash.Sum(nil))
+	if md5Hash != nil {
+		checksums.MD5 = hex.EncodeToString(md5Hash.Sum(nil))
+	}
+	return
+}
+
+// FileETag returns the entity tag of the content of the file, it is derived from the info if the file has not been hashed yet
+func FileETag(fileInfo *models.FileInfo) string {
+	if fileInfo.Sha256 != "" {
+		return `"` + fileInfo.Sha256 + `"`
+	}
+	return fmt.Sprintf(`"%x%x"`, fileInfo.LastChanged, fileInfo.Size)
+}
+
+// FileDigest returns the checksums of the file for the Digest header defined by RFC 3230, it is empty if the file has not been hashed yet
+func FileDigest(fileInfo *models.FileInfo) string {
+	digests := []string{}
+	if sum, err := hex.DecodeString(fileInfo.Sha256); err == nil && len(sum) == sha256.Size {
+		digests = append(digests, "SHA-256="+base64.StdEncoding.EncodeToString(sum))
+	}
+	if sum, err := hex.DecodeString(fileInfo.Md5); err == nil && len(sum) == md5.Size {
+		digests = append(digests, "MD5="+base64.StdEncoding.EncodeToString(sum))
+	}
+	return strings.Join(digests, ",")
+}
+
+// refreshChecksums computes the checksums of the file if its content changed or they are missing and returns whether they changed.
+// Files in temp folders and mounted storages are not hashed, as reading temporary or remote content completely is not worth it.
+// Failures are only logged, the checksums are computed again by the next scan then.
+func (mgr *FileManager) refreshChecksums(fileInfo *models.FileInfo, contentChanged bool) bool {
+	if fileInfo.IsDir || mgr.isTmpPath(fileInfo) {
+		return false
+	}
+	if _, _, ok := getMount(mgr.getStoragePath(fileInfo)); ok {
+		if !contentChanged || fileInfo.Sha256 == "" && fileInfo.Md5 == "" {
+			return false
+		}
+		Checksums{}.set(fileInfo)
+		return true
+	}
+	if !contentChanged && fileInfo.Sha256 != "" && (fileInfo.Md5 != "" || !isMD5Enabled()) {
+		return false
+	}
+
+	checksums, err := mgr.computeChecksums(mgr.getStoragePath(fileInfo))
+	if err != nil {
+		log.Warn("Could not compute checksums of %s%s of user %d: %v", fileInfo.Path, fileInfo.Name, fileInfo.OwnerID, err)
+		return false
+	}
+	changed := checksums.SHA256 != fileInfo.Sha256 || checksums.MD5 != fileInfo.Md5
+	checksums.set(fileInfo)
+	return changed
+}
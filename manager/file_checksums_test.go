@@ -0,0 +1,101 @@
+package manager
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+)
+
+func testChecksumsSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestFileChecksums(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	testAuthCleanup(nil)
+	repository.InitDatabaseConnection("", "", "", "", 0, testAuthDBName)
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+	mgr := GetFileManager()
+
+	fileInfo, err := mgr.WriteFile(testAuthUser, "/a.txt", strings.NewReader("first"))
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if fileInfo.Sha256 != testChecksumsSHA256("first") || fileInfo.Md5 != "" {
+		t.Errorf("Expected SHA-256 checksum of the written content but got: %s, %s", fileInfo.Sha256, fileInfo.Md5)
+	}
+
+	_, err = mgr.WriteVerifiedFile(testAuthUser, "/a.txt", strings.NewReader("second"), Checksums{SHA256: testChecksumsSHA256("other")})
+	if err != ErrChecksumMismatch {
+		t.Errorf("Expected mismatching upload to be rejected but got: %v", err)
+	}
+	file, fileInfo, err := mgr.OpenFile(testAuthUser, "/a.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "first" || fileInfo.Sha256 != testChecksumsSHA256("first") {
+		t.Errorf("Expected rejected upload to keep the previous content but got: %s", content)
+	}
+
+	fileInfo, err = mgr.WriteVerifiedFile(testAuthUser, "/a.txt", strings.NewReader("second"), Checksums{SHA256: strings.ToUpper(testChecksumsSHA256("second"))})
+	if err != nil || fileInfo.Sha256 != testChecksumsSHA256("second") {
+		t.Errorf("Expected matching upload to be written with its checksum: %v, %v", fileInfo, err)
+	}
+
+	path := filepath.Join(testAuthDataFolder, mgr.getUserPath(testAuthUser), "a.txt")
+	ioutil.WriteFile(path, []byte("changed on disk"), 0644)
+	ioutil.WriteFile(filepath.Join(filepath.Dir(path), "b.txt"), []byte("new on disk"), 0644)
+	mgr.CreateFile(testAuthUser, "/folder", true)
+	err = mgr.ScanUserFolderForChanges(testAuthUser)
+	if err != nil {
+		t.Fatalf("Failed to rescan files: %v", err)
+	}
+	if fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/a.txt", false); fileInfo.Sha256 != testChecksumsSHA256("changed on disk") {
+		t.Errorf("Expected rescan to update the checksum of the changed file but got: %s", fileInfo.Sha256)
+	}
+	if fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/b.txt", false); fileInfo.Sha256 != testChecksumsSHA256("new on disk") {
+		t.Errorf("Expected rescan to record the checksum of the new file but got: %s", fileInfo.Sha256)
+	}
+	if fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/folder", false); fileInfo.Sha256 != "" {
+		t.Errorf("Expected no checksum for folder but got: %s", fileInfo.Sha256)
+	}
+
+	fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/b.txt", false)
+	fileInfo.Sha256 = ""
+	mgr.fileInfoRep.Update(fileInfo)
+	mgr.ScanUserFolderForChanges(testAuthUser)
+	if fileInfo, _ = mgr.GetFileInfo(testAuthUser, "/b.txt", false); fileInfo.Sha256 != testChecksumsSHA256("new on disk") {
+		t.Errorf("Expected rescan to backfill the missing checksum but got: %s", fileInfo.Sha256)
+	}
+}
+
+func TestFileDigestAndETag(t *testing.T) {
+	fileInfo := &models.FileInfo{Sha256: testChecksumsSHA256("abc"), Md5: "900150983cd24fb0d6963f7d28e17f72"}
+	if digest := FileDigest(fileInfo); digest != "SHA-256=ungWv48Bz+pBQUDeXa4iI7ADYaOWF3qctBD/YfIAFa0=,MD5=kAFQmDzST7DWlj99KOF/cg==" {
+		t.Errorf("Expected digest of both checksums but got: %s", digest)
+	}
+	if etag := FileETag(fileInfo); etag != `"`+fileInfo.Sha256+`"` {
+		t.Errorf("Expected SHA-256 checksum as ETag but got: %s", etag)
+	}
+
+	fileInfo = &models.FileInfo{LastChanged: 255, Size: 16}
+	if digest := FileDigest(fileInfo); digest != "" {
+		t.Errorf("Expected no digest for file without checksums but got: %s", digest)
+	}
+	if etag := FileETag(fileInfo); etag != `"ff10"` {
+		t.Errorf("Expected ETag derived from the info but got: %s", etag)
+	}
+}
@@ -1,8 +1,11 @@
 package manager
 
 import (
+	"archive/zip"
+	"encoding/json"
 	"fmt"
-	"os"
+	"image/png"
+	"io"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -10,6 +13,7 @@ import (
 
 	"github.com/freecloudio/server/config"
 	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
 	"github.com/freecloudio/server/utils"
 
 	"errors"
@@ -25,26 +29,49 @@ var (
 	// ErrForbiddenPathName indicates a path having weird characters that nobody should use, also these characters are forbidden on Windows
 	ErrForbiddenPathName = errors.New("paths cannot contain the following characters: <>:\"\\|?*")
 	ErrFileNotExist      = errors.New("file does not exist")
+	// ErrFileExists indicates a file or folder with the same name in the target folder
+	ErrFileExists = errors.New("vfs: File already exists")
+	// ErrInvalidMoveTarget indicates moving the root or temp folder, into a folder not owned by the user or into itself
+	ErrInvalidMoveTarget = errors.New("vfs: File cannot be moved there")
+	// ErrOpenFolder indicates trying to read a folder like a file
+	ErrOpenFolder = errors.New("vfs: Folders have to be zipped before downloading them")
+	// ErrInvalidAvatarSize indicates a requested avatar size that is not one of AvatarSizes
+	ErrInvalidAvatarSize = errors.New("avatar size is not supported")
+	// ErrReadOnlyMount indicates changing a file in an external storage that is mounted read-only
+	ErrReadOnlyMount = errors.New("vfs: File is in a read-only mount")
+	// ErrMountPoint indicates moving or deleting the mount point of an external storage or a folder containing one
+	ErrMountPoint = errors.New("vfs: Mount points cannot be moved or deleted")
 )
 
+// uploadNameLength is the length of the random names of files in the temp folder while they are written
+const uploadNameLength = 16 // characters
+
+// AvatarSizes are the edge lengths in pixels avatars are stored in
+var AvatarSizes = []int{32, 64, 128, 256}
+
 type FileManager struct {
-	fileSystemRep *repository.FileSystemRepository
+	storageRep    repository.StorageRepository
 	fileInfoRep   *repository.FileInfoRepository
 	shareEntryRep *repository.ShareEntryRepository
+	starRep       *repository.StarRepository
 	tmpName       string
+	// fileLocks are held by the storage path while the content of a file is replaced, moved or deleted together with its info
+	fileLocks subjectLocks
 }
 
 var fileManager *FileManager
 
-func CreateFileManager(fileSystemRep *repository.FileSystemRepository, fileInfoRep *repository.FileInfoRepository, shareEntryRep *repository.ShareEntryRepository, tmpName string) (*FileManager, error) {
+// CreateFileManager creates the FileManager which stores the content of files in the given StorageRepository
+func CreateFileManager(storageRep repository.StorageRepository, fileInfoRep *repository.FileInfoRepository, shareEntryRep *repository.ShareEntryRepository, starRep *repository.StarRepository, tmpName string) (*FileManager, error) {
 	if fileManager != nil {
 		return fileManager, nil
 	}
 
 	fileManager = &FileManager{
-		fileSystemRep: fileSystemRep,
+		storageRep:    storageRep,
 		fileInfoRep:   fileInfoRep,
 		shareEntryRep: shareEntryRep,
+		starRep:       starRep,
 		tmpName:       tmpName,
 	}
 	err := fileManager.ScanFSForChanges()
@@ -81,7 +108,7 @@ func (mgr *FileManager) ScanUserFolderForChanges(user *models.User) (err error)
 		return err
 	}
 
-	_, err = mgr.scanDirForChanges(user, "/", "")
+	_, err = mgr.scanDirForChanges(user.ID, "/", "", true)
 	if err != nil {
 		log.Error(0, "Could not scan directory for user %v: %v", user.ID, err)
 		return err
@@ -89,23 +116,27 @@ func (mgr *FileManager) ScanUserFolderForChanges(user *models.User) (err error)
 	return
 }
 
-func (mgr *FileManager) scanDirForChanges(user *models.User, path, name string) (folderSize int64, err error) {
+// scanDirForChanges syncs the db with the content of the folder of the user and, if recursive, of its subfolders.
+// Mounted storages are not scanned recursively, their content is indexed one folder at a time when it is accessed.
+func (mgr *FileManager) scanDirForChanges(userID int64, path, name string, recursive bool) (folderSize int64, err error) {
 	fsPath := filepath.Join(path, name)
 
 	// Get all needed data, paths, etc.
-	userPath := mgr.getUserPath(user)
-	pathInfo, err := mgr.fileSystemRep.GetInfo(userPath, fsPath)
-	// Return if the scanning dir is a file
-	if err != nil || !pathInfo.IsDir {
+	userPath := mgr.getUserPathWithID(userID)
+	pathInfo, err := mgr.storageRep.GetInfo(userPath, fsPath)
+	// Return if the scanning dir is a file or cannot be read
+	if err != nil {
+		return 0, err
+	} else if !pathInfo.IsDir {
 		return pathInfo.Size, fmt.Errorf("path is not a directory")
 	}
 
 	// Get dir contents of fs and db
-	fsFiles, err := mgr.fileSystemRep.GetDirectoryInfo(userPath, fsPath)
+	fsFiles, err := mgr.storageRep.GetDirectoryInfo(userPath, fsPath)
 	if err != nil {
 		return
 	}
-	dbPathInfo, dbFiles, err := mgr.getDirectoryContentByPath(user.ID, path, name)
+	dbPathInfo, dbFiles, err := mgr.getDirectoryContentByPath(userID, path, name)
 	if err != nil {
 		return
 	}
@@ -120,28 +151,42 @@ func (mgr *FileManager) scanDirForChanges(user *models.User, path, name string)
 			}
 		}
 
+		indexedFile := fsFile
 		if dbIt == -1 {
 			// File not yet in db --> Add it
-			fsFile.OwnerID = user.ID
+			fsFile.OwnerID = userID
 			fsFile.ParentID = dbPathInfo.ID
+			mgr.refreshChecksums(fsFile, true)
 			err = mgr.fileInfoRep.Create(fsFile)
 			if err != nil {
 				log.Error(0, "Error inserting into db: %v", err)
 				return
 			}
+			if !mgr.isTmpPath(fsFile) {
+				recordFileChange(ActivityCreated, 0, fsFile, "")
+			}
 		} else {
 			// File found in db files --> Check whether an update is needed
 			dbFile := dbFiles[dbIt]
-			if (!fsFile.IsDir && fsFile.Size != dbFile.Size) || fsFile.LastChanged != dbFile.LastChanged || fsFile.IsDir != dbFile.IsDir {
+			indexedFile = dbFile
+			changed := (!fsFile.IsDir && fsFile.Size != dbFile.Size) || fsFile.LastChanged != dbFile.LastChanged || fsFile.IsDir != dbFile.IsDir || fsFile.BlobHash != dbFile.BlobHash
+			if changed {
 				dbFile.Size = fsFile.Size
 				dbFile.LastChanged = fsFile.LastChanged
 				dbFile.IsDir = fsFile.IsDir
+				dbFile.BlobHash = fsFile.BlobHash
+			}
+			if checksumsChanged := mgr.refreshChecksums(dbFile, changed); changed || checksumsChanged {
 				err = mgr.fileInfoRep.Update(dbFile)
 				if err != nil {
 					log.Error(0, "Error updating file in db: %v", err)
 					return
 				}
 			}
+			// Changed timestamps of folders only reflect changes of their content which are recorded themselves
+			if changed && !dbFile.IsDir && !mgr.isTmpPath(dbFile) {
+				recordFileChange(ActivityChanged, 0, dbFile, "")
+			}
 
 			// Delete file from db list as it is now used
 			dbFiles[dbIt] = dbFiles[len(dbFiles)-1]
@@ -149,11 +194,14 @@ func (mgr *FileManager) scanDirForChanges(user *models.User, path, name string)
 			dbFiles = dbFiles[:len(dbFiles)-1]
 		}
 
-		// If it is a file directly add the size; If it is an dir then scan it and add the size of the dir
+		// If it is a file directly add the size; If it is an dir then scan it and add the size of the dir.
+		// The stored size is used for folders that are not scanned now.
 		if !fsFile.IsDir {
 			folderSize += fsFile.Size
+		} else if !recursive || isMountPoint(filepath.Join(userPath, fsFile.Path, fsFile.Name)) {
+			folderSize += indexedFile.Size
 		} else {
-			subFolderSize, err := mgr.scanDirForChanges(user, fsFile.Path, fsFile.Name)
+			subFolderSize, err := mgr.scanDirForChanges(userID, fsFile.Path, fsFile.Name, true)
 			if err != nil {
 				log.Error(0, "Error scanning subfolder: %v", err)
 				return folderSize, err
@@ -168,11 +216,14 @@ func (mgr *FileManager) scanDirForChanges(user *models.User, path, name string)
 			continue
 		}
 
-		err = mgr.fileInfoRep.Delete(dbFile.ID)
+		err = mgr.unindexFile(dbFile)
 		if err != nil {
 			log.Error(0, "Error removing file from db: %v", err)
 			return
 		}
+		if !mgr.isTmpPath(dbFile) {
+			recordFileChange(ActivityDeleted, 0, dbFile, "")
+		}
 	}
 
 	// If the size of the folder has changed then update it in the db
@@ -213,7 +264,7 @@ func (mgr *FileManager) CreateUserFolders(userID int64) error {
 	userPath := mgr.getUserPathWithID(userID)
 
 	//Create user dir if not existing and add it to the db
-	created, err := mgr.fileSystemRep.CreateDirectory(userPath)
+	created, err := mgr.storageRep.CreateDirectory(userPath)
 	if err != nil {
 		return fmt.Errorf("failed to create folder for user id %v: %v", userID, err)
 	}
@@ -232,7 +283,7 @@ func (mgr *FileManager) CreateUserFolders(userID int64) error {
 	}
 
 	//Create tmp dir for if not existing and add it to the db
-	created, err = mgr.fileSystemRep.CreateDirectory(filepath.Join(userPath, mgr.tmpName))
+	created, err = mgr.storageRep.CreateDirectory(filepath.Join(userPath, mgr.tmpName))
 	if err != nil {
 		return fmt.Errorf("failed creating tmp folder for user id %v: %v", userID, err)
 	}
@@ -253,7 +304,7 @@ func (mgr *FileManager) CreateUserFolders(userID int64) error {
 	return nil
 }
 
-func (mgr *FileManager) NewFileHandleForUser(user *models.User, path string) (*os.File, error) {
+func (mgr *FileManager) NewFileHandleForUser(user *models.User, path string) (repository.StorageHandle, error) {
 	if !utils.ValidatePath(path) {
 		return nil, ErrForbiddenPathName
 	}
@@ -263,13 +314,22 @@ func (mgr *FileManager) NewFileHandleForUser(user *models.User, path string) (*o
 	if err != nil {
 		return nil, err
 	}
+	err = mgr.checkWritable(folderInfo)
+	if err != nil {
+		return nil, err
+	}
 
 	handlePath := filepath.Join(mgr.getUserPathWithID(folderInfo.OwnerID), folderInfo.Path, folderInfo.Name, fileName)
 
-	return mgr.fileSystemRep.CreateHandle(handlePath)
+	return mgr.storageRep.CreateHandle(handlePath)
 }
 
 func (mgr *FileManager) FinishNewFile(user *models.User, path string) (err error) {
+	return mgr.finishNewFile(user, path, nil)
+}
+
+// finishNewFile adds the new file to the db with the given checksums, they are computed if they are nil
+func (mgr *FileManager) finishNewFile(user *models.User, path string, checksums *Checksums) (err error) {
 	if !utils.ValidatePath(path) {
 		err = ErrForbiddenPathName
 		return
@@ -282,23 +342,190 @@ func (mgr *FileManager) FinishNewFile(user *models.User, path string) (err error
 	}
 
 	userPath := mgr.getUserPathWithID(folderInfo.OwnerID)
-	fileInfo, err := mgr.fileSystemRep.GetInfo(userPath, filepath.Join(folderInfo.Path, folderInfo.Name, fileName))
+	fileInfo, err := mgr.storageRep.GetInfo(userPath, filepath.Join(folderInfo.Path, folderInfo.Name, fileName))
 	if err != nil {
 		return
 	}
 
 	fileInfo.OwnerID = folderInfo.OwnerID
 	fileInfo.ParentID = folderInfo.ID
+	if checksums != nil {
+		checksums.set(fileInfo)
+	} else {
+		mgr.refreshChecksums(fileInfo, true)
+	}
 	err = mgr.fileInfoRep.Create(fileInfo)
 	if err != nil {
 		return
 	}
+	if !mgr.isTmpPath(fileInfo) {
+		recordFileChange(ActivityCreated, user.ID, fileInfo, "")
+	}
 
 	//TODO: Make asynchronus scan call for dir sizes?!?
 
 	return
 }
 
+// Upload is new content of a file which is written into the temp folder of the owner until it is finished
+type Upload struct {
+	repository.StorageHandle
+	user       *models.User
+	path       string
+	uploadPath string
+	// expected are the checksums the client expects, the upload is rejected if its content does not match them
+	expected Checksums
+}
+
+// CreateUpload checks that the file of the user at path can be written and returns a handle in the temp folder of the owner for its new content.
+// With keepContent the upload starts with the current content of an existing file, so it can be changed in place.
+func (mgr *FileManager) CreateUpload(user *models.User, path string, keepContent bool) (upload *Upload, err error) {
+	folderInfo, existingInfo, err := mgr.getUploadTarget(user, path)
+	if err != nil {
+		return
+	}
+
+	uploadName, err := utils.SecureRandomString(uploadNameLength)
+	if err != nil {
+		return
+	}
+	uploadPath := filepath.Join(mgr.getUserPathWithID(folderInfo.OwnerID), mgr.tmpName, "upload-"+uploadName)
+	file, err := mgr.storageRep.CreateHandle(uploadPath)
+	if err != nil {
+		return
+	}
+	upload = &Upload{StorageHandle: file, user: user, path: path, uploadPath: uploadPath}
+
+	if keepContent && existingInfo != nil {
+		var existingFile repository.StorageFile
+		existingFile, _, err = mgr.OpenFile(user, path)
+		if err == nil {
+			_, err = io.Copy(file, existingFile)
+			existingFile.Close()
+		}
+		if err != nil {
+			mgr.AbortUpload(upload)
+			return nil, err
+		}
+	}
+	return
+}
+
+// FinishUpload closes the upload and moves it into place, a new file is added to the db and an existing one gets its size, change time and checksums updated.
+// ErrChecksumMismatch is returned if the content does not match the expected checksums, the file keeps its previous content then.
+func (mgr *FileManager) FinishUpload(upload *Upload) (fileInfo *models.FileInfo, err error) {
+	err = upload.StorageHandle.Close()
+	if err != nil {
+		mgr.storageRep.Delete(upload.uploadPath)
+		return
+	}
+	checksums, err := mgr.computeChecksums(upload.uploadPath)
+	if err == nil && !checksums.matches(upload.expected) {
+		err = ErrChecksumMismatch
+	}
+	if err != nil {
+		mgr.storageRep.Delete(upload.uploadPath)
+		return
+	}
+
+	// The target is checked again as its folder could have been moved or deleted while writing
+	user, path := upload.user, upload.path
+	folderInfo, existingInfo, err := mgr.getUploadTarget(user, path)
+	if err == nil {
+		_, fileName := utils.SplitPath(path)
+		targetPath := filepath.Join(mgr.getUserPathWithID(folderInfo.OwnerID), folderInfo.Path, folderInfo.Name, fileName)
+		// Until the info is updated the new content would not match the recorded checksums
+		unlock := mgr.fileLocks.lock([]string{targetPath})
+		defer unlock()
+		err = mgr.storageRep.Move(upload.uploadPath, targetPath)
+	}
+	if err != nil {
+		mgr.storageRep.Delete(upload.uploadPath)
+		return
+	}
+
+	if existingInfo == nil {
+		err = mgr.finishNewFile(user, path, &checksums)
+		if err != nil {
+			return
+		}
+		return mgr.GetFileInfo(user, path, false)
+	}
+
+	writtenInfo, err := mgr.storageRep.GetInfo(mgr.getUserPathWithID(existingInfo.OwnerID), filepath.Join(existingInfo.Path, existingInfo.Name))
+	if err != nil {
+		return
+	}
+	existingInfo.Size = writtenInfo.Size
+	existingInfo.LastChanged = writtenInfo.LastChanged
+	existingInfo.BlobHash = writtenInfo.BlobHash
+	checksums.set(existingInfo)
+	err = mgr.fileInfoRep.Update(existingInfo)
+	if err != nil {
+		return
+	}
+	if !mgr.isTmpPath(existingInfo) {
+		recordFileChange(ActivityChanged, user.ID, existingInfo, "")
+	}
+	return existingInfo, nil
+}
+
+// AbortUpload closes and deletes the upload, the file keeps its previous content
+func (mgr *FileManager) AbortUpload(upload *Upload) {
+	upload.StorageHandle.Close()
+	mgr.storageRep.Delete(upload.uploadPath)
+}
+
+// getUploadTarget returns the folder a file at path can be written into and the info of the file if it exists already
+func (mgr *FileManager) getUploadTarget(user *models.User, path string) (folderInfo, existingInfo *models.FileInfo, err error) {
+	if !utils.ValidatePath(path) {
+		return nil, nil, ErrForbiddenPathName
+	}
+	filePath, fileName := utils.SplitPath(path)
+	if fileName == "" || fileName == mgr.tmpName && filePath == "/" {
+		return nil, nil, ErrInvalidMoveTarget
+	}
+
+	folderInfo, err = mgr.GetFileInfo(user, filePath, false)
+	if err != nil || !folderInfo.IsDir {
+		return nil, nil, ErrFileNotFound
+	}
+	err = mgr.checkWritable(folderInfo)
+	if err != nil {
+		return nil, nil, err
+	}
+	existingInfo, existingErr := mgr.GetFileInfo(user, path, false)
+	if existingErr != nil {
+		return folderInfo, nil, nil
+	}
+	if existingInfo.IsDir {
+		return nil, nil, ErrFileExists
+	}
+	return folderInfo, existingInfo, nil
+}
+
+// WriteFile creates the file of the user at path or replaces its content with everything read from reader.
+// The content is written as upload into the temp folder of the owner first and moved into place once it is complete,
+// so a failing reader neither leaves partial files nor destroys the previous content.
+func (mgr *FileManager) WriteFile(user *models.User, path string, reader io.Reader) (fileInfo *models.FileInfo, err error) {
+	return mgr.WriteVerifiedFile(user, path, reader, Checksums{})
+}
+
+// WriteVerifiedFile writes the file like WriteFile, but rejects the content with ErrChecksumMismatch if it does not match the expected checksums
+func (mgr *FileManager) WriteVerifiedFile(user *models.User, path string, reader io.Reader, expected Checksums) (fileInfo *models.FileInfo, err error) {
+	upload, err := mgr.CreateUpload(user, path, false)
+	if err != nil {
+		return
+	}
+	upload.expected = expected
+	_, err = io.Copy(upload, reader)
+	if err != nil {
+		mgr.AbortUpload(upload)
+		return
+	}
+	return mgr.FinishUpload(upload)
+}
+
 func (mgr *FileManager) CreateFile(user *models.User, path string, isDir bool) (fileInfo *models.FileInfo, err error) {
 	if exisFileInfo, _ := mgr.GetFileInfo(user, path, true); exisFileInfo != nil && exisFileInfo.ID > 0 {
 		return nil, fmt.Errorf("file %v already exists", path)
@@ -311,10 +538,14 @@ func (mgr *FileManager) CreateFile(user *models.User, path string, isDir bool) (
 		}
 	} else {
 		file, err := mgr.NewFileHandleForUser(user, path)
-		defer file.Close()
 		if err != nil {
 			return nil, fmt.Errorf("creating new file handle failed for path '%s': %v", path, err)
 		}
+		// Storages like object stores only create the file when its handle is closed
+		err = file.Close()
+		if err != nil {
+			return nil, fmt.Errorf("closing new file handle failed for path '%s': %v", path, err)
+		}
 		err = mgr.FinishNewFile(user, path)
 		if err != nil {
 			return nil, fmt.Errorf("finishing created file failed for path '%s': %v", path, err)
@@ -336,16 +567,20 @@ func (mgr *FileManager) CreateDirectoryForUser(user *models.User, path string) (
 	}
 
 	folderPath, folderName := utils.SplitPath(path)
-	userPath := mgr.getUserPath(user)
-
 	parFolderInfo, err := mgr.GetFileInfo(user, folderPath, false)
 	if err != nil {
 		err = fmt.Errorf("could not find parent folder of creating folder in db: %v", err)
 		log.Error(0, "%v", err)
 		return
 	}
+	err = mgr.checkWritable(parFolderInfo)
+	if err != nil {
+		return
+	}
 
-	_, err = mgr.fileSystemRep.CreateDirectory(filepath.Join(userPath, path))
+	// The parent folder may be shared with the user, so the folder is created below it in the files of its owner
+	userPath := mgr.getUserPathWithID(parFolderInfo.OwnerID)
+	_, err = mgr.storageRep.CreateDirectory(filepath.Join(userPath, parFolderInfo.Path, parFolderInfo.Name, folderName))
 	if err != nil {
 		err = fmt.Errorf("error creating directory for user %v: %v", user.ID, err)
 		log.Error(0, "%v", err)
@@ -364,6 +599,7 @@ func (mgr *FileManager) CreateDirectoryForUser(user *models.User, path string) (
 	if err != nil {
 		return
 	}
+	recordFileChange(ActivityCreated, user.ID, dirInfo, "")
 
 	return
 }
@@ -376,6 +612,11 @@ func (mgr *FileManager) GetPathInfo(user *models.User, path string) (*models.Pat
 
 	var content []*models.FileInfo
 	if dirInfo.IsDir {
+		// An unreachable storage is no reason to fail, the content indexed before is listed instead
+		if indexErr := mgr.indexMountedFolder(dirInfo); indexErr != nil {
+			log.Warn("Could not index mounted folder %v%v of user %v: %v", dirInfo.Path, dirInfo.Name, dirInfo.OwnerID, indexErr)
+		}
+
 		content, err = mgr.fileInfoRep.GetDirectoryContentByID(user.ID, dirInfo.ID)
 		if err != nil {
 			return nil, err
@@ -407,6 +648,11 @@ func (mgr *FileManager) ListSharedFilesForUser(user *models.User) (sharedFilesIn
 	return
 }
 
+// ListFilesSharedWithUser returns the files other users share with the user
+func (mgr *FileManager) ListFilesSharedWithUser(user *models.User) (sharedFilesInfo []*models.FileInfo, err error) {
+	return mgr.fileInfoRep.GetSharedWithFileInfosByUser(user.ID)
+}
+
 // GetFileInfo returns the stored fileInfo for a given path and user resolving shared folders
 // Set adaptSharePath to true if the returned path of the fileInfo should be from the root of the requesting user
 // Set adaptSharePath to false if it should stay the orig path of the sharing user
@@ -463,12 +709,16 @@ func (mgr *FileManager) GetFileInfo(user *models.User, requestedPath string, ada
 		}
 
 		if sharedParentInfo == nil {
-			return nil, ErrFileNotFound
+			// The file may be in a folder of a mounted storage that has not been listed yet
+			return mgr.indexMountedPath(fileInfo, filepath.Join(removedPath, fileName))
 		}
 
 		finalFileInfo := &models.FileInfo{}
 		finalPath := utils.ConvertToSlash(filepath.Join(sharedParentInfo.Path, sharedParentInfo.Name, removedPath), true)
 		finalFileInfo, err = mgr.fileInfoRep.GetByPath(sharedParentInfo.OwnerID, finalPath, fileName)
+		if repository.IsRecordNotFoundError(err) {
+			finalFileInfo, err = mgr.indexMountedPath(sharedParentInfo, filepath.Join(removedPath, fileName))
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -481,16 +731,21 @@ func (mgr *FileManager) GetFileInfo(user *models.User, requestedPath string, ada
 	}
 }
 
-func (mgr *FileManager) GetDownloadPath(user *models.User, path string) (downloadURL, filename string, err error) {
-	fileInfo, err := mgr.GetFileInfo(user, path, false)
+// OpenFile opens the file at path for reading, folders have to be zipped before they can be downloaded
+func (mgr *FileManager) OpenFile(user *models.User, path string) (file repository.StorageFile, fileInfo *models.FileInfo, err error) {
+	fileInfo, err = mgr.GetFileInfo(user, path, false)
 	if err != nil {
 		return
 	}
+	if fileInfo.IsDir {
+		return nil, nil, ErrOpenFolder
+	}
 
-	downloadURL = mgr.fileSystemRep.GetDownloadPath(filepath.Join(mgr.getUserPathWithID(fileInfo.OwnerID), fileInfo.Path, fileInfo.Name))
-
-	_, fileName := utils.SplitPath(path)
-	filename = fileName
+	file, err = mgr.storageRep.OpenFile(filepath.Join(mgr.getUserPathWithID(fileInfo.OwnerID), fileInfo.Path, fileInfo.Name))
+	if err != nil {
+		log.Error(0, "Could not open file %v%v of user %v: %v", fileInfo.Path, fileInfo.Name, fileInfo.OwnerID, err)
+		return nil, nil, err
+	}
 	return
 }
 
@@ -514,10 +769,29 @@ func (mgr *FileManager) ZipFiles(user *models.User, paths []string) (zipPath str
 	zipPath = filepath.Join(mgr.tmpName, outputName)
 	outputPath := filepath.Join(mgr.getUserPath(user), zipPath)
 
-	err = mgr.fileSystemRep.Zip(paths, outputPath)
+	file, err := mgr.storageRep.CreateHandle(outputPath)
 	if err != nil {
 		return
 	}
+	zipWriter := zip.NewWriter(file)
+	for _, path := range paths {
+		_, name := utils.SplitPath(path)
+		err = mgr.storageRep.AddToZip(zipWriter, path, name, nil)
+		if err != nil {
+			break
+		}
+	}
+	if err == nil {
+		err = zipWriter.Close()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Error(0, "Error zipping files into %v: %v", outputPath, err)
+		mgr.storageRep.Delete(outputPath)
+		return
+	}
 
 	err = mgr.FinishNewFile(user, zipPath)
 	if err != nil {
@@ -526,80 +800,359 @@ func (mgr *FileManager) ZipFiles(user *models.User, paths []string) (zipPath str
 	return
 }
 
-func (mgr *FileManager) UpdateFile(user *models.User, path string, updatedFileInfo *models.FileInfoUpdate) (fileInfo *models.FileInfo, err error) {
-	/*
-		fileInfo, err = vfs.GetFileInfo(user, path)
+// ExportUserData zips the given documents as indented JSON together with the avatar and all files of the user into his temp folder.
+// The documents are stored at their key in the root of the archive, the files below "files".
+func (mgr *FileManager) ExportUserData(user *models.User, documents map[string]interface{}) (zipPath string, err error) {
+	zipPath = filepath.Join(mgr.tmpName, time.Now().Format("data_export_20060102_150405.zip"))
+	userPath := mgr.getUserPath(user)
+
+	file, err := mgr.storageRep.CreateHandle(filepath.Join(userPath, zipPath))
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	zipWriter := zip.NewWriter(file)
+
+	for name, document := range documents {
+		var writer io.Writer
+		writer, err = zipWriter.Create(name)
 		if err != nil {
 			return
 		}
-
-		var newPath string
-		if path, ok := updatedFileInfo.PathOO.(*models.FileInfoUpdate_Path); ok == true && utils.ValidatePath(path.Path) {
-			newPath = path.Path
-		} else {
-			newPath = fileInfo.Path
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(document)
+		if err != nil {
+			return
 		}
+	}
 
-		var newName string
-		if name, ok := updatedFileInfo.NameOO.(*models.FileInfoUpdate_Name); ok == true && utils.ValidatePath(name.Name) {
-			newName = name.Name
-		} else {
-			newName = fileInfo.Name
+	if user.HasAvatar {
+		largestSize := AvatarSizes[len(AvatarSizes)-1]
+		err = mgr.storageRep.AddToZip(zipWriter, mgr.getAvatarPath(user.ID, largestSize), "avatar.png", nil)
+		if err != nil {
+			return
 		}
+	}
 
-		var newStarred bool
-		if starred, ok := updatedFileInfo.StarredOO.(*models.FileInfoUpdate_Starred); ok == true {
-			newStarred = starred.Starred
-		} else {
-			newStarred = fileInfo.Starred
-		}
+	// Mounted storages are not part of the data of the user
+	err = mgr.storageRep.AddToZip(zipWriter, userPath, "files", func(relPath string) bool {
+		return relPath == mgr.tmpName || isMountPoint(filepath.Join(userPath, relPath))
+	})
+	if err != nil {
+		return
+	}
+
+	err = zipWriter.Close()
+	if err != nil {
+		return
+	}
+
+	err = mgr.FinishNewFile(user, zipPath)
+	return
+}
+
+// WriteTmpFile creates a file with the given name in the temp folder of the user and fills it by calling write
+func (mgr *FileManager) WriteTmpFile(user *models.User, name string, write func(writer io.Writer) error) (tmpPath string, err error) {
+	tmpPath = filepath.Join(mgr.tmpName, name)
+
+	file, err := mgr.storageRep.CreateHandle(filepath.Join(mgr.getUserPath(user), tmpPath))
+	if err != nil {
+		return
+	}
+	err = write(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	err = mgr.FinishNewFile(user, tmpPath)
+	return
+}
+
+// isTmpPath returns whether the file is the temp folder of its owner or within it
+func (mgr *FileManager) isTmpPath(fileInfo *models.FileInfo) bool {
+	return isWithinPath(getFullPath(fileInfo), "/"+mgr.tmpName)
+}
+
+// getStoragePath returns the path of the file or folder in the StorageRepository
+func (mgr *FileManager) getStoragePath(fileInfo *models.FileInfo) string {
+	return filepath.Join(mgr.getUserPathWithID(fileInfo.OwnerID), fileInfo.Path, fileInfo.Name)
+}
+
+// checkWritable returns ErrReadOnlyMount if the file or folder is in an external storage mounted read-only
+func (mgr *FileManager) checkWritable(fileInfo *models.FileInfo) error {
+	if _, readOnly, ok := getMount(mgr.getStoragePath(fileInfo)); ok && readOnly {
+		return ErrReadOnlyMount
+	}
+	return nil
+}
+
+// checkRemovable returns ErrMountPoint for mount points and folders containing them and ErrReadOnlyMount for files in read-only mounts
+func (mgr *FileManager) checkRemovable(fileInfo *models.FileInfo) error {
+	if containsMountPoints(mgr.getStoragePath(fileInfo)) {
+		return ErrMountPoint
+	}
+	return mgr.checkWritable(fileInfo)
+}
+
+// indexMountedFolder syncs the db with the content of the folder if it is in a mounted storage, as their content is only indexed when it is accessed
+func (mgr *FileManager) indexMountedFolder(folderInfo *models.FileInfo) (err error) {
+	if _, _, ok := getMount(mgr.getStoragePath(folderInfo)); !ok {
+		return nil
+	}
+	_, err = mgr.scanDirForChanges(folderInfo.OwnerID, folderInfo.Path, folderInfo.Name, false)
+	return
+}
 
-		var copyFlag bool
-		if cpy, ok := updatedFileInfo.CopyOO.(*models.FileInfoUpdate_Copy); ok == true {
-			copyFlag = cpy.Copy
+// indexMountedPath indexes the folders from the indexed ancestor down to the file at relPath below it and returns its info.
+// ErrFileNotFound is returned if the file does not exist or the ancestor is not in a mounted storage.
+func (mgr *FileManager) indexMountedPath(ancestorInfo *models.FileInfo, relPath string) (fileInfo *models.FileInfo, err error) {
+	fileInfo = ancestorInfo
+	for _, name := range strings.Split(strings.Trim(utils.ConvertToSlash(relPath, false), "/"), "/") {
+		if _, _, ok := getMount(mgr.getStoragePath(fileInfo)); !ok || !fileInfo.IsDir {
+			return nil, ErrFileNotFound
+		}
+		_, err = mgr.scanDirForChanges(fileInfo.OwnerID, fileInfo.Path, fileInfo.Name, false)
+		if err != nil {
+			return nil, ErrFileNotFound
+		}
+		fileInfo, err = mgr.fileInfoRep.GetByPath(fileInfo.OwnerID, utils.ConvertToSlash(filepath.Join(fileInfo.Path, fileInfo.Name), true), name)
+		if err != nil {
+			return nil, ErrFileNotFound
 		}
+	}
+	return
+}
 
-		if newPath != fileInfo.Path || newName != fileInfo.Name {
-			newFolderInfo, err := vfs.GetFileInfo(user, newPath)
-			if err != nil {
-				log.Error(0, "Error getting parent for changed file %v%v: %v", fileInfo.Path, fileInfo.Name, err)
-				return nil, err
-			}
+// indexMountPoint adds the mount point of an external storage in the files of the user and its missing parent folders to the db
+func (mgr *FileManager) indexMountPoint(userID int64, mountPath string) (err error) {
+	err = mgr.CreateUserFolders(userID)
+	if err != nil {
+		return
+	}
+	parentInfo, err := mgr.fileInfoRep.GetByPath(userID, "/", "")
+	if err != nil {
+		return
+	}
 
-			// Has shareID and into something shared by me or shared with me should be blocked
-			if res, _ := vfs.isInSharedByMe(user.ID, 0, newFolderInfo); fileInfo.ShareID > 0 && (res || newFolderInfo.ShareID > 0 || newFolderInfo.OwnerID != user.ID) {
-				return nil, ErrSharedIntoShared
+	userPath := mgr.getUserPathWithID(userID)
+	for _, name := range strings.Split(strings.Trim(utils.ConvertToSlash(mountPath, false), "/"), "/") {
+		folderPath := utils.ConvertToSlash(filepath.Join(parentInfo.Path, parentInfo.Name), true)
+		fileInfo, getErr := mgr.fileInfoRep.GetByPath(userID, folderPath, name)
+		if repository.IsRecordNotFoundError(getErr) {
+			fileInfo, getErr = mgr.storageRep.GetInfo(userPath, filepath.Join(folderPath, name))
+			if getErr != nil {
+				return getErr
+			}
+			fileInfo.OwnerID = userID
+			fileInfo.ParentID = parentInfo.ID
+			getErr = mgr.fileInfoRep.Create(fileInfo)
+			if getErr == nil {
+				recordFileChange(ActivityCreated, 0, fileInfo, "")
 			}
+		}
+		if getErr != nil {
+			return getErr
+		}
+		parentInfo = fileInfo
+	}
+	return
+}
+
+// unindexMountPoint removes the mount point of an unmounted storage in the files of the user and its indexed content from the db.
+// Files hidden by the mount point are indexed again by the next scan.
+func (mgr *FileManager) unindexMountPoint(userID int64, mountPath string) (err error) {
+	folderPath, name := utils.SplitPath(mountPath)
+	fileInfo, err := mgr.fileInfoRep.GetByPath(userID, folderPath, name)
+	if repository.IsRecordNotFoundError(err) {
+		return nil
+	} else if err != nil {
+		return
+	}
 
-			fileInfo.Starred = newStarred
+	err = mgr.unindexFile(fileInfo)
+	if err != nil {
+		return
+	}
+	recordFileChange(ActivityDeleted, 0, fileInfo, "")
+	return
+}
 
-			if !copyFlag {
-				err = vfs.moveFile(user, fileInfo, newName, newFolderInfo)
+// unindexFile removes the file and, for folders, their content from the db together with their shares.
+// Files shared with the owner inside removed folders are removed together with their share entries.
+func (mgr *FileManager) unindexFile(fileInfo *models.FileInfo) (err error) {
+	if fileInfo.IsDir {
+		var folderContent []*models.FileInfo
+		folderContent, err = mgr.fileInfoRep.GetDirectoryContentByID(fileInfo.OwnerID, fileInfo.ID)
+		if err != nil {
+			return
+		}
+		for _, contentInfo := range folderContent {
+			if contentInfo.ShareID > 0 {
+				err = mgr.deleteSharedFileInfo(contentInfo)
 			} else {
-				err = vfs.copyFile(user, fileInfo, newName, newFolderInfo)
+				err = mgr.unindexFile(contentInfo)
 			}
 			if err != nil {
-				return nil, err
-			}
-
-		} else if newStarred != fileInfo.Starred {
-			fileInfo.LastChanged = utils.GetTimestampNow()
-			fileInfo.Starred = newStarred
-			err = mgr.fileInfoRep.UpdateFile(fileInfo)
-			if err != nil {
-				log.Error(0, "Error updating file in db: %v", err)
 				return
 			}
 		}
+	}
+
+	err = mgr.fileInfoRep.Delete(fileInfo.ID)
+	if err != nil {
+		return
+	}
+	return mgr.deleteShares(fileInfo)
+}
+
+// getVisibleSubtrees returns the subtree of the folder of the user, which may be shared with him, and those shared with him below it
+func (mgr *FileManager) getVisibleSubtrees(user *models.User, folder string) (views []*subtreeView, err error) {
+	folderInfo, err := mgr.GetFileInfo(user, folder, false)
+	if err != nil {
+		return
+	}
+	folderPath := utils.ConvertToSlash(folder, false)
+	views = append(views, &subtreeView{scope: &models.SubtreeScope{OwnerID: folderInfo.OwnerID, Path: getFullPath(folderInfo)}, viewPath: folderPath})
+
+	sharedInfos, err := mgr.fileInfoRep.GetSharedWithFileInfosByUser(user.ID)
+	if err != nil {
+		return
+	}
+	for _, sharedInfo := range sharedInfos {
+		sharedPath := getFullPath(sharedInfo)
+		// A shared folder requested directly has already been resolved above
+		if sharedPath == folderPath || !isWithinPath(sharedPath, folderPath) {
+			continue
+		}
 
-		//TODO: Make asynchronus scan call for dir sizes?!?
-	*/
+		shareEntry, shareErr := mgr.getCheckedShareEntry(user.ID, sharedInfo.ShareID)
+		if shareErr != nil {
+			continue
+		}
+		origInfo, origErr := mgr.fileInfoRep.GetByID(shareEntry.FileID)
+		if origErr != nil {
+			continue
+		}
+		views = append(views, &subtreeView{scope: &models.SubtreeScope{OwnerID: origInfo.OwnerID, Path: getFullPath(origInfo)}, viewPath: sharedPath})
+	}
+	return
+}
+
+// TmpFileExists returns whether the file at path in the temp folder of the user still exists and has not been cleaned up
+func (mgr *FileManager) TmpFileExists(user *models.User, path string) bool {
+	if !strings.HasPrefix(utils.ConvertToSlash(path, false), "/"+mgr.tmpName+"/") {
+		return false
+	}
+	_, err := mgr.storageRep.GetInfo(mgr.getUserPath(user), path)
+	return err == nil
+}
+
+// UpdateFile renames the file or folder of the user at path and/or moves it into the folder at the updated path.
+// Starring or unstarring it only affects the user himself, thus it is allowed for all of his files.
+// Only files of the user himself can be moved and only into his own folders, shared folders themselves not into ones shared by him.
+func (mgr *FileManager) UpdateFile(user *models.User, path string, updatedFileInfo *models.FileInfoUpdate) (fileInfo *models.FileInfo, err error) {
+	filePath, fileName := utils.SplitPath(path)
+	if fileName == "" || fileName == mgr.tmpName && filePath == "/" {
+		return nil, ErrInvalidMoveTarget
+	}
+	// The stored info is needed here, GetFileInfo would resolve shared folders to the file of the sharing user
+	fileInfo, err = mgr.fileInfoRep.GetByPath(user.ID, filePath, fileName)
+	if err != nil {
+		return nil, ErrFileNotFound
+	}
+
+	newPath := fileInfo.Path
+	if updatedFileInfo.Path != nil {
+		newPath = utils.ConvertToSlash(*updatedFileInfo.Path, true)
+	}
+	newName := fileInfo.Name
+	if updatedFileInfo.Name != nil {
+		newName = *updatedFileInfo.Name
+	}
+	if !utils.ValidatePath(newPath) || !utils.ValidatePath(newName) || strings.ContainsAny(newName, "/") || newName == "" || newName == "." {
+		return nil, ErrForbiddenPathName
+	}
+
+	if updatedFileInfo.Starred != nil && *updatedFileInfo.Starred != fileInfo.Starred {
+		err = mgr.setStarred(user, fileInfo, *updatedFileInfo.Starred)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if newPath == fileInfo.Path && newName == fileInfo.Name {
+		return fileInfo, nil
+	}
+
+	if fileInfo.ShareID <= 0 {
+		err = mgr.checkRemovable(fileInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	newFolderPath, newFolderName := utils.SplitPath(newPath)
+	newFolderInfo, err := mgr.fileInfoRep.GetByPath(user.ID, newFolderPath, newFolderName)
+	if err != nil || !newFolderInfo.IsDir || newFolderInfo.ShareID > 0 {
+		return nil, ErrInvalidMoveTarget
+	}
+	err = mgr.checkWritable(newFolderInfo)
+	if err != nil {
+		return nil, err
+	}
+	// Folders cannot be moved into themselves
+	if strings.HasPrefix(newPath, utils.ConvertToSlash(filepath.Join(fileInfo.Path, fileInfo.Name), true)) {
+		return nil, ErrInvalidMoveTarget
+	}
+	// Has shareID and into something shared by me should be blocked
+	if res, _ := mgr.isInSharedByMe(user.ID, 0, newFolderInfo); fileInfo.ShareID > 0 && res {
+		return nil, ErrSharedIntoShared
+	}
+	if _, err = mgr.fileInfoRep.GetByPath(user.ID, newPath, newName); err == nil {
+		return nil, ErrFileExists
+	}
+
+	oldPath := getFullPath(fileInfo)
+	_, _, fromMount := getMount(mgr.getStoragePath(fileInfo))
+	err = mgr.moveFile(user, fileInfo, newName, newFolderInfo)
+	if err != nil {
+		return nil, err
+	}
+	recordFileChange(ActivityMoved, user.ID, fileInfo, oldPath)
+
+	// Folders moved out of a mounted storage may contain content that has not been indexed yet
+	if _, _, toMount := getMount(mgr.getStoragePath(fileInfo)); fileInfo.IsDir && fromMount && !toMount {
+		if _, scanErr := mgr.scanDirForChanges(user.ID, fileInfo.Path, fileInfo.Name, true); scanErr != nil {
+			log.Warn("Could not index folder %v%v moved out of mounted storage: %v", fileInfo.Path, fileInfo.Name, scanErr)
+		}
+	}
+
+	//TODO: Make asynchronus scan call for dir sizes?!?
+	return
+}
+
+// setStarred stars or unstars the file for the user
+func (mgr *FileManager) setStarred(user *models.User, fileInfo *models.FileInfo, starred bool) (err error) {
+	if starred {
+		err = mgr.starRep.Create(&models.Star{FileID: fileInfo.ID, UserID: user.ID})
+	} else {
+		err = mgr.starRep.Delete(fileInfo.ID, user.ID)
+	}
+	if err != nil {
+		return
+	}
+	fileInfo.Starred = starred
 	return
 }
 
 func (mgr *FileManager) moveFile(user *models.User, fileInfo *models.FileInfo, newName string, newFolderInfo *models.FileInfo) (err error) {
 	userPath := mgr.getUserPath(user)
 	oldPath := filepath.Join(userPath, fileInfo.Path, fileInfo.Name)
+	newPath := filepath.Join(userPath, newFolderInfo.Path, newFolderInfo.Name, newName)
+	unlock := mgr.fileLocks.lock([]string{oldPath, newPath})
+	defer unlock()
 
 	fileInfo.LastChanged = utils.GetTimestampNow()
 	if newName != fileInfo.Name {
@@ -613,8 +1166,7 @@ func (mgr *FileManager) moveFile(user *models.User, fileInfo *models.FileInfo, n
 	}
 
 	if fileInfo.ShareID <= 0 {
-		newPath := filepath.Join(userPath, fileInfo.Path, fileInfo.Name)
-		err = mgr.fileSystemRep.Move(oldPath, newPath)
+		err = mgr.storageRep.Move(oldPath, newPath)
 		if err != nil {
 			log.Error(0, "Error moving file from %v to %v: %v", oldPath, newPath, err)
 			return
@@ -678,11 +1230,15 @@ func (mgr *FileManager) copyFile(user *models.User, fileInfo *models.FileInfo, n
 		} else {
 			userPath := mgr.getUserPath(user)
 			oldPath := filepath.Join(fileInfo.Path, fileInfo.Name)
-			err = mgr.fileSystemRep.Copy(filepath.Join(userPath, oldPath), filepath.Join(userPath, newPath))
+			err = mgr.storageRep.Copy(filepath.Join(userPath, oldPath), filepath.Join(userPath, newPath))
 			if err != nil {
 				return
 			}
-			err = mgr.FinishNewFile(user, newPath)
+			var checksums *Checksums
+			if fileInfo.Sha256 != "" {
+				checksums = &Checksums{SHA256: fileInfo.Sha256, MD5: fileInfo.Md5}
+			}
+			err = mgr.finishNewFile(user, newPath, checksums)
 			if err != nil {
 				return
 			}
@@ -708,33 +1264,54 @@ func (mgr *FileManager) DeleteFile(user *models.User, path string) (err error) {
 	if err != nil {
 		return
 	}
+	if fileInfo.ShareID <= 0 {
+		err = mgr.checkRemovable(fileInfo)
+		if err != nil {
+			return
+		}
+	}
+	unlock := mgr.fileLocks.lock([]string{mgr.getStoragePath(fileInfo)})
+	defer unlock()
 
 	err = mgr.deleteFileInDB(fileInfo)
 	if err != nil {
 		return
 	}
+	recordFileChange(ActivityDeleted, user.ID, fileInfo, "")
 
 	if fileInfo.ShareID <= 0 {
-		err = mgr.fileSystemRep.Delete(filepath.Join(mgr.getUserPathWithID(fileInfo.OwnerID), fileInfo.Path, fileInfo.Name))
+		err = mgr.storageRep.Delete(filepath.Join(mgr.getUserPathWithID(fileInfo.OwnerID), fileInfo.Path, fileInfo.Name))
 		if err != nil {
 			return
 		}
 	}
 
-	var shareEntries []*models.ShareEntry
-	shareEntries, err = mgr.shareEntryRep.GetByFileID(fileInfo.ID)
+	err = mgr.deleteShares(fileInfo)
+	if err != nil {
+		return
+	}
+
+	//TODO: Make asynchronus scan call for dir sizes?!?
+
+	return
+}
+
+// deleteShares removes all shares of the file together with the files they have been mounted as
+func (mgr *FileManager) deleteShares(fileInfo *models.FileInfo) (err error) {
+	shareEntries, err := mgr.shareEntryRep.GetByFileID(fileInfo.ID)
 	if err != nil {
 		return
 	}
 	for _, shareEntry := range shareEntries {
+		err = mgr.deleteShareMounts(shareEntry.ID)
+		if err != nil {
+			return
+		}
 		err = mgr.shareEntryRep.Delete(shareEntry.ID)
 		if err != nil {
 			return
 		}
 	}
-
-	//TODO: Make asynchronus scan call for dir sizes?!?
-
 	return
 }
 
@@ -758,13 +1335,145 @@ func (mgr *FileManager) DeleteUserFiles(user *models.User) (err error) {
 		return
 	}
 
-	err = mgr.fileSystemRep.Delete(mgr.getUserPath(user))
+	err = mgr.storageRep.Delete(mgr.getUserPath(user))
 	if err != nil {
 		return
 	}
 	return
 }
 
+// TransferUserFiles moves the whole file tree of fromUserID into a new folder in the root of toUser and makes toUser the owner of it.
+// Shares of the transferred files with other users are kept, shares with toUser become obsolete and are removed.
+// The tmp folder of fromUserID and files shared with fromUserID are not transferred but deleted.
+func (mgr *FileManager) TransferUserFiles(fromUserID int64, toUser *models.User, folderName string) (folderInfo *models.FileInfo, err error) {
+	fromRoot, err := mgr.fileInfoRep.GetByPath(fromUserID, "/", "")
+	if err != nil {
+		if repository.IsRecordNotFoundError(err) {
+			err = ErrFileNotFound
+		}
+		return
+	}
+	toRoot, err := mgr.fileInfoRep.GetByPath(toUser.ID, "/", "")
+	if err != nil {
+		return
+	}
+
+	folderName, err = mgr.getFreeName(toUser.ID, "/", folderName)
+	if err != nil {
+		return
+	}
+
+	fileInfos, err := mgr.fileInfoRep.GetByOwner(fromUserID)
+	if err != nil {
+		return
+	}
+	tmpPath := "/" + mgr.tmpName + "/"
+	for _, fileInfo := range fileInfos {
+		if fileInfo.ShareID > 0 {
+			err = mgr.deleteSharedFileInfo(fileInfo)
+		} else if (fileInfo.Path == "/" && fileInfo.Name == mgr.tmpName) || strings.HasPrefix(fileInfo.Path, tmpPath) {
+			err = mgr.fileInfoRep.Delete(fileInfo.ID)
+		} else {
+			err = mgr.deleteObsoleteShares(fileInfo, toUser.ID)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	fromUserPath := mgr.getUserPathWithID(fromUserID)
+	err = mgr.storageRep.Delete(filepath.Join(fromUserPath, mgr.tmpName))
+	if err != nil {
+		return
+	}
+	toFolderPath := filepath.Join(mgr.getUserPath(toUser), folderName)
+	err = mgr.storageRep.Move(fromUserPath, toFolderPath)
+	if err != nil {
+		return
+	}
+
+	err = mgr.fileInfoRep.TransferOwnership(fromUserID, toUser.ID, toRoot.ID, folderName)
+	if err != nil {
+		if moveErr := mgr.storageRep.Move(toFolderPath, fromUserPath); moveErr != nil {
+			log.Error(0, "Could not move back files of user %d after failed transfer: %v", fromUserID, moveErr)
+		}
+		return
+	}
+
+	// Update the folder sizes of the recipient, failing to do so does not undo the transfer
+	if scanErr := mgr.ScanUserFolderForChanges(toUser); scanErr != nil {
+		log.Warn("Could not rescan files of user %d after transfer: %v", toUser.ID, scanErr)
+	}
+
+	return mgr.fileInfoRep.GetByID(fromRoot.ID)
+}
+
+// getFreeName returns name if there is no file with it in path of the user, otherwise name with the first free number appended
+func (mgr *FileManager) getFreeName(userID int64, path, name string) (string, error) {
+	freeName := name
+	for it := 2; ; it++ {
+		_, err := mgr.fileInfoRep.GetByPath(userID, path, freeName)
+		if repository.IsRecordNotFoundError(err) {
+			return freeName, nil
+		} else if err != nil {
+			return "", err
+		}
+		freeName = fmt.Sprintf("%s (%d)", name, it)
+	}
+}
+
+// deleteSharedFileInfo removes a file shared with an user together with its share entry
+func (mgr *FileManager) deleteSharedFileInfo(fileInfo *models.FileInfo) (err error) {
+	err = mgr.fileInfoRep.Delete(fileInfo.ID)
+	if err != nil {
+		return
+	}
+	recordChange(ChangeUnshared, fileInfo, "")
+
+	return mgr.shareEntryRep.Delete(fileInfo.ShareID)
+}
+
+// deleteShareMounts removes the files a share has been mounted as for the user it is shared with
+func (mgr *FileManager) deleteShareMounts(shareID int64) (err error) {
+	sharedFileInfos, err := mgr.fileInfoRep.GetByShareID(shareID)
+	if err != nil {
+		return
+	}
+	for _, sharedFileInfo := range sharedFileInfos {
+		err = mgr.fileInfoRep.Delete(sharedFileInfo.ID)
+		if err != nil {
+			return
+		}
+		recordChange(ChangeUnshared, sharedFileInfo, "")
+	}
+	return
+}
+
+// deleteObsoleteShares removes all shares of fileInfo with the given user
+func (mgr *FileManager) deleteObsoleteShares(fileInfo *models.FileInfo, userID int64) (err error) {
+	shareEntries, err := mgr.shareEntryRep.GetByFileID(fileInfo.ID)
+	if err != nil {
+		return
+	}
+
+	for _, shareEntry := range shareEntries {
+		if shareEntry.SharedWithID != userID {
+			continue
+		}
+
+		err = mgr.deleteShareMounts(shareEntry.ID)
+		if err != nil {
+			return
+		}
+
+		err = mgr.shareEntryRep.Delete(shareEntry.ID)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
 func (mgr *FileManager) ShareFiles(fromUser *models.User, toUserIDs []int64, paths []string) error {
 	type failedShareStruct struct {
 		toUserMail string
@@ -803,6 +1512,13 @@ func (mgr *FileManager) ShareFile(fromUser, toUser *models.User, path string) (e
 	filePath, fileName := utils.SplitPath(path)
 	// Get fileInfo without resolving shared files
 	fileInfo, err := mgr.fileInfoRep.GetByPath(fromUser.ID, filePath, fileName)
+	if repository.IsRecordNotFoundError(err) {
+		// Files in mounted storages are indexed when they are accessed first, which resolves shared files though
+		fileInfo, err = mgr.GetFileInfo(fromUser, path, false)
+		if err == nil && fileInfo.OwnerID != fromUser.ID {
+			err = ErrFileNotFound
+		}
+	}
 	if err != nil {
 		return
 	}
@@ -846,6 +1562,10 @@ func (mgr *FileManager) ShareFile(fromUser, toUser *models.User, path string) (e
 	}
 
 	err = mgr.fileInfoRep.Create(sharedFileInfo)
+	if err != nil {
+		return
+	}
+	recordChange(ChangeShared, sharedFileInfo, "")
 	return
 }
 
@@ -890,31 +1610,104 @@ func (mgr *FileManager) getCheckedShareEntry(userID, shareID int64) (shareEntry
 	return
 }
 
-func (mgr *FileManager) GetAvatarForUser(userID int64) (string, error) {
-	p := filepath.Join(config.GetString("fs.base_directory"), config.GetString("fs.avatar_directory"), string(userID))
-	_, err := os.Stat(p)
+// SaveAvatarForUser stores the image of reader as avatar of the user in all AvatarSizes
+func (mgr *FileManager) SaveAvatarForUser(userID int64, reader io.Reader) (err error) {
+	img, err := utils.DecodeImage(reader)
+	if err != nil {
+		return
+	}
+
+	_, err = mgr.storageRep.CreateDirectory(mgr.getAvatarDirectory())
+	if err != nil {
+		return
+	}
+
+	for _, size := range AvatarSizes {
+		var file repository.StorageHandle
+		file, err = mgr.storageRep.CreateHandle(mgr.getAvatarPath(userID, size))
+		if err != nil {
+			return
+		}
+		err = png.Encode(file, utils.CropAndResizeSquare(img, size))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error(0, "Could not store avatar of size %d for user %d: %v", size, userID, err)
+			return
+		}
+	}
+	return
+}
+
+// GetAvatarForUser opens the avatar of the user with the given size, the caller has to close it
+func (mgr *FileManager) GetAvatarForUser(userID int64, size int) (repository.StorageFile, error) {
+	if !isAvatarSize(size) {
+		return nil, ErrInvalidAvatarSize
+	}
+
+	file, err := mgr.storageRep.OpenFile(mgr.getAvatarPath(userID, size))
 	if err != nil {
 		log.Error(0, "DB says user %d has avatar, but the file was not found: %v", userID, err)
-		return "", err
+		return nil, err
+	}
+	return file, nil
+}
+
+// DeleteAvatarForUser deletes the avatar of the user in all sizes
+func (mgr *FileManager) DeleteAvatarForUser(userID int64) (err error) {
+	for _, size := range AvatarSizes {
+		err = mgr.storageRep.Delete(mgr.getAvatarPath(userID, size))
+		if err != nil {
+			return
+		}
 	}
-	return p, nil
+	return
+}
+
+func (mgr *FileManager) getAvatarDirectory() string {
+	return "/" + config.GetString("fs.avatar_directory")
+}
+
+func (mgr *FileManager) getAvatarPath(userID int64, size int) string {
+	return filepath.Join(mgr.getAvatarDirectory(), fmt.Sprintf("%d_%d.png", userID, size))
+}
+
+func isAvatarSize(size int) bool {
+	for _, avatarSize := range AvatarSizes {
+		if size == avatarSize {
+			return true
+		}
+	}
+	return false
+}
+
+// GetStorageUsedByUsers returns the summed up size of all files owned by each of the given users
+func (mgr *FileManager) GetStorageUsedByUsers(userIDs []int64) (map[int64]int64, error) {
+	return mgr.fileInfoRep.GetStorageUsedByOwners(userIDs)
 }
 
 func (mgr *FileManager) GetShareEntryByID(shareID int64, user *models.User) (*models.ShareEntry, error) {
 	return mgr.shareEntryRep.GetByIDForUser(shareID, user.ID)
 }
 
-func (mgr *FileManager) DeleteShareEntryByID(shareID int64, user *models.User) error {
+// DeleteShareEntryByID revokes a share of or with the user and returns the revoked share
+func (mgr *FileManager) DeleteShareEntryByID(shareID int64, user *models.User) (*models.ShareEntry, error) {
 	shareEntry, err := mgr.shareEntryRep.GetByIDForUser(shareID, user.ID)
+	if repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.New(fcerrors.ShareNotFound)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	err = mgr.deleteShareMounts(shareEntry.ID)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	err = mgr.shareEntryRep.Delete(shareEntry.ID)
 	if err != nil {
-		return err
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
 	}
-
-	// TODO: Delete file of shared_with user
-	return nil
+	return shareEntry, nil
 }
@@ -1,9 +1,16 @@
 package manager
 
 import (
+	"archive/zip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
 )
 
 func TestGetUserPath(t *testing.T) {
@@ -20,4 +27,224 @@ func TestGetUserPath(t *testing.T) {
 	}
 }
 
+func TestUpdateFile(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUser, "/docs", true)
+	mgr.CreateFile(testAuthUser, "/docs/a.txt", false)
+	mgr.CreateFile(testAuthUser, "/b.txt", false)
+
+	newPath, newName := "/docs", "c.txt"
+	fileInfo, err := mgr.UpdateFile(testAuthUser, "/b.txt", &models.FileInfoUpdate{Path: &newPath, Name: &newName})
+	if err != nil {
+		t.Fatalf("Failed to move file: %v", err)
+	}
+	if fileInfo.Path != "/docs/" || fileInfo.Name != "c.txt" {
+		t.Errorf("Expected moved file at /docs/c.txt but got: %s%s", fileInfo.Path, fileInfo.Name)
+	}
+	if _, err = os.Stat(filepath.Join(testAuthDataFolder, mgr.getUserPath(testAuthUser), "docs", "c.txt")); err != nil {
+		t.Errorf("Expected moved file in file system: %v", err)
+	}
+
+	newName = "papers"
+	_, err = mgr.UpdateFile(testAuthUser, "/docs", &models.FileInfoUpdate{Name: &newName})
+	if err != nil {
+		t.Fatalf("Failed to rename folder: %v", err)
+	}
+	fileInfo, err = mgr.GetFileInfo(testAuthUser, "/papers/a.txt", false)
+	if err != nil || fileInfo.Path != "/papers/" {
+		t.Errorf("Expected content of renamed folder to be moved along but got: %v, %v", fileInfo, err)
+	}
+
+	starred := true
+	fileInfo, err = mgr.UpdateFile(testAuthUser, "/papers/a.txt", &models.FileInfoUpdate{Starred: &starred})
+	if err != nil || !fileInfo.Starred || fileInfo.Path != "/papers/" || fileInfo.Name != "a.txt" {
+		t.Fatalf("Failed to star file without moving it: %v, %v", fileInfo, err)
+	}
+	starredInfos, _ := mgr.GetStarredFileInfosForUser(testAuthUser)
+	if len(starredInfos) != 1 || starredInfos[0].ID != fileInfo.ID {
+		t.Errorf("Expected starred file to be listed: %v", starredInfos)
+	}
+	starred = false
+	_, err = mgr.UpdateFile(testAuthUser, "/papers/a.txt", &models.FileInfoUpdate{Starred: &starred})
+	starredInfos, _ = mgr.GetStarredFileInfosForUser(testAuthUser)
+	if err != nil || len(starredInfos) != 0 {
+		t.Errorf("Failed to unstar file: %v, %v", starredInfos, err)
+	}
+
+	newName = "a.txt"
+	invalids := map[string]struct {
+		path   string
+		update *models.FileInfoUpdate
+		err    error
+	}{
+		"unknown file":       {"/unknown.txt", &models.FileInfoUpdate{Name: &newName}, ErrFileNotFound},
+		"root folder":        {"/", &models.FileInfoUpdate{Name: &newName}, ErrInvalidMoveTarget},
+		"existing name":      {"/papers/c.txt", &models.FileInfoUpdate{Name: &newName}, ErrFileExists},
+		"folder into itself": {"/papers", &models.FileInfoUpdate{Path: &fileInfo.Path}, ErrInvalidMoveTarget},
+		"into a file":        {"/papers/c.txt", &models.FileInfoUpdate{Path: &[]string{"/papers/a.txt"}[0]}, ErrInvalidMoveTarget},
+		"forbidden name":     {"/papers/c.txt", &models.FileInfoUpdate{Name: &[]string{"c|d.txt"}[0]}, ErrForbiddenPathName},
+	}
+	for name, invalid := range invalids {
+		_, err = mgr.UpdateFile(testAuthUser, invalid.path, invalid.update)
+		if err != invalid.err {
+			t.Errorf("Expected '%v' for moving %s but got: %v", invalid.err, name, err)
+		}
+	}
+}
+
+func TestWriteFile(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUser, "/docs", true)
+
+	fileInfo, err := mgr.WriteFile(testAuthUser, "/docs/a.txt", strings.NewReader("first"))
+	if err != nil {
+		t.Fatalf("Failed to write new file: %v", err)
+	}
+	if fileInfo.Size != 5 || fileInfo.OwnerID != testAuthUser.ID {
+		t.Errorf("Expected written file of 5 bytes owned by the user but got: %v", fileInfo)
+	}
+
+	fileInfo, err = mgr.WriteFile(testAuthUser, "/docs/a.txt", strings.NewReader("replaced content"))
+	if err != nil {
+		t.Fatalf("Failed to replace file: %v", err)
+	}
+	content, _ := ioutil.ReadFile(filepath.Join(testAuthDataFolder, mgr.getUserPath(testAuthUser), "docs", "a.txt"))
+	if fileInfo.Size != 16 || string(content) != "replaced content" {
+		t.Errorf("Expected replaced content in db and file system but got: %v, %s", fileInfo, content)
+	}
+
+	_, err = mgr.WriteFile(testAuthUser, "/docs/a.txt", iotest.TimeoutReader(strings.NewReader("broken")))
+	if err == nil {
+		t.Errorf("Expected error for failing reader")
+	}
+	content, _ = ioutil.ReadFile(filepath.Join(testAuthDataFolder, mgr.getUserPath(testAuthUser), "docs", "a.txt"))
+	if string(content) != "replaced content" {
+		t.Errorf("Expected content to be kept after failed write but got: %s", content)
+	}
+
+	mgr.ShareFile(testAuthUser, testAuthUserAdmin, "/docs")
+	err = mgr.CreateDirectoryForUser(testAuthUserAdmin, "/docs/sub")
+	if err != nil {
+		t.Fatalf("Failed to create folder in shared folder: %v", err)
+	}
+	fileInfo, err = mgr.WriteFile(testAuthUserAdmin, "/docs/sub/b.txt", strings.NewReader("shared"))
+	if err != nil {
+		t.Fatalf("Failed to write file into shared folder: %v", err)
+	}
+	if fileInfo.OwnerID != testAuthUser.ID || fileInfo.Path != "/docs/sub/" {
+		t.Errorf("Expected file written into shared folder to belong to its owner but got: %v", fileInfo)
+	}
+	if _, err = os.Stat(filepath.Join(testAuthDataFolder, mgr.getUserPath(testAuthUser), "docs", "sub", "b.txt")); err != nil {
+		t.Errorf("Expected written file in file system of the owner: %v", err)
+	}
+
+	invalids := map[string]struct {
+		path string
+		err  error
+	}{
+		"missing folder": {"/unknown/c.txt", ErrFileNotFound},
+		"into a file":    {"/docs/a.txt/c.txt", ErrFileNotFound},
+		"onto a folder":  {"/docs", ErrFileExists},
+		"root folder":    {"/", ErrInvalidMoveTarget},
+		"forbidden name": {"/docs/c|d.txt", ErrForbiddenPathName},
+	}
+	for name, invalid := range invalids {
+		_, err = mgr.WriteFile(testAuthUser, invalid.path, strings.NewReader("content"))
+		if err != invalid.err {
+			t.Errorf("Expected '%v' for writing %s but got: %v", invalid.err, name, err)
+		}
+	}
+}
+
+func TestCreateFile(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	// Deduplicated files are only stored when their handle is closed like in object stores
+	authMgr, _ := testDeduplicationSetup()
+	defer testDeduplicationCleanup(authMgr)
+
+	mgr := GetFileManager()
+	fileInfo, err := mgr.CreateFile(testAuthUser, "/empty.txt", false)
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	if fileInfo.Name != "empty.txt" || fileInfo.Size != 0 || fileInfo.IsDir {
+		t.Errorf("Expected info of created empty file but got: %v", fileInfo)
+	}
+
+	if _, err = mgr.CreateFile(testAuthUser, "/missing/a.txt", false); err == nil {
+		t.Errorf("Expected error for creating file in missing folder")
+	}
+}
 
+func TestMemoryStorageFileManager(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	memoryRep, _ := repository.CreateMemoryStorageRepository(".tmp", 1, 1)
+	authMgr := testAuthSetupWithStorage(memoryRep)
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUser, "/docs", true)
+	_, err := mgr.WriteFile(testAuthUser, "/docs/a.txt", strings.NewReader("in memory"))
+	if err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	newName := "b.txt"
+	_, err = mgr.UpdateFile(testAuthUser, "/docs/a.txt", &models.FileInfoUpdate{Name: &newName})
+	if err != nil {
+		t.Fatalf("Failed to rename file: %v", err)
+	}
+
+	file, fileInfo, err := mgr.OpenFile(testAuthUser, "/docs/b.txt")
+	if err != nil {
+		t.Fatalf("Failed to open renamed file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if fileInfo.Size != 9 || string(content) != "in memory" {
+		t.Errorf("Expected written content in renamed file but got: %v, %s", fileInfo, content)
+	}
+
+	zipPath, err := mgr.ZipFiles(testAuthUser, []string{"/docs"})
+	if err != nil {
+		t.Fatalf("Failed to zip folder: %v", err)
+	}
+	file, fileInfo, err = mgr.OpenFile(testAuthUser, zipPath)
+	if err != nil {
+		t.Fatalf("Failed to open zip: %v", err)
+	}
+	defer file.Close()
+	zipReader, err := zip.NewReader(file, fileInfo.Size)
+	if err != nil || len(zipReader.File) != 1 || zipReader.File[0].Name != "docs/b.txt" {
+		t.Errorf("Expected zip containing docs/b.txt: %v", err)
+	}
+
+	err = mgr.DeleteFile(testAuthUser, "/docs")
+	if err != nil {
+		t.Fatalf("Failed to delete folder: %v", err)
+	}
+	if _, err = memoryRep.GetInfo(mgr.getUserPath(testAuthUser), "/docs/b.txt"); err != repository.ErrFileNotExist {
+		t.Errorf("Expected deleted file to be removed from memory but got: %v", err)
+	}
+	if _, err = os.Stat(testAuthDataFolder); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written to the file system: %v", err)
+	}
+}
@@ -0,0 +1,540 @@
+package manager
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Types of external storages that can be mounted
+const (
+	// MountTypeLocal mounts a directory on the server
+	MountTypeLocal = "local"
+	// MountTypeSFTP mounts a directory on an SFTP server
+	MountTypeSFTP = "sftp"
+)
+
+// MountManager mounts external storages into the files of users and groups.
+// Their content is not scanned on startup but indexed one folder at a time when it is accessed.
+type MountManager struct {
+	mountRep   *repository.ExternalMountRepository
+	groupRep   *repository.GroupRepository
+	storageRep *repository.MountStorageRepository
+	tmpName    string
+
+	lock   sync.Mutex
+	mounts map[int64]*activeMount
+}
+
+// activeMount is a stored mount together with its opened storage and the users it is mounted for
+type activeMount struct {
+	mount *models.ExternalMount
+	// storage is nil if the storage could not be opened, the mount is not mounted for anybody then
+	storage repository.StorageRepository
+	userIDs []int64
+}
+
+var mountManager *MountManager
+
+// CreateMountManager creates a new singleton MountManager and mounts all stored mounts into the given MountStorageRepository.
+// It has to be created before the FileManager, so the scan of the files of users does not remove the mount points.
+// Mounts whose storage cannot be opened are logged and skipped.
+func CreateMountManager(mountRep *repository.ExternalMountRepository, groupRep *repository.GroupRepository, storageRep *repository.MountStorageRepository, tmpName string) *MountManager {
+	if mountManager != nil {
+		return mountManager
+	}
+
+	mountManager = &MountManager{
+		mountRep:   mountRep,
+		groupRep:   groupRep,
+		storageRep: storageRep,
+		tmpName:    tmpName,
+		mounts:     make(map[int64]*activeMount),
+	}
+
+	mounts, err := mountRep.GetAll()
+	if err != nil {
+		log.Error(0, "Could not load mounts: %v", err)
+	}
+	for _, mount := range mounts {
+		mountManager.activate(mount, nil)
+	}
+	return mountManager
+}
+
+// GetMountManager returns the singleton instance of the MountManager
+func GetMountManager() *MountManager {
+	return mountManager
+}
+
+// Close closes the storages of all mounts
+func (mgr *MountManager) Close() {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	for _, active := range mgr.mounts {
+		if active.storage != nil {
+			active.storage.Close()
+		}
+	}
+}
+
+// GetMounts returns all mounts without their secrets
+func (mgr *MountManager) GetMounts() ([]*models.ExternalMount, error) {
+	mounts, err := mgr.mountRep.GetAll()
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	for it, mount := range mounts {
+		mounts[it] = withoutSecrets(mount)
+	}
+	return mounts, nil
+}
+
+// CreateMount checks that the storage can be reached and mounts it into the files of the user or all members of the group
+func (mgr *MountManager) CreateMount(request *models.ExternalMount) (*models.ExternalMount, error) {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	mount := newMountFromRequest(request)
+	userIDs, err := mgr.validateMount(nil, mount)
+	if err != nil {
+		return nil, err
+	}
+	storage, err := openCheckedStorage(mount)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mgr.mountRep.Create(mount)
+	if err != nil {
+		storage.Close()
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	mgr.activate(mount, storage, userIDs...)
+	return withoutSecrets(mount), nil
+}
+
+// UpdateMount replaces the storage and mount point of a mount, empty secrets are kept.
+// If only the read-only flag changes, the storage stays mounted and its indexed content is kept.
+func (mgr *MountManager) UpdateMount(mountID int64, request *models.ExternalMount) (*models.ExternalMount, error) {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	existing, err := mgr.mountRep.GetByID(mountID)
+	if repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.New(fcerrors.MountNotFound)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	mount := newMountFromRequest(request)
+	mount.ID = existing.ID
+	mount.Created = existing.Created
+	if mount.Type == MountTypeSFTP && mount.Password == "" {
+		mount.Password = existing.Password
+	}
+	if mount.Type == MountTypeSFTP && mount.PrivateKey == "" {
+		mount.PrivateKey = existing.PrivateKey
+	}
+	userIDs, err := mgr.validateMount(existing, mount)
+	if err != nil {
+		return nil, err
+	}
+
+	active := mgr.mounts[mountID]
+	if active != nil && active.storage != nil && isSameStorage(existing, mount) && mount.Path == existing.Path && mount.UserID == existing.UserID && mount.GroupID == existing.GroupID {
+		err = mgr.mountRep.Update(mount)
+		if err != nil {
+			return nil, fcerrors.Wrap(err, fcerrors.Database)
+		}
+		active.mount = mount
+		for _, userID := range active.userIDs {
+			mgr.storageRep.Mount(getMountPoint(userID, mount.Path), active.storage, mount.ReadOnly)
+		}
+		return withoutSecrets(mount), nil
+	}
+
+	storage, err := openCheckedStorage(mount)
+	if err != nil {
+		return nil, err
+	}
+	err = mgr.mountRep.Update(mount)
+	if err != nil {
+		storage.Close()
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	if active != nil {
+		mgr.deactivate(active)
+	}
+	mgr.activate(mount, storage, userIDs...)
+	return withoutSecrets(mount), nil
+}
+
+// DeleteMount unmounts the storage from the files of all users, the files in the storage are left untouched
+func (mgr *MountManager) DeleteMount(mountID int64) error {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	err := mgr.mountRep.Delete(mountID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.MountNotFound)
+	} else if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if active := mgr.mounts[mountID]; active != nil {
+		mgr.deactivate(active)
+	}
+	return nil
+}
+
+// RefreshGroupMounts mounts the storages of the group for new members and unmounts them for former ones
+func (mgr *MountManager) RefreshGroupMounts(groupID int64) error {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	mounts, err := mgr.mountRep.GetAllForGroup(groupID)
+	if err != nil {
+		return err
+	}
+	memberIDs, err := mgr.groupRep.GetMemberIDs(groupID)
+	if err != nil {
+		return err
+	}
+
+	for _, mount := range mounts {
+		active := mgr.mounts[mount.ID]
+		if active == nil || active.storage == nil {
+			// The storage could not be opened before, so it is tried again
+			if active != nil {
+				delete(mgr.mounts, mount.ID)
+			}
+			mgr.activate(mount, nil, memberIDs...)
+			continue
+		}
+
+		for _, userID := range memberIDs {
+			if !containsID(active.userIDs, userID) {
+				mgr.mountFor(active, userID)
+			}
+		}
+		for _, userID := range append([]int64{}, active.userIDs...) {
+			if !containsID(memberIDs, userID) {
+				mgr.unmountFor(active, userID)
+			}
+		}
+	}
+	return nil
+}
+
+// RemoveGroup unmounts and deletes all mounts of a deleted group
+func (mgr *MountManager) RemoveGroup(groupID int64) error {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	for _, active := range mgr.mounts {
+		if active.mount.GroupID == groupID {
+			mgr.deactivate(active)
+		}
+	}
+	return mgr.mountRep.DeleteAllForGroup(groupID)
+}
+
+// RemoveUser unmounts all storages from the files of a deleted user and deletes his own mounts
+func (mgr *MountManager) RemoveUser(userID int64) error {
+	mgr.lock.Lock()
+	defer mgr.lock.Unlock()
+
+	for _, active := range mgr.mounts {
+		if active.mount.UserID == userID {
+			mgr.deactivate(active)
+		} else if containsID(active.userIDs, userID) {
+			mgr.unmountFor(active, userID)
+		}
+	}
+	return mgr.mountRep.DeleteAllForUser(userID)
+}
+
+// validateMount checks the mount point and storage type of the new or updated mount and returns the users it is mounted for
+func (mgr *MountManager) validateMount(existing, mount *models.ExternalMount) (userIDs []int64, err error) {
+	if !utils.ValidatePath(mount.Path) {
+		return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Path contains forbidden characters")
+	}
+	mount.Path = utils.ConvertToSlash(mount.Path, false)
+	if mount.Path == "/" || isWithinPath(mount.Path, "/"+mgr.tmpName) {
+		return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Storages cannot be mounted at the root or into the temp folder")
+	}
+
+	switch mount.Type {
+	case MountTypeLocal:
+		if !filepath.IsAbs(mount.Root) {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Root has to be an absolute path")
+		}
+		mount.Host, mount.Port, mount.Username, mount.Password, mount.PrivateKey, mount.HostKey = "", 0, "", "", "", ""
+	case MountTypeSFTP:
+		if mount.Port < 0 || mount.Port > 65535 {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Port is out of range")
+		}
+	default:
+		return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Type has to be local or sftp")
+	}
+
+	if (mount.UserID > 0) == (mount.GroupID > 0) {
+		return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Either a user or a group has to be set")
+	} else if mount.UserID > 0 {
+		_, err = GetAuthManager().GetUserByID(mount.UserID)
+		if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.UserNotFound {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "User cannot be found")
+		} else if err != nil {
+			return
+		}
+	} else {
+		_, err = mgr.groupRep.GetByID(mount.GroupID)
+		if repository.IsRecordNotFoundError(err) {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Group cannot be found")
+		} else if err != nil {
+			return nil, fcerrors.Wrap(err, fcerrors.Database)
+		}
+	}
+
+	userIDs, err = mgr.getTargetUserIDs(mount)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	for _, active := range mgr.mounts {
+		if existing != nil && active.mount.ID == existing.ID {
+			continue
+		}
+		if !isWithinPath(mount.Path, active.mount.Path) && !isWithinPath(active.mount.Path, mount.Path) {
+			continue
+		}
+		otherUserIDs, err := mgr.getTargetUserIDs(active.mount)
+		if err != nil {
+			return nil, fcerrors.Wrap(err, fcerrors.Database)
+		}
+		for _, userID := range userIDs {
+			if containsID(otherUserIDs, userID) {
+				return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Path overlaps with another mount of the same user")
+			}
+		}
+	}
+
+	// Own files of a user are not hidden by mounts, members of groups may have files at the path of group mounts though
+	samePoint := existing != nil && existing.Path == mount.Path && existing.UserID == mount.UserID
+	if mount.UserID > 0 && !samePoint {
+		_, err = mgr.storageRep.GetInfo(getMountPoint(mount.UserID, "/"), mount.Path)
+		if err == nil {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "A file or folder exists at the path already")
+		}
+	}
+	return userIDs, nil
+}
+
+// getTargetUserIDs returns the user or the members of the group the mount is for
+func (mgr *MountManager) getTargetUserIDs(mount *models.ExternalMount) ([]int64, error) {
+	if mount.GroupID > 0 {
+		return mgr.groupRep.GetMemberIDs(mount.GroupID)
+	}
+	return []int64{mount.UserID}, nil
+}
+
+// activate opens the storage of the mount if it is nil and mounts it for the given users or, if there are none, for all it targets
+func (mgr *MountManager) activate(mount *models.ExternalMount, storage repository.StorageRepository, userIDs ...int64) {
+	active := &activeMount{mount: mount, storage: storage}
+	mgr.mounts[mount.ID] = active
+
+	var err error
+	if active.storage == nil {
+		active.storage, err = openStorage(mount)
+		if err != nil {
+			log.Error(0, "Could not open storage of mount %d at %v: %v", mount.ID, mount.Path, err)
+			return
+		}
+	}
+	if len(userIDs) == 0 {
+		userIDs, err = mgr.getTargetUserIDs(mount)
+		if err != nil {
+			log.Error(0, "Could not get users of mount %d: %v", mount.ID, err)
+			return
+		}
+	}
+
+	for _, userID := range userIDs {
+		mgr.mountFor(active, userID)
+	}
+}
+
+// deactivate unmounts the storage of the mount for all users and closes it
+func (mgr *MountManager) deactivate(active *activeMount) {
+	for _, userID := range append([]int64{}, active.userIDs...) {
+		mgr.unmountFor(active, userID)
+	}
+	if active.storage != nil {
+		active.storage.Close()
+	}
+	delete(mgr.mounts, active.mount.ID)
+}
+
+// mountFor mounts the storage into the files of the user, creating the parent folders of the mount point if needed
+func (mgr *MountManager) mountFor(active *activeMount, userID int64) {
+	mountPoint := getMountPoint(userID, active.mount.Path)
+	if _, _, ok := mgr.storageRep.GetMount(mountPoint); ok || len(mgr.storageRep.GetMountPointsBelow(mountPoint)) > 0 {
+		log.Warn("Could not mount storage of mount %d for user %d: another storage is mounted at %v", active.mount.ID, userID, active.mount.Path)
+		return
+	}
+	_, err := mgr.storageRep.CreateDirectory(path.Dir(mountPoint))
+	if err != nil {
+		log.Error(0, "Could not create parent folder of mount point %v for user %d: %v", active.mount.Path, userID, err)
+		return
+	}
+
+	mgr.storageRep.Mount(mountPoint, active.storage, active.mount.ReadOnly)
+	active.userIDs = append(active.userIDs, userID)
+
+	// Before the FileManager has been created, mount points are indexed by its first scan
+	if fileMgr := GetFileManager(); fileMgr != nil {
+		err = fileMgr.indexMountPoint(userID, active.mount.Path)
+		if err != nil {
+			log.Warn("Could not index mount point %v of user %d: %v", active.mount.Path, userID, err)
+		}
+	}
+}
+
+// unmountFor unmounts the storage from the files of the user and removes its indexed content
+func (mgr *MountManager) unmountFor(active *activeMount, userID int64) {
+	mgr.storageRep.Unmount(getMountPoint(userID, active.mount.Path))
+	for it, mountedID := range active.userIDs {
+		if mountedID == userID {
+			active.userIDs = append(active.userIDs[:it], active.userIDs[it+1:]...)
+			break
+		}
+	}
+
+	if fileMgr := GetFileManager(); fileMgr != nil {
+		err := fileMgr.unindexMountPoint(userID, active.mount.Path)
+		if err != nil {
+			log.Warn("Could not remove mount point %v of user %d from the index: %v", active.mount.Path, userID, err)
+		}
+	}
+}
+
+// newMountFromRequest copies the fields that can be set by clients into a new mount
+func newMountFromRequest(request *models.ExternalMount) *models.ExternalMount {
+	return &models.ExternalMount{
+		UserID:     request.UserID,
+		GroupID:    request.GroupID,
+		Path:       request.Path,
+		Type:       request.Type,
+		Root:       request.Root,
+		Host:       request.Host,
+		Port:       request.Port,
+		Username:   request.Username,
+		Password:   request.Password,
+		PrivateKey: request.PrivateKey,
+		HostKey:    request.HostKey,
+		ReadOnly:   request.ReadOnly,
+	}
+}
+
+// openStorage creates the StorageRepository for the type of the mount, SFTP servers are not contacted before their first use
+func openStorage(mount *models.ExternalMount) (repository.StorageRepository, error) {
+	switch mount.Type {
+	case MountTypeLocal:
+		// The root is not created if it is missing, it may be an unavailable network share
+		rootInfo, err := os.Stat(mount.Root)
+		if err != nil || !rootInfo.IsDir() {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Root is not an existing directory")
+		}
+		return repository.CreateFileSystemRepository(mount.Root, "", 0, 0)
+	case MountTypeSFTP:
+		storage, err := repository.CreateSFTPStorageRepository(repository.SFTPStorageConfig{
+			Host:       mount.Host,
+			Port:       int(mount.Port),
+			Root:       mount.Root,
+			Username:   mount.Username,
+			Password:   mount.Password,
+			PrivateKey: mount.PrivateKey,
+			HostKey:    mount.HostKey,
+		})
+		if err != nil {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Host, root, credentials or host key of the SFTP server are missing or invalid")
+		}
+		return storage, nil
+	}
+	return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Type has to be local or sftp")
+}
+
+// openCheckedStorage opens the storage of the mount and checks that its root can be read
+func openCheckedStorage(mount *models.ExternalMount) (repository.StorageRepository, error) {
+	storage, err := openStorage(mount)
+	if err != nil {
+		return nil, err
+	}
+	rootInfo, err := storage.GetInfo("", "/")
+	if err != nil {
+		storage.Close()
+		return nil, fcerrors.Wrap(err, fcerrors.MountUnavailable)
+	} else if !rootInfo.IsDir {
+		storage.Close()
+		return nil, fcerrors.NewMsg(fcerrors.InvalidMountData, "Root is not a directory")
+	}
+	return storage, nil
+}
+
+// isSameStorage returns whether both mounts access the same storage with the same credentials
+func isSameStorage(mount, other *models.ExternalMount) bool {
+	return mount.Type == other.Type && mount.Root == other.Root && mount.Host == other.Host && mount.Port == other.Port &&
+		mount.Username == other.Username && mount.Password == other.Password && mount.PrivateKey == other.PrivateKey && mount.HostKey == other.HostKey
+}
+
+// withoutSecrets returns a copy of the mount without the credentials for its storage
+func withoutSecrets(mount *models.ExternalMount) *models.ExternalMount {
+	copied := *mount
+	copied.Password = ""
+	copied.PrivateKey = ""
+	return &copied
+}
+
+// getMountPoint returns the path in the StorageRepository the storage is mounted at for the user
+func getMountPoint(userID int64, mountPath string) string {
+	return path.Join("/", strconv.FormatInt(userID, 10), mountPath)
+}
+
+// getMount returns the mount point of the external storage the path in the StorageRepository is in and whether it is read-only.
+// ok is false for all other paths and if there is no MountManager.
+func getMount(storagePath string) (mountPoint string, readOnly, ok bool) {
+	if mountMgr := GetMountManager(); mountMgr != nil {
+		return mountMgr.storageRep.GetMount(storagePath)
+	}
+	return
+}
+
+// isMountPoint returns whether an external storage is mounted at the path in the StorageRepository
+func isMountPoint(storagePath string) bool {
+	mountPoint, _, ok := getMount(storagePath)
+	return ok && mountPoint == path.Clean("/"+filepath.ToSlash(storagePath))
+}
+
+// containsMountPoints returns whether the path in the StorageRepository is a mount point or has mount points below it
+func containsMountPoints(storagePath string) bool {
+	mountMgr := GetMountManager()
+	return mountMgr != nil && (isMountPoint(storagePath) || len(mountMgr.storageRep.GetMountPointsBelow(storagePath)) > 0)
+}
+
+func containsID(ids []int64, id int64) bool {
+	for _, containedID := range ids {
+		if containedID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,285 @@
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+var testMountDataFolder = "testMountData"
+
+func testMountCleanup(authMgr *AuthManager) {
+	if mountManager != nil {
+		mountManager.Close()
+		mountManager = nil
+	}
+	policyManager = nil
+	testAuthCleanup(authMgr)
+	os.RemoveAll(testMountDataFolder)
+}
+
+// testMountSetup sets up the managers with a mountable folder containing projects/plan.txt and readme.txt
+func testMountSetup() (*AuthManager, *MountManager, string) {
+	os.RemoveAll(testMountDataFolder)
+	os.MkdirAll(filepath.Join(testMountDataFolder, "projects"), 0755)
+	ioutil.WriteFile(filepath.Join(testMountDataFolder, "projects", "plan.txt"), []byte("plan"), 0644)
+	ioutil.WriteFile(filepath.Join(testMountDataFolder, "readme.txt"), []byte("readme"), 0644)
+	root, _ := filepath.Abs(testMountDataFolder)
+
+	testAuthCleanup(nil)
+	os.MkdirAll(testAuthDataFolder, 0755)
+	baseRep, _ := repository.CreateFileSystemRepository(testAuthDataFolder, ".tmp", 1, 1)
+	storageRep, _ := repository.CreateMountStorageRepository(baseRep)
+	authMgr := testAuthSetupWithStorage(storageRep)
+	testAuthInsert(authMgr)
+
+	mountRep, _ := repository.CreateExternalMountRepository()
+	groupRep, _ := repository.CreateGroupRepository()
+	roleBindingRep, _ := repository.CreateRoleBindingRepository()
+	CreatePolicyManager(groupRep, roleBindingRep)
+	return authMgr, CreateMountManager(mountRep, groupRep, storageRep, ".tmp"), root
+}
+
+func TestCreateMount(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr, root := testMountSetup()
+	defer testMountCleanup(authMgr)
+	GetFileManager().CreateFile(testAuthUser, "/docs", true)
+
+	invalids := map[string]*models.ExternalMount{
+		"root path":        {UserID: testAuthUser.ID, Path: "/", Type: MountTypeLocal, Root: root},
+		"temp folder":      {UserID: testAuthUser.ID, Path: "/.tmp/nas", Type: MountTypeLocal, Root: root},
+		"unknown type":     {UserID: testAuthUser.ID, Path: "/nas", Type: "ftp", Root: root},
+		"relative root":    {UserID: testAuthUser.ID, Path: "/nas", Type: MountTypeLocal, Root: "data"},
+		"no user or group": {Path: "/nas", Type: MountTypeLocal, Root: root},
+		"unknown user":     {UserID: 42, Path: "/nas", Type: MountTypeLocal, Root: root},
+		"unknown group":    {GroupID: 42, Path: "/nas", Type: MountTypeLocal, Root: root},
+		"existing folder":  {UserID: testAuthUser.ID, Path: "/docs", Type: MountTypeLocal, Root: root},
+		"sftp port":        {UserID: testAuthUser.ID, Path: "/nas", Type: MountTypeSFTP, Host: "localhost", Port: 70000, Root: "/"},
+		"missing root":     {UserID: testAuthUser.ID, Path: "/nas", Type: MountTypeLocal, Root: filepath.Join(root, "missing")},
+	}
+	for name, invalid := range invalids {
+		_, err := mgr.CreateMount(invalid)
+		if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidMountData {
+			t.Errorf("Expected invalid mount data for mount with %s but got: %v", name, err)
+		}
+	}
+
+	mount, err := mgr.CreateMount(&models.ExternalMount{UserID: testAuthUser.ID, Path: "/docs/nas", Type: MountTypeLocal, Root: root, Password: "secret"})
+	if err != nil {
+		t.Fatalf("Failed to create mount: %v", err)
+	}
+	if mount.ID <= 0 || mount.Password != "" || mount.Created <= 0 {
+		t.Errorf("Expected stored mount without secrets but got: %v", mount)
+	}
+	_, err = mgr.CreateMount(&models.ExternalMount{UserID: testAuthUser.ID, Path: "/docs/nas/sub", Type: MountTypeLocal, Root: root})
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidMountData {
+		t.Errorf("Expected invalid mount data for overlapping mount but got: %v", err)
+	}
+
+	mounts, err := mgr.GetMounts()
+	if err != nil || len(mounts) != 1 || mounts[0].Path != "/docs/nas" {
+		t.Errorf("Expected created mount in list of mounts but got: %v, %v", mounts, err)
+	}
+}
+
+func TestMountedFiles(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr, root := testMountSetup()
+	defer testMountCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	_, err := mgr.CreateMount(&models.ExternalMount{UserID: testAuthUser.ID, Path: "/nas", Type: MountTypeLocal, Root: root})
+	if err != nil {
+		t.Fatalf("Failed to create mount: %v", err)
+	}
+
+	pathInfo, err := fileMgr.GetPathInfo(testAuthUser, "/")
+	if err != nil {
+		t.Fatalf("Failed to get root folder: %v", err)
+	}
+	var mountPoint *models.FileInfo
+	for _, fileInfo := range pathInfo.Content {
+		if fileInfo.Name == "nas" {
+			mountPoint = fileInfo
+		}
+	}
+	if mountPoint == nil || !mountPoint.IsDir {
+		t.Fatalf("Expected mount point in root folder but got: %v", mountPoint)
+	}
+	pathInfo, err = fileMgr.GetPathInfo(testAuthUser, "/nas")
+	if err != nil || len(pathInfo.Content) != 2 {
+		t.Fatalf("Expected content of mount to be listed but got: %v, %v", pathInfo, err)
+	}
+
+	fileInfo, err := fileMgr.GetFileInfo(testAuthUser, "/nas/projects/plan.txt", false)
+	if err != nil || fileInfo.Size != 4 || fileInfo.OwnerID != testAuthUser.ID {
+		t.Fatalf("Expected info of not yet listed file in mount but got: %v, %v", fileInfo, err)
+	}
+	file, _, err := fileMgr.OpenFile(testAuthUser, "/nas/projects/plan.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file in mount: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "plan" {
+		t.Errorf("Expected content of file in mount but got: %s", content)
+	}
+
+	_, err = fileMgr.WriteFile(testAuthUser, "/nas/projects/notes.txt", strings.NewReader("notes"))
+	if err != nil {
+		t.Fatalf("Failed to write file into mount: %v", err)
+	}
+	if content, _ = ioutil.ReadFile(filepath.Join(root, "projects", "notes.txt")); string(content) != "notes" {
+		t.Errorf("Expected file written into mount in mounted folder but got: %s", content)
+	}
+	results, err := fileMgr.SearchForFiles(testAuthUser, "/notes")
+	if err != nil || len(results) != 1 {
+		t.Errorf("Expected written file in mount to be found but got: %v, %v", results, err)
+	}
+
+	err = fileMgr.ShareFile(testAuthUser, testAuthUserAdmin, "/nas/readme.txt")
+	if err != nil {
+		t.Fatalf("Failed to share not yet indexed file in mount: %v", err)
+	}
+	fileInfo, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/readme.txt", false)
+	if err != nil || fileInfo.Size != 6 {
+		t.Errorf("Expected shared file from mount for other user but got: %v, %v", fileInfo, err)
+	}
+
+	newName := "storage"
+	if _, err = fileMgr.UpdateFile(testAuthUser, "/nas", &models.FileInfoUpdate{Name: &newName}); err != ErrMountPoint {
+		t.Errorf("Expected ErrMountPoint for renaming mount point but got: %v", err)
+	}
+	if err = fileMgr.DeleteFile(testAuthUser, "/nas"); err != ErrMountPoint {
+		t.Errorf("Expected ErrMountPoint for deleting mount point but got: %v", err)
+	}
+
+	newPath := "/"
+	fileInfo, err = fileMgr.UpdateFile(testAuthUser, "/nas/projects/notes.txt", &models.FileInfoUpdate{Path: &newPath})
+	if err != nil || fileInfo.Path != "/" {
+		t.Fatalf("Failed to move file out of mount: %v, %v", fileInfo, err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "projects", "notes.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected file moved out of mount to be removed from mounted folder: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(testAuthDataFolder, fileMgr.getUserPath(testAuthUser), "notes.txt")); err != nil {
+		t.Errorf("Expected file moved out of mount in files of the user: %v", err)
+	}
+}
+
+func TestUpdateAndDeleteMount(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr, root := testMountSetup()
+	defer testMountCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	mount, _ := mgr.CreateMount(&models.ExternalMount{UserID: testAuthUser.ID, Path: "/nas", Type: MountTypeLocal, Root: root})
+	fileMgr.GetPathInfo(testAuthUser, "/nas")
+
+	update := *mount
+	update.ReadOnly = true
+	mount, err := mgr.UpdateMount(mount.ID, &update)
+	if err != nil || !mount.ReadOnly {
+		t.Fatalf("Failed to make mount read-only: %v, %v", mount, err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/nas/readme.txt", false); err != nil {
+		t.Errorf("Expected indexed file to be kept when making mount read-only: %v", err)
+	}
+	if _, err = fileMgr.WriteFile(testAuthUser, "/nas/new.txt", strings.NewReader("new")); err != ErrReadOnlyMount {
+		t.Errorf("Expected ErrReadOnlyMount for writing into read-only mount but got: %v", err)
+	}
+	if err = fileMgr.DeleteFile(testAuthUser, "/nas/readme.txt"); err != ErrReadOnlyMount {
+		t.Errorf("Expected ErrReadOnlyMount for deleting in read-only mount but got: %v", err)
+	}
+
+	update.ReadOnly = false
+	update.Path = "/storage"
+	mount, err = mgr.UpdateMount(mount.ID, &update)
+	if err != nil || mount.Path != "/storage" {
+		t.Fatalf("Failed to move mount: %v, %v", mount, err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/nas", false); err != ErrFileNotFound {
+		t.Errorf("Expected old mount point to be removed but got: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/storage/readme.txt", false); err != nil {
+		t.Errorf("Expected file at new mount point: %v", err)
+	}
+
+	if _, err = mgr.UpdateMount(42, &update); err == nil || err.(*fcerrors.FCError).Code != fcerrors.MountNotFound {
+		t.Errorf("Expected mount not found for updating unknown mount but got: %v", err)
+	}
+
+	err = mgr.DeleteMount(mount.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete mount: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/storage/readme.txt", false); err != ErrFileNotFound {
+		t.Errorf("Expected files of deleted mount to be removed from index but got: %v", err)
+	}
+	if _, err = os.Stat(filepath.Join(root, "readme.txt")); err != nil {
+		t.Errorf("Expected files of deleted mount to be kept in mounted folder: %v", err)
+	}
+	if err = mgr.DeleteMount(mount.ID); err == nil || err.(*fcerrors.FCError).Code != fcerrors.MountNotFound {
+		t.Errorf("Expected mount not found for deleting deleted mount but got: %v", err)
+	}
+}
+
+func TestGroupMount(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr, root := testMountSetup()
+	defer testMountCleanup(authMgr)
+	fileMgr := GetFileManager()
+	policyMgr := GetPolicyManager()
+
+	group, err := policyMgr.CreateGroup(&models.Group{Name: "team", MemberIds: []int64{testAuthUser.ID}})
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	_, err = mgr.CreateMount(&models.ExternalMount{GroupID: group.ID, Path: "/team", Type: MountTypeLocal, Root: root})
+	if err != nil {
+		t.Fatalf("Failed to create group mount: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/team/readme.txt", false); err != nil {
+		t.Errorf("Expected group mount for member: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/team", false); err != ErrFileNotFound {
+		t.Errorf("Expected no group mount for user outside of group but got: %v", err)
+	}
+
+	_, err = policyMgr.UpdateGroup(group.ID, &models.Group{Name: "team", MemberIds: []int64{testAuthUserAdmin.ID}})
+	if err != nil {
+		t.Fatalf("Failed to update members of group: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/team/readme.txt", false); err != nil {
+		t.Errorf("Expected group mount for new member: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/team", false); err != ErrFileNotFound {
+		t.Errorf("Expected group mount to be removed for former member but got: %v", err)
+	}
+
+	err = policyMgr.DeleteGroup(group.ID)
+	if err != nil {
+		t.Fatalf("Failed to delete group: %v", err)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUserAdmin, "/team", false); err != ErrFileNotFound {
+		t.Errorf("Expected group mount to be removed with its group but got: %v", err)
+	}
+	if mounts, _ := mgr.GetMounts(); len(mounts) != 0 {
+		t.Errorf("Expected mount of deleted group to be deleted but got: %v", mounts)
+	}
+}
@@ -0,0 +1,67 @@
+package manager
+
+import (
+	"os"
+	"path"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+)
+
+// cleanFilePath returns the absolute path without trailing slashes the FileManager expects for paths sent by clients of file servers
+func cleanFilePath(name string) string {
+	return path.Clean("/" + name)
+}
+
+// toOSError converts errors of the FileManager to the ones of the os package file servers like WebDAV and SFTP derive their status codes from
+func toOSError(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case err == ErrFileNotFound || err == ErrFileNotExist || err == repository.ErrFileNotExist || repository.IsRecordNotFoundError(err):
+		return os.ErrNotExist
+	case err == ErrFileExists:
+		return os.ErrExist
+	case err == ErrForbiddenPathName || err == repository.ErrForbiddenPathName || err == ErrInvalidMoveTarget || err == ErrSharedIntoShared || err == ErrOpenFolder:
+		return os.ErrPermission
+	case err == ErrReadOnlyMount || err == ErrMountPoint || err == repository.ErrReadOnlyStorage || err == repository.ErrMountPoint:
+		return os.ErrPermission
+	}
+	return err
+}
+
+// osFileInfo describes a file by its info stored in the db
+type osFileInfo struct {
+	fileInfo *models.FileInfo
+}
+
+func (fi *osFileInfo) Name() string {
+	if fi.fileInfo.Name == "" {
+		return "/"
+	}
+	return fi.fileInfo.Name
+}
+
+func (fi *osFileInfo) Size() int64 {
+	return fi.fileInfo.Size
+}
+
+func (fi *osFileInfo) Mode() os.FileMode {
+	if fi.fileInfo.IsDir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (fi *osFileInfo) ModTime() time.Time {
+	return time.Unix(fi.fileInfo.LastChanged, 0)
+}
+
+func (fi *osFileInfo) IsDir() bool {
+	return fi.fileInfo.IsDir
+}
+
+func (fi *osFileInfo) Sys() interface{} {
+	return fi.fileInfo
+}
@@ -0,0 +1,450 @@
+package manager
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	log "gopkg.in/clog.v1"
+)
+
+// Permissions checked by the PolicyManager before an operation is executed
+const (
+	// PermissionFileWrite allows creating, changing and deleting own files
+	PermissionFileWrite = "file.write"
+	// PermissionFileShare allows sharing own files with other users
+	PermissionFileShare = "file.share"
+	// PermissionUserRead allows listing and viewing all users
+	PermissionUserRead = "user.read"
+	// PermissionUserManage allows approving, disabling, unlocking and deleting users, managing invite codes and transferring files
+	PermissionUserManage = "user.manage"
+	// PermissionUserImpersonate allows acting as another user in a time-limited session
+	PermissionUserImpersonate = "user.impersonate"
+	// PermissionSystemRead allows viewing system statistics
+	PermissionSystemRead = "system.read"
+	// PermissionRoleManage allows managing groups and assigning roles
+	PermissionRoleManage = "role.manage"
+	// PermissionAuditRead allows querying and exporting the audit log
+	PermissionAuditRead = "audit.read"
+	// PermissionMountManage allows mounting external storages into the files of users and groups
+	PermissionMountManage = "mount.manage"
+	// PermissionStorageManage allows managing the stored files, like encrypting, deduplicating and scrubbing them
+	PermissionStorageManage = "storage.manage"
+)
+
+// Names of the built-in roles
+const (
+	RoleAdmin         = "admin"
+	RoleUserManager   = "user_manager"
+	RoleAuditor       = "auditor"
+	RoleReadOnly      = "read_only"
+	RoleShareDisabled = "share_disabled"
+)
+
+// defaultPermissions are held by every user before the restrictions of his roles are applied
+var defaultPermissions = []string{PermissionFileWrite, PermissionFileShare}
+
+var roles = []*models.Role{
+	{
+		Name:        RoleAdmin,
+		Description: "Full access, held by all admins",
+		Grants:      []string{PermissionFileWrite, PermissionFileShare, PermissionUserRead, PermissionUserManage, PermissionUserImpersonate, PermissionSystemRead, PermissionRoleManage, PermissionAuditRead, PermissionMountManage, PermissionStorageManage},
+		Restricts:   []string{},
+	},
+	{
+		Name:        RoleUserManager,
+		Description: "Manage users and invite codes",
+		Grants:      []string{PermissionUserRead, PermissionUserManage},
+		Restricts:   []string{},
+	},
+	{
+		Name:        RoleAuditor,
+		Description: "View users, system statistics and the audit log",
+		Grants:      []string{PermissionUserRead, PermissionSystemRead, PermissionAuditRead},
+		Restricts:   []string{},
+	},
+	{
+		Name:        RoleReadOnly,
+		Description: "Cannot change or share files",
+		Grants:      []string{},
+		Restricts:   []string{PermissionFileWrite, PermissionFileShare},
+	},
+	{
+		Name:        RoleShareDisabled,
+		Description: "Cannot share files",
+		Grants:      []string{},
+		Restricts:   []string{PermissionFileShare},
+	},
+}
+
+// PolicyManager resolves the roles of users and their groups to permissions and checks them
+type PolicyManager struct {
+	groupRep       *repository.GroupRepository
+	roleBindingRep *repository.RoleBindingRepository
+}
+
+var policyManager *PolicyManager
+
+// CreatePolicyManager creates a new singleton PolicyManager which can be used immediately
+func CreatePolicyManager(groupRep *repository.GroupRepository, roleBindingRep *repository.RoleBindingRepository) *PolicyManager {
+	if policyManager != nil {
+		return policyManager
+	}
+
+	policyManager = &PolicyManager{
+		groupRep:       groupRep,
+		roleBindingRep: roleBindingRep,
+	}
+	return policyManager
+}
+
+// GetPolicyManager returns the singleton instance of the PolicyManager
+func GetPolicyManager() *PolicyManager {
+	return policyManager
+}
+
+// GetRoles returns all built-in roles
+func (mgr *PolicyManager) GetRoles() []*models.Role {
+	return roles
+}
+
+// ResolvePermissions sets the roles of the user, including those of his groups, and the permissions resulting from them.
+// Restrictions of any role take precedence over grants, so e.g. an admin with the read-only role cannot change files.
+func (mgr *PolicyManager) ResolvePermissions(user *models.User) error {
+	groupIDs, err := mgr.groupRep.GetGroupIDsForUser(user.ID)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	roleNames, err := mgr.roleBindingRep.GetForUserAndGroups(user.ID, groupIDs)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	if user.IsAdmin {
+		roleNames = append(roleNames, RoleAdmin)
+	}
+
+	assigned := map[string]bool{}
+	granted := map[string]bool{}
+	restricted := map[string]bool{}
+	for _, permission := range defaultPermissions {
+		granted[permission] = true
+	}
+	for _, roleName := range roleNames {
+		role := getRole(roleName)
+		if role == nil {
+			log.Warn("Ignoring unknown role %s of user %d", roleName, user.ID)
+			continue
+		}
+		assigned[roleName] = true
+		for _, permission := range role.Grants {
+			granted[permission] = true
+		}
+		for _, permission := range role.Restricts {
+			restricted[permission] = true
+		}
+	}
+
+	user.Roles = []string{}
+	for roleName := range assigned {
+		user.Roles = append(user.Roles, roleName)
+	}
+	sort.Strings(user.Roles)
+
+	user.Permissions = []string{}
+	for permission := range granted {
+		if !restricted[permission] {
+			user.Permissions = append(user.Permissions, permission)
+		}
+	}
+	sort.Strings(user.Permissions)
+	return nil
+}
+
+// Authorize returns a Forbidden error if the user lacks the permission.
+// This is the central check every controller calls before executing an operation.
+func (mgr *PolicyManager) Authorize(user *models.User, permission string) error {
+	if user.Permissions == nil {
+		err := mgr.ResolvePermissions(user)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, userPermission := range user.Permissions {
+		if userPermission == permission {
+			return nil
+		}
+	}
+	return fcerrors.New(fcerrors.Forbidden)
+}
+
+// AuthorizeUserManagement checks whether the actor may manage the target user.
+// Besides the permission to manage users, the actor needs all administrative permissions of the target,
+// so e.g. a user manager cannot disable or delete an admin.
+func (mgr *PolicyManager) AuthorizeUserManagement(actor *models.User, targetID int64) error {
+	err := mgr.Authorize(actor, PermissionUserManage)
+	if err != nil {
+		return err
+	}
+
+	target, err := GetAuthManager().GetUserByID(targetID)
+	if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.UserNotFound {
+		// Managing deleted users, e.g. transferring their files, is not restricted any further
+		return nil
+	} else if err != nil {
+		return err
+	}
+	err = mgr.ResolvePermissions(target)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range target.Permissions {
+		if isDefaultPermission(permission) {
+			continue
+		}
+		if mgr.Authorize(actor, permission) != nil {
+			return fcerrors.New(fcerrors.Forbidden)
+		}
+	}
+	return nil
+}
+
+// HasAdministrativePermission returns whether the user holds any permission beyond the default ones
+func (mgr *PolicyManager) HasAdministrativePermission(user *models.User) bool {
+	if user.Permissions == nil && mgr.ResolvePermissions(user) != nil {
+		return false
+	}
+
+	for _, permission := range user.Permissions {
+		if !isDefaultPermission(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetUserRoles returns the roles assigned directly to a user
+func (mgr *PolicyManager) GetUserRoles(userID int64) ([]string, error) {
+	roleNames, err := mgr.roleBindingRep.GetForUser(userID)
+	return roleNames, fcerrors.Wrap(err, fcerrors.Database)
+}
+
+// SetUserRoles replaces the roles assigned directly to a user, roles of his groups are not affected
+func (mgr *PolicyManager) SetUserRoles(userID int64, roleNames []string) ([]string, error) {
+	roleNames, err := validateRoles(roleNames)
+	if err != nil {
+		return nil, err
+	}
+	_, err = GetAuthManager().GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mgr.roleBindingRep.SetForUser(userID, roleNames)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return roleNames, nil
+}
+
+// GetGroups returns all groups with their roles and members
+func (mgr *PolicyManager) GetGroups() ([]*models.Group, error) {
+	groups, err := mgr.groupRep.GetAll()
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	for _, group := range groups {
+		err = mgr.fillGroup(group)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return groups, nil
+}
+
+// CreateGroup creates a new group with the given name, roles and members
+func (mgr *PolicyManager) CreateGroup(request *models.Group) (*models.Group, error) {
+	err := mgr.validateGroup(0, request)
+	if err != nil {
+		return nil, err
+	}
+
+	group := &models.Group{Name: request.Name}
+	err = mgr.groupRep.Create(group)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	return mgr.setGroupBindings(group, request)
+}
+
+// UpdateGroup replaces the name, roles and members of a group
+func (mgr *PolicyManager) UpdateGroup(groupID int64, request *models.Group) (*models.Group, error) {
+	group, err := mgr.groupRep.GetByID(groupID)
+	if repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.New(fcerrors.GroupNotFound)
+	} else if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	err = mgr.validateGroup(groupID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	group.Name = request.Name
+	err = mgr.groupRep.Update(group)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	return mgr.setGroupBindings(group, request)
+}
+
+// DeleteGroup deletes a group, its members lose the roles of the group
+func (mgr *PolicyManager) DeleteGroup(groupID int64) error {
+	err := mgr.groupRep.Delete(groupID)
+	if repository.IsRecordNotFoundError(err) {
+		return fcerrors.New(fcerrors.GroupNotFound)
+	} else if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	err = mgr.roleBindingRep.DeleteAllForGroup(groupID)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	if mountMgr := GetMountManager(); mountMgr != nil {
+		err = mountMgr.RemoveGroup(groupID)
+		if err != nil {
+			return fcerrors.Wrap(err, fcerrors.Database)
+		}
+	}
+	return nil
+}
+
+// DeleteBindingsForUser removes a deleted user from all groups and deletes the roles assigned to him
+func (mgr *PolicyManager) DeleteBindingsForUser(userID int64) error {
+	err := mgr.groupRep.DeleteMembershipsForUser(userID)
+	if err != nil {
+		return err
+	}
+	return mgr.roleBindingRep.DeleteAllForUser(userID)
+}
+
+func (mgr *PolicyManager) validateGroup(groupID int64, request *models.Group) (err error) {
+	request.Name = strings.TrimSpace(request.Name)
+	if request.Name == "" {
+		return fcerrors.New(fcerrors.InvalidGroupData)
+	}
+
+	existing, err := mgr.groupRep.GetByName(request.Name)
+	if err == nil && existing.ID != groupID {
+		return fcerrors.New(fcerrors.GroupExists)
+	} else if err != nil && !repository.IsRecordNotFoundError(err) {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+
+	request.Roles, err = validateRoles(request.Roles)
+	if err != nil {
+		return
+	}
+
+	for _, userID := range request.MemberIds {
+		_, err = GetAuthManager().GetUserByID(userID)
+		if fcErr, ok := err.(*fcerrors.FCError); ok && fcErr.Code == fcerrors.UserNotFound {
+			return fcerrors.NewMsg(fcerrors.InvalidGroupData, "Member of the group cannot be found")
+		} else if err != nil {
+			return
+		}
+	}
+	return nil
+}
+
+func (mgr *PolicyManager) setGroupBindings(group, request *models.Group) (*models.Group, error) {
+	err := mgr.groupRep.SetMembers(group.ID, uniqueIDs(request.MemberIds))
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	err = mgr.roleBindingRep.SetForGroup(group.ID, request.Roles)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	if mountMgr := GetMountManager(); mountMgr != nil {
+		err = mountMgr.RefreshGroupMounts(group.ID)
+		if err != nil {
+			return nil, fcerrors.Wrap(err, fcerrors.Database)
+		}
+	}
+
+	err = mgr.fillGroup(group)
+	if err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+func (mgr *PolicyManager) fillGroup(group *models.Group) (err error) {
+	group.Roles, err = mgr.roleBindingRep.GetForGroup(group.ID)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	group.MemberIds, err = mgr.groupRep.GetMemberIDs(group.ID)
+	if err != nil {
+		return fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return nil
+}
+
+func getRole(name string) *models.Role {
+	for _, role := range roles {
+		if role.Name == name {
+			return role
+		}
+	}
+	return nil
+}
+
+// validateRoles checks that all roles exist and returns them sorted and without duplicates
+func validateRoles(roleNames []string) ([]string, error) {
+	unique := map[string]bool{}
+	for _, roleName := range roleNames {
+		if getRole(roleName) == nil {
+			return nil, fcerrors.NewMsg(fcerrors.InvalidRole, "Role '"+roleName+"' does not exist")
+		}
+		unique[roleName] = true
+	}
+
+	validated := []string{}
+	for roleName := range unique {
+		validated = append(validated, roleName)
+	}
+	sort.Strings(validated)
+	return validated, nil
+}
+
+func uniqueIDs(ids []int64) []int64 {
+	seen := map[int64]bool{}
+	var unique []int64
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+func isDefaultPermission(permission string) bool {
+	for _, defaultPermission := range defaultPermissions {
+		if defaultPermission == permission {
+			return true
+		}
+	}
+	return false
+}
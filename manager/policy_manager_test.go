@@ -0,0 +1,208 @@
+package manager
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+func testPolicyCleanup(mgr *AuthManager) {
+	policyManager = nil
+	testAuthCleanup(mgr)
+}
+
+func testPolicySetup() (*AuthManager, *PolicyManager) {
+	authMgr := testAuthSetup()
+	groupRep, _ := repository.CreateGroupRepository()
+	roleBindingRep, _ := repository.CreateRoleBindingRepository()
+	return authMgr, CreatePolicyManager(groupRep, roleBindingRep)
+}
+
+// testPolicyUsers inserts the test users and a third one, returning fresh copies without resolved permissions
+func testPolicyUsers(authMgr *AuthManager) (admin, user, other *models.User) {
+	testAuthInsert(authMgr)
+	authMgr.CreateUser(&models.User{FirstName: "Other", LastName: "User", Email: "other.user@email.com", Password: testAuthUserPW}, "")
+	admin, _ = authMgr.GetUserByID(testAuthUserAdmin.ID)
+	user, _ = authMgr.GetUserByID(testAuthUser.ID)
+	other, _ = authMgr.GetUserByEmail("other.user@email.com")
+	return
+}
+
+func TestResolvePermissions(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testPolicySetup()
+	defer testPolicyCleanup(authMgr)
+	admin, user, other := testPolicyUsers(authMgr)
+
+	err := mgr.ResolvePermissions(admin)
+	if err != nil {
+		t.Fatalf("Failed to resolve permissions of admin: %v", err)
+	}
+	if !reflect.DeepEqual(admin.Roles, []string{RoleAdmin}) || len(admin.Permissions) != 10 {
+		t.Errorf("Expected admin role with all permissions but got %v, %v", admin.Roles, admin.Permissions)
+	}
+	mgr.ResolvePermissions(user)
+	if len(user.Roles) != 0 || !reflect.DeepEqual(user.Permissions, []string{PermissionFileShare, PermissionFileWrite}) {
+		t.Errorf("Expected no roles and default permissions for user but got %v, %v", user.Roles, user.Permissions)
+	}
+	if mgr.HasAdministrativePermission(user) {
+		t.Errorf("Expected user without roles to have no administrative permission")
+	}
+
+	_, err = mgr.SetUserRoles(user.ID, []string{RoleAuditor, RoleUserManager, RoleAuditor})
+	if err != nil {
+		t.Fatalf("Failed to set roles of user: %v", err)
+	}
+	_, err = mgr.CreateGroup(&models.Group{Name: "restricted", Roles: []string{RoleReadOnly}, MemberIds: []int64{user.ID, admin.ID}})
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+
+	mgr.ResolvePermissions(user)
+	expRoles := []string{RoleAuditor, RoleReadOnly, RoleUserManager}
+	expPermissions := []string{PermissionAuditRead, PermissionSystemRead, PermissionUserManage, PermissionUserRead}
+	if !reflect.DeepEqual(user.Roles, expRoles) || !reflect.DeepEqual(user.Permissions, expPermissions) {
+		t.Errorf("Expected roles %v and permissions %v but got %v, %v", expRoles, expPermissions, user.Roles, user.Permissions)
+	}
+	if !mgr.HasAdministrativePermission(user) {
+		t.Errorf("Expected user manager to have an administrative permission")
+	}
+	err = mgr.Authorize(user, PermissionFileWrite)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.Forbidden {
+		t.Errorf("Expected 'forbidden' for writing files as read-only user but got: %v", err)
+	}
+	err = mgr.Authorize(user, PermissionUserManage)
+	if err != nil {
+		t.Errorf("Expected user manager to be allowed to manage users but got: %v", err)
+	}
+
+	mgr.ResolvePermissions(admin)
+	if mgr.Authorize(admin, PermissionFileShare) == nil || mgr.Authorize(admin, PermissionRoleManage) != nil {
+		t.Errorf("Expected restrictions of the read-only role to apply to admins as well: %v", admin.Permissions)
+	}
+
+	// Permissions are resolved on demand if they have not been before
+	err = mgr.Authorize(other, PermissionFileWrite)
+	if err != nil || other.Permissions == nil {
+		t.Errorf("Expected permissions of other user to be resolved while authorizing: %v, %v", other.Permissions, err)
+	}
+
+	_, err = mgr.SetUserRoles(user.ID, []string{"superuser"})
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.InvalidRole {
+		t.Errorf("Expected 'invalid role' for unknown role but got: %v", err)
+	}
+	_, err = mgr.SetUserRoles(user.ID+100, []string{RoleAuditor})
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.UserNotFound {
+		t.Errorf("Expected 'user not found' for assigning roles to unknown user but got: %v", err)
+	}
+
+	authMgr.DeleteUser(user.ID)
+	roles, _ := mgr.GetUserRoles(user.ID)
+	groups, _ := mgr.GetGroups()
+	if len(roles) != 0 || len(groups) != 1 || !reflect.DeepEqual(groups[0].MemberIds, []int64{admin.ID}) {
+		t.Errorf("Expected roles and memberships of deleted user to be removed but got %v, %v", roles, groups)
+	}
+}
+
+func TestAuthorizeUserManagement(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testPolicySetup()
+	defer testPolicyCleanup(authMgr)
+	admin, user, other := testPolicyUsers(authMgr)
+
+	err := mgr.AuthorizeUserManagement(user, other.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.Forbidden {
+		t.Errorf("Expected 'forbidden' for managing users without role but got: %v", err)
+	}
+
+	mgr.SetUserRoles(user.ID, []string{RoleUserManager})
+	mgr.ResolvePermissions(user)
+	err = mgr.AuthorizeUserManagement(user, other.ID)
+	if err != nil {
+		t.Errorf("Expected user manager to be allowed to manage a regular user but got: %v", err)
+	}
+	err = mgr.AuthorizeUserManagement(user, other.ID+100)
+	if err != nil {
+		t.Errorf("Expected user manager to be allowed to manage a deleted user but got: %v", err)
+	}
+	err = mgr.AuthorizeUserManagement(user, admin.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.Forbidden {
+		t.Errorf("Expected 'forbidden' for user manager managing an admin but got: %v", err)
+	}
+
+	mgr.SetUserRoles(other.ID, []string{RoleAuditor})
+	err = mgr.AuthorizeUserManagement(user, other.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.Forbidden {
+		t.Errorf("Expected 'forbidden' for user manager managing an auditor but got: %v", err)
+	}
+	err = mgr.AuthorizeUserManagement(admin, other.ID)
+	if err != nil {
+		t.Errorf("Expected admin to be allowed to manage an auditor but got: %v", err)
+	}
+}
+
+func TestGroups(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testPolicySetup()
+	defer testPolicyCleanup(authMgr)
+	_, user, other := testPolicyUsers(authMgr)
+
+	group, err := mgr.CreateGroup(&models.Group{Name: " support ", Roles: []string{RoleShareDisabled}, MemberIds: []int64{other.ID, user.ID, other.ID}})
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	expMembers := []int64{user.ID, other.ID}
+	if group.Name != "support" || !reflect.DeepEqual(group.MemberIds, expMembers) || !reflect.DeepEqual(group.Roles, []string{RoleShareDisabled}) {
+		t.Errorf("Expected trimmed group with unique members %v but got: %v", expMembers, group)
+	}
+
+	invalids := map[string]struct {
+		group *models.Group
+		code  fcerrors.Code
+	}{
+		"empty name":     {&models.Group{Name: " "}, fcerrors.InvalidGroupData},
+		"duplicate name": {&models.Group{Name: "support"}, fcerrors.GroupExists},
+		"unknown role":   {&models.Group{Name: "other", Roles: []string{"superuser"}}, fcerrors.InvalidRole},
+		"unknown member": {&models.Group{Name: "other", MemberIds: []int64{other.ID + 100}}, fcerrors.InvalidGroupData},
+	}
+	for name, invalid := range invalids {
+		_, err = mgr.CreateGroup(invalid.group)
+		if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != invalid.code {
+			t.Errorf("Expected '%s' for group with %s but got: %v", invalid.code.Msg, name, err)
+		}
+	}
+
+	group, err = mgr.UpdateGroup(group.ID, &models.Group{Name: "support", Roles: []string{RoleAuditor}, MemberIds: []int64{other.ID}})
+	if err != nil {
+		t.Fatalf("Failed to update group: %v", err)
+	}
+	if !reflect.DeepEqual(group.MemberIds, []int64{other.ID}) || !reflect.DeepEqual(group.Roles, []string{RoleAuditor}) {
+		t.Errorf("Expected roles and members of group to be replaced but got: %v", group)
+	}
+	_, err = mgr.UpdateGroup(group.ID+100, &models.Group{Name: "unknown"})
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.GroupNotFound {
+		t.Errorf("Expected 'group not found' for updating unknown group but got: %v", err)
+	}
+
+	err = mgr.DeleteGroup(group.ID)
+	if err != nil {
+		t.Errorf("Failed to delete group: %v", err)
+	}
+	mgr.ResolvePermissions(other)
+	if len(other.Roles) != 0 {
+		t.Errorf("Expected roles of deleted group to be removed but got: %v", other.Roles)
+	}
+	err = mgr.DeleteGroup(group.ID)
+	if fcErr, ok := err.(*fcerrors.FCError); !ok || fcErr.Code != fcerrors.GroupNotFound {
+		t.Errorf("Expected 'group not found' for deleting deleted group but got: %v", err)
+	}
+}
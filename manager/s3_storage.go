@@ -0,0 +1,442 @@
+package manager
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/utils"
+)
+
+var (
+	// ErrS3NoSuchBucket indicates a bucket which is no top-level folder of the user
+	ErrS3NoSuchBucket = errors.New("s3: Bucket does not exist")
+	// ErrS3InvalidKey indicates an object key which cannot be mapped to a path, like ones with empty or relative segments
+	ErrS3InvalidKey = errors.New("s3: Object key is no valid path")
+	// ErrS3NoSuchUpload indicates a multipart upload which does not exist, has been finished or belongs to another object
+	ErrS3NoSuchUpload = errors.New("s3: Multipart upload does not exist")
+	// ErrS3InvalidPart indicates a part which has not been uploaded or whose content does not match its ETag
+	ErrS3InvalidPart = errors.New("s3: Part has not been uploaded or its ETag does not match")
+	// ErrS3InvalidPartOrder indicates parts for completing an upload which are not in ascending order
+	ErrS3InvalidPartOrder = errors.New("s3: Parts have to be in ascending order")
+)
+
+const (
+	// S3MaxPartNumber is the highest part number of multipart uploads
+	S3MaxPartNumber = 10000
+
+	s3UploadPrefix     = "s3-upload-"
+	s3UploadIDLength   = 32 // characters
+	s3UploadTargetName = "target"
+)
+
+// S3Object is a file in a listing of a bucket, empty folders are listed with a trailing slash in their key
+type S3Object struct {
+	Key      string
+	FileInfo *models.FileInfo
+}
+
+// S3ObjectList is a page of the objects in a bucket and the common prefixes the delimiter rolled them up into
+type S3ObjectList struct {
+	Objects        []*S3Object
+	CommonPrefixes []string
+	IsTruncated    bool
+	// NextStartAfter is the last key or common prefix of the page, listing after it continues a truncated list
+	NextStartAfter string
+}
+
+// S3CompletedPart is a part the client uploaded with the ETag it got for it
+type S3CompletedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// ListS3Buckets returns the top-level folders of the user including the ones shared with him, they are the buckets of the S3 API
+func (mgr *FileManager) ListS3Buckets(user *models.User) (buckets []*models.FileInfo, err error) {
+	pathInfo, err := mgr.GetPathInfo(user, "/")
+	if err != nil {
+		return
+	}
+	for _, fileInfo := range pathInfo.Content {
+		if fileInfo.IsDir && fileInfo.Name != mgr.tmpName {
+			buckets = append(buckets, fileInfo)
+		}
+	}
+	return
+}
+
+// GetS3Bucket returns the top-level folder of the user which is the bucket
+func (mgr *FileManager) GetS3Bucket(user *models.User, bucket string) (*models.FileInfo, error) {
+	if bucket == "" || bucket == mgr.tmpName || strings.Contains(bucket, "/") {
+		return nil, ErrS3NoSuchBucket
+	}
+	fileInfo, err := mgr.GetFileInfo(user, "/"+bucket, true)
+	if err != nil || !fileInfo.IsDir {
+		return nil, ErrS3NoSuchBucket
+	}
+	return fileInfo, nil
+}
+
+// ListS3Objects lists the objects in the bucket with keys starting with prefix in lexicographical order after startAfter.
+// If delimiter is set, keys containing it after the prefix are rolled up into a common prefix ending with the first delimiter.
+// Only folders within the prefix are read and those rolled up entirely are not descended into.
+func (mgr *FileManager) ListS3Objects(user *models.User, bucket, prefix, delimiter, startAfter string, maxKeys int) (list *S3ObjectList, err error) {
+	if _, err = mgr.GetS3Bucket(user, bucket); err != nil {
+		return
+	}
+
+	entries := make(map[string]*models.FileInfo)
+	add := func(key string, fileInfo *models.FileInfo) {
+		if key > startAfter {
+			entries[key] = fileInfo
+		}
+	}
+	folderKey := prefix[:strings.LastIndex(prefix, "/")+1]
+	_, err = mgr.walkS3Folder(user, bucket, folderKey, prefix, delimiter, startAfter, add)
+	if err != nil && toOSError(err) != os.ErrNotExist {
+		return
+	}
+
+	keys := make([]string, 0, len(entries))
+	for key := range entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	list = &S3ObjectList{}
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+		list.IsTruncated = true
+		if maxKeys > 0 {
+			list.NextStartAfter = keys[maxKeys-1]
+		}
+	}
+	for _, key := range keys {
+		if fileInfo := entries[key]; fileInfo != nil {
+			list.Objects = append(list.Objects, &S3Object{Key: key, FileInfo: fileInfo})
+		} else {
+			list.CommonPrefixes = append(list.CommonPrefixes, key)
+		}
+	}
+	return list, nil
+}
+
+// walkS3Folder adds the objects below the folder which match the prefix, common prefixes are added without file info.
+// Returns whether the folder is empty.
+func (mgr *FileManager) walkS3Folder(user *models.User, bucket, folderKey, prefix, delimiter, startAfter string, add func(key string, fileInfo *models.FileInfo)) (empty bool, err error) {
+	pathInfo, err := mgr.GetPathInfo(user, s3Path(bucket, folderKey))
+	if err != nil {
+		return
+	}
+
+	for _, fileInfo := range pathInfo.Content {
+		key := folderKey + fileInfo.Name
+		if fileInfo.IsDir {
+			key += "/"
+		}
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		// All keys below one sorting before startAfter, which is no prefix of it, sort before it as well
+		if key <= startAfter && !strings.HasPrefix(startAfter, key) {
+			continue
+		}
+		if delimiter != "" {
+			if idx := strings.Index(key[len(prefix):], delimiter); idx >= 0 {
+				add(key[:len(prefix)+idx+len(delimiter)], nil)
+				continue
+			}
+		}
+		if !fileInfo.IsDir {
+			add(key, fileInfo)
+			continue
+		}
+
+		folderEmpty, walkErr := mgr.walkS3Folder(user, bucket, key, prefix, delimiter, startAfter, add)
+		if walkErr != nil {
+			return false, walkErr
+		}
+		if folderEmpty {
+			add(key, fileInfo)
+		}
+	}
+	return len(pathInfo.Content) == 0, nil
+}
+
+// StatS3Object returns the info of the file which is the object, keys with a trailing slash refer to folders
+func (mgr *FileManager) StatS3Object(user *models.User, bucket, key string) (*models.FileInfo, error) {
+	if _, err := mgr.GetS3Bucket(user, bucket); err != nil {
+		return nil, err
+	}
+	if !validS3Key(key) {
+		return nil, ErrFileNotFound
+	}
+	fileInfo, err := mgr.GetFileInfo(user, s3Path(bucket, key), true)
+	if err != nil || fileInfo.IsDir != strings.HasSuffix(key, "/") {
+		return nil, ErrFileNotFound
+	}
+	return fileInfo, nil
+}
+
+// OpenS3Object opens the file which is the object for reading, folders have no content so no file is returned for them
+func (mgr *FileManager) OpenS3Object(user *models.User, bucket, key string) (file repository.StorageFile, fileInfo *models.FileInfo, err error) {
+	fileInfo, err = mgr.StatS3Object(user, bucket, key)
+	if err != nil || fileInfo.IsDir {
+		return
+	}
+	file, _, err = mgr.OpenFile(user, s3Path(bucket, key))
+	return
+}
+
+// PutS3Object writes the content of the object into its file, missing folders of its key are created.
+// A key with a trailing slash creates the folder and ignores the content.
+func (mgr *FileManager) PutS3Object(user *models.User, bucket, key string, reader io.Reader) (fileInfo *models.FileInfo, err error) {
+	if _, err = mgr.GetS3Bucket(user, bucket); err != nil {
+		return
+	}
+	if !validS3Key(key) {
+		return nil, ErrS3InvalidKey
+	}
+
+	path := s3Path(bucket, key)
+	if strings.HasSuffix(key, "/") {
+		err = mgr.createS3Folders(user, path)
+		if err != nil {
+			return
+		}
+		return mgr.GetFileInfo(user, path, true)
+	}
+
+	folderPath, _ := utils.SplitPath(path)
+	err = mgr.createS3Folders(user, folderPath)
+	if err != nil {
+		return
+	}
+	return mgr.WriteFile(user, path, reader)
+}
+
+// DeleteS3Object deletes the file which is the object, deleting objects which do not exist succeeds like in S3.
+// Folders are only deleted with keys having a trailing slash once they are empty, as prefixes in S3 exist as long as objects have them.
+func (mgr *FileManager) DeleteS3Object(user *models.User, bucket, key string) (err error) {
+	if _, err = mgr.GetS3Bucket(user, bucket); err != nil {
+		return
+	}
+	if !validS3Key(key) {
+		return nil
+	}
+
+	path := s3Path(bucket, key)
+	pathInfo, err := mgr.GetPathInfo(user, path)
+	if toOSError(err) == os.ErrNotExist {
+		return nil
+	} else if err != nil {
+		return
+	}
+	if pathInfo.FileInfo.IsDir != strings.HasSuffix(key, "/") || len(pathInfo.Content) > 0 {
+		return nil
+	}
+	return mgr.DeleteFile(user, path)
+}
+
+// createS3Folders creates the folder at path and all its missing parents like S3 clients expect for keys of new objects
+func (mgr *FileManager) createS3Folders(user *models.User, path string) error {
+	folderPath := "/"
+	for _, name := range strings.Split(strings.Trim(path, "/"), "/") {
+		folderPath = filepath.ToSlash(filepath.Join(folderPath, name))
+		fileInfo, err := mgr.GetFileInfo(user, folderPath, false)
+		if err == nil && !fileInfo.IsDir {
+			return ErrFileExists
+		} else if err == nil {
+			continue
+		}
+		err = mgr.CreateDirectoryForUser(user, folderPath)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateS3MultipartUpload starts an upload of the object in parts and returns its ID.
+// The parts are collected in the temp folder of the user, so uploads which are never finished are cleaned up with it.
+func (mgr *FileManager) CreateS3MultipartUpload(user *models.User, bucket, key string) (uploadID string, err error) {
+	if _, err = mgr.GetS3Bucket(user, bucket); err != nil {
+		return
+	}
+	if !validS3Key(key) || strings.HasSuffix(key, "/") {
+		return "", ErrS3InvalidKey
+	}
+
+	uploadID, err = utils.SecureRandomString(s3UploadIDLength)
+	if err != nil {
+		return
+	}
+	uploadPath := mgr.getS3UploadPath(user, uploadID)
+	_, err = mgr.storageRep.CreateDirectory(uploadPath)
+	if err != nil {
+		return "", err
+	}
+
+	// The target is stored with the parts to refuse parts and completions for other objects
+	file, err := mgr.storageRep.CreateHandle(filepath.Join(uploadPath, s3UploadTargetName))
+	if err == nil {
+		_, err = io.WriteString(file, s3Path(bucket, key))
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		mgr.storageRep.Delete(uploadPath)
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// UploadS3Part stores a part of the multipart upload, uploading a part again replaces it. Returns the MD5 hash of the part as its ETag.
+func (mgr *FileManager) UploadS3Part(user *models.User, bucket, key, uploadID string, partNumber int, reader io.Reader) (etag string, err error) {
+	if partNumber < 1 || partNumber > S3MaxPartNumber {
+		return "", ErrS3InvalidPart
+	}
+	uploadPath, err := mgr.getS3Upload(user, bucket, key, uploadID)
+	if err != nil {
+		return
+	}
+
+	partPath := filepath.Join(uploadPath, fmt.Sprintf("part-%05d", partNumber))
+	file, err := mgr.storageRep.CreateHandle(partPath)
+	if err != nil {
+		return
+	}
+	partHash := md5.New()
+	_, err = io.Copy(io.MultiWriter(file, partHash), reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		mgr.storageRep.Delete(partPath)
+		return
+	}
+	return hex.EncodeToString(partHash.Sum(nil)), nil
+}
+
+// CompleteS3MultipartUpload writes the given parts in their order into the file of the object and deletes the upload.
+// The content of every part is checked against its ETag while writing, so the object keeps its previous content if any part does not match.
+func (mgr *FileManager) CompleteS3MultipartUpload(user *models.User, bucket, key, uploadID string, parts []*S3CompletedPart) (fileInfo *models.FileInfo, err error) {
+	uploadPath, err := mgr.getS3Upload(user, bucket, key, uploadID)
+	if err != nil {
+		return
+	}
+	if len(parts) == 0 {
+		return nil, ErrS3InvalidPart
+	}
+
+	readers := make([]io.Reader, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, ErrS3InvalidPartOrder
+		}
+		partMD5, decodeErr := hex.DecodeString(strings.Trim(part.ETag, `"`))
+		if decodeErr != nil || len(partMD5) != md5.Size || part.PartNumber < 1 || part.PartNumber > S3MaxPartNumber {
+			return nil, ErrS3InvalidPart
+		}
+		file, openErr := mgr.storageRep.OpenFile(filepath.Join(uploadPath, fmt.Sprintf("part-%05d", part.PartNumber)))
+		if openErr != nil {
+			return nil, ErrS3InvalidPart
+		}
+		defer file.Close()
+		readers = append(readers, &s3PartReader{reader: file, hash: md5.New(), expectedMD5: partMD5})
+	}
+
+	path := s3Path(bucket, key)
+	folderPath, _ := utils.SplitPath(path)
+	err = mgr.createS3Folders(user, folderPath)
+	if err != nil {
+		return
+	}
+	fileInfo, err = mgr.WriteFile(user, path, io.MultiReader(readers...))
+	if err != nil {
+		return
+	}
+
+	mgr.storageRep.Delete(uploadPath)
+	return fileInfo, nil
+}
+
+// AbortS3MultipartUpload deletes the upload and all of its parts
+func (mgr *FileManager) AbortS3MultipartUpload(user *models.User, bucket, key, uploadID string) error {
+	uploadPath, err := mgr.getS3Upload(user, bucket, key, uploadID)
+	if err != nil {
+		return err
+	}
+	return mgr.storageRep.Delete(uploadPath)
+}
+
+// getS3Upload returns the folder of the multipart upload of the user after checking that it is one for the object
+func (mgr *FileManager) getS3Upload(user *models.User, bucket, key, uploadID string) (uploadPath string, err error) {
+	if len(uploadID) != s3UploadIDLength || strings.Trim(uploadID, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789") != "" {
+		return "", ErrS3NoSuchUpload
+	}
+	uploadPath = mgr.getS3UploadPath(user, uploadID)
+	file, err := mgr.storageRep.OpenFile(filepath.Join(uploadPath, s3UploadTargetName))
+	if err != nil {
+		return "", ErrS3NoSuchUpload
+	}
+	defer file.Close()
+	target, err := ioutil.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+	if string(target) != s3Path(bucket, key) {
+		return "", ErrS3NoSuchUpload
+	}
+	return uploadPath, nil
+}
+
+func (mgr *FileManager) getS3UploadPath(user *models.User, uploadID string) string {
+	return filepath.Join(mgr.getUserPath(user), mgr.tmpName, s3UploadPrefix+uploadID)
+}
+
+// s3Path returns the path of the file which is the object in the bucket
+func s3Path(bucket, key string) string {
+	return "/" + bucket + "/" + key
+}
+
+// validS3Key returns whether the key can be mapped to a path, only the last segment may be empty for folders
+func validS3Key(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, name := range strings.Split(strings.TrimSuffix(key, "/"), "/") {
+		if name == "" || name == "." || name == ".." {
+			return false
+		}
+	}
+	return utils.ValidatePath(key)
+}
+
+// s3PartReader reads a part of a multipart upload and fails at its end if the content does not match the MD5 hash the client sent for it
+type s3PartReader struct {
+	reader      io.Reader
+	hash        hash.Hash
+	expectedMD5 []byte
+}
+
+func (r *s3PartReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	r.hash.Write(p[:n])
+	if err == io.EOF && !bytes.Equal(r.hash.Sum(nil), r.expectedMD5) {
+		return n, ErrS3InvalidPart
+	}
+	return n, err
+}
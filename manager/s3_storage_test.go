@@ -0,0 +1,211 @@
+package manager
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"io/ioutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func testS3Keys(list *S3ObjectList) (keys []string) {
+	for _, object := range list.Objects {
+		keys = append(keys, object.Key)
+	}
+	return
+}
+
+func TestS3Objects(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUser, "/docs", true)
+	mgr.CreateFile(testAuthUserAdmin, "/team", true)
+	mgr.ShareFile(testAuthUserAdmin, testAuthUser, "/team")
+
+	buckets, err := mgr.ListS3Buckets(testAuthUser)
+	if err != nil || len(buckets) != 2 {
+		t.Fatalf("Expected own and shared folder but not the temp folder as buckets: %v, %v", buckets, err)
+	}
+	if _, err = mgr.PutS3Object(testAuthUser, ".tmp", "a.txt", strings.NewReader("a")); err != ErrS3NoSuchBucket {
+		t.Errorf("Expected no such bucket error for temp folder but got: %v", err)
+	}
+	if _, err = mgr.PutS3Object(testAuthUser, "docs", "a/../b.txt", strings.NewReader("a")); err != ErrS3InvalidKey {
+		t.Errorf("Expected invalid key error for relative key but got: %v", err)
+	}
+
+	for _, key := range []string{"a.txt", "photos/2019/x.jpg", "photos/2020/y.jpg", "photos/2020-z.jpg", "empty/"} {
+		if _, err = mgr.PutS3Object(testAuthUser, "docs", key, strings.NewReader("content of "+key)); err != nil {
+			t.Fatalf("Failed to put object %s: %v", key, err)
+		}
+	}
+	if _, err = mgr.PutS3Object(testAuthUser, "docs", "a.txt/b.txt", strings.NewReader("b")); err == nil {
+		t.Errorf("Expected error for putting object below file")
+	}
+	if _, err = mgr.PutS3Object(testAuthUser, "team", "shared.txt", strings.NewReader("shared")); err != nil {
+		t.Errorf("Failed to put object into shared bucket: %v", err)
+	}
+	if fileInfo, err := mgr.GetFileInfo(testAuthUserAdmin, "/team/shared.txt", false); err != nil || fileInfo.OwnerID != testAuthUserAdmin.ID {
+		t.Errorf("Expected object in shared bucket to belong to its owner: %v, %v", fileInfo, err)
+	}
+
+	list, err := mgr.ListS3Objects(testAuthUser, "docs", "", "", "", 1000)
+	expKeys := []string{"a.txt", "empty/", "photos/2019/x.jpg", "photos/2020-z.jpg", "photos/2020/y.jpg"}
+	if err != nil || !reflect.DeepEqual(testS3Keys(list), expKeys) || len(list.CommonPrefixes) != 0 {
+		t.Errorf("Expected all objects and empty folders sorted by key but got: %v, %v", testS3Keys(list), err)
+	}
+	list, err = mgr.ListS3Objects(testAuthUser, "docs", "", "/", "", 1000)
+	if err != nil || !reflect.DeepEqual(testS3Keys(list), []string{"a.txt"}) || !reflect.DeepEqual(list.CommonPrefixes, []string{"empty/", "photos/"}) {
+		t.Errorf("Expected folders rolled up into common prefixes but got: %v, %v, %v", testS3Keys(list), list.CommonPrefixes, err)
+	}
+	list, err = mgr.ListS3Objects(testAuthUser, "docs", "photos/2020", "/", "", 1000)
+	if err != nil || !reflect.DeepEqual(testS3Keys(list), []string{"photos/2020-z.jpg"}) || !reflect.DeepEqual(list.CommonPrefixes, []string{"photos/2020/"}) {
+		t.Errorf("Expected objects and common prefixes within prefix but got: %v, %v, %v", testS3Keys(list), list.CommonPrefixes, err)
+	}
+	list, err = mgr.ListS3Objects(testAuthUser, "docs", "missing/", "/", "", 1000)
+	if err != nil || len(list.Objects) != 0 || len(list.CommonPrefixes) != 0 {
+		t.Errorf("Expected empty list for missing prefix but got: %v, %v", list, err)
+	}
+
+	var pagedKeys []string
+	startAfter := ""
+	for page := 0; page < 5; page++ {
+		list, err = mgr.ListS3Objects(testAuthUser, "docs", "", "", startAfter, 2)
+		if err != nil {
+			t.Fatalf("Failed to list page %d: %v", page, err)
+		}
+		pagedKeys = append(pagedKeys, testS3Keys(list)...)
+		if !list.IsTruncated {
+			break
+		}
+		startAfter = list.NextStartAfter
+	}
+	if !reflect.DeepEqual(pagedKeys, expKeys) {
+		t.Errorf("Expected pages to contain all objects once but got: %v", pagedKeys)
+	}
+
+	file, fileInfo, err := mgr.OpenS3Object(testAuthUser, "docs", "photos/2020/y.jpg")
+	if err != nil {
+		t.Fatalf("Failed to open object: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "content of photos/2020/y.jpg" || fileInfo.Size != int64(len(content)) {
+		t.Errorf("Expected content of object but got: %s, %v", content, fileInfo)
+	}
+	if _, err = mgr.StatS3Object(testAuthUser, "docs", "photos/"); err != nil {
+		t.Errorf("Failed to stat folder by key with trailing slash: %v", err)
+	}
+	if _, err = mgr.StatS3Object(testAuthUser, "docs", "photos"); err != ErrFileNotFound {
+		t.Errorf("Expected not found error for folder key without trailing slash but got: %v", err)
+	}
+
+	if err = mgr.DeleteS3Object(testAuthUser, "docs", "photos/"); err != nil {
+		t.Errorf("Failed to delete folder which is not empty: %v", err)
+	}
+	if _, err = mgr.StatS3Object(testAuthUser, "docs", "photos/2019/x.jpg"); err != nil {
+		t.Errorf("Expected deleting folder marker to keep the objects below it: %v", err)
+	}
+	if err = mgr.DeleteS3Object(testAuthUser, "docs", "a.txt"); err != nil {
+		t.Errorf("Failed to delete object: %v", err)
+	}
+	if err = mgr.DeleteS3Object(testAuthUser, "docs", "a.txt"); err != nil {
+		t.Errorf("Expected deleting missing object to succeed but got: %v", err)
+	}
+	if err = mgr.DeleteS3Object(testAuthUser, "docs", "empty/"); err != nil {
+		t.Errorf("Failed to delete empty folder: %v", err)
+	}
+	list, _ = mgr.ListS3Objects(testAuthUser, "docs", "", "/", "", 1000)
+	if len(list.Objects) != 0 || !reflect.DeepEqual(list.CommonPrefixes, []string{"photos/"}) {
+		t.Errorf("Expected only photos to be left but got: %v, %v", testS3Keys(list), list.CommonPrefixes)
+	}
+}
+
+func TestS3MultipartUpload(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUser, "/docs", true)
+	mgr.PutS3Object(testAuthUser, "docs", "big/file.bin", strings.NewReader("previous"))
+
+	uploadID, err := mgr.CreateS3MultipartUpload(testAuthUser, "docs", "big/file.bin")
+	if err != nil {
+		t.Fatalf("Failed to create multipart upload: %v", err)
+	}
+	if _, err = mgr.UploadS3Part(testAuthUser, "docs", "other.bin", uploadID, 1, strings.NewReader("a")); err != ErrS3NoSuchUpload {
+		t.Errorf("Expected no such upload error for part of other object but got: %v", err)
+	}
+	if _, err = mgr.UploadS3Part(testAuthUserAdmin, "docs", "big/file.bin", uploadID, 1, strings.NewReader("a")); err == nil {
+		t.Errorf("Expected error for part of upload of another user")
+	}
+
+	parts := []string{"first part ", "second part ", "third part"}
+	var completedParts []*S3CompletedPart
+	for i, part := range parts {
+		etag, err := mgr.UploadS3Part(testAuthUser, "docs", "big/file.bin", uploadID, i+1, strings.NewReader(part))
+		if err != nil {
+			t.Fatalf("Failed to upload part %d: %v", i+1, err)
+		}
+		hash := md5.Sum([]byte(part))
+		if etag != hex.EncodeToString(hash[:]) {
+			t.Errorf("Expected MD5 of part as ETag but got: %s", etag)
+		}
+		completedParts = append(completedParts, &S3CompletedPart{PartNumber: i + 1, ETag: `"` + etag + `"`})
+	}
+
+	reversedParts := []*S3CompletedPart{completedParts[1], completedParts[0]}
+	if _, err = mgr.CompleteS3MultipartUpload(testAuthUser, "docs", "big/file.bin", uploadID, reversedParts); err != ErrS3InvalidPartOrder {
+		t.Errorf("Expected invalid part order error but got: %v", err)
+	}
+	wrongParts := []*S3CompletedPart{completedParts[0], {PartNumber: 2, ETag: completedParts[2].ETag}}
+	if _, err = mgr.CompleteS3MultipartUpload(testAuthUser, "docs", "big/file.bin", uploadID, wrongParts); err != ErrS3InvalidPart {
+		t.Errorf("Expected invalid part error for wrong ETag but got: %v", err)
+	}
+	file, _, _ := mgr.OpenS3Object(testAuthUser, "docs", "big/file.bin")
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "previous" {
+		t.Errorf("Expected failed completion to keep previous content but got: %s", content)
+	}
+
+	fileInfo, err := mgr.CompleteS3MultipartUpload(testAuthUser, "docs", "big/file.bin", uploadID, completedParts)
+	if err != nil {
+		t.Fatalf("Failed to complete multipart upload: %v", err)
+	}
+	file, _, _ = mgr.OpenS3Object(testAuthUser, "docs", "big/file.bin")
+	content, _ = ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != strings.Join(parts, "") || fileInfo.Size != int64(len(content)) {
+		t.Errorf("Expected parts joined in object but got: %s, %v", content, fileInfo)
+	}
+	if _, err = mgr.CompleteS3MultipartUpload(testAuthUser, "docs", "big/file.bin", uploadID, completedParts); err != ErrS3NoSuchUpload {
+		t.Errorf("Expected no such upload error for completing upload twice but got: %v", err)
+	}
+
+	uploadID, _ = mgr.CreateS3MultipartUpload(testAuthUser, "docs", "aborted.bin")
+	mgr.UploadS3Part(testAuthUser, "docs", "aborted.bin", uploadID, 1, strings.NewReader("part"))
+	if err = mgr.AbortS3MultipartUpload(testAuthUser, "docs", "aborted.bin", uploadID); err != nil {
+		t.Errorf("Failed to abort multipart upload: %v", err)
+	}
+	if err = mgr.AbortS3MultipartUpload(testAuthUser, "docs", "aborted.bin", uploadID); err != ErrS3NoSuchUpload {
+		t.Errorf("Expected no such upload error for aborting upload twice but got: %v", err)
+	}
+	if _, err = mgr.StatS3Object(testAuthUser, "docs", "aborted.bin"); err != ErrFileNotFound {
+		t.Errorf("Expected aborted upload to create no object but got: %v", err)
+	}
+	list, _ := mgr.ListS3Objects(testAuthUser, "docs", "", "", "", 1000)
+	if !reflect.DeepEqual(testS3Keys(list), []string{"big/file.bin"}) {
+		t.Errorf("Expected only the completed object in bucket but got: %v", testS3Keys(list))
+	}
+}
@@ -0,0 +1,384 @@
+package manager
+
+import (
+	"errors"
+	"io"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// errScrubClosed ends a running scrub when the ScrubManager is closed
+var errScrubClosed = errors.New("scrub: Scrubber has been closed")
+
+// ScrubManager verifies the integrity of the stored files by hashing their content again and comparing it to their recorded checksums.
+// Files whose content is damaged can be moved into a quarantine folder of the storage, there are no versions to restore them from.
+// Deduplicated files are only reported, their damaged blob can be shared with other files which moving one of them would not protect.
+type ScrubManager struct {
+	scrubReportRep *repository.ScrubReportRepository
+	// interval is the interval in hours in which the files are scrubbed, 0 only scrubs them on request
+	interval int
+	// rateLimit is the count of bytes read per second while scrubbing, 0 does not limit it
+	rateLimit int64
+	// quarantineDirectory is the folder in the storage damaged files are moved to, they are only reported if it is empty
+	quarantineDirectory string
+	running             bool
+	runningLock         sync.Mutex
+	runs                sync.WaitGroup
+	done                chan struct{}
+}
+
+var scrubManager *ScrubManager
+
+// CreateScrubManager creates a new singleton ScrubManager which scrubs the files every interval hours if it is greater than 0.
+// Scrubs read at most rateLimit bytes per second and move damaged files below quarantineDirectory if it is not empty.
+// Scrubs left unfinished by a previous run are marked as failed.
+func CreateScrubManager(scrubReportRep *repository.ScrubReportRepository, interval int, rateLimit int64, quarantineDirectory string) *ScrubManager {
+	if scrubManager != nil {
+		return scrubManager
+	}
+
+	err := scrubReportRep.FailUnfinished()
+	if err != nil {
+		log.Warn("Could not mark unfinished scrubs as failed: %v", err)
+	}
+
+	scrubManager = &ScrubManager{
+		scrubReportRep:      scrubReportRep,
+		interval:            interval,
+		rateLimit:           rateLimit,
+		quarantineDirectory: quarantineDirectory,
+		done:                make(chan struct{}),
+	}
+	if interval > 0 {
+		go scrubManager.scrubRoutine()
+	}
+	return scrubManager
+}
+
+// GetScrubManager returns the singleton instance of the ScrubManager
+func GetScrubManager() *ScrubManager {
+	return scrubManager
+}
+
+// Close is used to end running tasks, it waits for a running scrub to be marked as failed
+func (mgr *ScrubManager) Close() {
+	close(mgr.done)
+	mgr.runs.Wait()
+}
+
+func (mgr *ScrubManager) scrubRoutine() {
+	log.Trace("Files will be scrubbed every %v hours", mgr.interval)
+	ticker := time.NewTicker(time.Hour * time.Duration(mgr.interval))
+	for {
+		select {
+		case <-mgr.done:
+			ticker.Stop()
+			return
+		case <-ticker.C:
+			mgr.Scrub()
+		}
+	}
+}
+
+// StartScrub starts scrubbing the files of all users in the background and returns the report it fills
+func (mgr *ScrubManager) StartScrub() (*models.ScrubReport, error) {
+	report, err := mgr.startRun()
+	if err != nil {
+		return nil, err
+	}
+
+	job := *report
+	go mgr.runScrub(&job)
+	return report, nil
+}
+
+// Scrub scrubs the files of all users and returns the finished report
+func (mgr *ScrubManager) Scrub() (report *models.ScrubReport, err error) {
+	report, err = mgr.startRun()
+	if err != nil {
+		return
+	}
+
+	mgr.runScrub(report)
+	if report.Status != repository.ScrubFinished {
+		return report, fcerrors.New(fcerrors.Filesystem)
+	}
+	return
+}
+
+// GetLatestReport returns the report of the most recently started scrub with the issues found so far
+func (mgr *ScrubManager) GetLatestReport() (*models.ScrubReport, error) {
+	report, err := mgr.scrubReportRep.GetLatest()
+	if repository.IsRecordNotFoundError(err) {
+		return nil, fcerrors.New(fcerrors.ScrubReportNotFound)
+	} else if err != nil {
+		log.Error(0, "Could not get latest scrub report: %v", err)
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	return report, nil
+}
+
+// startRun creates the report of a new scrub, only one scrub runs at a time
+func (mgr *ScrubManager) startRun() (*models.ScrubReport, error) {
+	mgr.runningLock.Lock()
+	defer mgr.runningLock.Unlock()
+	if mgr.running {
+		return nil, fcerrors.New(fcerrors.ScrubRunning)
+	}
+
+	report := &models.ScrubReport{Issues: []*models.ScrubIssue{}}
+	err := mgr.scrubReportRep.Create(report)
+	if err != nil {
+		return nil, fcerrors.Wrap(err, fcerrors.Database)
+	}
+	mgr.running = true
+	mgr.runs.Add(1)
+	return report, nil
+}
+
+func (mgr *ScrubManager) runScrub(report *models.ScrubReport) {
+	defer func() {
+		mgr.runningLock.Lock()
+		mgr.running = false
+		mgr.runningLock.Unlock()
+		mgr.runs.Done()
+	}()
+
+	log.Info("Scrubbing stored files")
+	run := &scrubRun{mgr: mgr, fileMgr: GetFileManager(), report: report, started: time.Now()}
+	err := run.scrubUsers()
+	report.Status = repository.ScrubFinished
+	if err != nil {
+		log.Error(0, "Scrubbing stored files failed: %v", err)
+		report.Status = repository.ScrubFailed
+	}
+	report.Finished = utils.GetTimestampNow()
+	mgr.scrubReportRep.Update(report)
+	log.Info("Scrubbed %d files with %d bytes, found %d issues", report.CheckedFiles, report.CheckedBytes, len(report.Issues))
+}
+
+// scrubRun is a running scrub, its reads are throttled to the rate limit over the whole run
+type scrubRun struct {
+	mgr     *ScrubManager
+	fileMgr *FileManager
+	report  *models.ScrubReport
+	started time.Time
+	read    int64
+}
+
+func (run *scrubRun) scrubUsers() error {
+	users, err := GetAuthManager().GetAllUsers()
+	if err != nil {
+		return err
+	}
+
+	for _, user := range users {
+		err = run.scrubFolder(user.ID, "/", "")
+		if err == errScrubClosed {
+			return err
+		} else if err != nil {
+			log.Warn("Could not scrub files of user %d: %v", user.ID, err)
+		}
+		// Make the progress visible in the latest report
+		run.mgr.scrubReportRep.Update(run.report)
+	}
+	return nil
+}
+
+// scrubFolder compares the content of the folder of the user and its subfolders in the storage with the indexed files.
+// Temp folders and mounted storages are skipped, as their content is not hashed.
+func (run *scrubRun) scrubFolder(userID int64, folderPath, name string) (err error) {
+	fsFiles, err := run.fileMgr.storageRep.GetDirectoryInfo(run.fileMgr.getUserPathWithID(userID), path.Join(folderPath, name))
+	if err != nil {
+		return
+	}
+	_, dbFiles, err := run.fileMgr.getDirectoryContentByPath(userID, folderPath, name)
+	if err != nil {
+		return
+	}
+	indexedFiles := make(map[string]*models.FileInfo)
+	for _, dbFile := range dbFiles {
+		if dbFile.ShareID <= 0 {
+			indexedFiles[dbFile.Name] = dbFile
+		}
+	}
+
+	for _, fsFile := range fsFiles {
+		select {
+		case <-run.mgr.done:
+			return errScrubClosed
+		default:
+		}
+
+		fsFile.OwnerID = userID
+		dbFile, indexed := indexedFiles[fsFile.Name]
+		delete(indexedFiles, fsFile.Name)
+		if run.fileMgr.isTmpPath(fsFile) || isMountPoint(run.fileMgr.getStoragePath(fsFile)) {
+			continue
+		}
+
+		if !indexed {
+			run.addIssue(&models.ScrubIssue{Kind: repository.ScrubIssueUnindexed}, fsFile)
+		} else if fsFile.IsDir != dbFile.IsDir {
+			run.addIssue(&models.ScrubIssue{Kind: repository.ScrubIssueModified, ExpectedSha256: dbFile.Sha256}, dbFile)
+		} else if fsFile.IsDir {
+			err = run.scrubFolder(userID, fsFile.Path, fsFile.Name)
+			if err != nil && err != errScrubClosed {
+				log.Warn("Could not scrub folder %s%s of user %d: %v", fsFile.Path, fsFile.Name, userID, err)
+				err = nil
+			}
+		} else {
+			err = run.scrubFile(dbFile, fsFile)
+		}
+		if err != nil {
+			return
+		}
+	}
+
+	for _, dbFile := range dbFiles {
+		if _, missing := indexedFiles[dbFile.Name]; missing && !run.fileMgr.isTmpPath(dbFile) {
+			run.addIssue(&models.ScrubIssue{Kind: repository.ScrubIssueMissing, ExpectedSha256: dbFile.Sha256}, dbFile)
+		}
+	}
+	return
+}
+
+// scrubFile hashes the content of the indexed file and reports it if it does not match the recorded checksum.
+// Files whose size or change time differ have been changed out-of-band and are only reported, damaged ones are quarantined.
+func (run *scrubRun) scrubFile(dbFile, fsFile *models.FileInfo) error {
+	// Files are hashed by the scan after they have been indexed, until then there is nothing to compare to
+	if dbFile.Sha256 == "" {
+		return nil
+	}
+
+	issue := &models.ScrubIssue{ExpectedSha256: dbFile.Sha256}
+	storagePath := run.fileMgr.getStoragePath(dbFile)
+	file, err := run.fileMgr.storageRep.OpenFile(storagePath)
+	if err == nil {
+		var checksums Checksums
+		checksums, err = hashContent(&scrubReader{reader: file, run: run})
+		file.Close()
+		if err == errScrubClosed {
+			return err
+		}
+		issue.ActualSha256 = checksums.SHA256
+	}
+
+	run.report.CheckedFiles++
+	run.report.CheckedBytes += fsFile.Size
+	if err == nil && issue.ActualSha256 == dbFile.Sha256 {
+		return nil
+	}
+
+	// The file could have been replaced, moved or deleted since its folder was listed, it is checked by the next scrub then.
+	// Holding its lock until it is quarantined keeps uploads from replacing it in the meantime.
+	unlock := run.fileMgr.fileLocks.lock([]string{storagePath})
+	defer unlock()
+	if !run.isUnchanged(dbFile, fsFile) {
+		return nil
+	}
+
+	if err != nil {
+		log.Warn("Could not read %s%s of user %d while scrubbing: %v", dbFile.Path, dbFile.Name, dbFile.OwnerID, err)
+		issue.Kind = repository.ScrubIssueUnreadable
+	} else if fsFile.Size != dbFile.Size || fsFile.LastChanged != dbFile.LastChanged {
+		issue.Kind = repository.ScrubIssueModified
+	} else {
+		issue.Kind = repository.ScrubIssueCorrupted
+	}
+
+	if issue.Kind != repository.ScrubIssueModified && run.mgr.quarantineDirectory != "" && fsFile.BlobHash != "" {
+		log.Warn("Not quarantining %s%s of user %d as its blob %s may be shared with other files", dbFile.Path, dbFile.Name, dbFile.OwnerID, fsFile.BlobHash)
+	} else if issue.Kind != repository.ScrubIssueModified && run.mgr.quarantineDirectory != "" {
+		issue.QuarantinePath, err = run.quarantine(dbFile)
+		if err != nil {
+			log.Error(0, "Could not quarantine %s%s of user %d: %v", dbFile.Path, dbFile.Name, dbFile.OwnerID, err)
+		}
+	}
+	run.addIssue(issue, dbFile)
+	return nil
+}
+
+// isUnchanged returns whether the stored file and its info are still the ones the folder listing of the scrub returned
+func (run *scrubRun) isUnchanged(dbFile, fsFile *models.FileInfo) bool {
+	currentDBFile, err := run.fileMgr.fileInfoRep.GetByID(dbFile.ID)
+	if err != nil || currentDBFile.OwnerID != dbFile.OwnerID || currentDBFile.Path != dbFile.Path || currentDBFile.Name != dbFile.Name ||
+		currentDBFile.Sha256 != dbFile.Sha256 || currentDBFile.Size != dbFile.Size || currentDBFile.LastChanged != dbFile.LastChanged {
+		return false
+	}
+	currentFSFile, err := run.fileMgr.storageRep.GetInfo(run.fileMgr.getUserPathWithID(fsFile.OwnerID), path.Join(fsFile.Path, fsFile.Name))
+	return err == nil && currentFSFile.Size == fsFile.Size && currentFSFile.LastChanged == fsFile.LastChanged
+}
+
+// quarantine moves the damaged file into the quarantine folder of the storage, so it is not served anymore, and removes it from the db
+func (run *scrubRun) quarantine(fileInfo *models.FileInfo) (quarantinePath string, err error) {
+	quarantinePath = path.Join("/", run.mgr.quarantineDirectory, strconv.FormatInt(run.report.ID, 10), strconv.FormatInt(fileInfo.OwnerID, 10), fileInfo.Path, fileInfo.Name)
+	_, err = run.fileMgr.storageRep.CreateDirectory(path.Dir(quarantinePath))
+	if err != nil {
+		return "", err
+	}
+	err = run.fileMgr.storageRep.Move(run.fileMgr.getStoragePath(fileInfo), quarantinePath)
+	if err != nil {
+		return "", err
+	}
+
+	err = run.fileMgr.unindexFile(fileInfo)
+	if err != nil {
+		return
+	}
+	recordFileChange(ActivityDeleted, 0, fileInfo, "")
+	log.Warn("Quarantined damaged file %s%s of user %d at %s", fileInfo.Path, fileInfo.Name, fileInfo.OwnerID, quarantinePath)
+	return
+}
+
+func (run *scrubRun) addIssue(issue *models.ScrubIssue, fileInfo *models.FileInfo) {
+	issue.ReportID = run.report.ID
+	issue.OwnerID = fileInfo.OwnerID
+	issue.Path = getFullPath(fileInfo)
+	run.report.Issues = append(run.report.Issues, issue)
+	run.mgr.scrubReportRep.AddIssue(issue)
+}
+
+// scrubReader reads the content of a scrubbed file, it is throttled to the rate limit and fails once the ScrubManager is closed
+type scrubReader struct {
+	reader io.Reader
+	run    *scrubRun
+}
+
+func (r *scrubReader) Read(p []byte) (n int, err error) {
+	rateLimit := r.run.mgr.rateLimit
+	if rateLimit > 0 && int64(len(p)) > rateLimit {
+		p = p[:rateLimit]
+	}
+	n, err = r.reader.Read(p)
+	r.run.read += int64(n)
+
+	var throttle <-chan time.Time
+	if rateLimit > 0 {
+		if wait := time.Duration(float64(r.run.read)/float64(rateLimit)*float64(time.Second)) - time.Since(r.run.started); wait > 0 {
+			throttle = time.After(wait)
+		}
+	}
+	if throttle == nil {
+		select {
+		case <-r.run.mgr.done:
+			return n, errScrubClosed
+		default:
+			return
+		}
+	}
+	select {
+	case <-r.run.mgr.done:
+		return n, errScrubClosed
+	case <-throttle:
+		return
+	}
+}
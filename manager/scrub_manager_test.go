@@ -0,0 +1,216 @@
+package manager
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/restapi/fcerrors"
+)
+
+func testScrubCleanup(authMgr *AuthManager) {
+	if scrubManager != nil {
+		scrubManager.Close()
+		scrubManager = nil
+	}
+	testAuthCleanup(authMgr)
+}
+
+// testScrubSetup sets up the managers with the files below testAuthDataFolder and damaged ones quarantined below "quarantine"
+func testScrubSetup(rateLimit int64) (*AuthManager, *ScrubManager) {
+	testAuthCleanup(nil)
+	repository.InitDatabaseConnection("", "", "", "", 0, testAuthDBName)
+	scrubReportRep, _ := repository.CreateScrubReportRepository()
+	authMgr := testAuthSetup()
+	testAuthInsert(authMgr)
+	return authMgr, CreateScrubManager(scrubReportRep, 0, rateLimit, "quarantine")
+}
+
+func testScrubStoragePath(path string) string {
+	return filepath.Join(testAuthDataFolder, GetFileManager().getUserPath(testAuthUser), path)
+}
+
+func TestScrub(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testScrubSetup(0)
+	defer testScrubCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	corruptedInfo, _ := fileMgr.WriteFile(testAuthUser, "/a.txt", strings.NewReader("content a"))
+	fileMgr.CreateFile(testAuthUser, "/docs", true)
+	fileMgr.WriteFile(testAuthUser, "/docs/b.txt", strings.NewReader("content b"))
+	fileMgr.WriteFile(testAuthUser, "/c.txt", strings.NewReader("content c"))
+	fileMgr.WriteFile(testAuthUser, "/intact.txt", strings.NewReader("intact"))
+
+	// Bit rot keeps the size and change time of the file
+	ioutil.WriteFile(testScrubStoragePath("a.txt"), []byte("content x"), 0644)
+	os.Chtimes(testScrubStoragePath("a.txt"), time.Now(), time.Unix(corruptedInfo.LastChanged, 0))
+	ioutil.WriteFile(testScrubStoragePath("c.txt"), []byte("changed content"), 0644)
+	os.Remove(testScrubStoragePath("docs/b.txt"))
+	ioutil.WriteFile(testScrubStoragePath("d.txt"), []byte("content d"), 0644)
+
+	report, err := mgr.Scrub()
+	if err != nil {
+		t.Fatalf("Failed to scrub files: %v", err)
+	}
+	if report.Status != repository.ScrubFinished || report.Finished == 0 || report.CheckedFiles != 3 {
+		t.Errorf("Expected finished report with the existing indexed files checked: %v", report)
+	}
+	issues := map[string]string{}
+	for _, issue := range report.Issues {
+		issues[issue.Path] = issue.Kind
+		if issue.Kind == repository.ScrubIssueCorrupted && (issue.ExpectedSha256 != corruptedInfo.Sha256 || issue.ActualSha256 != testChecksumsSHA256("content x")) {
+			t.Errorf("Expected recorded and actual checksum in issue but got: %v", issue)
+		}
+	}
+	expectedIssues := map[string]string{
+		"/a.txt":      repository.ScrubIssueCorrupted,
+		"/c.txt":      repository.ScrubIssueModified,
+		"/docs/b.txt": repository.ScrubIssueMissing,
+		"/d.txt":      repository.ScrubIssueUnindexed,
+	}
+	if len(issues) != len(expectedIssues) {
+		t.Errorf("Expected %d issues but got: %v", len(expectedIssues), issues)
+	}
+	for path, kind := range expectedIssues {
+		if issues[path] != kind {
+			t.Errorf("Expected %s issue for %s but got: %s", kind, path, issues[path])
+		}
+	}
+
+	quarantinePath := filepath.Join(testAuthDataFolder, "quarantine", strconv.FormatInt(report.ID, 10), strconv.FormatInt(testAuthUser.ID, 10), "a.txt")
+	if content, _ := ioutil.ReadFile(quarantinePath); string(content) != "content x" {
+		t.Errorf("Expected corrupted file to be quarantined but got: %s", content)
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/a.txt", false); err == nil {
+		t.Errorf("Expected quarantined file to be removed from the files of the user")
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/c.txt", false); err != nil {
+		t.Errorf("Expected modified file to be kept: %v", err)
+	}
+
+	latest, err := mgr.GetLatestReport()
+	if err != nil || latest.ID != report.ID || len(latest.Issues) != len(expectedIssues) {
+		t.Errorf("Expected latest report with its issues: %v, %v", latest, err)
+	}
+}
+
+func TestScrubRunning(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testScrubSetup(1000)
+	defer testScrubCleanup(authMgr)
+
+	if _, err := mgr.GetLatestReport(); err == nil || err.(*fcerrors.FCError).Code != fcerrors.ScrubReportNotFound {
+		t.Errorf("Expected ScrubReportNotFound before the first scrub but got: %v", err)
+	}
+
+	GetFileManager().WriteFile(testAuthUser, "/large.bin", strings.NewReader(strings.Repeat("x", 10000)))
+	report, err := mgr.StartScrub()
+	if err != nil || report.Status != repository.ScrubRunning {
+		t.Fatalf("Failed to start scrub: %v, %v", report, err)
+	}
+	if _, err = mgr.StartScrub(); err == nil || err.(*fcerrors.FCError).Code != fcerrors.ScrubRunning {
+		t.Errorf("Expected ScrubRunning while the throttled scrub is running but got: %v", err)
+	}
+
+	// Closing ends the throttled scrub long before it could have read the file
+	closeStarted := time.Now()
+	mgr.Close()
+	scrubManager = nil
+	if time.Since(closeStarted) > 5*time.Second {
+		t.Errorf("Expected closing to end the running scrub promptly")
+	}
+	if report, _ = mgr.GetLatestReport(); report.Status != repository.ScrubFailed || report.CheckedFiles != 0 {
+		t.Errorf("Expected interrupted scrub to be marked as failed: %v", report)
+	}
+}
+
+func TestScrubConcurrentUpload(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, mgr := testScrubSetup(1000)
+	defer testScrubCleanup(authMgr)
+	fileMgr := GetFileManager()
+
+	fileMgr.WriteFile(testAuthUser, "/a.bin", strings.NewReader(strings.Repeat("x", 2000)))
+	fileMgr.WriteFile(testAuthUser, "/b.txt", strings.NewReader("content b"))
+
+	// The folder is listed before the throttled scrub reads a.bin, b.txt is replaced with content of the same size meanwhile
+	report, err := mgr.StartScrub()
+	if err != nil {
+		t.Fatalf("Failed to start scrub: %v", err)
+	}
+	time.Sleep(500 * time.Millisecond)
+	_, err = fileMgr.WriteFile(testAuthUser, "/b.txt", strings.NewReader("content y"))
+	if err != nil {
+		t.Fatalf("Failed to replace file during scrub: %v", err)
+	}
+
+	for start := time.Now(); report.Status == repository.ScrubRunning && time.Since(start) < 20*time.Second; {
+		time.Sleep(100 * time.Millisecond)
+		report, _ = mgr.GetLatestReport()
+	}
+	if report.Status != repository.ScrubFinished || len(report.Issues) != 0 {
+		t.Errorf("Expected finished scrub without issues for the replaced file: %v", report)
+	}
+	file, _, err := fileMgr.OpenFile(testAuthUser, "/b.txt")
+	if err != nil {
+		t.Fatalf("Expected replaced file to be kept: %v", err)
+	}
+	defer file.Close()
+	if content, _ := ioutil.ReadAll(file); string(content) != "content y" {
+		t.Errorf("Expected new content of replaced file but got: %s", content)
+	}
+}
+
+func TestScrubDeduplicated(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr, _ := testDeduplicationSetup()
+	defer testDeduplicationCleanup(authMgr)
+	scrubReportRep, _ := repository.CreateScrubReportRepository()
+	mgr := CreateScrubManager(scrubReportRep, 0, 0, "quarantine")
+	defer func() {
+		mgr.Close()
+		scrubManager = nil
+	}()
+	fileMgr := GetFileManager()
+
+	content := strings.Repeat("artifact", 100)
+	fileInfo, _ := fileMgr.WriteFile(testAuthUser, "/a.bin", strings.NewReader(content))
+	fileMgr.WriteFile(testAuthUserAdmin, "/b.bin", strings.NewReader(content))
+
+	// Bit rot in the blob damages every file referencing it
+	hash := fileInfo.BlobHash
+	ioutil.WriteFile(filepath.Join(testAuthDataFolder, "blobs", hash[:2], hash[2:4], hash), []byte(strings.Repeat("artefact", 100)), 0644)
+
+	report, err := mgr.Scrub()
+	if err != nil {
+		t.Fatalf("Failed to scrub files: %v", err)
+	}
+	if len(report.Issues) != 2 {
+		t.Errorf("Expected both files referencing the damaged blob to be reported: %v", report.Issues)
+	}
+	for _, issue := range report.Issues {
+		if issue.Kind != repository.ScrubIssueCorrupted || issue.QuarantinePath != "" {
+			t.Errorf("Expected deduplicated file to be reported as corrupted without being quarantined: %v", issue)
+		}
+	}
+	if _, err = fileMgr.GetFileInfo(testAuthUser, "/a.bin", false); err != nil {
+		t.Errorf("Expected deduplicated file to be kept: %v", err)
+	}
+	if blob, err := testDeduplicationBlobRep.GetByHash(hash); err != nil || blob.RefCount != 2 {
+		t.Errorf("Expected damaged blob to keep its references: %v, %v", blob, err)
+	}
+}
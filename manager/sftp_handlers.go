@@ -0,0 +1,216 @@
+package manager
+
+import (
+	"errors"
+	"io"
+	"os"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/pkg/sftp"
+)
+
+var (
+	errSFTPNotDir   = errors.New("not a folder")
+	errSFTPIsDir    = errors.New("is a folder")
+	errSFTPNotEmpty = errors.New("folder is not empty")
+)
+
+// sftpHandlers serve the files of a user including those shared with him to an SFTP request server.
+// Like for WebDAV all operations go through the FileManager, so shares and the bookkeeping in the db apply like for the API.
+type sftpHandlers struct {
+	mgr      *FileManager
+	user     *models.User
+	readOnly bool
+}
+
+// GetSFTPHandlers returns handlers serving the files the user can see to an SFTP request server, with readOnly all changes are refused
+func (mgr *FileManager) GetSFTPHandlers(user *models.User, readOnly bool) sftp.Handlers {
+	handlers := &sftpHandlers{mgr: mgr, user: user, readOnly: readOnly}
+	return sftp.Handlers{FileGet: handlers, FilePut: handlers, FileCmd: handlers, FileList: handlers}
+}
+
+func (h *sftpHandlers) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	file, _, err := h.mgr.OpenFile(h.user, cleanFilePath(r.Filepath))
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	return file, nil
+}
+
+// Filewrite creates an upload for the new content of the file, it starts with the current content unless the client truncates the file
+func (h *sftpHandlers) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	if h.readOnly {
+		return nil, sftp.ErrSSHFxPermissionDenied
+	}
+	name := cleanFilePath(r.Filepath)
+	flags := r.Pflags()
+	existingInfo, err := h.mgr.GetFileInfo(h.user, name, true)
+	if err == nil && existingInfo.IsDir {
+		return nil, errSFTPIsDir
+	} else if err == nil && flags.Excl {
+		return nil, os.ErrExist
+	} else if err != nil && !flags.Creat {
+		return nil, sftp.ErrSSHFxNoSuchFile
+	}
+
+	upload, err := h.mgr.CreateUpload(h.user, name, !flags.Trunc)
+	if err != nil {
+		return nil, toSFTPError(err)
+	}
+	return &sftpWriter{mgr: h.mgr, upload: upload}, nil
+}
+
+func (h *sftpHandlers) Filecmd(r *sftp.Request) error {
+	if h.readOnly {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	name := cleanFilePath(r.Filepath)
+	switch r.Method {
+	case "Setstat":
+		// Modes and times are not stored, but clients set them after uploading and would report an error otherwise
+		_, err := h.mgr.GetFileInfo(h.user, name, true)
+		return toSFTPError(err)
+	case "Rename":
+		return h.rename(name, cleanFilePath(r.Target))
+	case "Mkdir":
+		return h.mkdir(name)
+	case "Rmdir":
+		return h.remove(name, true)
+	case "Remove":
+		return h.remove(name, false)
+	}
+	return sftp.ErrSSHFxOpUnsupported
+}
+
+// PosixRename replaces an existing file at the target unlike Rename
+func (h *sftpHandlers) PosixRename(r *sftp.Request) error {
+	if h.readOnly {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	target := cleanFilePath(r.Target)
+	if targetInfo, err := h.mgr.GetFileInfo(h.user, target, true); err == nil && !targetInfo.IsDir && target != cleanFilePath(r.Filepath) {
+		err = h.mgr.DeleteFile(h.user, target)
+		if err != nil {
+			return toSFTPError(err)
+		}
+	}
+	return h.rename(cleanFilePath(r.Filepath), target)
+}
+
+func (h *sftpHandlers) rename(oldName, newName string) error {
+	newPath, newName := utils.SplitPath(newName)
+	_, err := h.mgr.UpdateFile(h.user, oldName, &models.FileInfoUpdate{Path: &newPath, Name: &newName})
+	return toSFTPError(err)
+}
+
+func (h *sftpHandlers) mkdir(name string) error {
+	if _, err := h.mgr.GetFileInfo(h.user, name, false); err == nil {
+		return os.ErrExist
+	}
+	folderPath, _ := utils.SplitPath(name)
+	if folderInfo, err := h.mgr.GetFileInfo(h.user, folderPath, false); err != nil || !folderInfo.IsDir {
+		return sftp.ErrSSHFxNoSuchFile
+	}
+	return toSFTPError(h.mgr.CreateDirectoryForUser(h.user, name))
+}
+
+// remove deletes a file or an empty folder like the matching system calls do
+func (h *sftpHandlers) remove(name string, isDir bool) error {
+	if name == "/" {
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	pathInfo, err := h.mgr.GetPathInfo(h.user, name)
+	if err != nil {
+		return toSFTPError(err)
+	}
+	if isDir && !pathInfo.FileInfo.IsDir {
+		return errSFTPNotDir
+	} else if !isDir && pathInfo.FileInfo.IsDir {
+		return errSFTPIsDir
+	} else if len(pathInfo.Content) > 0 {
+		return errSFTPNotEmpty
+	}
+	return toSFTPError(h.mgr.DeleteFile(h.user, name))
+}
+
+func (h *sftpHandlers) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	name := cleanFilePath(r.Filepath)
+	switch r.Method {
+	case "List":
+		pathInfo, err := h.mgr.GetPathInfo(h.user, name)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		if !pathInfo.FileInfo.IsDir {
+			return nil, errSFTPNotDir
+		}
+		var content sftpListerAt
+		for _, fileInfo := range pathInfo.Content {
+			// The temp folder of the user is left out as it only contains files the server creates for him
+			if fileInfo.Name == h.mgr.tmpName && name == "/" {
+				continue
+			}
+			content = append(content, &osFileInfo{fileInfo: fileInfo})
+		}
+		return content, nil
+	case "Stat", "Lstat":
+		fileInfo, err := h.mgr.GetFileInfo(h.user, name, true)
+		if err != nil {
+			return nil, toSFTPError(err)
+		}
+		return sftpListerAt{&osFileInfo{fileInfo: fileInfo}}, nil
+	}
+	return nil, sftp.ErrSSHFxOpUnsupported
+}
+
+// toSFTPError converts errors of the FileManager to the status codes of SFTP
+func toSFTPError(err error) error {
+	err = toOSError(err)
+	switch err {
+	case os.ErrNotExist:
+		return sftp.ErrSSHFxNoSuchFile
+	case os.ErrPermission:
+		return sftp.ErrSSHFxPermissionDenied
+	}
+	return err
+}
+
+// sftpListerAt lists files which have all been read from the db before
+type sftpListerAt []os.FileInfo
+
+func (l sftpListerAt) ListAt(fileInfos []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(l)) {
+		return 0, io.EOF
+	}
+	n := copy(fileInfos, l[offset:])
+	if n < len(fileInfos) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// sftpWriter writes the content into an upload at the offsets sent by the client, the file is only stored once it is closed
+type sftpWriter struct {
+	mgr         *FileManager
+	upload      *Upload
+	transferErr error
+}
+
+func (w *sftpWriter) WriteAt(p []byte, offset int64) (int, error) {
+	return w.upload.WriteAt(p, offset)
+}
+
+// TransferError is called if the connection got lost before the client closed the file, the previous content is kept then
+func (w *sftpWriter) TransferError(err error) {
+	w.transferErr = err
+}
+
+func (w *sftpWriter) Close() error {
+	if w.transferErr != nil {
+		w.mgr.AbortUpload(w.upload)
+		return w.transferErr
+	}
+	_, err := w.mgr.FinishUpload(w.upload)
+	return toSFTPError(err)
+}
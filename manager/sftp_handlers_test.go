@@ -0,0 +1,150 @@
+package manager
+
+import (
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/pkg/sftp"
+)
+
+func testSFTPClient(t *testing.T, user *models.User, readOnly bool) *sftp.Client {
+	serverConn, clientConn := net.Pipe()
+	server := sftp.NewRequestServer(serverConn, GetFileManager().GetSFTPHandlers(user, readOnly))
+	go server.Serve()
+
+	client, err := sftp.NewClientPipe(clientConn, clientConn)
+	if err != nil {
+		t.Fatalf("Failed to create sftp client: %v", err)
+	}
+	return client
+}
+
+func testSFTPWrite(client *sftp.Client, path, content string, flags int) error {
+	file, err := client.OpenFile(path, flags)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func TestSFTPHandlers(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUserAdmin, "/team", true)
+	mgr.ShareFile(testAuthUserAdmin, testAuthUser, "/team")
+
+	client := testSFTPClient(t, testAuthUser, false)
+	defer client.Close()
+
+	if err := client.Mkdir("/docs"); err != nil {
+		t.Fatalf("Failed to create folder: %v", err)
+	}
+	if err := client.Mkdir("/missing/docs"); !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error for creating folder in missing folder but got: %v", err)
+	}
+
+	writeFlags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if err := testSFTPWrite(client, "/docs/a.txt", "content", writeFlags); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	if err := testSFTPWrite(client, "/docs/a.txt", "new content", writeFlags); err != nil {
+		t.Errorf("Failed to replace file: %v", err)
+	}
+	if err := testSFTPWrite(client, "/docs/a.txt", "NEW", os.O_WRONLY); err != nil {
+		t.Errorf("Failed to change file in place: %v", err)
+	}
+	if err := testSFTPWrite(client, "/docs/b.txt", "content", os.O_WRONLY); !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error for writing missing file without creating it but got: %v", err)
+	}
+	if err := testSFTPWrite(client, "/team/shared.txt", "shared", writeFlags); err != nil {
+		t.Errorf("Failed to write file into shared folder: %v", err)
+	}
+
+	fileInfo, err := mgr.GetFileInfo(testAuthUser, "/docs/a.txt", false)
+	if err != nil || fileInfo.Size != int64(len("NEW content")) {
+		t.Errorf("Expected changed file in db but got: %v, %v", fileInfo, err)
+	}
+	fileInfo, err = mgr.GetFileInfo(testAuthUserAdmin, "/team/shared.txt", false)
+	if err != nil || fileInfo.OwnerID != testAuthUserAdmin.ID {
+		t.Errorf("Expected file written into shared folder to belong to its owner but got: %v, %v", fileInfo, err)
+	}
+
+	file, err := client.Open("/docs/a.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(file)
+	file.Close()
+	if string(content) != "NEW content" {
+		t.Errorf("Expected changed content but got: %s", content)
+	}
+
+	stat, err := client.Stat("/docs/a.txt")
+	if err != nil || stat.Size() != int64(len(content)) || stat.IsDir() {
+		t.Errorf("Expected size of file in its stat but got: %v, %v", stat, err)
+	}
+	entries, err := client.ReadDir("/")
+	if err != nil {
+		t.Fatalf("Failed to list root folder: %v", err)
+	}
+	names := make(map[string]bool)
+	for _, entry := range entries {
+		names[entry.Name()] = true
+	}
+	if len(names) != 2 || !names["docs"] || !names["team"] {
+		t.Errorf("Expected docs and team but neither temp folder nor anything else in root folder: %v", names)
+	}
+
+	if err = client.Rename("/docs/a.txt", "/docs/b.txt"); err != nil {
+		t.Errorf("Failed to rename file: %v", err)
+	}
+	testSFTPWrite(client, "/docs/c.txt", "c", writeFlags)
+	if err = client.Rename("/docs/c.txt", "/docs/b.txt"); err == nil {
+		t.Errorf("Expected error for renaming onto existing file")
+	}
+	if err = client.PosixRename("/docs/c.txt", "/docs/b.txt"); err != nil {
+		t.Errorf("Failed to replace file by posix rename: %v", err)
+	}
+	if fileInfo, err = mgr.GetFileInfo(testAuthUser, "/docs/b.txt", false); err != nil || fileInfo.Size != 1 {
+		t.Errorf("Expected replaced file in db but got: %v, %v", fileInfo, err)
+	}
+
+	if err = client.RemoveDirectory("/docs"); err == nil {
+		t.Errorf("Expected error for removing folder which is not empty")
+	}
+	if err = client.Remove("/docs/b.txt"); err != nil {
+		t.Errorf("Failed to remove file: %v", err)
+	}
+	if err = client.RemoveDirectory("/docs"); err != nil {
+		t.Errorf("Failed to remove empty folder: %v", err)
+	}
+	if _, err = mgr.GetFileInfo(testAuthUser, "/docs", false); err == nil {
+		t.Errorf("Expected removed folder to be deleted from db")
+	}
+
+	readOnlyClient := testSFTPClient(t, testAuthUser, true)
+	defer readOnlyClient.Close()
+	if _, err = readOnlyClient.ReadDir("/team"); err != nil {
+		t.Errorf("Failed to list folder with read only client: %v", err)
+	}
+	if err = readOnlyClient.Mkdir("/other"); !os.IsPermission(err) {
+		t.Errorf("Expected permission error for creating folder with read only client but got: %v", err)
+	}
+	if err = testSFTPWrite(readOnlyClient, "/team/other.txt", "content", writeFlags); !os.IsPermission(err) {
+		t.Errorf("Expected permission error for writing file with read only client but got: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package manager
+
+import (
+	"sort"
+	"sync"
+)
+
+// subjectLocks provides a mutex per subject which only exists while it is in use.
+// The zero value is ready to use.
+type subjectLocks struct {
+	mutex sync.Mutex
+	locks map[string]*subjectLock
+}
+
+type subjectLock struct {
+	sync.Mutex
+	users int
+}
+
+// lock acquires the mutexes of all subjects and returns a function releasing them again.
+// They are always acquired in sorted order, so callers locking several subjects don't deadlock each other.
+func (l *subjectLocks) lock(subjects []string) (unlock func()) {
+	sorted := append([]string{}, subjects...)
+	sort.Strings(sorted)
+	// A subject locked twice would wait for itself
+	for i := len(sorted) - 1; i > 0; i-- {
+		if sorted[i] == sorted[i-1] {
+			sorted = append(sorted[:i], sorted[i+1:]...)
+		}
+	}
+
+	l.mutex.Lock()
+	if l.locks == nil {
+		l.locks = make(map[string]*subjectLock)
+	}
+	locks := make([]*subjectLock, len(sorted))
+	for i, subject := range sorted {
+		lock, ok := l.locks[subject]
+		if !ok {
+			lock = &subjectLock{}
+			l.locks[subject] = lock
+		}
+		lock.users++
+		locks[i] = lock
+	}
+	l.mutex.Unlock()
+
+	for _, lock := range locks {
+		lock.Lock()
+	}
+
+	return func() {
+		l.mutex.Lock()
+		defer l.mutex.Unlock()
+		for i, lock := range locks {
+			lock.Unlock()
+			lock.users--
+			if lock.users == 0 {
+				delete(l.locks, sorted[i])
+			}
+		}
+	}
+}
@@ -22,8 +22,13 @@ func testSystemReq() {
 	repository.InitDatabaseConnection("", "", "", "", 0, testSystemDBName)
 	sessionRep, _ := repository.CreateSessionRepository()
 	userRep, _ := repository.CreateUserRepository()
+	loginAttemptRep, _ := repository.CreateLoginAttemptRepository()
+	inviteCodeRep, _ := repository.CreateInviteCodeRepository()
+	appPasswordRep, _ := repository.CreateAppPasswordRepository()
+	sshKeyRep, _ := repository.CreateSSHKeyRepository()
+	accessKeyRep, _ := repository.CreateAccessKeyRepository()
 
-	CreateAuthManager(sessionRep, userRep, 24, 1)
+	CreateAuthManager(sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep, AuthConfig{SessionExpiry: 24, SessionCleanupInterval: 1, ImpersonationExpiry: 30})
 }
 
 func TestCreateSystemManager(t *testing.T) {
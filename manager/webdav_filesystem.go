@@ -0,0 +1,254 @@
+package manager
+
+import (
+	"context"
+	"io"
+	"mime"
+	"os"
+	"path/filepath"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/repository"
+	"github.com/freecloudio/server/utils"
+	"golang.org/x/net/webdav"
+)
+
+// webdavFileSystem exposes the files of a user including those shared with him to the WebDAV handler.
+// All operations go through the FileManager, so shares, permissions and the bookkeeping in the db apply like for the API.
+type webdavFileSystem struct {
+	mgr  *FileManager
+	user *models.User
+}
+
+// GetWebDAVFileSystem returns the files the user can see as file system for a WebDAV handler
+func (mgr *FileManager) GetWebDAVFileSystem(user *models.User) webdav.FileSystem {
+	return &webdavFileSystem{mgr: mgr, user: user}
+}
+
+func (fs *webdavFileSystem) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	name = cleanFilePath(name)
+	if _, err := fs.mgr.GetFileInfo(fs.user, name, false); err == nil {
+		return os.ErrExist
+	}
+	folderPath, _ := utils.SplitPath(name)
+	if folderInfo, err := fs.mgr.GetFileInfo(fs.user, folderPath, false); err != nil || !folderInfo.IsDir {
+		return os.ErrNotExist
+	}
+	return toOSError(fs.mgr.CreateDirectoryForUser(fs.user, name))
+}
+
+func (fs *webdavFileSystem) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	name = cleanFilePath(name)
+	if flag&(os.O_WRONLY|os.O_RDWR) != 0 {
+		return fs.openWriter(ctx, name, flag)
+	}
+
+	fileInfo, err := fs.mgr.GetFileInfo(fs.user, name, true)
+	if err != nil {
+		return nil, toOSError(err)
+	}
+	if fileInfo.IsDir {
+		return &webdavDir{fs: fs, name: name, fileInfo: fileInfo}, nil
+	}
+
+	file, _, err := fs.mgr.OpenFile(fs.user, name)
+	if err != nil {
+		return nil, toOSError(err)
+	}
+	return &webdavFile{StorageFile: file, fileInfo: fileInfo}, nil
+}
+
+// openWriter checks whether the file can be written before creating an upload for its new content
+func (fs *webdavFileSystem) openWriter(ctx context.Context, name string, flag int) (webdav.File, error) {
+	existingInfo, err := fs.mgr.GetFileInfo(fs.user, name, true)
+	if err == nil && (existingInfo.IsDir || flag&os.O_EXCL != 0) {
+		return nil, os.ErrExist
+	} else if err != nil && flag&os.O_CREATE == 0 {
+		return nil, os.ErrNotExist
+	}
+	folderPath, fileName := utils.SplitPath(name)
+	if folderInfo, err := fs.mgr.GetFileInfo(fs.user, folderPath, false); err != nil || !folderInfo.IsDir {
+		return nil, os.ErrNotExist
+	}
+
+	upload, err := fs.mgr.CreateUpload(fs.user, name, false)
+	if err != nil {
+		return nil, toOSError(err)
+	}
+	upload.expected = getExpectedChecksums(ctx)
+	return &webdavWriter{
+		ctx:    ctx,
+		mgr:    fs.mgr,
+		upload: upload,
+		fileInfo: &models.FileInfo{
+			Path:        utils.ConvertToSlash(folderPath, true),
+			Name:        fileName,
+			LastChanged: utils.GetTimestampNow(),
+			MimeType:    mime.TypeByExtension(filepath.Ext(fileName)),
+		},
+	}, nil
+}
+
+func (fs *webdavFileSystem) RemoveAll(ctx context.Context, name string) error {
+	name = cleanFilePath(name)
+	if name == "/" {
+		return os.ErrPermission
+	}
+	return toOSError(fs.mgr.DeleteFile(fs.user, name))
+}
+
+func (fs *webdavFileSystem) Rename(ctx context.Context, oldName, newName string) error {
+	newPath, newName := utils.SplitPath(cleanFilePath(newName))
+	_, err := fs.mgr.UpdateFile(fs.user, cleanFilePath(oldName), &models.FileInfoUpdate{Path: &newPath, Name: &newName})
+	return toOSError(err)
+}
+
+func (fs *webdavFileSystem) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	fileInfo, err := fs.mgr.GetFileInfo(fs.user, cleanFilePath(name), true)
+	if err != nil {
+		return nil, toOSError(err)
+	}
+	return &webdavFileInfo{osFileInfo{fileInfo: fileInfo}}, nil
+}
+
+// webdavFileInfo adds the properties WebDAV clients ask for when listing folders to the info of a file
+type webdavFileInfo struct {
+	osFileInfo
+}
+
+// ContentType returns the stored mime type, so files don't have to be opened for listing folders
+func (fi *webdavFileInfo) ContentType(ctx context.Context) (string, error) {
+	if fi.fileInfo.MimeType == "" {
+		return "", webdav.ErrNotImplemented
+	}
+	return fi.fileInfo.MimeType, nil
+}
+
+// ETag is derived from the stored info, it is read after closing written files and then reflects the stored file
+func (fi *webdavFileInfo) ETag(ctx context.Context) (string, error) {
+	return FileETag(fi.fileInfo), nil
+}
+
+// webdavFile is a file opened for reading
+type webdavFile struct {
+	repository.StorageFile
+	fileInfo *models.FileInfo
+}
+
+func (f *webdavFile) Stat() (os.FileInfo, error) {
+	return &webdavFileInfo{osFileInfo{fileInfo: f.fileInfo}}, nil
+}
+
+func (f *webdavFile) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (f *webdavFile) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrInvalid
+}
+
+// webdavDir is an opened folder whose content is read from the db on the first call of Readdir
+type webdavDir struct {
+	fs       *webdavFileSystem
+	name     string
+	fileInfo *models.FileInfo
+	content  []os.FileInfo
+	read     bool
+}
+
+func (d *webdavDir) Close() error {
+	return nil
+}
+
+func (d *webdavDir) Read(p []byte) (int, error) {
+	return 0, ErrOpenFolder
+}
+
+func (d *webdavDir) Seek(offset int64, whence int) (int64, error) {
+	return 0, ErrOpenFolder
+}
+
+func (d *webdavDir) Write(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (d *webdavDir) Stat() (os.FileInfo, error) {
+	return &webdavFileInfo{osFileInfo{fileInfo: d.fileInfo}}, nil
+}
+
+// Readdir returns the next count files of the folder or all remaining ones for a count of zero or less.
+// The temp folder of the user is left out as it only contains files the server creates for him.
+func (d *webdavDir) Readdir(count int) ([]os.FileInfo, error) {
+	if !d.read {
+		pathInfo, err := d.fs.mgr.GetPathInfo(d.fs.user, d.name)
+		if err != nil {
+			return nil, toOSError(err)
+		}
+		for _, fileInfo := range pathInfo.Content {
+			if fileInfo.Name == d.fs.mgr.tmpName && d.name == "/" {
+				continue
+			}
+			d.content = append(d.content, &webdavFileInfo{osFileInfo{fileInfo: fileInfo}})
+		}
+		d.read = true
+	}
+
+	if count <= 0 {
+		content := d.content
+		d.content = nil
+		return content, nil
+	}
+	if len(d.content) == 0 {
+		return nil, io.EOF
+	}
+	if count > len(d.content) {
+		count = len(d.content)
+	}
+	content := d.content[:count]
+	d.content = d.content[count:]
+	return content, nil
+}
+
+// webdavWriter writes the content into an upload, the file is only stored once it is closed
+type webdavWriter struct {
+	ctx      context.Context
+	mgr      *FileManager
+	upload   *Upload
+	fileInfo *models.FileInfo
+}
+
+func (w *webdavWriter) Write(p []byte) (int, error) {
+	return w.upload.Write(p)
+}
+
+// Close stores the written file unless the request has been canceled meanwhile, then the previous content is kept
+func (w *webdavWriter) Close() error {
+	if err := w.ctx.Err(); err != nil {
+		w.mgr.AbortUpload(w.upload)
+		return err
+	}
+	fileInfo, err := w.mgr.FinishUpload(w.upload)
+	if err != nil {
+		return toOSError(err)
+	}
+	fileInfo.Path = w.fileInfo.Path
+	*w.fileInfo = *fileInfo
+	return nil
+}
+
+// Stat returns the info of the written file, it is only complete after closing the file
+func (w *webdavWriter) Stat() (os.FileInfo, error) {
+	return &webdavFileInfo{osFileInfo{fileInfo: w.fileInfo}}, nil
+}
+
+func (w *webdavWriter) Read(p []byte) (int, error) {
+	return 0, os.ErrPermission
+}
+
+func (w *webdavWriter) Seek(offset int64, whence int) (int64, error) {
+	return 0, os.ErrPermission
+}
+
+func (w *webdavWriter) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, os.ErrPermission
+}
@@ -0,0 +1,126 @@
+package manager
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/webdav"
+)
+
+func testWebDAVRequest(handler http.Handler, method, path, body string, headers map[string]string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestWebDAVFileSystem(t *testing.T) {
+	if testAuthSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	authMgr := testAuthSetup()
+	defer testAuthCleanup(authMgr)
+	testAuthInsert(authMgr)
+
+	mgr := GetFileManager()
+	mgr.CreateFile(testAuthUserAdmin, "/team", true)
+	mgr.ShareFile(testAuthUserAdmin, testAuthUser, "/team")
+
+	handler := &webdav.Handler{
+		Prefix:     "/webdav",
+		FileSystem: mgr.GetWebDAVFileSystem(testAuthUser),
+		LockSystem: webdav.NewMemLS(),
+	}
+
+	steps := []struct {
+		method  string
+		path    string
+		body    string
+		headers map[string]string
+		status  int
+	}{
+		{"MKCOL", "/webdav/docs", "", nil, http.StatusCreated},
+		{"MKCOL", "/webdav/docs", "", nil, http.StatusMethodNotAllowed},
+		{"MKCOL", "/webdav/missing/docs", "", nil, http.StatusConflict},
+		{"PUT", "/webdav/docs/a.txt", "content", nil, http.StatusCreated},
+		{"PUT", "/webdav/docs/a.txt", "new content", nil, http.StatusCreated},
+		{"PUT", "/webdav/missing/a.txt", "content", nil, http.StatusNotFound},
+		{"GET", "/webdav/docs/a.txt", "", nil, http.StatusOK},
+		{"COPY", "/webdav/docs", "", map[string]string{"Destination": "/webdav/copy"}, http.StatusCreated},
+		{"MOVE", "/webdav/copy/a.txt", "", map[string]string{"Destination": "/webdav/team/a.txt"}, http.StatusForbidden},
+		{"MOVE", "/webdav/copy/a.txt", "", map[string]string{"Destination": "/webdav/docs/b.txt"}, http.StatusCreated},
+		{"PUT", "/webdav/team/shared.txt", "shared", nil, http.StatusCreated},
+		{"DELETE", "/webdav/copy", "", nil, http.StatusNoContent},
+		{"DELETE", "/webdav/copy", "", nil, http.StatusNotFound},
+	}
+	for _, step := range steps {
+		rec := testWebDAVRequest(handler, step.method, step.path, step.body, step.headers)
+		if rec.Code != step.status {
+			t.Errorf("Expected status %d for %s %s but got: %d %s", step.status, step.method, step.path, rec.Code, rec.Body.String())
+		}
+	}
+
+	fileInfo, err := mgr.GetFileInfo(testAuthUser, "/docs/a.txt", false)
+	if err != nil || fileInfo.Size != int64(len("new content")) {
+		t.Errorf("Expected replaced file in db but got: %v, %v", fileInfo, err)
+	}
+	if _, err = mgr.GetFileInfo(testAuthUser, "/docs/b.txt", false); err != nil {
+		t.Errorf("Expected moved file in db: %v", err)
+	}
+	fileInfo, err = mgr.GetFileInfo(testAuthUserAdmin, "/team/shared.txt", false)
+	if err != nil || fileInfo.OwnerID != testAuthUserAdmin.ID {
+		t.Errorf("Expected file written into shared folder to belong to its owner but got: %v, %v", fileInfo, err)
+	}
+
+	rec := testWebDAVRequest(handler, "GET", "/webdav/docs/a.txt", "", nil)
+	if body, _ := ioutil.ReadAll(rec.Body); string(body) != "new content" {
+		t.Errorf("Expected replaced content but got: %s", body)
+	}
+
+	rec = testWebDAVRequest(handler, "PROPFIND", "/webdav/", "", map[string]string{"Depth": "1"})
+	body := rec.Body.String()
+	if rec.Code != http.StatusMultiStatus {
+		t.Fatalf("Expected multi status for listing root folder but got: %d", rec.Code)
+	}
+	for _, name := range []string{"/webdav/docs/", "/webdav/team/"} {
+		if !strings.Contains(body, name) {
+			t.Errorf("Expected %s in listing of root folder: %s", name, body)
+		}
+	}
+	if strings.Contains(body, "/webdav/"+mgr.tmpName) || strings.Contains(body, "/webdav/copy") {
+		t.Errorf("Expected neither temp folder nor deleted folder in listing of root folder: %s", body)
+	}
+
+	rec = testWebDAVRequest(handler, "PROPFIND", "/webdav/docs/a.txt", "", map[string]string{"Depth": "0"})
+	if body = rec.Body.String(); !strings.Contains(body, "<D:getcontentlength>11</D:getcontentlength>") || !strings.Contains(body, "text/plain") {
+		t.Errorf("Expected size and content type of file in its properties: %s", body)
+	}
+
+	lockBody := `<?xml version="1.0" encoding="utf-8"?><D:lockinfo xmlns:D="DAV:"><D:lockscope><D:exclusive/></D:lockscope><D:locktype><D:write/></D:locktype></D:lockinfo>`
+	rec = testWebDAVRequest(handler, "LOCK", "/webdav/docs/a.txt", lockBody, map[string]string{"Timeout": "Second-60"})
+	lockToken := rec.Header().Get("Lock-Token")
+	if rec.Code != http.StatusOK || lockToken == "" {
+		t.Fatalf("Failed to lock file: %d %s", rec.Code, rec.Body.String())
+	}
+	if rec = testWebDAVRequest(handler, "PUT", "/webdav/docs/a.txt", "locked", nil); rec.Code != http.StatusLocked {
+		t.Errorf("Expected locked file to be protected from writing without its token but got: %d", rec.Code)
+	}
+	if rec = testWebDAVRequest(handler, "UNLOCK", "/webdav/docs/a.txt", "", map[string]string{"Lock-Token": lockToken}); rec.Code != http.StatusNoContent {
+		t.Errorf("Failed to unlock file: %d", rec.Code)
+	}
+
+	_, err = mgr.GetWebDAVFileSystem(testAuthUser).OpenFile(context.Background(), "/docs", 0, 0)
+	if err != nil {
+		t.Errorf("Failed to open folder: %v", err)
+	}
+	if err = mgr.GetWebDAVFileSystem(testAuthUser).RemoveAll(context.Background(), "/"); err == nil {
+		t.Errorf("Expected error for deleting root folder")
+	}
+}
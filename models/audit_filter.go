@@ -0,0 +1,11 @@
+package models
+
+// AuditFilter restricts which audit entries are returned, unset fields match all entries.
+// Action matches either the action itself or, like "file", all actions of that category.
+type AuditFilter struct {
+	Action       string
+	ActorID      int64
+	TargetUserID int64
+	Since        int64
+	Until        int64
+}
@@ -0,0 +1,9 @@
+package models
+
+// Blob is content stored once by its SHA-256 hash, it can be deleted once no file references it anymore
+type Blob struct {
+	Hash     string `gorm:"primary_key"`
+	Size     int64
+	RefCount int64 `gorm:"index"`
+	Created  int64
+}
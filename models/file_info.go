@@ -18,12 +18,18 @@ type FileInfo struct {
 	// ID
 	ID int64 `json:"ID,omitempty" gorm:"primary_key;auto_increment"`
 
+	// SHA-256 hash of the deduplicated content the file references
+	BlobHash string `json:"blobHash,omitempty" gorm:"index"`
+
 	// is dir
 	IsDir bool `json:"isDir,omitempty"`
 
 	// last changed
 	LastChanged int64 `json:"lastChanged,omitempty"`
 
+	// Hex encoded MD5 checksum of the content for legacy tools, only computed if enabled
+	Md5 string `json:"md5,omitempty"`
+
 	// mime type
 	MimeType string `json:"mimeType,omitempty"`
 
@@ -39,6 +45,9 @@ type FileInfo struct {
 	// path
 	Path string `json:"path,omitempty" gorm:"index:fullPath"`
 
+	// Hex encoded SHA-256 checksum of the content, empty for folders and files not hashed yet
+	Sha256 string `json:"sha256,omitempty"`
+
 	// share ID
 	ShareID int64 `json:"shareID,omitempty"`
 
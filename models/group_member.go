@@ -0,0 +1,7 @@
+package models
+
+// GroupMember links a user to a group he is a member of
+type GroupMember struct {
+	GroupID int64 `gorm:"primary_key;auto_increment:false"`
+	UserID  int64 `gorm:"primary_key;auto_increment:false;index"`
+}
@@ -0,0 +1,9 @@
+package models
+
+// LoginAttempt keeps track of failed logins for one account or client address
+type LoginAttempt struct {
+	Subject     string `gorm:"primary_key"`
+	Failures    int
+	LastFailure int64 `gorm:"index"`
+	LockedUntil int64
+}
@@ -16,6 +16,12 @@ import (
 // swagger:model Principal
 type Principal struct {
 
+	// impersonator
+	Impersonator *User `json:"impersonator,omitempty"`
+
+	// read only
+	ReadOnly bool `json:"readOnly,omitempty"`
+
 	// token
 	Token *Token `json:"token,omitempty"`
 
@@ -27,6 +33,10 @@ type Principal struct {
 func (m *Principal) Validate(formats strfmt.Registry) error {
 	var res []error
 
+	if err := m.validateImpersonator(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateToken(formats); err != nil {
 		res = append(res, err)
 	}
@@ -41,6 +51,24 @@ func (m *Principal) Validate(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Principal) validateImpersonator(formats strfmt.Registry) error {
+
+	if swag.IsZero(m.Impersonator) { // not required
+		return nil
+	}
+
+	if m.Impersonator != nil {
+		if err := m.Impersonator.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("impersonator")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Principal) validateToken(formats strfmt.Registry) error {
 
 	if swag.IsZero(m.Token) { // not required
@@ -0,0 +1,9 @@
+package models
+
+// RoleBinding assigns a role either directly to a user or to all members of a group
+type RoleBinding struct {
+	ID      int64 `gorm:"primary_key;auto_increment"`
+	Role    string
+	UserID  int64 `gorm:"index"`
+	GroupID int64 `gorm:"index"`
+}
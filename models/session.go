@@ -13,6 +13,10 @@ type Session struct {
 	UserID    int64  `gorm:"index"`
 	Token     string `gorm:"primary_key"`
 	ExpiresAt int64
+	// ImpersonatorID is set if an admin acts as the user in this session
+	ImpersonatorID int64
+	// ReadOnly sessions must not change any data, impersonation sessions are read-only by default
+	ReadOnly bool
 }
 
 // GetSessionString assembles the session string for the frontend
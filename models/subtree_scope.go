@@ -0,0 +1,7 @@
+package models
+
+// SubtreeScope is the subtree at Path within the files of one owner whose activities and changes are visible to a user
+type SubtreeScope struct {
+	OwnerID int64
+	Path    string
+}
@@ -18,15 +18,27 @@ type User struct {
 	// ID
 	ID int64 `json:"ID,omitempty" gorm:"primary_key;auto_increment"`
 
+	// auth provider
+	AuthProvider string `json:"authProvider,omitempty"`
+
+	// auth subject
+	AuthSubject string `json:"authSubject,omitempty" gorm:"index"`
+
 	// created
 	Created int64 `json:"created,omitempty"`
 
+	// disabled
+	Disabled bool `json:"disabled,omitempty"`
+
 	// email
 	Email string `json:"email,omitempty" gorm:"unique_index"`
 
 	// first name
 	FirstName string `json:"firstName,omitempty"`
 
+	// has avatar
+	HasAvatar bool `json:"hasAvatar,omitempty"`
+
 	// is admin
 	IsAdmin bool `json:"isAdmin,omitempty"`
 
@@ -39,9 +51,21 @@ type User struct {
 	// password
 	Password string `json:"password,omitempty"`
 
+	// pending approval
+	PendingApproval bool `json:"pendingApproval,omitempty"`
+
+	// permissions
+	Permissions []string `json:"permissions" gorm:"-"`
+
 	// retain files after deletion
 	RetainFilesAfterDeletion bool `json:"retainFilesAfterDeletion,omitempty"`
 
+	// roles
+	Roles []string `json:"roles" gorm:"-"`
+
+	// storage used
+	StorageUsed int64 `json:"storageUsed,omitempty" gorm:"-"`
+
 	// updated
 	Updated int64 `json:"updated,omitempty"`
 }
@@ -0,0 +1,8 @@
+package models
+
+// UserKey is the key encrypting the data keys of the files of a user, stored wrapped by the master key
+type UserKey struct {
+	UserID     int64 `gorm:"primary_key;auto_increment:false"`
+	WrappedKey []byte
+	Created    int64
+}
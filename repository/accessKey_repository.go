@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.AccessKey{})
+}
+
+// AccessKeyRepository represents the database for storing the S3 access keys of users
+type AccessKeyRepository struct{}
+
+// CreateAccessKeyRepository creates a new AccessKeyRepository IF gorm has been initialized before
+func CreateAccessKeyRepository() (*AccessKeyRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &AccessKeyRepository{}, nil
+}
+
+// Create stores a new access key, its secret is never stored
+func (rep *AccessKeyRepository) Create(accessKey *models.AccessKey) (err error) {
+	accessKey.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(accessKey).Error
+	if err != nil {
+		log.Error(0, "Could not create access key: %v", err)
+		return
+	}
+	return
+}
+
+// GetAllForUser returns all access keys of the user, oldest first
+func (rep *AccessKeyRepository) GetAllForUser(userID int64) (accessKeys []*models.AccessKey, err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Order("id asc").Find(&accessKeys).Error
+	if err != nil {
+		log.Error(0, "Could not get access keys of user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// GetByAccessKeyID reads and returns the access key clients sign their requests with
+func (rep *AccessKeyRepository) GetByAccessKeyID(accessKeyID string) (accessKey *models.AccessKey, err error) {
+	accessKey = &models.AccessKey{}
+	err = databaseConnection.First(accessKey, "access_key_id = ?", accessKeyID).Error
+	return
+}
+
+// UpdateLastUsed sets the time the access key has been used last to now
+func (rep *AccessKeyRepository) UpdateLastUsed(accessKeyID int64) (err error) {
+	err = databaseConnection.Model(&models.AccessKey{ID: accessKeyID}).UpdateColumn("last_used", utils.GetTimestampNow()).Error
+	if err != nil {
+		log.Error(0, "Could not update last usage of access key %d: %v", accessKeyID, err)
+		return
+	}
+	return
+}
+
+// Delete deletes an access key by its ID if it belongs to the user
+func (rep *AccessKeyRepository) Delete(accessKeyID, userID int64) (err error) {
+	db := databaseConnection.Where("id = ? AND user_id = ?", accessKeyID, userID).Delete(&models.AccessKey{})
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not delete access key %d of user %d: %v", accessKeyID, userID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// DeleteAllForUser deletes all access keys of the user
+func (rep *AccessKeyRepository) DeleteAllForUser(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.AccessKey{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete access keys of user %d: %v", userID, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testAccessKeySetupFailed = false
+var testAccessKeyDBName = "accessKeyTest.db"
+var testAccessKey0 = &models.AccessKey{UserID: 1, Name: "backup", AccessKeyID: "FCAK0"}
+var testAccessKey1 = &models.AccessKey{UserID: 1, Name: "rclone", AccessKeyID: "FCAK1"}
+var testAccessKey2 = &models.AccessKey{UserID: 2, Name: "backup", AccessKeyID: "FCAK2"}
+
+func testAccessKeyCleanup() {
+	os.Remove(testAccessKeyDBName)
+}
+
+func testAccessKeySetup() *AccessKeyRepository {
+	testAccessKeyCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testAccessKeyDBName)
+	rep, _ := CreateAccessKeyRepository()
+	return rep
+}
+
+func testAccessKeyInsert(rep *AccessKeyRepository) {
+	rep.Create(testAccessKey0)
+	rep.Create(testAccessKey1)
+	rep.Create(testAccessKey2)
+}
+
+func TestCreateAccessKeyRepository(t *testing.T) {
+	testAccessKeyCleanup()
+	defer testAccessKeyCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testAccessKeyDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateAccessKeyRepository()
+	if err != nil {
+		t.Errorf("Failed to create access key repository: %v", err)
+	}
+
+	if t.Failed() {
+		testAccessKeySetupFailed = true
+	}
+}
+
+func TestCreateAndGetAccessKeys(t *testing.T) {
+	if testAccessKeySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAccessKeyCleanup()
+	rep := testAccessKeySetup()
+
+	testAccessKeyInsert(rep)
+
+	accessKeys, err := rep.GetAllForUser(1)
+	if err != nil {
+		t.Fatalf("Failed to get access keys of user 1: %v", err)
+	}
+	if len(accessKeys) != 2 {
+		t.Fatalf("Expected 2 access keys of user 1, got %d", len(accessKeys))
+	}
+	if !reflect.DeepEqual(accessKeys[0], testAccessKey0) {
+		t.Errorf("Read back access key0 and access key0 not deeply equal: %v != %v", accessKeys[0], testAccessKey0)
+	}
+
+	readBackKey, err := rep.GetByAccessKeyID(testAccessKey2.AccessKeyID)
+	if err != nil {
+		t.Errorf("Failed to get access key2 by its access key id: %v", err)
+	} else if readBackKey.ID != testAccessKey2.ID || readBackKey.UserID != 2 {
+		t.Errorf("Expected access key2 for its access key id, got %v", readBackKey)
+	}
+	_, err = rep.GetByAccessKeyID("FCAKmissing")
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for unknown access key id, got %v", err)
+	}
+
+	err = rep.Create(&models.AccessKey{UserID: 2, Name: "duplicate", AccessKeyID: testAccessKey0.AccessKeyID})
+	if err == nil {
+		t.Errorf("Expected error for creating access key with existing access key id")
+	}
+
+	err = rep.UpdateLastUsed(testAccessKey1.ID)
+	if err != nil {
+		t.Errorf("Failed to update last usage of access key1: %v", err)
+	}
+	accessKeys, _ = rep.GetAllForUser(1)
+	if len(accessKeys) != 2 || accessKeys[1].LastUsed == 0 {
+		t.Errorf("Expected last usage of access key1 to be set: %v", accessKeys)
+	}
+}
+
+func TestDeleteAccessKey(t *testing.T) {
+	if testAccessKeySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAccessKeyCleanup()
+	rep := testAccessKeySetup()
+
+	testAccessKeyInsert(rep)
+
+	err := rep.Delete(testAccessKey2.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting access key of another user, got %v", err)
+	}
+
+	err = rep.Delete(testAccessKey0.ID, 1)
+	if err != nil {
+		t.Errorf("Failed to delete access key0: %v", err)
+	}
+	err = rep.Delete(testAccessKey0.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting access key twice, got %v", err)
+	}
+
+	err = rep.DeleteAllForUser(1)
+	if err != nil {
+		t.Errorf("Failed to delete access keys of user 1: %v", err)
+	}
+	accessKeys, _ := rep.GetAllForUser(1)
+	if len(accessKeys) != 0 {
+		t.Errorf("Expected no access keys of user 1 after deleting all, got %d", len(accessKeys))
+	}
+	accessKeys, _ = rep.GetAllForUser(2)
+	if len(accessKeys) != 1 {
+		t.Errorf("Expected access key of user 2 to be kept, got %d", len(accessKeys))
+	}
+}
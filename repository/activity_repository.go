@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.Activity{})
+}
+
+// ActivityRepository represents the database for storing the activities in the files of users
+type ActivityRepository struct{}
+
+// CreateActivityRepository creates a new ActivityRepository IF gorm has been initialized before
+func CreateActivityRepository() (*ActivityRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &ActivityRepository{}, nil
+}
+
+// Create stores a new activity
+func (rep *ActivityRepository) Create(activity *models.Activity) (err error) {
+	activity.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(activity).Error
+	if err != nil {
+		log.Error(0, "Could not create activity: %v", err)
+		return
+	}
+	return
+}
+
+// GetPage returns the activities within any of the scopes and the time range, newest first, together with their total count.
+// Moves are included if either their old or new path is within a scope, since and until are ignored if they are 0.
+func (rep *ActivityRepository) GetPage(scopes []*models.SubtreeScope, since, until, offset, limit int64) (activities []*models.Activity, total int64, err error) {
+	activities = []*models.Activity{}
+	if len(scopes) == 0 {
+		return
+	}
+
+	condition, args := subtreeScopesCondition(scopes)
+	query := databaseConnection.Model(&models.Activity{}).Where(condition, args...)
+	if since != 0 {
+		query = query.Where("created >= ?", since)
+	}
+	if until != 0 {
+		query = query.Where("created < ?", until)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		log.Error(0, "Could not count activities: %v", err)
+		return
+	}
+
+	err = query.Order("id desc").Offset(offset).Limit(limit).Find(&activities).Error
+	if err != nil {
+		log.Error(0, "Could not get activities: %v", err)
+		return
+	}
+	return
+}
+
+// DeleteAllForOwner deletes all activities in the files of a user
+func (rep *ActivityRepository) DeleteAllForOwner(ownerID int64) (err error) {
+	err = databaseConnection.Delete(&models.Activity{}, "owner_id = ?", ownerID).Error
+	if err != nil {
+		log.Error(0, "Could not delete activities for owner %d: %v", ownerID, err)
+		return
+	}
+	return
+}
+
+// subtreeScopesCondition returns a where condition matching rows with an owner_id, path and old_path within any of the scopes
+func subtreeScopesCondition(scopes []*models.SubtreeScope) (condition string, args []interface{}) {
+	// '!' is used as escape character as the backslash would need different escaping per database
+	escaper := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+	var conditions []string
+	for _, scope := range scopes {
+		below := escaper.Replace(strings.TrimSuffix(scope.Path, "/")) + "/%"
+		conditions = append(conditions, "(owner_id = ? and (path = ? or path like ? escape '!' or old_path = ? or old_path like ? escape '!'))")
+		args = append(args, scope.OwnerID, scope.Path, below, scope.Path, below)
+	}
+	condition = strings.Join(conditions, " or ")
+	return
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testActivitySetupFailed = false
+var testActivityDBName = "activityTest.db"
+
+func testActivityCleanup() {
+	os.Remove(testActivityDBName)
+}
+
+func testActivitySetup() *ActivityRepository {
+	testActivityCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testActivityDBName)
+	rep, _ := CreateActivityRepository()
+	return rep
+}
+
+func TestCreateActivityRepository(t *testing.T) {
+	testActivityCleanup()
+	defer testActivityCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testActivityDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateActivityRepository()
+	if err != nil {
+		t.Errorf("Failed to create activity repository: %v", err)
+	}
+
+	if t.Failed() {
+		testActivitySetupFailed = true
+	}
+}
+
+func TestActivityGetPage(t *testing.T) {
+	if testActivitySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testActivityCleanup()
+	rep := testActivitySetup()
+
+	activities := []*models.Activity{
+		{Action: "created", OwnerID: 1, Path: "/docs"},
+		{Action: "created", OwnerID: 1, Path: "/docs/a.txt"},
+		{Action: "created", OwnerID: 1, Path: "/docs_old/b.txt"},
+		{Action: "moved", OwnerID: 1, Path: "/c.txt", OldPath: "/docs/c.txt"},
+		{Action: "created", OwnerID: 2, Path: "/docs/d.txt"},
+	}
+	for i, activity := range activities {
+		err := rep.Create(activity)
+		if err != nil {
+			t.Fatalf("Failed to create activity: %v", err)
+		}
+		databaseConnection.Model(activity).Update("created", int64(100+i))
+	}
+
+	readBack, total, err := rep.GetPage([]*models.SubtreeScope{{OwnerID: 1, Path: "/docs"}}, 0, 0, 0, 10)
+	if err != nil || total != 3 || len(readBack) != 3 {
+		t.Fatalf("Expected 3 activities in folder of owner 1 but got: %v, %v, %v", readBack, total, err)
+	}
+	if readBack[0].Action != "moved" || readBack[2].Path != "/docs" {
+		t.Errorf("Expected newest activities first including moves out of the folder but got: %v, %v", readBack[0], readBack[2])
+	}
+
+	readBack, total, _ = rep.GetPage([]*models.SubtreeScope{{OwnerID: 1, Path: "/"}, {OwnerID: 2, Path: "/docs/d.txt"}}, 101, 104, 1, 2)
+	if total != 3 || len(readBack) != 2 || readBack[0].Path != "/docs_old/b.txt" || readBack[1].Path != "/docs/a.txt" {
+		t.Errorf("Expected second page of activities in time range of both scopes but got: %v, %v", readBack, total)
+	}
+
+	readBack, total, _ = rep.GetPage(nil, 0, 0, 0, 10)
+	if total != 0 || len(readBack) != 0 {
+		t.Errorf("Expected no activities without scopes but got: %v, %v", readBack, total)
+	}
+
+	err = rep.DeleteAllForOwner(1)
+	if err != nil {
+		t.Errorf("Failed to delete activities of owner: %v", err)
+	}
+	readBack, total, _ = rep.GetPage([]*models.SubtreeScope{{OwnerID: 1, Path: "/"}, {OwnerID: 2, Path: "/"}}, 0, 0, 0, 10)
+	if total != 1 || readBack[0].OwnerID != 2 {
+		t.Errorf("Expected only activities of other owner to remain but got: %v, %v", readBack, total)
+	}
+}
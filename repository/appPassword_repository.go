@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.AppPassword{})
+}
+
+// AppPasswordRepository represents the database for storing app passwords, their passwords are stored as hashes
+type AppPasswordRepository struct{}
+
+// CreateAppPasswordRepository creates a new AppPasswordRepository IF gorm has been initialized before
+func CreateAppPasswordRepository() (*AppPasswordRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &AppPasswordRepository{}, nil
+}
+
+// Create stores a new app password
+func (rep *AppPasswordRepository) Create(appPassword *models.AppPassword) (err error) {
+	appPassword.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(appPassword).Error
+	if err != nil {
+		log.Error(0, "Could not create app password: %v", err)
+		return
+	}
+	return
+}
+
+// GetAllForUser returns all app passwords of the user, oldest first
+func (rep *AppPasswordRepository) GetAllForUser(userID int64) (appPasswords []*models.AppPassword, err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Order("id asc").Find(&appPasswords).Error
+	if err != nil {
+		log.Error(0, "Could not get app passwords of user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// GetForUserByPassword reads and returns the app password of the user with the given password hash
+func (rep *AppPasswordRepository) GetForUserByPassword(userID int64, passwordHash string) (appPassword *models.AppPassword, err error) {
+	appPassword = &models.AppPassword{}
+	err = databaseConnection.First(appPassword, "user_id = ? AND password = ?", userID, passwordHash).Error
+	return
+}
+
+// UpdateLastUsed sets the time the app password has been used last to now
+func (rep *AppPasswordRepository) UpdateLastUsed(appPasswordID int64) (err error) {
+	err = databaseConnection.Model(&models.AppPassword{ID: appPasswordID}).UpdateColumn("last_used", utils.GetTimestampNow()).Error
+	if err != nil {
+		log.Error(0, "Could not update last usage of app password %d: %v", appPasswordID, err)
+		return
+	}
+	return
+}
+
+// Delete deletes an app password by its ID if it belongs to the user
+func (rep *AppPasswordRepository) Delete(appPasswordID, userID int64) (err error) {
+	db := databaseConnection.Where("id = ? AND user_id = ?", appPasswordID, userID).Delete(&models.AppPassword{})
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not delete app password %d of user %d: %v", appPasswordID, userID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// DeleteAllForUser deletes all app passwords of the user
+func (rep *AppPasswordRepository) DeleteAllForUser(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.AppPassword{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete app passwords of user %d: %v", userID, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testAppPasswordSetupFailed = false
+var testAppPasswordDBName = "appPasswordTest.db"
+var testAppPassword0 = &models.AppPassword{UserID: 1, Name: "laptop", Password: "hash0"}
+var testAppPassword1 = &models.AppPassword{UserID: 1, Name: "phone", Password: "hash1"}
+var testAppPassword2 = &models.AppPassword{UserID: 2, Name: "laptop", Password: "hash2"}
+
+func testAppPasswordCleanup() {
+	os.Remove(testAppPasswordDBName)
+}
+
+func testAppPasswordSetup() *AppPasswordRepository {
+	testAppPasswordCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testAppPasswordDBName)
+	rep, _ := CreateAppPasswordRepository()
+	return rep
+}
+
+func testAppPasswordInsert(rep *AppPasswordRepository) {
+	rep.Create(testAppPassword0)
+	rep.Create(testAppPassword1)
+	rep.Create(testAppPassword2)
+}
+
+func TestCreateAppPasswordRepository(t *testing.T) {
+	testAppPasswordCleanup()
+	defer testAppPasswordCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testAppPasswordDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateAppPasswordRepository()
+	if err != nil {
+		t.Errorf("Failed to create app password repository: %v", err)
+	}
+
+	if t.Failed() {
+		testAppPasswordSetupFailed = true
+	}
+}
+
+func TestCreateAndGetAppPasswords(t *testing.T) {
+	if testAppPasswordSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAppPasswordCleanup()
+	rep := testAppPasswordSetup()
+
+	testAppPasswordInsert(rep)
+
+	appPasswords, err := rep.GetAllForUser(1)
+	if err != nil {
+		t.Fatalf("Failed to get app passwords of user 1: %v", err)
+	}
+	if len(appPasswords) != 2 {
+		t.Fatalf("Expected 2 app passwords of user 1, got %d", len(appPasswords))
+	}
+	if !reflect.DeepEqual(appPasswords[0], testAppPassword0) {
+		t.Errorf("Read back app password0 and app password0 not deeply equal: %v != %v", appPasswords[0], testAppPassword0)
+	}
+
+	readBackPassword, err := rep.GetForUserByPassword(1, testAppPassword1.Password)
+	if err != nil {
+		t.Errorf("Failed to get app password1 by its password: %v", err)
+	} else if readBackPassword.ID != testAppPassword1.ID {
+		t.Errorf("Expected app password1 for its password, got %v", readBackPassword)
+	}
+	_, err = rep.GetForUserByPassword(2, testAppPassword1.Password)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for password of another user, got %v", err)
+	}
+
+	err = rep.UpdateLastUsed(testAppPassword1.ID)
+	if err != nil {
+		t.Errorf("Failed to update last usage of app password1: %v", err)
+	}
+	appPasswords, _ = rep.GetAllForUser(1)
+	if len(appPasswords) != 2 || appPasswords[1].LastUsed == 0 {
+		t.Errorf("Expected last usage of app password1 to be set: %v", appPasswords)
+	}
+}
+
+func TestDeleteAppPassword(t *testing.T) {
+	if testAppPasswordSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAppPasswordCleanup()
+	rep := testAppPasswordSetup()
+
+	testAppPasswordInsert(rep)
+
+	err := rep.Delete(testAppPassword2.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting app password of another user, got %v", err)
+	}
+
+	err = rep.Delete(testAppPassword0.ID, 1)
+	if err != nil {
+		t.Errorf("Failed to delete app password0: %v", err)
+	}
+	err = rep.Delete(testAppPassword0.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting app password twice, got %v", err)
+	}
+
+	err = rep.DeleteAllForUser(1)
+	if err != nil {
+		t.Errorf("Failed to delete app passwords of user 1: %v", err)
+	}
+	appPasswords, _ := rep.GetAllForUser(1)
+	if len(appPasswords) != 0 {
+		t.Errorf("Expected no app passwords of user 1 after deleting all, got %d", len(appPasswords))
+	}
+	appPasswords, _ = rep.GetAllForUser(2)
+	if len(appPasswords) != 1 {
+		t.Errorf("Expected app password of user 2 to be kept, got %d", len(appPasswords))
+	}
+}
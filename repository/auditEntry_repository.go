@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"strings"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.AuditEntry{})
+}
+
+// AuditEntryRepository represents the database for storing the audit log
+type AuditEntryRepository struct{}
+
+// CreateAuditEntryRepository creates a new AuditEntryRepository IF gorm has been initialized before
+func CreateAuditEntryRepository() (*AuditEntryRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &AuditEntryRepository{}, nil
+}
+
+// Create stores a new audit entry
+func (rep *AuditEntryRepository) Create(auditEntry *models.AuditEntry) (err error) {
+	auditEntry.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(auditEntry).Error
+	if err != nil {
+		log.Error(0, "Could not create audit entry: %v", err)
+		return
+	}
+	return
+}
+
+// GetForTargetUser returns all audit entries concerning a user in the order they were recorded
+func (rep *AuditEntryRepository) GetForTargetUser(userID int64) (auditEntries []*models.AuditEntry, err error) {
+	err = databaseConnection.Where("target_user_id = ?", userID).Order("id").Find(&auditEntries).Error
+	if err != nil {
+		log.Error(0, "Could not get audit entries for user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// GetPage returns the matching audit entries, newest first, together with the total count of matching entries
+func (rep *AuditEntryRepository) GetPage(filter *models.AuditFilter, offset, limit int64) (auditEntries []*models.AuditEntry, total int64, err error) {
+	query := rep.filterQuery(filter)
+
+	err = query.Count(&total).Error
+	if err != nil {
+		log.Error(0, "Could not count audit entries for %v: %v", filter, err)
+		return
+	}
+
+	err = query.Order("id desc").Offset(offset).Limit(limit).Find(&auditEntries).Error
+	if err != nil {
+		log.Error(0, "Could not get audit entries for %v: %v", filter, err)
+		return
+	}
+	return
+}
+
+// GetBatchAfter returns up to limit matching audit entries recorded after the one with the given ID in the order they were recorded
+func (rep *AuditEntryRepository) GetBatchAfter(filter *models.AuditFilter, afterID, limit int64) (auditEntries []*models.AuditEntry, err error) {
+	err = rep.filterQuery(filter).Where("id > ?", afterID).Order("id").Limit(limit).Find(&auditEntries).Error
+	if err != nil {
+		log.Error(0, "Could not get audit entries for %v after %d: %v", filter, afterID, err)
+		return
+	}
+	return
+}
+
+// DeleteOlderThan deletes all audit entries recorded before the given timestamp
+func (rep *AuditEntryRepository) DeleteOlderThan(timestamp int64) (err error) {
+	err = databaseConnection.Where("created < ?", timestamp).Delete(&models.AuditEntry{}).Error
+	if err != nil {
+		log.Error(0, "Deleting old audit entries failed: %v", err)
+		return
+	}
+	return
+}
+
+func (rep *AuditEntryRepository) filterQuery(filter *models.AuditFilter) *gorm.DB {
+	query := databaseConnection.Model(&models.AuditEntry{})
+	if filter.Action != "" {
+		// '!' is used as escape character as the backslash would need different escaping per database
+		escaper := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+		query = query.Where("action = ? or action like ? escape '!'", filter.Action, escaper.Replace(filter.Action)+".%")
+	}
+	if filter.ActorID != 0 {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.TargetUserID != 0 {
+		query = query.Where("target_user_id = ?", filter.TargetUserID)
+	}
+	if filter.Since != 0 {
+		query = query.Where("created >= ?", filter.Since)
+	}
+	if filter.Until != 0 {
+		query = query.Where("created < ?", filter.Until)
+	}
+	return query
+}
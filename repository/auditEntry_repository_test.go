@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testAuditEntrySetupFailed = false
+var testAuditEntryDBName = "auditEntryTest.db"
+
+func testAuditEntryCleanup() {
+	os.Remove(testAuditEntryDBName)
+}
+
+func testAuditEntrySetup() *AuditEntryRepository {
+	testAuditEntryCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testAuditEntryDBName)
+	rep, _ := CreateAuditEntryRepository()
+	return rep
+}
+
+func TestCreateAuditEntryRepository(t *testing.T) {
+	testAuditEntryCleanup()
+	defer testAuditEntryCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testAuditEntryDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateAuditEntryRepository()
+	if err != nil {
+		t.Errorf("Failed to create audit entry repository: %v", err)
+	}
+
+	if t.Failed() {
+		testAuditEntrySetupFailed = true
+	}
+}
+
+func TestAuditEntryGetForTargetUser(t *testing.T) {
+	if testAuditEntrySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAuditEntryCleanup()
+	rep := testAuditEntrySetup()
+
+	auditEntries := []*models.AuditEntry{
+		{Action: "first", ActorID: 1, TargetUserID: 2},
+		{Action: "other", ActorID: 1, TargetUserID: 3},
+		{Action: "second", ActorID: 1, TargetUserID: 2, Target: "GET /file", IP: "192.0.2.1"},
+	}
+	for _, auditEntry := range auditEntries {
+		err := rep.Create(auditEntry)
+		if err != nil {
+			t.Fatalf("Failed to create audit entry: %v", err)
+		}
+	}
+
+	readBack, err := rep.GetForTargetUser(2)
+	if err != nil || len(readBack) != 2 {
+		t.Fatalf("Expected 2 audit entries for user 2 but got: %v, %v", readBack, err)
+	}
+	if readBack[0].Action != "first" || readBack[1].Action != "second" || readBack[1].Target != "GET /file" || readBack[1].Created == 0 {
+		t.Errorf("Expected audit entries of user 2 in recorded order but got: %v, %v", readBack[0], readBack[1])
+	}
+}
+
+func TestAuditEntryGetPage(t *testing.T) {
+	if testAuditEntrySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testAuditEntryCleanup()
+	rep := testAuditEntrySetup()
+
+	auditEntries := []*models.AuditEntry{
+		{Action: "auth.login", ActorID: 1, TargetUserID: 1},
+		{Action: "file.create", ActorID: 1, TargetUserID: 1, Target: "/a.txt"},
+		{Action: "file.delete", ActorID: 2, TargetUserID: 2, Target: "/b.txt"},
+		{Action: "file_x.create", ActorID: 2, TargetUserID: 2},
+		{Action: "file.move", ActorID: 1, TargetUserID: 1, Target: "/a.txt"},
+	}
+	for i, auditEntry := range auditEntries {
+		rep.Create(auditEntry)
+		databaseConnection.Model(auditEntry).Update("created", int64(100+i))
+	}
+
+	readBack, total, err := rep.GetPage(&models.AuditFilter{Action: "file"}, 0, 2)
+	if err != nil || total != 3 || len(readBack) != 2 {
+		t.Fatalf("Expected first 2 of 3 file entries but got: %v, %v, %v", readBack, total, err)
+	}
+	if readBack[0].Action != "file.move" || readBack[1].Action != "file.delete" {
+		t.Errorf("Expected newest entries first but got: %v, %v", readBack[0], readBack[1])
+	}
+
+	readBack, total, _ = rep.GetPage(&models.AuditFilter{Action: "file.create", ActorID: 1}, 0, 10)
+	if total != 1 || len(readBack) != 1 || readBack[0].Target != "/a.txt" {
+		t.Errorf("Expected the exact action of actor 1 but got: %v, %v", readBack, total)
+	}
+
+	readBack, total, _ = rep.GetPage(&models.AuditFilter{TargetUserID: 1, Since: 101, Until: 104}, 1, 10)
+	if total != 1 || len(readBack) != 0 {
+		t.Errorf("Expected empty page after the only entry in the time range but got: %v, %v", readBack, total)
+	}
+
+	readBack, err = rep.GetBatchAfter(&models.AuditFilter{}, auditEntries[1].ID, 2)
+	if err != nil || len(readBack) != 2 || readBack[0].ID != auditEntries[2].ID || readBack[1].ID != auditEntries[3].ID {
+		t.Errorf("Expected the 2 entries after the second in recorded order but got: %v, %v", readBack, err)
+	}
+
+	err = rep.DeleteOlderThan(103)
+	if err != nil {
+		t.Errorf("Failed to delete old audit entries: %v", err)
+	}
+	readBack, total, _ = rep.GetPage(&models.AuditFilter{}, 0, 10)
+	if total != 2 || readBack[1].Created != 103 {
+		t.Errorf("Expected only the 2 newest entries to be retained but got: %v, %v", readBack, total)
+	}
+}
@@ -0,0 +1,545 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"io"
+	"os"
+	"path"
+	"sync"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+const (
+	blobReferenceMagic = "FCB1"
+	// blobReferenceSize is the size of the magic, the SHA-256 hash and the size of the content and the HMAC-SHA256 of them of a reference
+	blobReferenceSize = int64(len(blobReferenceMagic) + sha256.Size + 8 + sha256.Size)
+)
+
+// blobReference is the content of a file which is stored as blob
+type blobReference struct {
+	hash string
+	size int64
+}
+
+// BlobStorageRepository stores the content of the files of users once by its SHA-256 hash in a blob folder of its base StorageRepository.
+// The files themselves only reference their blob, so copies and files with identical content take no extra space.
+// The references to every blob are counted in the database, blobs without references are deleted by CollectGarbage.
+// Files outside of user paths like avatars and files stored before the deduplication was enabled are passed through.
+type BlobStorageRepository struct {
+	base    StorageRepository
+	blobRep *BlobRepository
+	// referenceKey authenticates the references, so files of users with the same content are not mistaken for one
+	referenceKey []byte
+	blobPath     string
+	tmpName      string
+
+	// lock makes sure references are counted correctly while files are replaced, copied or deleted concurrently
+	lock sync.Mutex
+}
+
+// CreateBlobStorageRepository creates a new BlobStorageRepository storing the blobs in the folder blobFolder of the base and counting their references in blobRep.
+// The references are authenticated with referenceKey, files referencing blobs cannot be read anymore without it.
+// New content is written into the temp folder named tmpName in the blob folder, so the base cleans it up if it is never finished.
+func CreateBlobStorageRepository(base StorageRepository, blobRep *BlobRepository, referenceKey []byte, blobFolder, tmpName string) (*BlobStorageRepository, error) {
+	blobPath := path.Join("/", blobFolder)
+	if _, ok := storageUserID(blobPath); ok || blobPath == "/" || !utils.ValidatePath(blobPath) {
+		return nil, ErrForbiddenPathName
+	}
+	_, err := base.CreateDirectory(path.Join(blobPath, tmpName))
+	if err != nil {
+		return nil, err
+	}
+	return &BlobStorageRepository{
+		base:         base,
+		blobRep:      blobRep,
+		referenceKey: referenceKey,
+		blobPath:     blobPath,
+		tmpName:      tmpName,
+	}, nil
+}
+
+// Close closes the base
+func (rep *BlobStorageRepository) Close() error {
+	return rep.base.Close()
+}
+
+// CreateHandle creates the file at path, its content is written into the temp folder and stored as blob once the handle is closed
+func (rep *BlobStorageRepository) CreateHandle(filePath string) (StorageHandle, error) {
+	if _, ok := storageUserID(filePath); !ok {
+		return rep.base.CreateHandle(filePath)
+	}
+	tmpPath := path.Join(rep.blobPath, rep.tmpName, "blob_"+utils.RandomString(16))
+	handle, err := rep.base.CreateHandle(tmpPath)
+	if os.IsNotExist(err) {
+		// The temp folder is created again if it has been removed
+		if _, err = rep.base.CreateDirectory(path.Dir(tmpPath)); err == nil {
+			handle, err = rep.base.CreateHandle(tmpPath)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &blobStorageHandle{StorageHandle: handle, rep: rep, filePath: filePath, tmpPath: tmpPath}, nil
+}
+
+// OpenFile opens the blob referenced by the file at path for reading
+func (rep *BlobStorageRepository) OpenFile(filePath string) (StorageFile, error) {
+	file, err := rep.base.OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := storageUserID(filePath); !ok {
+		return file, nil
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	ref := rep.parseReference(file, info.Size())
+	if ref == nil {
+		return file, nil
+	}
+	file.Close()
+
+	blob, err := rep.base.OpenFile(rep.getBlobPath(ref.hash))
+	if err != nil {
+		log.Error(0, "Could not open blob %s of file %s: %v", ref.hash, filePath, err)
+		return nil, err
+	}
+	return &blobStorageFile{StorageFile: blob, info: &blobStorageFileInfo{FileInfo: info, size: ref.size}}, nil
+}
+
+// CreateDirectory creates the folder at path including its parents and returns whether it did not exist yet
+func (rep *BlobStorageRepository) CreateDirectory(filePath string) (created bool, err error) {
+	return rep.base.CreateDirectory(filePath)
+}
+
+// GetInfo returns the info of the file or folder at path below userPath with the size and hash of the referenced blob
+func (rep *BlobStorageRepository) GetInfo(userPath, filePath string) (*models.FileInfo, error) {
+	fileInfo, err := rep.base.GetInfo(userPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	rep.setBlobInfo(path.Join(userPath, filePath), fileInfo)
+	return fileInfo, nil
+}
+
+// GetDirectoryInfo returns the infos of all files and folders in the folder at path below userPath with the sizes and hashes of the referenced blobs
+func (rep *BlobStorageRepository) GetDirectoryInfo(userPath, filePath string) ([]*models.FileInfo, error) {
+	fileInfos, err := rep.base.GetDirectoryInfo(userPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileInfo := range fileInfos {
+		rep.setBlobInfo(path.Join(userPath, filePath, fileInfo.Name), fileInfo)
+	}
+	return fileInfos, nil
+}
+
+// setBlobInfo replaces the size of a file referencing a blob by the size of the blob and sets its hash
+func (rep *BlobStorageRepository) setBlobInfo(filePath string, fileInfo *models.FileInfo) {
+	if _, ok := storageUserID(filePath); !ok || fileInfo.IsDir || fileInfo.Size != blobReferenceSize {
+		return
+	}
+	ref, err := rep.readReference(filePath)
+	if err != nil {
+		log.Warn("Could not read blob reference of %s: %v", filePath, err)
+		return
+	}
+	if ref != nil {
+		fileInfo.Size = ref.size
+		fileInfo.BlobHash = ref.hash
+	}
+}
+
+// Move moves the file or folder at oldPath to newPath, the blobs of replaced files lose their references
+func (rep *BlobStorageRepository) Move(oldPath, newPath string) error {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	replaced, err := rep.collectReferences(newPath)
+	if err != nil {
+		return err
+	}
+	err = rep.base.Move(oldPath, newPath)
+	if err != nil {
+		return err
+	}
+	rep.releaseReferences(replaced)
+	return nil
+}
+
+// Copy copies the file at oldPath to newPath by referencing the same blob again, the blob of a replaced file loses its reference
+func (rep *BlobStorageRepository) Copy(oldPath, newPath string) error {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	replaced, err := rep.collectReferences(newPath)
+	if err != nil {
+		return err
+	}
+	var ref *blobReference
+	if _, ok := storageUserID(newPath); ok {
+		if ref, err = rep.readReference(oldPath); err != nil {
+			return err
+		}
+	}
+
+	if ref == nil {
+		err = rep.base.Copy(oldPath, newPath)
+	} else if err = rep.blobRep.AddReferences(ref.hash, 1); err == nil {
+		if err = rep.writeReference(newPath, ref); err != nil {
+			rep.releaseReferences([]string{ref.hash})
+		}
+	}
+	if err != nil {
+		return err
+	}
+	rep.releaseReferences(replaced)
+	return nil
+}
+
+// Delete deletes the file or folder at path with all its content, the blobs of deleted files lose their references
+func (rep *BlobStorageRepository) Delete(filePath string) error {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	deleted, err := rep.collectReferences(filePath)
+	if err != nil {
+		return err
+	}
+	err = rep.base.Delete(filePath)
+	if err != nil {
+		return err
+	}
+	rep.releaseReferences(deleted)
+	return nil
+}
+
+// AddToZip writes the file or content of the folder at path with the content of the referenced blobs into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *BlobStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) error {
+	if _, ok := storageUserID(filePath); !ok {
+		return rep.base.AddToZip(zipWriter, filePath, zipPath, skip)
+	}
+	return addOpenedToZip(rep.base, rep.OpenFile, zipWriter, filePath, zipPath, skip)
+}
+
+// CollectGarbage deletes all blobs which are not referenced by any file anymore and returns their count and the sum of their sizes.
+// Blobs which have been stored without being recorded, e.g. due to a crash, are deleted as well.
+func (rep *BlobStorageRepository) CollectGarbage() (count, size int64, err error) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	blobs, err := rep.blobRep.GetUnreferenced()
+	if err != nil {
+		return
+	}
+	for _, blob := range blobs {
+		err = rep.base.Delete(rep.getBlobPath(blob.Hash))
+		if err == nil {
+			err = rep.blobRep.Delete(blob.Hash)
+		}
+		if err != nil {
+			log.Error(0, "Could not delete unreferenced blob %s: %v", blob.Hash, err)
+			return
+		}
+		count++
+		size += blob.Size
+	}
+
+	err = rep.collectUnrecorded(rep.blobPath, &count, &size)
+	if err != nil {
+		log.Error(0, "Could not delete unrecorded blobs: %v", err)
+		return
+	}
+	return
+}
+
+// collectUnrecorded deletes the blobs in the folder at path and its subfolders which are not in the database
+func (rep *BlobStorageRepository) collectUnrecorded(folderPath string, count, size *int64) error {
+	fileInfos, err := rep.base.GetDirectoryInfo("", folderPath)
+	if err != nil {
+		return err
+	}
+	for _, fileInfo := range fileInfos {
+		filePath := path.Join(folderPath, fileInfo.Name)
+		if fileInfo.IsDir {
+			if filePath == path.Join(rep.blobPath, rep.tmpName) {
+				continue
+			}
+			if err = rep.collectUnrecorded(filePath, count, size); err != nil {
+				return err
+			}
+			continue
+		}
+
+		_, err = rep.blobRep.GetByHash(fileInfo.Name)
+		if err == nil {
+			continue
+		} else if !IsRecordNotFoundError(err) {
+			return err
+		}
+		if err = rep.base.Delete(filePath); err != nil {
+			return err
+		}
+		*count++
+		*size += fileInfo.Size
+	}
+	return nil
+}
+
+// storeBlob stores the content written to tmpPath as blob and replaces the file at path by a reference to it
+func (rep *BlobStorageRepository) storeBlob(tmpPath, filePath string) (err error) {
+	ref, err := rep.hashFile(tmpPath)
+	if err != nil {
+		rep.base.Delete(tmpPath)
+		return
+	}
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	replaced, err := rep.collectReferences(filePath)
+	if err == nil {
+		err = rep.addBlob(tmpPath, ref)
+	}
+	if err != nil {
+		rep.base.Delete(tmpPath)
+		return
+	}
+	err = rep.writeReference(filePath, ref)
+	if err != nil {
+		rep.releaseReferences([]string{ref.hash})
+		return
+	}
+	rep.releaseReferences(replaced)
+	return
+}
+
+// addBlob moves the content at tmpPath to the blob of its hash or references the existing blob with the same content
+func (rep *BlobStorageRepository) addBlob(tmpPath string, ref *blobReference) error {
+	blobPath := rep.getBlobPath(ref.hash)
+	_, err := rep.blobRep.GetByHash(ref.hash)
+	if err == nil {
+		// The content is stored again if the blob has been lost
+		if _, err = rep.base.GetInfo("", blobPath); err == ErrFileNotExist {
+			log.Warn("Blob %s is missing, storing it again", ref.hash)
+			err = rep.base.Move(tmpPath, blobPath)
+		} else {
+			rep.base.Delete(tmpPath)
+		}
+		if err != nil {
+			return err
+		}
+		return rep.blobRep.AddReferences(ref.hash, 1)
+	} else if !IsRecordNotFoundError(err) {
+		return err
+	}
+
+	_, err = rep.base.CreateDirectory(path.Dir(blobPath))
+	if err != nil {
+		return err
+	}
+	err = rep.base.Move(tmpPath, blobPath)
+	if err != nil {
+		return err
+	}
+	return rep.blobRep.Create(&models.Blob{Hash: ref.hash, Size: ref.size, RefCount: 1})
+}
+
+// hashFile returns the reference to the content of the file at path
+func (rep *BlobStorageRepository) hashFile(filePath string) (*blobReference, error) {
+	file, err := rep.base.OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, file)
+	if err != nil {
+		return nil, err
+	}
+	return &blobReference{hash: hex.EncodeToString(hash.Sum(nil)), size: size}, nil
+}
+
+// collectReferences returns the hashes of the blobs referenced by the file or the files in the folder at path
+func (rep *BlobStorageRepository) collectReferences(filePath string) (hashes []string, err error) {
+	if _, ok := storageUserID(filePath); !ok {
+		return
+	}
+	fileInfo, err := rep.base.GetInfo("", filePath)
+	if err == ErrFileNotExist {
+		return nil, nil
+	} else if err != nil {
+		return
+	}
+	err = rep.collectReferencesWalk(filePath, fileInfo, &hashes)
+	return
+}
+
+func (rep *BlobStorageRepository) collectReferencesWalk(filePath string, fileInfo *models.FileInfo, hashes *[]string) error {
+	if !fileInfo.IsDir {
+		if fileInfo.Size != blobReferenceSize {
+			return nil
+		}
+		ref, err := rep.readReference(filePath)
+		if err != nil {
+			return err
+		}
+		if ref != nil {
+			*hashes = append(*hashes, ref.hash)
+		}
+		return nil
+	}
+
+	fileInfos, err := rep.base.GetDirectoryInfo("", filePath)
+	if err != nil {
+		return err
+	}
+	for _, childInfo := range fileInfos {
+		err = rep.collectReferencesWalk(path.Join(filePath, childInfo.Name), childInfo, hashes)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseReferences removes a reference from the blobs of the hashes, failures are only logged as the garbage collection just misses the blobs then
+func (rep *BlobStorageRepository) releaseReferences(hashes []string) {
+	counts := make(map[string]int64)
+	for _, hash := range hashes {
+		counts[hash]++
+	}
+	for hash, count := range counts {
+		rep.blobRep.AddReferences(hash, -count)
+	}
+}
+
+// readReference returns the blob referenced by the file at path or nil if it is stored as it is
+func (rep *BlobStorageRepository) readReference(filePath string) (*blobReference, error) {
+	file, err := rep.base.OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return rep.parseReference(file, info.Size()), nil
+}
+
+func (rep *BlobStorageRepository) writeReference(filePath string, ref *blobReference) (err error) {
+	handle, err := rep.base.CreateHandle(filePath)
+	if err != nil {
+		return
+	}
+	_, err = handle.Write(rep.encodeReference(ref))
+	if closeErr := handle.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Error(0, "Could not write blob reference to %s: %v", filePath, err)
+		return
+	}
+	return
+}
+
+// getBlobPath returns the path of the blob with the hash, blobs are spread into subfolders by the start of their hash
+func (rep *BlobStorageRepository) getBlobPath(hash string) string {
+	return path.Join(rep.blobPath, hash[:2], hash[2:4], hash)
+}
+
+// parseReference returns the blob referenced by the content of file with the size or nil if it is no reference.
+// Content which only looks like a reference but is not authenticated by the reference key is no reference.
+func (rep *BlobStorageRepository) parseReference(file io.ReaderAt, size int64) *blobReference {
+	if size != blobReferenceSize {
+		return nil
+	}
+	content := make([]byte, blobReferenceSize)
+	if _, err := file.ReadAt(content, 0); err != nil && err != io.EOF {
+		return nil
+	}
+	if !bytes.HasPrefix(content, []byte(blobReferenceMagic)) {
+		return nil
+	}
+	macStart := len(content) - sha256.Size
+	if !hmac.Equal(content[macStart:], rep.referenceMAC(content[:macStart])) {
+		return nil
+	}
+	hash := content[len(blobReferenceMagic) : len(blobReferenceMagic)+sha256.Size]
+	return &blobReference{
+		hash: hex.EncodeToString(hash),
+		size: int64(binary.BigEndian.Uint64(content[len(blobReferenceMagic)+sha256.Size : macStart])),
+	}
+}
+
+// encodeReference returns the content of a file referencing the blob
+func (rep *BlobStorageRepository) encodeReference(ref *blobReference) []byte {
+	content := make([]byte, 0, blobReferenceSize)
+	content = append(content, blobReferenceMagic...)
+	hash, _ := hex.DecodeString(ref.hash)
+	content = append(content, hash...)
+	size := make([]byte, 8)
+	binary.BigEndian.PutUint64(size, uint64(ref.size))
+	content = append(content, size...)
+	return append(content, rep.referenceMAC(content)...)
+}
+
+// referenceMAC returns the HMAC-SHA256 of the magic, hash and size of a reference
+func (rep *BlobStorageRepository) referenceMAC(content []byte) []byte {
+	mac := hmac.New(sha256.New, rep.referenceKey)
+	mac.Write(content)
+	return mac.Sum(nil)
+}
+
+// blobStorageHandle writes the content of a file into the temp folder and stores it as blob when it is closed
+type blobStorageHandle struct {
+	StorageHandle
+	rep      *BlobStorageRepository
+	filePath string
+	tmpPath  string
+}
+
+func (h *blobStorageHandle) Close() error {
+	err := h.StorageHandle.Close()
+	if err != nil {
+		h.rep.base.Delete(h.tmpPath)
+		return err
+	}
+	return h.rep.storeBlob(h.tmpPath, h.filePath)
+}
+
+// blobStorageFile reads a blob with the info of the file referencing it
+type blobStorageFile struct {
+	StorageFile
+	info os.FileInfo
+}
+
+func (f *blobStorageFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+// blobStorageFileInfo describes a file referencing a blob with the size of the blob
+type blobStorageFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (i *blobStorageFileInfo) Size() int64 {
+	return i.size
+}
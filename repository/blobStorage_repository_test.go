@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var testBlobStorageSetupFailed = false
+var testBlobStorageDBName = "blobStorageTest.db"
+var testBlobStorageKey = []byte("blob storage test key")
+
+func testBlobStorageCleanup(rep *BlobStorageRepository) {
+	if rep != nil {
+		rep.Close()
+	}
+	os.Remove(testBlobStorageDBName)
+}
+
+func testBlobStorageSetup() (*BlobStorageRepository, *MemoryStorageRepository, *BlobRepository) {
+	testBlobStorageCleanup(nil)
+	InitDatabaseConnection("", "", "", "", 0, testBlobStorageDBName)
+	blobRep, _ := CreateBlobRepository()
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	base.CreateDirectory("/1/docs")
+	base.CreateDirectory("/2")
+	base.CreateDirectory("/avatars")
+	rep, _ := CreateBlobStorageRepository(base, blobRep, testBlobStorageKey, "blobs", ".tmp")
+	return rep, base, blobRep
+}
+
+// testBlobStorageCount returns the count of stored blobs with their sizes and the count of files in the blob folder
+func testBlobStorageCount(base StorageRepository, blobRep *BlobRepository) (count, size int64, files int) {
+	count, size, _ = blobRep.Count()
+	var walk func(folderPath string)
+	walk = func(folderPath string) {
+		fileInfos, _ := base.GetDirectoryInfo("", folderPath)
+		for _, fileInfo := range fileInfos {
+			if fileInfo.IsDir {
+				walk(folderPath + "/" + fileInfo.Name)
+			} else {
+				files++
+			}
+		}
+	}
+	walk("/blobs")
+	return
+}
+
+func TestCreateBlobStorageRepository(t *testing.T) {
+	testBlobStorageCleanup(nil)
+	defer testBlobStorageCleanup(nil)
+
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	for _, folder := range []string{"", "/", "12", "7/blobs"} {
+		_, err := CreateBlobStorageRepository(base, nil, testBlobStorageKey, folder, ".tmp")
+		if err != ErrForbiddenPathName {
+			t.Errorf("Error for blob folder %s unequal to ErrForbiddenPathName: %v", folder, err)
+		}
+	}
+	_, err := CreateBlobStorageRepository(base, nil, testBlobStorageKey, "blobs", ".tmp")
+	if err != nil {
+		t.Errorf("Failed to create blobStorageRepository: %v", err)
+	}
+
+	if t.Failed() {
+		testBlobStorageSetupFailed = true
+	}
+}
+
+func TestBlobStorageDeduplication(t *testing.T) {
+	if testBlobStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base, blobRep := testBlobStorageSetup()
+	defer testBlobStorageCleanup(rep)
+
+	content := string(bytes.Repeat([]byte("large artifact "), 1000))
+	if err := testMountStorageWrite(rep, "/1/docs/a.bin", content); err != nil {
+		t.Fatalf("Failed to write file: %v", err)
+	}
+	testMountStorageWrite(rep, "/2/b.bin", content)
+	if err := rep.Copy("/1/docs/a.bin", "/1/c.bin"); err != nil {
+		t.Fatalf("Failed to copy file: %v", err)
+	}
+
+	if count, size, files := testBlobStorageCount(base, blobRep); count != 1 || size != int64(len(content)) || files != 1 {
+		t.Errorf("Expected identical content to be stored once, got %d blobs with %d bytes in %d files", count, size, files)
+	}
+	if stored := testMountStorageRead(base, "/1/docs/a.bin"); int64(len(stored)) != blobReferenceSize {
+		t.Errorf("Expected file to only store a reference but it has %d bytes", len(stored))
+	}
+	for _, filePath := range []string{"/1/docs/a.bin", "/2/b.bin", "/1/c.bin"} {
+		if read := testMountStorageRead(rep, filePath); read != content {
+			t.Errorf("Content of %s unequal to written content of %d bytes: %d bytes", filePath, len(content), len(read))
+		}
+	}
+
+	fileInfo, err := rep.GetInfo("/1", "/docs/a.bin")
+	if err != nil || fileInfo.Size != int64(len(content)) || len(fileInfo.BlobHash) != 64 {
+		t.Errorf("Info of file does not have the size and hash of its blob: %v, %v", fileInfo, err)
+	}
+	dirInfo, err := rep.GetDirectoryInfo("/2", "/")
+	if err != nil || len(dirInfo) != 1 || dirInfo[0].BlobHash != fileInfo.BlobHash {
+		t.Errorf("Directory info does not have the hash of the blob: %v, %v", dirInfo, err)
+	}
+	file, _ := rep.OpenFile("/1/c.bin")
+	if stat, _ := file.Stat(); stat.Size() != int64(len(content)) || stat.Name() != "c.bin" {
+		t.Errorf("Stat of file does not have its name and the size of its blob: %s, %d", stat.Name(), stat.Size())
+	}
+	file.Close()
+
+	testMountStorageWrite(rep, "/avatars/1_64.png", "avatar")
+	if stored := testMountStorageRead(base, "/avatars/1_64.png"); stored != "avatar" {
+		t.Errorf("File outside of user paths has been stored as blob: %s", stored)
+	}
+}
+
+func TestBlobStorageReferences(t *testing.T) {
+	if testBlobStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base, blobRep := testBlobStorageSetup()
+	defer testBlobStorageCleanup(rep)
+
+	testMountStorageWrite(rep, "/1/docs/a.txt", "a")
+	testMountStorageWrite(rep, "/1/docs/b.txt", "a")
+	fileInfo, _ := rep.GetInfo("/1", "/docs/a.txt")
+	hashA := fileInfo.BlobHash
+
+	// Replacing content releases the reference to the old blob
+	testMountStorageWrite(rep, "/1/docs/b.txt", "b")
+	if blob, _ := blobRep.GetByHash(hashA); blob.RefCount != 1 {
+		t.Errorf("Expected blob to have one reference after replacing a file, got %d", blob.RefCount)
+	}
+
+	// Moving over a file releases the reference of the replaced file
+	testMountStorageWrite(rep, "/2/c.txt", "c")
+	if err := rep.Move("/1/docs/b.txt", "/2/c.txt"); err != nil {
+		t.Fatalf("Failed to move file: %v", err)
+	}
+	if read := testMountStorageRead(rep, "/2/c.txt"); read != "b" {
+		t.Errorf("Content of moved file unequal to expected content: %s", read)
+	}
+
+	if err := rep.Delete("/1/docs"); err != nil {
+		t.Fatalf("Failed to delete folder: %v", err)
+	}
+	if blob, _ := blobRep.GetByHash(hashA); blob.RefCount != 0 {
+		t.Errorf("Expected blob to have no references after deleting its files, got %d", blob.RefCount)
+	}
+	unreferenced, _ := blobRep.GetUnreferenced()
+	if len(unreferenced) != 2 {
+		t.Errorf("Expected the blobs of the deleted and replaced file to be unreferenced, got %v", unreferenced)
+	}
+
+	// A blob which has been stored without being recorded is collected as well
+	base.CreateDirectory("/blobs/ff/ff")
+	testMountStorageWrite(base, "/blobs/ff/ff/ffff", "lost")
+	count, size, err := rep.CollectGarbage()
+	if err != nil || count != 3 || size != 6 {
+		t.Errorf("Expected 3 blobs with 6 bytes to be collected, got %d, %d, %v", count, size, err)
+	}
+	if count, _, files := testBlobStorageCount(base, blobRep); count != 1 || files != 1 {
+		t.Errorf("Expected only the blob of the moved file to be left, got %d blobs in %d files", count, files)
+	}
+	if read := testMountStorageRead(rep, "/2/c.txt"); read != "b" {
+		t.Errorf("Content of referenced blob changed by garbage collection: %s", read)
+	}
+
+	// Content is stored again if it is written after its blob has been collected
+	testMountStorageWrite(rep, "/1/d.txt", "a")
+	if read := testMountStorageRead(rep, "/1/d.txt"); read != "a" {
+		t.Errorf("Content of file written after collecting its blob unequal to expected content: %s", read)
+	}
+}
+
+func TestBlobStorageLegacyFilesAndZip(t *testing.T) {
+	if testBlobStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base, _ := testBlobStorageSetup()
+	defer testBlobStorageCleanup(rep)
+
+	testMountStorageWrite(base, "/1/docs/legacy.txt", "legacy")
+	testMountStorageWrite(rep, "/1/docs/a.txt", "a")
+	if read := testMountStorageRead(rep, "/1/docs/legacy.txt"); read != "legacy" {
+		t.Errorf("Content of file stored before deduplication unequal to expected content: %s", read)
+	}
+	if err := rep.Copy("/1/docs/legacy.txt", "/1/legacy.txt"); err != nil {
+		t.Errorf("Failed to copy file stored before deduplication: %v", err)
+	}
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	err := rep.AddToZip(zipWriter, "/1", "files", nil)
+	if err != nil {
+		t.Fatalf("Failed to add folder to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, _ := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	contents := map[string]string{}
+	for _, zipFile := range zipReader.File {
+		file, _ := zipFile.Open()
+		content, _ := ioutil.ReadAll(file)
+		file.Close()
+		contents[zipFile.Name] = string(content)
+	}
+	expContents := map[string]string{"files/docs/a.txt": "a", "files/docs/legacy.txt": "legacy", "files/legacy.txt": "legacy"}
+	if !reflect.DeepEqual(contents, expContents) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", contents, expContents)
+	}
+}
+
+func TestBlobStorageForgedReference(t *testing.T) {
+	if testBlobStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base, blobRep := testBlobStorageSetup()
+	defer testBlobStorageCleanup(rep)
+
+	testMountStorageWrite(rep, "/2/secret.txt", "secret")
+	fileInfo, _ := rep.GetInfo("/2", "/secret.txt")
+	ref := &blobReference{hash: fileInfo.BlobHash, size: fileInfo.Size}
+
+	// A file stored before the deduplication was enabled can look like a reference, but only the key authenticates it
+	forged := string((&BlobStorageRepository{referenceKey: []byte("other key")}).encodeReference(ref))
+	testMountStorageWrite(base, "/1/docs/forged.bin", forged)
+	if read := testMountStorageRead(rep, "/1/docs/forged.bin"); read != forged {
+		t.Errorf("Expected forged reference to be read as it is but got: %q", read)
+	}
+	if forgedInfo, _ := rep.GetInfo("/1", "/docs/forged.bin"); forgedInfo.BlobHash != "" || forgedInfo.Size != blobReferenceSize {
+		t.Errorf("Expected forged reference not to have the info of the blob: %v", forgedInfo)
+	}
+	rep.Copy("/1/docs/forged.bin", "/1/copy.bin")
+	if read := testMountStorageRead(rep, "/1/copy.bin"); read != forged {
+		t.Errorf("Expected copy of forged reference to be read as it is but got: %q", read)
+	}
+	if blob, _ := blobRep.GetByHash(ref.hash); blob.RefCount != 1 {
+		t.Errorf("Expected forged reference not to reference the blob, got %d references", blob.RefCount)
+	}
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.Blob{})
+}
+
+// BlobRepository represents the database for storing the blobs of deduplicated content with the count of their references
+type BlobRepository struct{}
+
+// CreateBlobRepository creates a new BlobRepository IF gorm has been initialized before
+func CreateBlobRepository() (*BlobRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &BlobRepository{}, nil
+}
+
+// Create stores a new blob
+func (rep *BlobRepository) Create(blob *models.Blob) (err error) {
+	blob.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(blob).Error
+	if err != nil {
+		log.Error(0, "Could not create blob %s: %v", blob.Hash, err)
+		return
+	}
+	return
+}
+
+// GetByHash reads and returns the blob with the hash
+func (rep *BlobRepository) GetByHash(hash string) (blob *models.Blob, err error) {
+	blob = &models.Blob{}
+	err = databaseConnection.First(blob, "hash = ?", hash).Error
+	return
+}
+
+// GetUnreferenced reads and returns all blobs which are not referenced by any file
+func (rep *BlobRepository) GetUnreferenced() (blobs []*models.Blob, err error) {
+	err = databaseConnection.Where("ref_count <= 0").Find(&blobs).Error
+	if err != nil {
+		log.Error(0, "Could not get unreferenced blobs: %v", err)
+		return
+	}
+	return
+}
+
+// AddReferences changes the count of references of the blob by delta in one statement
+func (rep *BlobRepository) AddReferences(hash string, delta int64) (err error) {
+	err = databaseConnection.Model(&models.Blob{}).Where("hash = ?", hash).UpdateColumn("ref_count", gorm.Expr("ref_count + ?", delta)).Error
+	if err != nil {
+		log.Error(0, "Could not change references of blob %s: %v", hash, err)
+		return
+	}
+	return
+}
+
+// Delete deletes the blob
+func (rep *BlobRepository) Delete(hash string) (err error) {
+	err = databaseConnection.Where("hash = ?", hash).Delete(&models.Blob{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete blob %s: %v", hash, err)
+		return
+	}
+	return
+}
+
+// Count returns the count of stored blobs and the sum of their sizes
+func (rep *BlobRepository) Count() (count, size int64, err error) {
+	row := databaseConnection.Model(&models.Blob{}).Select("count(*), coalesce(sum(size), 0)").Row()
+	err = row.Scan(&count, &size)
+	if err != nil {
+		log.Error(0, "Could not count blobs: %v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testBlobSetupFailed = false
+var testBlobDBName = "blobTest.db"
+
+func testBlobCleanup() {
+	os.Remove(testBlobDBName)
+}
+
+func testBlobSetup() *BlobRepository {
+	testBlobCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testBlobDBName)
+	rep, _ := CreateBlobRepository()
+	return rep
+}
+
+func TestCreateBlobRepository(t *testing.T) {
+	testBlobCleanup()
+	defer testBlobCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testBlobDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateBlobRepository()
+	if err != nil {
+		t.Errorf("Failed to create blob repository: %v", err)
+	}
+
+	if t.Failed() {
+		testBlobSetupFailed = true
+	}
+}
+
+func TestBlobReferences(t *testing.T) {
+	if testBlobSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testBlobCleanup()
+	rep := testBlobSetup()
+
+	err := rep.Create(&models.Blob{Hash: "aa", Size: 10, RefCount: 1})
+	if err != nil {
+		t.Fatalf("Failed to create blob: %v", err)
+	}
+	rep.Create(&models.Blob{Hash: "bb", Size: 5, RefCount: 1})
+
+	rep.AddReferences("aa", 2)
+	rep.AddReferences("bb", -1)
+	blob, err := rep.GetByHash("aa")
+	if err != nil || blob.RefCount != 3 || blob.Created == 0 {
+		t.Errorf("Expected blob with 3 references, got %v, %v", blob, err)
+	}
+
+	unreferenced, err := rep.GetUnreferenced()
+	if err != nil || len(unreferenced) != 1 || unreferenced[0].Hash != "bb" {
+		t.Errorf("Expected only blob bb to be unreferenced, got %v, %v", unreferenced, err)
+	}
+
+	count, size, err := rep.Count()
+	if err != nil || count != 2 || size != 15 {
+		t.Errorf("Expected 2 blobs with 15 bytes, got %d, %d, %v", count, size, err)
+	}
+
+	err = rep.Delete("bb")
+	if err != nil {
+		t.Errorf("Failed to delete blob: %v", err)
+	}
+	_, err = rep.GetByHash("bb")
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleted blob, got %v", err)
+	}
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Status of a data export, expired exports are finished ones whose file has been cleaned up
+const (
+	DataExportPending  = "pending"
+	DataExportRunning  = "running"
+	DataExportFinished = "finished"
+	DataExportFailed   = "failed"
+	DataExportExpired  = "expired"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.DataExport{})
+}
+
+// DataExportRepository represents the database for storing data export jobs
+type DataExportRepository struct{}
+
+// CreateDataExportRepository creates a new DataExportRepository IF gorm has been initialized before
+func CreateDataExportRepository() (*DataExportRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &DataExportRepository{}, nil
+}
+
+// Create stores a new data export
+func (rep *DataExportRepository) Create(dataExport *models.DataExport) (err error) {
+	dataExport.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(dataExport).Error
+	if err != nil {
+		log.Error(0, "Could not create data export: %v", err)
+		return
+	}
+	return
+}
+
+// Update updates a stored data export
+func (rep *DataExportRepository) Update(dataExport *models.DataExport) (err error) {
+	err = databaseConnection.Save(dataExport).Error
+	if err != nil {
+		log.Error(0, "Could not update data export %d: %v", dataExport.ID, err)
+		return
+	}
+	return
+}
+
+// GetByIDForUser reads and returns a data export by its ID if it belongs to the user
+func (rep *DataExportRepository) GetByIDForUser(dataExportID, userID int64) (dataExport *models.DataExport, err error) {
+	dataExport = &models.DataExport{}
+	err = databaseConnection.First(dataExport, "id = ? AND user_id = ?", dataExportID, userID).Error
+	return
+}
+
+// GetUnfinishedForUser returns the pending or running data export of the user
+func (rep *DataExportRepository) GetUnfinishedForUser(userID int64) (dataExport *models.DataExport, err error) {
+	dataExport = &models.DataExport{}
+	err = databaseConnection.First(dataExport, "user_id = ? AND status IN (?)", userID, []string{DataExportPending, DataExportRunning}).Error
+	return
+}
+
+// FailUnfinished marks all pending or running data exports as failed, they cannot finish after a restart
+func (rep *DataExportRepository) FailUnfinished() (err error) {
+	err = databaseConnection.Model(&models.DataExport{}).
+		Where("status IN (?)", []string{DataExportPending, DataExportRunning}).
+		Updates(map[string]interface{}{"status": DataExportFailed, "finished": utils.GetTimestampNow()}).Error
+	if err != nil {
+		log.Error(0, "Could not fail unfinished data exports: %v", err)
+		return
+	}
+	return
+}
+
+// DeleteAllForUser deletes all data exports of the user
+func (rep *DataExportRepository) DeleteAllForUser(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.DataExport{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete data exports of user %d: %v", userID, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testDataExportSetupFailed = false
+var testDataExportDBName = "dataExportTest.db"
+
+func testDataExportCleanup() {
+	os.Remove(testDataExportDBName)
+}
+
+func testDataExportSetup() *DataExportRepository {
+	testDataExportCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testDataExportDBName)
+	rep, _ := CreateDataExportRepository()
+	return rep
+}
+
+func TestCreateDataExportRepository(t *testing.T) {
+	testDataExportCleanup()
+	defer testDataExportCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testDataExportDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateDataExportRepository()
+	if err != nil {
+		t.Errorf("Failed to create data export repository: %v", err)
+	}
+
+	if t.Failed() {
+		testDataExportSetupFailed = true
+	}
+}
+
+func TestDataExportGetByIDForUser(t *testing.T) {
+	if testDataExportSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testDataExportCleanup()
+	rep := testDataExportSetup()
+
+	dataExport := &models.DataExport{UserID: 1, Status: DataExportPending}
+	err := rep.Create(dataExport)
+	if err != nil {
+		t.Fatalf("Failed to create data export: %v", err)
+	}
+
+	readBack, err := rep.GetByIDForUser(dataExport.ID, 1)
+	if err != nil || readBack.Status != DataExportPending || readBack.Created == 0 {
+		t.Errorf("Failed to read back data export: %v, %v", readBack, err)
+	}
+	_, err = rep.GetByIDForUser(dataExport.ID, 2)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected data export of other user to not be found but got: %v", err)
+	}
+}
+
+func TestDataExportUnfinished(t *testing.T) {
+	if testDataExportSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testDataExportCleanup()
+	rep := testDataExportSetup()
+
+	dataExports := []*models.DataExport{
+		{UserID: 1, Status: DataExportFinished},
+		{UserID: 1, Status: DataExportRunning},
+		{UserID: 2, Status: DataExportPending},
+		{UserID: 3, Status: DataExportFailed},
+	}
+	for _, dataExport := range dataExports {
+		rep.Create(dataExport)
+	}
+
+	unfinished, err := rep.GetUnfinishedForUser(1)
+	if err != nil || unfinished.ID != dataExports[1].ID {
+		t.Errorf("Expected running data export of user 1 as unfinished but got: %v, %v", unfinished, err)
+	}
+	_, err = rep.GetUnfinishedForUser(3)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected no unfinished data export for user 3 but got: %v", err)
+	}
+
+	err = rep.FailUnfinished()
+	if err != nil {
+		t.Fatalf("Failed to fail unfinished data exports: %v", err)
+	}
+	expStatus := []string{DataExportFinished, DataExportFailed, DataExportFailed, DataExportFailed}
+	for it, dataExport := range dataExports {
+		readBack, _ := rep.GetByIDForUser(dataExport.ID, dataExport.UserID)
+		if readBack.Status != expStatus[it] {
+			t.Errorf("Expected status %s of data export %d but got %s", expStatus[it], it, readBack.Status)
+		}
+	}
+
+	err = rep.DeleteAllForUser(1)
+	if err != nil {
+		t.Errorf("Failed to delete data exports of user 1: %v", err)
+	}
+	_, err = rep.GetByIDForUser(dataExports[0].ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected data export of deleted user to not be found but got: %v", err)
+	}
+}
@@ -0,0 +1,659 @@
+package repository
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"sync"
+
+	"github.com/freecloudio/server/crypt"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+var (
+	// ErrMissingUserKey is returned when reading an encrypted file of a user without key
+	ErrMissingUserKey = errors.New("key of the user for decrypting his files is missing")
+	// ErrEncryptedRewrite is returned when writing into a part of a new encrypted file that has been encrypted already
+	ErrEncryptedRewrite = errors.New("encrypted parts of files cannot be written again")
+)
+
+// EncryptedStorageRepository encrypts the content of the files of users before storing them in its base StorageRepository.
+// Every file gets its own data key which is stored in its header wrapped by the key of its user, which is wrapped by the master key.
+// Files outside of user paths like avatars and files stored before the encryption was enabled are passed through unencrypted.
+type EncryptedStorageRepository struct {
+	base       StorageRepository
+	userKeyRep *UserKeyRepository
+	masterKey  []byte
+	tmpName    string
+
+	lock sync.Mutex
+	// userKeys are the unwrapped keys of the users by their ID
+	userKeys map[int64][]byte
+}
+
+// CreateEncryptedStorageRepository creates a new EncryptedStorageRepository storing the keys of users wrapped by the master key in userKeyRep.
+// Existing files are encrypted in place by EncryptExisting, which uses the temp folders of the users named tmpName.
+func CreateEncryptedStorageRepository(base StorageRepository, userKeyRep *UserKeyRepository, masterKey []byte, tmpName string) (*EncryptedStorageRepository, error) {
+	if len(masterKey) != crypt.EncryptionKeySize {
+		return nil, crypt.ErrInvalidKey
+	}
+	return &EncryptedStorageRepository{
+		base:       base,
+		userKeyRep: userKeyRep,
+		masterKey:  masterKey,
+		tmpName:    tmpName,
+		userKeys:   make(map[int64][]byte),
+	}, nil
+}
+
+// Close closes the base
+func (rep *EncryptedStorageRepository) Close() error {
+	return rep.base.Close()
+}
+
+// CreateHandle creates the file at path whose content is encrypted while it is written
+func (rep *EncryptedStorageRepository) CreateHandle(filePath string) (StorageHandle, error) {
+	userID, ok := storageUserID(filePath)
+	if !ok {
+		return rep.base.CreateHandle(filePath)
+	}
+	userKey, err := rep.getUserKey(userID, true)
+	if err != nil {
+		return nil, err
+	}
+	header, dataKey, err := crypt.NewEncryptionHeader(userKey)
+	if err != nil {
+		return nil, err
+	}
+	chunkCipher, err := crypt.NewChunkCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	handle, err := rep.base.CreateHandle(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = handle.WriteAt(header, 0); err != nil {
+		handle.Close()
+		return nil, err
+	}
+	return &encryptedStorageHandle{
+		base:      handle,
+		cipher:    chunkCipher,
+		chunks:    make(map[int64]*encryptedStorageChunk),
+		sealed:    make(map[int64]bool),
+		lastIndex: -1,
+	}, nil
+}
+
+// OpenFile opens the file at path for reading its decrypted content
+func (rep *EncryptedStorageRepository) OpenFile(filePath string) (StorageFile, error) {
+	file, err := rep.base.OpenFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	userID, ok := storageUserID(filePath)
+	if !ok {
+		return file, nil
+	}
+
+	header := make([]byte, crypt.EncryptionHeaderSize)
+	n, _ := file.ReadAt(header, 0)
+	if !crypt.IsEncrypted(header[:n]) {
+		return file, nil
+	}
+
+	encryptedFile, err := rep.openEncryptedFile(file, userID, header[:n])
+	if err != nil {
+		file.Close()
+		log.Error(0, "Could not decrypt file %s: %v", filePath, err)
+		return nil, err
+	}
+	return encryptedFile, nil
+}
+
+func (rep *EncryptedStorageRepository) openEncryptedFile(file StorageFile, userID int64, header []byte) (*encryptedStorageFile, error) {
+	userKey, err := rep.getUserKey(userID, false)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := crypt.ParseEncryptionHeader(userKey, header)
+	if err != nil {
+		return nil, err
+	}
+	chunkCipher, err := crypt.NewChunkCipher(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	size, err := crypt.PlaintextSize(info.Size())
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedStorageFile{base: file, cipher: chunkCipher, size: size, chunkIndex: -1}, nil
+}
+
+// CreateDirectory creates the folder at path including its parents and returns whether it did not exist yet
+func (rep *EncryptedStorageRepository) CreateDirectory(filePath string) (created bool, err error) {
+	return rep.base.CreateDirectory(filePath)
+}
+
+// GetInfo returns the info of the file or folder at path below userPath with the size of the decrypted content
+func (rep *EncryptedStorageRepository) GetInfo(userPath, filePath string) (*models.FileInfo, error) {
+	fileInfo, err := rep.base.GetInfo(userPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	rep.setPlaintextSize(path.Join(userPath, filePath), fileInfo)
+	return fileInfo, nil
+}
+
+// GetDirectoryInfo returns the infos of all files and folders in the folder at path below userPath with the sizes of their decrypted content
+func (rep *EncryptedStorageRepository) GetDirectoryInfo(userPath, filePath string) ([]*models.FileInfo, error) {
+	fileInfos, err := rep.base.GetDirectoryInfo(userPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	for _, fileInfo := range fileInfos {
+		rep.setPlaintextSize(path.Join(userPath, filePath, fileInfo.Name), fileInfo)
+	}
+	return fileInfos, nil
+}
+
+// setPlaintextSize replaces the size of an encrypted file by the size of its decrypted content.
+// This needs to read its header as files stored before the encryption was enabled are not encrypted.
+func (rep *EncryptedStorageRepository) setPlaintextSize(filePath string, fileInfo *models.FileInfo) {
+	if _, ok := storageUserID(filePath); !ok || fileInfo.IsDir {
+		return
+	}
+	encrypted, err := rep.isEncrypted(filePath)
+	if err == nil && encrypted {
+		fileInfo.Size, err = crypt.PlaintextSize(fileInfo.Size)
+	}
+	if err != nil {
+		log.Warn("Could not get size of decrypted content of %s: %v", filePath, err)
+	}
+}
+
+func (rep *EncryptedStorageRepository) isEncrypted(filePath string) (bool, error) {
+	file, err := rep.base.OpenFile(filePath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	header := make([]byte, crypt.EncryptionHeaderSize)
+	n, _ := file.ReadAt(header, 0)
+	return crypt.IsEncrypted(header[:n]), nil
+}
+
+// Move moves the file or folder at oldPath to newPath, it is encrypted again if it is moved to another user.
+// Content moved out of the folders of users is kept as it is stored, so it is not stored in plain and damaged content can be moved as well.
+func (rep *EncryptedStorageRepository) Move(oldPath, newPath string) (err error) {
+	if _, ok := storageUserID(newPath); !ok || isSameEncryptedStorageUser(oldPath, newPath) {
+		return rep.base.Move(oldPath, newPath)
+	}
+	err = rep.transfer(oldPath, newPath)
+	if err != nil {
+		return
+	}
+	return rep.base.Delete(oldPath)
+}
+
+// Copy copies the file at oldPath to newPath, it is encrypted again if it is copied to another user
+func (rep *EncryptedStorageRepository) Copy(oldPath, newPath string) error {
+	if isSameEncryptedStorageUser(oldPath, newPath) {
+		return rep.base.Copy(oldPath, newPath)
+	}
+	return rep.transfer(oldPath, newPath)
+}
+
+// transfer copies the file or folder at oldPath to newPath by decrypting and encrypting it again
+func (rep *EncryptedStorageRepository) transfer(oldPath, newPath string) (err error) {
+	fileInfo, err := rep.base.GetInfo("", oldPath)
+	if err != nil {
+		return
+	}
+	if !fileInfo.IsDir {
+		return rep.transferFile(oldPath, newPath)
+	}
+
+	_, err = rep.base.CreateDirectory(newPath)
+	if err != nil {
+		return
+	}
+	fileInfos, err := rep.base.GetDirectoryInfo("", oldPath)
+	if err != nil {
+		return
+	}
+	for _, fileInfo := range fileInfos {
+		err = rep.transfer(path.Join(oldPath, fileInfo.Name), path.Join(newPath, fileInfo.Name))
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (rep *EncryptedStorageRepository) transferFile(oldPath, newPath string) (err error) {
+	in, err := rep.OpenFile(oldPath)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+
+	out, err := rep.CreateHandle(newPath)
+	if err != nil {
+		return
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Error(0, "Could not transfer %v to %v: %v", oldPath, newPath, err)
+		return
+	}
+	return
+}
+
+// Delete deletes the file or folder at path with all its content
+func (rep *EncryptedStorageRepository) Delete(filePath string) error {
+	return rep.base.Delete(filePath)
+}
+
+// AddToZip writes the decrypted file or content of the folder at path into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *EncryptedStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) error {
+	if _, ok := storageUserID(filePath); !ok {
+		return rep.base.AddToZip(zipWriter, filePath, zipPath, skip)
+	}
+	return addOpenedToZip(rep.base, rep.OpenFile, zipWriter, filePath, zipPath, skip)
+}
+
+// EncryptExisting encrypts the files of users at or below path which have been stored before the encryption was enabled and returns their count.
+// Every file is encrypted into the temp folder of its user and moved over the original afterwards,
+// changes of a file while it is encrypted are lost.
+func (rep *EncryptedStorageRepository) EncryptExisting(filePath string) (count int64, err error) {
+	if !utils.ValidatePath(filePath) {
+		return 0, ErrForbiddenPathName
+	}
+	fileInfo, err := rep.base.GetInfo("", filePath)
+	if err != nil {
+		return
+	}
+	err = rep.encryptExisting(filePath, fileInfo, &count)
+	if err != nil {
+		log.Error(0, "Error encrypting files in %v: %v", filePath, err)
+		return
+	}
+	return
+}
+
+func (rep *EncryptedStorageRepository) encryptExisting(filePath string, fileInfo *models.FileInfo, count *int64) error {
+	userID, ok := storageUserID(filePath)
+	tmpPath := path.Join("/", strconv.FormatInt(userID, 10), rep.tmpName)
+	if ok && path.Clean("/"+filePath) == tmpPath {
+		return nil
+	}
+
+	if fileInfo.IsDir {
+		fileInfos, err := rep.base.GetDirectoryInfo("", filePath)
+		if err != nil {
+			return err
+		}
+		for _, childInfo := range fileInfos {
+			err = rep.encryptExisting(path.Join(filePath, childInfo.Name), childInfo, count)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if !ok {
+		return nil
+	}
+	encrypted, err := rep.isEncrypted(filePath)
+	if err != nil || encrypted {
+		return err
+	}
+
+	_, err = rep.base.CreateDirectory(tmpPath)
+	if err != nil {
+		return err
+	}
+	tmpFilePath := path.Join(tmpPath, "encrypt_"+utils.RandomString(16))
+	err = rep.transferFile(filePath, tmpFilePath)
+	if err == nil {
+		err = rep.base.Move(tmpFilePath, filePath)
+	}
+	if err != nil {
+		rep.base.Delete(tmpFilePath)
+		return err
+	}
+	*count++
+	return nil
+}
+
+// DeleteUserKey deletes the key of a user, his encrypted files cannot be read anymore afterwards
+func (rep *EncryptedStorageRepository) DeleteUserKey(userID int64) error {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	delete(rep.userKeys, userID)
+	return rep.userKeyRep.Delete(userID)
+}
+
+// getUserKey returns the unwrapped key of the user, it is generated if create is set and the user has none yet
+func (rep *EncryptedStorageRepository) getUserKey(userID int64, create bool) ([]byte, error) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	if userKey, ok := rep.userKeys[userID]; ok {
+		return userKey, nil
+	}
+
+	var userKey []byte
+	storedKey, err := rep.userKeyRep.GetByUserID(userID)
+	if IsRecordNotFoundError(err) {
+		if !create {
+			return nil, ErrMissingUserKey
+		}
+		userKey, err = crypt.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		storedKey = &models.UserKey{UserID: userID}
+		storedKey.WrappedKey, err = crypt.WrapKey(rep.masterKey, userKey)
+		if err != nil {
+			return nil, err
+		}
+		err = rep.userKeyRep.Create(storedKey)
+	} else if err == nil {
+		userKey, err = crypt.UnwrapKey(rep.masterKey, storedKey.WrappedKey)
+	}
+	if err != nil {
+		log.Error(0, "Could not get key of user %d: %v", userID, err)
+		return nil, err
+	}
+
+	rep.userKeys[userID] = userKey
+	return userKey, nil
+}
+
+func isSameEncryptedStorageUser(oldPath, newPath string) bool {
+	oldUserID, oldOK := storageUserID(oldPath)
+	newUserID, newOK := storageUserID(newPath)
+	return oldOK == newOK && oldUserID == newUserID
+}
+
+// encryptedStorageHandle encrypts the written content in chunks.
+// A chunk is encrypted and written to the base once it is complete and content after it has been written,
+// only the last one is encrypted as final chunk when the handle is closed. Encrypted chunks cannot be written again.
+type encryptedStorageHandle struct {
+	base   StorageHandle
+	cipher *crypt.ChunkCipher
+
+	lock   sync.Mutex
+	offset int64
+	// chunks are the chunks being written by their index
+	chunks map[int64]*encryptedStorageChunk
+	// sealed are the indices of the chunks which have been encrypted already
+	sealed    map[int64]bool
+	lastIndex int64
+	err       error
+}
+
+// encryptedStorageChunk is the plaintext of a chunk being written with the ranges that have been written
+type encryptedStorageChunk struct {
+	data   []byte
+	ranges [][2]int
+}
+
+func (h *encryptedStorageHandle) Write(p []byte) (n int, err error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	n, err = h.writeAt(p, h.offset)
+	h.offset += int64(n)
+	return
+}
+
+func (h *encryptedStorageHandle) WriteAt(p []byte, offset int64) (int, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	return h.writeAt(p, offset)
+}
+
+func (h *encryptedStorageHandle) writeAt(p []byte, offset int64) (n int, err error) {
+	if h.err != nil {
+		return 0, h.err
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+
+	for n < len(p) {
+		index, start := (offset+int64(n))/crypt.EncryptionChunkSize, int((offset+int64(n))%crypt.EncryptionChunkSize)
+		if h.sealed[index] {
+			return n, ErrEncryptedRewrite
+		}
+		chunk, ok := h.chunks[index]
+		if !ok {
+			chunk = &encryptedStorageChunk{data: make([]byte, crypt.EncryptionChunkSize)}
+			h.chunks[index] = chunk
+		}
+		written := copy(chunk.data[start:], p[n:])
+		chunk.addRange(start, start+written)
+		n += written
+		if index > h.lastIndex {
+			h.lastIndex = index
+		}
+	}
+
+	for index, chunk := range h.chunks {
+		if index < h.lastIndex && chunk.isComplete() {
+			if err = h.seal(index, chunk.data, false); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// Close encrypts the remaining chunks, chunks which have not been written are filled with zeros
+func (h *encryptedStorageHandle) Close() error {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+
+	if h.lastIndex < 0 {
+		h.lastIndex = 0
+	}
+	for index := int64(0); index <= h.lastIndex && h.err == nil; index++ {
+		if h.sealed[index] {
+			continue
+		}
+		data := make([]byte, crypt.EncryptionChunkSize)
+		if chunk, ok := h.chunks[index]; ok {
+			data = chunk.data
+			if index == h.lastIndex {
+				data = data[:chunk.end()]
+			}
+		} else if index == h.lastIndex {
+			data = data[:0]
+		}
+		h.seal(index, data, index == h.lastIndex)
+	}
+
+	err := h.base.Close()
+	if h.err != nil {
+		return h.err
+	}
+	return err
+}
+
+func (h *encryptedStorageHandle) seal(index int64, data []byte, final bool) error {
+	_, h.err = h.base.WriteAt(h.cipher.Seal(nil, data, index, final), crypt.EncryptedChunkOffset(index))
+	delete(h.chunks, index)
+	h.sealed[index] = true
+	return h.err
+}
+
+// addRange adds the written range and merges it with the overlapping ones
+func (c *encryptedStorageChunk) addRange(start, end int) {
+	merged := [][2]int{}
+	for _, r := range c.ranges {
+		if r[1] < start || r[0] > end {
+			merged = append(merged, r)
+			continue
+		}
+		if r[0] < start {
+			start = r[0]
+		}
+		if r[1] > end {
+			end = r[1]
+		}
+	}
+	c.ranges = append(merged, [2]int{start, end})
+}
+
+func (c *encryptedStorageChunk) isComplete() bool {
+	return len(c.ranges) == 1 && c.ranges[0][0] == 0 && c.ranges[0][1] == len(c.data)
+}
+
+// end returns the end of the written range which ends last
+func (c *encryptedStorageChunk) end() (end int) {
+	for _, r := range c.ranges {
+		if r[1] > end {
+			end = r[1]
+		}
+	}
+	return
+}
+
+// encryptedStorageFile decrypts the chunk containing the read position, it keeps the last decrypted chunk for reading on sequentially
+type encryptedStorageFile struct {
+	base   StorageFile
+	cipher *crypt.ChunkCipher
+	size   int64
+
+	lock       sync.Mutex
+	offset     int64
+	chunkIndex int64
+	chunk      []byte
+}
+
+func (f *encryptedStorageFile) Read(p []byte) (n int, err error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	n, err = f.readAt(p, f.offset)
+	f.offset += int64(n)
+	return
+}
+
+func (f *encryptedStorageFile) ReadAt(p []byte, offset int64) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	return f.readAt(p, offset)
+}
+
+func (f *encryptedStorageFile) readAt(p []byte, offset int64) (n int, err error) {
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	for n < len(p) && offset+int64(n) < f.size {
+		index := (offset + int64(n)) / crypt.EncryptionChunkSize
+		if err = f.readChunk(index); err != nil {
+			return
+		}
+		n += copy(p[n:], f.chunk[(offset+int64(n))%crypt.EncryptionChunkSize:])
+	}
+	if n < len(p) {
+		err = io.EOF
+	}
+	return
+}
+
+// readChunk decrypts the chunk with the index unless it has been decrypted already
+func (f *encryptedStorageFile) readChunk(index int64) error {
+	if index == f.chunkIndex {
+		return nil
+	}
+
+	length := f.size - index*crypt.EncryptionChunkSize
+	if length > crypt.EncryptionChunkSize {
+		length = crypt.EncryptionChunkSize
+	}
+	ciphertext := make([]byte, length+crypt.EncryptionTagSize)
+	n, err := f.base.ReadAt(ciphertext, crypt.EncryptedChunkOffset(index))
+	if n < len(ciphertext) {
+		if err == nil || err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return err
+	}
+
+	lastIndex := int64(0)
+	if f.size > 0 {
+		lastIndex = (f.size - 1) / crypt.EncryptionChunkSize
+	}
+	f.chunkIndex = -1
+	f.chunk, err = f.cipher.Open(f.chunk[:0], ciphertext, index, index == lastIndex)
+	if err != nil {
+		return err
+	}
+	f.chunkIndex = index
+	return nil
+}
+
+func (f *encryptedStorageFile) Seek(offset int64, whence int) (int64, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.size
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *encryptedStorageFile) Close() error {
+	return f.base.Close()
+}
+
+func (f *encryptedStorageFile) Stat() (os.FileInfo, error) {
+	info, err := f.base.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return &encryptedStorageFileInfo{FileInfo: info, size: f.size}, nil
+}
+
+// encryptedStorageFileInfo describes an encrypted file with the size of its decrypted content
+type encryptedStorageFileInfo struct {
+	os.FileInfo
+	size int64
+}
+
+func (fi *encryptedStorageFileInfo) Size() int64 {
+	return fi.size
+}
@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/crypt"
+)
+
+var testEncryptedStorageSetupFailed = false
+var testEncryptedStorageDBName = "encryptedStorageTest.db"
+var testEncryptedStorageMasterKey = []byte("0123456789abcdef0123456789abcdef")
+
+func testEncryptedStorageCleanup(rep *EncryptedStorageRepository) {
+	if rep != nil {
+		rep.Close()
+	}
+	os.Remove(testEncryptedStorageDBName)
+}
+
+func testEncryptedStorageSetup() (*EncryptedStorageRepository, *MemoryStorageRepository) {
+	testEncryptedStorageCleanup(nil)
+	InitDatabaseConnection("", "", "", "", 0, testEncryptedStorageDBName)
+	userKeyRep, _ := CreateUserKeyRepository()
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	base.CreateDirectory("/1/docs")
+	base.CreateDirectory("/2")
+	base.CreateDirectory("/avatars")
+	rep, _ := CreateEncryptedStorageRepository(base, userKeyRep, testEncryptedStorageMasterKey, ".tmp")
+	return rep, base
+}
+
+func TestCreateEncryptedStorageRepository(t *testing.T) {
+	testEncryptedStorageCleanup(nil)
+	defer testEncryptedStorageCleanup(nil)
+
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	_, err := CreateEncryptedStorageRepository(base, nil, []byte("short"), ".tmp")
+	if err != crypt.ErrInvalidKey {
+		t.Errorf("Error for short master key unequal to ErrInvalidKey: %v", err)
+	}
+	_, err = CreateEncryptedStorageRepository(base, nil, testEncryptedStorageMasterKey, ".tmp")
+	if err != nil {
+		t.Errorf("Failed to create encryptedStorageRepository: %v", err)
+	}
+
+	if t.Failed() {
+		testEncryptedStorageSetupFailed = true
+	}
+}
+
+func TestEncryptedStorageCreateAndRead(t *testing.T) {
+	if testEncryptedStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base := testEncryptedStorageSetup()
+	defer testEncryptedStorageCleanup(rep)
+
+	content := strings.Repeat("0123456789", crypt.EncryptionChunkSize/4)
+	if err := testMountStorageWrite(rep, "/1/docs/a.txt", content); err != nil {
+		t.Fatalf("Failed to write encrypted file: %v", err)
+	}
+	if stored := testMountStorageRead(base, "/1/docs/a.txt"); !crypt.IsEncrypted([]byte(stored)) || strings.Contains(stored, "0123456789") {
+		t.Errorf("Stored content of written file is not encrypted")
+	}
+	if read := testMountStorageRead(rep, "/1/docs/a.txt"); read != content {
+		t.Errorf("Read content unequal to written content of %d bytes: %d bytes", len(content), len(read))
+	}
+
+	fileInfo, err := rep.GetInfo("/1", "/docs/a.txt")
+	if err != nil || fileInfo.Size != int64(len(content)) {
+		t.Errorf("Info of encrypted file does not have the size of its content: %v, %v", fileInfo, err)
+	}
+	dirInfo, err := rep.GetDirectoryInfo("/1", "/docs")
+	if err != nil || len(dirInfo) != 1 || dirInfo[0].Size != int64(len(content)) {
+		t.Errorf("Directory info does not have the size of the decrypted content: %v, %v", dirInfo, err)
+	}
+
+	file, _ := rep.OpenFile("/1/docs/a.txt")
+	defer file.Close()
+	buf := make([]byte, 10)
+	if n, err := file.ReadAt(buf, crypt.EncryptionChunkSize-5); n != 10 || err != nil || string(buf) != content[crypt.EncryptionChunkSize-5:crypt.EncryptionChunkSize+5] {
+		t.Errorf("Read across chunk boundary unequal to expected content: %s, %v", buf[:n], err)
+	}
+	if pos, _ := file.Seek(-3, io.SeekEnd); pos != int64(len(content)-3) {
+		t.Errorf("Seeking from the end returned wrong position: %d", pos)
+	}
+	if rest, _ := ioutil.ReadAll(file); string(rest) != "789" {
+		t.Errorf("Read from sought position unequal to expected content: %s", rest)
+	}
+	if stat, _ := file.Stat(); stat.Size() != int64(len(content)) {
+		t.Errorf("Stat of encrypted file does not have the size of its content: %d", stat.Size())
+	}
+
+	testMountStorageWrite(rep, "/1/docs/empty.txt", "")
+	if fileInfo, _ = rep.GetInfo("/1", "/docs/empty.txt"); fileInfo.Size != 0 {
+		t.Errorf("Size of empty encrypted file unequal to 0: %d", fileInfo.Size)
+	}
+
+	testMountStorageWrite(rep, "/avatars/1_64.png", "avatar")
+	if stored := testMountStorageRead(base, "/avatars/1_64.png"); stored != "avatar" {
+		t.Errorf("File outside of user paths has been encrypted: %s", stored)
+	}
+}
+
+func TestEncryptedStorageWriteAt(t *testing.T) {
+	if testEncryptedStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, _ := testEncryptedStorageSetup()
+	defer testEncryptedStorageCleanup(rep)
+
+	handle, _ := rep.CreateHandle("/1/sparse.bin")
+	handle.WriteAt([]byte("end"), 2*crypt.EncryptionChunkSize+1)
+	handle.WriteAt(bytes.Repeat([]byte("a"), crypt.EncryptionChunkSize), 0)
+	if _, err := handle.WriteAt([]byte("b"), 5); err != ErrEncryptedRewrite {
+		t.Errorf("Error for writing into encrypted chunk unequal to ErrEncryptedRewrite: %v", err)
+	}
+	if err := handle.Close(); err != nil {
+		t.Fatalf("Failed to close handle: %v", err)
+	}
+
+	expContent := string(bytes.Repeat([]byte("a"), crypt.EncryptionChunkSize)) + string(make([]byte, crypt.EncryptionChunkSize+1)) + "end"
+	if content := testMountStorageRead(rep, "/1/sparse.bin"); content != expContent {
+		t.Errorf("Content of file written at offsets unequal to expected content of %d bytes: %d bytes", len(expContent), len(content))
+	}
+}
+
+func TestEncryptedStorageMoveAndCopy(t *testing.T) {
+	if testEncryptedStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base := testEncryptedStorageSetup()
+	defer testEncryptedStorageCleanup(rep)
+
+	testMountStorageWrite(rep, "/1/docs/a.txt", "a")
+	if err := rep.Copy("/1/docs/a.txt", "/1/b.txt"); err != nil {
+		t.Fatalf("Failed to copy file of user: %v", err)
+	}
+	if err := rep.Move("/1/docs", "/2/docs"); err != nil {
+		t.Fatalf("Failed to move folder to another user: %v", err)
+	}
+	if content := testMountStorageRead(rep, "/2/docs/a.txt"); content != "a" {
+		t.Errorf("Content of file moved to another user unequal to expected content: %s", content)
+	}
+	if _, err := base.GetInfo("/1", "/docs"); err != ErrFileNotExist {
+		t.Errorf("Moved folder still exists at old path: %v", err)
+	}
+
+	// The file is encrypted for the other user, so his key is needed
+	rep.DeleteUserKey(2)
+	if _, err := rep.OpenFile("/2/docs/a.txt"); err != ErrMissingUserKey {
+		t.Errorf("Error for reading file without key of its user unequal to ErrMissingUserKey: %v", err)
+	}
+	if content := testMountStorageRead(rep, "/1/b.txt"); content != "a" {
+		t.Errorf("Content of copied file unequal to expected content: %s", content)
+	}
+
+	// Files moved out of the folders of users keep their encryption
+	base.CreateDirectory("/quarantine")
+	if err := rep.Move("/1/b.txt", "/quarantine/b.txt"); err != nil {
+		t.Fatalf("Failed to move file out of the folder of its user: %v", err)
+	}
+	if content := testMountStorageRead(base, "/quarantine/b.txt"); !crypt.IsEncrypted([]byte(content)) {
+		t.Errorf("Expected file moved out of the folder of its user to stay encrypted but got: %s", content)
+	}
+}
+
+func TestEncryptedStorageAddToZip(t *testing.T) {
+	if testEncryptedStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, _ := testEncryptedStorageSetup()
+	defer testEncryptedStorageCleanup(rep)
+
+	testMountStorageWrite(rep, "/1/docs/a.txt", "a")
+	testMountStorageWrite(rep, "/1/docs/skipped.txt", "skipped")
+	testMountStorageWrite(rep, "/1/b.txt", "b")
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	err := rep.AddToZip(zipWriter, "/1", "files", func(relPath string) bool { return relPath == "docs/skipped.txt" })
+	if err != nil {
+		t.Fatalf("Failed to add folder to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open created zip: %v", err)
+	}
+	contents := map[string]string{}
+	for _, zipFile := range zipReader.File {
+		file, _ := zipFile.Open()
+		content, _ := ioutil.ReadAll(file)
+		file.Close()
+		contents[zipFile.Name] = string(content)
+	}
+	expContents := map[string]string{"files/b.txt": "b", "files/docs/a.txt": "a"}
+	if !reflect.DeepEqual(contents, expContents) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", contents, expContents)
+	}
+}
+
+func TestEncryptedStorageEncryptExisting(t *testing.T) {
+	if testEncryptedStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, base := testEncryptedStorageSetup()
+	defer testEncryptedStorageCleanup(rep)
+
+	testMountStorageWrite(base, "/1/docs/plain.txt", "plain")
+	testMountStorageWrite(rep, "/1/docs/encrypted.txt", "encrypted")
+	testMountStorageWrite(base, "/avatars/1_64.png", "avatar")
+	if content := testMountStorageRead(rep, "/1/docs/plain.txt"); content != "plain" {
+		t.Errorf("Content of file stored before encryption unequal to expected content: %s", content)
+	}
+
+	count, err := rep.EncryptExisting("/")
+	if err != nil || count != 1 {
+		t.Fatalf("Expected one file to be encrypted: %d, %v", count, err)
+	}
+	if stored := testMountStorageRead(base, "/1/docs/plain.txt"); !crypt.IsEncrypted([]byte(stored)) {
+		t.Errorf("File stored before encryption has not been encrypted: %s", stored)
+	}
+	if content := testMountStorageRead(rep, "/1/docs/plain.txt"); content != "plain" {
+		t.Errorf("Content of encrypted file unequal to expected content: %s", content)
+	}
+	if content := testMountStorageRead(rep, "/1/docs/encrypted.txt"); content != "encrypted" {
+		t.Errorf("Content of file encrypted before unequal to expected content: %s", content)
+	}
+	if stored := testMountStorageRead(base, "/avatars/1_64.png"); stored != "avatar" {
+		t.Errorf("File outside of user paths has been encrypted: %s", stored)
+	}
+	if dirInfo, _ := base.GetDirectoryInfo("/1", "/.tmp"); len(dirInfo) != 0 {
+		t.Errorf("Temp files of the encryption have not been removed: %v", dirInfo)
+	}
+}
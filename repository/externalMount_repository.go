@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.ExternalMount{})
+}
+
+// ExternalMountRepository represents the database for storing external storages mounted into the files of users and groups
+type ExternalMountRepository struct{}
+
+// CreateExternalMountRepository creates a new ExternalMountRepository IF gorm has been initialized before
+func CreateExternalMountRepository() (*ExternalMountRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &ExternalMountRepository{}, nil
+}
+
+// Create stores a new mount
+func (rep *ExternalMountRepository) Create(mount *models.ExternalMount) (err error) {
+	mount.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(mount).Error
+	if err != nil {
+		log.Error(0, "Could not create mount at %v: %v", mount.Path, err)
+		return
+	}
+	return
+}
+
+// Update replaces all stored fields of the mount except its creation time
+func (rep *ExternalMountRepository) Update(mount *models.ExternalMount) (err error) {
+	err = databaseConnection.Model(mount).Omit("created").Save(mount).Error
+	if err != nil {
+		log.Error(0, "Could not update mount %d: %v", mount.ID, err)
+		return
+	}
+	return
+}
+
+// GetByID reads and returns the mount by its ID
+func (rep *ExternalMountRepository) GetByID(mountID int64) (mount *models.ExternalMount, err error) {
+	mount = &models.ExternalMount{}
+	err = databaseConnection.First(mount, "id = ?", mountID).Error
+	return
+}
+
+// GetAll returns all mounts, oldest first
+func (rep *ExternalMountRepository) GetAll() (mounts []*models.ExternalMount, err error) {
+	err = databaseConnection.Order("id asc").Find(&mounts).Error
+	if err != nil {
+		log.Error(0, "Could not get all mounts: %v", err)
+		return
+	}
+	return
+}
+
+// GetAllForUser returns all mounts of the user itself, mounts of his groups are not included
+func (rep *ExternalMountRepository) GetAllForUser(userID int64) (mounts []*models.ExternalMount, err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Order("id asc").Find(&mounts).Error
+	if err != nil {
+		log.Error(0, "Could not get mounts of user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// GetAllForGroup returns all mounts of the group
+func (rep *ExternalMountRepository) GetAllForGroup(groupID int64) (mounts []*models.ExternalMount, err error) {
+	err = databaseConnection.Where("group_id = ?", groupID).Order("id asc").Find(&mounts).Error
+	if err != nil {
+		log.Error(0, "Could not get mounts of group %d: %v", groupID, err)
+		return
+	}
+	return
+}
+
+// Delete deletes a mount by its ID
+func (rep *ExternalMountRepository) Delete(mountID int64) (err error) {
+	db := databaseConnection.Delete(&models.ExternalMount{ID: mountID})
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not delete mount %d: %v", mountID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// DeleteAllForUser deletes all mounts of the user
+func (rep *ExternalMountRepository) DeleteAllForUser(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.ExternalMount{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete mounts of user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// DeleteAllForGroup deletes all mounts of the group
+func (rep *ExternalMountRepository) DeleteAllForGroup(groupID int64) (err error) {
+	err = databaseConnection.Where("group_id = ?", groupID).Delete(&models.ExternalMount{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete mounts of group %d: %v", groupID, err)
+		return
+	}
+	return
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testExternalMountSetupFailed = false
+var testExternalMountDBName = "externalMountTest.db"
+
+func testExternalMountCleanup() {
+	os.Remove(testExternalMountDBName)
+}
+
+func testExternalMountSetup() *ExternalMountRepository {
+	testExternalMountCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testExternalMountDBName)
+	rep, _ := CreateExternalMountRepository()
+	return rep
+}
+
+func testExternalMountInsert(rep *ExternalMountRepository) []*models.ExternalMount {
+	mounts := []*models.ExternalMount{
+		{UserID: 1, Path: "/nas", Type: "local", Root: "/srv/nas"},
+		{GroupID: 1, Path: "/team", Type: "sftp", Root: "/data", Host: "sftp.example.com", Port: 22, Username: "freecloud", Password: "secret", HostKey: "ssh-ed25519 AAAA", ReadOnly: true},
+		{UserID: 2, Path: "/nas", Type: "local", Root: "/srv/nas2"},
+	}
+	for _, mount := range mounts {
+		rep.Create(mount)
+	}
+	return mounts
+}
+
+func TestCreateExternalMountRepository(t *testing.T) {
+	testExternalMountCleanup()
+	defer testExternalMountCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testExternalMountDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateExternalMountRepository()
+	if err != nil {
+		t.Errorf("Failed to create external mount repository: %v", err)
+	}
+
+	if t.Failed() {
+		testExternalMountSetupFailed = true
+	}
+}
+
+func TestCreateAndGetExternalMounts(t *testing.T) {
+	if testExternalMountSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testExternalMountCleanup()
+	rep := testExternalMountSetup()
+
+	mounts := testExternalMountInsert(rep)
+
+	allMounts, err := rep.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get all mounts: %v", err)
+	}
+	if !reflect.DeepEqual(allMounts, mounts) {
+		t.Errorf("Read back mounts and inserted mounts not deeply equal: %v != %v", allMounts, mounts)
+	}
+
+	userMounts, err := rep.GetAllForUser(1)
+	if err != nil || len(userMounts) != 1 || userMounts[0].ID != mounts[0].ID {
+		t.Errorf("Mounts of user 1 unequal to expected mounts: %v, %v", userMounts, err)
+	}
+	groupMounts, err := rep.GetAllForGroup(1)
+	if err != nil || len(groupMounts) != 1 || !reflect.DeepEqual(groupMounts[0], mounts[1]) {
+		t.Errorf("Mounts of group 1 unequal to expected mounts: %v, %v", groupMounts, err)
+	}
+
+	_, err = rep.GetByID(42)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for unknown mount, got %v", err)
+	}
+}
+
+func TestUpdateExternalMount(t *testing.T) {
+	if testExternalMountSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testExternalMountCleanup()
+	rep := testExternalMountSetup()
+
+	mounts := testExternalMountInsert(rep)
+
+	updated := *mounts[1]
+	updated.Path = "/shared"
+	updated.ReadOnly = false
+	updated.Created = 0
+	err := rep.Update(&updated)
+	if err != nil {
+		t.Fatalf("Failed to update mount: %v", err)
+	}
+
+	readBack, err := rep.GetByID(mounts[1].ID)
+	if err != nil {
+		t.Fatalf("Failed to get updated mount: %v", err)
+	}
+	updated.Created = mounts[1].Created
+	if !reflect.DeepEqual(readBack, &updated) {
+		t.Errorf("Read back mount and updated mount not deeply equal: %v != %v", readBack, &updated)
+	}
+}
+
+func TestDeleteExternalMounts(t *testing.T) {
+	if testExternalMountSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testExternalMountCleanup()
+	rep := testExternalMountSetup()
+
+	mounts := testExternalMountInsert(rep)
+
+	err := rep.Delete(mounts[0].ID)
+	if err != nil {
+		t.Errorf("Failed to delete mount: %v", err)
+	}
+	err = rep.Delete(mounts[0].ID)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting deleted mount, got %v", err)
+	}
+
+	err = rep.DeleteAllForGroup(1)
+	if err != nil {
+		t.Errorf("Failed to delete mounts of group 1: %v", err)
+	}
+	err = rep.DeleteAllForUser(2)
+	if err != nil {
+		t.Errorf("Failed to delete mounts of user 2: %v", err)
+	}
+
+	allMounts, err := rep.GetAll()
+	if err != nil || len(allMounts) != 0 {
+		t.Errorf("Mounts left after deleting all mounts: %v, %v", allMounts, err)
+	}
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.FileChange{})
+}
+
+// FileChangeRepository represents the database for storing the log of changes to the files of users
+type FileChangeRepository struct{}
+
+// CreateFileChangeRepository creates a new FileChangeRepository IF gorm has been initialized before
+func CreateFileChangeRepository() (*FileChangeRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &FileChangeRepository{}, nil
+}
+
+// Create stores a new change, its ID is greater than the IDs of all changes stored before
+func (rep *FileChangeRepository) Create(change *models.FileChange) (err error) {
+	change.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(change).Error
+	if err != nil {
+		log.Error(0, "Could not create file change: %v", err)
+		return
+	}
+	return
+}
+
+// GetAfter returns up to limit changes within any of the scopes with an ID greater than afterID, oldest first
+func (rep *FileChangeRepository) GetAfter(scopes []*models.SubtreeScope, afterID, limit int64) (changes []*models.FileChange, err error) {
+	changes = []*models.FileChange{}
+	if len(scopes) == 0 {
+		return
+	}
+
+	condition, args := subtreeScopesCondition(scopes)
+	err = databaseConnection.Where("id > ?", afterID).Where(condition, args...).Order("id asc").Limit(limit).Find(&changes).Error
+	if err != nil {
+		log.Error(0, "Could not get file changes after %d: %v", afterID, err)
+		return
+	}
+	return
+}
+
+// GetIDRange returns the lowest and highest ID of all stored changes, both are 0 if there are none
+func (rep *FileChangeRepository) GetIDRange() (minID, maxID int64, err error) {
+	row := databaseConnection.Model(&models.FileChange{}).Select("coalesce(min(id), 0), coalesce(max(id), 0)").Row()
+	err = row.Scan(&minID, &maxID)
+	if err != nil {
+		log.Error(0, "Could not get range of file change IDs: %v", err)
+		return
+	}
+	return
+}
+
+// DeleteOlderThan deletes all changes recorded before the given timestamp except for the newest one.
+// Keeping it preserves the highest ID, so the IDs remain comparable to cursors of clients.
+func (rep *FileChangeRepository) DeleteOlderThan(timestamp int64) (err error) {
+	_, maxID, err := rep.GetIDRange()
+	if err != nil {
+		return
+	}
+
+	err = databaseConnection.Where("created < ? and id < ?", timestamp, maxID).Delete(&models.FileChange{}).Error
+	if err != nil {
+		log.Error(0, "Deleting old file changes failed: %v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testFileChangeSetupFailed = false
+var testFileChangeDBName = "fileChangeTest.db"
+
+func testFileChangeCleanup() {
+	os.Remove(testFileChangeDBName)
+}
+
+func testFileChangeSetup() *FileChangeRepository {
+	testFileChangeCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testFileChangeDBName)
+	rep, _ := CreateFileChangeRepository()
+	return rep
+}
+
+func TestCreateFileChangeRepository(t *testing.T) {
+	testFileChangeCleanup()
+	defer testFileChangeCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testFileChangeDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateFileChangeRepository()
+	if err != nil {
+		t.Errorf("Failed to create file change repository: %v", err)
+	}
+
+	if t.Failed() {
+		testFileChangeSetupFailed = true
+	}
+}
+
+func TestFileChangeGetAfter(t *testing.T) {
+	if testFileChangeSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testFileChangeCleanup()
+	rep := testFileChangeSetup()
+
+	minID, maxID, err := rep.GetIDRange()
+	if err != nil || minID != 0 || maxID != 0 {
+		t.Errorf("Expected empty range of IDs without changes but got: %v, %v, %v", minID, maxID, err)
+	}
+
+	changes := []*models.FileChange{
+		{Action: "create", OwnerID: 1, Path: "/docs", IsDir: true},
+		{Action: "create", OwnerID: 2, Path: "/docs/a.txt"},
+		{Action: "create", OwnerID: 1, Path: "/docs/b.txt"},
+		{Action: "move", OwnerID: 1, Path: "/c.txt", OldPath: "/docs/b.txt"},
+		{Action: "delete", OwnerID: 1, Path: "/docs"},
+	}
+	for i, change := range changes {
+		err = rep.Create(change)
+		if err != nil {
+			t.Fatalf("Failed to create file change: %v", err)
+		}
+		databaseConnection.Model(change).Update("created", int64(100+i))
+	}
+
+	readBack, err := rep.GetAfter([]*models.SubtreeScope{{OwnerID: 1, Path: "/docs"}}, changes[0].ID, 2)
+	if err != nil || len(readBack) != 2 || readBack[0].ID != changes[2].ID || readBack[1].Action != "move" {
+		t.Errorf("Expected oldest 2 changes of owner 1 after the first one but got: %v, %v", readBack, err)
+	}
+
+	readBack, _ = rep.GetAfter(nil, 0, 10)
+	if len(readBack) != 0 {
+		t.Errorf("Expected no changes without scopes but got: %v", readBack)
+	}
+
+	err = rep.DeleteOlderThan(200)
+	if err != nil {
+		t.Errorf("Failed to delete old file changes: %v", err)
+	}
+	minID, maxID, _ = rep.GetIDRange()
+	if minID != changes[4].ID || maxID != changes[4].ID {
+		t.Errorf("Expected newest change to be kept but got range: %v, %v", minID, maxID)
+	}
+}
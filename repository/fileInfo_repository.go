@@ -71,11 +71,27 @@ func (rep *FileInfoRepository) GetStarredFileInfosByUser(userID int64) (starredF
 
 // GetSharedWithFileInfosByUser returns all file infos shared with the user
 func (rep *FileInfoRepository) GetSharedWithFileInfosByUser(userID int64) (sharedFilesForUser []*models.FileInfo, err error) {
+	err = databaseConnection.Raw(getSharedWithByUserID, userID, userID).Order(fileListOrder).Scan(&sharedFilesForUser).Error
+	if err != nil && IsRecordNotFoundError(err) {
+		err = nil
+	} else if err != nil {
+		log.Error(0, "Could not get files shared with userID %v: %v", userID, err)
+		return
+	}
+
 	return
 }
 
 // GetSharedFileInfosByUser returns all file infos a user shared with someone else
 func (rep *FileInfoRepository) GetSharedFileInfosByUser(userID int64) (sharedFilesForUser []*models.FileInfo, err error) {
+	err = databaseConnection.Raw(getSharedByUserID, userID, userID).Order(fileListOrder).Scan(&sharedFilesForUser).Error
+	if err != nil && IsRecordNotFoundError(err) {
+		err = nil
+	} else if err != nil {
+		log.Error(0, "Could not get files shared by userID %v: %v", userID, err)
+		return
+	}
+
 	return
 }
 
@@ -153,6 +169,99 @@ func (rep *FileInfoRepository) DeleteUserFileInfos(userID int64) (err error) {
 	return
 }
 
+// GetByOwner returns all file infos owned by an user
+func (rep *FileInfoRepository) GetByOwner(ownerID int64) (fileInfos []*models.FileInfo, err error) {
+	err = databaseConnection.Where(&models.FileInfo{OwnerID: ownerID}).Find(&fileInfos).Error
+	if err != nil {
+		log.Error(0, "Could not get all files for %v: %v", ownerID, err)
+		return
+	}
+	return
+}
+
+// GetByShareID returns the file infos through which a share entry is shown to the user it is shared with
+func (rep *FileInfoRepository) GetByShareID(shareID int64) (fileInfos []*models.FileInfo, err error) {
+	err = databaseConnection.Where(&models.FileInfo{ShareID: shareID}).Find(&fileInfos).Error
+	if err != nil {
+		log.Error(0, "Could not get files for share %v: %v", shareID, err)
+		return
+	}
+	return
+}
+
+// TransferOwnership re-owns all file infos of fromOwnerID to toOwnerID in a single transaction.
+// The root directory of fromOwnerID becomes the directory folderName below the directory toParentID,
+// which has to be the root directory of toOwnerID, and all paths are adapted accordingly.
+func (rep *FileInfoRepository) TransferOwnership(fromOwnerID, toOwnerID, toParentID int64, folderName string) (err error) {
+	tx := databaseConnection.Begin()
+	if err = tx.Error; err != nil {
+		log.Error(0, "Could not begin transaction to transfer files of %v: %v", fromOwnerID, err)
+		return
+	}
+
+	var fileInfos []*models.FileInfo
+	err = tx.Where(&models.FileInfo{OwnerID: fromOwnerID}).Find(&fileInfos).Error
+	if err != nil {
+		tx.Rollback()
+		log.Error(0, "Could not get all files for %v: %v", fromOwnerID, err)
+		return
+	}
+
+	for _, fileInfo := range fileInfos {
+		if fileInfo.Path == "/" && fileInfo.Name == "" {
+			fileInfo.Name = folderName
+			fileInfo.ParentID = toParentID
+		} else {
+			fileInfo.Path = "/" + folderName + fileInfo.Path
+		}
+		fileInfo.OwnerID = toOwnerID
+
+		err = tx.Save(fileInfo).Error
+		if err != nil {
+			tx.Rollback()
+			log.Error(0, "Could not transfer file %v from %v to %v: %v", fileInfo.ID, fromOwnerID, toOwnerID, err)
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		log.Error(0, "Could not commit transfer of files from %v to %v: %v", fromOwnerID, toOwnerID, err)
+		return
+	}
+	return
+}
+
+// GetStorageUsedByOwners returns the summed up size of all files owned by each of the given users.
+// Directories and files shared with the users are not counted, users without files are missing in the result.
+func (rep *FileInfoRepository) GetStorageUsedByOwners(ownerIDs []int64) (storageUsed map[int64]int64, err error) {
+	storageUsed = make(map[int64]int64)
+	if len(ownerIDs) == 0 {
+		return
+	}
+
+	rows, err := databaseConnection.Model(&models.FileInfo{}).
+		Select("owner_id, sum(size)").
+		Where("owner_id in (?) and is_dir = ? and share_id = 0", ownerIDs, false).
+		Group("owner_id").Rows()
+	if err != nil {
+		log.Error(0, "Could not get storage used by users: %v", err)
+		return
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerID, size int64
+		if err = rows.Scan(&ownerID, &size); err != nil {
+			log.Error(0, "Could not read storage used by users: %v", err)
+			return
+		}
+		storageUsed[ownerID] = size
+	}
+	err = rows.Err()
+	return
+}
+
 // Count returns the count of file infos
 func (rep *FileInfoRepository) Count() (count int64, err error) {
 	err = databaseConnection.Model(&models.FileInfo{}).Count(&count).Error
@@ -164,12 +273,14 @@ func (rep *FileInfoRepository) Count() (count int64, err error) {
 }
 
 var (
-	selectPart             = "select file.id, file.is_dir, file.last_changed, file.mime_type, file.name, file.owner_id, file.parent_id, file.path, file.share_id, file.size, (stars.file_id is not null) as starred"
+	selectPart             = "select file.id, file.blob_hash, file.is_dir, file.last_changed, file.md5, file.mime_type, file.name, file.owner_id, file.parent_id, file.path, file.sha256, file.share_id, file.size, (stars.file_id is not null) as starred"
 	joinStarsPart          = " join stars on stars.file_id = file.id and stars.user_id = ?"
 	leftOuterJoinStarsPart = " left outer" + joinStarsPart
 
 	getStarredFilesByUserID = selectPart + " from file_infos as file" + joinStarsPart
-	getDirectoryContent     = selectPart + " from (select * from file_infos where parent_id = ?) as file" + leftOuterJoinStarsPart                                // ParentID and userID
-	getByPath               = selectPart + " from (select * from file_infos where path = ? and name = ? and owner_id = ?) as file" + leftOuterJoinStarsPart       // Path, name and two times userID
-	getSearch               = selectPart + " from (select * from file_infos where path LIKE ? and name LIKE ? and owner_id = ?) as file" + leftOuterJoinStarsPart // PathMatch, FileMatch and two times userID
+	getDirectoryContent     = selectPart + " from (select * from file_infos where parent_id = ?) as file" + leftOuterJoinStarsPart                                                               // ParentID and userID
+	getByPath               = selectPart + " from (select * from file_infos where path = ? and name = ? and owner_id = ?) as file" + leftOuterJoinStarsPart                                      // Path, name and two times userID
+	getSearch               = selectPart + " from (select * from file_infos where path LIKE ? and name LIKE ? and owner_id = ?) as file" + leftOuterJoinStarsPart                                // PathMatch, FileMatch and two times userID
+	getSharedWithByUserID   = selectPart + " from (select * from file_infos where owner_id = ? and share_id > 0) as file" + leftOuterJoinStarsPart                                               // Two times userID
+	getSharedByUserID       = selectPart + " from (select * from file_infos where owner_id = ? and share_id = 0 and id in (select file_id from share_entries)) as file" + leftOuterJoinStarsPart // Two times userID
 )
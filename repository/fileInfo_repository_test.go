@@ -224,6 +224,32 @@ func TestFileInfoGetStarredByUser(t *testing.T) {
 	}
 }
 
+func TestFileInfoGetSharedByUser(t *testing.T) {
+	if testFileInfoSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testFileInfoCleanup()
+	rep := testFileInfoSetup()
+
+	testFileInfoInsertComplete(rep)
+
+	sharedBy, err := rep.GetSharedFileInfosByUser(1)
+	if err != nil {
+		t.Errorf("Failed to get files shared by user 1: %v", err)
+	}
+	if len(sharedBy) != 1 || sharedBy[0].ID != testFileInfoOrig0.ID || !sharedBy[0].Starred {
+		t.Errorf("Expected only starred file orig 0 as shared by user 1 but got: %v", sharedBy)
+	}
+
+	sharedWith, err := rep.GetSharedWithFileInfosByUser(1)
+	if err != nil {
+		t.Errorf("Failed to get files shared with user 1: %v", err)
+	}
+	if len(sharedWith) != 1 || sharedWith[0].ID != testFileInfoShared1.ID {
+		t.Errorf("Expected only file shared 1 as shared with user 1 but got: %v", sharedWith)
+	}
+}
+
 func TestFileInfoSearch(t *testing.T) {
 	if testFileInfoSetupFailed {
 		t.Skip("Skipped due to failed setup")
@@ -326,3 +352,69 @@ func TestDeleteUserFileInfo(t *testing.T) {
 		t.Errorf("Count after deleting user file infos for user 2 is unqual to three: %d", count)
 	}
 }
+
+func TestFileInfoGetStorageUsedByOwners(t *testing.T) {
+	if testFileInfoSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testFileInfoCleanup()
+	rep := testFileInfoSetup()
+
+	testFileInfoInsertComplete(rep)
+	rep.Create(&models.FileInfo{OwnerID: 1, ParentID: 101, Path: "/", Name: "file0", Size: 100})
+	rep.Create(&models.FileInfo{OwnerID: 1, ParentID: 101, Path: "/", Name: "file1", Size: 50})
+	rep.Create(&models.FileInfo{OwnerID: 1, ParentID: 101, Path: "/", Name: "dir0", Size: 150, IsDir: true})
+	rep.Create(&models.FileInfo{OwnerID: 3, ParentID: 103, Path: "/", Name: "sharedFile", Size: 100, ShareID: testFileInfoShareEntry0.ID})
+
+	storageUsed, err := rep.GetStorageUsedByOwners([]int64{1, 2, 3, 4})
+	if err != nil {
+		t.Fatalf("Failed to get storage used by owners: %v", err)
+	}
+	expStorageUsed := map[int64]int64{1: 150, 2: 0}
+	if !reflect.DeepEqual(storageUsed, expStorageUsed) {
+		t.Errorf("Storage used and expected storage used not deeply equal: %v != %v", storageUsed, expStorageUsed)
+	}
+}
+
+func TestFileInfoTransferOwnership(t *testing.T) {
+	if testFileInfoSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testFileInfoCleanup()
+	rep := testFileInfoSetup()
+
+	fromRoot := &models.FileInfo{OwnerID: 1, Path: "/", Name: "", IsDir: true}
+	toRoot := &models.FileInfo{OwnerID: 2, Path: "/", Name: "", IsDir: true}
+	rep.Create(fromRoot)
+	rep.Create(toRoot)
+	dir := &models.FileInfo{OwnerID: 1, ParentID: fromRoot.ID, Path: "/", Name: "dir", IsDir: true}
+	rep.Create(dir)
+	file := &models.FileInfo{OwnerID: 1, ParentID: dir.ID, Path: "/dir/", Name: "file"}
+	rep.Create(file)
+
+	err := rep.TransferOwnership(1, 2, toRoot.ID, "transferred")
+	if err != nil {
+		t.Fatalf("Failed to transfer ownership: %v", err)
+	}
+
+	fileInfos, err := rep.GetByOwner(1)
+	if err != nil || len(fileInfos) != 0 {
+		t.Errorf("Expected no files left for previous owner but got %d: %v", len(fileInfos), err)
+	}
+
+	expFileInfos := map[string]*models.FileInfo{
+		"root": {ID: fromRoot.ID, OwnerID: 2, ParentID: toRoot.ID, Path: "/", Name: "transferred", IsDir: true},
+		"dir":  {ID: dir.ID, OwnerID: 2, ParentID: fromRoot.ID, Path: "/transferred/", Name: "dir", IsDir: true},
+		"file": {ID: file.ID, OwnerID: 2, ParentID: dir.ID, Path: "/transferred/dir/", Name: "file"},
+	}
+	for name, expFileInfo := range expFileInfos {
+		fileInfo, err := rep.GetByID(expFileInfo.ID)
+		if err != nil {
+			t.Errorf("Failed to get transferred %s: %v", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(fileInfo, expFileInfo) {
+			t.Errorf("Transferred %s and expected %s not deeply equal: %v != %v", name, name, fileInfo, expFileInfo)
+		}
+	}
+}
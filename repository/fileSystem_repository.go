@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"archive/zip"
 	"errors"
 	"fmt"
 	"io"
@@ -23,7 +24,7 @@ var (
 	ErrFileNotExist = errors.New("file does not exist")
 )
 
-// FileSystemRepository represents the local filesystem for storing files
+// FileSystemRepository represents the local filesystem for storing files, it is the default StorageRepository
 type FileSystemRepository struct {
 	base               string
 	tmpName            string
@@ -32,7 +33,8 @@ type FileSystemRepository struct {
 	done               chan struct{}
 }
 
-// CreateFileSystemRepository creates a new fileSystemRepository at a given relative or abolute path with a interval for temp cleanup in hours and a tmp data expiry in hours
+// CreateFileSystemRepository creates a new fileSystemRepository at a given relative or abolute path with a interval for temp cleanup in hours and a tmp data expiry in hours.
+// An empty tmpName disables the temp cleanup, e.g. for directories mounted into the files of users.
 func CreateFileSystemRepository(baseDir, tmpName string, tmpCleanupInterval, tmpDataExpiry int) (*FileSystemRepository, error) {
 	base, err := filepath.Abs(baseDir)
 	if err != nil {
@@ -66,14 +68,18 @@ func CreateFileSystemRepository(baseDir, tmpName string, tmpCleanupInterval, tmp
 		done:               make(chan struct{}),
 	}
 
-	go fileSystemRepository.cleanupTempFolderRoutine()
+	if tmpName != "" {
+		go fileSystemRepository.cleanupTempFolderRoutine()
+	}
 
 	return fileSystemRepository, nil
 }
 
 // Close closes the repository and with that ends the go routine for tmp cleanup
 func (rep *FileSystemRepository) Close() error {
-	rep.done <- struct{}{}
+	if rep.tmpName != "" {
+		rep.done <- struct{}{}
+	}
 	return nil
 }
 
@@ -132,7 +138,7 @@ func (rep *FileSystemRepository) cleanupTempFolder() (err error) {
 
 // CreateHandle opens an *os.File handle for writing to.
 // Before opening the file, it check the path for sanity.
-func (rep *FileSystemRepository) CreateHandle(path string) (*os.File, error) {
+func (rep *FileSystemRepository) CreateHandle(path string) (StorageHandle, error) {
 	if !utils.ValidatePath(path) {
 		return nil, ErrForbiddenPathName
 	}
@@ -144,6 +150,19 @@ func (rep *FileSystemRepository) CreateHandle(path string) (*os.File, error) {
 	return f, nil
 }
 
+// OpenFile opens an *os.File handle for reading from.
+// Before opening the file, it check the path for sanity.
+func (rep *FileSystemRepository) OpenFile(path string) (StorageFile, error) {
+	if !utils.ValidatePath(path) {
+		return nil, ErrForbiddenPathName
+	}
+	f, err := os.Open(filepath.Join(rep.base, path))
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
 // CreateDirectory checks whether directory exists and creates it otherwise
 func (rep *FileSystemRepository) CreateDirectory(path string) (created bool, err error) {
 	if !utils.ValidatePath(path) {
@@ -242,6 +261,60 @@ func (rep *FileSystemRepository) Zip(paths []string, outputPath string) (err err
 	return
 }
 
+// AddToZip writes the file or the content of the folder at path into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *FileSystemRepository) AddToZip(zipWriter *zip.Writer, path, zipPath string, skip func(relPath string) bool) (err error) {
+	if !utils.ValidatePath(path) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	fullPath := filepath.Join(rep.base, path)
+	err = filepath.Walk(fullPath, func(walkPath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		relPath, err := filepath.Rel(fullPath, walkPath)
+		if err != nil {
+			return err
+		}
+		if skip != nil && relPath != "." && skip(filepath.ToSlash(relPath)) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(filepath.Join(zipPath, relPath))
+		header.Method = zip.Deflate
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		file, err := os.Open(walkPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(writer, file)
+		return err
+	})
+	if err != nil {
+		log.Error(0, "Error adding %v to zip: %v", path, err)
+		return
+	}
+	return
+}
+
 // Move moves the file/folder of oldPath to newPath
 func (rep *FileSystemRepository) Move(oldPath, newPath string) (err error) {
 	if !utils.ValidatePath(oldPath) {
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"archive/zip"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -349,3 +350,40 @@ func TestFileSystemCreateZip(t *testing.T) {
 		t.Errorf("FileInfo of created zip and expected fileInfo not deeply equal: %v", fileInfo)
 	}
 }
+
+func TestFileSystemAddToZip(t *testing.T) {
+	if testFileSystemSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testFileSystemSetup()
+	defer testFileSystemCleanup(rep)
+
+	testFileSystemInsertComplete(rep)
+
+	file, _ := rep.CreateHandle("2/test.zip")
+	zipWriter := zip.NewWriter(file)
+	err := rep.AddToZip(zipWriter, "1", "one", func(relPath string) bool { return relPath == ".tmp" })
+	if err != nil {
+		t.Errorf("Failed to add '1' to zip: %v", err)
+	}
+	err = rep.AddToZip(zipWriter, "2/anotherFile.txt", "two/file.txt", nil)
+	if err != nil {
+		t.Errorf("Failed to add '2/anotherFile.txt' to zip: %v", err)
+	}
+	zipWriter.Close()
+	file.Close()
+
+	zipReader, err := zip.OpenReader(filepath.Join(testFileSystemDirName, "2", "test.zip"))
+	if err != nil {
+		t.Fatalf("Failed to open created zip: %v", err)
+	}
+	defer zipReader.Close()
+	var names []string
+	for _, zipFile := range zipReader.File {
+		names = append(names, zipFile.Name)
+	}
+	expNames := []string{"two/file.txt"}
+	if !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", names, expNames)
+	}
+}
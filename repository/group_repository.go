@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.Group{}, &models.GroupMember{})
+}
+
+// GroupRepository represents the database for storing groups and their members
+type GroupRepository struct{}
+
+// CreateGroupRepository creates a new GroupRepository IF gorm has been initialized before
+func CreateGroupRepository() (*GroupRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &GroupRepository{}, nil
+}
+
+// Create stores a new group
+func (rep *GroupRepository) Create(group *models.Group) (err error) {
+	group.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(group).Error
+	if err != nil {
+		log.Error(0, "Could not create group: %v", err)
+		return
+	}
+	return
+}
+
+// Update updates the name of a group
+func (rep *GroupRepository) Update(group *models.Group) (err error) {
+	db := databaseConnection.Model(&models.Group{ID: group.ID}).Update("name", group.Name)
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not update group %v: %v", group.ID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// Delete deletes a group and all its memberships
+func (rep *GroupRepository) Delete(groupID int64) (err error) {
+	tx := databaseConnection.Begin()
+	if err = tx.Error; err != nil {
+		log.Error(0, "Could not begin transaction to delete group %v: %v", groupID, err)
+		return
+	}
+
+	db := tx.Delete(&models.Group{ID: groupID})
+	if err = db.Error; err != nil {
+		tx.Rollback()
+		log.Error(0, "Could not delete group %v: %v", groupID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		tx.Rollback()
+		return gorm.ErrRecordNotFound
+	}
+
+	err = tx.Delete(&models.GroupMember{}, "group_id = ?", groupID).Error
+	if err != nil {
+		tx.Rollback()
+		log.Error(0, "Could not delete members of group %v: %v", groupID, err)
+		return
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		log.Error(0, "Could not commit deletion of group %v: %v", groupID, err)
+		return
+	}
+	return
+}
+
+// GetByID reads and returns a group by its ID
+func (rep *GroupRepository) GetByID(groupID int64) (group *models.Group, err error) {
+	group = &models.Group{}
+	err = databaseConnection.First(group, groupID).Error
+	return
+}
+
+// GetByName reads and returns a group by its name
+func (rep *GroupRepository) GetByName(name string) (group *models.Group, err error) {
+	group = &models.Group{}
+	err = databaseConnection.First(group, &models.Group{Name: name}).Error
+	return
+}
+
+// GetAll reads and returns all stored groups ordered by name
+func (rep *GroupRepository) GetAll() (groups []*models.Group, err error) {
+	err = databaseConnection.Order("name").Find(&groups).Error
+	if err != nil {
+		log.Error(0, "Could not get all groups: %v", err)
+		return
+	}
+	return
+}
+
+// SetMembers replaces all members of a group with the given users
+func (rep *GroupRepository) SetMembers(groupID int64, userIDs []int64) (err error) {
+	tx := databaseConnection.Begin()
+	if err = tx.Error; err != nil {
+		log.Error(0, "Could not begin transaction to set members of group %v: %v", groupID, err)
+		return
+	}
+
+	err = tx.Delete(&models.GroupMember{}, "group_id = ?", groupID).Error
+	if err != nil {
+		tx.Rollback()
+		log.Error(0, "Could not delete members of group %v: %v", groupID, err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		err = tx.Create(&models.GroupMember{GroupID: groupID, UserID: userID}).Error
+		if err != nil {
+			tx.Rollback()
+			log.Error(0, "Could not add user %v to group %v: %v", userID, groupID, err)
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		log.Error(0, "Could not commit members of group %v: %v", groupID, err)
+		return
+	}
+	return
+}
+
+// GetMemberIDs returns the IDs of all members of a group
+func (rep *GroupRepository) GetMemberIDs(groupID int64) (userIDs []int64, err error) {
+	userIDs = []int64{}
+	err = databaseConnection.Model(&models.GroupMember{}).Where("group_id = ?", groupID).Order("user_id").Pluck("user_id", &userIDs).Error
+	if err != nil {
+		log.Error(0, "Could not get members of group %v: %v", groupID, err)
+		return
+	}
+	return
+}
+
+// GetGroupIDsForUser returns the IDs of all groups a user is a member of
+func (rep *GroupRepository) GetGroupIDsForUser(userID int64) (groupIDs []int64, err error) {
+	err = databaseConnection.Model(&models.GroupMember{}).Where("user_id = ?", userID).Pluck("group_id", &groupIDs).Error
+	if err != nil {
+		log.Error(0, "Could not get groups of user %v: %v", userID, err)
+		return
+	}
+	return
+}
+
+// DeleteMembershipsForUser removes a user from all groups
+func (rep *GroupRepository) DeleteMembershipsForUser(userID int64) (err error) {
+	err = databaseConnection.Delete(&models.GroupMember{}, "user_id = ?", userID).Error
+	if err != nil {
+		log.Error(0, "Could not delete group memberships of user %v: %v", userID, err)
+		return
+	}
+	return
+}
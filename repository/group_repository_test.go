@@ -0,0 +1,142 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testGroupSetupFailed = false
+var testGroupDBName = "groupTest.db"
+
+func testGroupCleanup() {
+	os.Remove(testGroupDBName)
+}
+
+func testGroupSetup() *GroupRepository {
+	testGroupCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testGroupDBName)
+	rep, _ := CreateGroupRepository()
+	return rep
+}
+
+func TestCreateGroupRepository(t *testing.T) {
+	testGroupCleanup()
+	defer testGroupCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testGroupDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateGroupRepository()
+	if err != nil {
+		t.Errorf("Failed to create group repository: %v", err)
+	}
+
+	if t.Failed() {
+		testGroupSetupFailed = true
+	}
+}
+
+func TestCreateUpdateAndDeleteGroup(t *testing.T) {
+	if testGroupSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testGroupCleanup()
+	rep := testGroupSetup()
+
+	group := &models.Group{Name: "support"}
+	err := rep.Create(group)
+	if err != nil {
+		t.Fatalf("Failed to create group: %v", err)
+	}
+	err = rep.Create(&models.Group{Name: "support"})
+	if err == nil {
+		t.Errorf("Expected creating a group with a duplicate name to fail")
+	}
+
+	group.Name = "helpdesk"
+	err = rep.Update(group)
+	if err != nil {
+		t.Errorf("Failed to update group: %v", err)
+	}
+	readBack, err := rep.GetByName("helpdesk")
+	if err != nil || readBack.ID != group.ID || readBack.Created == 0 {
+		t.Errorf("Failed to read back renamed group: %v, %v", readBack, err)
+	}
+	err = rep.Update(&models.Group{ID: group.ID + 1, Name: "unknown"})
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected updating an unknown group to fail with not found but got: %v", err)
+	}
+
+	rep.SetMembers(group.ID, []int64{1, 2})
+	err = rep.Delete(group.ID)
+	if err != nil {
+		t.Errorf("Failed to delete group: %v", err)
+	}
+	_, err = rep.GetByID(group.ID)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected deleted group to not be found but got: %v", err)
+	}
+	groupIDs, _ := rep.GetGroupIDsForUser(1)
+	if len(groupIDs) != 0 {
+		t.Errorf("Expected no memberships after deleting the group but got: %v", groupIDs)
+	}
+	err = rep.Delete(group.ID)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected deleting a deleted group to fail with not found but got: %v", err)
+	}
+}
+
+func TestGroupMembers(t *testing.T) {
+	if testGroupSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testGroupCleanup()
+	rep := testGroupSetup()
+
+	group0 := &models.Group{Name: "group0"}
+	group1 := &models.Group{Name: "group1"}
+	rep.Create(group0)
+	rep.Create(group1)
+
+	err := rep.SetMembers(group0.ID, []int64{3, 1})
+	if err != nil {
+		t.Fatalf("Failed to set members of group0: %v", err)
+	}
+	rep.SetMembers(group1.ID, []int64{1})
+	err = rep.SetMembers(group0.ID, []int64{1, 2})
+	if err != nil {
+		t.Fatalf("Failed to replace members of group0: %v", err)
+	}
+
+	memberIDs, err := rep.GetMemberIDs(group0.ID)
+	if err != nil || !reflect.DeepEqual(memberIDs, []int64{1, 2}) {
+		t.Errorf("Expected members [1 2] of group0 but got: %v, %v", memberIDs, err)
+	}
+	groupIDs, err := rep.GetGroupIDsForUser(1)
+	if err != nil || len(groupIDs) != 2 {
+		t.Errorf("Expected user 1 to be member of 2 groups but got: %v, %v", groupIDs, err)
+	}
+
+	err = rep.DeleteMembershipsForUser(1)
+	if err != nil {
+		t.Errorf("Failed to delete memberships of user 1: %v", err)
+	}
+	groupIDs, _ = rep.GetGroupIDsForUser(1)
+	if len(groupIDs) != 0 {
+		t.Errorf("Expected no memberships of user 1 after deletion but got: %v", groupIDs)
+	}
+	memberIDs, _ = rep.GetMemberIDs(group0.ID)
+	if !reflect.DeepEqual(memberIDs, []int64{2}) {
+		t.Errorf("Expected members [2] of group0 after deletion but got: %v", memberIDs)
+	}
+
+	groups, err := rep.GetAll()
+	if err != nil || len(groups) != 2 || groups[0].Name != "group0" {
+		t.Errorf("Expected 2 groups ordered by name but got: %v, %v", groups, err)
+	}
+}
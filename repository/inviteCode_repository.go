@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// ErrInviteCodeUnusable is returned if an invite code is expired or has no uses left
+var ErrInviteCodeUnusable = errors.New("invite code repository: invite code expired or used up")
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.InviteCode{})
+}
+
+// InviteCodeRepository represents the database for storing invite codes
+type InviteCodeRepository struct{}
+
+// CreateInviteCodeRepository creates a new InviteCodeRepository IF gorm has been initialized before
+func CreateInviteCodeRepository() (*InviteCodeRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &InviteCodeRepository{}, nil
+}
+
+// Create stores a new invite code
+func (rep *InviteCodeRepository) Create(inviteCode *models.InviteCode) (err error) {
+	inviteCode.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(inviteCode).Error
+	if err != nil {
+		log.Error(0, "Could not create invite code: %v", err)
+		return
+	}
+	return
+}
+
+// Delete deletes an invite code by its ID
+func (rep *InviteCodeRepository) Delete(inviteCodeID int64) (err error) {
+	db := databaseConnection.Delete(&models.InviteCode{ID: inviteCodeID})
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not delete invite code with ID %v: %v", inviteCodeID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// GetByCode reads and returns an invite code by its code
+func (rep *InviteCodeRepository) GetByCode(code string) (inviteCode *models.InviteCode, err error) {
+	inviteCode = &models.InviteCode{}
+	err = databaseConnection.First(inviteCode, &models.InviteCode{Code: code}).Error
+	return
+}
+
+// GetAll reads and returns all stored invite codes
+func (rep *InviteCodeRepository) GetAll() (inviteCodes []*models.InviteCode, err error) {
+	err = databaseConnection.Find(&inviteCodes).Error
+	if err != nil {
+		log.Error(0, "Could not get all invite codes: %v", err)
+		return
+	}
+	return
+}
+
+// Use counts a redemption of the invite code if it is neither expired nor used up.
+// The check and the increment happen in one statement, so concurrent signups cannot exceed the usage limit.
+func (rep *InviteCodeRepository) Use(code string) (err error) {
+	db := databaseConnection.Model(&models.InviteCode{}).
+		Where("code = ? AND (max_uses = 0 OR uses < max_uses) AND (expires_at = 0 OR expires_at > ?)", code, utils.GetTimestampNow()).
+		UpdateColumn("uses", gorm.Expr("uses + 1"))
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not use invite code: %v", err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return ErrInviteCodeUnusable
+	}
+	return
+}
+
+// Release gives back a use of an invite code, e.g. if the signup failed after redeeming it
+func (rep *InviteCodeRepository) Release(code string) (err error) {
+	err = databaseConnection.Model(&models.InviteCode{}).
+		Where("code = ? AND uses > 0", code).
+		UpdateColumn("uses", gorm.Expr("uses - 1")).Error
+	if err != nil {
+		log.Error(0, "Could not release invite code: %v", err)
+		return
+	}
+	return
+}
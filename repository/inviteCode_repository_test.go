@@ -0,0 +1,174 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+)
+
+var testInviteCodeSetupFailed = false
+var testInviteCodeDBName = "inviteCodeTest.db"
+var testInviteCode0 = &models.InviteCode{Code: "code0", CreatedBy: 1, MaxUses: 2}
+var testInviteCode1 = &models.InviteCode{Code: "code1", CreatedBy: 1, ExpiresAt: 1000}
+var testInviteCode2 = &models.InviteCode{Code: "code2", CreatedBy: 1}
+
+func testInviteCodeCleanup() {
+	os.Remove(testInviteCodeDBName)
+}
+
+func testInviteCodeSetup() *InviteCodeRepository {
+	testInviteCodeCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testInviteCodeDBName)
+	rep, _ := CreateInviteCodeRepository()
+	return rep
+}
+
+func testInviteCodeInsert(rep *InviteCodeRepository) {
+	rep.Create(testInviteCode0)
+	rep.Create(testInviteCode1)
+	rep.Create(testInviteCode2)
+}
+
+func TestCreateInviteCodeRepository(t *testing.T) {
+	testInviteCodeCleanup()
+	defer testInviteCodeCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testInviteCodeDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateInviteCodeRepository()
+	if err != nil {
+		t.Errorf("Failed to create invite code repository: %v", err)
+	}
+
+	if t.Failed() {
+		testInviteCodeSetupFailed = true
+	}
+}
+
+func TestCreateAndGetInviteCode(t *testing.T) {
+	if testInviteCodeSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testInviteCodeCleanup()
+	rep := testInviteCodeSetup()
+
+	testInviteCodeInsert(rep)
+
+	readBackCode, err := rep.GetByCode(testInviteCode0.Code)
+	if err != nil {
+		t.Fatalf("Failed to read back invite code0: %v", err)
+	}
+	if !reflect.DeepEqual(readBackCode, testInviteCode0) {
+		t.Errorf("Read back invite code0 and invite code0 not deeply equal: %v != %v", readBackCode, testInviteCode0)
+	}
+
+	inviteCodes, err := rep.GetAll()
+	if err != nil {
+		t.Fatalf("Failed to get all invite codes: %v", err)
+	}
+	if len(inviteCodes) != 3 {
+		t.Errorf("Expected 3 invite codes, got %d", len(inviteCodes))
+	}
+
+	_, err = rep.GetByCode("unknown")
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for unknown invite code, got %v", err)
+	}
+}
+
+func TestDeleteInviteCode(t *testing.T) {
+	if testInviteCodeSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testInviteCodeCleanup()
+	rep := testInviteCodeSetup()
+
+	testInviteCodeInsert(rep)
+
+	err := rep.Delete(testInviteCode0.ID)
+	if err != nil {
+		t.Errorf("Failed to delete invite code0: %v", err)
+	}
+	_, err = rep.GetByCode(testInviteCode0.Code)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleted invite code, got %v", err)
+	}
+
+	err = rep.Delete(testInviteCode0.ID)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting invite code twice, got %v", err)
+	}
+}
+
+func TestUseInviteCode(t *testing.T) {
+	if testInviteCodeSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testInviteCodeCleanup()
+	rep := testInviteCodeSetup()
+
+	testInviteCodeInsert(rep)
+
+	for i := 0; i < int(testInviteCode0.MaxUses); i++ {
+		if err := rep.Use(testInviteCode0.Code); err != nil {
+			t.Errorf("Failed to use invite code0 %d times: %v", i+1, err)
+		}
+	}
+	if err := rep.Use(testInviteCode0.Code); err != ErrInviteCodeUnusable {
+		t.Errorf("Expected used up invite code to be unusable, got %v", err)
+	}
+	readBackCode, _ := rep.GetByCode(testInviteCode0.Code)
+	if readBackCode.Uses != testInviteCode0.MaxUses {
+		t.Errorf("Expected %d uses of invite code0, got %d", testInviteCode0.MaxUses, readBackCode.Uses)
+	}
+
+	if testInviteCode1.ExpiresAt > utils.GetTimestampNow() {
+		t.Fatalf("Invite code1 is expected to be expired")
+	}
+	if err := rep.Use(testInviteCode1.Code); err != ErrInviteCodeUnusable {
+		t.Errorf("Expected expired invite code to be unusable, got %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if err := rep.Use(testInviteCode2.Code); err != nil {
+			t.Errorf("Failed to use unlimited invite code2: %v", err)
+		}
+	}
+
+	if err := rep.Use("unknown"); err != ErrInviteCodeUnusable {
+		t.Errorf("Expected unknown invite code to be unusable, got %v", err)
+	}
+}
+
+func TestReleaseInviteCode(t *testing.T) {
+	if testInviteCodeSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testInviteCodeCleanup()
+	rep := testInviteCodeSetup()
+
+	testInviteCodeInsert(rep)
+
+	for i := 0; i < int(testInviteCode0.MaxUses); i++ {
+		rep.Use(testInviteCode0.Code)
+	}
+	if err := rep.Release(testInviteCode0.Code); err != nil {
+		t.Errorf("Failed to release invite code0: %v", err)
+	}
+	if err := rep.Use(testInviteCode0.Code); err != nil {
+		t.Errorf("Failed to use released invite code0 again: %v", err)
+	}
+
+	// Releasing an unused code must not lead to negative uses
+	rep.Release(testInviteCode2.Code)
+	readBackCode, _ := rep.GetByCode(testInviteCode2.Code)
+	if readBackCode.Uses != 0 {
+		t.Errorf("Expected 0 uses of invite code2 after releasing it unused, got %d", readBackCode.Uses)
+	}
+}
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	log "gopkg.in/clog.v1"
+	"gopkg.in/ldap.v2"
+
+	"github.com/freecloudio/server/models"
+)
+
+const ldapTimeout = 10 * time.Second
+
+var (
+	// ErrLDAPInvalidCredentials is returned if the user is not found or the password does not match
+	ErrLDAPInvalidCredentials = errors.New("ldap repository: invalid credentials")
+	// ErrLDAPInvalidConfig is returned if the ldap repository is created with an incomplete configuration
+	ErrLDAPInvalidConfig = errors.New("ldap repository: invalid configuration")
+)
+
+// LDAPConfig contains everything needed to authenticate users against a LDAP directory
+type LDAPConfig struct {
+	// URL of the directory, use ldaps:// for TLS
+	URL                string
+	StartTLS           bool
+	InsecureSkipVerify bool
+
+	// BindDN and BindPassword of the account used for searching users, leave empty for anonymous binds
+	BindDN       string
+	BindPassword string
+
+	BaseDN string
+	// UserFilter is used to find the user entry, %s is replaced with the escaped email
+	UserFilter string
+
+	EmailAttribute     string
+	FirstNameAttribute string
+	LastNameAttribute  string
+
+	// Members of the group with AdminGroupDN become admins, the group entry lists them in GroupMemberAttribute
+	AdminGroupDN         string
+	GroupMemberAttribute string
+}
+
+// LDAPRepository represents a LDAP directory used for authenticating users
+type LDAPRepository struct {
+	config LDAPConfig
+	host   string
+	useTLS bool
+}
+
+// CreateLDAPRepository creates a new LDAPRepository IF the given configuration is complete
+func CreateLDAPRepository(config LDAPConfig) (*LDAPRepository, error) {
+	ldapURL, err := url.Parse(config.URL)
+	if err != nil || config.BaseDN == "" || !strings.Contains(config.UserFilter, "%s") || config.EmailAttribute == "" {
+		log.Error(0, "Incomplete LDAP configuration for %s", config.URL)
+		return nil, ErrLDAPInvalidConfig
+	}
+
+	rep := &LDAPRepository{config: config, host: ldapURL.Host}
+	switch ldapURL.Scheme {
+	case "ldaps":
+		rep.useTLS = true
+		if ldapURL.Port() == "" {
+			rep.host = net.JoinHostPort(ldapURL.Hostname(), "636")
+		}
+	case "ldap":
+		if ldapURL.Port() == "" {
+			rep.host = net.JoinHostPort(ldapURL.Hostname(), "389")
+		}
+	default:
+		log.Error(0, "Unsupported LDAP scheme %s", ldapURL.Scheme)
+		return nil, ErrLDAPInvalidConfig
+	}
+	if rep.useTLS && config.StartTLS {
+		log.Error(0, "LDAP StartTLS cannot be used with ldaps")
+		return nil, ErrLDAPInvalidConfig
+	}
+
+	return rep, nil
+}
+
+// HasAdminGroup returns whether the admin status of users is managed by the directory
+func (rep *LDAPRepository) HasAdminGroup() bool {
+	return rep.config.AdminGroupDN != ""
+}
+
+// Authenticate verifies the credentials against the directory and returns the user as found in the directory.
+// The admin status is only set if an admin group is configured.
+func (rep *LDAPRepository) Authenticate(email, password string) (user *models.User, err error) {
+	// An empty password would result in an unauthenticated bind, which always succeeds
+	if password == "" {
+		return nil, ErrLDAPInvalidCredentials
+	}
+
+	conn, err := rep.connect()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	entry, err := rep.searchUser(conn, email)
+	if err != nil {
+		return
+	}
+
+	err = conn.Bind(entry.DN, password)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+		return nil, ErrLDAPInvalidCredentials
+	} else if err != nil {
+		log.Error(0, "Could not bind as LDAP user %s: %v", entry.DN, err)
+		return
+	}
+
+	user = &models.User{
+		Email:     entry.GetAttributeValue(rep.config.EmailAttribute),
+		FirstName: entry.GetAttributeValue(rep.config.FirstNameAttribute),
+		LastName:  entry.GetAttributeValue(rep.config.LastNameAttribute),
+	}
+	if user.Email == "" {
+		user.Email = email
+	}
+
+	if rep.HasAdminGroup() {
+		user.IsAdmin, err = rep.isAdmin(conn, entry.DN)
+	}
+	return
+}
+
+// connect dials the directory, secures the connection if configured and binds with the search account
+func (rep *LDAPRepository) connect() (conn *ldap.Conn, err error) {
+	tlsConfig := &tls.Config{
+		ServerName:         strings.Split(rep.host, ":")[0],
+		InsecureSkipVerify: rep.config.InsecureSkipVerify,
+	}
+
+	if rep.useTLS {
+		conn, err = ldap.DialTLS("tcp", rep.host, tlsConfig)
+	} else {
+		conn, err = ldap.Dial("tcp", rep.host)
+	}
+	if err != nil {
+		log.Error(0, "Could not connect to LDAP server %s: %v", rep.host, err)
+		return
+	}
+	conn.SetTimeout(ldapTimeout)
+
+	if rep.config.StartTLS {
+		err = conn.StartTLS(tlsConfig)
+		if err != nil {
+			log.Error(0, "Could not start TLS with LDAP server %s: %v", rep.host, err)
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	err = rep.bindSearchAccount(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return
+}
+
+func (rep *LDAPRepository) bindSearchAccount(conn *ldap.Conn) (err error) {
+	if rep.config.BindDN == "" {
+		return
+	}
+	err = conn.Bind(rep.config.BindDN, rep.config.BindPassword)
+	if err != nil {
+		log.Error(0, "Could not bind as LDAP search account %s: %v", rep.config.BindDN, err)
+	}
+	return
+}
+
+func (rep *LDAPRepository) searchUser(conn *ldap.Conn, email string) (entry *ldap.Entry, err error) {
+	attributes := []string{"dn", rep.config.EmailAttribute}
+	for _, attr := range []string{rep.config.FirstNameAttribute, rep.config.LastNameAttribute} {
+		if attr != "" {
+			attributes = append(attributes, attr)
+		}
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		rep.config.BaseDN, ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 2, int(ldapTimeout/time.Second), false,
+		fmt.Sprintf(rep.config.UserFilter, escapeLDAPFilter(email)),
+		attributes, nil,
+	)
+	result, err := conn.Search(searchRequest)
+	if err != nil {
+		log.Error(0, "Could not search LDAP user %s: %v", email, err)
+		return
+	}
+
+	// we intentionally don't distinguish between a missing user and a wrong password
+	if len(result.Entries) != 1 {
+		log.Warn("Found %d LDAP users for %s", len(result.Entries), email)
+		return nil, ErrLDAPInvalidCredentials
+	}
+	return result.Entries[0], nil
+}
+
+// isAdmin checks whether the admin group lists userDN as a member, using the search account again
+func (rep *LDAPRepository) isAdmin(conn *ldap.Conn, userDN string) (isAdmin bool, err error) {
+	err = rep.bindSearchAccount(conn)
+	if err != nil {
+		return
+	}
+
+	searchRequest := ldap.NewSearchRequest(
+		rep.config.AdminGroupDN, ldap.ScopeBaseObject, ldap.NeverDerefAliases, 1, int(ldapTimeout/time.Second), false,
+		fmt.Sprintf("(%s=%s)", rep.config.GroupMemberAttribute, escapeLDAPFilter(userDN)),
+		[]string{"dn"}, nil,
+	)
+	result, err := conn.Search(searchRequest)
+	if ldap.IsErrorWithCode(err, ldap.LDAPResultNoSuchObject) {
+		log.Warn("LDAP admin group %s does not exist", rep.config.AdminGroupDN)
+		return false, nil
+	} else if err != nil {
+		log.Error(0, "Could not search LDAP admin group for %s: %v", userDN, err)
+		return
+	}
+
+	return len(result.Entries) > 0, nil
+}
+
+// escapeLDAPFilter escapes all special characters of a filter value as defined in RFC 4515
+func escapeLDAPFilter(value string) string {
+	var escaped strings.Builder
+	for i := 0; i < len(value); i++ {
+		switch c := value[i]; c {
+		case '\\', '*', '(', ')', 0:
+			escaped.WriteString(fmt.Sprintf("\\%02x", c))
+		default:
+			escaped.WriteByte(c)
+		}
+	}
+	return escaped.String()
+}
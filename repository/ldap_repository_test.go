@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	ber "gopkg.in/asn1-ber.v1"
+	"gopkg.in/ldap.v2"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testLDAPServiceDN = "cn=freecloud,dc=example,dc=com"
+var testLDAPServicePassword = "servicePassword"
+var testLDAPAdminGroupDN = "cn=admins,ou=groups,dc=example,dc=com"
+var testLDAPUserPassword = "userPassword"
+var testLDAPEntries = map[string]map[string][]string{
+	"uid=alice,ou=people,dc=example,dc=com": {
+		"objectClass": {"inetOrgPerson"}, "mail": {"alice@example.com"}, "givenName": {"Alice"}, "sn": {"Admin"},
+	},
+	"uid=bob,ou=people,dc=example,dc=com": {
+		"objectClass": {"inetOrgPerson"}, "mail": {"bob@example.com"}, "givenName": {"Bob"}, "sn": {"User"},
+	},
+	"uid=(eve),ou=people,dc=example,dc=com": {
+		"objectClass": {"inetOrgPerson"}, "mail": {"eve*@example.com"}, "givenName": {"Eve"}, "sn": {"Special"},
+	},
+	testLDAPAdminGroupDN: {
+		"objectClass": {"groupOfNames"}, "member": {"uid=alice,ou=people,dc=example,dc=com"},
+	},
+}
+
+// testLDAPServer is a minimal stand-in for an OpenLDAP server, it only supports simple binds and searches
+type testLDAPServer struct {
+	listener net.Listener
+}
+
+func testLDAPServerStart(t *testing.T) *testLDAPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Failed to start fake ldap server: %v", err)
+	}
+	srv := &testLDAPServer{listener: listener}
+	go srv.serve()
+	return srv
+}
+
+func (srv *testLDAPServer) close() {
+	srv.listener.Close()
+}
+
+func (srv *testLDAPServer) config() LDAPConfig {
+	return LDAPConfig{
+		URL:                  "ldap://" + srv.listener.Addr().String(),
+		BindDN:               testLDAPServiceDN,
+		BindPassword:         testLDAPServicePassword,
+		BaseDN:               "dc=example,dc=com",
+		UserFilter:           "(&(objectClass=inetOrgPerson)(mail=%s))",
+		EmailAttribute:       "mail",
+		FirstNameAttribute:   "givenName",
+		LastNameAttribute:    "sn",
+		AdminGroupDN:         testLDAPAdminGroupDN,
+		GroupMemberAttribute: "member",
+	}
+}
+
+func (srv *testLDAPServer) serve() {
+	for {
+		conn, err := srv.listener.Accept()
+		if err != nil {
+			return
+		}
+		go srv.handle(conn)
+	}
+}
+
+func (srv *testLDAPServer) handle(conn net.Conn) {
+	defer conn.Close()
+	for {
+		packet, err := ber.ReadPacket(conn)
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+		messageID := packet.Children[0].Value.(int64)
+		request := packet.Children[1]
+
+		switch request.Tag {
+		case ldap.ApplicationBindRequest:
+			dn := request.Children[1].Value.(string)
+			password := request.Children[2].Data.String()
+			resultCode := uint8(ldap.LDAPResultInvalidCredentials)
+			if (dn == testLDAPServiceDN && password == testLDAPServicePassword) || (testLDAPEntries[dn] != nil && password == testLDAPUserPassword) {
+				resultCode = ldap.LDAPResultSuccess
+			}
+			conn.Write(testLDAPResponse(messageID, ldap.ApplicationBindResponse, resultCode).Bytes())
+		case ldap.ApplicationSearchRequest:
+			baseDN := request.Children[0].Value.(string)
+			scope := request.Children[1].Value.(int64)
+			for dn, attributes := range testLDAPEntries {
+				inScope := dn == baseDN || (scope == ldap.ScopeWholeSubtree && strings.HasSuffix(dn, ","+baseDN))
+				if inScope && testLDAPMatches(request.Children[6], attributes) {
+					conn.Write(testLDAPEntry(messageID, dn, attributes).Bytes())
+				}
+			}
+			resultCode := ldap.LDAPResultSuccess
+			if scope == ldap.ScopeBaseObject && testLDAPEntries[baseDN] == nil {
+				resultCode = ldap.LDAPResultNoSuchObject
+			}
+			conn.Write(testLDAPResponse(messageID, ldap.ApplicationSearchResultDone, uint8(resultCode)).Bytes())
+		default:
+			return
+		}
+	}
+}
+
+func testLDAPMatches(filter *ber.Packet, attributes map[string][]string) bool {
+	switch filter.Tag {
+	case ldap.FilterAnd:
+		for _, child := range filter.Children {
+			if !testLDAPMatches(child, attributes) {
+				return false
+			}
+		}
+		return true
+	case ldap.FilterOr:
+		for _, child := range filter.Children {
+			if testLDAPMatches(child, attributes) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterEqualityMatch:
+		for _, value := range attributes[filter.Children[0].Value.(string)] {
+			if value == filter.Children[1].Value.(string) {
+				return true
+			}
+		}
+		return false
+	case ldap.FilterPresent:
+		return len(attributes[filter.Data.String()]) > 0
+	}
+	return false
+}
+
+func testLDAPResponse(messageID int64, tag ber.Tag, resultCode uint8) *ber.Packet {
+	packet := ber.NewSequence("LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	response := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Response")
+	response.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, uint64(resultCode), "Result Code"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	response.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+	packet.AppendChild(response)
+	return packet
+}
+
+func testLDAPEntry(messageID int64, dn string, attributes map[string][]string) *ber.Packet {
+	packet := ber.NewSequence("LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "MessageID"))
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, dn, "Object Name"))
+	attributeList := ber.NewSequence("Attributes")
+	for name, values := range attributes {
+		attribute := ber.NewSequence("Attribute")
+		attribute.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+		valueSet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		for _, value := range values {
+			valueSet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+		}
+		attribute.AppendChild(valueSet)
+		attributeList.AppendChild(attribute)
+	}
+	entry.AppendChild(attributeList)
+	packet.AppendChild(entry)
+	return packet
+}
+
+func TestCreateLDAPRepository(t *testing.T) {
+	validConfig := LDAPConfig{URL: "ldap://localhost", BaseDN: "dc=example,dc=com", UserFilter: "(mail=%s)", EmailAttribute: "mail"}
+
+	rep, err := CreateLDAPRepository(validConfig)
+	if err != nil {
+		t.Fatalf("Failed to create ldap repository: %v", err)
+	}
+	if rep.host != "localhost:389" || rep.useTLS {
+		t.Errorf("Expected plain connection to localhost:389, got %s with tls %v", rep.host, rep.useTLS)
+	}
+
+	tlsConfig := validConfig
+	tlsConfig.URL = "ldaps://localhost"
+	rep, err = CreateLDAPRepository(tlsConfig)
+	if err != nil {
+		t.Fatalf("Failed to create ldaps repository: %v", err)
+	}
+	if rep.host != "localhost:636" || !rep.useTLS {
+		t.Errorf("Expected tls connection to localhost:636, got %s with tls %v", rep.host, rep.useTLS)
+	}
+
+	invalidConfigs := map[string]func(config *LDAPConfig){
+		"scheme":         func(config *LDAPConfig) { config.URL = "http://localhost" },
+		"startTLS":       func(config *LDAPConfig) { config.URL = "ldaps://localhost"; config.StartTLS = true },
+		"baseDN":         func(config *LDAPConfig) { config.BaseDN = "" },
+		"filter":         func(config *LDAPConfig) { config.UserFilter = "(mail=*)" },
+		"emailAttribute": func(config *LDAPConfig) { config.EmailAttribute = "" },
+	}
+	for name, modify := range invalidConfigs {
+		config := validConfig
+		modify(&config)
+		if _, err = CreateLDAPRepository(config); err != ErrLDAPInvalidConfig {
+			t.Errorf("Expected invalid config error for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestLDAPAuthenticate(t *testing.T) {
+	srv := testLDAPServerStart(t)
+	defer srv.close()
+
+	rep, err := CreateLDAPRepository(srv.config())
+	if err != nil {
+		t.Fatalf("Failed to create ldap repository: %v", err)
+	}
+
+	testCases := map[string]struct {
+		email    string
+		password string
+		user     *models.User
+		err      error
+	}{
+		"admin":         {"alice@example.com", testLDAPUserPassword, &models.User{Email: "alice@example.com", FirstName: "Alice", LastName: "Admin", IsAdmin: true}, nil},
+		"user":          {"bob@example.com", testLDAPUserPassword, &models.User{Email: "bob@example.com", FirstName: "Bob", LastName: "User"}, nil},
+		"escaped":       {"eve*@example.com", testLDAPUserPassword, &models.User{Email: "eve*@example.com", FirstName: "Eve", LastName: "Special"}, nil},
+		"wrongPassword": {"bob@example.com", "wrong", nil, ErrLDAPInvalidCredentials},
+		"emptyPassword": {"bob@example.com", "", nil, ErrLDAPInvalidCredentials},
+		"unknownUser":   {"mallory@example.com", testLDAPUserPassword, nil, ErrLDAPInvalidCredentials},
+		"injection":     {"*", testLDAPUserPassword, nil, ErrLDAPInvalidCredentials},
+	}
+
+	for name, testCase := range testCases {
+		user, err := rep.Authenticate(testCase.email, testCase.password)
+		if err != testCase.err {
+			t.Errorf("Expected error %v for %s, got %v", testCase.err, name, err)
+		}
+		if !reflect.DeepEqual(user, testCase.user) {
+			t.Errorf("Authenticated user and expected user for %s not deeply equal: %v != %v", name, user, testCase.user)
+		}
+	}
+}
+
+func TestLDAPAuthenticateWithoutAdminGroup(t *testing.T) {
+	srv := testLDAPServerStart(t)
+	defer srv.close()
+
+	config := srv.config()
+	config.AdminGroupDN = ""
+	rep, _ := CreateLDAPRepository(config)
+	if rep.HasAdminGroup() {
+		t.Errorf("Expected no admin group to be configured")
+	}
+
+	user, err := rep.Authenticate("alice@example.com", testLDAPUserPassword)
+	if err != nil {
+		t.Fatalf("Failed to authenticate ldap user: %v", err)
+	}
+	if user.IsAdmin {
+		t.Errorf("Expected admin status not to be set without admin group")
+	}
+}
+
+func TestLDAPAuthenticateUnavailable(t *testing.T) {
+	srv := testLDAPServerStart(t)
+	config := srv.config()
+	srv.close()
+
+	rep, _ := CreateLDAPRepository(config)
+	_, err := rep.Authenticate("alice@example.com", testLDAPUserPassword)
+	if err == nil || err == ErrLDAPInvalidCredentials {
+		t.Errorf("Expected connection error for unavailable server, got %v", err)
+	}
+}
+
+func TestEscapeLDAPFilter(t *testing.T) {
+	escaped := escapeLDAPFilter("a*b(c)d\\e\x00")
+	if escaped != "a\\2ab\\28c\\29d\\5ce\\00" {
+		t.Errorf("Expected filter value to be escaped, got %s", escaped)
+	}
+}
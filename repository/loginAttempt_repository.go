@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.LoginAttempt{})
+}
+
+// LoginAttemptRepository represents the database for storing failed login attempts
+type LoginAttemptRepository struct{}
+
+// CreateLoginAttemptRepository creates a new LoginAttemptRepository IF gorm has been initialized before
+func CreateLoginAttemptRepository() (*LoginAttemptRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &LoginAttemptRepository{}, nil
+}
+
+// Save creates or updates the login attempts for attempt.Subject
+func (rep *LoginAttemptRepository) Save(attempt *models.LoginAttempt) (err error) {
+	err = databaseConnection.Save(attempt).Error
+	if err != nil {
+		log.Error(0, "Could not save login attempt for %s: %v", attempt.Subject, err)
+		return
+	}
+	return
+}
+
+// AddFailure atomically counts a failed login for subject at now and returns the updated login attempts.
+// If the last failure happened before resetBefore counting starts from scratch.
+func (rep *LoginAttemptRepository) AddFailure(subject string, now, resetBefore int64) (attempt *models.LoginAttempt, err error) {
+	// If there are no attempts yet, they are created. Should another failure have created them meanwhile, they are updated again.
+	for i := 0; i < 2; i++ {
+		db := databaseConnection.Model(&models.LoginAttempt{}).Where("subject = ?", subject).UpdateColumns(map[string]interface{}{
+			"failures":     gorm.Expr("CASE WHEN last_failure < ? THEN 1 ELSE failures + 1 END", resetBefore),
+			"last_failure": now,
+		})
+		err = db.Error
+		if err != nil || db.RowsAffected > 0 {
+			break
+		}
+		err = databaseConnection.Create(&models.LoginAttempt{Subject: subject, Failures: 1, LastFailure: now}).Error
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		log.Error(0, "Could not add failed login for %s: %v", subject, err)
+		return
+	}
+	return rep.GetBySubject(subject)
+}
+
+// SetLockedUntil locks the subject out of logins until the given timestamp
+func (rep *LoginAttemptRepository) SetLockedUntil(subject string, lockedUntil int64) (err error) {
+	err = databaseConnection.Model(&models.LoginAttempt{}).Where("subject = ?", subject).UpdateColumn("locked_until", lockedUntil).Error
+	if err != nil {
+		log.Error(0, "Could not lock out %s: %v", subject, err)
+		return
+	}
+	return
+}
+
+// GetBySubject reads and returns the login attempts for a subject
+func (rep *LoginAttemptRepository) GetBySubject(subject string) (attempt *models.LoginAttempt, err error) {
+	attempt = &models.LoginAttempt{}
+	err = databaseConnection.First(attempt, "subject = ?", subject).Error
+	return
+}
+
+// Delete deletes the login attempts for a subject
+func (rep *LoginAttemptRepository) Delete(subject string) (err error) {
+	err = databaseConnection.Delete(&models.LoginAttempt{Subject: subject}).Error
+	if err != nil {
+		log.Error(0, "Could not delete login attempts for %s: %v", subject, err)
+		return
+	}
+	return
+}
+
+// DeleteOlderThan deletes all login attempts whose last failure happened before the given timestamp
+func (rep *LoginAttemptRepository) DeleteOlderThan(timestamp int64) (err error) {
+	err = databaseConnection.Where("last_failure < ? AND locked_until < ?", timestamp, timestamp).Delete(&models.LoginAttempt{}).Error
+	if err != nil {
+		log.Error(0, "Deleting old login attempts failed: %v", err)
+		return
+	}
+	return
+}
+
+// Count returns the amount of stored login attempts
+func (rep *LoginAttemptRepository) Count() (count int64, err error) {
+	err = databaseConnection.Model(&models.LoginAttempt{}).Count(&count).Error
+	if err != nil {
+		log.Error(0, "Error counting login attempts: %v", err)
+		return
+	}
+	return
+}
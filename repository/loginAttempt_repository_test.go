@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testLoginAttemptSetupFailed = false
+var testLoginAttemptDBName = "loginAttemptTest.db"
+var testLoginAttempt0 = &models.LoginAttempt{Subject: "account:user@email.com", Failures: 3, LastFailure: 2000, LockedUntil: 2060}
+var testLoginAttempt1 = &models.LoginAttempt{Subject: "ip:192.0.2.1", Failures: 1, LastFailure: 1000}
+
+func testLoginAttemptCleanup() {
+	os.Remove(testLoginAttemptDBName)
+}
+
+func testLoginAttemptSetup() *LoginAttemptRepository {
+	testLoginAttemptCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testLoginAttemptDBName)
+	rep, _ := CreateLoginAttemptRepository()
+	return rep
+}
+
+func testLoginAttemptInsert(rep *LoginAttemptRepository) {
+	rep.Save(testLoginAttempt0)
+	rep.Save(testLoginAttempt1)
+}
+
+func TestCreateLoginAttemptRepository(t *testing.T) {
+	testLoginAttemptCleanup()
+	defer testLoginAttemptCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testLoginAttemptDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateLoginAttemptRepository()
+	if err != nil {
+		t.Errorf("Failed to create login attempt repository: %v", err)
+	}
+
+	if t.Failed() {
+		testLoginAttemptSetupFailed = true
+	}
+}
+
+func TestSaveAndGetLoginAttempt(t *testing.T) {
+	if testLoginAttemptSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testLoginAttemptCleanup()
+	rep := testLoginAttemptSetup()
+
+	testLoginAttemptInsert(rep)
+
+	readBackAttempt, err := rep.GetBySubject(testLoginAttempt0.Subject)
+	if err != nil {
+		t.Errorf("Failed to read back attempt0: %v", err)
+	}
+	if !reflect.DeepEqual(readBackAttempt, testLoginAttempt0) {
+		t.Errorf("Read back attempt0 and attempt0 not deeply equal: %v != %v", readBackAttempt, testLoginAttempt0)
+	}
+
+	updatedAttempt := *testLoginAttempt1
+	updatedAttempt.Failures++
+	err = rep.Save(&updatedAttempt)
+	if err != nil {
+		t.Errorf("Failed to update attempt1: %v", err)
+	}
+	readBackAttempt, err = rep.GetBySubject(testLoginAttempt1.Subject)
+	if err != nil {
+		t.Errorf("Failed to read back updated attempt1: %v", err)
+	}
+	if !reflect.DeepEqual(readBackAttempt, &updatedAttempt) {
+		t.Errorf("Read back attempt1 and updated attempt1 not deeply equal: %v != %v", readBackAttempt, &updatedAttempt)
+	}
+
+	count, err := rep.Count()
+	if err != nil {
+		t.Errorf("Failed to get count: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("Count unequal to two after updating attempt: %d", count)
+	}
+}
+
+func TestAddFailureLoginAttempt(t *testing.T) {
+	if testLoginAttemptSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testLoginAttemptCleanup()
+	rep := testLoginAttemptSetup()
+
+	testLoginAttemptInsert(rep)
+
+	attempt, err := rep.AddFailure(testLoginAttempt0.Subject, 2100, 1500)
+	if err != nil || attempt.Failures != testLoginAttempt0.Failures+1 || attempt.LastFailure != 2100 || attempt.LockedUntil != testLoginAttempt0.LockedUntil {
+		t.Errorf("Failed to add failure to attempt0: %v, %v", attempt, err)
+	}
+
+	attempt, err = rep.AddFailure(testLoginAttempt1.Subject, 2100, 1500)
+	if err != nil || attempt.Failures != 1 || attempt.LastFailure != 2100 {
+		t.Errorf("Failures of attempt1 before reset timestamp have not been forgotten: %v, %v", attempt, err)
+	}
+
+	// Concurrent failures for a new subject are all counted
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rep.AddFailure("ip:192.0.2.2", 2100, 1500)
+		}()
+	}
+	wg.Wait()
+	attempt, err = rep.GetBySubject("ip:192.0.2.2")
+	if err != nil || attempt.Failures != 10 {
+		t.Errorf("Expected 10 failures for concurrently failed logins: %v, %v", attempt, err)
+	}
+
+	err = rep.SetLockedUntil(testLoginAttempt1.Subject, 2200)
+	if err != nil {
+		t.Errorf("Failed to lock out attempt1: %v", err)
+	}
+	attempt, _ = rep.GetBySubject(testLoginAttempt1.Subject)
+	if attempt.LockedUntil != 2200 || attempt.Failures != 1 {
+		t.Errorf("Lock out of attempt1 has not been stored correctly: %v", attempt)
+	}
+}
+
+func TestDeleteLoginAttempt(t *testing.T) {
+	if testLoginAttemptSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testLoginAttemptCleanup()
+	rep := testLoginAttemptSetup()
+
+	testLoginAttemptInsert(rep)
+
+	err := rep.Delete(testLoginAttempt0.Subject)
+	if err != nil {
+		t.Errorf("Failed to delete attempt0: %v", err)
+	}
+
+	_, err = rep.GetBySubject(testLoginAttempt0.Subject)
+	if err == nil || !IsRecordNotFoundError(err) {
+		t.Errorf("Succeeded to read deleted attempt or error is not 'record not found': %v", err)
+	}
+}
+
+func TestDeleteOlderThanLoginAttempts(t *testing.T) {
+	if testLoginAttemptSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testLoginAttemptCleanup()
+	rep := testLoginAttemptSetup()
+
+	testLoginAttemptInsert(rep)
+
+	err := rep.DeleteOlderThan(2050)
+	if err != nil {
+		t.Errorf("Failed to delete old attempts: %v", err)
+	}
+
+	_, err = rep.GetBySubject(testLoginAttempt1.Subject)
+	if err == nil || !IsRecordNotFoundError(err) {
+		t.Errorf("Succeeded to read old attempt or error is not 'record not found': %v", err)
+	}
+	_, err = rep.GetBySubject(testLoginAttempt0.Subject)
+	if err != nil {
+		t.Errorf("Failed to read still locked attempt: %v", err)
+	}
+}
@@ -0,0 +1,482 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// MemoryStorageRepository keeps files and folders in memory, everything is lost when the process ends.
+// It is meant for fast tests and throwaway demo instances.
+type MemoryStorageRepository struct {
+	lock sync.RWMutex
+	// entries are stored by their cleaned slash separated path, the root is "/"
+	entries            map[string]*memoryStorageEntry
+	tmpName            string
+	tmpCleanupInterval int
+	tmpDataExpiry      int
+	done               chan struct{}
+}
+
+// memoryStorageEntry is a file or a folder, the content of files is replaced and never changed in place, so readers can keep it
+type memoryStorageEntry struct {
+	isDir   bool
+	content []byte
+	modTime time.Time
+}
+
+// CreateMemoryStorageRepository creates a new empty MemoryStorageRepository with a interval for temp cleanup in hours and a tmp data expiry in hours
+func CreateMemoryStorageRepository(tmpName string, tmpCleanupInterval, tmpDataExpiry int) (*MemoryStorageRepository, error) {
+	log.Info("Initialized in-memory storage, all files are lost when the server stops")
+	memoryStorageRepository := &MemoryStorageRepository{
+		entries:            map[string]*memoryStorageEntry{"/": {isDir: true, modTime: time.Now()}},
+		tmpName:            tmpName,
+		tmpCleanupInterval: tmpCleanupInterval,
+		tmpDataExpiry:      tmpDataExpiry,
+		done:               make(chan struct{}),
+	}
+
+	go memoryStorageRepository.cleanupTempFolderRoutine()
+
+	return memoryStorageRepository, nil
+}
+
+// Close closes the repository and with that ends the go routine for tmp cleanup
+func (rep *MemoryStorageRepository) Close() error {
+	rep.done <- struct{}{}
+	return nil
+}
+
+// cleanupTempFolderRoutine is the actual routine that periodically calls cleanupTempFolder
+func (rep *MemoryStorageRepository) cleanupTempFolderRoutine() {
+	log.Trace("Starting temp folder cleaner, running now and every %v hours", rep.tmpCleanupInterval)
+	rep.cleanupTempFolder()
+
+	ticker := time.NewTicker(time.Hour * time.Duration(rep.tmpCleanupInterval))
+	for {
+		select {
+		case <-rep.done:
+			return
+		case <-ticker.C:
+			rep.cleanupTempFolder()
+		}
+	}
+}
+
+// cleanupTempFolder deletes the content of all tmp folders
+func (rep *MemoryStorageRepository) cleanupTempFolder() (err error) {
+	log.Trace("Cleaning temp folder")
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	now := time.Now()
+	for _, baseName := range rep.childNames("/") {
+		tmpFolderPath := path.Join("/", baseName, rep.tmpName)
+		if entry, ok := rep.entries[tmpFolderPath]; !ok || !entry.isDir {
+			continue
+		}
+		for _, tmpName := range rep.childNames(tmpFolderPath) {
+			tmpPath := path.Join(tmpFolderPath, tmpName)
+			expires := rep.entries[tmpPath].modTime.Add(time.Hour * time.Duration(rep.tmpDataExpiry))
+			if now.After(expires) {
+				rep.deleteEntries(tmpPath)
+			}
+		}
+	}
+	return
+}
+
+// CreateHandle creates or truncates the file, the written content is stored once the handle is closed.
+// Before creating the file, it check the path for sanity.
+func (rep *MemoryStorageRepository) CreateHandle(filePath string) (StorageHandle, error) {
+	if !utils.ValidatePath(filePath) {
+		return nil, ErrForbiddenPathName
+	}
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	memoryPath := memoryStoragePath(filePath)
+	if entry, ok := rep.entries[memoryPath]; ok && entry.isDir {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrExist}
+	}
+	if !rep.isDir(path.Dir(memoryPath)) {
+		log.Error(0, "Could not create file %s: parent folder does not exist", filePath)
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+	}
+	rep.setEntry(memoryPath, &memoryStorageEntry{})
+	return &memoryStorageHandle{rep: rep, path: memoryPath}, nil
+}
+
+// OpenFile opens the file for reading from.
+// Before opening the file, it check the path for sanity.
+func (rep *MemoryStorageRepository) OpenFile(filePath string) (StorageFile, error) {
+	if !utils.ValidatePath(filePath) {
+		return nil, ErrForbiddenPathName
+	}
+
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	memoryPath := memoryStoragePath(filePath)
+	entry, ok := rep.entries[memoryPath]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist}
+	} else if entry.isDir {
+		return nil, &os.PathError{Op: "open", Path: filePath, Err: os.ErrInvalid}
+	}
+	return &memoryStorageFile{Reader: bytes.NewReader(entry.content), info: &memoryStorageFileInfo{name: path.Base(memoryPath), entry: entry}}, nil
+}
+
+// CreateDirectory checks whether directory exists and creates it and its missing parents otherwise
+func (rep *MemoryStorageRepository) CreateDirectory(dirPath string) (created bool, err error) {
+	if !utils.ValidatePath(dirPath) {
+		return false, ErrForbiddenPathName
+	}
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	memoryPath := memoryStoragePath(dirPath)
+	if rep.isDir(memoryPath) {
+		return false, nil
+	}
+
+	log.Info("Directory does not exist, creating it now")
+	parentPath := "/"
+	for _, name := range strings.Split(strings.TrimPrefix(memoryPath, "/"), "/") {
+		parentPath = path.Join(parentPath, name)
+		entry, ok := rep.entries[parentPath]
+		if ok && !entry.isDir {
+			err = &os.PathError{Op: "mkdir", Path: dirPath, Err: os.ErrExist}
+			log.Error(0, "Could not create directory %s: %v", dirPath, err)
+			return false, err
+		} else if !ok {
+			rep.setEntry(parentPath, &memoryStorageEntry{isDir: true})
+		}
+	}
+	return true, nil
+}
+
+// GetDirectoryInfo returns a list of all files and folders in the given "path" (relative to the user's directory).
+// Before doing so, it checks the path for sanity.
+func (rep *MemoryStorageRepository) GetDirectoryInfo(userPath, dirPath string) ([]*models.FileInfo, error) {
+	if !utils.ValidatePath(dirPath) {
+		return nil, ErrForbiddenPathName
+	}
+
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	memoryPath := memoryStoragePath(path.Join(userPath, filepath.ToSlash(dirPath)))
+	if !rep.isDir(memoryPath) {
+		log.Error(0, "Could not list files in %s: %v", dirPath, ErrFileNotExist)
+		return nil, ErrFileNotExist
+	}
+
+	if dirPath == "" {
+		dirPath = "/"
+	}
+	dirPath = utils.ConvertToSlash(dirPath, true)
+
+	names := rep.childNames(memoryPath)
+	fileInfos := make([]*models.FileInfo, len(names), len(names))
+	for i, name := range names {
+		fileInfos[i] = rep.generateInfo(name, rep.entries[path.Join(memoryPath, name)], dirPath)
+	}
+	return fileInfos, nil
+}
+
+// GetInfo generates and returns the fileInfo of a file in memory
+func (rep *MemoryStorageRepository) GetInfo(userPath, filePath string) (fileInfo *models.FileInfo, err error) {
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	memoryPath := memoryStoragePath(path.Join(userPath, filepath.ToSlash(filePath)))
+	entry, ok := rep.entries[memoryPath]
+	if !ok {
+		err = ErrFileNotExist
+		return
+	}
+
+	folderPath, _ := utils.SplitPath(filePath)
+	fileInfo = rep.generateInfo(path.Base(memoryPath), entry, folderPath)
+	return
+}
+
+func (rep *MemoryStorageRepository) generateInfo(name string, entry *memoryStorageEntry, path string) *models.FileInfo {
+	return &models.FileInfo{
+		Path:        utils.ConvertToSlash(path, true),
+		Name:        name,
+		IsDir:       entry.isDir,
+		Size:        int64(len(entry.content)),
+		LastChanged: entry.modTime.UTC().Unix(),
+		MimeType:    mime.TypeByExtension(filepath.Ext(name)),
+	}
+}
+
+// AddToZip writes the file or the content of the folder at path into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *MemoryStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) (err error) {
+	if !utils.ValidatePath(filePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	memoryPath := memoryStoragePath(filePath)
+	if _, ok := rep.entries[memoryPath]; !ok {
+		err = ErrFileNotExist
+	} else {
+		err = rep.addToZip(zipWriter, memoryPath, "", zipPath, skip)
+	}
+	if err != nil {
+		log.Error(0, "Error adding %v to zip: %v", filePath, err)
+		return
+	}
+	return
+}
+
+// addToZip walks the entries below memoryPath in lexical order like filepath.Walk does
+func (rep *MemoryStorageRepository) addToZip(zipWriter *zip.Writer, memoryPath, relPath, zipPath string, skip func(relPath string) bool) error {
+	if skip != nil && relPath != "" && skip(relPath) {
+		return nil
+	}
+
+	entry := rep.entries[memoryPath]
+	if entry.isDir {
+		for _, name := range rep.childNames(memoryPath) {
+			if err := rep.addToZip(zipWriter, path.Join(memoryPath, name), path.Join(relPath, name), zipPath, skip); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	header, err := zip.FileInfoHeader(&memoryStorageFileInfo{name: path.Base(memoryPath), entry: entry})
+	if err != nil {
+		return err
+	}
+	header.Name = path.Join(filepath.ToSlash(zipPath), relPath)
+	header.Method = zip.Deflate
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(entry.content)
+	return err
+}
+
+// Move moves the file/folder of oldPath to newPath, an existing file at newPath is replaced
+func (rep *MemoryStorageRepository) Move(oldPath, newPath string) (err error) {
+	if !utils.ValidatePath(oldPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	if !utils.ValidatePath(newPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	oldMemoryPath, newMemoryPath := memoryStoragePath(oldPath), memoryStoragePath(newPath)
+	entry, ok := rep.entries[oldMemoryPath]
+	if !ok || oldMemoryPath == "/" {
+		err = &os.PathError{Op: "rename", Path: oldPath, Err: os.ErrNotExist}
+	} else if !rep.isDir(path.Dir(newMemoryPath)) || strings.HasPrefix(newMemoryPath, oldMemoryPath+"/") {
+		err = &os.PathError{Op: "rename", Path: newPath, Err: os.ErrInvalid}
+	} else if existing, ok := rep.entries[newMemoryPath]; ok && (existing.isDir || entry.isDir) && newMemoryPath != oldMemoryPath {
+		err = &os.PathError{Op: "rename", Path: newPath, Err: os.ErrExist}
+	}
+	if err != nil {
+		log.Error(0, "Moving %v to %v failed", oldPath, newPath)
+		return
+	}
+
+	for entryPath, entry := range rep.entries {
+		if entryPath == oldMemoryPath || strings.HasPrefix(entryPath, oldMemoryPath+"/") {
+			delete(rep.entries, entryPath)
+			rep.entries[newMemoryPath+strings.TrimPrefix(entryPath, oldMemoryPath)] = entry
+		}
+	}
+	now := time.Now()
+	rep.entries[path.Dir(oldMemoryPath)].modTime = now
+	rep.entries[path.Dir(newMemoryPath)].modTime = now
+	return
+}
+
+// Delete deletes the file/folder at the given path
+func (rep *MemoryStorageRepository) Delete(deletePath string) (err error) {
+	if !utils.ValidatePath(deletePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	rep.deleteEntries(memoryStoragePath(deletePath))
+	return
+}
+
+// Copy copied the file of oldPath to newPath
+func (rep *MemoryStorageRepository) Copy(oldPath, newPath string) (err error) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	entry, ok := rep.entries[memoryStoragePath(oldPath)]
+	if !ok || entry.isDir {
+		err = fmt.Errorf("Error opening file %v to copy", oldPath)
+		return
+	}
+
+	newMemoryPath := memoryStoragePath(newPath)
+	if existing, ok := rep.entries[newMemoryPath]; !rep.isDir(path.Dir(newMemoryPath)) || ok && existing.isDir {
+		err = fmt.Errorf("Error creating file %v to copy to", newPath)
+		log.Error(0, "%v", err)
+		return
+	}
+	rep.setEntry(newMemoryPath, &memoryStorageEntry{content: entry.content})
+	return
+}
+
+// memoryStoragePath returns the key of the entry at path
+func memoryStoragePath(filePath string) string {
+	return path.Clean("/" + filepath.ToSlash(filePath))
+}
+
+func (rep *MemoryStorageRepository) isDir(memoryPath string) bool {
+	entry, ok := rep.entries[memoryPath]
+	return ok && entry.isDir
+}
+
+// setEntry stores the entry and updates the change time of it and its folder like file systems do
+func (rep *MemoryStorageRepository) setEntry(memoryPath string, entry *memoryStorageEntry) {
+	now := time.Now()
+	entry.modTime = now
+	rep.entries[memoryPath] = entry
+	rep.entries[path.Dir(memoryPath)].modTime = now
+}
+
+// deleteEntries deletes the entry and everything below it, the root itself is kept
+func (rep *MemoryStorageRepository) deleteEntries(memoryPath string) {
+	for entryPath := range rep.entries {
+		if entryPath == memoryPath && memoryPath != "/" || strings.HasPrefix(entryPath, strings.TrimSuffix(memoryPath, "/")+"/") {
+			delete(rep.entries, entryPath)
+		}
+	}
+	if parent, ok := rep.entries[path.Dir(memoryPath)]; ok {
+		parent.modTime = time.Now()
+	}
+}
+
+// childNames returns the sorted names of the files and folders in the folder
+func (rep *MemoryStorageRepository) childNames(memoryPath string) (names []string) {
+	for entryPath := range rep.entries {
+		if entryPath != "/" && path.Dir(entryPath) == memoryPath {
+			names = append(names, path.Base(entryPath))
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+// memoryStorageHandle collects the written content which replaces the content of the file once the handle is closed
+type memoryStorageHandle struct {
+	rep     *MemoryStorageRepository
+	path    string
+	content []byte
+	offset  int64
+}
+
+func (h *memoryStorageHandle) Write(p []byte) (n int, err error) {
+	n, err = h.WriteAt(p, h.offset)
+	h.offset += int64(n)
+	return
+}
+
+func (h *memoryStorageHandle) WriteAt(p []byte, offset int64) (int, error) {
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	if end := offset + int64(len(p)); end > int64(len(h.content)) {
+		h.content = append(h.content, make([]byte, end-int64(len(h.content)))...)
+	}
+	return copy(h.content[offset:], p), nil
+}
+
+// Close stores the written content, the file is created again if it has been deleted meanwhile
+func (h *memoryStorageHandle) Close() error {
+	h.rep.lock.Lock()
+	defer h.rep.lock.Unlock()
+
+	if !h.rep.isDir(path.Dir(h.path)) || h.rep.isDir(h.path) {
+		return &os.PathError{Op: "close", Path: h.path, Err: os.ErrNotExist}
+	}
+	h.rep.setEntry(h.path, &memoryStorageEntry{content: h.content})
+	return nil
+}
+
+// memoryStorageFile reads the content the file had when it was opened
+type memoryStorageFile struct {
+	*bytes.Reader
+	info *memoryStorageFileInfo
+}
+
+func (f *memoryStorageFile) Close() error {
+	return nil
+}
+
+func (f *memoryStorageFile) Stat() (os.FileInfo, error) {
+	return f.info, nil
+}
+
+// memoryStorageFileInfo describes an entry as file
+type memoryStorageFileInfo struct {
+	name  string
+	entry *memoryStorageEntry
+}
+
+func (fi *memoryStorageFileInfo) Name() string {
+	return fi.name
+}
+
+func (fi *memoryStorageFileInfo) Size() int64 {
+	return int64(len(fi.entry.content))
+}
+
+func (fi *memoryStorageFileInfo) Mode() os.FileMode {
+	if fi.entry.isDir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+func (fi *memoryStorageFileInfo) ModTime() time.Time {
+	return fi.entry.modTime
+}
+
+func (fi *memoryStorageFileInfo) IsDir() bool {
+	return fi.entry.isDir
+}
+
+func (fi *memoryStorageFileInfo) Sys() interface{} {
+	return nil
+}
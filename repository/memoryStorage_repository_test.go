@@ -0,0 +1,350 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testMemoryStorageSetupFailed = false
+var testMemoryStorageTmpName = ".tmp"
+
+func testMemoryStorageCleanup(rep *MemoryStorageRepository) {
+	if rep != nil {
+		rep.Close()
+	}
+}
+
+func testMemoryStorageSetup() *MemoryStorageRepository {
+	rep, _ := CreateMemoryStorageRepository(testMemoryStorageTmpName, 1, 0)
+	return rep
+}
+
+func testMemoryStorageInsertComplete(rep *MemoryStorageRepository) {
+	rep.CreateDirectory("1/.tmp")
+	rep.CreateDirectory("/2")
+	file, _ := rep.CreateHandle("1/.tmp/testfile.txt")
+	file.Write([]byte("temporary"))
+	file.Close()
+	file, _ = rep.CreateHandle("2/anotherFile.txt")
+	file.Write([]byte("another"))
+	file.Close()
+}
+
+func TestCreateMemoryStorageRepository(t *testing.T) {
+	rep, err := CreateMemoryStorageRepository(testMemoryStorageTmpName, 1, 0)
+	if err != nil {
+		t.Errorf("Failed to create memoryStorageRepository: %v", err)
+	}
+
+	if t.Failed() {
+		testMemoryStorageSetupFailed = true
+	}
+
+	testMemoryStorageCleanup(rep)
+}
+
+func TestMemoryStorageCreateDir(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	created, err := rep.CreateDirectory("1/.tmp")
+	if err != nil {
+		t.Errorf("Failed to create directory '1/.tmp': %v", err)
+	}
+	if !created {
+		t.Error("Directory '1/.tmp' not created")
+	}
+	created, err = rep.CreateDirectory("/1")
+	if err != nil {
+		t.Errorf("Failed to check existing directory '/1': %v", err)
+	}
+	if created {
+		t.Error("Existing directory '/1' created again")
+	}
+
+	if t.Failed() {
+		testMemoryStorageSetupFailed = true
+	}
+
+	created, err = rep.CreateDirectory("~/badDir")
+	if err != ErrForbiddenPathName {
+		t.Errorf("Error for forbidden file name is unequal to ErrForbiddenFileName: %v", err)
+	}
+	if created {
+		t.Error("Directory with forbidden name created")
+	}
+}
+
+func TestMemoryStorageCreateAndReadFile(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	file, err := rep.CreateHandle("2/written.txt")
+	if err != nil {
+		t.Fatalf("Failed to create file handle for '2/written.txt': %v", err)
+	}
+	file.Write([]byte("hello"))
+	file.WriteAt([]byte(" world"), 5)
+	file.WriteAt([]byte("H"), 0)
+	_, err = rep.GetInfo("2", "/written.txt")
+	if err != nil {
+		t.Errorf("Failed to get fileInfo of file being written: %v", err)
+	}
+	if err = file.Close(); err != nil {
+		t.Fatalf("Failed to close file handle for '2/written.txt': %v", err)
+	}
+
+	readFile, err := rep.OpenFile("2/written.txt")
+	if err != nil {
+		t.Fatalf("Failed to open '2/written.txt': %v", err)
+	}
+	defer readFile.Close()
+	content, err := ioutil.ReadAll(readFile)
+	if err != nil || string(content) != "Hello world" {
+		t.Errorf("Read content unequal to written content: %s, %v", content, err)
+	}
+	buf := make([]byte, 5)
+	if _, err = readFile.ReadAt(buf, 6); err != nil || string(buf) != "world" {
+		t.Errorf("Content read at offset unequal to expected content: %s, %v", buf, err)
+	}
+	stat, err := readFile.Stat()
+	if err != nil || stat.Size() != 11 || stat.Name() != "written.txt" {
+		t.Errorf("Stat of opened file unequal to expected stat: %v, %v", stat, err)
+	}
+
+	_, err = rep.CreateHandle("3/missingParent.txt")
+	if !os.IsNotExist(err) {
+		t.Errorf("Error for file in missing folder does not satisfy os.IsNotExist: %v", err)
+	}
+	_, err = rep.OpenFile("2/missing.txt")
+	if !os.IsNotExist(err) {
+		t.Errorf("Error for opening missing file does not satisfy os.IsNotExist: %v", err)
+	}
+	_, err = rep.CreateHandle("~/badFile.txt")
+	if err != ErrForbiddenPathName {
+		t.Errorf("Error for forbidden file name is unequal to ErrForbiddenPathName: %v", err)
+	}
+}
+
+func TestMemoryStorageGetInfo(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	fileInfo, err := rep.GetInfo("1", ".tmp/testfile.txt")
+	if err != nil {
+		t.Fatalf("Failed to get fileInfo for '1/.tmp/testfile.txt': %v", err)
+	}
+	expFileInfo := &models.FileInfo{
+		IsDir:    false,
+		MimeType: "text/plain; charset=utf-8",
+		Name:     "testfile.txt",
+		Path:     "/.tmp/",
+		Size:     9,
+	}
+	fileInfo.LastChanged = 0
+	if !reflect.DeepEqual(fileInfo, expFileInfo) {
+		t.Errorf("Read fileInfo and expected fileInfo not deeply equal: %v != %v", fileInfo, expFileInfo)
+	}
+}
+
+func TestMemoryStorageGetDirectoryInfo(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	dirInfo, err := rep.GetDirectoryInfo("/1", "/")
+	if err != nil {
+		t.Fatalf("Failed to get directory info for '1/': %v", err)
+	}
+	if len(dirInfo) != 1 {
+		t.Fatalf("Length of dir info unequal to 1: %d", len(dirInfo))
+	}
+	expFileInfo := &models.FileInfo{
+		IsDir: true,
+		Name:  ".tmp",
+		Path:  "/",
+	}
+	dirInfo[0].LastChanged = 0
+	if !reflect.DeepEqual(dirInfo[0], expFileInfo) {
+		t.Errorf("Read fileInfo in dir info and expected fileInfo are not deeply equal: %v != %v", dirInfo[0], expFileInfo)
+	}
+
+	_, err = rep.GetDirectoryInfo("/1", "missing")
+	if err != ErrFileNotExist {
+		t.Errorf("Error for missing directory unequal to 'file does not exist': %v", err)
+	}
+}
+
+func TestMemoryStorageMoveFolder(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	err := rep.Move("2", "1/moved")
+	if err != nil {
+		t.Fatalf("Failed to move folder '2' to '1/moved': %v", err)
+	}
+	_, err = rep.GetInfo("2", "/anotherFile.txt")
+	if err != ErrFileNotExist {
+		t.Errorf("Getting fileInfo of moved file was successfull or error unequal to 'file not found': %v", err)
+	}
+	fileInfo, err := rep.GetInfo("1", "/moved/anotherFile.txt")
+	if err != nil {
+		t.Fatalf("Failed to get fileInfo of moved file: %v", err)
+	}
+	if fileInfo.Path != "/moved/" || fileInfo.Size != 7 {
+		t.Errorf("FileInfo of moved file unequal to expected fileInfo: %v", fileInfo)
+	}
+
+	err = rep.Move("1", "1/moved/inside")
+	if err == nil {
+		t.Error("Moving folder into itself succeeded")
+	}
+}
+
+func TestMemoryStorageCopyFile(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	err := rep.Copy("1/.tmp/testfile.txt", "1/copiedFile.txt")
+	if err != nil {
+		t.Fatalf("Failed to copy file '1/.tmp/testfile.txt' to '1/copiedFile.txt': %v", err)
+	}
+	_, err = rep.GetInfo("1", "/.tmp/testfile.txt")
+	if err != nil {
+		t.Errorf("Failed getting fileInfo for orig file after copying: %v", err)
+	}
+	fileInfo, err := rep.GetInfo("1", "/copiedFile.txt")
+	if err != nil {
+		t.Fatalf("Failed to get fileInfo of copied file: %v", err)
+	}
+	if fileInfo.Size != 9 {
+		t.Errorf("Size of copied file unequal to size of orig file: %d", fileInfo.Size)
+	}
+}
+
+func TestMemoryStorageDeleteFolder(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	err := rep.Delete("1")
+	if err != nil {
+		t.Fatalf("Failed to delete folder '1': %v", err)
+	}
+	_, err = rep.GetInfo("1", "/.tmp/testfile.txt")
+	if err != ErrFileNotExist {
+		t.Errorf("Getting fileInfo for file in deleted folder succeeded or error is unequal to 'file does not exist': %v", err)
+	}
+	_, err = rep.GetInfo("2", "/anotherFile.txt")
+	if err != nil {
+		t.Errorf("Failed to get fileInfo of file outside of deleted folder: %v", err)
+	}
+	err = rep.Delete("1")
+	if err != nil {
+		t.Errorf("Deleting already deleted folder failed: %v", err)
+	}
+}
+
+func TestMemoryStorageCleanTemp(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	err := rep.cleanupTempFolder()
+	if err != nil {
+		t.Fatalf("Failed to cleanup tmp folder: %v", err)
+	}
+	_, err = rep.GetInfo("1", ".tmp/testfile.txt")
+	if err != ErrFileNotExist {
+		t.Errorf("Reading tmp file after tmp cleanup successfull or error unequal to 'file does not exist': %v", err)
+	}
+	_, err = rep.GetInfo("1", ".tmp")
+	if err != nil {
+		t.Errorf("Failed to read tmp folder after tmp cleanup: %v", err)
+	}
+	_, err = rep.GetInfo("2", "/anotherFile.txt")
+	if err != nil {
+		t.Errorf("Failed to read normal file after tmp cleanup: %v", err)
+	}
+}
+
+func TestMemoryStorageAddToZip(t *testing.T) {
+	if testMemoryStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep := testMemoryStorageSetup()
+	defer testMemoryStorageCleanup(rep)
+
+	testMemoryStorageInsertComplete(rep)
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	err := rep.AddToZip(zipWriter, "1", "one", func(relPath string) bool { return relPath == ".tmp" })
+	if err != nil {
+		t.Errorf("Failed to add '1' to zip: %v", err)
+	}
+	err = rep.AddToZip(zipWriter, "2", "two", nil)
+	if err != nil {
+		t.Errorf("Failed to add '2' to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open created zip: %v", err)
+	}
+	var names []string
+	for _, zipFile := range zipReader.File {
+		names = append(names, zipFile.Name)
+	}
+	expNames := []string{"two/anotherFile.txt"}
+	if !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", names, expNames)
+	}
+
+	err = rep.AddToZip(zipWriter, "3", "three", nil)
+	if err != ErrFileNotExist {
+		t.Errorf("Error for adding missing folder to zip unequal to 'file does not exist': %v", err)
+	}
+}
@@ -0,0 +1,371 @@
+package repository
+
+import (
+	"archive/zip"
+	"errors"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+var (
+	// ErrReadOnlyStorage is returned when changing files in a storage mounted read-only
+	ErrReadOnlyStorage = errors.New("storage is mounted read-only")
+	// ErrMountPoint is returned when moving or deleting a mount point or moving a folder containing one
+	ErrMountPoint = errors.New("mount points cannot be moved or deleted")
+)
+
+// MountStorageRepository stores files in its base StorageRepository except for those below mount points,
+// which are stored in the StorageRepository mounted there, e.g. a directory of a NAS mounted at "/1/NAS".
+// Mount points do not have to exist in the base, they are listed in the content of their parent folder.
+type MountStorageRepository struct {
+	base StorageRepository
+
+	lock sync.RWMutex
+	// mounts are stored by their cleaned slash separated mount point
+	mounts map[string]*storageMount
+}
+
+type storageMount struct {
+	storage  StorageRepository
+	readOnly bool
+}
+
+// CreateMountStorageRepository creates a new MountStorageRepository without any mounts
+func CreateMountStorageRepository(base StorageRepository) (*MountStorageRepository, error) {
+	return &MountStorageRepository{
+		base:   base,
+		mounts: make(map[string]*storageMount),
+	}, nil
+}
+
+// Close closes the base, the mounted StorageRepositories have to be closed by whoever created them
+func (rep *MountStorageRepository) Close() error {
+	return rep.base.Close()
+}
+
+// Mount presents the root of storage at mountPoint, replacing what has been mounted there before
+func (rep *MountStorageRepository) Mount(mountPoint string, storage StorageRepository, readOnly bool) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	rep.mounts[mountStoragePath(mountPoint)] = &storageMount{storage: storage, readOnly: readOnly}
+}
+
+// Unmount removes the mount at mountPoint
+func (rep *MountStorageRepository) Unmount(mountPoint string) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	delete(rep.mounts, mountStoragePath(mountPoint))
+}
+
+// GetMount returns the mount point the path is at or below and whether it is read-only, ok is false if the path is not mounted
+func (rep *MountStorageRepository) GetMount(filePath string) (mountPoint string, readOnly, ok bool) {
+	mountPoint, mount, _ := rep.resolve(filePath)
+	if mount == nil {
+		return "", false, false
+	}
+	return mountPoint, mount.readOnly, true
+}
+
+// GetMountPointsBelow returns the sorted mount points below the path, not including one at the path itself
+func (rep *MountStorageRepository) GetMountPointsBelow(filePath string) (mountPoints []string) {
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	prefix := strings.TrimSuffix(mountStoragePath(filePath), "/") + "/"
+	for mountPoint := range rep.mounts {
+		if strings.HasPrefix(mountPoint, prefix) {
+			mountPoints = append(mountPoints, mountPoint)
+		}
+	}
+	sort.Strings(mountPoints)
+	return
+}
+
+// resolve returns the innermost mount the path is at or below and the path relative to its root, mount is nil for paths in the base
+func (rep *MountStorageRepository) resolve(filePath string) (mountPoint string, mount *storageMount, innerPath string) {
+	rep.lock.RLock()
+	defer rep.lock.RUnlock()
+
+	cleanPath := mountStoragePath(filePath)
+	for candidate, candidateMount := range rep.mounts {
+		if (cleanPath == candidate || strings.HasPrefix(cleanPath, candidate+"/")) && len(candidate) > len(mountPoint) {
+			mountPoint, mount = candidate, candidateMount
+		}
+	}
+	if mount == nil {
+		return "", nil, filePath
+	}
+	return mountPoint, mount, "/" + strings.TrimPrefix(cleanPath, mountPoint)
+}
+
+// resolveWritable resolves the path like resolve and fails for paths in read-only mounts
+func (rep *MountStorageRepository) resolveWritable(filePath string) (storage StorageRepository, innerPath string, err error) {
+	_, mount, innerPath := rep.resolve(filePath)
+	if mount == nil {
+		return rep.base, innerPath, nil
+	} else if mount.readOnly {
+		return nil, "", ErrReadOnlyStorage
+	}
+	return mount.storage, innerPath, nil
+}
+
+// CreateHandle creates or truncates the file in the storage the path is in
+func (rep *MountStorageRepository) CreateHandle(filePath string) (StorageHandle, error) {
+	storage, innerPath, err := rep.resolveWritable(filePath)
+	if err != nil {
+		return nil, err
+	}
+	return storage.CreateHandle(innerPath)
+}
+
+// OpenFile opens the file in the storage the path is in
+func (rep *MountStorageRepository) OpenFile(filePath string) (StorageFile, error) {
+	_, mount, innerPath := rep.resolve(filePath)
+	if mount == nil {
+		return rep.base.OpenFile(innerPath)
+	}
+	return mount.storage.OpenFile(innerPath)
+}
+
+// CreateDirectory creates the folder in the storage the path is in, mount points exist already
+func (rep *MountStorageRepository) CreateDirectory(dirPath string) (created bool, err error) {
+	if _, mount, innerPath := rep.resolve(dirPath); mount != nil && innerPath == "/" {
+		return false, nil
+	}
+	storage, innerPath, err := rep.resolveWritable(dirPath)
+	if err != nil {
+		return false, err
+	}
+	return storage.CreateDirectory(innerPath)
+}
+
+// GetInfo returns the info of the file or folder from the storage it is in
+func (rep *MountStorageRepository) GetInfo(userPath, filePath string) (fileInfo *models.FileInfo, err error) {
+	fullPath := path.Join(filepath.ToSlash(userPath), filepath.ToSlash(filePath))
+	mountPoint, mount, innerPath := rep.resolve(fullPath)
+	if mount == nil {
+		return rep.base.GetInfo(userPath, filePath)
+	} else if innerPath == "/" {
+		folderPath, _ := utils.SplitPath(filePath)
+		return rep.getMountPointInfo(mountPoint, mount, folderPath), nil
+	}
+
+	fileInfo, err = mount.storage.GetInfo("", innerPath)
+	if err != nil {
+		return
+	}
+	folderPath, _ := utils.SplitPath(filePath)
+	fileInfo.Path = utils.ConvertToSlash(folderPath, true)
+	return
+}
+
+// GetDirectoryInfo returns the content of the folder from the storage it is in together with the mount points in it
+func (rep *MountStorageRepository) GetDirectoryInfo(userPath, dirPath string) (fileInfos []*models.FileInfo, err error) {
+	fullPath := mountStoragePath(path.Join(filepath.ToSlash(userPath), filepath.ToSlash(dirPath)))
+	_, mount, innerPath := rep.resolve(fullPath)
+	if mount == nil {
+		fileInfos, err = rep.base.GetDirectoryInfo(userPath, dirPath)
+	} else {
+		fileInfos, err = mount.storage.GetDirectoryInfo("", innerPath)
+	}
+	if err != nil {
+		return
+	}
+
+	if dirPath == "" {
+		dirPath = "/"
+	}
+	dirPath = utils.ConvertToSlash(dirPath, true)
+	for _, fileInfo := range fileInfos {
+		fileInfo.Path = dirPath
+	}
+
+	rep.lock.RLock()
+	var mountPointInfos []*models.FileInfo
+	for mountPoint, mount := range rep.mounts {
+		if path.Dir(mountPoint) == fullPath && mountPoint != "/" {
+			mountPointInfos = append(mountPointInfos, rep.getMountPointInfo(mountPoint, mount, dirPath))
+		}
+	}
+	rep.lock.RUnlock()
+	if len(mountPointInfos) == 0 {
+		return
+	}
+
+	// Mount points hide files and folders with the same name
+	for _, mountPointInfo := range mountPointInfos {
+		replaced := false
+		for it, fileInfo := range fileInfos {
+			if fileInfo.Name == mountPointInfo.Name {
+				fileInfos[it] = mountPointInfo
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			fileInfos = append(fileInfos, mountPointInfo)
+		}
+	}
+	sort.Slice(fileInfos, func(i, j int) bool { return fileInfos[i].Name < fileInfos[j].Name })
+	return
+}
+
+// getMountPointInfo returns the info of the root of the mount named like the mount point,
+// a mount point is listed as folder even if its storage is not reachable, so its indexed content is kept
+func (rep *MountStorageRepository) getMountPointInfo(mountPoint string, mount *storageMount, folderPath string) *models.FileInfo {
+	fileInfo, err := mount.storage.GetInfo("", "/")
+	if err != nil {
+		log.Warn("Could not get info of storage mounted at %s: %v", mountPoint, err)
+		fileInfo = &models.FileInfo{}
+	}
+	fileInfo.Path = utils.ConvertToSlash(folderPath, true)
+	fileInfo.Name = path.Base(mountPoint)
+	fileInfo.IsDir = true
+	fileInfo.MimeType = ""
+	return fileInfo
+}
+
+// AddToZip writes the file or folder into the zip archive like the storage it is in does, including the content of mount points below it
+func (rep *MountStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) (err error) {
+	_, mount, innerPath := rep.resolve(filePath)
+	if mount != nil {
+		return mount.storage.AddToZip(zipWriter, innerPath, zipPath, skip)
+	}
+
+	err = rep.base.AddToZip(zipWriter, filePath, zipPath, skip)
+	if err != nil {
+		return
+	}
+
+	cleanPath := mountStoragePath(filePath)
+	for _, mountPoint := range rep.GetMountPointsBelow(filePath) {
+		relMountPoint := strings.TrimPrefix(strings.TrimPrefix(mountPoint, cleanPath), "/")
+		if isSkippedZipPath(relMountPoint, skip) {
+			continue
+		}
+		_, mount, _ := rep.resolve(mountPoint)
+		err = mount.storage.AddToZip(zipWriter, "/", path.Join(filepath.ToSlash(zipPath), relMountPoint), func(relPath string) bool {
+			return skip != nil && skip(path.Join(relMountPoint, relPath))
+		})
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// Move moves the file or folder within its storage or copies it into the other storage and deletes it afterwards
+func (rep *MountStorageRepository) Move(oldPath, newPath string) (err error) {
+	if _, mount, innerPath := rep.resolve(oldPath); mount != nil && innerPath == "/" || len(rep.GetMountPointsBelow(oldPath)) > 0 {
+		return ErrMountPoint
+	}
+	if _, mount, innerPath := rep.resolve(newPath); mount != nil && innerPath == "/" {
+		return ErrMountPoint
+	}
+	oldStorage, oldInnerPath, err := rep.resolveWritable(oldPath)
+	if err != nil {
+		return
+	}
+	newStorage, newInnerPath, err := rep.resolveWritable(newPath)
+	if err != nil {
+		return
+	}
+
+	if oldStorage == newStorage {
+		return oldStorage.Move(oldInnerPath, newInnerPath)
+	}
+	err = copyBetweenStorages(oldStorage, oldInnerPath, newStorage, newInnerPath)
+	if err != nil {
+		log.Error(0, "Moving %v to %v failed: %v", oldPath, newPath, err)
+		newStorage.Delete(newInnerPath)
+		return
+	}
+	return oldStorage.Delete(oldInnerPath)
+}
+
+// Copy copies the file within its storage or into the other storage
+func (rep *MountStorageRepository) Copy(oldPath, newPath string) (err error) {
+	_, oldMount, oldInnerPath := rep.resolve(oldPath)
+	oldStorage := rep.base
+	if oldMount != nil {
+		oldStorage = oldMount.storage
+	}
+	newStorage, newInnerPath, err := rep.resolveWritable(newPath)
+	if err != nil {
+		return
+	}
+
+	if oldStorage == newStorage {
+		return oldStorage.Copy(oldInnerPath, newInnerPath)
+	}
+	return copyBetweenStorages(oldStorage, oldInnerPath, newStorage, newInnerPath)
+}
+
+// Delete deletes the file or folder in the storage it is in, mount points below it are kept
+func (rep *MountStorageRepository) Delete(deletePath string) (err error) {
+	if _, mount, innerPath := rep.resolve(deletePath); mount != nil && innerPath == "/" {
+		return ErrMountPoint
+	}
+	storage, innerPath, err := rep.resolveWritable(deletePath)
+	if err != nil {
+		return
+	}
+	return storage.Delete(innerPath)
+}
+
+// copyBetweenStorages copies the file or folder with all its content from one storage into another
+func copyBetweenStorages(from StorageRepository, fromPath string, to StorageRepository, toPath string) (err error) {
+	fileInfo, err := from.GetInfo("", fromPath)
+	if err != nil {
+		return
+	}
+
+	if fileInfo.IsDir {
+		_, err = to.CreateDirectory(toPath)
+		if err != nil {
+			return
+		}
+		var content []*models.FileInfo
+		content, err = from.GetDirectoryInfo("", fromPath)
+		if err != nil {
+			return
+		}
+		for _, contentInfo := range content {
+			err = copyBetweenStorages(from, path.Join(filepath.ToSlash(fromPath), contentInfo.Name), to, path.Join(filepath.ToSlash(toPath), contentInfo.Name))
+			if err != nil {
+				return
+			}
+		}
+		return
+	}
+
+	in, err := from.OpenFile(fromPath)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+	out, err := to.CreateHandle(toPath)
+	if err != nil {
+		return
+	}
+	_, err = io.Copy(out, in)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	return
+}
+
+// mountStoragePath returns the cleaned slash separated path mount points are compared with
+func mountStoragePath(filePath string) string {
+	return path.Clean("/" + filepath.ToSlash(filePath))
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"reflect"
+	"testing"
+)
+
+var testMountStorageSetupFailed = false
+
+func testMountStorageCleanup(rep *MountStorageRepository, mounted *MemoryStorageRepository) {
+	if rep != nil {
+		rep.Close()
+	}
+	if mounted != nil {
+		mounted.Close()
+	}
+}
+
+func testMountStorageSetup() (*MountStorageRepository, *MemoryStorageRepository) {
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	base.CreateDirectory("1/docs")
+	base.CreateDirectory("1/.tmp")
+	base.CreateDirectory("2")
+	mounted, _ := CreateMemoryStorageRepository("", 1, 1)
+	mounted.CreateDirectory("projects")
+	file, _ := mounted.CreateHandle("projects/plan.txt")
+	file.Write([]byte("plan"))
+	file.Close()
+
+	rep, _ := CreateMountStorageRepository(base)
+	rep.Mount("/1/docs/nas", mounted, false)
+	return rep, mounted
+}
+
+func testMountStorageWrite(rep StorageRepository, filePath, content string) error {
+	file, err := rep.CreateHandle(filePath)
+	if err != nil {
+		return err
+	}
+	file.Write([]byte(content))
+	return file.Close()
+}
+
+func testMountStorageRead(rep StorageRepository, filePath string) string {
+	file, err := rep.OpenFile(filePath)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	content, _ := ioutil.ReadAll(file)
+	return string(content)
+}
+
+func TestCreateMountStorageRepository(t *testing.T) {
+	base, _ := CreateMemoryStorageRepository(".tmp", 1, 1)
+	rep, err := CreateMountStorageRepository(base)
+	if err != nil {
+		t.Errorf("Failed to create mountStorageRepository: %v", err)
+	}
+
+	if t.Failed() {
+		testMountStorageSetupFailed = true
+	}
+
+	testMountStorageCleanup(rep, nil)
+}
+
+func TestMountStorageGetMount(t *testing.T) {
+	if testMountStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, mounted := testMountStorageSetup()
+	defer testMountStorageCleanup(rep, mounted)
+
+	mountPoint, readOnly, ok := rep.GetMount("1/docs/nas/projects/plan.txt")
+	if !ok || mountPoint != "/1/docs/nas" || readOnly {
+		t.Errorf("Mount of file below mount point unequal to expected mount: %s, %v, %v", mountPoint, readOnly, ok)
+	}
+	if _, _, ok = rep.GetMount("/1/docs/nasty"); ok {
+		t.Error("Path next to mount point is mounted")
+	}
+	if mountPoints := rep.GetMountPointsBelow("/1"); !reflect.DeepEqual(mountPoints, []string{"/1/docs/nas"}) {
+		t.Errorf("Mount points below '/1' unequal to expected mount points: %v", mountPoints)
+	}
+
+	rep.Unmount("/1/docs/nas")
+	if _, _, ok = rep.GetMount("/1/docs/nas"); ok {
+		t.Error("Path still mounted after unmounting")
+	}
+}
+
+func TestMountStorageGetDirectoryInfo(t *testing.T) {
+	if testMountStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, mounted := testMountStorageSetup()
+	defer testMountStorageCleanup(rep, mounted)
+
+	dirInfo, err := rep.GetDirectoryInfo("/1", "/docs")
+	if err != nil {
+		t.Fatalf("Failed to get directory info for '/1/docs': %v", err)
+	}
+	if len(dirInfo) != 1 || dirInfo[0].Name != "nas" || !dirInfo[0].IsDir || dirInfo[0].Path != "/docs/" {
+		t.Fatalf("Directory info of parent of mount point does not only contain the mount point: %v", dirInfo)
+	}
+
+	dirInfo, err = rep.GetDirectoryInfo("/1", "/docs/nas/projects")
+	if err != nil {
+		t.Fatalf("Failed to get directory info in mount: %v", err)
+	}
+	if len(dirInfo) != 1 || dirInfo[0].Name != "plan.txt" || dirInfo[0].Path != "/docs/nas/projects/" || dirInfo[0].Size != 4 {
+		t.Errorf("Directory info in mount unequal to expected info: %v", dirInfo)
+	}
+
+	fileInfo, err := rep.GetInfo("/1", "/docs/nas")
+	if err != nil || fileInfo.Name != "nas" || fileInfo.Path != "/docs/" || !fileInfo.IsDir {
+		t.Errorf("Info of mount point unequal to expected info: %v, %v", fileInfo, err)
+	}
+}
+
+func TestMountStorageCreateAndRead(t *testing.T) {
+	if testMountStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, mounted := testMountStorageSetup()
+	defer testMountStorageCleanup(rep, mounted)
+
+	err := testMountStorageWrite(rep, "/1/docs/nas/projects/new.txt", "new")
+	if err != nil {
+		t.Fatalf("Failed to write file in mount: %v", err)
+	}
+	if content := testMountStorageRead(mounted, "/projects/new.txt"); content != "new" {
+		t.Errorf("Content of file written in mount unequal to expected content: %s", content)
+	}
+	if created, err := rep.CreateDirectory("/1/docs/nas"); created || err != nil {
+		t.Errorf("Creating directory at mount point did not succeed without creating it: %v, %v", created, err)
+	}
+
+	rep.Mount("/1/docs/nas", mounted, true)
+	err = testMountStorageWrite(rep, "/1/docs/nas/projects/other.txt", "other")
+	if err != ErrReadOnlyStorage {
+		t.Errorf("Error for writing into read-only mount unequal to ErrReadOnlyStorage: %v", err)
+	}
+	_, err = rep.CreateDirectory("/1/docs/nas/other")
+	if err != ErrReadOnlyStorage {
+		t.Errorf("Error for creating directory in read-only mount unequal to ErrReadOnlyStorage: %v", err)
+	}
+	err = rep.Delete("/1/docs/nas/projects/new.txt")
+	if err != ErrReadOnlyStorage {
+		t.Errorf("Error for deleting in read-only mount unequal to ErrReadOnlyStorage: %v", err)
+	}
+	if content := testMountStorageRead(rep, "/1/docs/nas/projects/new.txt"); content != "new" {
+		t.Errorf("Failed to read file in read-only mount: %s", content)
+	}
+}
+
+func TestMountStorageMove(t *testing.T) {
+	if testMountStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, mounted := testMountStorageSetup()
+	defer testMountStorageCleanup(rep, mounted)
+
+	testMountStorageWrite(rep, "/1/.tmp/upload", "uploaded")
+	err := rep.Move("/1/.tmp/upload", "/1/docs/nas/uploaded.txt")
+	if err != nil {
+		t.Fatalf("Failed to move file into mount: %v", err)
+	}
+	if content := testMountStorageRead(mounted, "/uploaded.txt"); content != "uploaded" {
+		t.Errorf("Content of file moved into mount unequal to expected content: %s", content)
+	}
+	if _, err = rep.GetInfo("/1", "/.tmp/upload"); err != ErrFileNotExist {
+		t.Errorf("Moved file still exists at old path: %v", err)
+	}
+
+	err = rep.Move("/1/docs/nas/projects", "/2/projects")
+	if err != nil {
+		t.Fatalf("Failed to move folder out of mount: %v", err)
+	}
+	if content := testMountStorageRead(rep, "/2/projects/plan.txt"); content != "plan" {
+		t.Errorf("Content of file in folder moved out of mount unequal to expected content: %s", content)
+	}
+	if _, err = mounted.GetInfo("", "/projects"); err != ErrFileNotExist {
+		t.Errorf("Moved folder still exists in mount: %v", err)
+	}
+
+	invalids := map[string][2]string{
+		"mount point":                {"/1/docs/nas", "/2/nas"},
+		"folder containing mount":    {"/1/docs", "/2/docs"},
+		"onto mount point":           {"/2/projects", "/1/docs/nas"},
+		"delete mount point as well": {"/1/docs/nas", ""},
+	}
+	for name, invalid := range invalids {
+		if invalid[1] == "" {
+			err = rep.Delete(invalid[0])
+		} else {
+			err = rep.Move(invalid[0], invalid[1])
+		}
+		if err != ErrMountPoint {
+			t.Errorf("Error for %s unequal to ErrMountPoint: %v", name, err)
+		}
+	}
+}
+
+func TestMountStorageAddToZip(t *testing.T) {
+	if testMountStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, mounted := testMountStorageSetup()
+	defer testMountStorageCleanup(rep, mounted)
+
+	testMountStorageWrite(rep, "/1/docs/a.txt", "a")
+	testMountStorageWrite(rep, "/1/docs/nas/skipped.txt", "skipped")
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	err := rep.AddToZip(zipWriter, "/1", "files", func(relPath string) bool { return relPath == "docs/nas/skipped.txt" })
+	if err != nil {
+		t.Fatalf("Failed to add folder containing mount point to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open created zip: %v", err)
+	}
+	var names []string
+	for _, zipFile := range zipReader.File {
+		names = append(names, zipFile.Name)
+	}
+	expNames := []string{"files/docs/a.txt", "files/docs/nas/projects/plan.txt"}
+	if !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", names, expNames)
+	}
+}
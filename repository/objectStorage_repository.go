@@ -0,0 +1,1012 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/freecloudio/server/crypt"
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+const (
+	// objectStoragePartSize is the size of the parts larger files are uploaded in, S3 allows at most 10000 parts per object
+	objectStoragePartSize = 64 << 20 // bytes
+	// objectStorageMaxCopySize is the largest object S3 copies with a single request, larger ones are copied in parts
+	objectStorageMaxCopySize = 5 << 30 // bytes
+	// objectStorageCopyPartSize is the size of the parts larger objects are copied in
+	objectStorageCopyPartSize = 512 << 20 // bytes
+	// objectStorageDeleteBatch is the maximum number of objects S3 deletes with a single request
+	objectStorageDeleteBatch = 1000
+)
+
+// ErrObjectStorageInvalidConfig is returned if the object storage repository is created with an incomplete configuration
+var ErrObjectStorageInvalidConfig = errors.New("object storage repository: invalid configuration")
+
+// ObjectStorageConfig contains everything needed to store files in a bucket of a S3-compatible object storage like MinIO
+type ObjectStorageConfig struct {
+	// Endpoint is the URL of the storage, e.g. http://localhost:9000, buckets are addressed path-style below it
+	Endpoint string
+	Region   string
+	Bucket   string
+	// Prefix is put in front of all keys, so the bucket can also hold other data
+	Prefix string
+
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// ObjectStorageRepository stores files as objects in a bucket of a S3-compatible object storage.
+// Folders are kept as empty objects with a trailing slash in their key like S3 consoles create them,
+// moving and deleting folders is not atomic as every object below them has to be copied or deleted.
+type ObjectStorageRepository struct {
+	config             ObjectStorageConfig
+	client             *http.Client
+	partSize           int64
+	tmpName            string
+	tmpCleanupInterval int
+	tmpDataExpiry      int
+	done               chan struct{}
+}
+
+// objectStorageError is an error response of the object storage
+type objectStorageError struct {
+	StatusCode int    `xml:"-"`
+	Code       string `xml:"Code"`
+	Message    string `xml:"Message"`
+	Key        string `xml:"Key"`
+}
+
+func (err *objectStorageError) Error() string {
+	if err.Code == "" {
+		return fmt.Sprintf("object storage responded with status %d", err.StatusCode)
+	}
+	return fmt.Sprintf("object storage responded with %s: %s", err.Code, err.Message)
+}
+
+// objectStorageObject is an object in the bucket as it is listed
+type objectStorageObject struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	Size         int64     `xml:"Size"`
+}
+
+type objectStorageListResult struct {
+	Contents       []*objectStorageObject `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+type objectStoragePart struct {
+	PartNumber int    `xml:"PartNumber"`
+	ETag       string `xml:"ETag"`
+}
+
+// CreateObjectStorageRepository creates a new ObjectStorageRepository IF the given configuration is complete and the bucket can be accessed.
+// A missing bucket is created, the interval for temp cleanup and the tmp data expiry are in hours.
+func CreateObjectStorageRepository(config ObjectStorageConfig, tmpName string, tmpCleanupInterval, tmpDataExpiry int) (*ObjectStorageRepository, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil || (endpoint.Scheme != "http" && endpoint.Scheme != "https") || endpoint.Host == "" || config.Bucket == "" || config.Region == "" || config.AccessKeyID == "" || config.SecretAccessKey == "" {
+		log.Error(0, "Incomplete object storage configuration for %s", config.Endpoint)
+		return nil, ErrObjectStorageInvalidConfig
+	}
+	config.Endpoint = strings.TrimSuffix(config.Endpoint, "/")
+	config.Prefix = strings.Trim(config.Prefix, "/")
+	if config.Prefix != "" {
+		config.Prefix += "/"
+	}
+
+	rep := &ObjectStorageRepository{
+		config:             config,
+		client:             &http.Client{},
+		partSize:           objectStoragePartSize,
+		tmpName:            tmpName,
+		tmpCleanupInterval: tmpCleanupInterval,
+		tmpDataExpiry:      tmpDataExpiry,
+		done:               make(chan struct{}),
+	}
+
+	// Check if the bucket does not exist. If it doesn't, create it.
+	resp, err := rep.do("HEAD", "", nil, nil, nil, 0)
+	if isObjectStorageNotFound(err) {
+		log.Info("Bucket does not exist, creating it now")
+		var body []byte
+		if config.Region != "us-east-1" {
+			body = []byte("<CreateBucketConfiguration><LocationConstraint>" + config.Region + "</LocationConstraint></CreateBucketConfiguration>")
+		}
+		resp, err = rep.do("PUT", "", nil, nil, bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			log.Error(0, "Could not create bucket %s: %v", config.Bucket, err)
+			return nil, err
+		}
+	} else if err != nil {
+		log.Error(0, "Could not check if bucket %s exists: %v", config.Bucket, err)
+		return nil, err
+	}
+	resp.Body.Close()
+
+	log.Info("Initialized object storage at bucket %s of %s", config.Bucket, config.Endpoint)
+	go rep.cleanupTempFolderRoutine()
+
+	return rep, nil
+}
+
+// Close closes the repository and with that ends the go routine for tmp cleanup
+func (rep *ObjectStorageRepository) Close() error {
+	rep.done <- struct{}{}
+	return nil
+}
+
+// cleanupTempFolderRoutine is the actual routine that periodically calls cleanupTempFolder
+func (rep *ObjectStorageRepository) cleanupTempFolderRoutine() {
+	log.Trace("Starting temp folder cleaner, running now and every %v hours", rep.tmpCleanupInterval)
+	rep.cleanupTempFolder()
+
+	ticker := time.NewTicker(time.Hour * time.Duration(rep.tmpCleanupInterval))
+	for {
+		select {
+		case <-rep.done:
+			return
+		case <-ticker.C:
+			rep.cleanupTempFolder()
+		}
+	}
+}
+
+// cleanupTempFolder deletes the expired objects in all tmp folders, the tmp folders themselves are kept
+func (rep *ObjectStorageRepository) cleanupTempFolder() (err error) {
+	log.Trace("Cleaning temp folder")
+
+	now := time.Now()
+	var expiredKeys []string
+	err = rep.listObjects(rep.config.Prefix, "/", func(objects []*objectStorageObject, prefixes []string) error {
+		for _, prefix := range prefixes {
+			tmpFolderKey := prefix + rep.tmpName + "/"
+			listErr := rep.listObjects(tmpFolderKey, "", func(objects []*objectStorageObject, prefixes []string) error {
+				for _, object := range objects {
+					expires := object.LastModified.Add(time.Hour * time.Duration(rep.tmpDataExpiry))
+					if object.Key != tmpFolderKey && now.After(expires) {
+						expiredKeys = append(expiredKeys, object.Key)
+					}
+				}
+				return nil
+			})
+			if listErr != nil {
+				log.Warn("Error reading temp folder in %v during temp cleanup: %v", tmpFolderKey, listErr)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Warn("Cleaning temp folder failed: %v", err)
+		return
+	}
+
+	err = rep.deleteObjects(expiredKeys)
+	if err != nil {
+		log.Warn("Error deleting expired objects during temp cleanup: %v", err)
+	}
+	return
+}
+
+// CreateHandle returns a handle for writing the file at path, the content is buffered in a local temp file and uploaded on closing the handle.
+// Before creating the handle, it check the path for sanity.
+func (rep *ObjectStorageRepository) CreateHandle(path string) (StorageHandle, error) {
+	if !utils.ValidatePath(path) {
+		return nil, ErrForbiddenPathName
+	}
+	file, err := ioutil.TempFile("", "freecloud-upload-")
+	if err != nil {
+		log.Error(0, "Could not create temp file for %s: %v", path, err)
+		return nil, err
+	}
+	return &objectStorageHandle{rep: rep, key: rep.objectKey(path), file: file}, nil
+}
+
+// OpenFile opens the object of the file at path for reading, its content is fetched in ranges when it is read.
+// Before opening the file, it check the path for sanity.
+func (rep *ObjectStorageRepository) OpenFile(path string) (StorageFile, error) {
+	if !utils.ValidatePath(path) {
+		return nil, ErrForbiddenPathName
+	}
+	key := rep.objectKey(path)
+	if key == rep.folderKey(path) {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrInvalid}
+	}
+	object, err := rep.headObject(key)
+	if isObjectStorageNotFound(err) {
+		return nil, &os.PathError{Op: "open", Path: path, Err: os.ErrNotExist}
+	} else if err != nil {
+		return nil, err
+	}
+	return &objectStorageFile{rep: rep, object: object}, nil
+}
+
+// CreateDirectory checks whether directory exists and creates it and its missing parents otherwise
+func (rep *ObjectStorageRepository) CreateDirectory(dirPath string) (created bool, err error) {
+	if !utils.ValidatePath(dirPath) {
+		return false, ErrForbiddenPathName
+	}
+
+	exists, _, err := rep.getFolder(dirPath)
+	if err != nil {
+		log.Warn("Could not check if directory exists, assuming it does: %v", err)
+		return false, err
+	} else if exists {
+		return false, nil
+	}
+
+	log.Info("Directory does not exist, creating it now")
+	parentPath := "/"
+	for _, name := range strings.Split(strings.Trim(path.Clean("/"+filepath.ToSlash(dirPath)), "/"), "/") {
+		parentPath = path.Join(parentPath, name)
+		folderKey := rep.folderKey(parentPath)
+		_, err = rep.headObject(folderKey)
+		if isObjectStorageNotFound(err) {
+			err = rep.putObject(folderKey, bytes.NewReader(nil), 0)
+		}
+		if err != nil {
+			log.Error(0, "Could not create directory %s: %v", dirPath, err)
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// GetDirectoryInfo returns a list of all files and folders in the given "path" (relative to the user's directory).
+// Before doing so, it checks the path for sanity.
+func (rep *ObjectStorageRepository) GetDirectoryInfo(userPath, dirPath string) ([]*models.FileInfo, error) {
+	if !utils.ValidatePath(dirPath) {
+		return nil, ErrForbiddenPathName
+	}
+
+	folderKey := rep.folderKey(path.Join(userPath, dirPath))
+	if dirPath == "" {
+		dirPath = "/"
+	}
+	dirPath = utils.ConvertToSlash(dirPath, true)
+
+	var fileInfos []*models.FileInfo
+	err := rep.listObjects(folderKey, "/", func(objects []*objectStorageObject, prefixes []string) error {
+		for _, object := range objects {
+			name := strings.TrimPrefix(object.Key, folderKey)
+			if name == "" || strings.Contains(name, "/") {
+				continue
+			}
+			fileInfos = append(fileInfos, rep.generateInfo(name, false, object.Size, object.LastModified, dirPath))
+		}
+		for _, prefix := range prefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(prefix, folderKey), "/")
+			if name == "" {
+				continue
+			}
+			var lastModified time.Time
+			if marker, err := rep.headObject(prefix); err == nil {
+				lastModified = marker.LastModified
+			}
+			fileInfos = append(fileInfos, rep.generateInfo(name, true, 0, lastModified, dirPath))
+		}
+		return nil
+	})
+	if err == nil && len(fileInfos) == 0 {
+		var exists bool
+		exists, _, err = rep.getFolder(path.Join(userPath, dirPath))
+		if err == nil && !exists {
+			err = ErrFileNotExist
+		}
+	}
+	if err != nil {
+		log.Error(0, "Could not list files in %s: %v", dirPath, err)
+		return nil, err
+	}
+
+	sort.Slice(fileInfos, func(i, j int) bool { return fileInfos[i].Name < fileInfos[j].Name })
+	return fileInfos, nil
+}
+
+// GetInfo generates and returns the fileInfo of a file in the object storage
+func (rep *ObjectStorageRepository) GetInfo(userPath, filePath string) (fileInfo *models.FileInfo, err error) {
+	fullPath := path.Join(userPath, filepath.ToSlash(filePath))
+	folderPath, _ := utils.SplitPath(filePath)
+	_, name := path.Split(path.Clean("/" + fullPath))
+
+	key := rep.objectKey(fullPath)
+	if key != rep.folderKey(fullPath) {
+		var object *objectStorageObject
+		object, err = rep.headObject(key)
+		if err == nil {
+			fileInfo = rep.generateInfo(name, false, object.Size, object.LastModified, folderPath)
+			return
+		} else if !isObjectStorageNotFound(err) {
+			err = fmt.Errorf("Error resolving file path: %v", err)
+			return
+		}
+	}
+
+	exists, lastModified, err := rep.getFolder(fullPath)
+	if err != nil {
+		err = fmt.Errorf("Error resolving file path: %v", err)
+		return
+	} else if !exists {
+		err = ErrFileNotExist
+		return
+	}
+	fileInfo = rep.generateInfo(name, true, 0, lastModified, folderPath)
+	return
+}
+
+func (rep *ObjectStorageRepository) generateInfo(name string, isDir bool, size int64, lastModified time.Time, path string) *models.FileInfo {
+	fileInfo := &models.FileInfo{
+		Path:     utils.ConvertToSlash(path, true),
+		Name:     name,
+		IsDir:    isDir,
+		Size:     size,
+		MimeType: mime.TypeByExtension(filepath.Ext(name)),
+	}
+	if !lastModified.IsZero() {
+		fileInfo.LastChanged = lastModified.UTC().Unix()
+	}
+	return fileInfo
+}
+
+// AddToZip writes the file or the content of the folder at path into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *ObjectStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) (err error) {
+	if !utils.ValidatePath(filePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	defer func() {
+		if err != nil {
+			log.Error(0, "Error adding %v to zip: %v", filePath, err)
+		}
+	}()
+
+	key := rep.objectKey(filePath)
+	if key != rep.folderKey(filePath) {
+		object, headErr := rep.headObject(key)
+		if headErr == nil {
+			return rep.addObjectToZip(zipWriter, object, zipPath)
+		} else if !isObjectStorageNotFound(headErr) {
+			return headErr
+		}
+	}
+
+	folderKey := rep.folderKey(filePath)
+	found := false
+	err = rep.listObjects(folderKey, "", func(objects []*objectStorageObject, prefixes []string) error {
+		for _, object := range objects {
+			found = true
+			relPath := strings.TrimPrefix(object.Key, folderKey)
+			if relPath == "" || isSkippedZipPath(relPath, skip) || strings.HasSuffix(relPath, "/") {
+				continue
+			}
+			if err := rep.addObjectToZip(zipWriter, object, path.Join(filepath.ToSlash(zipPath), relPath)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err == nil && !found {
+		err = ErrFileNotExist
+	}
+	return
+}
+
+// isSkippedZipPath returns whether skip returns true for the relative path or one of the folders it is in
+func isSkippedZipPath(relPath string, skip func(relPath string) bool) bool {
+	if skip == nil {
+		return false
+	}
+	names := strings.Split(strings.TrimSuffix(relPath, "/"), "/")
+	for it := range names {
+		if skip(strings.Join(names[:it+1], "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+func (rep *ObjectStorageRepository) addObjectToZip(zipWriter *zip.Writer, object *objectStorageObject, name string) (err error) {
+	header, err := zip.FileInfoHeader(&objectStorageFileInfo{object: object})
+	if err != nil {
+		return
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return
+	}
+
+	body, err := rep.getObject(object.Key, 0)
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	_, err = io.Copy(writer, body)
+	return
+}
+
+// Move moves the file/folder of oldPath to newPath
+func (rep *ObjectStorageRepository) Move(oldPath, newPath string) (err error) {
+	if !utils.ValidatePath(oldPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	if !utils.ValidatePath(newPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	defer func() {
+		if err != nil {
+			log.Error(0, "Moving %v to %v failed: %v", oldPath, newPath, err)
+		}
+	}()
+
+	oldKey := rep.objectKey(oldPath)
+	if oldKey != rep.folderKey(oldPath) {
+		object, headErr := rep.headObject(oldKey)
+		if headErr == nil {
+			err = rep.copyObject(object, rep.objectKey(newPath))
+			if err != nil {
+				return
+			}
+			return rep.deleteObjects([]string{oldKey})
+		} else if !isObjectStorageNotFound(headErr) {
+			return headErr
+		}
+	}
+
+	oldFolderKey, newFolderKey := rep.folderKey(oldPath), rep.folderKey(newPath)
+	var movedKeys []string
+	err = rep.listObjects(oldFolderKey, "", func(objects []*objectStorageObject, prefixes []string) error {
+		for _, object := range objects {
+			if err := rep.copyObject(object, newFolderKey+strings.TrimPrefix(object.Key, oldFolderKey)); err != nil {
+				return err
+			}
+			movedKeys = append(movedKeys, object.Key)
+		}
+		return nil
+	})
+	if err == nil && len(movedKeys) == 0 {
+		err = ErrFileNotExist
+	}
+	if err != nil {
+		return
+	}
+	return rep.deleteObjects(movedKeys)
+}
+
+// Delete deletes the file/folder at the given path
+func (rep *ObjectStorageRepository) Delete(deletePath string) (err error) {
+	if !utils.ValidatePath(deletePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	var keys []string
+	if key := rep.objectKey(deletePath); key != rep.folderKey(deletePath) {
+		keys = append(keys, key)
+	}
+	err = rep.listObjects(rep.folderKey(deletePath), "", func(objects []*objectStorageObject, prefixes []string) error {
+		for _, object := range objects {
+			keys = append(keys, object.Key)
+		}
+		return nil
+	})
+	if err == nil {
+		err = rep.deleteObjects(keys)
+	}
+	if err != nil {
+		log.Error(0, "Deleting %v failed: %v", deletePath, err)
+		return
+	}
+	return
+}
+
+// Copy copied the file of oldPath to newPath
+func (rep *ObjectStorageRepository) Copy(oldPath, newPath string) (err error) {
+	object, err := rep.headObject(rep.objectKey(oldPath))
+	if err != nil {
+		err = fmt.Errorf("Error opening file %v to copy", oldPath)
+		return
+	}
+
+	err = rep.copyObject(object, rep.objectKey(newPath))
+	if err != nil {
+		err = fmt.Errorf("Error copying %v to %v: %v", oldPath, newPath, err)
+		log.Error(0, "%v", err)
+		return
+	}
+	return
+}
+
+// objectKey returns the key of the object of the file at path
+func (rep *ObjectStorageRepository) objectKey(filePath string) string {
+	return rep.config.Prefix + strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(filePath)), "/")
+}
+
+// folderKey returns the key of the marker of the folder at path which is also the prefix of the keys of its content
+func (rep *ObjectStorageRepository) folderKey(folderPath string) string {
+	key := rep.objectKey(folderPath)
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key
+	}
+	return key + "/"
+}
+
+// getFolder returns whether the folder at path exists and when its marker has been created if it has one.
+// Folders created by other S3 clients may have no marker and only exist as long as they have content.
+func (rep *ObjectStorageRepository) getFolder(folderPath string) (exists bool, lastModified time.Time, err error) {
+	folderKey := rep.folderKey(folderPath)
+	if folderKey == "" {
+		return true, time.Time{}, nil
+	}
+
+	marker, err := rep.headObject(folderKey)
+	if err == nil {
+		return true, marker.LastModified, nil
+	} else if !isObjectStorageNotFound(err) {
+		return
+	}
+
+	result, err := rep.listPage(folderKey, "", "", 1)
+	if err != nil {
+		return
+	}
+	return len(result.Contents) > 0, time.Time{}, nil
+}
+
+// do sends a signed request for the key, or for the bucket if the key is empty, and returns the response if it is successful
+func (rep *ObjectStorageRepository) do(method, key string, query url.Values, header http.Header, body io.Reader, size int64) (*http.Response, error) {
+	req, err := http.NewRequest(method, rep.config.Endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.Path = strings.TrimSuffix(req.URL.Path, "/") + "/" + rep.config.Bucket + "/" + key
+	req.URL.RawQuery = query.Encode()
+	req.ContentLength = size
+	if size == 0 {
+		req.Body = http.NoBody
+	}
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	crypt.SignSigV4(req, rep.config.AccessKeyID, rep.config.SecretAccessKey, rep.config.Region, time.Now())
+
+	resp, err := rep.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		storageErr := &objectStorageError{StatusCode: resp.StatusCode}
+		xml.NewDecoder(resp.Body).Decode(storageErr)
+		return nil, storageErr
+	}
+	return resp, nil
+}
+
+// doXML sends the request and decodes its XML response into result, S3 reports some errors with a successful status in the body
+func (rep *ObjectStorageRepository) doXML(method, key string, query url.Values, header http.Header, body []byte, result interface{}) error {
+	resp, err := rep.do(method, key, query, header, bytes.NewReader(body), int64(len(body)))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil || len(content) == 0 {
+		return err
+	}
+	storageErr := &objectStorageError{StatusCode: resp.StatusCode}
+	if xml.Unmarshal(content, &struct {
+		XMLName xml.Name `xml:"Error"`
+		*objectStorageError
+	}{objectStorageError: storageErr}) == nil {
+		return storageErr
+	}
+	if result == nil {
+		return nil
+	}
+	return xml.Unmarshal(content, result)
+}
+
+func isObjectStorageNotFound(err error) bool {
+	storageErr, ok := err.(*objectStorageError)
+	return ok && storageErr.StatusCode == http.StatusNotFound
+}
+
+func (rep *ObjectStorageRepository) headObject(key string) (*objectStorageObject, error) {
+	resp, err := rep.do("HEAD", key, nil, nil, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body.Close()
+
+	object := &objectStorageObject{Key: key, Size: resp.ContentLength}
+	object.LastModified, _ = http.ParseTime(resp.Header.Get("Last-Modified"))
+	return object, nil
+}
+
+// getObject returns the content of the object starting at the given offset
+func (rep *ObjectStorageRepository) getObject(key string, offset int64) (io.ReadCloser, error) {
+	header := http.Header{}
+	if offset > 0 {
+		header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	resp, err := rep.do("GET", key, nil, header, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+// putObject stores the content as object, content larger than the part size is uploaded in parts
+func (rep *ObjectStorageRepository) putObject(key string, content io.ReaderAt, size int64) (err error) {
+	if size <= rep.partSize {
+		resp, err := rep.do("PUT", key, nil, nil, io.NewSectionReader(content, 0, size), size)
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		return nil
+	}
+
+	return rep.multipartUpload(key, func(uploadQuery url.Values) (parts []*objectStoragePart, err error) {
+		for offset := int64(0); offset < size; offset += rep.partSize {
+			partSize := rep.partSize
+			if offset+partSize > size {
+				partSize = size - offset
+			}
+			part := &objectStoragePart{PartNumber: len(parts) + 1}
+			query := url.Values{"uploadId": uploadQuery["uploadId"], "partNumber": {strconv.Itoa(part.PartNumber)}}
+			resp, err := rep.do("PUT", key, query, nil, io.NewSectionReader(content, offset, partSize), partSize)
+			if err != nil {
+				return nil, err
+			}
+			resp.Body.Close()
+			part.ETag = resp.Header.Get("ETag")
+			parts = append(parts, part)
+		}
+		return
+	})
+}
+
+// copyObject copies the object to the key within the bucket, objects larger than S3 copies at once are copied in parts
+func (rep *ObjectStorageRepository) copyObject(object *objectStorageObject, key string) error {
+	header := http.Header{}
+	header.Set("X-Amz-Copy-Source", (&url.URL{Path: "/" + rep.config.Bucket + "/" + object.Key}).EscapedPath())
+	if object.Size <= objectStorageMaxCopySize {
+		return rep.doXML("PUT", key, nil, header, nil, nil)
+	}
+
+	return rep.multipartUpload(key, func(uploadQuery url.Values) (parts []*objectStoragePart, err error) {
+		for offset := int64(0); offset < object.Size; offset += objectStorageCopyPartSize {
+			end := offset + objectStorageCopyPartSize - 1
+			if end >= object.Size {
+				end = object.Size - 1
+			}
+			part := &objectStoragePart{PartNumber: len(parts) + 1}
+			query := url.Values{"uploadId": uploadQuery["uploadId"], "partNumber": {strconv.Itoa(part.PartNumber)}}
+			header.Set("X-Amz-Copy-Source-Range", fmt.Sprintf("bytes=%d-%d", offset, end))
+			result := &struct {
+				ETag string `xml:"ETag"`
+			}{}
+			err = rep.doXML("PUT", key, query, header, nil, result)
+			if err != nil {
+				return nil, err
+			}
+			part.ETag = result.ETag
+			parts = append(parts, part)
+		}
+		return
+	})
+}
+
+// multipartUpload creates a multipart upload whose parts are uploaded by uploadParts and completes it, on errors the upload is aborted
+func (rep *ObjectStorageRepository) multipartUpload(key string, uploadParts func(uploadQuery url.Values) ([]*objectStoragePart, error)) error {
+	result := &struct {
+		UploadID string `xml:"UploadId"`
+	}{}
+	err := rep.doXML("POST", key, url.Values{"uploads": {""}}, nil, nil, result)
+	if err != nil {
+		return err
+	}
+	uploadQuery := url.Values{"uploadId": {result.UploadID}}
+
+	parts, err := uploadParts(uploadQuery)
+	if err == nil {
+		var body []byte
+		body, err = xml.Marshal(&struct {
+			XMLName xml.Name             `xml:"CompleteMultipartUpload"`
+			Parts   []*objectStoragePart `xml:"Part"`
+		}{Parts: parts})
+		if err == nil {
+			err = rep.doXML("POST", key, uploadQuery, nil, body, nil)
+		}
+	}
+	if err != nil {
+		if resp, abortErr := rep.do("DELETE", key, uploadQuery, nil, nil, 0); abortErr == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+	return nil
+}
+
+// deleteObjects deletes the objects with the given keys in batches, keys of missing objects are ignored
+func (rep *ObjectStorageRepository) deleteObjects(keys []string) error {
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > objectStorageDeleteBatch {
+			batch = batch[:objectStorageDeleteBatch]
+		}
+		keys = keys[len(batch):]
+
+		request := &struct {
+			XMLName xml.Name `xml:"Delete"`
+			Quiet   bool     `xml:"Quiet"`
+			Objects []struct {
+				Key string `xml:"Key"`
+			} `xml:"Object"`
+		}{Quiet: true}
+		for _, key := range batch {
+			request.Objects = append(request.Objects, struct {
+				Key string `xml:"Key"`
+			}{key})
+		}
+		body, err := xml.Marshal(request)
+		if err != nil {
+			return err
+		}
+		hash := md5.Sum(body)
+		header := http.Header{}
+		header.Set("Content-MD5", base64.StdEncoding.EncodeToString(hash[:]))
+
+		result := &struct {
+			Errors []*objectStorageError `xml:"Error"`
+		}{}
+		err = rep.doXML("POST", "", url.Values{"delete": {""}}, header, body, result)
+		if err != nil {
+			return err
+		}
+		if len(result.Errors) > 0 {
+			return fmt.Errorf("could not delete %s: %v", result.Errors[0].Key, result.Errors[0])
+		}
+	}
+	return nil
+}
+
+// listObjects calls fn with the objects and common prefixes of each page of the listing of the keys starting with prefix
+func (rep *ObjectStorageRepository) listObjects(prefix, delimiter string, fn func(objects []*objectStorageObject, prefixes []string) error) error {
+	continuationToken := ""
+	for {
+		result, err := rep.listPage(prefix, delimiter, continuationToken, 1000)
+		if err != nil {
+			return err
+		}
+
+		prefixes := make([]string, len(result.CommonPrefixes))
+		for it, commonPrefix := range result.CommonPrefixes {
+			prefixes[it] = commonPrefix.Prefix
+		}
+		err = fn(result.Contents, prefixes)
+		if err != nil {
+			return err
+		}
+
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return nil
+		}
+		continuationToken = result.NextContinuationToken
+	}
+}
+
+// listPage lists a page of keys, they are requested URL encoded as not all of them can be put into XML
+func (rep *ObjectStorageRepository) listPage(prefix, delimiter, continuationToken string, maxKeys int) (result *objectStorageListResult, err error) {
+	query := url.Values{
+		"list-type":     {"2"},
+		"encoding-type": {"url"},
+		"prefix":        {prefix},
+		"max-keys":      {strconv.Itoa(maxKeys)},
+	}
+	if delimiter != "" {
+		query.Set("delimiter", delimiter)
+	}
+	if continuationToken != "" {
+		query.Set("continuation-token", continuationToken)
+	}
+
+	result = &objectStorageListResult{}
+	err = rep.doXML("GET", "", query, nil, nil, result)
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range result.Contents {
+		if object.Key, err = url.QueryUnescape(object.Key); err != nil {
+			return nil, err
+		}
+	}
+	for it := range result.CommonPrefixes {
+		if result.CommonPrefixes[it].Prefix, err = url.QueryUnescape(result.CommonPrefixes[it].Prefix); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// objectStorageHandle buffers the written content in a local temp file which is uploaded as object once the handle is closed
+type objectStorageHandle struct {
+	rep  *ObjectStorageRepository
+	key  string
+	file *os.File
+}
+
+func (h *objectStorageHandle) Write(p []byte) (int, error) {
+	return h.file.Write(p)
+}
+
+func (h *objectStorageHandle) WriteAt(p []byte, offset int64) (int, error) {
+	return h.file.WriteAt(p, offset)
+}
+
+// Close uploads the written content and deletes the temp file
+func (h *objectStorageHandle) Close() (err error) {
+	defer os.Remove(h.file.Name())
+
+	size, err := h.file.Seek(0, io.SeekEnd)
+	if err == nil {
+		err = h.rep.putObject(h.key, h.file, size)
+	}
+	if closeErr := h.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		log.Error(0, "Could not store object %s: %v", h.key, err)
+	}
+	return
+}
+
+// objectStorageFile reads an object with ranged requests, Read and ReadAt each keep their response open for reading on sequentially
+type objectStorageFile struct {
+	rep    *ObjectStorageRepository
+	object *objectStorageObject
+
+	offset int64
+	body   io.ReadCloser
+
+	readAtLock   sync.Mutex
+	readAtOffset int64
+	readAtBody   io.ReadCloser
+}
+
+func (f *objectStorageFile) Read(p []byte) (n int, err error) {
+	if f.offset >= f.object.Size {
+		return 0, io.EOF
+	}
+	if f.body == nil {
+		f.body, err = f.rep.getObject(f.object.Key, f.offset)
+		if err != nil {
+			return
+		}
+	}
+	n, err = f.body.Read(p)
+	f.offset += int64(n)
+	return
+}
+
+func (f *objectStorageFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.object.Size
+	}
+	if offset < 0 {
+		return 0, os.ErrInvalid
+	}
+	if offset != f.offset && f.body != nil {
+		f.body.Close()
+		f.body = nil
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *objectStorageFile) ReadAt(p []byte, offset int64) (n int, err error) {
+	f.readAtLock.Lock()
+	defer f.readAtLock.Unlock()
+
+	if offset >= f.object.Size {
+		return 0, io.EOF
+	}
+	if f.readAtBody != nil && f.readAtOffset != offset {
+		f.readAtBody.Close()
+		f.readAtBody = nil
+	}
+	if f.readAtBody == nil {
+		f.readAtBody, err = f.rep.getObject(f.object.Key, offset)
+		if err != nil {
+			return
+		}
+	}
+	n, err = io.ReadFull(f.readAtBody, p)
+	f.readAtOffset = offset + int64(n)
+	if err == io.ErrUnexpectedEOF {
+		err = io.EOF
+	}
+	if err != nil {
+		f.readAtBody.Close()
+		f.readAtBody = nil
+	}
+	return
+}
+
+func (f *objectStorageFile) Close() error {
+	if f.body != nil {
+		f.body.Close()
+	}
+	f.readAtLock.Lock()
+	defer f.readAtLock.Unlock()
+	if f.readAtBody != nil {
+		f.readAtBody.Close()
+	}
+	return nil
+}
+
+func (f *objectStorageFile) Stat() (os.FileInfo, error) {
+	return &objectStorageFileInfo{object: f.object}, nil
+}
+
+// objectStorageFileInfo describes an object as file
+type objectStorageFileInfo struct {
+	object *objectStorageObject
+}
+
+func (fi *objectStorageFileInfo) Name() string {
+	return path.Base(fi.object.Key)
+}
+
+func (fi *objectStorageFileInfo) Size() int64 {
+	return fi.object.Size
+}
+
+func (fi *objectStorageFileInfo) Mode() os.FileMode {
+	return 0644
+}
+
+func (fi *objectStorageFileInfo) ModTime() time.Time {
+	return fi.object.LastModified
+}
+
+func (fi *objectStorageFileInfo) IsDir() bool {
+	return false
+}
+
+func (fi *objectStorageFileInfo) Sys() interface{} {
+	return nil
+}
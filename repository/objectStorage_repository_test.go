@@ -0,0 +1,314 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+// The object storage tests run against a S3-compatible storage like a local MinIO instance and are skipped unless it is configured, e.g.
+// FC_TEST_S3_ENDPOINT=http://localhost:9000 FC_TEST_S3_ACCESS_KEY_ID=minioadmin FC_TEST_S3_SECRET_ACCESS_KEY=minioadmin go test ./repository
+var testObjectStorageSetupFailed = false
+var testObjectStoragePrefix = "testData"
+var testObjectStorageTmpName = ".tmp"
+
+func testObjectStorageConfig(t *testing.T) ObjectStorageConfig {
+	config := ObjectStorageConfig{
+		Endpoint:        os.Getenv("FC_TEST_S3_ENDPOINT"),
+		Region:          "us-east-1",
+		Bucket:          "freecloud-test",
+		Prefix:          testObjectStoragePrefix,
+		AccessKeyID:     os.Getenv("FC_TEST_S3_ACCESS_KEY_ID"),
+		SecretAccessKey: os.Getenv("FC_TEST_S3_SECRET_ACCESS_KEY"),
+	}
+	if config.Endpoint == "" {
+		t.Skip("Skipped as FC_TEST_S3_ENDPOINT is not set")
+	}
+	return config
+}
+
+func testObjectStorageCleanup(rep *ObjectStorageRepository) {
+	if rep != nil {
+		rep.Delete("/")
+		rep.Close()
+	}
+}
+
+func testObjectStorageSetup(t *testing.T) *ObjectStorageRepository {
+	if testObjectStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, err := CreateObjectStorageRepository(testObjectStorageConfig(t), testObjectStorageTmpName, 1, 1)
+	if err != nil {
+		t.Fatalf("Failed to create objectStorageRepository: %v", err)
+	}
+	rep.Delete("/")
+	return rep
+}
+
+func testObjectStorageWrite(rep *ObjectStorageRepository, path string, content []byte) error {
+	handle, err := rep.CreateHandle(path)
+	if err != nil {
+		return err
+	}
+	handle.Write(content)
+	return handle.Close()
+}
+
+func testObjectStorageInsertComplete(rep *ObjectStorageRepository) {
+	rep.CreateDirectory("1/.tmp")
+	rep.CreateDirectory("/2/empty")
+	testObjectStorageWrite(rep, "1/.tmp/testfile.txt", []byte("tmp content"))
+	testObjectStorageWrite(rep, "2/anotherFile.txt", []byte("another content"))
+	testObjectStorageWrite(rep, "2/sub/nested.txt", []byte("nested content"))
+}
+
+func TestCreateObjectStorageRepository(t *testing.T) {
+	config := testObjectStorageConfig(t)
+
+	rep, err := CreateObjectStorageRepository(config, testObjectStorageTmpName, 1, 1)
+	if err != nil {
+		t.Errorf("Failed to create objectStorageRepository: %v", err)
+	}
+
+	if t.Failed() {
+		testObjectStorageSetupFailed = true
+	}
+	testObjectStorageCleanup(rep)
+
+	config.Endpoint = "localhost:9000"
+	_, err = CreateObjectStorageRepository(config, testObjectStorageTmpName, 1, 0)
+	if err != ErrObjectStorageInvalidConfig {
+		t.Errorf("Expected invalid config error for endpoint without scheme but got: %v", err)
+	}
+}
+
+func TestObjectStorageCreateAndRead(t *testing.T) {
+	rep := testObjectStorageSetup(t)
+	defer testObjectStorageCleanup(rep)
+
+	created, err := rep.CreateDirectory("1/.tmp")
+	if err != nil || !created {
+		t.Errorf("Failed to create directory '1/.tmp': %v, %v", created, err)
+	}
+	created, err = rep.CreateDirectory("1")
+	if err != nil || created {
+		t.Errorf("Expected parent of created directory to exist: %v, %v", created, err)
+	}
+	if _, err = rep.CreateDirectory("~/badDir"); err != ErrForbiddenPathName {
+		t.Errorf("Error for forbidden file name is unequal to ErrForbiddenPathName: %v", err)
+	}
+	if _, err = rep.CreateHandle("~/badFile.txt"); err != ErrForbiddenPathName {
+		t.Errorf("Error for forbidden file name is unequal to ErrForbiddenPathName: %v", err)
+	}
+
+	handle, err := rep.CreateHandle("1/.tmp/testfile.txt")
+	if err != nil {
+		t.Fatalf("Failed to create new file handle for '1/.tmp/testfile.txt': %v", err)
+	}
+	handle.Write([]byte("hello world"))
+	handle.WriteAt([]byte("W"), 6)
+	if err = handle.Close(); err != nil {
+		t.Fatalf("Failed to store file on closing handle: %v", err)
+	}
+
+	fileInfo, err := rep.GetInfo("1", ".tmp/testfile.txt")
+	if err != nil {
+		t.Fatalf("Failed to get fileInfo for '1/.tmp/testfile.txt': %v", err)
+	}
+	expFileInfo := &models.FileInfo{
+		IsDir:    false,
+		MimeType: "text/plain; charset=utf-8",
+		Name:     "testfile.txt",
+		Path:     "/.tmp/",
+		Size:     11,
+	}
+	fileInfo.LastChanged = 0
+	if !reflect.DeepEqual(fileInfo, expFileInfo) {
+		t.Errorf("Read fileInfo and expected fileInfo not deeply equal: %v != %v", fileInfo, expFileInfo)
+	}
+	if fileInfo, err = rep.GetInfo("/1", "/"); err != nil || !fileInfo.IsDir || fileInfo.Name != "1" {
+		t.Errorf("Expected user directory to be a directory: %v, %v", fileInfo, err)
+	}
+	if _, err = rep.GetInfo("1", "missing.txt"); err != ErrFileNotExist {
+		t.Errorf("Error for missing file is unequal to ErrFileNotExist: %v", err)
+	}
+
+	dirInfo, err := rep.GetDirectoryInfo("/1", "")
+	if err != nil || len(dirInfo) != 1 || !dirInfo[0].IsDir || dirInfo[0].Name != ".tmp" || dirInfo[0].Path != "/" || dirInfo[0].LastChanged == 0 {
+		t.Errorf("Expected tmp folder in user directory: %v, %v", dirInfo, err)
+	}
+	dirInfo, err = rep.GetDirectoryInfo("/1", ".tmp")
+	if err != nil || len(dirInfo) != 1 {
+		t.Fatalf("Failed to get directory info for '1/.tmp': %v, %v", dirInfo, err)
+	}
+	dirInfo[0].LastChanged = 0
+	if !reflect.DeepEqual(dirInfo[0], expFileInfo) {
+		t.Errorf("Read fileInfo in dir info and expected fileInfo are not deeply equal: %v != %v", dirInfo[0], expFileInfo)
+	}
+	if _, err = rep.GetDirectoryInfo("/1", "missing"); err != ErrFileNotExist {
+		t.Errorf("Error for listing missing directory is unequal to ErrFileNotExist: %v", err)
+	}
+
+	file, err := rep.OpenFile("1/.tmp/testfile.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	defer file.Close()
+	content, _ := ioutil.ReadAll(file)
+	if string(content) != "hello World" {
+		t.Errorf("Expected written content but got: %s", content)
+	}
+	file.Seek(6, io.SeekStart)
+	content, _ = ioutil.ReadAll(file)
+	if string(content) != "World" {
+		t.Errorf("Expected content after seeking but got: %s", content)
+	}
+	part := make([]byte, 5)
+	if n, err := file.ReadAt(part, 0); n != 5 || err != nil || string(part) != "hello" {
+		t.Errorf("Expected content read at offset but got: %s, %v", part[:n], err)
+	}
+	if n, err := file.ReadAt(part, 8); n != 3 || err != io.EOF || string(part[:n]) != "rld" {
+		t.Errorf("Expected EOF reading beyond end but got: %s, %v", part[:n], err)
+	}
+	if stat, err := file.Stat(); err != nil || stat.Size() != 11 || stat.Name() != "testfile.txt" {
+		t.Errorf("Expected stat of object: %v, %v", stat, err)
+	}
+	if _, err = rep.OpenFile("1/missing.txt"); !os.IsNotExist(err) {
+		t.Errorf("Expected not exist error for opening missing file but got: %v", err)
+	}
+}
+
+func TestObjectStorageMoveCopyDelete(t *testing.T) {
+	rep := testObjectStorageSetup(t)
+	defer testObjectStorageCleanup(rep)
+
+	testObjectStorageInsertComplete(rep)
+
+	if err := rep.Copy("2/anotherFile.txt", "1/copiedFile.txt"); err != nil {
+		t.Fatalf("Failed to copy file '2/anotherFile.txt' to '1/copiedFile.txt': %v", err)
+	}
+	if fileInfo, err := rep.GetInfo("1", "/copiedFile.txt"); err != nil || fileInfo.Size != 15 {
+		t.Errorf("Failed to get fileInfo of copied file: %v, %v", fileInfo, err)
+	}
+
+	if err := rep.Move("1/copiedFile.txt", "1/movedFile.txt"); err != nil {
+		t.Fatalf("Failed to move file: %v", err)
+	}
+	if _, err := rep.GetInfo("1", "/copiedFile.txt"); err != ErrFileNotExist {
+		t.Errorf("Getting fileInfo of moved file was successfull or error unequal to 'file not found': %v", err)
+	}
+
+	if err := rep.Move("2", "1/two"); err != nil {
+		t.Fatalf("Failed to move folder '2' to '1/two': %v", err)
+	}
+	dirInfo, err := rep.GetDirectoryInfo("/1", "two")
+	var names []string
+	for _, fileInfo := range dirInfo {
+		names = append(names, fileInfo.Name)
+	}
+	if expNames := []string{"anotherFile.txt", "empty", "sub"}; err != nil || !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Expected moved folder with its content and empty folders: %v, %v", names, err)
+	}
+	if _, err = rep.GetInfo("2", "/"); err != ErrFileNotExist {
+		t.Errorf("Expected moved folder to be gone but got: %v", err)
+	}
+	if err = rep.Move("missing", "1/missing"); err == nil {
+		t.Errorf("Expected error for moving missing folder")
+	}
+
+	if err = rep.Delete("1/two"); err != nil {
+		t.Fatalf("Failed to delete folder: %v", err)
+	}
+	if _, err = rep.GetInfo("1", "two/sub/nested.txt"); err != ErrFileNotExist {
+		t.Errorf("Expected content of deleted folder to be deleted but got: %v", err)
+	}
+	if err = rep.Delete("1/two"); err != nil {
+		t.Errorf("Expected deleting missing folder to succeed but got: %v", err)
+	}
+	if _, err = rep.GetInfo("1", "movedFile.txt"); err != nil {
+		t.Errorf("Expected file next to deleted folder to be kept but got: %v", err)
+	}
+}
+
+func TestObjectStorageMultipart(t *testing.T) {
+	rep := testObjectStorageSetup(t)
+	defer testObjectStorageCleanup(rep)
+
+	// S3 requires all parts but the last to have at least 5 MiB
+	rep.partSize = 5 << 20
+	content := bytes.Repeat([]byte("0123456789abcdef"), 700*1024)
+	if err := testObjectStorageWrite(rep, "1/large.bin", content); err != nil {
+		t.Fatalf("Failed to store file in parts: %v", err)
+	}
+
+	file, err := rep.OpenFile("1/large.bin")
+	if err != nil {
+		t.Fatalf("Failed to open file stored in parts: %v", err)
+	}
+	defer file.Close()
+	readContent, _ := ioutil.ReadAll(file)
+	if !bytes.Equal(readContent, content) {
+		t.Errorf("Expected content of file stored in parts to be equal, got %d instead of %d bytes", len(readContent), len(content))
+	}
+}
+
+func TestObjectStorageCleanTemp(t *testing.T) {
+	rep := testObjectStorageSetup(t)
+	defer testObjectStorageCleanup(rep)
+
+	testObjectStorageInsertComplete(rep)
+
+	rep.tmpDataExpiry = 0
+	err := rep.cleanupTempFolder()
+	if err != nil {
+		t.Fatalf("Failed to cleanup tmp folder: %v", err)
+	}
+	if _, err = rep.GetInfo("1", ".tmp/testfile.txt"); err != ErrFileNotExist {
+		t.Errorf("Reading tmp file after tmp cleanup successfull or error unequal to 'file does not exist': %v", err)
+	}
+	if _, err = rep.GetInfo("1", ".tmp"); err != nil {
+		t.Errorf("Expected tmp folder to be kept after tmp cleanup: %v", err)
+	}
+	if _, err = rep.GetInfo("2", "/anotherFile.txt"); err != nil {
+		t.Errorf("Failed to read normal file after tmp cleanup: %v", err)
+	}
+}
+
+func TestObjectStorageAddToZip(t *testing.T) {
+	rep := testObjectStorageSetup(t)
+	defer testObjectStorageCleanup(rep)
+
+	testObjectStorageInsertComplete(rep)
+
+	var buffer bytes.Buffer
+	zipWriter := zip.NewWriter(&buffer)
+	err := rep.AddToZip(zipWriter, "2", "two", func(relPath string) bool { return relPath == "sub" })
+	if err != nil {
+		t.Errorf("Failed to add '2' to zip: %v", err)
+	}
+	err = rep.AddToZip(zipWriter, "1/.tmp/testfile.txt", "one/file.txt", nil)
+	if err != nil {
+		t.Errorf("Failed to add '1/.tmp/testfile.txt' to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buffer.Bytes()), int64(buffer.Len()))
+	if err != nil {
+		t.Fatalf("Failed to read created zip: %v", err)
+	}
+	var names []string
+	for _, zipFile := range zipReader.File {
+		names = append(names, zipFile.Name)
+	}
+	expNames := []string{"two/anotherFile.txt", "one/file.txt"}
+	if !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", names, expNames)
+	}
+}
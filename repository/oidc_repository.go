@@ -0,0 +1,387 @@
+package repository
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	log "gopkg.in/clog.v1"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+)
+
+const (
+	oidcTimeout = 10 * time.Second
+	// oidcClockSkew is the tolerance in seconds when checking the validity period of ID tokens
+	oidcClockSkew = 60
+)
+
+var (
+	// ErrOIDCInvalidConfig is returned if the oidc repository is created with an incomplete configuration
+	ErrOIDCInvalidConfig = errors.New("oidc repository: invalid configuration")
+	// ErrOIDCInvalidToken is returned if the provider rejects the authorization code or the ID token is not valid
+	ErrOIDCInvalidToken = errors.New("oidc repository: invalid token")
+	// ErrOIDCUnverifiedEmail is returned if the provider does not vouch for the email of the user
+	ErrOIDCUnverifiedEmail = errors.New("oidc repository: email not verified")
+)
+
+// OIDCConfig contains everything needed to authenticate users against an OpenID Connect provider
+type OIDCConfig struct {
+	// IssuerURL is used for discovery and has to match the issuer of the ID tokens
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is registered at the provider and receives the authorization code
+	RedirectURL string
+	Scopes      []string
+
+	FirstNameClaim string
+	LastNameClaim  string
+
+	// Users become admins if AdminClaim is true, equals AdminValue or is a list containing AdminValue
+	AdminClaim string
+	AdminValue string
+}
+
+// oidcProviderMetadata are the used parts of the discovery document
+type oidcProviderMetadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcJSONWebKey struct {
+	KeyType string `json:"kty"`
+	KeyID   string `json:"kid"`
+	Use     string `json:"use"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+type oidcTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// OIDCRepository represents an OpenID Connect provider used for authenticating users
+type OIDCRepository struct {
+	config   OIDCConfig
+	client   *http.Client
+	mutex    sync.Mutex
+	metadata *oidcProviderMetadata
+	keys     map[string]*rsa.PublicKey
+}
+
+// CreateOIDCRepository creates a new OIDCRepository IF the given configuration is complete.
+// The provider is discovered on the first login so the server can start while the provider is unavailable.
+func CreateOIDCRepository(config OIDCConfig) (*OIDCRepository, error) {
+	issuerURL, err := url.Parse(config.IssuerURL)
+	if err != nil || (issuerURL.Scheme != "https" && issuerURL.Scheme != "http") || config.ClientID == "" || config.RedirectURL == "" {
+		log.Error(0, "Incomplete OIDC configuration for %s", config.IssuerURL)
+		return nil, ErrOIDCInvalidConfig
+	}
+
+	hasOpenID := false
+	for _, scope := range config.Scopes {
+		hasOpenID = hasOpenID || scope == "openid"
+	}
+	if !hasOpenID {
+		config.Scopes = append([]string{"openid"}, config.Scopes...)
+	}
+	config.IssuerURL = strings.TrimSuffix(config.IssuerURL, "/")
+
+	return &OIDCRepository{config: config, client: &http.Client{Timeout: oidcTimeout}}, nil
+}
+
+// HasAdminClaim returns whether the admin status of users is managed by the provider
+func (rep *OIDCRepository) HasAdminClaim() bool {
+	return rep.config.AdminClaim != ""
+}
+
+// AuthCodeURL returns the URL of the provider the user has to be sent to for logging in.
+// The code challenge for PKCE is derived from the codeVerifier, which has to be kept secret until the code is exchanged.
+func (rep *OIDCRepository) AuthCodeURL(state, nonce, codeVerifier string) (authURL string, err error) {
+	metadata, err := rep.discover()
+	if err != nil {
+		return
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {rep.config.ClientID},
+		"redirect_uri":          {rep.config.RedirectURL},
+		"scope":                 {strings.Join(rep.config.Scopes, " ")},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}
+
+	separator := "?"
+	if strings.Contains(metadata.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return metadata.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange redeems the authorization code at the provider and returns the user described by the validated ID token.
+// The admin status is only set if an admin claim is configured.
+func (rep *OIDCRepository) Exchange(code, codeVerifier, nonce string) (user *models.User, err error) {
+	metadata, err := rep.discover()
+	if err != nil {
+		return
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {rep.config.RedirectURL},
+		"code_verifier": {codeVerifier},
+	}
+	req, err := http.NewRequest(http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(rep.config.ClientID), url.QueryEscape(rep.config.ClientSecret))
+
+	resp, err := rep.client.Do(req)
+	if err != nil {
+		log.Error(0, "Could not reach OIDC token endpoint: %v", err)
+		return
+	}
+	defer resp.Body.Close()
+
+	tokenResp := &oidcTokenResponse{}
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(tokenResp)
+	if resp.StatusCode == http.StatusBadRequest || resp.StatusCode == http.StatusUnauthorized {
+		log.Warn("OIDC provider rejected authorization code: %s %s", tokenResp.Error, tokenResp.ErrorDescription)
+		return nil, ErrOIDCInvalidToken
+	} else if resp.StatusCode != http.StatusOK {
+		log.Error(0, "OIDC token endpoint returned status %d", resp.StatusCode)
+		return nil, fmt.Errorf("oidc repository: token endpoint returned status %d", resp.StatusCode)
+	} else if err != nil {
+		log.Error(0, "Could not decode OIDC token response: %v", err)
+		return
+	}
+
+	claims, err := rep.verifyIDToken(tokenResp.IDToken, nonce)
+	if err != nil {
+		return
+	}
+	return rep.mapClaims(claims)
+}
+
+// discover fetches the provider metadata once and caches it afterwards
+func (rep *OIDCRepository) discover() (metadata *oidcProviderMetadata, err error) {
+	rep.mutex.Lock()
+	defer rep.mutex.Unlock()
+	if rep.metadata != nil {
+		return rep.metadata, nil
+	}
+
+	metadata = &oidcProviderMetadata{}
+	err = rep.getJSON(rep.config.IssuerURL+"/.well-known/openid-configuration", metadata)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.Issuer != rep.config.IssuerURL || metadata.AuthorizationEndpoint == "" || metadata.TokenEndpoint == "" || metadata.JWKSURI == "" {
+		log.Error(0, "Invalid OIDC discovery document for issuer %s", rep.config.IssuerURL)
+		return nil, ErrOIDCInvalidConfig
+	}
+
+	rep.metadata = metadata
+	return
+}
+
+// getKey returns the signing key with the given ID, the key set is refetched for unknown keys to follow key rotation
+func (rep *OIDCRepository) getKey(keyID string) (key *rsa.PublicKey, err error) {
+	rep.mutex.Lock()
+	defer rep.mutex.Unlock()
+	if key, ok := rep.keys[keyID]; ok {
+		return key, nil
+	}
+
+	keySet := &struct {
+		Keys []oidcJSONWebKey `json:"keys"`
+	}{}
+	err = rep.getJSON(rep.metadata.JWKSURI, keySet)
+	if err != nil {
+		return
+	}
+
+	rep.keys = make(map[string]*rsa.PublicKey)
+	for _, jwk := range keySet.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") {
+			continue
+		}
+		n, errN := base64.RawURLEncoding.DecodeString(jwk.N)
+		e, errE := base64.RawURLEncoding.DecodeString(jwk.E)
+		if errN != nil || errE != nil || len(e) > 4 {
+			log.Warn("Skipping invalid OIDC key %s", jwk.KeyID)
+			continue
+		}
+		rep.keys[jwk.KeyID] = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+	}
+
+	key, ok := rep.keys[keyID]
+	if !ok {
+		log.Warn("OIDC signing key %s not found", keyID)
+		return nil, ErrOIDCInvalidToken
+	}
+	return key, nil
+}
+
+func (rep *OIDCRepository) getJSON(url string, target interface{}) (err error) {
+	resp, err := rep.client.Get(url)
+	if err != nil {
+		log.Error(0, "Could not reach OIDC provider at %s: %v", url, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, resp.Body)
+		log.Error(0, "OIDC provider returned status %d for %s", resp.StatusCode, url)
+		return fmt.Errorf("oidc repository: %s returned status %d", url, resp.StatusCode)
+	}
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(target)
+	if err != nil {
+		log.Error(0, "Could not decode OIDC provider response from %s: %v", url, err)
+	}
+	return
+}
+
+// verifyIDToken checks signature, issuer, audience, validity period and nonce of the ID token and returns its claims
+func (rep *OIDCRepository) verifyIDToken(idToken, nonce string) (claims map[string]interface{}, err error) {
+	parts := strings.Split(idToken, ".")
+	if len(parts) != 3 {
+		log.Warn("Malformed OIDC ID token")
+		return nil, ErrOIDCInvalidToken
+	}
+
+	header := &struct {
+		Algorithm string `json:"alg"`
+		KeyID     string `json:"kid"`
+	}{}
+	if err = decodeJWTPart(parts[0], header); err != nil {
+		return nil, ErrOIDCInvalidToken
+	}
+	// Only asymmetric signatures are accepted, this especially rules out unsigned tokens
+	if header.Algorithm != "RS256" {
+		log.Warn("Unsupported OIDC ID token algorithm %s", header.Algorithm)
+		return nil, ErrOIDCInvalidToken
+	}
+
+	key, err := rep.getKey(header.KeyID)
+	if err != nil {
+		return
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrOIDCInvalidToken
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err = rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+		log.Warn("Invalid OIDC ID token signature")
+		return nil, ErrOIDCInvalidToken
+	}
+
+	if err = decodeJWTPart(parts[1], &claims); err != nil {
+		return nil, ErrOIDCInvalidToken
+	}
+
+	now := float64(utils.GetTimestampNow())
+	exp, _ := claims["exp"].(float64)
+	iat, _ := claims["iat"].(float64)
+	sub, _ := claims["sub"].(string)
+	switch {
+	case claims["iss"] != rep.config.IssuerURL:
+		log.Warn("OIDC ID token issued by unexpected issuer %v", claims["iss"])
+	case !oidcHasAudience(claims, rep.config.ClientID):
+		log.Warn("OIDC ID token issued for unexpected audience %v", claims["aud"])
+	case exp+oidcClockSkew < now || iat-oidcClockSkew > now:
+		log.Warn("OIDC ID token expired or issued in the future")
+	case claims["nonce"] != nonce:
+		log.Warn("OIDC ID token has an unexpected nonce")
+	case sub == "":
+		log.Warn("OIDC ID token misses the subject")
+	default:
+		return claims, nil
+	}
+	return nil, ErrOIDCInvalidToken
+}
+
+// mapClaims converts the claims of an ID token to the user they describe
+func (rep *OIDCRepository) mapClaims(claims map[string]interface{}) (user *models.User, err error) {
+	email, _ := claims["email"].(string)
+	// Without a verified email anybody could take over an account by using an email of another user at the provider
+	if verified, ok := claims["email_verified"].(bool); email == "" || (ok && !verified) {
+		log.Warn("OIDC user %v has no verified email", claims["sub"])
+		return nil, ErrOIDCUnverifiedEmail
+	}
+
+	user = &models.User{AuthSubject: claims["sub"].(string), Email: email}
+	user.FirstName, _ = claims[rep.config.FirstNameClaim].(string)
+	user.LastName, _ = claims[rep.config.LastNameClaim].(string)
+
+	if rep.HasAdminClaim() {
+		switch value := claims[rep.config.AdminClaim].(type) {
+		case bool:
+			user.IsAdmin = value && rep.config.AdminValue == ""
+		case string:
+			user.IsAdmin = value == rep.config.AdminValue
+		case []interface{}:
+			for _, entry := range value {
+				user.IsAdmin = user.IsAdmin || entry == rep.config.AdminValue
+			}
+		}
+	}
+	return
+}
+
+func oidcHasAudience(claims map[string]interface{}, clientID string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == clientID
+	case []interface{}:
+		found := false
+		for _, entry := range aud {
+			found = found || entry == clientID
+		}
+		// Tokens for several audiences have to name us as authorized party
+		azp, hasAzp := claims["azp"]
+		return found && (len(aud) == 1 || (hasAzp && azp == clientID))
+	}
+	return false
+}
+
+func decodeJWTPart(part string, target interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(part)
+	if err != nil {
+		log.Warn("Could not decode OIDC ID token: %v", err)
+		return err
+	}
+	err = json.Unmarshal(data, target)
+	if err != nil {
+		log.Warn("Could not decode OIDC ID token: %v", err)
+	}
+	return err
+}
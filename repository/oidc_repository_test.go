@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+)
+
+var testOIDCClientID = "freecloud"
+var testOIDCClientSecret = "clientSecret"
+var testOIDCRedirectURL = "http://localhost:8080/oidc/callback"
+var testOIDCVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+// testOIDCProvider is a minimal mock of an OpenID Connect provider issuing ID tokens for preregistered codes
+type testOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	keyID  string
+	// codes maps authorization codes to the code challenge and claims of the ID token
+	codes map[string]testOIDCGrant
+}
+
+type testOIDCGrant struct {
+	challenge string
+	claims    map[string]interface{}
+	header    map[string]interface{}
+}
+
+func testOIDCProviderStart(t *testing.T) *testOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate provider key: %v", err)
+	}
+	idp := &testOIDCProvider{key: key, keyID: "key1", codes: make(map[string]testOIDCGrant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 idp.server.URL,
+			"authorization_endpoint": idp.server.URL + "/authorize",
+			"token_endpoint":         idp.server.URL + "/token",
+			"jwks_uri":               idp.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": idp.keyID,
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, _ := r.BasicAuth()
+		grant, ok := idp.codes[r.FormValue("code")]
+		challenge := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if !ok || clientID != testOIDCClientID || clientSecret != testOIDCClientSecret || r.FormValue("redirect_uri") != testOIDCRedirectURL ||
+			grant.challenge != base64.RawURLEncoding.EncodeToString(challenge[:]) {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		delete(idp.codes, r.FormValue("code"))
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "accessToken", "token_type": "Bearer", "id_token": idp.sign(grant)})
+	})
+	idp.server = httptest.NewServer(mux)
+	return idp
+}
+
+func (idp *testOIDCProvider) close() {
+	idp.server.Close()
+}
+
+func (idp *testOIDCProvider) config() OIDCConfig {
+	return OIDCConfig{
+		IssuerURL:      idp.server.URL,
+		ClientID:       testOIDCClientID,
+		ClientSecret:   testOIDCClientSecret,
+		RedirectURL:    testOIDCRedirectURL,
+		Scopes:         []string{"openid", "profile", "email"},
+		FirstNameClaim: "given_name",
+		LastNameClaim:  "family_name",
+		AdminClaim:     "groups",
+		AdminValue:     "freecloud-admins",
+	}
+}
+
+// authorize simulates a successful login at the provider and returns the authorization code
+func (idp *testOIDCProvider) authorize(t *testing.T, authURL string, claims map[string]interface{}) string {
+	parsedURL, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("Failed to parse auth code url: %v", err)
+	}
+	query := parsedURL.Query()
+	if query.Get("code_challenge_method") != "S256" || query.Get("client_id") != testOIDCClientID || query.Get("response_type") != "code" {
+		t.Errorf("Auth code url is missing PKCE or client parameters: %s", authURL)
+	}
+
+	grant := testOIDCGrant{
+		challenge: query.Get("code_challenge"),
+		claims: map[string]interface{}{
+			"iss":   idp.server.URL,
+			"sub":   "248289761001",
+			"aud":   testOIDCClientID,
+			"exp":   utils.GetTimestampNow() + 300,
+			"iat":   utils.GetTimestampNow(),
+			"nonce": query.Get("nonce"),
+		},
+		header: map[string]interface{}{"alg": "RS256", "kid": idp.keyID},
+	}
+	for name, value := range claims {
+		if value == nil {
+			delete(grant.claims, name)
+		} else {
+			grant.claims[name] = value
+		}
+	}
+
+	code := utils.RandomString(16)
+	idp.codes[code] = grant
+	return code
+}
+
+func (idp *testOIDCProvider) sign(grant testOIDCGrant) string {
+	header, _ := json.Marshal(grant.header)
+	claims, _ := json.Marshal(grant.claims)
+	payload := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(payload))
+	signature, _ := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	return payload + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func TestCreateOIDCRepository(t *testing.T) {
+	validConfig := OIDCConfig{IssuerURL: "https://idp.example.com/", ClientID: testOIDCClientID, RedirectURL: testOIDCRedirectURL, Scopes: []string{"email"}}
+
+	rep, err := CreateOIDCRepository(validConfig)
+	if err != nil {
+		t.Fatalf("Failed to create oidc repository: %v", err)
+	}
+	if rep.config.IssuerURL != "https://idp.example.com" || !reflect.DeepEqual(rep.config.Scopes, []string{"openid", "email"}) {
+		t.Errorf("Expected normalized issuer and openid scope, got %s and %v", rep.config.IssuerURL, rep.config.Scopes)
+	}
+
+	invalidConfigs := map[string]func(config *OIDCConfig){
+		"scheme":      func(config *OIDCConfig) { config.IssuerURL = "ldap://idp.example.com" },
+		"clientID":    func(config *OIDCConfig) { config.ClientID = "" },
+		"redirectURL": func(config *OIDCConfig) { config.RedirectURL = "" },
+	}
+	for name, modify := range invalidConfigs {
+		config := validConfig
+		modify(&config)
+		if _, err = CreateOIDCRepository(config); err != ErrOIDCInvalidConfig {
+			t.Errorf("Expected invalid config error for %s, got %v", name, err)
+		}
+	}
+}
+
+func TestOIDCLogin(t *testing.T) {
+	idp := testOIDCProviderStart(t)
+	defer idp.close()
+
+	rep, err := CreateOIDCRepository(idp.config())
+	if err != nil {
+		t.Fatalf("Failed to create oidc repository: %v", err)
+	}
+
+	authURL, err := rep.AuthCodeURL("state", "nonce", testOIDCVerifier)
+	if err != nil {
+		t.Fatalf("Failed to get auth code url: %v", err)
+	}
+	if !strings.HasPrefix(authURL, idp.server.URL+"/authorize?") || !strings.Contains(authURL, "state=state") {
+		t.Errorf("Auth code url does not point to discovered endpoint: %s", authURL)
+	}
+
+	code := idp.authorize(t, authURL, map[string]interface{}{
+		"email":          "user@example.com",
+		"email_verified": true,
+		"given_name":     "OIDC",
+		"family_name":    "User",
+		"groups":         []string{"users", "freecloud-admins"},
+	})
+	user, err := rep.Exchange(code, testOIDCVerifier, "nonce")
+	if err != nil {
+		t.Fatalf("Failed to exchange authorization code: %v", err)
+	}
+	expUser := &models.User{Email: "user@example.com", FirstName: "OIDC", LastName: "User", IsAdmin: true, AuthSubject: "248289761001"}
+	if !reflect.DeepEqual(user, expUser) {
+		t.Errorf("Exchanged user and expected user not deeply equal: %v != %v", user, expUser)
+	}
+
+	// Codes can only be redeemed once
+	_, err = rep.Exchange(code, testOIDCVerifier, "nonce")
+	if err != ErrOIDCInvalidToken {
+		t.Errorf("Expected invalid token error for reused code, got %v", err)
+	}
+}
+
+func TestOIDCInvalidTokens(t *testing.T) {
+	idp := testOIDCProviderStart(t)
+	defer idp.close()
+
+	rep, _ := CreateOIDCRepository(idp.config())
+	authURL, _ := rep.AuthCodeURL("state", "nonce", testOIDCVerifier)
+	now := utils.GetTimestampNow()
+
+	testCases := map[string]struct {
+		claims   map[string]interface{}
+		header   map[string]interface{}
+		verifier string
+		err      error
+	}{
+		"verifier":   {verifier: "wrongVerifier", err: ErrOIDCInvalidToken},
+		"issuer":     {claims: map[string]interface{}{"iss": "https://evil.example.com"}, err: ErrOIDCInvalidToken},
+		"audience":   {claims: map[string]interface{}{"aud": "other"}, err: ErrOIDCInvalidToken},
+		"azp":        {claims: map[string]interface{}{"aud": []string{testOIDCClientID, "other"}}, err: ErrOIDCInvalidToken},
+		"expired":    {claims: map[string]interface{}{"exp": now - 600}, err: ErrOIDCInvalidToken},
+		"future":     {claims: map[string]interface{}{"iat": now + 600}, err: ErrOIDCInvalidToken},
+		"nonce":      {claims: map[string]interface{}{"nonce": "other"}, err: ErrOIDCInvalidToken},
+		"subject":    {claims: map[string]interface{}{"sub": nil}, err: ErrOIDCInvalidToken},
+		"algorithm":  {header: map[string]interface{}{"alg": "none", "kid": "key1"}, err: ErrOIDCInvalidToken},
+		"keyID":      {header: map[string]interface{}{"alg": "RS256", "kid": "unknown"}, err: ErrOIDCInvalidToken},
+		"noEmail":    {claims: map[string]interface{}{"email": nil}, err: ErrOIDCUnverifiedEmail},
+		"unverified": {claims: map[string]interface{}{"email_verified": false}, err: ErrOIDCUnverifiedEmail},
+	}
+
+	for name, testCase := range testCases {
+		claims := map[string]interface{}{"email": "user@example.com"}
+		for claim, value := range testCase.claims {
+			claims[claim] = value
+		}
+		code := idp.authorize(t, authURL, claims)
+		if testCase.header != nil {
+			grant := idp.codes[code]
+			grant.header = testCase.header
+			idp.codes[code] = grant
+		}
+		verifier := testOIDCVerifier
+		if testCase.verifier != "" {
+			verifier = testCase.verifier
+		}
+
+		_, err := rep.Exchange(code, verifier, "nonce")
+		if err != testCase.err {
+			t.Errorf("Expected error %v for %s, got %v", testCase.err, name, err)
+		}
+	}
+}
+
+func TestOIDCAdminClaim(t *testing.T) {
+	rep, _ := CreateOIDCRepository(OIDCConfig{IssuerURL: "https://idp.example.com", ClientID: testOIDCClientID, RedirectURL: testOIDCRedirectURL, AdminClaim: "admin"})
+	testCases := map[string]struct {
+		value      interface{}
+		adminValue string
+		isAdmin    bool
+	}{
+		"bool":         {true, "", true},
+		"boolFalse":    {false, "", false},
+		"boolValue":    {true, "yes", false},
+		"string":       {"yes", "yes", true},
+		"stringOther":  {"no", "yes", false},
+		"list":         {[]interface{}{"a", "yes"}, "yes", true},
+		"listMissing":  {[]interface{}{"a", "b"}, "yes", false},
+		"claimMissing": {nil, "yes", false},
+	}
+
+	for name, testCase := range testCases {
+		rep.config.AdminValue = testCase.adminValue
+		user, err := rep.mapClaims(map[string]interface{}{"sub": "1", "email": "user@example.com", "admin": testCase.value})
+		if err != nil {
+			t.Fatalf("Failed to map claims for %s: %v", name, err)
+		}
+		if user.IsAdmin != testCase.isAdmin {
+			t.Errorf("Expected admin status %v for %s, got %v", testCase.isAdmin, name, user.IsAdmin)
+		}
+	}
+}
+
+func TestOIDCProviderUnavailable(t *testing.T) {
+	idp := testOIDCProviderStart(t)
+	config := idp.config()
+	idp.close()
+
+	rep, _ := CreateOIDCRepository(config)
+	_, err := rep.AuthCodeURL("state", "nonce", testOIDCVerifier)
+	if err == nil || err == ErrOIDCInvalidToken {
+		t.Errorf("Expected connection error for unavailable provider, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.RoleBinding{})
+}
+
+// RoleBindingRepository represents the database for storing the roles assigned to users and groups
+type RoleBindingRepository struct{}
+
+// CreateRoleBindingRepository creates a new RoleBindingRepository IF gorm has been initialized before
+func CreateRoleBindingRepository() (*RoleBindingRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &RoleBindingRepository{}, nil
+}
+
+// SetForUser replaces all roles assigned directly to a user
+func (rep *RoleBindingRepository) SetForUser(userID int64, roles []string) error {
+	return rep.set("user_id", userID, roles)
+}
+
+// SetForGroup replaces all roles assigned to a group
+func (rep *RoleBindingRepository) SetForGroup(groupID int64, roles []string) error {
+	return rep.set("group_id", groupID, roles)
+}
+
+// GetForUser returns the roles assigned directly to a user
+func (rep *RoleBindingRepository) GetForUser(userID int64) ([]string, error) {
+	return rep.get("user_id = ?", userID)
+}
+
+// GetForGroup returns the roles assigned to a group
+func (rep *RoleBindingRepository) GetForGroup(groupID int64) ([]string, error) {
+	return rep.get("group_id = ?", groupID)
+}
+
+// GetForUserAndGroups returns the roles assigned directly to a user or to any of the given groups, duplicates are included
+func (rep *RoleBindingRepository) GetForUserAndGroups(userID int64, groupIDs []int64) ([]string, error) {
+	if len(groupIDs) == 0 {
+		return rep.GetForUser(userID)
+	}
+	return rep.get("user_id = ? OR group_id IN (?)", userID, groupIDs)
+}
+
+// DeleteAllForUser removes all roles assigned directly to a user
+func (rep *RoleBindingRepository) DeleteAllForUser(userID int64) error {
+	return rep.set("user_id", userID, nil)
+}
+
+// DeleteAllForGroup removes all roles assigned to a group
+func (rep *RoleBindingRepository) DeleteAllForGroup(groupID int64) error {
+	return rep.set("group_id", groupID, nil)
+}
+
+func (rep *RoleBindingRepository) set(column string, id int64, roles []string) (err error) {
+	tx := databaseConnection.Begin()
+	if err = tx.Error; err != nil {
+		log.Error(0, "Could not begin transaction to set roles for %s %v: %v", column, id, err)
+		return
+	}
+
+	err = tx.Delete(&models.RoleBinding{}, column+" = ?", id).Error
+	if err != nil {
+		tx.Rollback()
+		log.Error(0, "Could not delete roles for %s %v: %v", column, id, err)
+		return
+	}
+
+	for _, role := range roles {
+		roleBinding := &models.RoleBinding{Role: role}
+		if column == "user_id" {
+			roleBinding.UserID = id
+		} else {
+			roleBinding.GroupID = id
+		}
+		err = tx.Create(roleBinding).Error
+		if err != nil {
+			tx.Rollback()
+			log.Error(0, "Could not assign role %s for %s %v: %v", role, column, id, err)
+			return
+		}
+	}
+
+	err = tx.Commit().Error
+	if err != nil {
+		log.Error(0, "Could not commit roles for %s %v: %v", column, id, err)
+		return
+	}
+	return
+}
+
+func (rep *RoleBindingRepository) get(query string, args ...interface{}) (roles []string, err error) {
+	roles = []string{}
+	err = databaseConnection.Model(&models.RoleBinding{}).Where(query, args...).Order("role").Pluck("role", &roles).Error
+	if err != nil {
+		log.Error(0, "Could not get roles: %v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+var testRoleBindingSetupFailed = false
+var testRoleBindingDBName = "roleBindingTest.db"
+
+func testRoleBindingCleanup() {
+	os.Remove(testRoleBindingDBName)
+}
+
+func testRoleBindingSetup() *RoleBindingRepository {
+	testRoleBindingCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testRoleBindingDBName)
+	rep, _ := CreateRoleBindingRepository()
+	return rep
+}
+
+func TestCreateRoleBindingRepository(t *testing.T) {
+	testRoleBindingCleanup()
+	defer testRoleBindingCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testRoleBindingDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateRoleBindingRepository()
+	if err != nil {
+		t.Errorf("Failed to create role binding repository: %v", err)
+	}
+
+	if t.Failed() {
+		testRoleBindingSetupFailed = true
+	}
+}
+
+func TestRoleBindings(t *testing.T) {
+	if testRoleBindingSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testRoleBindingCleanup()
+	rep := testRoleBindingSetup()
+
+	err := rep.SetForUser(1, []string{"read_only", "auditor"})
+	if err != nil {
+		t.Fatalf("Failed to set roles of user 1: %v", err)
+	}
+	rep.SetForUser(1, []string{"user_manager"})
+	rep.SetForUser(2, []string{"auditor"})
+	err = rep.SetForGroup(1, []string{"share_disabled", "auditor"})
+	if err != nil {
+		t.Fatalf("Failed to set roles of group 1: %v", err)
+	}
+
+	roles, err := rep.GetForUser(1)
+	if err != nil || !reflect.DeepEqual(roles, []string{"user_manager"}) {
+		t.Errorf("Expected replaced roles [user_manager] of user 1 but got: %v, %v", roles, err)
+	}
+	roles, err = rep.GetForGroup(1)
+	if err != nil || !reflect.DeepEqual(roles, []string{"auditor", "share_disabled"}) {
+		t.Errorf("Expected roles [auditor share_disabled] of group 1 but got: %v, %v", roles, err)
+	}
+	roles, err = rep.GetForUserAndGroups(1, []int64{1})
+	if err != nil || !reflect.DeepEqual(roles, []string{"auditor", "share_disabled", "user_manager"}) {
+		t.Errorf("Expected roles of user 1 and group 1 combined but got: %v, %v", roles, err)
+	}
+	roles, err = rep.GetForUserAndGroups(3, nil)
+	if err != nil || len(roles) != 0 {
+		t.Errorf("Expected no roles for user 3 without groups but got: %v, %v", roles, err)
+	}
+
+	rep.DeleteAllForUser(1)
+	rep.DeleteAllForGroup(1)
+	roles, _ = rep.GetForUserAndGroups(1, []int64{1})
+	if len(roles) != 0 {
+		t.Errorf("Expected no roles after deletion but got: %v", roles)
+	}
+	roles, _ = rep.GetForUser(2)
+	if !reflect.DeepEqual(roles, []string{"auditor"}) {
+		t.Errorf("Expected roles of user 2 to be unaffected but got: %v", roles)
+	}
+}
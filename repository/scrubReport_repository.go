@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Status of a scrub report, failed scrubs have been interrupted by an error or a restart
+const (
+	ScrubRunning  = "running"
+	ScrubFinished = "finished"
+	ScrubFailed   = "failed"
+)
+
+// Kind of an issue found by a scrub
+const (
+	// ScrubIssueCorrupted is a file whose content does not match its checksum although its size and change time do
+	ScrubIssueCorrupted = "corrupted"
+	// ScrubIssueModified is a file whose content has been changed out-of-band since it has been indexed
+	ScrubIssueModified = "modified"
+	// ScrubIssueUnreadable is a file whose content cannot be read, encrypted content fails to decrypt if it is corrupted
+	ScrubIssueUnreadable = "unreadable"
+	// ScrubIssueMissing is an indexed file which does not exist in the storage anymore
+	ScrubIssueMissing = "missing"
+	// ScrubIssueUnindexed is a file in the storage which has not been indexed
+	ScrubIssueUnindexed = "unindexed"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.ScrubReport{}, &models.ScrubIssue{})
+}
+
+// ScrubReportRepository represents the database for storing the reports of integrity scrubs and the issues they found
+type ScrubReportRepository struct{}
+
+// CreateScrubReportRepository creates a new ScrubReportRepository IF gorm has been initialized before
+func CreateScrubReportRepository() (*ScrubReportRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &ScrubReportRepository{}, nil
+}
+
+// Create stores a new running scrub report
+func (rep *ScrubReportRepository) Create(report *models.ScrubReport) (err error) {
+	report.Started = utils.GetTimestampNow()
+	report.Status = ScrubRunning
+	err = databaseConnection.Create(report).Error
+	if err != nil {
+		log.Error(0, "Could not create scrub report: %v", err)
+		return
+	}
+	return
+}
+
+// Update updates the status and counts of a stored scrub report, its issues are added separately
+func (rep *ScrubReportRepository) Update(report *models.ScrubReport) (err error) {
+	err = databaseConnection.Save(report).Error
+	if err != nil {
+		log.Error(0, "Could not update scrub report %d: %v", report.ID, err)
+		return
+	}
+	return
+}
+
+// AddIssue stores an issue found by the scrub of the report
+func (rep *ScrubReportRepository) AddIssue(issue *models.ScrubIssue) (err error) {
+	err = databaseConnection.Create(issue).Error
+	if err != nil {
+		log.Error(0, "Could not add issue to scrub report %d: %v", issue.ReportID, err)
+		return
+	}
+	return
+}
+
+// GetLatest returns the most recently started scrub report with its issues
+func (rep *ScrubReportRepository) GetLatest() (report *models.ScrubReport, err error) {
+	report = &models.ScrubReport{}
+	err = databaseConnection.Order("started desc, id desc").First(report).Error
+	if err != nil {
+		return
+	}
+	report.Issues = []*models.ScrubIssue{}
+	err = databaseConnection.Where("report_id = ?", report.ID).Order("id").Find(&report.Issues).Error
+	return
+}
+
+// FailUnfinished marks all running scrubs as failed, they cannot finish after a restart
+func (rep *ScrubReportRepository) FailUnfinished() (err error) {
+	err = databaseConnection.Model(&models.ScrubReport{}).
+		Where("status = ?", ScrubRunning).
+		Updates(map[string]interface{}{"status": ScrubFailed, "finished": utils.GetTimestampNow()}).Error
+	if err != nil {
+		log.Error(0, "Could not fail unfinished scrubs: %v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testScrubReportSetupFailed = false
+var testScrubReportDBName = "scrubReportTest.db"
+
+func testScrubReportCleanup() {
+	os.Remove(testScrubReportDBName)
+}
+
+func testScrubReportSetup() *ScrubReportRepository {
+	testScrubReportCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testScrubReportDBName)
+	rep, _ := CreateScrubReportRepository()
+	return rep
+}
+
+func TestCreateScrubReportRepository(t *testing.T) {
+	testScrubReportCleanup()
+	defer testScrubReportCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testScrubReportDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateScrubReportRepository()
+	if err != nil {
+		t.Errorf("Failed to create scrub report repository: %v", err)
+	}
+
+	if t.Failed() {
+		testScrubReportSetupFailed = true
+	}
+}
+
+func TestScrubReportGetLatest(t *testing.T) {
+	if testScrubReportSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testScrubReportCleanup()
+	rep := testScrubReportSetup()
+
+	if _, err := rep.GetLatest(); !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error without reports but got: %v", err)
+	}
+
+	first := &models.ScrubReport{}
+	rep.Create(first)
+	rep.AddIssue(&models.ScrubIssue{ReportID: first.ID, Kind: ScrubIssueMissing, Path: "/old.txt"})
+	latest := &models.ScrubReport{}
+	err := rep.Create(latest)
+	if err != nil {
+		t.Fatalf("Failed to create scrub report: %v", err)
+	}
+	rep.AddIssue(&models.ScrubIssue{ReportID: latest.ID, Kind: ScrubIssueCorrupted, Path: "/a.txt"})
+	rep.AddIssue(&models.ScrubIssue{ReportID: latest.ID, Kind: ScrubIssueUnindexed, Path: "/b.txt"})
+
+	readBack, err := rep.GetLatest()
+	if err != nil || readBack.ID != latest.ID || readBack.Status != ScrubRunning || readBack.Started == 0 {
+		t.Fatalf("Failed to read back latest scrub report: %v, %v", readBack, err)
+	}
+	if len(readBack.Issues) != 2 || readBack.Issues[0].Path != "/a.txt" || readBack.Issues[1].Kind != ScrubIssueUnindexed {
+		t.Errorf("Expected issues of the latest report only but got: %v", readBack.Issues)
+	}
+
+	latest.Status = ScrubFinished
+	latest.CheckedFiles = 2
+	rep.Update(latest)
+	err = rep.FailUnfinished()
+	if err != nil {
+		t.Fatalf("Failed to fail unfinished scrubs: %v", err)
+	}
+	if readBack, _ = rep.GetLatest(); readBack.Status != ScrubFinished || readBack.CheckedFiles != 2 {
+		t.Errorf("Expected finished report to be kept but got: %v", readBack)
+	}
+}
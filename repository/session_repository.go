@@ -59,6 +59,15 @@ func (rep *SessionRepository) DeleteAllForUser(userID int64) (err error) {
 	return
 }
 
+// GetAllForUser returns all sessions of one user
+func (rep *SessionRepository) GetAllForUser(userID int64) (sessions []*models.Session, err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Find(&sessions).Error
+	if err != nil {
+		log.Error(0, "Could not get all sessions for user %d: %v", userID, err)
+	}
+	return
+}
+
 // DeleteExpired deletes all expired sessions
 func (rep *SessionRepository) DeleteExpired() (err error) {
 	log.Trace("Cleaning old sessions")
@@ -151,6 +151,25 @@ func TestDeleteSession(t *testing.T) {
 	}
 }
 
+func TestGetAllForUserSessions(t *testing.T) {
+	if testSessionSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testSessionCleanup()
+	rep := testSessionSetup()
+
+	testSessionInsert(rep)
+
+	sessions, err := rep.GetAllForUser(testSession2.UserID)
+	if err != nil {
+		t.Errorf("Failed to get all sessions for user of session2: %v", err)
+	}
+	expSessions := []*models.Session{testSession2, testSession3}
+	if !reflect.DeepEqual(sessions, expSessions) {
+		t.Errorf("Sessions for user of session2 and expected sessions not deeply equal: %v != %v", sessions, expSessions)
+	}
+}
+
 func TestDeleteAllForUserSessions(t *testing.T) {
 	if testSessionSetupFailed {
 		t.Skip("Skipped due to failed setup")
@@ -0,0 +1,417 @@
+package repository
+
+import (
+	"archive/zip"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	log "gopkg.in/clog.v1"
+)
+
+// sftpStorageDialTimeout is the time connecting and authenticating to the SFTP server may take
+const sftpStorageDialTimeout = 10 * time.Second
+
+// ErrSFTPStorageInvalidConfig is returned if the SFTP storage repository is created with an incomplete configuration
+var ErrSFTPStorageInvalidConfig = errors.New("sftp storage repository: invalid configuration")
+
+// SFTPStorageConfig contains everything needed to store files in a directory of an SFTP server
+type SFTPStorageConfig struct {
+	Host string
+	Port int
+	// Root is the absolute path of the directory on the server all paths are relative to
+	Root string
+
+	Username string
+	// Password and PrivateKey in PEM format are used for authentication, at least one of them has to be set
+	Password   string
+	PrivateKey string
+	// HostKey is the public key of the server in authorized_keys format, connections to servers with other keys are refused
+	HostKey string
+}
+
+// SFTPStorageRepository stores files in a directory of an SFTP server.
+// It connects on first use and reconnects on the next one after the connection has been lost.
+// There is no temp cleanup, it is meant for directories mounted into the files of users.
+type SFTPStorageRepository struct {
+	address   string
+	root      string
+	sshConfig *ssh.ClientConfig
+
+	lock      sync.Mutex
+	sshClient *ssh.Client
+	client    *sftp.Client
+}
+
+// CreateSFTPStorageRepository creates a new SFTPStorageRepository, the server is not contacted before the first operation
+func CreateSFTPStorageRepository(config SFTPStorageConfig) (*SFTPStorageRepository, error) {
+	if config.Host == "" || config.Username == "" || !path.IsAbs(config.Root) || !utils.ValidatePath(config.Root) || (config.Password == "" && config.PrivateKey == "") {
+		return nil, ErrSFTPStorageInvalidConfig
+	}
+	if config.Port == 0 {
+		config.Port = 22
+	}
+
+	hostKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(config.HostKey))
+	if err != nil {
+		log.Error(0, "Could not parse host key of SFTP server %s: %v", config.Host, err)
+		return nil, ErrSFTPStorageInvalidConfig
+	}
+	var auth []ssh.AuthMethod
+	if config.PrivateKey != "" {
+		signer, err := ssh.ParsePrivateKey([]byte(config.PrivateKey))
+		if err != nil {
+			log.Error(0, "Could not parse private key for SFTP server %s: %v", config.Host, err)
+			return nil, ErrSFTPStorageInvalidConfig
+		}
+		auth = append(auth, ssh.PublicKeys(signer))
+	}
+	if config.Password != "" {
+		auth = append(auth, ssh.Password(config.Password))
+	}
+
+	return &SFTPStorageRepository{
+		address: net.JoinHostPort(config.Host, strconv.Itoa(config.Port)),
+		root:    path.Clean(config.Root),
+		sshConfig: &ssh.ClientConfig{
+			User:            config.Username,
+			Auth:            auth,
+			HostKeyCallback: ssh.FixedHostKey(hostKey),
+			Timeout:         sftpStorageDialTimeout,
+		},
+	}, nil
+}
+
+// Close closes the connection to the server if there is one
+func (rep *SFTPStorageRepository) Close() error {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	if rep.client == nil {
+		return nil
+	}
+	rep.client.Close()
+	err := rep.sshClient.Close()
+	rep.client, rep.sshClient = nil, nil
+	return err
+}
+
+// getClient returns the client of the current connection, connecting to the server if there is none
+func (rep *SFTPStorageRepository) getClient() (*sftp.Client, error) {
+	rep.lock.Lock()
+	defer rep.lock.Unlock()
+
+	if rep.client != nil {
+		return rep.client, nil
+	}
+
+	sshClient, err := ssh.Dial("tcp", rep.address, rep.sshConfig)
+	if err != nil {
+		log.Error(0, "Could not connect to SFTP server %s: %v", rep.address, err)
+		return nil, err
+	}
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		log.Error(0, "Could not start SFTP session with %s: %v", rep.address, err)
+		return nil, err
+	}
+	rep.sshClient, rep.client = sshClient, client
+
+	// Forget the connection once it is lost, so the next operation reconnects
+	go func() {
+		sshClient.Wait()
+		rep.lock.Lock()
+		defer rep.lock.Unlock()
+		if rep.client == client {
+			client.Close()
+			rep.client, rep.sshClient = nil, nil
+		}
+	}()
+	return client, nil
+}
+
+// remotePath returns the path on the server for the path relative to the root
+func (rep *SFTPStorageRepository) remotePath(filePath string) string {
+	return path.Join(rep.root, filepath.ToSlash(filePath))
+}
+
+// CreateHandle creates or truncates the file on the server for writing to.
+// Before creating the file, it check the path for sanity.
+func (rep *SFTPStorageRepository) CreateHandle(filePath string) (StorageHandle, error) {
+	if !utils.ValidatePath(filePath) {
+		return nil, ErrForbiddenPathName
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := client.Create(rep.remotePath(filePath))
+	if err != nil {
+		log.Error(0, "Could not create file %s: %v", filePath, err)
+		return nil, err
+	}
+	return file, nil
+}
+
+// OpenFile opens the file on the server for reading from.
+// Before opening the file, it check the path for sanity.
+func (rep *SFTPStorageRepository) OpenFile(filePath string) (StorageFile, error) {
+	if !utils.ValidatePath(filePath) {
+		return nil, ErrForbiddenPathName
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := client.Open(rep.remotePath(filePath))
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// CreateDirectory checks whether directory exists and creates it and its missing parents otherwise
+func (rep *SFTPStorageRepository) CreateDirectory(dirPath string) (created bool, err error) {
+	if !utils.ValidatePath(dirPath) {
+		return false, ErrForbiddenPathName
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return false, err
+	}
+
+	remotePath := rep.remotePath(dirPath)
+	_, statErr := client.Stat(remotePath)
+	if os.IsNotExist(statErr) {
+		log.Info("Directory does not exist, creating it now")
+		err = client.MkdirAll(remotePath)
+		if err != nil {
+			log.Error(0, "Could not create directory %s: %v", dirPath, err)
+		}
+		return true, err
+	} else if statErr != nil {
+		log.Warn("Could not check if directory exists, assuming it does: %v", statErr)
+		return false, statErr
+	}
+	return false, nil
+}
+
+// GetDirectoryInfo returns a list of all files and folders in the given "path" (relative to the user's directory).
+// Before doing so, it checks the path for sanity.
+func (rep *SFTPStorageRepository) GetDirectoryInfo(userPath, dirPath string) ([]*models.FileInfo, error) {
+	if !utils.ValidatePath(dirPath) {
+		return nil, ErrForbiddenPathName
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := client.ReadDir(rep.remotePath(path.Join(filepath.ToSlash(userPath), filepath.ToSlash(dirPath))))
+	if err != nil {
+		log.Error(0, "Could not list files in %s: %v", dirPath, err)
+		return nil, err
+	}
+
+	if dirPath == "" {
+		dirPath = "/"
+	}
+	dirPath = utils.ConvertToSlash(dirPath, true)
+
+	fileInfos := make([]*models.FileInfo, len(info), len(info))
+	for i, f := range info {
+		fileInfos[i] = rep.generateInfo(f, dirPath)
+	}
+	return fileInfos, nil
+}
+
+// GetInfo generates and returns the fileInfo of a file on the server
+func (rep *SFTPStorageRepository) GetInfo(userPath, filePath string) (fileInfo *models.FileInfo, err error) {
+	client, err := rep.getClient()
+	if err != nil {
+		return
+	}
+
+	osFileInfo, err := client.Stat(rep.remotePath(path.Join(filepath.ToSlash(userPath), filepath.ToSlash(filePath))))
+	if os.IsNotExist(err) {
+		err = ErrFileNotExist
+		return
+	} else if err != nil {
+		err = fmt.Errorf("Error resolving file path: %v", err)
+		return
+	}
+
+	folderPath, _ := utils.SplitPath(filePath)
+	fileInfo = rep.generateInfo(osFileInfo, folderPath)
+	return
+}
+
+func (rep *SFTPStorageRepository) generateInfo(osFileInfo os.FileInfo, path string) *models.FileInfo {
+	return &models.FileInfo{
+		Path:        utils.ConvertToSlash(path, true),
+		Name:        osFileInfo.Name(),
+		IsDir:       osFileInfo.IsDir(),
+		Size:        osFileInfo.Size(),
+		LastChanged: osFileInfo.ModTime().UTC().Unix(),
+		MimeType:    mime.TypeByExtension(filepath.Ext(osFileInfo.Name())),
+	}
+}
+
+// AddToZip writes the file or the content of the folder at path into the zip archive below zipPath.
+// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+func (rep *SFTPStorageRepository) AddToZip(zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) (err error) {
+	if !utils.ValidatePath(filePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return
+	}
+
+	remotePath := rep.remotePath(filePath)
+	walker := client.Walk(remotePath)
+	for walker.Step() {
+		if err = walker.Err(); err != nil {
+			break
+		}
+
+		relPath := strings.TrimPrefix(strings.TrimPrefix(walker.Path(), remotePath), "/")
+		info := walker.Stat()
+		if skip != nil && relPath != "" && skip(relPath) {
+			if info.IsDir() {
+				walker.SkipDir()
+			}
+			continue
+		}
+		if info.IsDir() {
+			continue
+		}
+
+		err = rep.addFileToZip(client, zipWriter, walker.Path(), info, path.Join(filepath.ToSlash(zipPath), relPath))
+		if err != nil {
+			break
+		}
+	}
+	if err != nil {
+		log.Error(0, "Error adding %v to zip: %v", filePath, err)
+		return
+	}
+	return
+}
+
+func (rep *SFTPStorageRepository) addFileToZip(client *sftp.Client, zipWriter *zip.Writer, remotePath string, info os.FileInfo, name string) (err error) {
+	header, err := zip.FileInfoHeader(info)
+	if err != nil {
+		return
+	}
+	header.Name = name
+	header.Method = zip.Deflate
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return
+	}
+
+	file, err := client.Open(remotePath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	_, err = io.Copy(writer, file)
+	return
+}
+
+// Move moves the file/folder of oldPath to newPath, an existing file at newPath is replaced if the server supports it
+func (rep *SFTPStorageRepository) Move(oldPath, newPath string) (err error) {
+	if !utils.ValidatePath(oldPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	if !utils.ValidatePath(newPath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return
+	}
+
+	if _, ok := client.HasExtension("posix-rename@openssh.com"); ok {
+		err = client.PosixRename(rep.remotePath(oldPath), rep.remotePath(newPath))
+	} else {
+		err = client.Rename(rep.remotePath(oldPath), rep.remotePath(newPath))
+	}
+	if err != nil {
+		log.Error(0, "Moving %v to %v failed", oldPath, newPath)
+		return
+	}
+	return
+}
+
+// Delete deletes the file/folder at the given path
+func (rep *SFTPStorageRepository) Delete(deletePath string) (err error) {
+	if !utils.ValidatePath(deletePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+	client, err := rep.getClient()
+	if err != nil {
+		return
+	}
+
+	err = client.RemoveAll(rep.remotePath(deletePath))
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		log.Error(0, "Deleting %v failed", deletePath)
+		return
+	}
+	return
+}
+
+// Copy copied the file of oldPath to newPath
+func (rep *SFTPStorageRepository) Copy(oldPath, newPath string) (err error) {
+	client, err := rep.getClient()
+	if err != nil {
+		return
+	}
+
+	in, err := client.Open(rep.remotePath(oldPath))
+	if err != nil {
+		err = fmt.Errorf("Error opening file %v to copy", oldPath)
+		return
+	}
+	defer in.Close()
+
+	out, err := client.Create(rep.remotePath(newPath))
+	if err != nil {
+		err = fmt.Errorf("Error creating file %v to copy to", newPath)
+		log.Error(0, "%v", err)
+		return
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	if err != nil {
+		err = fmt.Errorf("Error copying %v to %v", oldPath, newPath)
+		log.Error(0, "%v", err)
+		return
+	}
+	return
+}
@@ -0,0 +1,305 @@
+package repository
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+var testSFTPStorageSetupFailed = false
+var testSFTPStorageDirName = "testDataSFTP"
+var testSFTPStoragePassword = "sftpPassword"
+
+// testSFTPServer serves the local file system over SFTP for a single user with password login
+type testSFTPServer struct {
+	listener net.Listener
+	hostKey  ssh.PublicKey
+}
+
+func testSFTPStartServer() (*testSFTPServer, error) {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() != "tester" || string(password) != testSFTPStoragePassword {
+				return nil, errors.New("wrong username or password")
+			}
+			return nil, nil
+		},
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go testSFTPHandleConn(conn, config)
+		}
+	}()
+	return &testSFTPServer{listener: listener, hostKey: signer.PublicKey()}, nil
+}
+
+func testSFTPHandleConn(conn net.Conn, config *ssh.ServerConfig) {
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	defer sshConn.Close()
+	go ssh.DiscardRequests(requests)
+
+	for newChannel := range channels {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "only sessions are supported")
+			continue
+		}
+		channel, channelRequests, err := newChannel.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			for req := range channelRequests {
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+				if ok {
+					go func() {
+						server, err := sftp.NewServer(channel)
+						if err == nil {
+							server.Serve()
+							server.Close()
+						}
+						channel.Close()
+					}()
+				}
+			}
+		}()
+	}
+}
+
+func (srv *testSFTPServer) config(root string) SFTPStorageConfig {
+	addr := srv.listener.Addr().(*net.TCPAddr)
+	return SFTPStorageConfig{
+		Host:     addr.IP.String(),
+		Port:     addr.Port,
+		Root:     root,
+		Username: "tester",
+		Password: testSFTPStoragePassword,
+		HostKey:  string(ssh.MarshalAuthorizedKey(srv.hostKey)),
+	}
+}
+
+func testSFTPStorageCleanup(rep *SFTPStorageRepository, srv *testSFTPServer) {
+	if rep != nil {
+		rep.Close()
+	}
+	if srv != nil {
+		srv.listener.Close()
+	}
+	os.RemoveAll(testSFTPStorageDirName)
+}
+
+func testSFTPStorageSetup(t *testing.T) (*SFTPStorageRepository, *testSFTPServer) {
+	testSFTPStorageCleanup(nil, nil)
+	os.MkdirAll(filepath.Join(testSFTPStorageDirName, "projects"), 0755)
+	ioutil.WriteFile(filepath.Join(testSFTPStorageDirName, "projects", "plan.txt"), []byte("plan"), 0644)
+
+	root, _ := filepath.Abs(testSFTPStorageDirName)
+	srv, err := testSFTPStartServer()
+	if err != nil {
+		t.Fatalf("Failed to start SFTP server: %v", err)
+	}
+	rep, err := CreateSFTPStorageRepository(srv.config(filepath.ToSlash(root)))
+	if err != nil {
+		srv.listener.Close()
+		t.Fatalf("Failed to create sftpStorageRepository: %v", err)
+	}
+	return rep, srv
+}
+
+func TestCreateSFTPStorageRepository(t *testing.T) {
+	rep, srv := testSFTPStorageSetup(t)
+	defer testSFTPStorageCleanup(rep, srv)
+
+	invalids := map[string]func(config *SFTPStorageConfig){
+		"missing host":         func(config *SFTPStorageConfig) { config.Host = "" },
+		"relative root":        func(config *SFTPStorageConfig) { config.Root = "data" },
+		"missing credentials":  func(config *SFTPStorageConfig) { config.Password = "" },
+		"missing host key":     func(config *SFTPStorageConfig) { config.HostKey = "" },
+		"invalid private key":  func(config *SFTPStorageConfig) { config.PrivateKey = "key" },
+		"root with parent dir": func(config *SFTPStorageConfig) { config.Root = "/data/../etc" },
+	}
+	for name, invalidate := range invalids {
+		config := srv.config("/data")
+		invalidate(&config)
+		if _, err := CreateSFTPStorageRepository(config); err != ErrSFTPStorageInvalidConfig {
+			t.Errorf("Error for config with %s unequal to ErrSFTPStorageInvalidConfig: %v", name, err)
+		}
+	}
+
+	if _, err := rep.GetInfo("", "/"); err != nil {
+		t.Errorf("Failed to connect to SFTP server: %v", err)
+	}
+
+	config := srv.config("/")
+	config.Password = "wrong"
+	wrongRep, _ := CreateSFTPStorageRepository(config)
+	if _, err := wrongRep.GetInfo("", "/"); err == nil {
+		t.Error("Succeeded to connect to SFTP server with wrong password")
+	}
+
+	if t.Failed() {
+		testSFTPStorageSetupFailed = true
+	}
+}
+
+func TestSFTPStorageCreateAndRead(t *testing.T) {
+	if testSFTPStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, srv := testSFTPStorageSetup(t)
+	defer testSFTPStorageCleanup(rep, srv)
+
+	if created, err := rep.CreateDirectory("/docs/sub"); !created || err != nil {
+		t.Fatalf("Failed to create directory: %v, %v", created, err)
+	}
+	if created, err := rep.CreateDirectory("/docs"); created || err != nil {
+		t.Errorf("Creating existing directory did not succeed without creating it: %v, %v", created, err)
+	}
+
+	file, err := rep.CreateHandle("/docs/sub/new.txt")
+	if err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+	file.Write([]byte("new content"))
+	file.Close()
+
+	readFile, err := rep.OpenFile("/docs/sub/new.txt")
+	if err != nil {
+		t.Fatalf("Failed to open file: %v", err)
+	}
+	content, _ := ioutil.ReadAll(readFile)
+	readFile.Close()
+	if string(content) != "new content" {
+		t.Errorf("Content of read file unequal to written content: %s", content)
+	}
+
+	if _, err = rep.CreateHandle("/docs/../escape.txt"); err != ErrForbiddenPathName {
+		t.Errorf("Error for path with parent dir unequal to ErrForbiddenPathName: %v", err)
+	}
+}
+
+func TestSFTPStorageGetInfo(t *testing.T) {
+	if testSFTPStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, srv := testSFTPStorageSetup(t)
+	defer testSFTPStorageCleanup(rep, srv)
+
+	fileInfo, err := rep.GetInfo("", "/projects/plan.txt")
+	if err != nil {
+		t.Fatalf("Failed to get file info: %v", err)
+	}
+	if fileInfo.Name != "plan.txt" || fileInfo.Path != "/projects/" || fileInfo.Size != 4 || fileInfo.IsDir || fileInfo.MimeType != "text/plain; charset=utf-8" {
+		t.Errorf("File info unequal to expected info: %v", fileInfo)
+	}
+
+	dirInfo, err := rep.GetDirectoryInfo("", "/projects")
+	if err != nil {
+		t.Fatalf("Failed to get directory info: %v", err)
+	}
+	if len(dirInfo) != 1 || dirInfo[0].Name != "plan.txt" {
+		t.Errorf("Directory info unequal to expected info: %v", dirInfo)
+	}
+
+	if _, err = rep.GetInfo("", "/missing"); err != ErrFileNotExist {
+		t.Errorf("Error for missing file unequal to ErrFileNotExist: %v", err)
+	}
+}
+
+func TestSFTPStorageMoveCopyDelete(t *testing.T) {
+	if testSFTPStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, srv := testSFTPStorageSetup(t)
+	defer testSFTPStorageCleanup(rep, srv)
+
+	rep.CreateDirectory("/copied")
+	if err := rep.Copy("/projects/plan.txt", "/copied/plan.txt"); err != nil {
+		t.Fatalf("Failed to copy file: %v", err)
+	}
+	if content, _ := ioutil.ReadFile(filepath.Join(testSFTPStorageDirName, "copied", "plan.txt")); string(content) != "plan" {
+		t.Errorf("Content of copied file unequal to original content: %s", content)
+	}
+
+	if err := rep.Move("/copied/plan.txt", "/projects/plan.txt"); err != nil {
+		t.Fatalf("Failed to move file onto existing file: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(testSFTPStorageDirName, "copied", "plan.txt")); !os.IsNotExist(err) {
+		t.Errorf("Moved file still exists at old path: %v", err)
+	}
+
+	if err := rep.Delete("/projects"); err != nil {
+		t.Fatalf("Failed to delete folder: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(testSFTPStorageDirName, "projects")); !os.IsNotExist(err) {
+		t.Errorf("Deleted folder still exists: %v", err)
+	}
+	if err := rep.Delete("/projects"); err != nil {
+		t.Errorf("Failed to delete already deleted folder: %v", err)
+	}
+}
+
+func TestSFTPStorageAddToZip(t *testing.T) {
+	if testSFTPStorageSetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	rep, srv := testSFTPStorageSetup(t)
+	defer testSFTPStorageCleanup(rep, srv)
+
+	ioutil.WriteFile(filepath.Join(testSFTPStorageDirName, "projects", "skipped.txt"), []byte("skipped"), 0644)
+
+	buf := &bytes.Buffer{}
+	zipWriter := zip.NewWriter(buf)
+	err := rep.AddToZip(zipWriter, "/", "nas", func(relPath string) bool { return relPath == "projects/skipped.txt" })
+	if err != nil {
+		t.Fatalf("Failed to add folder to zip: %v", err)
+	}
+	zipWriter.Close()
+
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("Failed to open created zip: %v", err)
+	}
+	var names []string
+	for _, zipFile := range zipReader.File {
+		if !zipFile.FileInfo().IsDir() {
+			names = append(names, zipFile.Name)
+		}
+	}
+	expNames := []string{"nas/projects/plan.txt"}
+	if !reflect.DeepEqual(names, expNames) {
+		t.Errorf("Files in zip and expected files not deeply equal: %v != %v", names, expNames)
+	}
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	"github.com/jinzhu/gorm"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.SSHKey{})
+}
+
+// SSHKeyRepository represents the database for storing the SSH public keys of users
+type SSHKeyRepository struct{}
+
+// CreateSSHKeyRepository creates a new SSHKeyRepository IF gorm has been initialized before
+func CreateSSHKeyRepository() (*SSHKeyRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &SSHKeyRepository{}, nil
+}
+
+// Create stores a new SSH key
+func (rep *SSHKeyRepository) Create(sshKey *models.SSHKey) (err error) {
+	sshKey.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(sshKey).Error
+	if err != nil {
+		log.Error(0, "Could not create ssh key: %v", err)
+		return
+	}
+	return
+}
+
+// GetAllForUser returns all SSH keys of the user, oldest first
+func (rep *SSHKeyRepository) GetAllForUser(userID int64) (sshKeys []*models.SSHKey, err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Order("id asc").Find(&sshKeys).Error
+	if err != nil {
+		log.Error(0, "Could not get ssh keys of user %d: %v", userID, err)
+		return
+	}
+	return
+}
+
+// GetForUserByFingerprint reads and returns the SSH key of the user with the given fingerprint
+func (rep *SSHKeyRepository) GetForUserByFingerprint(userID int64, fingerprint string) (sshKey *models.SSHKey, err error) {
+	sshKey = &models.SSHKey{}
+	err = databaseConnection.First(sshKey, "user_id = ? AND fingerprint = ?", userID, fingerprint).Error
+	return
+}
+
+// UpdateLastUsed sets the time the SSH key has been used last to now
+func (rep *SSHKeyRepository) UpdateLastUsed(sshKeyID int64) (err error) {
+	err = databaseConnection.Model(&models.SSHKey{ID: sshKeyID}).UpdateColumn("last_used", utils.GetTimestampNow()).Error
+	if err != nil {
+		log.Error(0, "Could not update last usage of ssh key %d: %v", sshKeyID, err)
+		return
+	}
+	return
+}
+
+// Delete deletes an SSH key by its ID if it belongs to the user
+func (rep *SSHKeyRepository) Delete(sshKeyID, userID int64) (err error) {
+	db := databaseConnection.Where("id = ? AND user_id = ?", sshKeyID, userID).Delete(&models.SSHKey{})
+	err = db.Error
+	if err != nil {
+		log.Error(0, "Could not delete ssh key %d of user %d: %v", sshKeyID, userID, err)
+		return
+	}
+	if db.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return
+}
+
+// DeleteAllForUser deletes all SSH keys of the user
+func (rep *SSHKeyRepository) DeleteAllForUser(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.SSHKey{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete ssh keys of user %d: %v", userID, err)
+		return
+	}
+	return
+}
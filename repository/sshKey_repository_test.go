@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testSSHKeySetupFailed = false
+var testSSHKeyDBName = "sshKeyTest.db"
+var testSSHKey0 = &models.SSHKey{UserID: 1, Name: "laptop", Fingerprint: "SHA256:fp0"}
+var testSSHKey1 = &models.SSHKey{UserID: 1, Name: "phone", Fingerprint: "SHA256:fp1"}
+var testSSHKey2 = &models.SSHKey{UserID: 2, Name: "laptop", Fingerprint: "SHA256:fp2"}
+
+func testSSHKeyCleanup() {
+	os.Remove(testSSHKeyDBName)
+}
+
+func testSSHKeySetup() *SSHKeyRepository {
+	testSSHKeyCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testSSHKeyDBName)
+	rep, _ := CreateSSHKeyRepository()
+	return rep
+}
+
+func testSSHKeyInsert(rep *SSHKeyRepository) {
+	rep.Create(testSSHKey0)
+	rep.Create(testSSHKey1)
+	rep.Create(testSSHKey2)
+}
+
+func TestCreateSSHKeyRepository(t *testing.T) {
+	testSSHKeyCleanup()
+	defer testSSHKeyCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testSSHKeyDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateSSHKeyRepository()
+	if err != nil {
+		t.Errorf("Failed to create ssh key repository: %v", err)
+	}
+
+	if t.Failed() {
+		testSSHKeySetupFailed = true
+	}
+}
+
+func TestCreateAndGetSSHKeys(t *testing.T) {
+	if testSSHKeySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testSSHKeyCleanup()
+	rep := testSSHKeySetup()
+
+	testSSHKeyInsert(rep)
+
+	sshKeys, err := rep.GetAllForUser(1)
+	if err != nil {
+		t.Fatalf("Failed to get ssh keys of user 1: %v", err)
+	}
+	if len(sshKeys) != 2 {
+		t.Fatalf("Expected 2 ssh keys of user 1, got %d", len(sshKeys))
+	}
+	if !reflect.DeepEqual(sshKeys[0], testSSHKey0) {
+		t.Errorf("Read back ssh key0 and ssh key0 not deeply equal: %v != %v", sshKeys[0], testSSHKey0)
+	}
+
+	readBackKey, err := rep.GetForUserByFingerprint(1, testSSHKey1.Fingerprint)
+	if err != nil {
+		t.Errorf("Failed to get ssh key1 by its fingerprint: %v", err)
+	} else if readBackKey.ID != testSSHKey1.ID {
+		t.Errorf("Expected ssh key1 for its fingerprint, got %v", readBackKey)
+	}
+	_, err = rep.GetForUserByFingerprint(2, testSSHKey1.Fingerprint)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for fingerprint of another user, got %v", err)
+	}
+
+	err = rep.UpdateLastUsed(testSSHKey1.ID)
+	if err != nil {
+		t.Errorf("Failed to update last usage of ssh key1: %v", err)
+	}
+	sshKeys, _ = rep.GetAllForUser(1)
+	if len(sshKeys) != 2 || sshKeys[1].LastUsed == 0 {
+		t.Errorf("Expected last usage of ssh key1 to be set: %v", sshKeys)
+	}
+}
+
+func TestDeleteSSHKey(t *testing.T) {
+	if testSSHKeySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testSSHKeyCleanup()
+	rep := testSSHKeySetup()
+
+	testSSHKeyInsert(rep)
+
+	err := rep.Delete(testSSHKey2.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting ssh key of another user, got %v", err)
+	}
+
+	err = rep.Delete(testSSHKey0.ID, 1)
+	if err != nil {
+		t.Errorf("Failed to delete ssh key0: %v", err)
+	}
+	err = rep.Delete(testSSHKey0.ID, 1)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleting ssh key twice, got %v", err)
+	}
+
+	err = rep.DeleteAllForUser(1)
+	if err != nil {
+		t.Errorf("Failed to delete ssh keys of user 1: %v", err)
+	}
+	sshKeys, _ := rep.GetAllForUser(1)
+	if len(sshKeys) != 0 {
+		t.Errorf("Expected no ssh keys of user 1 after deleting all, got %d", len(sshKeys))
+	}
+	sshKeys, _ = rep.GetAllForUser(2)
+	if len(sshKeys) != 1 {
+		t.Errorf("Expected ssh key of user 2 to be kept, got %d", len(sshKeys))
+	}
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// StorageRepository stores the content of files and folders at slash separated paths below its root.
+// Paths of users start with their user path, e.g. "/1", and are checked for sanity before they are used.
+type StorageRepository interface {
+	// Close ends the go routine for tmp cleanup
+	Close() error
+
+	// CreateHandle creates or truncates the file at path, its content is stored once the handle is closed
+	CreateHandle(path string) (StorageHandle, error)
+	// OpenFile opens the file at path for reading, an error satisfying os.IsNotExist is returned if it does not exist
+	OpenFile(path string) (StorageFile, error)
+	// CreateDirectory creates the folder at path including its parents and returns whether it did not exist yet
+	CreateDirectory(path string) (created bool, err error)
+
+	// GetInfo returns the info of the file or folder at path below userPath, ErrFileNotExist is returned if it does not exist
+	GetInfo(userPath, path string) (*models.FileInfo, error)
+	// GetDirectoryInfo returns the infos of all files and folders in the folder at path below userPath sorted by name
+	GetDirectoryInfo(userPath, path string) ([]*models.FileInfo, error)
+
+	// Move moves the file or folder at oldPath to newPath
+	Move(oldPath, newPath string) error
+	// Copy copies the file at oldPath to newPath
+	Copy(oldPath, newPath string) error
+	// Delete deletes the file or folder at path with all its content, deleting something not existing succeeds
+	Delete(path string) error
+
+	// AddToZip writes the file or the content of the folder at path into the zip archive below zipPath.
+	// Files and folders for which skip returns true are left out, skip gets their path relative to the given path.
+	AddToZip(zipWriter *zip.Writer, path, zipPath string, skip func(relPath string) bool) error
+}
+
+// StorageFile is a file opened for reading from a StorageRepository
+type StorageFile interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+	io.Closer
+	Stat() (os.FileInfo, error)
+}
+
+// StorageHandle is a file created in a StorageRepository for writing, its content may only be stored once it is closed
+type StorageHandle interface {
+	io.Writer
+	io.WriterAt
+	io.Closer
+}
+
+// storageUserID returns the ID of the user whose user path the path is in
+func storageUserID(filePath string) (int64, bool) {
+	cleanPath := strings.TrimPrefix(path.Clean("/"+filepath.ToSlash(filePath)), "/")
+	userID, err := strconv.ParseInt(strings.SplitN(cleanPath, "/", 2)[0], 10, 64)
+	return userID, err == nil && userID > 0
+}
+
+// addOpenedToZip writes the file or the content of the folder at path into the zip archive below zipPath like AddToZip.
+// Files and folders are listed by lister, the content of files is read by open, so repositories decorating lister can decode it.
+func addOpenedToZip(lister StorageRepository, open func(filePath string) (StorageFile, error), zipWriter *zip.Writer, filePath, zipPath string, skip func(relPath string) bool) (err error) {
+	if !utils.ValidatePath(filePath) {
+		err = ErrForbiddenPathName
+		return
+	}
+
+	fileInfo, err := lister.GetInfo("", filePath)
+	if err == nil {
+		err = addOpenedToZipWalk(lister, open, zipWriter, filePath, fileInfo, "", zipPath, skip)
+	}
+	if err != nil {
+		log.Error(0, "Error adding %v to zip: %v", filePath, err)
+		return
+	}
+	return
+}
+
+// addOpenedToZipWalk walks the files below filePath in lexical order like filepath.Walk does
+func addOpenedToZipWalk(lister StorageRepository, open func(filePath string) (StorageFile, error), zipWriter *zip.Writer, filePath string, fileInfo *models.FileInfo, relPath, zipPath string, skip func(relPath string) bool) error {
+	if skip != nil && relPath != "" && skip(relPath) {
+		return nil
+	}
+
+	if fileInfo.IsDir {
+		fileInfos, err := lister.GetDirectoryInfo("", filePath)
+		if err != nil {
+			return err
+		}
+		for _, childInfo := range fileInfos {
+			err = addOpenedToZipWalk(lister, open, zipWriter, path.Join(filePath, childInfo.Name), childInfo, path.Join(relPath, childInfo.Name), zipPath, skip)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	file, err := open(filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	header := &zip.FileHeader{
+		Name:     path.Join(filepath.ToSlash(zipPath), relPath),
+		Method:   zip.Deflate,
+		Modified: time.Unix(fileInfo.LastChanged, 0),
+	}
+	writer, err := zipWriter.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(writer, file)
+	return err
+}
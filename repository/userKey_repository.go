@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/freecloudio/server/models"
+	"github.com/freecloudio/server/utils"
+	log "gopkg.in/clog.v1"
+)
+
+// Add used models to enable auto migration for them
+func init() {
+	databaseModels = append(databaseModels, &models.UserKey{})
+}
+
+// UserKeyRepository represents the database for storing the wrapped keys used to encrypt the files of users
+type UserKeyRepository struct{}
+
+// CreateUserKeyRepository creates a new UserKeyRepository IF gorm has been initialized before
+func CreateUserKeyRepository() (*UserKeyRepository, error) {
+	if databaseConnection == nil {
+		return nil, ErrGormNotInitialized
+	}
+	return &UserKeyRepository{}, nil
+}
+
+// Create stores the key of a user, it fails if the user has a key already
+func (rep *UserKeyRepository) Create(userKey *models.UserKey) (err error) {
+	userKey.Created = utils.GetTimestampNow()
+	err = databaseConnection.Create(userKey).Error
+	if err != nil {
+		log.Error(0, "Could not create key of user %d: %v", userKey.UserID, err)
+		return
+	}
+	return
+}
+
+// GetByUserID reads and returns the key of the user
+func (rep *UserKeyRepository) GetByUserID(userID int64) (userKey *models.UserKey, err error) {
+	userKey = &models.UserKey{}
+	err = databaseConnection.First(userKey, "user_id = ?", userID).Error
+	return
+}
+
+// Delete deletes the key of the user, which makes his encrypted files unreadable
+func (rep *UserKeyRepository) Delete(userID int64) (err error) {
+	err = databaseConnection.Where("user_id = ?", userID).Delete(&models.UserKey{}).Error
+	if err != nil {
+		log.Error(0, "Could not delete key of user %d: %v", userID, err)
+		return
+	}
+	return
+}
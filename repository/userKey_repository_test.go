@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/freecloudio/server/models"
+)
+
+var testUserKeySetupFailed = false
+var testUserKeyDBName = "userKeyTest.db"
+
+func testUserKeyCleanup() {
+	os.Remove(testUserKeyDBName)
+}
+
+func testUserKeySetup() *UserKeyRepository {
+	testUserKeyCleanup()
+	InitDatabaseConnection("", "", "", "", 0, testUserKeyDBName)
+	rep, _ := CreateUserKeyRepository()
+	return rep
+}
+
+func TestCreateUserKeyRepository(t *testing.T) {
+	testUserKeyCleanup()
+	defer testUserKeyCleanup()
+
+	err := InitDatabaseConnection("", "", "", "", 0, testUserKeyDBName)
+	if err != nil {
+		t.Errorf("Failed to connect to gorm database: %v", err)
+	}
+
+	_, err = CreateUserKeyRepository()
+	if err != nil {
+		t.Errorf("Failed to create user key repository: %v", err)
+	}
+
+	if t.Failed() {
+		testUserKeySetupFailed = true
+	}
+}
+
+func TestCreateGetAndDeleteUserKey(t *testing.T) {
+	if testUserKeySetupFailed {
+		t.Skip("Skipped due to failed setup")
+	}
+	defer testUserKeyCleanup()
+	rep := testUserKeySetup()
+
+	userKey := &models.UserKey{UserID: 3, WrappedKey: []byte("wrapped")}
+	err := rep.Create(userKey)
+	if err != nil {
+		t.Fatalf("Failed to create key of user 3: %v", err)
+	}
+	err = rep.Create(&models.UserKey{UserID: 3, WrappedKey: []byte("other")})
+	if err == nil {
+		t.Errorf("Succeeded to create a second key of user 3")
+	}
+
+	readBackKey, err := rep.GetByUserID(3)
+	if err != nil || !bytes.Equal(readBackKey.WrappedKey, userKey.WrappedKey) || readBackKey.Created == 0 {
+		t.Errorf("Expected created key of user 3 to be read back, got %v, %v", readBackKey, err)
+	}
+	_, err = rep.GetByUserID(4)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for user without key, got %v", err)
+	}
+
+	err = rep.Delete(3)
+	if err != nil {
+		t.Errorf("Failed to delete key of user 3: %v", err)
+	}
+	_, err = rep.GetByUserID(3)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for deleted key, got %v", err)
+	}
+}
@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"strings"
+
 	"github.com/freecloudio/server/models"
 	"github.com/freecloudio/server/utils"
 	log "gopkg.in/clog.v1"
@@ -80,12 +82,49 @@ func (rep *UserRepository) GetByEmail(email string) (user *models.User, err erro
 	return
 }
 
+// GetByAuthSubject reads and returns an user by the subject identifying him at an external auth provider
+func (rep *UserRepository) GetByAuthSubject(provider, subject string) (user *models.User, err error) {
+	user = &models.User{}
+	err = databaseConnection.First(user, &models.User{AuthProvider: provider, AuthSubject: subject}).Error
+	return
+}
+
 // GetAll reads and returns all stored users
 func (rep *UserRepository) GetAll() (users []*models.User, err error) {
 	err = databaseConnection.Find(&users).Error
 	return
 }
 
+// GetPage reads and returns a page of users whose name or email contain the search term.
+// The total count of matching users is returned as well, sortColumn has to be a valid column name.
+func (rep *UserRepository) GetPage(search, sortColumn string, descending bool, offset, limit int64) (users []*models.User, total int64, err error) {
+	query := databaseConnection.Model(&models.User{})
+	if search != "" {
+		// '!' is used as escape character as the backslash would need different escaping per database
+		escaper := strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+		term := "%" + escaper.Replace(strings.ToLower(search)) + "%"
+		query = query.Where("lower(email) like ? escape '!' or lower(first_name) like ? escape '!' or lower(last_name) like ? escape '!'", term, term, term)
+	}
+
+	err = query.Count(&total).Error
+	if err != nil {
+		log.Error(0, "Could not count users for search %s: %v", search, err)
+		return
+	}
+
+	order := sortColumn
+	if descending {
+		order += " desc"
+	}
+	// Sort by ID as well to get a stable order for equal values
+	err = query.Order(order).Order("id").Offset(offset).Limit(limit).Find(&users).Error
+	if err != nil {
+		log.Error(0, "Could not get users for search %s: %v", search, err)
+		return
+	}
+	return
+}
+
 // AdminCount returns the amount of stored admins
 func (rep *UserRepository) AdminCount() (count int64, err error) {
 	err = databaseConnection.Model(&models.User{}).Where(&models.User{IsAdmin: true}).Count(&count).Error
@@ -13,7 +13,7 @@ var testUserSetupFailed = false
 var testUserDBName = "userTest.db"
 var testUserAdmin = &models.User{Email: "admin.user@example.com", IsAdmin: true}
 var testUser0 = &models.User{Email: "user1@example.com"}
-var testUser1 = &models.User{Email: "user2@example.com"}
+var testUser1 = &models.User{Email: "user2@example.com", AuthProvider: "oidc", AuthSubject: "248289761001"}
 
 func testUserCleanup() {
 	os.Remove(testUserDBName)
@@ -145,6 +145,29 @@ func TestUserGetByEmail(t *testing.T) {
 	}
 }
 
+func TestUserGetByAuthSubject(t *testing.T) {
+	if testUserSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	defer testUserCleanup()
+	rep := testUserSetup()
+
+	testUserInsert(rep)
+
+	readBackUser, err := rep.GetByAuthSubject(testUser1.AuthProvider, testUser1.AuthSubject)
+	if err != nil {
+		t.Fatalf("Failed to read back user1 by auth subject: %v", err)
+	}
+	if !reflect.DeepEqual(readBackUser, testUser1) {
+		t.Errorf("Read back user1 and user1 not deeply equal: %v != %v", readBackUser, testUser1)
+	}
+
+	_, err = rep.GetByAuthSubject("ldap", testUser1.AuthSubject)
+	if !IsRecordNotFoundError(err) {
+		t.Errorf("Expected record not found error for subject of other provider, got %v", err)
+	}
+}
+
 func TestGetAllUsers(t *testing.T) {
 	if testUserSetupFailed {
 		t.Skip("Skip due to failed setup")
@@ -163,6 +186,52 @@ func TestGetAllUsers(t *testing.T) {
 	}
 }
 
+func TestGetUserPage(t *testing.T) {
+	if testUserSetupFailed {
+		t.Skip("Skip due to failed setup")
+	}
+	defer testUserCleanup()
+	rep := testUserSetup()
+
+	testUserInsert(rep)
+	rep.Create(&models.User{Email: "carl@example.org", FirstName: "Carl", LastName: "100%_Sure"})
+	rep.Create(&models.User{Email: "dora@example.org", FirstName: "Dora", LastName: "Carlsson"})
+
+	testCases := map[string]struct {
+		search     string
+		sortColumn string
+		descending bool
+		offset     int64
+		limit      int64
+		total      int64
+		emails     []string
+	}{
+		"all":        {"", "email", false, 0, 10, 5, []string{"admin.user@example.com", "carl@example.org", "dora@example.org", "user1@example.com", "user2@example.com"}},
+		"descending": {"", "email", true, 0, 2, 5, []string{"user2@example.com", "user1@example.com"}},
+		"offset":     {"", "email", false, 4, 2, 5, []string{"user2@example.com"}},
+		"email":      {"EXAMPLE.ORG", "email", false, 0, 10, 2, []string{"carl@example.org", "dora@example.org"}},
+		"name":       {"carl", "first_name", true, 0, 10, 2, []string{"dora@example.org", "carl@example.org"}},
+		"wildcard":   {"0%_", "email", false, 0, 10, 1, []string{"carl@example.org"}},
+		"escaped":    {"%", "email", false, 0, 10, 1, []string{"carl@example.org"}},
+		"none":       {"nobody", "email", false, 0, 10, 0, nil},
+	}
+
+	for name, testCase := range testCases {
+		users, total, err := rep.GetPage(testCase.search, testCase.sortColumn, testCase.descending, testCase.offset, testCase.limit)
+		if err != nil {
+			t.Errorf("Failed to get user page for %s: %v", name, err)
+			continue
+		}
+		var emails []string
+		for _, user := range users {
+			emails = append(emails, user.Email)
+		}
+		if total != testCase.total || !reflect.DeepEqual(emails, testCase.emails) {
+			t.Errorf("Expected %d users %v for %s, got %d users %v", testCase.total, testCase.emails, name, total, emails)
+		}
+	}
+}
+
 func TestDeleteUser(t *testing.T) {
 	if testUserSetupFailed {
 		t.Skip("Skip due to failed setup")
@@ -3,9 +3,13 @@
 package restapi
 
 import (
+	"compress/gzip"
 	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
 	"io"
 	"net/http"
+	"strings"
 
 	errors "github.com/go-openapi/errors"
 	runtime "github.com/go-openapi/runtime"
@@ -13,10 +17,13 @@ import (
 	log "gopkg.in/clog.v1"
 
 	"github.com/freecloudio/server/config"
+	"github.com/freecloudio/server/crypt"
 	"github.com/freecloudio/server/manager"
 	"github.com/freecloudio/server/restapi/operations"
+	"github.com/freecloudio/server/restapi/operations/audit"
 	"github.com/freecloudio/server/restapi/operations/auth"
 	"github.com/freecloudio/server/restapi/operations/file"
+	"github.com/freecloudio/server/restapi/operations/policy"
 	"github.com/freecloudio/server/restapi/operations/system"
 	"github.com/freecloudio/server/restapi/operations/user"
 	"github.com/freecloudio/server/utils"
@@ -28,6 +35,8 @@ import (
 
 const tmpName = ".tmp"
 
+var sftpServer *controller.SFTPServer
+
 //go:generate swagger generate server --name Freecloud --spec ./api/freecloud.yml --principal models.Principal
 
 func configureFlags(api *operations.FreecloudAPI) {
@@ -45,58 +54,192 @@ func configureAPI(api *operations.FreecloudAPI) http.Handler {
 
 	api.MultipartformConsumer = MultipartformConsumer()
 
+	// Downloads are written by their responders directly, errors are compressed as JSON
 	api.GzipProducer = runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
-		return errors.NotImplemented("gzip producer has not yet been implemented")
+		gzipWriter := gzip.NewWriter(w)
+		if err := json.NewEncoder(gzipWriter).Encode(data); err != nil {
+			return err
+		}
+		return gzipWriter.Close()
 	})
+	api.BinProducer = runtime.ByteStreamProducer()
 	api.JSONProducer = runtime.JSONProducer()
 
+	api.PngProducer = runtime.ByteStreamProducer()
+	// Event streams are written by their responders directly
+	api.TextEventStreamProducer = runtime.ByteStreamProducer()
+
 	// Applies when the "Authorization" header is set
 	api.TokenAuthAuth = func(token string, scopes []string) (*models.Principal, error) {
 		return controller.ValidateToken(token, scopes)
 	}
+	api.APIAuthorizer = runtime.AuthorizerFunc(controller.AuthorizeRequest)
 
+	api.UserAddSSHKeyHandler = user.AddSSHKeyHandlerFunc(func(params user.AddSSHKeyParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthAddSSHKeyHandler(params, principal)
+	})
+	api.UserApproveUserByIDHandler = user.ApproveUserByIDHandlerFunc(func(params user.ApproveUserByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthApproveUserByIDHandler(params, principal)
+	})
+	api.UserCreateAccessKeyHandler = user.CreateAccessKeyHandlerFunc(func(params user.CreateAccessKeyParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthCreateAccessKeyHandler(params, principal)
+	})
+	api.UserCreateAppPasswordHandler = user.CreateAppPasswordHandlerFunc(func(params user.CreateAppPasswordParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthCreateAppPasswordHandler(params, principal)
+	})
 	api.FileCreateFileHandler = file.CreateFileHandlerFunc(func(params file.CreateFileParams, principal *models.Principal) middleware.Responder {
 		return controller.FileCreateHandler(params, principal)
 	})
+	api.PolicyCreateGroupHandler = policy.CreateGroupHandlerFunc(func(params policy.CreateGroupParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicyCreateGroupHandler(params, principal)
+	})
+	api.AuthCreateInviteCodeHandler = auth.CreateInviteCodeHandlerFunc(func(params auth.CreateInviteCodeParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthCreateInviteCodeHandler(params, principal)
+	})
+	api.UserDeleteAccessKeyHandler = user.DeleteAccessKeyHandlerFunc(func(params user.DeleteAccessKeyParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthDeleteAccessKeyHandler(params, principal)
+	})
+	api.UserDeleteAppPasswordHandler = user.DeleteAppPasswordHandlerFunc(func(params user.DeleteAppPasswordParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthDeleteAppPasswordHandler(params, principal)
+	})
+	api.UserDeleteAvatarHandler = user.DeleteAvatarHandlerFunc(func(params user.DeleteAvatarParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthDeleteAvatarHandler(params, principal)
+	})
 	api.UserDeleteCurrentUserHandler = user.DeleteCurrentUserHandlerFunc(func(params user.DeleteCurrentUserParams, principal *models.Principal) middleware.Responder {
 		return controller.AuthDeleteCurrentUserHandler(params, principal)
 	})
 	api.FileDeleteFileHandler = file.DeleteFileHandlerFunc(func(params file.DeleteFileParams, principal *models.Principal) middleware.Responder {
 		return controller.FileDeleteHandler(params, principal)
 	})
+	api.PolicyDeleteGroupHandler = policy.DeleteGroupHandlerFunc(func(params policy.DeleteGroupParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicyDeleteGroupHandler(params, principal)
+	})
+	api.UserDeleteSSHKeyHandler = user.DeleteSSHKeyHandlerFunc(func(params user.DeleteSSHKeyParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthDeleteSSHKeyHandler(params, principal)
+	})
 	api.UserDeleteUserByIDHandler = user.DeleteUserByIDHandlerFunc(func(params user.DeleteUserByIDParams, principal *models.Principal) middleware.Responder {
 		return controller.AuthDeleteUserByIDHandler(params, principal)
 	})
+	api.UserDisableUserByIDHandler = user.DisableUserByIDHandlerFunc(func(params user.DisableUserByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthDisableUserByIDHandler(params, principal)
+	})
 	api.FileDownloadFileHandler = file.DownloadFileHandlerFunc(func(params file.DownloadFileParams, principal *models.Principal) middleware.Responder {
-		return middleware.NotImplemented("operation file.DownloadFile has not yet been implemented")
+		return controller.FileDownloadHandler(params, principal)
+	})
+	api.UserEnableUserByIDHandler = user.EnableUserByIDHandlerFunc(func(params user.EnableUserByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthEnableUserByIDHandler(params, principal)
+	})
+	api.AuditExportAuditEntriesHandler = audit.ExportAuditEntriesHandlerFunc(func(params audit.ExportAuditEntriesParams, principal *models.Principal) middleware.Responder {
+		return controller.AuditExportEntriesHandler(params, principal)
+	})
+	api.UserGetAccessKeysHandler = user.GetAccessKeysHandlerFunc(func(params user.GetAccessKeysParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetAccessKeysHandler(params, principal)
+	})
+	api.UserGetAppPasswordsHandler = user.GetAppPasswordsHandlerFunc(func(params user.GetAppPasswordsParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetAppPasswordsHandler(params, principal)
+	})
+	api.AuditGetAuditEntriesHandler = audit.GetAuditEntriesHandlerFunc(func(params audit.GetAuditEntriesParams, principal *models.Principal) middleware.Responder {
+		return controller.AuditGetEntriesHandler(params, principal)
+	})
+	api.FileGetActivitiesHandler = file.GetActivitiesHandlerFunc(func(params file.GetActivitiesParams, principal *models.Principal) middleware.Responder {
+		return controller.FileGetActivitiesHandler(params, principal)
+	})
+	api.FileGetFileChangesHandler = file.GetFileChangesHandlerFunc(func(params file.GetFileChangesParams, principal *models.Principal) middleware.Responder {
+		return controller.FileGetChangesHandler(params, principal)
+	})
+	api.FileGetFileEventsHandler = file.GetFileEventsHandlerFunc(func(params file.GetFileEventsParams, principal *models.Principal) middleware.Responder {
+		return controller.FileGetEventsHandler(params, principal)
+	})
+	api.UserGetAvatarByIDHandler = user.GetAvatarByIDHandlerFunc(func(params user.GetAvatarByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetAvatarByIDHandler(params, principal)
 	})
 	api.UserGetCurrentUserHandler = user.GetCurrentUserHandlerFunc(func(params user.GetCurrentUserParams, principal *models.Principal) middleware.Responder {
 		return controller.AuthGetCurrentUserHandler(params, principal)
 	})
+	api.UserGetDataExportHandler = user.GetDataExportHandlerFunc(func(params user.GetDataExportParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetDataExportHandler(params, principal)
+	})
+	api.PolicyGetGroupsHandler = policy.GetGroupsHandlerFunc(func(params policy.GetGroupsParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicyGetGroupsHandler(params, principal)
+	})
+	api.AuthGetInviteCodesHandler = auth.GetInviteCodesHandlerFunc(func(params auth.GetInviteCodesParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetInviteCodesHandler(params, principal)
+	})
 	api.FileGetPathInfoHandler = file.GetPathInfoHandlerFunc(func(params file.GetPathInfoParams, principal *models.Principal) middleware.Responder {
 		return controller.FileGetPathInfoHandler(params, principal)
 	})
+	api.PolicyGetRolesHandler = policy.GetRolesHandlerFunc(func(params policy.GetRolesParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicyGetRolesHandler(params, principal)
+	})
+	api.UserGetSSHKeysHandler = user.GetSSHKeysHandlerFunc(func(params user.GetSSHKeysParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetSSHKeysHandler(params, principal)
+	})
 	api.SystemGetSystemStatsHandler = system.GetSystemStatsHandlerFunc(func(params system.GetSystemStatsParams, principal *models.Principal) middleware.Responder {
-		return controller.SystemStatsHandler()
+		return controller.SystemStatsHandler(params, principal)
+	})
+	api.SystemGetMountsHandler = system.GetMountsHandlerFunc(func(params system.GetMountsParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemGetMountsHandler(params, principal)
+	})
+	api.SystemCreateMountHandler = system.CreateMountHandlerFunc(func(params system.CreateMountParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemCreateMountHandler(params, principal)
+	})
+	api.SystemUpdateMountHandler = system.UpdateMountHandlerFunc(func(params system.UpdateMountParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemUpdateMountHandler(params, principal)
+	})
+	api.SystemDeleteMountHandler = system.DeleteMountHandlerFunc(func(params system.DeleteMountParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemDeleteMountHandler(params, principal)
+	})
+	api.SystemEncryptStorageHandler = system.EncryptStorageHandlerFunc(func(params system.EncryptStorageParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemEncryptStorageHandler(params, principal)
+	})
+	api.SystemCollectBlobsHandler = system.CollectBlobsHandlerFunc(func(params system.CollectBlobsParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemCollectBlobsHandler(params, principal)
+	})
+	api.SystemGetScrubReportHandler = system.GetScrubReportHandlerFunc(func(params system.GetScrubReportParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemGetScrubReportHandler(params, principal)
+	})
+	api.SystemStartScrubHandler = system.StartScrubHandlerFunc(func(params system.StartScrubParams, principal *models.Principal) middleware.Responder {
+		return controller.SystemStartScrubHandler(params, principal)
 	})
 	api.UserGetUserByIDHandler = user.GetUserByIDHandlerFunc(func(params user.GetUserByIDParams, principal *models.Principal) middleware.Responder {
 		return controller.AuthGetUserByIDHandler(params, principal)
 	})
+	api.UserGetUsersHandler = user.GetUsersHandlerFunc(func(params user.GetUsersParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthGetUsersHandler(params, principal)
+	})
+	api.UserImpersonateUserByIDHandler = user.ImpersonateUserByIDHandlerFunc(func(params user.ImpersonateUserByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthImpersonateUserByIDHandler(params, principal)
+	})
 	api.AuthLoginHandler = auth.LoginHandlerFunc(func(params auth.LoginParams) middleware.Responder {
 		return controller.AuthLoginHandler(params)
 	})
 	api.AuthLogoutHandler = auth.LogoutHandlerFunc(func(params auth.LogoutParams, principal *models.Principal) middleware.Responder {
 		return controller.AuthLogoutHandler(params, principal)
 	})
+	api.AuthOidcAuthorizeHandler = auth.OidcAuthorizeHandlerFunc(func(params auth.OidcAuthorizeParams) middleware.Responder {
+		return controller.AuthOIDCAuthorizeHandler(params)
+	})
+	api.AuthOidcLoginHandler = auth.OidcLoginHandlerFunc(func(params auth.OidcLoginParams) middleware.Responder {
+		return controller.AuthOIDCLoginHandler(params)
+	})
 	api.FileRescanCurrentUserHandler = file.RescanCurrentUserHandlerFunc(func(params file.RescanCurrentUserParams, principal *models.Principal) middleware.Responder {
 		return controller.FileRescanCurrentUserHandler(params, principal)
 	})
 	api.FileRescanUserByIDHandler = file.RescanUserByIDHandlerFunc(func(params file.RescanUserByIDParams, principal *models.Principal) middleware.Responder {
 		return controller.FileRescanUserByIDHandler(params, principal)
 	})
+	api.UserRequestDataExportHandler = user.RequestDataExportHandlerFunc(func(params user.RequestDataExportParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthRequestDataExportHandler(params, principal)
+	})
+	api.AuthRevokeInviteCodeHandler = auth.RevokeInviteCodeHandlerFunc(func(params auth.RevokeInviteCodeParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthRevokeInviteCodeHandler(params, principal)
+	})
 	api.FileSearchFileHandler = file.SearchFileHandlerFunc(func(params file.SearchFileParams, principal *models.Principal) middleware.Responder {
 		return middleware.NotImplemented("operation file.SearchFile has not yet been implemented")
 	})
+	api.PolicySetUserRolesHandler = policy.SetUserRolesHandlerFunc(func(params policy.SetUserRolesParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicySetUserRolesHandler(params, principal)
+	})
 	api.FileShareFilesHandler = file.ShareFilesHandlerFunc(func(params file.ShareFilesParams, principal *models.Principal) middleware.Responder {
 		return controller.FileShareFilesHandler(params, principal)
 	})
@@ -106,17 +249,29 @@ func configureAPI(api *operations.FreecloudAPI) http.Handler {
 	api.FileGetStarredFileInfosHandler = file.GetStarredFileInfosHandlerFunc(func(params file.GetStarredFileInfosParams, principal *models.Principal) middleware.Responder {
 		return controller.FileGetStarredFileInfosHandler(params, principal)
 	})
+	api.UserTransferUserFilesHandler = user.TransferUserFilesHandlerFunc(func(params user.TransferUserFilesParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthTransferUserFilesHandler(params, principal)
+	})
 	api.UserUpdateCurrentUserHandler = user.UpdateCurrentUserHandlerFunc(func(params user.UpdateCurrentUserParams, principal *models.Principal) middleware.Responder {
 		return middleware.NotImplemented("operation user.UpdateCurrentUser has not yet been implemented")
 	})
 	api.FileUpdateFileHandler = file.UpdateFileHandlerFunc(func(params file.UpdateFileParams, principal *models.Principal) middleware.Responder {
-		return middleware.NotImplemented("operation file.UpdateFile has not yet been implemented")
+		return controller.FileUpdateHandler(params, principal)
+	})
+	api.PolicyUpdateGroupHandler = policy.UpdateGroupHandlerFunc(func(params policy.UpdateGroupParams, principal *models.Principal) middleware.Responder {
+		return controller.PolicyUpdateGroupHandler(params, principal)
 	})
 	api.UserUpdateUserByIDHandler = user.UpdateUserByIDHandlerFunc(func(params user.UpdateUserByIDParams, principal *models.Principal) middleware.Responder {
 		return middleware.NotImplemented("operation user.UpdateUserByID has not yet been implemented")
 	})
+	api.UserUploadAvatarHandler = user.UploadAvatarHandlerFunc(func(params user.UploadAvatarParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthUploadAvatarHandler(params, principal)
+	})
 	api.FileUploadFileHandler = file.UploadFileHandlerFunc(func(params file.UploadFileParams, principal *models.Principal) middleware.Responder {
-		return middleware.NotImplemented("operation file.UploadFile has not yet been implemented")
+		return controller.FileUploadHandler(params, principal)
+	})
+	api.UserUnlockUserByIDHandler = user.UnlockUserByIDHandlerFunc(func(params user.UnlockUserByIDParams, principal *models.Principal) middleware.Responder {
+		return controller.AuthUnlockUserByIDHandler(params, principal)
 	})
 	api.FileZipFilesHandler = file.ZipFilesHandlerFunc(func(params file.ZipFilesParams, principal *models.Principal) middleware.Responder {
 		return controller.FileZipFilesHandler(params, principal)
@@ -146,7 +301,8 @@ func configureTLS(tlsConfig *tls.Config) {
 // This function can be called multiple times, depending on the number of serving schemes.
 // scheme value will be set accordingly: "http", "https" or "unix"
 func configureServer(s *http.Server, scheme, addr string) {
-
+	// Event streams only end with their clients otherwise and would delay shutting down the server until it times out
+	s.RegisterOnShutdown(manager.GetChangeManager().EndSubscriptions)
 }
 
 // The middleware configuration is for the handler executors. These do not apply to the swagger.json document.
@@ -159,13 +315,24 @@ func setupMiddlewares(handler http.Handler) http.Handler {
 // So this is a good place to plug in a panic handling middleware, logging and metrics
 func setupGlobalMiddleware(handler http.Handler) http.Handler {
 	fileServer := controller.FileServerMiddleware(handler)
+	if config.GetBool("webdav.enabled") {
+		fileServer = controller.WebDAVMiddleware(fileServer)
+	}
+	if config.GetBool("s3.enabled") {
+		fileServer = controller.S3Middleware(fileServer)
+	}
 	return controller.LoggingMiddleware(fileServer)
 }
 
 func initializeServer() {
 	config.Init()
 
-	err := repository.InitDatabaseConnection(config.GetString("db.type"), config.GetString("db.user"), config.GetString("db.password"), config.GetString("db.host"), config.GetInt("db.port"), config.GetString("db.name"))
+	err := crypt.SetDefaultHasher(config.GetString("auth.password_hash"))
+	if err != nil {
+		log.Fatal(0, "Password hasher setup failed, bailing out!: %v", err)
+	}
+
+	err = repository.InitDatabaseConnection(config.GetString("db.type"), config.GetString("db.user"), config.GetString("db.password"), config.GetString("db.host"), config.GetInt("db.port"), config.GetString("db.name"))
 	if err != nil {
 		log.Fatal(0, "Database setup failed, bailing out!: %v", err)
 	}
@@ -178,6 +345,26 @@ func initializeServer() {
 	if err != nil {
 		log.Fatal(0, "SessionRepository setup failed, bailing out!: %v", err)
 	}
+	loginAttemptRep, err := repository.CreateLoginAttemptRepository()
+	if err != nil {
+		log.Fatal(0, "LoginAttemptRepository setup failed, bailing out!: %v", err)
+	}
+	inviteCodeRep, err := repository.CreateInviteCodeRepository()
+	if err != nil {
+		log.Fatal(0, "InviteCodeRepository setup failed, bailing out!: %v", err)
+	}
+	appPasswordRep, err := repository.CreateAppPasswordRepository()
+	if err != nil {
+		log.Fatal(0, "AppPasswordRepository setup failed, bailing out!: %v", err)
+	}
+	sshKeyRep, err := repository.CreateSSHKeyRepository()
+	if err != nil {
+		log.Fatal(0, "SSHKeyRepository setup failed, bailing out!: %v", err)
+	}
+	accessKeyRep, err := repository.CreateAccessKeyRepository()
+	if err != nil {
+		log.Fatal(0, "AccessKeyRepository setup failed, bailing out!: %v", err)
+	}
 	fileInfoRep, err := repository.CreateFileInfoRepository()
 	if err != nil {
 		log.Fatal(0, "FileInfoRepository setup failed, bailing out!: %v", err)
@@ -186,18 +373,226 @@ func initializeServer() {
 	if err != nil {
 		log.Fatal(0, "ShareEntryRepository setup failed, bailing out!: %v", err)
 	}
-	fileSystemRep, err := repository.CreateFileSystemRepository(config.GetString("fs.base_directory"), tmpName, config.GetInt("fs.tmp_clear_interval"), config.GetInt("fs.tmp_data_expiry"))
+	starRep, err := repository.CreateStarRepository()
+	if err != nil {
+		log.Fatal(0, "StarRepository setup failed, bailing out!: %v", err)
+	}
+	dataExportRep, err := repository.CreateDataExportRepository()
 	if err != nil {
-		log.Fatal(0, "FileSystemRepository setup failed, bailing out!: %v", err)
+		log.Fatal(0, "DataExportRepository setup failed, bailing out!: %v", err)
+	}
+	groupRep, err := repository.CreateGroupRepository()
+	if err != nil {
+		log.Fatal(0, "GroupRepository setup failed, bailing out!: %v", err)
+	}
+	roleBindingRep, err := repository.CreateRoleBindingRepository()
+	if err != nil {
+		log.Fatal(0, "RoleBindingRepository setup failed, bailing out!: %v", err)
+	}
+	auditEntryRep, err := repository.CreateAuditEntryRepository()
+	if err != nil {
+		log.Fatal(0, "AuditEntryRepository setup failed, bailing out!: %v", err)
+	}
+	activityRep, err := repository.CreateActivityRepository()
+	if err != nil {
+		log.Fatal(0, "ActivityRepository setup failed, bailing out!: %v", err)
+	}
+	fileChangeRep, err := repository.CreateFileChangeRepository()
+	if err != nil {
+		log.Fatal(0, "FileChangeRepository setup failed, bailing out!: %v", err)
+	}
+	externalMountRep, err := repository.CreateExternalMountRepository()
+	if err != nil {
+		log.Fatal(0, "ExternalMountRepository setup failed, bailing out!: %v", err)
+	}
+	userKeyRep, err := repository.CreateUserKeyRepository()
+	if err != nil {
+		log.Fatal(0, "UserKeyRepository setup failed, bailing out!: %v", err)
+	}
+	blobRep, err := repository.CreateBlobRepository()
+	if err != nil {
+		log.Fatal(0, "BlobRepository setup failed, bailing out!: %v", err)
+	}
+	scrubReportRep, err := repository.CreateScrubReportRepository()
+	if err != nil {
+		log.Fatal(0, "ScrubReportRepository setup failed, bailing out!: %v", err)
+	}
+	var storageRep repository.StorageRepository
+	switch config.GetString("fs.storage") {
+	case "local":
+		storageRep, err = repository.CreateFileSystemRepository(config.GetString("fs.base_directory"), tmpName, config.GetInt("fs.tmp_clear_interval"), config.GetInt("fs.tmp_data_expiry"))
+		if err != nil {
+			log.Fatal(0, "FileSystemRepository setup failed, bailing out!: %v", err)
+		}
+	case "s3":
+		storageRep, err = repository.CreateObjectStorageRepository(repository.ObjectStorageConfig{
+			Endpoint:        config.GetString("fs.s3.endpoint"),
+			Region:          config.GetString("fs.s3.region"),
+			Bucket:          config.GetString("fs.s3.bucket"),
+			Prefix:          config.GetString("fs.s3.prefix"),
+			AccessKeyID:     config.GetString("fs.s3.access_key_id"),
+			SecretAccessKey: config.GetString("fs.s3.secret_access_key"),
+		}, tmpName, config.GetInt("fs.tmp_clear_interval"), config.GetInt("fs.tmp_data_expiry"))
+		if err != nil {
+			log.Fatal(0, "ObjectStorageRepository setup failed, bailing out!: %v", err)
+		}
+	case "memory":
+		storageRep, err = repository.CreateMemoryStorageRepository(tmpName, config.GetInt("fs.tmp_clear_interval"), config.GetInt("fs.tmp_data_expiry"))
+		if err != nil {
+			log.Fatal(0, "MemoryStorageRepository setup failed, bailing out!: %v", err)
+		}
+	default:
+		log.Fatal(0, "Unknown storage %s, bailing out!", config.GetString("fs.storage"))
+	}
+	// Files of users are encrypted before they are stored, external storages are mounted on top unencrypted
+	var encryptedStorageRep *repository.EncryptedStorageRepository
+	if config.GetBool("fs.encryption.enabled") {
+		var masterKey []byte
+		if encodedKey := config.GetString("fs.encryption.master_key"); encodedKey != "" {
+			masterKey, err = base64.StdEncoding.DecodeString(encodedKey)
+		} else {
+			masterKey, err = crypt.LoadOrCreateKeyFile(config.GetString("fs.encryption.key_file"), crypt.EncryptionKeySize)
+		}
+		if err != nil {
+			log.Fatal(0, "Encryption master key setup failed, bailing out!: %v", err)
+		}
+		encryptedStorageRep, err = repository.CreateEncryptedStorageRepository(storageRep, userKeyRep, masterKey, tmpName)
+		if err != nil {
+			log.Fatal(0, "EncryptedStorageRepository setup failed, bailing out!: %v", err)
+		}
+		storageRep = encryptedStorageRep
+	}
+	// The content of files of users is stored once by its hash, which the random keys of the encryption would defeat
+	var blobStorageRep *repository.BlobStorageRepository
+	if config.GetBool("fs.deduplication.enabled") {
+		if encryptedStorageRep != nil {
+			log.Fatal(0, "Deduplication cannot be combined with encryption at rest, bailing out!")
+		}
+		referenceKey, err := crypt.LoadOrCreateKeyFile(config.GetString("fs.deduplication.key_file"), 32)
+		if err != nil {
+			log.Fatal(0, "Deduplication reference key setup failed, bailing out!: %v", err)
+		}
+		blobStorageRep, err = repository.CreateBlobStorageRepository(storageRep, blobRep, referenceKey, config.GetString("fs.deduplication.blob_directory"), tmpName)
+		if err != nil {
+			log.Fatal(0, "BlobStorageRepository setup failed, bailing out!: %v", err)
+		}
+		storageRep = blobStorageRep
+	}
+	// External storages are mounted into the files of users on top of the configured storage
+	mountStorageRep, err := repository.CreateMountStorageRepository(storageRep)
+	if err != nil {
+		log.Fatal(0, "MountStorageRepository setup failed, bailing out!: %v", err)
 	}
 
-	manager.CreateAuthManager(sessionRep, userRep, config.GetInt("auth.session_expiry"), config.GetInt("auth.session_cleanup_interval"))
-	manager.CreateFileManager(fileSystemRep, fileInfoRep, shareEntryRep, tmpName)
+	var ldapRep *repository.LDAPRepository
+	if config.GetBool("auth.ldap.enabled") {
+		ldapRep, err = repository.CreateLDAPRepository(repository.LDAPConfig{
+			URL:                  config.GetString("auth.ldap.url"),
+			StartTLS:             config.GetBool("auth.ldap.start_tls"),
+			InsecureSkipVerify:   config.GetBool("auth.ldap.insecure_skip_verify"),
+			BindDN:               config.GetString("auth.ldap.bind_dn"),
+			BindPassword:         config.GetString("auth.ldap.bind_password"),
+			BaseDN:               config.GetString("auth.ldap.base_dn"),
+			UserFilter:           config.GetString("auth.ldap.user_filter"),
+			EmailAttribute:       config.GetString("auth.ldap.attr_email"),
+			FirstNameAttribute:   config.GetString("auth.ldap.attr_first_name"),
+			LastNameAttribute:    config.GetString("auth.ldap.attr_last_name"),
+			AdminGroupDN:         config.GetString("auth.ldap.admin_group_dn"),
+			GroupMemberAttribute: config.GetString("auth.ldap.group_member_attribute"),
+		})
+		if err != nil {
+			log.Fatal(0, "LDAPRepository setup failed, bailing out!: %v", err)
+		}
+	}
+
+	var oidcRep *repository.OIDCRepository
+	if config.GetBool("auth.oidc.enabled") {
+		oidcRep, err = repository.CreateOIDCRepository(repository.OIDCConfig{
+			IssuerURL:      config.GetString("auth.oidc.issuer_url"),
+			ClientID:       config.GetString("auth.oidc.client_id"),
+			ClientSecret:   config.GetString("auth.oidc.client_secret"),
+			RedirectURL:    config.GetString("auth.oidc.redirect_url"),
+			Scopes:         strings.Fields(config.GetString("auth.oidc.scopes")),
+			FirstNameClaim: config.GetString("auth.oidc.claim_first_name"),
+			LastNameClaim:  config.GetString("auth.oidc.claim_last_name"),
+			AdminClaim:     config.GetString("auth.oidc.claim_admin"),
+			AdminValue:     config.GetString("auth.oidc.admin_value"),
+		})
+		if err != nil {
+			log.Fatal(0, "OIDCRepository setup failed, bailing out!: %v", err)
+		}
+	}
+
+	lockoutPolicy := manager.LockoutPolicy{
+		AccountThreshold: config.GetInt("auth.lockout_threshold"),
+		IPThreshold:      config.GetInt("auth.lockout_ip_threshold"),
+		BaseDuration:     config.GetInt("auth.lockout_duration"),
+		MaxDuration:      config.GetInt("auth.lockout_max_duration"),
+	}
+	registrationPolicy := manager.RegistrationPolicy{
+		Mode:    config.GetString("auth.registration_mode"),
+		Domains: strings.Fields(config.GetString("auth.registration_domains")),
+	}
+	var accessKeyMasterKey []byte
+	if config.GetBool("s3.enabled") {
+		accessKeyMasterKey, err = crypt.LoadOrCreateKeyFile(config.GetString("s3.key_file"), 32)
+		if err != nil {
+			log.Fatal(0, "S3 access key setup failed, bailing out!: %v", err)
+		}
+	}
+	manager.CreateAuthManager(sessionRep, userRep, loginAttemptRep, inviteCodeRep, appPasswordRep, sshKeyRep, accessKeyRep, manager.AuthConfig{
+		AccessKeyMasterKey:     accessKeyMasterKey,
+		LDAPRep:                ldapRep,
+		LDAPLocalFallback:      config.GetBool("auth.ldap.local_fallback"),
+		OIDCRep:                oidcRep,
+		SessionExpiry:          config.GetInt("auth.session_expiry"),
+		SessionCleanupInterval: config.GetInt("auth.session_cleanup_interval"),
+		ImpersonationExpiry:    config.GetInt("auth.impersonation_expiry"),
+		LockoutPolicy:          lockoutPolicy,
+		RegistrationPolicy:     registrationPolicy,
+	})
+	// Created before the FileManager to record the changes in the file system found by its initial scan
+	manager.CreateActivityManager(activityRep)
+	manager.CreateChangeManager(fileChangeRep, config.GetInt("fs.changes_retention_days"))
+	// Created before the FileManager so its initial scan finds the mount points
+	if encryptedStorageRep != nil {
+		manager.CreateEncryptionManager(encryptedStorageRep)
+	}
+	if blobStorageRep != nil {
+		manager.CreateDeduplicationManager(blobStorageRep, config.GetInt("fs.deduplication.collect_interval"))
+	}
+	manager.CreateMountManager(externalMountRep, groupRep, mountStorageRep, tmpName)
+	manager.CreateFileManager(mountStorageRep, fileInfoRep, shareEntryRep, starRep, tmpName)
+	manager.CreateExportManager(dataExportRep)
+	quarantineDirectory := ""
+	if config.GetBool("fs.scrub.quarantine") {
+		quarantineDirectory = config.GetString("fs.scrub.quarantine_directory")
+	}
+	manager.CreateScrubManager(scrubReportRep, config.GetInt("fs.scrub.interval"), config.GetInt64("fs.scrub.rate_limit")*1024*1024, quarantineDirectory)
+	manager.CreatePolicyManager(groupRep, roleBindingRep)
+	manager.CreateAuditManager(auditEntryRep, config.GetInt("audit.retention_days"))
 	manager.CreateSystemManager("0.0.1") // TODO: Better place to save version
+
+	if config.GetBool("sftp.enabled") {
+		sftpServer, err = controller.StartSFTPServer(config.GetString("sftp.address"), config.GetString("sftp.host_key"))
+		if err != nil {
+			log.Fatal(0, "SFTP server setup failed, bailing out!: %v", err)
+		}
+	}
 }
 
 func shutdownServer() {
+	if sftpServer != nil {
+		sftpServer.Close()
+	}
 	manager.GetAuthManager().Close()
+	manager.GetAuditManager().Close()
+	manager.GetChangeManager().Close()
+	manager.GetMountManager().Close()
+	manager.GetScrubManager().Close()
+	if deduplicationMgr := manager.GetDeduplicationManager(); deduplicationMgr != nil {
+		deduplicationMgr.Close()
+	}
 	repository.CloseDatabaseConnection()
 	utils.CloseLogger()
 }
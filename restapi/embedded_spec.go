@@ -40,6 +40,156 @@ func init() {
   "host": "freecloud.glidingthrough.space",
   "basePath": "/api/v1",
   "paths": {
+    "/audit": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "audit"
+        ],
+        "summary": "Get a page of audit entries matching the filters, newest first",
+        "operationId": "getAuditEntries",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Entries per page, at most 500",
+            "name": "perPage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries of this action or, like 'file', of all actions in this category",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries caused by this user",
+            "name": "actorID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries concerning this user",
+            "name": "targetUserID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Audit entries and the total count of matching entries",
+            "schema": {
+              "$ref": "#/definitions/AuditEntryList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/audit/export": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "audit"
+        ],
+        "summary": "Export all audit entries matching the filters as JSON Lines into the temp folder of the user",
+        "operationId": "exportAuditEntries",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return entries of this action or, like 'file', of all actions in this category",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries caused by this user",
+            "name": "actorID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries concerning this user",
+            "name": "targetUserID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Path of the exported file",
+            "schema": {
+              "$ref": "#/definitions/Path"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/auth/login": {
       "post": {
         "tags": [
@@ -65,6 +215,19 @@ func init() {
               "$ref": "#/definitions/Token"
             }
           },
+          "429": {
+            "description": "Too many failed login attempts",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Seconds until the next login attempt will be accepted"
+              }
+            }
+          },
           "default": {
             "description": "Unexpected error",
             "schema": {
@@ -101,6 +264,63 @@ func init() {
         }
       }
     },
+    "/auth/oidc/authorize": {
+      "get": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Get the URL of the OpenID Connect provider to login with",
+        "operationId": "oidcAuthorize",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/AuthRedirect"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/oidc/callback": {
+      "post": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Login with the authorization code returned by the OpenID Connect provider",
+        "operationId": "oidcLogin",
+        "parameters": [
+          {
+            "description": "Authorization code and state returned by the provider",
+            "name": "callback",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/AuthCallback"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
     "/auth/signup": {
       "post": {
         "tags": [
@@ -117,6 +337,12 @@ func init() {
             "schema": {
               "$ref": "#/definitions/User"
             }
+          },
+          {
+            "type": "string",
+            "description": "Invitation code, required if registration is invite-only",
+            "name": "inviteCode",
+            "in": "query"
           }
         ],
         "responses": {
@@ -131,6 +357,9 @@ func init() {
             "schema": {
               "$ref": "#/definitions/Error"
             }
+          },
+          "202": {
+            "description": "Registration awaits approval by an admin"
           }
         }
       }
@@ -288,7 +517,7 @@ func init() {
         }
       }
     },
-    "/file/download": {
+    "/file/activity": {
       "get": {
         "security": [
           {
@@ -297,28 +526,57 @@ func init() {
             ]
           }
         ],
-        "produces": [
-          "application/gzip"
-        ],
         "tags": [
           "file"
         ],
-        "summary": "Downloads a file.",
-        "operationId": "downloadFile",
+        "summary": "Get a page of recent activities in the files of the user and in folders shared with him, newest first",
+        "operationId": "getActivities",
         "parameters": [
           {
             "type": "string",
-            "description": "Path to the file to download",
-            "name": "path",
-            "in": "query",
-            "required": true
+            "default": "/",
+            "description": "Only return activities within this folder, including shared folders below it",
+            "name": "folder",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return activities at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return activities before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Activities per page, at most 200",
+            "name": "perPage",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Requested gzipped file",
+            "description": "Activities and the total count of matching activities",
             "schema": {
-              "type": "file"
+              "$ref": "#/definitions/ActivityList"
             }
           },
           "default": {
@@ -330,8 +588,8 @@ func init() {
         }
       }
     },
-    "/file/rescan/me": {
-      "post": {
+    "/file/changes": {
+      "get": {
         "security": [
           {
             "TokenAuth": [
@@ -342,11 +600,32 @@ func init() {
         "tags": [
           "file"
         ],
-        "summary": "Rescan own data folder",
-        "operationId": "rescanCurrentUser",
+        "summary": "Get the changes in the files of the user and in files shared with him after a cursor, oldest first",
+        "operationId": "getFileChanges",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Cursor returned by the last request, omit it to only get the current cursor",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 500,
+            "description": "Changes to return at most, at most 1000",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Changes after the cursor and the cursor to continue with",
+            "schema": {
+              "$ref": "#/definitions/FileChangeList"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -357,33 +636,49 @@ func init() {
         }
       }
     },
-    "/file/rescan/{id}": {
-      "post": {
+    "/file/download": {
+      "get": {
         "security": [
           {
             "TokenAuth": [
-              "admin"
+              "user"
             ]
           }
         ],
+        "produces": [
+          "application/gzip",
+          "application/octet-stream"
+        ],
         "tags": [
           "file"
         ],
-        "summary": "Rescan data folder by user id",
-        "operationId": "rescanUserByID",
+        "summary": "Downloads a file.",
+        "operationId": "downloadFile",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "description": "The user id",
-            "name": "id",
-            "in": "path",
+            "type": "string",
+            "description": "Path to the file to download",
+            "name": "path",
+            "in": "query",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Requested file, gzipped unless only application/octet-stream is accepted",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Digest": {
+                "type": "string",
+                "description": "Checksums of the content as defined by RFC 3230, only sent if the file is downloaded uncompressed"
+              },
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag derived from the SHA-256 checksum of the content, weak if the file is downloaded gzipped"
+              }
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -394,7 +689,7 @@ func init() {
         }
       }
     },
-    "/file/search": {
+    "/file/events": {
       "get": {
         "security": [
           {
@@ -403,26 +698,29 @@ func init() {
             ]
           }
         ],
+        "description": "The events 'change' and 'share' contain a FileChange with the same ID as the event, see getFileChanges. 'quota' contains the storage used by the user after his files changed. The first event is 'ready' or, if Last-Event-ID is unknown or too old, 'reset' after which the client has to list all files again. The token can be passed as access_token query parameter for clients which cannot set headers.",
+        "produces": [
+          "text/event-stream"
+        ],
         "tags": [
           "file"
         ],
-        "summary": "Search files/folders",
-        "operationId": "searchFile",
+        "summary": "Stream the changes in the files of the user and in files shared with him as server-sent events",
+        "operationId": "getFileEvents",
         "parameters": [
           {
-            "name": "searchRequest",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/SearchRequest"
-            }
+            "type": "integer",
+            "format": "int64",
+            "description": "ID of the last received event to continue after when reconnecting",
+            "name": "Last-Event-ID",
+            "in": "header"
           }
         ],
         "responses": {
           "200": {
-            "description": "Search results",
+            "description": "Stream of server-sent events",
             "schema": {
-              "$ref": "#/definitions/FileList"
+              "type": "file"
             }
           },
           "default": {
@@ -434,7 +732,7 @@ func init() {
         }
       }
     },
-    "/file/share/": {
+    "/file/rescan/me": {
       "post": {
         "security": [
           {
@@ -446,18 +744,8 @@ func init() {
         "tags": [
           "file"
         ],
-        "summary": "Share files/folders",
-        "operationId": "shareFiles",
-        "parameters": [
-          {
-            "name": "shareRequest",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/ShareRequest"
-            }
-          }
-        ],
+        "summary": "Rescan own data folder",
+        "operationId": "rescanCurrentUser",
         "responses": {
           "200": {
             "description": "Success"
@@ -471,27 +759,141 @@ func init() {
         }
       }
     },
-    "/file/share/{shareID}": {
-      "get": {
+    "/file/rescan/{id}": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
           "file"
         ],
-        "summary": "Get share entry by shareID",
-        "operationId": "getShareEntryByID",
+        "summary": "Rescan data folder by user id",
+        "operationId": "rescanUserByID",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
-            "description": "Requested shareID",
-            "name": "shareID",
-            "in": "path",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/search": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Search files/folders",
+        "operationId": "searchFile",
+        "parameters": [
+          {
+            "name": "searchRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SearchRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Search results",
+            "schema": {
+              "$ref": "#/definitions/FileList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/share/": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Share files/folders",
+        "operationId": "shareFiles",
+        "parameters": [
+          {
+            "name": "shareRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ShareRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/share/{shareID}": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get share entry by shareID",
+        "operationId": "getShareEntryByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requested shareID",
+            "name": "shareID",
+            "in": "path",
             "required": true
           }
         ],
@@ -596,7 +998,7 @@ func init() {
         "parameters": [
           {
             "type": "string",
-            "description": "Path where to upload file",
+            "description": "Path of the uploaded file, an existing file gets replaced",
             "name": "path",
             "in": "query",
             "required": true
@@ -606,11 +1008,26 @@ func init() {
             "description": "The file to upload.",
             "name": "upfile",
             "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Expected hex encoded SHA-256 checksum, the upload is rejected if the content does not match",
+            "name": "sha256",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Expected hex encoded MD5 checksum, the upload is rejected if the content does not match",
+            "name": "md5",
+            "in": "query"
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Info of the uploaded file",
+            "schema": {
+              "$ref": "#/definitions/FileInfo"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -661,7 +1078,7 @@ func init() {
         }
       }
     },
-    "/system/stats": {
+    "/group": {
       "get": {
         "security": [
           {
@@ -671,15 +1088,15 @@ func init() {
           }
         ],
         "tags": [
-          "system"
+          "policy"
         ],
-        "summary": "Get system status",
-        "operationId": "getSystemStats",
+        "summary": "Get all groups",
+        "operationId": "getGroups",
         "responses": {
           "200": {
-            "description": "System stats",
+            "description": "Groups",
             "schema": {
-              "$ref": "#/definitions/SystemStats"
+              "$ref": "#/definitions/GroupList"
             }
           },
           "default": {
@@ -689,27 +1106,36 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/user/me": {
-      "get": {
+      },
+      "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "user"
+          "policy"
+        ],
+        "summary": "Create a new group",
+        "operationId": "createGroup",
+        "parameters": [
+          {
+            "description": "Name, roles and members of the group",
+            "name": "group",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          }
         ],
-        "summary": "Get current user",
-        "operationId": "getCurrentUser",
         "responses": {
           "200": {
-            "description": "Success",
+            "description": "Created group",
             "schema": {
-              "$ref": "#/definitions/User"
+              "$ref": "#/definitions/Group"
             }
           },
           "default": {
@@ -719,23 +1145,47 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/group/{id}": {
+      "put": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "user"
+          "policy"
+        ],
+        "summary": "Replace the name, roles and members of a group",
+        "operationId": "updateGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The group id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Name, roles and members of the group",
+            "name": "group",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          }
         ],
-        "summary": "Delete current user",
-        "operationId": "deleteCurrentUser",
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Updated group",
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -745,28 +1195,27 @@ func init() {
           }
         }
       },
-      "patch": {
+      "delete": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "user"
+          "policy"
         ],
-        "summary": "Modify current user",
-        "operationId": "updateCurrentUser",
+        "summary": "Delete a group, its members lose the roles of the group",
+        "operationId": "deleteGroup",
         "parameters": [
           {
-            "description": "Updated user info",
-            "name": "userInfo",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/User"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "description": "The group id",
+            "name": "id",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
@@ -782,7 +1231,7 @@ func init() {
         }
       }
     },
-    "/user/{id}": {
+    "/invite": {
       "get": {
         "security": [
           {
@@ -792,25 +1241,15 @@ func init() {
           }
         ],
         "tags": [
-          "user"
-        ],
-        "summary": "Get specific user by id",
-        "operationId": "getUserByID",
-        "parameters": [
-          {
-            "minimum": 1,
-            "type": "integer",
-            "description": "The user id",
-            "name": "id",
-            "in": "path",
-            "required": true
-          }
+          "auth"
         ],
+        "summary": "Get all invitation codes",
+        "operationId": "getInviteCodes",
         "responses": {
           "200": {
-            "description": "Success",
+            "description": "Invitation codes",
             "schema": {
-              "$ref": "#/definitions/User"
+              "$ref": "#/definitions/InviteCodeList"
             }
           },
           "default": {
@@ -821,7 +1260,7 @@ func init() {
           }
         }
       },
-      "delete": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
@@ -830,23 +1269,27 @@ func init() {
           }
         ],
         "tags": [
-          "user"
+          "auth"
         ],
-        "summary": "Delete user by id",
-        "operationId": "deleteUserByID",
+        "summary": "Create a new invitation code",
+        "operationId": "createInviteCode",
         "parameters": [
           {
-            "minimum": 1,
-            "type": "integer",
-            "description": "The user id",
-            "name": "id",
-            "in": "path",
-            "required": true
+            "description": "Usage limit and expiry of the code, zero means unlimited",
+            "name": "inviteCodeRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/InviteCodeRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Created invitation code",
+            "schema": {
+              "$ref": "#/definitions/InviteCode"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -855,8 +1298,10 @@ func init() {
             }
           }
         }
-      },
-      "patch": {
+      }
+    },
+    "/invite/{id}": {
+      "delete": {
         "security": [
           {
             "TokenAuth": [
@@ -865,27 +1310,18 @@ func init() {
           }
         ],
         "tags": [
-          "user"
+          "auth"
         ],
-        "summary": "Modify user by id",
-        "operationId": "updateUserByID",
+        "summary": "Revoke an invitation code",
+        "operationId": "revokeInviteCode",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
-            "description": "The user id",
+            "description": "The invitation code id",
             "name": "id",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "Updated user info",
-            "name": "userUpdate",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/UserUpdate"
-            }
           }
         ],
         "responses": {
@@ -900,458 +1336,4426 @@ func init() {
           }
         }
       }
-    }
+    },
+    "/mount": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get all external storages mounted into the files of users and groups",
+        "operationId": "getMounts",
+        "responses": {
+          "200": {
+            "description": "Mounts without their secrets",
+            "schema": {
+              "$ref": "#/definitions/ExternalMountList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Mount a local directory or SFTP server into the files of a user or group",
+        "operationId": "createMount",
+        "parameters": [
+          {
+            "description": "Target and mount point of the storage",
+            "name": "mount",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The created mount",
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/mount/{id}": {
+      "put": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Replace the target and mount point of a mount, empty secrets are kept",
+        "operationId": "updateMount",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The mount id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Target and mount point of the storage",
+            "name": "mount",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated mount",
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Unmount an external storage, its files are left untouched",
+        "operationId": "deleteMount",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The mount id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/role": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Get all roles with the permissions they grant and restrict",
+        "operationId": "getRoles",
+        "responses": {
+          "200": {
+            "description": "Roles",
+            "schema": {
+              "$ref": "#/definitions/RoleList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/system/blobs/collect": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Delete the blobs of deduplicated content that are not referenced by any file anymore",
+        "operationId": "collectBlobs",
+        "responses": {
+          "200": {
+            "description": "Count and size of the deleted blobs",
+            "schema": {
+              "$ref": "#/definitions/BlobCollectionResult"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/system/encrypt": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Encrypt all files stored before the encryption at rest was enabled",
+        "operationId": "encryptStorage",
+        "responses": {
+          "200": {
+            "description": "Count of the encrypted files",
+            "schema": {
+              "$ref": "#/definitions/EncryptionResult"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/system/scrub": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get the report of the latest integrity scrub of the stored files",
+        "operationId": "getScrubReport",
+        "responses": {
+          "200": {
+            "description": "The latest scrub report, it is still running if it has no finish time",
+            "schema": {
+              "$ref": "#/definitions/ScrubReport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Start an integrity scrub re-hashing the stored files in the background",
+        "operationId": "startScrub",
+        "responses": {
+          "202": {
+            "description": "The scrub has been started",
+            "schema": {
+              "$ref": "#/definitions/ScrubReport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/system/stats": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get system status",
+        "operationId": "getSystemStats",
+        "responses": {
+          "200": {
+            "description": "System stats",
+            "schema": {
+              "$ref": "#/definitions/SystemStats"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get a page of all users",
+        "operationId": "getUsers",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 25,
+            "description": "Users per page, at most 100",
+            "name": "perPage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "email",
+            "description": "Attribute to sort by, one of email, firstName, lastName, created or lastSession",
+            "name": "sortBy",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Sort in descending order",
+            "name": "descending",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return users whose name or email contain this term",
+            "name": "search",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Users and the total count of matching users",
+            "schema": {
+              "$ref": "#/definitions/UserList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get current user",
+        "operationId": "getCurrentUser",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Delete current user",
+        "operationId": "deleteCurrentUser",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "Whether files are kept after deletion, has to match retainFilesAfterDeletion of the user as confirmation",
+            "name": "retainFiles",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "patch": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Modify current user",
+        "operationId": "updateCurrentUser",
+        "parameters": [
+          {
+            "description": "Updated user info",
+            "name": "userInfo",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/access-keys": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get all S3 access keys of the current user",
+        "operationId": "getAccessKeys",
+        "responses": {
+          "200": {
+            "description": "Access keys without their secrets",
+            "schema": {
+              "$ref": "#/definitions/AccessKeyList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Create an access key S3 clients can use to access the files of the current user",
+        "operationId": "createAccessKey",
+        "parameters": [
+          {
+            "name": "accessKeyRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/AccessKeyRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The new access key including its secret",
+            "schema": {
+              "$ref": "#/definitions/AccessKey"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/access-keys/{id}": {
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Revoke an S3 access key of the current user",
+        "operationId": "deleteAccessKey",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The access key id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/app-passwords": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get all app passwords of the current user",
+        "operationId": "getAppPasswords",
+        "responses": {
+          "200": {
+            "description": "App passwords without the passwords themselves",
+            "schema": {
+              "$ref": "#/definitions/AppPasswordList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Create an app password for the current user which can be used by clients like WebDAV instead of his password",
+        "operationId": "createAppPassword",
+        "parameters": [
+          {
+            "name": "appPasswordRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/AppPasswordRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The new app password including the generated password",
+            "schema": {
+              "$ref": "#/definitions/AppPassword"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/app-passwords/{id}": {
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Revoke an app password of the current user",
+        "operationId": "deleteAppPassword",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The app password id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/avatar": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Upload an avatar for the current user, it is cropped to a square and resized",
+        "operationId": "uploadAvatar",
+        "parameters": [
+          {
+            "type": "file",
+            "description": "The JPEG, PNG or GIF image to use as avatar",
+            "name": "upfile",
+            "in": "formData",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Delete the avatar of the current user",
+        "operationId": "deleteAvatar",
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/export": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Request an export of all personal data of the current user, it is built in the background",
+        "operationId": "requestDataExport",
+        "responses": {
+          "202": {
+            "description": "The export has been started or is still running",
+            "schema": {
+              "$ref": "#/definitions/DataExport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/export/{id}": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get the status of a data export of the current user",
+        "operationId": "getDataExport",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The data export id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The data export, finished exports contain a download link",
+            "schema": {
+              "$ref": "#/definitions/DataExport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/ssh-keys": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get all SSH public keys of the current user",
+        "operationId": "getSSHKeys",
+        "responses": {
+          "200": {
+            "description": "SSH public keys of the user",
+            "schema": {
+              "$ref": "#/definitions/SSHKeyList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Add an SSH public key the current user can log in to the SFTP server with",
+        "operationId": "addSSHKey",
+        "parameters": [
+          {
+            "name": "sshKeyRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SSHKeyRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The added SSH public key",
+            "schema": {
+              "$ref": "#/definitions/SSHKey"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/ssh-keys/{id}": {
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Remove an SSH public key of the current user",
+        "operationId": "deleteSSHKey",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The SSH key id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get specific user by id",
+        "operationId": "getUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Delete user by id",
+        "operationId": "deleteUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "patch": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Modify user by id",
+        "operationId": "updateUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Updated user info",
+            "name": "userUpdate",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UserUpdate"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/approve": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Approve a user registration pending for approval",
+        "operationId": "approveUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/avatar": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "produces": [
+          "image/png"
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get the avatar of a user as PNG",
+        "operationId": "getAvatarByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 128,
+            "description": "Edge length of the avatar in pixels, one of 32, 64, 128 or 256",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "ETag of a cached avatar",
+            "name": "If-None-Match",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The avatar image",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "304": {
+            "description": "The cached avatar is still valid",
+            "headers": {
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/disable": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Disable a user, this ends all his sessions and blocks logins",
+        "operationId": "disableUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/enable": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Enable a disabled user",
+        "operationId": "enableUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/impersonate": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Start a time-limited session acting as the user, it is read-only unless writing is allowed explicitly",
+        "operationId": "impersonateUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Whether the session may change data of the user",
+            "name": "impersonationRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ImpersonationRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Token of the impersonation session",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/roles": {
+      "put": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Replace the roles assigned directly to a user",
+        "operationId": "setUserRoles",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Roles of the user",
+            "name": "roleAssignment",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/RoleAssignment"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Roles assigned to the user",
+            "schema": {
+              "$ref": "#/definitions/RoleAssignment"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/transfer": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Transfer all files of a deleted or disabled user into a new folder of another user",
+        "operationId": "transferUserFiles",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "The recipient of the files",
+            "name": "fileTransferRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FileTransferRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The folder containing the transferred files",
+            "schema": {
+              "$ref": "#/definitions/FileInfo"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/{id}/unlock": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Unlock a user locked out due to failed login attempts",
+        "operationId": "unlockUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    }
   },
   "definitions": {
+    "AccessKey": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "accessKeyID": {
+          "description": "ID of the key S3 clients sign their requests with",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "secretAccessKey": {
+          "description": "Secret of the key, only returned once after creating the access key",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AccessKeyList": {
+      "type": "object",
+      "properties": {
+        "accessKeys": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AccessKey"
+          }
+        }
+      }
+    },
+    "AccessKeyRequest": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the client using the access key",
+          "type": "string"
+        }
+      }
+    },
+    "Activity": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "description": "One of created, changed, moved or deleted",
+          "type": "string"
+        },
+        "actorID": {
+          "description": "User who caused the activity, 0 if the change was detected in the file system",
+          "type": "integer",
+          "format": "int64"
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "isDir": {
+          "type": "boolean"
+        },
+        "oldPath": {
+          "description": "Full path of a moved file before moving it",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "path": {
+          "description": "Full path of the file, for moves after moving it",
+          "type": "string"
+        }
+      }
+    },
+    "ActivityList": {
+      "type": "object",
+      "properties": {
+        "activities": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Activity"
+          }
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "AppPassword": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "password": {
+          "description": "Generated password, only returned once after creating the app password",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AppPasswordList": {
+      "type": "object",
+      "properties": {
+        "appPasswords": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AppPassword"
+          }
+        }
+      }
+    },
+    "AppPasswordRequest": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the client using the app password",
+          "type": "string"
+        }
+      }
+    },
+    "AuditEntry": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "actorID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "ip": {
+          "type": "string"
+        },
+        "target": {
+          "type": "string"
+        },
+        "targetUserID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AuditEntryList": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditEntry"
+          }
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "AuthCallback": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "string"
+        },
+        "state": {
+          "type": "string"
+        }
+      }
+    },
+    "AuthRedirect": {
+      "type": "object",
+      "properties": {
+        "url": {
+          "type": "string"
+        }
+      }
+    },
+    "BlobCollectionResult": {
+      "type": "object",
+      "properties": {
+        "deletedBlobs": {
+          "description": "Count of the unreferenced blobs that have been deleted",
+          "type": "integer",
+          "format": "int64"
+        },
+        "freedBytes": {
+          "description": "Sum of the sizes of the deleted blobs",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "CreateFileRequest": {
       "type": "object",
       "properties": {
-        "fullPath": {
-          "type": "string"
+        "fullPath": {
+          "type": "string"
+        },
+        "isDir": {
+          "type": "boolean"
+        }
+      }
+    },
+    "DataExport": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "downloadURL": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "finished": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "path": {
+          "description": "Path of the finished export in the files of the user",
+          "type": "string"
+        },
+        "status": {
+          "description": "One of pending, running, finished, failed or expired",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "EncryptionResult": {
+      "type": "object",
+      "properties": {
+        "encryptedFiles": {
+          "description": "Count of the files that have been encrypted",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Error": {
+      "type": "object",
+      "properties": {
+        "message": {
+          "type": "string"
+        }
+      }
+    },
+    "ExternalMount": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "groupID": {
+          "description": "Group the storage is mounted for at the same path for every member",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "host": {
+          "type": "string"
+        },
+        "hostKey": {
+          "description": "Public key of the SFTP host in authorized_keys format",
+          "type": "string"
+        },
+        "password": {
+          "description": "Password for the SFTP host, never returned",
+          "type": "string"
+        },
+        "path": {
+          "description": "Path of the mount point in the files of the user or the group members",
+          "type": "string"
+        },
+        "port": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "privateKey": {
+          "description": "Private key in PEM format for the SFTP host, never returned",
+          "type": "string"
+        },
+        "readOnly": {
+          "type": "boolean"
+        },
+        "root": {
+          "description": "Directory on the server or SFTP host that is mounted",
+          "type": "string"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "local",
+            "sftp"
+          ]
+        },
+        "userID": {
+          "description": "User the storage is mounted for, either this or the group has to be set",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "username": {
+          "type": "string"
+        }
+      }
+    },
+    "ExternalMountList": {
+      "type": "object",
+      "properties": {
+        "mounts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ExternalMount"
+          }
+        }
+      }
+    },
+    "FileChange": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "description": "One of created, changed, moved, deleted, shared or unshared",
+          "type": "string"
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "isDir": {
+          "type": "boolean"
+        },
+        "lastChanged": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "oldPath": {
+          "description": "Full path of a moved file before moving it",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "path": {
+          "description": "Full path of the file, for moves after moving it",
+          "type": "string"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "FileChangeList": {
+      "type": "object",
+      "properties": {
+        "changes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileChange"
+          }
+        },
+        "cursor": {
+          "description": "Cursor to request the following changes with",
+          "type": "integer",
+          "format": "int64"
+        },
+        "hasMore": {
+          "description": "More changes are available after the cursor",
+          "type": "boolean"
+        },
+        "reset": {
+          "description": "The requested cursor is unknown or too old, the client has to list all files again and continue with the returned cursor",
+          "type": "boolean"
+        }
+      }
+    },
+    "FileInfo": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "blobHash": {
+          "description": "SHA-256 hash of the deduplicated content the file references",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "isDir": {
+          "type": "boolean"
+        },
+        "lastChanged": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "md5": {
+          "description": "Hex encoded MD5 checksum of the content for legacy tools, only computed if enabled",
+          "type": "string"
+        },
+        "mimeType": {
+          "type": "string"
+        },
+        "name": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index:fullPath\""
+        },
+        "ownerID": {
+          "type": "integer"
+        },
+        "parentID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "path": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index:fullPath\""
+        },
+        "sha256": {
+          "description": "Hex encoded SHA-256 checksum of the content, empty for folders and files not hashed yet",
+          "type": "string"
+        },
+        "shareID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "starred": {
+          "type": "boolean",
+          "x-go-custom-tag": "gorm:\"-\""
+        }
+      }
+    },
+    "FileInfoUpdate": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "isDir": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "lastChanged": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "mimeType": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "name": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "parentID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "path": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "shareID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "starred": {
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
+    "FileList": {
+      "type": "object",
+      "properties": {
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileInfo"
+          }
+        }
+      }
+    },
+    "FileTransferRequest": {
+      "type": "object",
+      "required": [
+        "recipientID"
+      ],
+      "properties": {
+        "recipientID": {
+          "description": "ID of the user receiving the files",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Group": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "memberIDs": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "name": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        }
+      }
+    },
+    "GroupList": {
+      "type": "object",
+      "properties": {
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Group"
+          }
+        }
+      }
+    },
+    "ImpersonationRequest": {
+      "type": "object",
+      "properties": {
+        "allowWrite": {
+          "type": "boolean"
+        }
+      }
+    },
+    "InviteCode": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "code": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "createdBy": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "expiresAt": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "maxUses": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "uses": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "InviteCodeList": {
+      "type": "object",
+      "properties": {
+        "inviteCodes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/InviteCode"
+          }
+        }
+      }
+    },
+    "InviteCodeRequest": {
+      "type": "object",
+      "properties": {
+        "expiresAt": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "maxUses": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "LoginData": {
+      "type": "object",
+      "properties": {
+        "email": {
+          "type": "string"
+        },
+        "password": {
+          "type": "string"
+        }
+      }
+    },
+    "Path": {
+      "type": "object",
+      "properties": {
+        "path": {
+          "type": "string"
+        }
+      }
+    },
+    "PathInfo": {
+      "type": "object",
+      "properties": {
+        "content": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileInfo"
+          }
+        },
+        "fileInfo": {
+          "$ref": "#/definitions/FileInfo"
+        }
+      }
+    },
+    "PathList": {
+      "type": "object",
+      "properties": {
+        "paths": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "Principal": {
+      "type": "object",
+      "properties": {
+        "impersonator": {
+          "$ref": "#/definitions/User"
+        },
+        "readOnly": {
+          "type": "boolean"
+        },
+        "token": {
+          "$ref": "#/definitions/Token"
+        },
+        "user": {
+          "$ref": "#/definitions/User"
+        }
+      }
+    },
+    "Role": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "grants": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "restricts": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "RoleAssignment": {
+      "type": "object",
+      "required": [
+        "roles"
+      ],
+      "properties": {
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "RoleList": {
+      "type": "object",
+      "properties": {
+        "roles": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Role"
+          }
+        }
+      }
+    },
+    "SSHKey": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "fingerprint": {
+          "description": "SHA256 fingerprint of the public key",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "Public key in the format of authorized_keys files",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "SSHKeyList": {
+      "type": "object",
+      "properties": {
+        "sshKeys": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SSHKey"
+          }
+        }
+      }
+    },
+    "SSHKeyRequest": {
+      "type": "object",
+      "required": [
+        "publicKey"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the key, defaults to the comment of the public key",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "Public key in the format of authorized_keys files",
+          "type": "string"
+        }
+      }
+    },
+    "ScrubIssue": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "actualSha256": {
+          "description": "SHA-256 checksum of the stored content",
+          "type": "string"
+        },
+        "expectedSha256": {
+          "description": "Recorded SHA-256 checksum of the file",
+          "type": "string"
+        },
+        "kind": {
+          "description": "One of corrupted, modified, unreadable, missing or unindexed",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "path": {
+          "description": "Path of the file in the files of its owner",
+          "type": "string"
+        },
+        "quarantinePath": {
+          "description": "Path in the storage the corrupted file has been moved to, empty if it has not been quarantined",
+          "type": "string"
+        },
+        "reportID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "ScrubReport": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "checkedBytes": {
+          "description": "Sum of the sizes of the hashed files",
+          "type": "integer",
+          "format": "int64"
+        },
+        "checkedFiles": {
+          "description": "Count of the files whose content has been hashed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "finished": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "issues": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ScrubIssue"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "started": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "One of running, finished or failed",
+          "type": "string"
+        }
+      }
+    },
+    "SearchRequest": {
+      "type": "object",
+      "properties": {
+        "keyword": {
+          "type": "string"
+        }
+      }
+    },
+    "ShareEntry": {
+      "type": "object",
+      "properties": {
+        "FileID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "OwnerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "SharedWithID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"-\""
+        }
+      }
+    },
+    "ShareRequest": {
+      "type": "object",
+      "properties": {
+        "paths": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "users": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          }
+        }
+      }
+    },
+    "SystemStats": {
+      "type": "object",
+      "properties": {
+        "allocMem": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "goVersion": {
+          "type": "string"
+        },
+        "numGC": {
+          "type": "integer"
+        },
+        "numGoroutines": {
+          "type": "integer"
+        },
+        "numSessions": {
+          "type": "integer"
+        },
+        "systemMem": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "totalAllocMem": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "uptime": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "version": {
+          "type": "string"
+        }
+      }
+    },
+    "Token": {
+      "type": "object",
+      "properties": {
+        "token": {
+          "type": "string"
+        }
+      }
+    },
+    "User": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "authProvider": {
+          "type": "string"
+        },
+        "authSubject": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "disabled": {
+          "type": "boolean"
+        },
+        "email": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "firstName": {
+          "type": "string"
+        },
+        "hasAvatar": {
+          "type": "boolean"
+        },
+        "isAdmin": {
+          "type": "boolean"
+        },
+        "lastName": {
+          "type": "string"
+        },
+        "lastSession": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "password": {
+          "type": "string"
+        },
+        "pendingApproval": {
+          "type": "boolean"
+        },
+        "permissions": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "retainFilesAfterDeletion": {
+          "type": "boolean"
+        },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "storageUsed": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "updated": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "UserList": {
+      "type": "object",
+      "properties": {
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "users": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/User"
+          }
+        }
+      }
+    },
+    "UserUpdate": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
         },
-        "isDir": {
-          "type": "boolean"
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "email": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "firstName": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "isAdmin": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "lastName": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "lastSession": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "password": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "retainFilesAfterDeletion": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "updated": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        }
+      }
+    }
+  },
+  "securityDefinitions": {
+    "TokenAuth": {
+      "type": "oauth2",
+      "flow": "accessCode",
+      "authorizationUrl": "https://dummy.oauth.net/auth",
+      "tokenUrl": "https://dumy.oauth.net/token",
+      "scopes": {
+        "admin": "admin with all privileges",
+        "user": "normal user"
+      }
+    }
+  },
+  "tags": [
+    {
+      "description": "Authentication",
+      "name": "auth"
+    },
+    {
+      "description": "User management",
+      "name": "user"
+    },
+    {
+      "description": "File management",
+      "name": "file"
+    },
+    {
+      "description": "System management",
+      "name": "system"
+    },
+    {
+      "description": "Roles, groups and permissions",
+      "name": "policy"
+    },
+    {
+      "description": "Audit log",
+      "name": "audit"
+    }
+  ]
+}`))
+	FlatSwaggerJSON = json.RawMessage([]byte(`{
+  "consumes": [
+    "application/json"
+  ],
+  "produces": [
+    "application/json"
+  ],
+  "schemes": [
+    "http"
+  ],
+  "swagger": "2.0",
+  "info": {
+    "description": "This is the API for the freecloud server.",
+    "title": "freecloud API",
+    "license": {
+      "name": "Apache 2.0",
+      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+    },
+    "version": "1.0.0"
+  },
+  "host": "freecloud.glidingthrough.space",
+  "basePath": "/api/v1",
+  "paths": {
+    "/audit": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "audit"
+        ],
+        "summary": "Get a page of audit entries matching the filters, newest first",
+        "operationId": "getAuditEntries",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Entries per page, at most 500",
+            "name": "perPage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return entries of this action or, like 'file', of all actions in this category",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries caused by this user",
+            "name": "actorID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries concerning this user",
+            "name": "targetUserID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Audit entries and the total count of matching entries",
+            "schema": {
+              "$ref": "#/definitions/AuditEntryList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/audit/export": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "audit"
+        ],
+        "summary": "Export all audit entries matching the filters as JSON Lines into the temp folder of the user",
+        "operationId": "exportAuditEntries",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Only return entries of this action or, like 'file', of all actions in this category",
+            "name": "action",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries caused by this user",
+            "name": "actorID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries concerning this user",
+            "name": "targetUserID",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return entries recorded before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Path of the exported file",
+            "schema": {
+              "$ref": "#/definitions/Path"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/login": {
+      "post": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Login with existing user",
+        "operationId": "login",
+        "parameters": [
+          {
+            "description": "Credentials for login",
+            "name": "credentials",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/LoginData"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
+          },
+          "429": {
+            "description": "Too many failed login attempts",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            },
+            "headers": {
+              "Retry-After": {
+                "type": "integer",
+                "format": "int64",
+                "description": "Seconds until the next login attempt will be accepted"
+              }
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/logout": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "auth"
+        ],
+        "summary": "Logout current user",
+        "operationId": "logout",
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/oidc/authorize": {
+      "get": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Get the URL of the OpenID Connect provider to login with",
+        "operationId": "oidcAuthorize",
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/AuthRedirect"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/oidc/callback": {
+      "post": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Login with the authorization code returned by the OpenID Connect provider",
+        "operationId": "oidcLogin",
+        "parameters": [
+          {
+            "description": "Authorization code and state returned by the provider",
+            "name": "callback",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/AuthCallback"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/auth/signup": {
+      "post": {
+        "tags": [
+          "auth"
+        ],
+        "summary": "Signup as a new user",
+        "operationId": "signup",
+        "parameters": [
+          {
+            "description": "User that should be registered",
+            "name": "user",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
+          },
+          {
+            "type": "string",
+            "description": "Invitation code, required if registration is invite-only",
+            "name": "inviteCode",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          },
+          "202": {
+            "description": "Registration awaits approval by an admin"
+          }
+        }
+      }
+    },
+    "/file": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get pathInfo of requested path",
+        "operationId": "getPathInfo",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Requested path",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Requested pathInfo",
+            "schema": {
+              "$ref": "#/definitions/PathInfo"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Create new file/folder",
+        "operationId": "createFile",
+        "parameters": [
+          {
+            "name": "createFileRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/CreateFileRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/FileInfo"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Delete file/folder",
+        "operationId": "deleteFile",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Path to fileInfo to delete",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "patch": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Update file/folder",
+        "operationId": "updateFile",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Path to fileInfo to update",
+            "name": "path",
+            "in": "query",
+            "required": true
+          },
+          {
+            "name": "fileInfoUpdate",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FileInfoUpdate"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/activity": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get a page of recent activities in the files of the user and in folders shared with him, newest first",
+        "operationId": "getActivities",
+        "parameters": [
+          {
+            "type": "string",
+            "default": "/",
+            "description": "Only return activities within this folder, including shared folders below it",
+            "name": "folder",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return activities at or after this unix timestamp",
+            "name": "since",
+            "in": "query"
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Only return activities before this unix timestamp",
+            "name": "until",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 50,
+            "description": "Activities per page, at most 200",
+            "name": "perPage",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Activities and the total count of matching activities",
+            "schema": {
+              "$ref": "#/definitions/ActivityList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/changes": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get the changes in the files of the user and in files shared with him after a cursor, oldest first",
+        "operationId": "getFileChanges",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "Cursor returned by the last request, omit it to only get the current cursor",
+            "name": "cursor",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 500,
+            "description": "Changes to return at most, at most 1000",
+            "name": "limit",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Changes after the cursor and the cursor to continue with",
+            "schema": {
+              "$ref": "#/definitions/FileChangeList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/download": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "produces": [
+          "application/gzip",
+          "application/octet-stream"
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Downloads a file.",
+        "operationId": "downloadFile",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Path to the file to download",
+            "name": "path",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Requested file, gzipped unless only application/octet-stream is accepted",
+            "schema": {
+              "type": "file"
+            },
+            "headers": {
+              "Digest": {
+                "type": "string",
+                "description": "Checksums of the content as defined by RFC 3230, only sent if the file is downloaded uncompressed"
+              },
+              "ETag": {
+                "type": "string",
+                "description": "Entity tag derived from the SHA-256 checksum of the content, weak if the file is downloaded gzipped"
+              }
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/events": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "description": "The events 'change' and 'share' contain a FileChange with the same ID as the event, see getFileChanges. 'quota' contains the storage used by the user after his files changed. The first event is 'ready' or, if Last-Event-ID is unknown or too old, 'reset' after which the client has to list all files again. The token can be passed as access_token query parameter for clients which cannot set headers.",
+        "produces": [
+          "text/event-stream"
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Stream the changes in the files of the user and in files shared with him as server-sent events",
+        "operationId": "getFileEvents",
+        "parameters": [
+          {
+            "type": "integer",
+            "format": "int64",
+            "description": "ID of the last received event to continue after when reconnecting",
+            "name": "Last-Event-ID",
+            "in": "header"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Stream of server-sent events",
+            "schema": {
+              "type": "file"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/rescan/me": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Rescan own data folder",
+        "operationId": "rescanCurrentUser",
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/rescan/{id}": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Rescan data folder by user id",
+        "operationId": "rescanUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/search": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Search files/folders",
+        "operationId": "searchFile",
+        "parameters": [
+          {
+            "name": "searchRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/SearchRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Search results",
+            "schema": {
+              "$ref": "#/definitions/FileList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/file/share/": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Share files/folders",
+        "operationId": "shareFiles",
+        "parameters": [
+          {
+            "name": "shareRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ShareRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "Error": {
-      "type": "object",
-      "properties": {
-        "message": {
-          "type": "string"
+    "/file/share/{shareID}": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get share entry by shareID",
+        "operationId": "getShareEntryByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "Requested shareID",
+            "name": "shareID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Share entry",
+            "schema": {
+              "$ref": "#/definitions/ShareEntry"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Delete share entry by shareID",
+        "operationId": "deleteShareEntryByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "ShareID to be deleted",
+            "name": "shareID",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "FileInfo": {
-      "type": "object",
-      "properties": {
-        "ID": {
-          "type": "integer",
-          "format": "int64",
-          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
-        },
-        "isDir": {
-          "type": "boolean"
-        },
-        "lastChanged": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "mimeType": {
-          "type": "string"
-        },
-        "name": {
-          "type": "string",
-          "x-go-custom-tag": "gorm:\"index:fullPath\""
-        },
-        "ownerID": {
-          "type": "integer"
-        },
-        "parentID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "path": {
-          "type": "string",
-          "x-go-custom-tag": "gorm:\"index:fullPath\""
-        },
-        "shareID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "size": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "starred": {
-          "type": "boolean",
-          "x-go-custom-tag": "gorm:\"-\""
+    "/file/starred": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Get starred files/folders infos",
+        "operationId": "getStarredFileInfos",
+        "responses": {
+          "200": {
+            "description": "Starred file infos",
+            "schema": {
+              "$ref": "#/definitions/FileList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "FileInfoUpdate": {
-      "type": "object",
-      "properties": {
-        "ID": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "isDir": {
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "lastChanged": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "mimeType": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "name": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "ownerID": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "parentID": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "path": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "shareID": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "size": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "starred": {
-          "type": "boolean",
-          "x-nullable": true
+    "/file/upload": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "consumes": [
+          "multipart/form-data"
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Uploads a file.",
+        "operationId": "uploadFile",
+        "parameters": [
+          {
+            "type": "string",
+            "description": "Path of the uploaded file, an existing file gets replaced",
+            "name": "path",
+            "in": "query",
+            "required": true
+          },
+          {
+            "type": "file",
+            "description": "The file to upload.",
+            "name": "upfile",
+            "in": "formData"
+          },
+          {
+            "type": "string",
+            "description": "Expected hex encoded SHA-256 checksum, the upload is rejected if the content does not match",
+            "name": "sha256",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Expected hex encoded MD5 checksum, the upload is rejected if the content does not match",
+            "name": "md5",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Info of the uploaded file",
+            "schema": {
+              "$ref": "#/definitions/FileInfo"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "FileList": {
-      "type": "object",
-      "properties": {
-        "files": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/FileInfo"
+    "/file/zip": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "file"
+        ],
+        "summary": "Creates a zip archive from files",
+        "operationId": "zipFiles",
+        "parameters": [
+          {
+            "name": "paths",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/PathList"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/Path"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "LoginData": {
-      "type": "object",
-      "properties": {
-        "email": {
-          "type": "string"
-        },
-        "password": {
-          "type": "string"
+    "/group": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Get all groups",
+        "operationId": "getGroups",
+        "responses": {
+          "200": {
+            "description": "Groups",
+            "schema": {
+              "$ref": "#/definitions/GroupList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
-      }
-    },
-    "Path": {
-      "type": "object",
-      "properties": {
-        "path": {
-          "type": "string"
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Create a new group",
+        "operationId": "createGroup",
+        "parameters": [
+          {
+            "description": "Name, roles and members of the group",
+            "name": "group",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Created group",
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "PathInfo": {
-      "type": "object",
-      "properties": {
-        "content": {
-          "type": "array",
-          "items": {
-            "$ref": "#/definitions/FileInfo"
+    "/group/{id}": {
+      "put": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Replace the name, roles and members of a group",
+        "operationId": "updateGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The group id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Name, roles and members of the group",
+            "name": "group",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Updated group",
+            "schema": {
+              "$ref": "#/definitions/Group"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Delete a group, its members lose the roles of the group",
+        "operationId": "deleteGroup",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The group id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
-        },
-        "fileInfo": {
-          "$ref": "#/definitions/FileInfo"
         }
       }
     },
-    "PathList": {
-      "type": "object",
-      "properties": {
-        "paths": {
-          "type": "array",
-          "items": {
-            "type": "string"
+    "/invite": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "auth"
+        ],
+        "summary": "Get all invitation codes",
+        "operationId": "getInviteCodes",
+        "responses": {
+          "200": {
+            "description": "Invitation codes",
+            "schema": {
+              "$ref": "#/definitions/InviteCodeList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "auth"
+        ],
+        "summary": "Create a new invitation code",
+        "operationId": "createInviteCode",
+        "parameters": [
+          {
+            "description": "Usage limit and expiry of the code, zero means unlimited",
+            "name": "inviteCodeRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/InviteCodeRequest"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Created invitation code",
+            "schema": {
+              "$ref": "#/definitions/InviteCode"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "Principal": {
-      "type": "object",
-      "properties": {
-        "token": {
-          "$ref": "#/definitions/Token"
-        },
-        "user": {
-          "$ref": "#/definitions/User"
+    "/invite/{id}": {
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "auth"
+        ],
+        "summary": "Revoke an invitation code",
+        "operationId": "revokeInviteCode",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The invitation code id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "SearchRequest": {
-      "type": "object",
-      "properties": {
-        "keyword": {
-          "type": "string"
+    "/mount": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get all external storages mounted into the files of users and groups",
+        "operationId": "getMounts",
+        "responses": {
+          "200": {
+            "description": "Mounts without their secrets",
+            "schema": {
+              "$ref": "#/definitions/ExternalMountList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
-      }
-    },
-    "ShareEntry": {
-      "type": "object",
-      "properties": {
-        "FileID": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "ID": {
-          "type": "integer",
-          "format": "int64",
-          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
-        },
-        "OwnerID": {
-          "type": "integer",
-          "format": "int64",
-          "x-go-custom-tag": "gorm:\"-\""
-        },
-        "SharedWithID": {
-          "type": "integer",
-          "format": "int64",
-          "x-go-custom-tag": "gorm:\"-\""
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Mount a local directory or SFTP server into the files of a user or group",
+        "operationId": "createMount",
+        "parameters": [
+          {
+            "description": "Target and mount point of the storage",
+            "name": "mount",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The created mount",
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "ShareRequest": {
-      "type": "object",
-      "properties": {
-        "paths": {
-          "type": "array",
-          "items": {
-            "type": "string"
+    "/mount/{id}": {
+      "put": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
           }
-        },
-        "users": {
-          "type": "array",
-          "items": {
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Replace the target and mount point of a mount, empty secrets are kept",
+        "operationId": "updateMount",
+        "parameters": [
+          {
+            "minimum": 1,
             "type": "integer",
-            "format": "int64"
+            "description": "The mount id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Target and mount point of the storage",
+            "name": "mount",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "The updated mount",
+            "schema": {
+              "$ref": "#/definitions/ExternalMount"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "delete": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Unmount an external storage, its files are left untouched",
+        "operationId": "deleteMount",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The mount id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
           }
         }
       }
     },
-    "SystemStats": {
-      "type": "object",
-      "properties": {
-        "allocMem": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "goVersion": {
-          "type": "string"
-        },
-        "numGC": {
-          "type": "integer"
-        },
-        "numGoroutines": {
-          "type": "integer"
-        },
-        "numSessions": {
-          "type": "integer"
-        },
-        "systemMem": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "totalAllocMem": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "uptime": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "version": {
-          "type": "string"
+    "/role": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "policy"
+        ],
+        "summary": "Get all roles with the permissions they grant and restrict",
+        "operationId": "getRoles",
+        "responses": {
+          "200": {
+            "description": "Roles",
+            "schema": {
+              "$ref": "#/definitions/RoleList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "Token": {
-      "type": "object",
-      "properties": {
-        "token": {
-          "type": "string"
+    "/system/blobs/collect": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Delete the blobs of deduplicated content that are not referenced by any file anymore",
+        "operationId": "collectBlobs",
+        "responses": {
+          "200": {
+            "description": "Count and size of the deleted blobs",
+            "schema": {
+              "$ref": "#/definitions/BlobCollectionResult"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "User": {
-      "type": "object",
-      "properties": {
-        "ID": {
-          "type": "integer",
-          "format": "int64",
-          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
-        },
-        "created": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "email": {
-          "type": "string",
-          "x-go-custom-tag": "gorm:\"unique_index\""
-        },
-        "firstName": {
-          "type": "string"
-        },
-        "isAdmin": {
-          "type": "boolean"
-        },
-        "lastName": {
-          "type": "string"
-        },
-        "lastSession": {
-          "type": "integer",
-          "format": "int64"
-        },
-        "password": {
-          "type": "string"
-        },
-        "retainFilesAfterDeletion": {
-          "type": "boolean"
-        },
-        "updated": {
-          "type": "integer",
-          "format": "int64"
+    "/system/encrypt": {
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Encrypt all files stored before the encryption at rest was enabled",
+        "operationId": "encryptStorage",
+        "responses": {
+          "200": {
+            "description": "Count of the encrypted files",
+            "schema": {
+              "$ref": "#/definitions/EncryptionResult"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
     },
-    "UserUpdate": {
-      "type": "object",
-      "properties": {
-        "ID": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "created": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "email": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "firstName": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "isAdmin": {
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "lastName": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "lastSession": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "password": {
-          "type": "string",
-          "x-nullable": true
-        },
-        "retainFilesAfterDeletion": {
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "updated": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
+    "/system/scrub": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get the report of the latest integrity scrub of the stored files",
+        "operationId": "getScrubReport",
+        "responses": {
+          "200": {
+            "description": "The latest scrub report, it is still running if it has no finish time",
+            "schema": {
+              "$ref": "#/definitions/ScrubReport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Start an integrity scrub re-hashing the stored files in the background",
+        "operationId": "startScrub",
+        "responses": {
+          "202": {
+            "description": "The scrub has been started",
+            "schema": {
+              "$ref": "#/definitions/ScrubReport"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
         }
       }
-    }
-  },
-  "securityDefinitions": {
-    "TokenAuth": {
-      "type": "oauth2",
-      "flow": "accessCode",
-      "authorizationUrl": "https://dummy.oauth.net/auth",
-      "tokenUrl": "https://dumy.oauth.net/token",
-      "scopes": {
-        "admin": "admin with all privileges",
-        "user": "normal user"
-      }
-    }
-  },
-  "tags": [
-    {
-      "description": "Authentication",
-      "name": "auth"
-    },
-    {
-      "description": "User management",
-      "name": "user"
     },
-    {
-      "description": "File management",
-      "name": "file"
-    },
-    {
-      "description": "System management",
-      "name": "system"
-    }
-  ]
-}`))
-	FlatSwaggerJSON = json.RawMessage([]byte(`{
-  "consumes": [
-    "application/json"
-  ],
-  "produces": [
-    "application/json"
-  ],
-  "schemes": [
-    "http"
-  ],
-  "swagger": "2.0",
-  "info": {
-    "description": "This is the API for the freecloud server.",
-    "title": "freecloud API",
-    "license": {
-      "name": "Apache 2.0",
-      "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+    "/system/stats": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
+        "tags": [
+          "system"
+        ],
+        "summary": "Get system status",
+        "operationId": "getSystemStats",
+        "responses": {
+          "200": {
+            "description": "System stats",
+            "schema": {
+              "$ref": "#/definitions/SystemStats"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
     },
-    "version": "1.0.0"
-  },
-  "host": "freecloud.glidingthrough.space",
-  "basePath": "/api/v1",
-  "paths": {
-    "/auth/login": {
-      "post": {
+    "/user": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "admin"
+            ]
+          }
+        ],
         "tags": [
-          "auth"
+          "user"
         ],
-        "summary": "Login with existing user",
-        "operationId": "login",
+        "summary": "Get a page of all users",
+        "operationId": "getUsers",
         "parameters": [
           {
-            "description": "Credentials for login",
-            "name": "credentials",
-            "in": "body",
-            "required": true,
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 1,
+            "description": "Page to return, starting at 1",
+            "name": "page",
+            "in": "query"
+          },
+          {
+            "minimum": 1,
+            "type": "integer",
+            "format": "int64",
+            "default": 25,
+            "description": "Users per page, at most 100",
+            "name": "perPage",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "default": "email",
+            "description": "Attribute to sort by, one of email, firstName, lastName, created or lastSession",
+            "name": "sortBy",
+            "in": "query"
+          },
+          {
+            "type": "boolean",
+            "default": false,
+            "description": "Sort in descending order",
+            "name": "descending",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "Only return users whose name or email contain this term",
+            "name": "search",
+            "in": "query"
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Users and the total count of matching users",
             "schema": {
-              "$ref": "#/definitions/LoginData"
+              "$ref": "#/definitions/UserList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
             }
           }
+        }
+      }
+    },
+    "/user/me": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
         ],
+        "summary": "Get current user",
+        "operationId": "getCurrentUser",
         "responses": {
           "200": {
             "description": "Success",
             "schema": {
-              "$ref": "#/definitions/Token"
+              "$ref": "#/definitions/User"
             }
           },
           "default": {
@@ -1361,10 +5765,8 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/auth/logout": {
-      "post": {
+      },
+      "delete": {
         "security": [
           {
             "TokenAuth": [
@@ -1373,10 +5775,55 @@ func init() {
           }
         ],
         "tags": [
-          "auth"
+          "user"
+        ],
+        "summary": "Delete current user",
+        "operationId": "deleteCurrentUser",
+        "parameters": [
+          {
+            "type": "boolean",
+            "description": "Whether files are kept after deletion, has to match retainFilesAfterDeletion of the user as confirmation",
+            "name": "retainFiles",
+            "in": "query",
+            "required": true
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
+      "patch": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Modify current user",
+        "operationId": "updateCurrentUser",
+        "parameters": [
+          {
+            "description": "Updated user info",
+            "name": "userInfo",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
+          }
         ],
-        "summary": "Logout current user",
-        "operationId": "logout",
         "responses": {
           "200": {
             "description": "Success"
@@ -1390,29 +5837,63 @@ func init() {
         }
       }
     },
-    "/auth/signup": {
+    "/user/me/access-keys": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get all S3 access keys of the current user",
+        "operationId": "getAccessKeys",
+        "responses": {
+          "200": {
+            "description": "Access keys without their secrets",
+            "schema": {
+              "$ref": "#/definitions/AccessKeyList"
+            }
+          },
+          "default": {
+            "description": "Unexpected error",
+            "schema": {
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      },
       "post": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
         "tags": [
-          "auth"
+          "user"
         ],
-        "summary": "Signup as a new user",
-        "operationId": "signup",
+        "summary": "Create an access key S3 clients can use to access the files of the current user",
+        "operationId": "createAccessKey",
         "parameters": [
           {
-            "description": "User that should be registered",
-            "name": "user",
+            "name": "accessKeyRequest",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/User"
+              "$ref": "#/definitions/AccessKeyRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success",
+            "description": "The new access key including its secret",
             "schema": {
-              "$ref": "#/definitions/Token"
+              "$ref": "#/definitions/AccessKey"
             }
           },
           "default": {
@@ -1424,8 +5905,8 @@ func init() {
         }
       }
     },
-    "/file": {
-      "get": {
+    "/user/me/access-keys/{id}": {
+      "delete": {
         "security": [
           {
             "TokenAuth": [
@@ -1434,24 +5915,52 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Get pathInfo of requested path",
-        "operationId": "getPathInfo",
+        "summary": "Revoke an S3 access key of the current user",
+        "operationId": "deleteAccessKey",
         "parameters": [
           {
-            "type": "string",
-            "description": "Requested path",
-            "name": "path",
-            "in": "query",
+            "minimum": 1,
+            "type": "integer",
+            "description": "The access key id",
+            "name": "id",
+            "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Requested pathInfo",
+            "description": "Success"
+          },
+          "default": {
+            "description": "Unexpected error",
             "schema": {
-              "$ref": "#/definitions/PathInfo"
+              "$ref": "#/definitions/Error"
+            }
+          }
+        }
+      }
+    },
+    "/user/me/app-passwords": {
+      "get": {
+        "security": [
+          {
+            "TokenAuth": [
+              "user"
+            ]
+          }
+        ],
+        "tags": [
+          "user"
+        ],
+        "summary": "Get all app passwords of the current user",
+        "operationId": "getAppPasswords",
+        "responses": {
+          "200": {
+            "description": "App passwords without the passwords themselves",
+            "schema": {
+              "$ref": "#/definitions/AppPasswordList"
             }
           },
           "default": {
@@ -1471,25 +5980,25 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Create new file/folder",
-        "operationId": "createFile",
+        "summary": "Create an app password for the current user which can be used by clients like WebDAV instead of his password",
+        "operationId": "createAppPassword",
         "parameters": [
           {
-            "name": "createFileRequest",
+            "name": "appPasswordRequest",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/CreateFileRequest"
+              "$ref": "#/definitions/AppPasswordRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success",
+            "description": "The new app password including the generated password",
             "schema": {
-              "$ref": "#/definitions/FileInfo"
+              "$ref": "#/definitions/AppPassword"
             }
           },
           "default": {
@@ -1499,7 +6008,9 @@ func init() {
             }
           }
         }
-      },
+      }
+    },
+    "/user/me/app-passwords/{id}": {
       "delete": {
         "security": [
           {
@@ -1509,16 +6020,17 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Delete file/folder",
-        "operationId": "deleteFile",
+        "summary": "Revoke an app password of the current user",
+        "operationId": "deleteAppPassword",
         "parameters": [
           {
-            "type": "string",
-            "description": "Path to fileInfo to delete",
-            "name": "path",
-            "in": "query",
+            "minimum": 1,
+            "type": "integer",
+            "description": "The app password id",
+            "name": "id",
+            "in": "path",
             "required": true
           }
         ],
@@ -1533,8 +6045,10 @@ func init() {
             }
           }
         }
-      },
-      "patch": {
+      }
+    },
+    "/user/me/avatar": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
@@ -1542,26 +6056,21 @@ func init() {
             ]
           }
         ],
+        "consumes": [
+          "multipart/form-data"
+        ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Update file/folder",
-        "operationId": "updateFile",
+        "summary": "Upload an avatar for the current user, it is cropped to a square and resized",
+        "operationId": "uploadAvatar",
         "parameters": [
           {
-            "type": "string",
-            "description": "Path to fileInfo to update",
-            "name": "path",
-            "in": "query",
+            "type": "file",
+            "description": "The JPEG, PNG or GIF image to use as avatar",
+            "name": "upfile",
+            "in": "formData",
             "required": true
-          },
-          {
-            "name": "fileInfoUpdate",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/FileInfoUpdate"
-            }
           }
         ],
         "responses": {
@@ -1575,10 +6084,8 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/file/download": {
-      "get": {
+      },
+      "delete": {
         "security": [
           {
             "TokenAuth": [
@@ -1586,29 +6093,14 @@ func init() {
             ]
           }
         ],
-        "produces": [
-          "application/gzip"
-        ],
         "tags": [
-          "file"
-        ],
-        "summary": "Downloads a file.",
-        "operationId": "downloadFile",
-        "parameters": [
-          {
-            "type": "string",
-            "description": "Path to the file to download",
-            "name": "path",
-            "in": "query",
-            "required": true
-          }
+          "user"
         ],
+        "summary": "Delete the avatar of the current user",
+        "operationId": "deleteAvatar",
         "responses": {
           "200": {
-            "description": "Requested gzipped file",
-            "schema": {
-              "type": "file"
-            }
+            "description": "Success"
           },
           "default": {
             "description": "Unexpected error",
@@ -1619,7 +6111,7 @@ func init() {
         }
       }
     },
-    "/file/rescan/me": {
+    "/user/me/export": {
       "post": {
         "security": [
           {
@@ -1629,13 +6121,16 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Rescan own data folder",
-        "operationId": "rescanCurrentUser",
+        "summary": "Request an export of all personal data of the current user, it is built in the background",
+        "operationId": "requestDataExport",
         "responses": {
-          "200": {
-            "description": "Success"
+          "202": {
+            "description": "The export has been started or is still running",
+            "schema": {
+              "$ref": "#/definitions/DataExport"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -1646,25 +6141,25 @@ func init() {
         }
       }
     },
-    "/file/rescan/{id}": {
-      "post": {
+    "/user/me/export/{id}": {
+      "get": {
         "security": [
           {
             "TokenAuth": [
-              "admin"
+              "user"
             ]
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Rescan data folder by user id",
-        "operationId": "rescanUserByID",
+        "summary": "Get the status of a data export of the current user",
+        "operationId": "getDataExport",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
-            "description": "The user id",
+            "description": "The data export id",
             "name": "id",
             "in": "path",
             "required": true
@@ -1672,7 +6167,10 @@ func init() {
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "The data export, finished exports contain a download link",
+            "schema": {
+              "$ref": "#/definitions/DataExport"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -1683,7 +6181,7 @@ func init() {
         }
       }
     },
-    "/file/search": {
+    "/user/me/ssh-keys": {
       "get": {
         "security": [
           {
@@ -1693,25 +6191,15 @@ func init() {
           }
         ],
         "tags": [
-          "file"
-        ],
-        "summary": "Search files/folders",
-        "operationId": "searchFile",
-        "parameters": [
-          {
-            "name": "searchRequest",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/SearchRequest"
-            }
-          }
+          "user"
         ],
+        "summary": "Get all SSH public keys of the current user",
+        "operationId": "getSSHKeys",
         "responses": {
           "200": {
-            "description": "Search results",
+            "description": "SSH public keys of the user",
             "schema": {
-              "$ref": "#/definitions/FileList"
+              "$ref": "#/definitions/SSHKeyList"
             }
           },
           "default": {
@@ -1721,9 +6209,7 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/file/share/": {
+      },
       "post": {
         "security": [
           {
@@ -1733,23 +6219,26 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Share files/folders",
-        "operationId": "shareFiles",
+        "summary": "Add an SSH public key the current user can log in to the SFTP server with",
+        "operationId": "addSSHKey",
         "parameters": [
           {
-            "name": "shareRequest",
+            "name": "sshKeyRequest",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/ShareRequest"
+              "$ref": "#/definitions/SSHKeyRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "The added SSH public key",
+            "schema": {
+              "$ref": "#/definitions/SSHKey"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -1760,8 +6249,8 @@ func init() {
         }
       }
     },
-    "/file/share/{shareID}": {
-      "get": {
+    "/user/me/ssh-keys/{id}": {
+      "delete": {
         "security": [
           {
             "TokenAuth": [
@@ -1770,26 +6259,23 @@ func init() {
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Get share entry by shareID",
-        "operationId": "getShareEntryByID",
+        "summary": "Remove an SSH public key of the current user",
+        "operationId": "deleteSSHKey",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
-            "description": "Requested shareID",
-            "name": "shareID",
+            "description": "The SSH key id",
+            "name": "id",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Share entry",
-            "schema": {
-              "$ref": "#/definitions/ShareEntry"
-            }
+            "description": "Success"
           },
           "default": {
             "description": "Unexpected error",
@@ -1798,33 +6284,38 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/user/{id}": {
+      "get": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Delete share entry by shareID",
-        "operationId": "deleteShareEntryByID",
+        "summary": "Get specific user by id",
+        "operationId": "getUserByID",
         "parameters": [
           {
             "minimum": 1,
             "type": "integer",
-            "description": "ShareID to be deleted",
-            "name": "shareID",
+            "description": "The user id",
+            "name": "id",
             "in": "path",
             "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Success",
+            "schema": {
+              "$ref": "#/definitions/User"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -1833,28 +6324,33 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/file/starred": {
-      "get": {
+      },
+      "delete": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "file"
+          "user"
+        ],
+        "summary": "Delete user by id",
+        "operationId": "deleteUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
         ],
-        "summary": "Get starred files/folders infos",
-        "operationId": "getStarredFileInfos",
         "responses": {
           "200": {
-            "description": "Starred file infos",
-            "schema": {
-              "$ref": "#/definitions/FileList"
-            }
+            "description": "Success"
           },
           "default": {
             "description": "Unexpected error",
@@ -1863,38 +6359,37 @@ func init() {
             }
           }
         }
-      }
-    },
-    "/file/upload": {
-      "post": {
+      },
+      "patch": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
-        "consumes": [
-          "multipart/form-data"
-        ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Uploads a file.",
-        "operationId": "uploadFile",
+        "summary": "Modify user by id",
+        "operationId": "updateUserByID",
         "parameters": [
           {
-            "type": "string",
-            "description": "Path where to upload file",
-            "name": "path",
-            "in": "query",
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
             "required": true
           },
           {
-            "type": "file",
-            "description": "The file to upload.",
-            "name": "upfile",
-            "in": "formData"
+            "description": "Updated user info",
+            "name": "userUpdate",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/UserUpdate"
+            }
           }
         ],
         "responses": {
@@ -1910,36 +6405,33 @@ func init() {
         }
       }
     },
-    "/file/zip": {
+    "/user/{id}/approve": {
       "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
-          "file"
+          "user"
         ],
-        "summary": "Creates a zip archive from files",
-        "operationId": "zipFiles",
+        "summary": "Approve a user registration pending for approval",
+        "operationId": "approveUserByID",
         "parameters": [
           {
-            "name": "paths",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/PathList"
-            }
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
           }
         ],
         "responses": {
           "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/Path"
-            }
+            "description": "Success"
           },
           "default": {
             "description": "Unexpected error",
@@ -1950,25 +6442,63 @@ func init() {
         }
       }
     },
-    "/system/stats": {
+    "/user/{id}/avatar": {
       "get": {
         "security": [
           {
             "TokenAuth": [
-              "admin"
+              "user"
             ]
           }
         ],
+        "produces": [
+          "image/png"
+        ],
         "tags": [
-          "system"
+          "user"
+        ],
+        "summary": "Get the avatar of a user as PNG",
+        "operationId": "getAvatarByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "type": "integer",
+            "format": "int64",
+            "default": 128,
+            "description": "Edge length of the avatar in pixels, one of 32, 64, 128 or 256",
+            "name": "size",
+            "in": "query"
+          },
+          {
+            "type": "string",
+            "description": "ETag of a cached avatar",
+            "name": "If-None-Match",
+            "in": "header"
+          }
         ],
-        "summary": "Get system status",
-        "operationId": "getSystemStats",
         "responses": {
           "200": {
-            "description": "System stats",
+            "description": "The avatar image",
             "schema": {
-              "$ref": "#/definitions/SystemStats"
+              "type": "file"
+            },
+            "headers": {
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
             }
           },
           "default": {
@@ -1976,30 +6506,51 @@ func init() {
             "schema": {
               "$ref": "#/definitions/Error"
             }
+          },
+          "304": {
+            "description": "The cached avatar is still valid",
+            "headers": {
+              "Cache-Control": {
+                "type": "string"
+              },
+              "ETag": {
+                "type": "string"
+              },
+              "Last-Modified": {
+                "type": "string"
+              }
+            }
           }
         }
       }
     },
-    "/user/me": {
-      "get": {
+    "/user/{id}/disable": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
           "user"
         ],
-        "summary": "Get current user",
-        "operationId": "getCurrentUser",
+        "summary": "Disable a user, this ends all his sessions and blocks logins",
+        "operationId": "disableUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
         "responses": {
           "200": {
-            "description": "Success",
-            "schema": {
-              "$ref": "#/definitions/User"
-            }
+            "description": "Success"
           },
           "default": {
             "description": "Unexpected error",
@@ -2008,20 +6559,32 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/user/{id}/enable": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
           "user"
         ],
-        "summary": "Delete current user",
-        "operationId": "deleteCurrentUser",
+        "summary": "Enable a disabled user",
+        "operationId": "enableUserByID",
+        "parameters": [
+          {
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          }
+        ],
         "responses": {
           "200": {
             "description": "Success"
@@ -2033,34 +6596,47 @@ func init() {
             }
           }
         }
-      },
-      "patch": {
+      }
+    },
+    "/user/{id}/impersonate": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
-              "user"
+              "admin"
             ]
           }
         ],
         "tags": [
           "user"
         ],
-        "summary": "Modify current user",
-        "operationId": "updateCurrentUser",
+        "summary": "Start a time-limited session acting as the user, it is read-only unless writing is allowed explicitly",
+        "operationId": "impersonateUserByID",
         "parameters": [
           {
-            "description": "Updated user info",
-            "name": "userInfo",
+            "minimum": 1,
+            "type": "integer",
+            "description": "The user id",
+            "name": "id",
+            "in": "path",
+            "required": true
+          },
+          {
+            "description": "Whether the session may change data of the user",
+            "name": "impersonationRequest",
             "in": "body",
             "required": true,
             "schema": {
-              "$ref": "#/definitions/User"
+              "$ref": "#/definitions/ImpersonationRequest"
             }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "Token of the impersonation session",
+            "schema": {
+              "$ref": "#/definitions/Token"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -2071,8 +6647,8 @@ func init() {
         }
       }
     },
-    "/user/{id}": {
-      "get": {
+    "/user/{id}/roles": {
+      "put": {
         "security": [
           {
             "TokenAuth": [
@@ -2081,10 +6657,10 @@ func init() {
           }
         ],
         "tags": [
-          "user"
+          "policy"
         ],
-        "summary": "Get specific user by id",
-        "operationId": "getUserByID",
+        "summary": "Replace the roles assigned directly to a user",
+        "operationId": "setUserRoles",
         "parameters": [
           {
             "minimum": 1,
@@ -2093,13 +6669,22 @@ func init() {
             "name": "id",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "Roles of the user",
+            "name": "roleAssignment",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/RoleAssignment"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success",
+            "description": "Roles assigned to the user",
             "schema": {
-              "$ref": "#/definitions/User"
+              "$ref": "#/definitions/RoleAssignment"
             }
           },
           "default": {
@@ -2109,8 +6694,10 @@ func init() {
             }
           }
         }
-      },
-      "delete": {
+      }
+    },
+    "/user/{id}/transfer": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
@@ -2121,8 +6708,8 @@ func init() {
         "tags": [
           "user"
         ],
-        "summary": "Delete user by id",
-        "operationId": "deleteUserByID",
+        "summary": "Transfer all files of a deleted or disabled user into a new folder of another user",
+        "operationId": "transferUserFiles",
         "parameters": [
           {
             "minimum": 1,
@@ -2131,11 +6718,23 @@ func init() {
             "name": "id",
             "in": "path",
             "required": true
+          },
+          {
+            "description": "The recipient of the files",
+            "name": "fileTransferRequest",
+            "in": "body",
+            "required": true,
+            "schema": {
+              "$ref": "#/definitions/FileTransferRequest"
+            }
           }
         ],
         "responses": {
           "200": {
-            "description": "Success"
+            "description": "The folder containing the transferred files",
+            "schema": {
+              "$ref": "#/definitions/FileInfo"
+            }
           },
           "default": {
             "description": "Unexpected error",
@@ -2144,8 +6743,10 @@ func init() {
             }
           }
         }
-      },
-      "patch": {
+      }
+    },
+    "/user/{id}/unlock": {
+      "post": {
         "security": [
           {
             "TokenAuth": [
@@ -2156,8 +6757,8 @@ func init() {
         "tags": [
           "user"
         ],
-        "summary": "Modify user by id",
-        "operationId": "updateUserByID",
+        "summary": "Unlock a user locked out due to failed login attempts",
+        "operationId": "unlockUserByID",
         "parameters": [
           {
             "minimum": 1,
@@ -2166,15 +6767,6 @@ func init() {
             "name": "id",
             "in": "path",
             "required": true
-          },
-          {
-            "description": "Updated user info",
-            "name": "userUpdate",
-            "in": "body",
-            "required": true,
-            "schema": {
-              "$ref": "#/definitions/UserUpdate"
-            }
           }
         ],
         "responses": {
@@ -2189,9 +6781,260 @@ func init() {
           }
         }
       }
-    }
-  },
-  "definitions": {
+    }
+  },
+  "definitions": {
+    "AccessKey": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "accessKeyID": {
+          "description": "ID of the key S3 clients sign their requests with",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "secretAccessKey": {
+          "description": "Secret of the key, only returned once after creating the access key",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AccessKeyList": {
+      "type": "object",
+      "properties": {
+        "accessKeys": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AccessKey"
+          }
+        }
+      }
+    },
+    "AccessKeyRequest": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the client using the access key",
+          "type": "string"
+        }
+      }
+    },
+    "Activity": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "description": "One of created, changed, moved or deleted",
+          "type": "string"
+        },
+        "actorID": {
+          "description": "User who caused the activity, 0 if the change was detected in the file system",
+          "type": "integer",
+          "format": "int64"
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "isDir": {
+          "type": "boolean"
+        },
+        "oldPath": {
+          "description": "Full path of a moved file before moving it",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "path": {
+          "description": "Full path of the file, for moves after moving it",
+          "type": "string"
+        }
+      }
+    },
+    "ActivityList": {
+      "type": "object",
+      "properties": {
+        "activities": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Activity"
+          }
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "AppPassword": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "password": {
+          "description": "Generated password, only returned once after creating the app password",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AppPasswordList": {
+      "type": "object",
+      "properties": {
+        "appPasswords": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AppPassword"
+          }
+        }
+      }
+    },
+    "AppPasswordRequest": {
+      "type": "object",
+      "required": [
+        "name"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the client using the app password",
+          "type": "string"
+        }
+      }
+    },
+    "AuditEntry": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "actorID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "ip": {
+          "type": "string"
+        },
+        "target": {
+          "type": "string"
+        },
+        "targetUserID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "AuditEntryList": {
+      "type": "object",
+      "properties": {
+        "entries": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/AuditEntry"
+          }
+        },
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "AuthCallback": {
+      "type": "object",
+      "properties": {
+        "code": {
+          "type": "string"
+        },
+        "state": {
+          "type": "string"
+        }
+      }
+    },
+    "AuthRedirect": {
+      "type": "object",
+      "properties": {
+        "url": {
+          "type": "string"
+        }
+      }
+    },
+    "BlobCollectionResult": {
+      "type": "object",
+      "properties": {
+        "deletedBlobs": {
+          "description": "Count of the unreferenced blobs that have been deleted",
+          "type": "integer",
+          "format": "int64"
+        },
+        "freedBytes": {
+          "description": "Sum of the sizes of the deleted blobs",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "CreateFileRequest": {
       "type": "object",
       "properties": {
@@ -2203,6 +7046,51 @@ func init() {
         }
       }
     },
+    "DataExport": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "downloadURL": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "finished": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "path": {
+          "description": "Path of the finished export in the files of the user",
+          "type": "string"
+        },
+        "status": {
+          "description": "One of pending, running, finished, failed or expired",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "EncryptionResult": {
+      "type": "object",
+      "properties": {
+        "encryptedFiles": {
+          "description": "Count of the files that have been encrypted",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "Error": {
       "type": "object",
       "properties": {
@@ -2211,6 +7099,150 @@ func init() {
         }
       }
     },
+    "ExternalMount": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "groupID": {
+          "description": "Group the storage is mounted for at the same path for every member",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "host": {
+          "type": "string"
+        },
+        "hostKey": {
+          "description": "Public key of the SFTP host in authorized_keys format",
+          "type": "string"
+        },
+        "password": {
+          "description": "Password for the SFTP host, never returned",
+          "type": "string"
+        },
+        "path": {
+          "description": "Path of the mount point in the files of the user or the group members",
+          "type": "string"
+        },
+        "port": {
+          "type": "integer",
+          "format": "int32"
+        },
+        "privateKey": {
+          "description": "Private key in PEM format for the SFTP host, never returned",
+          "type": "string"
+        },
+        "readOnly": {
+          "type": "boolean"
+        },
+        "root": {
+          "description": "Directory on the server or SFTP host that is mounted",
+          "type": "string"
+        },
+        "type": {
+          "type": "string",
+          "enum": [
+            "local",
+            "sftp"
+          ]
+        },
+        "userID": {
+          "description": "User the storage is mounted for, either this or the group has to be set",
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "username": {
+          "type": "string"
+        }
+      }
+    },
+    "ExternalMountList": {
+      "type": "object",
+      "properties": {
+        "mounts": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ExternalMount"
+          }
+        }
+      }
+    },
+    "FileChange": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "action": {
+          "description": "One of created, changed, moved, deleted, shared or unshared",
+          "type": "string"
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "isDir": {
+          "type": "boolean"
+        },
+        "lastChanged": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "oldPath": {
+          "description": "Full path of a moved file before moving it",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "path": {
+          "description": "Full path of the file, for moves after moving it",
+          "type": "string"
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "FileChangeList": {
+      "type": "object",
+      "properties": {
+        "changes": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileChange"
+          }
+        },
+        "cursor": {
+          "description": "Cursor to request the following changes with",
+          "type": "integer",
+          "format": "int64"
+        },
+        "hasMore": {
+          "description": "More changes are available after the cursor",
+          "type": "boolean"
+        },
+        "reset": {
+          "description": "The requested cursor is unknown or too old, the client has to list all files again and continue with the returned cursor",
+          "type": "boolean"
+        }
+      }
+    },
     "FileInfo": {
       "type": "object",
       "properties": {
@@ -2219,6 +7251,11 @@ func init() {
           "format": "int64",
           "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
         },
+        "blobHash": {
+          "description": "SHA-256 hash of the deduplicated content the file references",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
         "isDir": {
           "type": "boolean"
         },
@@ -2226,6 +7263,10 @@ func init() {
           "type": "integer",
           "format": "int64"
         },
+        "md5": {
+          "description": "Hex encoded MD5 checksum of the content for legacy tools, only computed if enabled",
+          "type": "string"
+        },
         "mimeType": {
           "type": "string"
         },
@@ -2244,6 +7285,10 @@ func init() {
           "type": "string",
           "x-go-custom-tag": "gorm:\"index:fullPath\""
         },
+        "sha256": {
+          "description": "Hex encoded SHA-256 checksum of the content, empty for folders and files not hashed yet",
+          "type": "string"
+        },
         "shareID": {
           "type": "integer",
           "format": "int64"
@@ -2258,72 +7303,195 @@ func init() {
         }
       }
     },
-    "FileInfoUpdate": {
+    "FileInfoUpdate": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "isDir": {
+          "type": "boolean",
+          "x-nullable": true
+        },
+        "lastChanged": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "mimeType": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "name": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "parentID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "path": {
+          "type": "string",
+          "x-nullable": true
+        },
+        "shareID": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "size": {
+          "type": "integer",
+          "format": "int64",
+          "x-nullable": true
+        },
+        "starred": {
+          "type": "boolean",
+          "x-nullable": true
+        }
+      }
+    },
+    "FileList": {
+      "type": "object",
+      "properties": {
+        "files": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/FileInfo"
+          }
+        }
+      }
+    },
+    "FileTransferRequest": {
+      "type": "object",
+      "required": [
+        "recipientID"
+      ],
+      "properties": {
+        "recipientID": {
+          "description": "ID of the user receiving the files",
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
+    "Group": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "memberIDs": {
+          "type": "array",
+          "items": {
+            "type": "integer",
+            "format": "int64"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "name": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"unique_index\""
+        },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        }
+      }
+    },
+    "GroupList": {
+      "type": "object",
+      "properties": {
+        "groups": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Group"
+          }
+        }
+      }
+    },
+    "ImpersonationRequest": {
+      "type": "object",
+      "properties": {
+        "allowWrite": {
+          "type": "boolean"
+        }
+      }
+    },
+    "InviteCode": {
       "type": "object",
       "properties": {
         "ID": {
           "type": "integer",
           "format": "int64",
-          "x-nullable": true
-        },
-        "isDir": {
-          "type": "boolean",
-          "x-nullable": true
-        },
-        "lastChanged": {
-          "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "mimeType": {
-          "type": "string",
-          "x-nullable": true
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
         },
-        "name": {
+        "code": {
           "type": "string",
-          "x-nullable": true
+          "x-go-custom-tag": "gorm:\"unique_index\""
         },
-        "ownerID": {
+        "created": {
           "type": "integer",
-          "format": "int64",
-          "x-nullable": true
+          "format": "int64"
         },
-        "parentID": {
+        "createdBy": {
           "type": "integer",
-          "format": "int64",
-          "x-nullable": true
-        },
-        "path": {
-          "type": "string",
-          "x-nullable": true
+          "format": "int64"
         },
-        "shareID": {
+        "expiresAt": {
           "type": "integer",
-          "format": "int64",
-          "x-nullable": true
+          "format": "int64"
         },
-        "size": {
+        "maxUses": {
           "type": "integer",
-          "format": "int64",
-          "x-nullable": true
+          "format": "int64"
         },
-        "starred": {
-          "type": "boolean",
-          "x-nullable": true
+        "uses": {
+          "type": "integer",
+          "format": "int64"
         }
       }
     },
-    "FileList": {
+    "InviteCodeList": {
       "type": "object",
       "properties": {
-        "files": {
+        "inviteCodes": {
           "type": "array",
           "items": {
-            "$ref": "#/definitions/FileInfo"
+            "$ref": "#/definitions/InviteCode"
           }
         }
       }
     },
+    "InviteCodeRequest": {
+      "type": "object",
+      "properties": {
+        "expiresAt": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "maxUses": {
+          "type": "integer",
+          "format": "int64"
+        }
+      }
+    },
     "LoginData": {
       "type": "object",
       "properties": {
@@ -2371,6 +7539,12 @@ func init() {
     "Principal": {
       "type": "object",
       "properties": {
+        "impersonator": {
+          "$ref": "#/definitions/User"
+        },
+        "readOnly": {
+          "type": "boolean"
+        },
         "token": {
           "$ref": "#/definitions/Token"
         },
@@ -2379,6 +7553,194 @@ func init() {
         }
       }
     },
+    "Role": {
+      "type": "object",
+      "properties": {
+        "description": {
+          "type": "string"
+        },
+        "grants": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        },
+        "name": {
+          "type": "string"
+        },
+        "restricts": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "RoleAssignment": {
+      "type": "object",
+      "required": [
+        "roles"
+      ],
+      "properties": {
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          }
+        }
+      }
+    },
+    "RoleList": {
+      "type": "object",
+      "properties": {
+        "roles": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/Role"
+          }
+        }
+      }
+    },
+    "SSHKey": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "created": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "fingerprint": {
+          "description": "SHA256 fingerprint of the public key",
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
+        "lastUsed": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "name": {
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "Public key in the format of authorized_keys files",
+          "type": "string"
+        },
+        "userID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "SSHKeyList": {
+      "type": "object",
+      "properties": {
+        "sshKeys": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/SSHKey"
+          }
+        }
+      }
+    },
+    "SSHKeyRequest": {
+      "type": "object",
+      "required": [
+        "publicKey"
+      ],
+      "properties": {
+        "name": {
+          "description": "Name of the key, defaults to the comment of the public key",
+          "type": "string"
+        },
+        "publicKey": {
+          "description": "Public key in the format of authorized_keys files",
+          "type": "string"
+        }
+      }
+    },
+    "ScrubIssue": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "actualSha256": {
+          "description": "SHA-256 checksum of the stored content",
+          "type": "string"
+        },
+        "expectedSha256": {
+          "description": "Recorded SHA-256 checksum of the file",
+          "type": "string"
+        },
+        "kind": {
+          "description": "One of corrupted, modified, unreadable, missing or unindexed",
+          "type": "string"
+        },
+        "ownerID": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "path": {
+          "description": "Path of the file in the files of its owner",
+          "type": "string"
+        },
+        "quarantinePath": {
+          "description": "Path in the storage the corrupted file has been moved to, empty if it has not been quarantined",
+          "type": "string"
+        },
+        "reportID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"index\""
+        }
+      }
+    },
+    "ScrubReport": {
+      "type": "object",
+      "properties": {
+        "ID": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
+        },
+        "checkedBytes": {
+          "description": "Sum of the sizes of the hashed files",
+          "type": "integer",
+          "format": "int64"
+        },
+        "checkedFiles": {
+          "description": "Count of the files whose content has been hashed",
+          "type": "integer",
+          "format": "int64"
+        },
+        "finished": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "issues": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/ScrubIssue"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "started": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "status": {
+          "description": "One of running, finished or failed",
+          "type": "string"
+        }
+      }
+    },
     "SearchRequest": {
       "type": "object",
       "properties": {
@@ -2481,10 +7843,20 @@ func init() {
           "format": "int64",
           "x-go-custom-tag": "gorm:\"primary_key;auto_increment\""
         },
+        "authProvider": {
+          "type": "string"
+        },
+        "authSubject": {
+          "type": "string",
+          "x-go-custom-tag": "gorm:\"index\""
+        },
         "created": {
           "type": "integer",
           "format": "int64"
         },
+        "disabled": {
+          "type": "boolean"
+        },
         "email": {
           "type": "string",
           "x-go-custom-tag": "gorm:\"unique_index\""
@@ -2492,6 +7864,9 @@ func init() {
         "firstName": {
           "type": "string"
         },
+        "hasAvatar": {
+          "type": "boolean"
+        },
         "isAdmin": {
           "type": "boolean"
         },
@@ -2505,15 +7880,52 @@ func init() {
         "password": {
           "type": "string"
         },
+        "pendingApproval": {
+          "type": "boolean"
+        },
+        "permissions": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
         "retainFilesAfterDeletion": {
           "type": "boolean"
         },
+        "roles": {
+          "type": "array",
+          "items": {
+            "type": "string"
+          },
+          "x-go-custom-tag": "gorm:\"-\""
+        },
+        "storageUsed": {
+          "type": "integer",
+          "format": "int64",
+          "x-go-custom-tag": "gorm:\"-\""
+        },
         "updated": {
           "type": "integer",
           "format": "int64"
         }
       }
     },
+    "UserList": {
+      "type": "object",
+      "properties": {
+        "total": {
+          "type": "integer",
+          "format": "int64"
+        },
+        "users": {
+          "type": "array",
+          "items": {
+            "$ref": "#/definitions/User"
+          }
+        }
+      }
+    },
     "UserUpdate": {
       "type": "object",
       "properties": {
@@ -2592,6 +8004,14 @@ func init() {
     {
       "description": "System management",
       "name": "system"
+    },
+    {
+      "description": "Roles, groups and permissions",
+      "name": "policy"
+    },
+    {
+      "description": "Audit log",
+      "name": "audit"
     }
   ]
 }`))
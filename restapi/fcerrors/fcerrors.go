@@ -19,8 +19,108 @@ var (
 	InvalidUserData = Code{"Invalid user data", http.StatusBadRequest}
 	// UserExists is thrown when a user already exists on signup
 	UserExists = Code{"A user with the same email already exists", http.StatusBadRequest}
+	// RegistrationDisabled is thrown on signup if registration is disabled or the registration mode is unknown
+	RegistrationDisabled = Code{"Registration is disabled", http.StatusForbidden}
+	// InvalidInviteCode is thrown on signup if the invite code is missing, unknown, expired or used up
+	InvalidInviteCode = Code{"Invite code is invalid", http.StatusForbidden}
+	// EmailDomainNotAllowed is thrown on signup if the domain of the email is not on the allowlist
+	EmailDomainNotAllowed = Code{"Registration is not allowed for this email domain", http.StatusForbidden}
+	// UserPendingApproval is thrown on login if the registration of the user has not been approved yet
+	UserPendingApproval = Code{"Registration awaits approval by an admin", http.StatusForbidden}
+	// UserDisabled is thrown on login if the user has been disabled by an admin
+	UserDisabled = Code{"User is disabled", http.StatusForbidden}
+	// DisableSelf is thrown when an admin tries to disable himself
+	DisableSelf = Code{"Admins cannot disable themselves", http.StatusBadRequest}
+	// InvalidUserListQuery is thrown when the page or sort attribute of a user list request is invalid
+	InvalidUserListQuery = Code{"Invalid page or sort attribute", http.StatusBadRequest}
+	// TransferFromActiveUser is thrown when transferring the files of a user that is neither deleted nor disabled
+	TransferFromActiveUser = Code{"Files can only be transferred from deleted or disabled users", http.StatusBadRequest}
+	// InvalidTransferRecipient is thrown when files should be transferred to their current owner or to a disabled or unapproved user
+	InvalidTransferRecipient = Code{"Files cannot be transferred to this user", http.StatusBadRequest}
+	// UserFilesNotFound is thrown when transferring the files of a user who has none
+	UserFilesNotFound = Code{"No files found for this user", http.StatusNotFound}
+	// InvalidInviteCodeData is thrown when the usage limit or expiry of a new invite code is invalid
+	InvalidInviteCodeData = Code{"Invalid invite code data", http.StatusBadRequest}
+	// InviteCodeNotFound is thrown when revoking an unknown invite code
+	InviteCodeNotFound = Code{"Invite code cannot be found", http.StatusNotFound}
 	// UserNotFound is pretty clear
 	UserNotFound = Code{"User cannot be found", http.StatusNotFound}
+	// InvalidAvatar is thrown when an uploaded avatar is not a JPEG, PNG or GIF image or too large
+	InvalidAvatar = Code{"Invalid avatar image", http.StatusBadRequest}
+	// InvalidAvatarSize is thrown when an avatar is requested in a size it is not stored in
+	InvalidAvatarSize = Code{"Avatar size is not supported", http.StatusBadRequest}
+	// AvatarNotFound is thrown when the requested user has no avatar
+	AvatarNotFound = Code{"Avatar cannot be found", http.StatusNotFound}
+	// DataExportNotFound is thrown when the requested data export does not exist or belongs to another user
+	DataExportNotFound = Code{"Data export cannot be found", http.StatusNotFound}
+	// InvalidAppPasswordData is thrown when creating an app password without a name
+	InvalidAppPasswordData = Code{"Invalid app password data", http.StatusBadRequest}
+	// AppPasswordNotFound is thrown when revoking an app password which does not exist or belongs to another user
+	AppPasswordNotFound = Code{"App password cannot be found", http.StatusNotFound}
+	// InvalidSSHKey is thrown when adding an SSH key which cannot be parsed as public key
+	InvalidSSHKey = Code{"Invalid SSH public key", http.StatusBadRequest}
+	// SSHKeyExists is thrown when adding an SSH key the user has already added
+	SSHKeyExists = Code{"The SSH key has already been added", http.StatusBadRequest}
+	// SSHKeyNotFound is thrown when removing an SSH key which does not exist or belongs to another user
+	SSHKeyNotFound = Code{"SSH key cannot be found", http.StatusNotFound}
+	// InvalidAccessKeyData is thrown when creating an access key without a name
+	InvalidAccessKeyData = Code{"Invalid access key data", http.StatusBadRequest}
+	// AccessKeysDisabled is thrown when creating or using an access key while the S3 API is not enabled
+	AccessKeysDisabled = Code{"The S3 API is not enabled", http.StatusConflict}
+	// AccessKeyNotFound is thrown when revoking an access key which does not exist or belongs to another user
+	AccessKeyNotFound = Code{"Access key cannot be found", http.StatusNotFound}
+	// RetentionNotConfirmed is thrown when deleting the current user with a retention choice differing from his settings
+	RetentionNotConfirmed = Code{"Retention of files does not match the account settings", http.StatusBadRequest}
+	// InvalidImpersonationTarget is thrown when an admin tries to impersonate himself or a disabled or unapproved user
+	InvalidImpersonationTarget = Code{"This user cannot be impersonated", http.StatusBadRequest}
+	// Forbidden is thrown when a user lacks the permission for an operation
+	Forbidden = Code{"Insufficient privileges", http.StatusForbidden}
+	// InvalidRole is thrown when assigning a role that does not exist
+	InvalidRole = Code{"Role does not exist", http.StatusBadRequest}
+	// InvalidGroupData is thrown when the name of a group is empty or one of its members does not exist
+	InvalidGroupData = Code{"Invalid group data", http.StatusBadRequest}
+	// GroupExists is thrown when creating or renaming a group to the name of another group
+	GroupExists = Code{"A group with the same name already exists", http.StatusBadRequest}
+	// GroupNotFound is pretty clear
+	GroupNotFound = Code{"Group cannot be found", http.StatusNotFound}
+	// InvalidMountData is thrown when the mount point or the target of an external storage mount is invalid
+	InvalidMountData = Code{"Invalid mount data", http.StatusBadRequest}
+	// MountNotFound is pretty clear
+	MountNotFound = Code{"Mount cannot be found", http.StatusNotFound}
+	// MountUnavailable is thrown when the external storage of a mount cannot be reached
+	MountUnavailable = Code{"External storage unavailable", http.StatusBadGateway}
+	// EncryptionDisabled is thrown when encrypting existing files while the encryption at rest is not enabled
+	EncryptionDisabled = Code{"Encryption at rest is not enabled", http.StatusConflict}
+	// DeduplicationDisabled is thrown when collecting unreferenced blobs while the deduplication is not enabled
+	DeduplicationDisabled = Code{"Deduplication of file content is not enabled", http.StatusConflict}
+	// ScrubRunning is thrown when starting an integrity scrub while another one is still running
+	ScrubRunning = Code{"A scrub of the stored files is already running", http.StatusConflict}
+	// ScrubReportNotFound is thrown when requesting the scrub report before any scrub has been started
+	ScrubReportNotFound = Code{"No scrub of the stored files has been started yet", http.StatusNotFound}
+	// InvalidChecksum is thrown when a checksum expected for an upload is not a hex or base64 encoded SHA-256 or MD5 sum
+	InvalidChecksum = Code{"Invalid checksum", http.StatusBadRequest}
+	// ChecksumMismatch is thrown when uploaded content does not match the checksum expected by the client
+	ChecksumMismatch = Code{"Checksum of the uploaded content does not match", http.StatusBadRequest}
+	// InvalidAuditQuery is thrown when the page or time range of an audit log request is invalid
+	InvalidAuditQuery = Code{"Invalid page or time range", http.StatusBadRequest}
+	// InvalidActivityQuery is thrown when the page or time range of an activity request is invalid
+	InvalidActivityQuery = Code{"Invalid page or time range", http.StatusBadRequest}
+	// InvalidChangeQuery is thrown when the cursor or limit of a file changes request is invalid
+	InvalidChangeQuery = Code{"Invalid cursor or limit", http.StatusBadRequest}
+	// FileNotFound is thrown when the requested file does not exist or is not accessible for the user
+	FileNotFound = Code{"File cannot be found", http.StatusNotFound}
+	// FileExists is thrown when moving or renaming a file to the path of another file
+	FileExists = Code{"A file with the same name already exists", http.StatusConflict}
+	// InvalidFileName is thrown when the name or path of a file contains forbidden characters
+	InvalidFileName = Code{"Invalid file name or path", http.StatusBadRequest}
+	// InvalidMoveTarget is thrown when a file cannot be moved into the requested folder, e.g. a folder into itself
+	InvalidMoveTarget = Code{"File cannot be moved there", http.StatusBadRequest}
+	// DownloadFolder is thrown when downloading a folder without zipping it first
+	DownloadFolder = Code{"Folders have to be zipped before downloading them", http.StatusBadRequest}
+	// FileReadOnly is thrown when changing a file in a read-only mount or a mount point itself
+	FileReadOnly = Code{"File is in a read-only mount or is a mount point", http.StatusForbidden}
+	// ShareNotFound is thrown when revoking a share which does not exist or neither belongs to nor is shared with the user
+	ShareNotFound = Code{"Share cannot be found", http.StatusNotFound}
 	// HashingFailed is thrown when a password hash operation failed
 	HashingFailed = Code{"Password hashing failed", http.StatusInternalServerError}
 	// Database is thrown when a DB operation failed - Try to use more fine-grained errors
@@ -29,6 +129,16 @@ var (
 	Filesystem = Code{"Filesystem error", http.StatusInternalServerError}
 	// BadCredentials for login
 	BadCredentials = Code{"Email or Password incorrect", http.StatusUnauthorized}
+	// TooManyLoginAttempts is thrown when logins are locked out after too many failed attempts
+	TooManyLoginAttempts = Code{"Too many failed login attempts, try again later", http.StatusTooManyRequests}
+	// LDAPUnavailable is thrown when the LDAP directory cannot be queried
+	LDAPUnavailable = Code{"LDAP directory unavailable", http.StatusServiceUnavailable}
+	// OIDCDisabled is thrown when a login with OpenID Connect is attempted without a configured provider
+	OIDCDisabled = Code{"OpenID Connect login is not enabled", http.StatusNotFound}
+	// OIDCLoginFailed is thrown when the state, authorization code or ID token of an OpenID Connect login is invalid
+	OIDCLoginFailed = Code{"OpenID Connect login failed", http.StatusUnauthorized}
+	// OIDCUnavailable is thrown when the OpenID Connect provider cannot be reached
+	OIDCUnavailable = Code{"OpenID Connect provider unavailable", http.StatusBadGateway}
 	// MissingCredentials from the request
 	MissingCredentials = Code{"Email or Password are missing", http.StatusBadRequest}
 	// DeleteSession failed
@@ -9,6 +9,7 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/swag"
 
 	models "github.com/freecloudio/server/models"
 )
@@ -57,6 +58,72 @@ func (o *LoginOK) WriteResponse(rw http.ResponseWriter, producer runtime.Produce
 	}
 }
 
+// LoginTooManyRequestsCode is the HTTP code returned for type LoginTooManyRequests
+const LoginTooManyRequestsCode int = 429
+
+/*LoginTooManyRequests Too many failed login attempts
+
+swagger:response loginTooManyRequests
+*/
+type LoginTooManyRequests struct {
+	/*Seconds until the next login attempt will be accepted
+
+	 */
+	RetryAfter int64 `json:"Retry-After"`
+
+	/*
+	  In: Body
+	*/
+	Payload *models.Error `json:"body,omitempty"`
+}
+
+// NewLoginTooManyRequests creates LoginTooManyRequests with default headers values
+func NewLoginTooManyRequests() *LoginTooManyRequests {
+
+	return &LoginTooManyRequests{}
+}
+
+// WithRetryAfter adds the retryAfter to the login too many requests response
+func (o *LoginTooManyRequests) WithRetryAfter(retryAfter int64) *LoginTooManyRequests {
+	o.RetryAfter = retryAfter
+	return o
+}
+
+// SetRetryAfter sets the retryAfter to the login too many requests response
+func (o *LoginTooManyRequests) SetRetryAfter(retryAfter int64) {
+	o.RetryAfter = retryAfter
+}
+
+// WithPayload adds the payload to the login too many requests response
+func (o *LoginTooManyRequests) WithPayload(payload *models.Error) *LoginTooManyRequests {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the login too many requests response
+func (o *LoginTooManyRequests) SetPayload(payload *models.Error) {
+	o.Payload = payload
+}
+
+// WriteResponse to the client
+func (o *LoginTooManyRequests) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	// response header Retry-After
+
+	retryAfter := swag.FormatInt64(o.RetryAfter)
+	if retryAfter != "" {
+		rw.Header().Set("Retry-After", retryAfter)
+	}
+
+	rw.WriteHeader(429)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
+}
+
 /*LoginDefault Unexpected error
 
 swagger:response loginDefault
@@ -13,6 +13,8 @@ import (
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
 
+	strfmt "github.com/go-openapi/strfmt"
+
 	models "github.com/freecloudio/server/models"
 )
 
@@ -32,6 +34,10 @@ type SignupParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
+	/*Invitation code, required if registration is invite-only
+	  In: query
+	*/
+	InviteCode *string
 	/*User that should be registered
 	  Required: true
 	  In: body
@@ -48,6 +54,13 @@ func (o *SignupParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qInviteCode, qhkInviteCode, _ := qs.GetOK("inviteCode")
+	if err := o.bindInviteCode(qInviteCode, qhkInviteCode, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if runtime.HasBody(r) {
 		defer r.Body.Close()
 		var body models.User
@@ -75,3 +88,22 @@ func (o *SignupParams) BindRequest(r *http.Request, route *middleware.MatchedRou
 	}
 	return nil
 }
+
+// bindInviteCode binds and validates parameter InviteCode from query.
+func (o *SignupParams) bindInviteCode(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewSignupParams()
+		return nil
+	}
+
+	o.InviteCode = &raw
+
+	return nil
+}
@@ -57,6 +57,30 @@ func (o *SignupOK) WriteResponse(rw http.ResponseWriter, producer runtime.Produc
 	}
 }
 
+// SignupAcceptedCode is the HTTP code returned for type SignupAccepted
+const SignupAcceptedCode int = 202
+
+/*SignupAccepted Registration awaits approval by an admin
+
+swagger:response signupAccepted
+*/
+type SignupAccepted struct {
+}
+
+// NewSignupAccepted creates SignupAccepted with default headers values
+func NewSignupAccepted() *SignupAccepted {
+
+	return &SignupAccepted{}
+}
+
+// WriteResponse to the client
+func (o *SignupAccepted) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+
+	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
+
+	rw.WriteHeader(202)
+}
+
 /*SignupDefault Unexpected error
 
 swagger:response signupDefault
@@ -13,7 +13,11 @@ import (
 
 // SignupURL generates an URL for the signup operation
 type SignupURL struct {
+	InviteCode *string
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +47,18 @@ func (o *SignupURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	var inviteCode string
+	if o.InviteCode != nil {
+		inviteCode = *o.InviteCode
+	}
+	if inviteCode != "" {
+		qs.Set("inviteCode", inviteCode)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -17,11 +17,19 @@ import (
 // DownloadFileOKCode is the HTTP code returned for type DownloadFileOK
 const DownloadFileOKCode int = 200
 
-/*DownloadFileOK Requested gzipped file
+/*DownloadFileOK Requested file, gzipped unless only application/octet-stream is accepted
 
 swagger:response downloadFileOK
 */
 type DownloadFileOK struct {
+	/*Checksums of the content as defined by RFC 3230, only sent if the file is downloaded uncompressed
+
+	 */
+	Digest string `json:"Digest"`
+	/*Entity tag derived from the SHA-256 checksum of the content, weak if the file is downloaded gzipped
+
+	 */
+	ETag string `json:"ETag"`
 
 	/*
 	  In: Body
@@ -35,6 +43,28 @@ func NewDownloadFileOK() *DownloadFileOK {
 	return &DownloadFileOK{}
 }
 
+// WithDigest adds the digest to the download file o k response
+func (o *DownloadFileOK) WithDigest(digest string) *DownloadFileOK {
+	o.Digest = digest
+	return o
+}
+
+// SetDigest sets the digest to the download file o k response
+func (o *DownloadFileOK) SetDigest(digest string) {
+	o.Digest = digest
+}
+
+// WithETag adds the eTag to the download file o k response
+func (o *DownloadFileOK) WithETag(eTag string) *DownloadFileOK {
+	o.ETag = eTag
+	return o
+}
+
+// SetETag sets the eTag to the download file o k response
+func (o *DownloadFileOK) SetETag(eTag string) {
+	o.ETag = eTag
+}
+
 // WithPayload adds the payload to the download file o k response
 func (o *DownloadFileOK) WithPayload(payload io.ReadCloser) *DownloadFileOK {
 	o.Payload = payload
@@ -49,6 +79,20 @@ func (o *DownloadFileOK) SetPayload(payload io.ReadCloser) {
 // WriteResponse to the client
 func (o *DownloadFileOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
+	// response header Digest
+
+	digest := o.Digest
+	if digest != "" {
+		rw.Header().Set("Digest", digest)
+	}
+
+	// response header ETag
+
+	eTag := o.ETag
+	if eTag != "" {
+		rw.Header().Set("ETag", eTag)
+	}
+
 	rw.WriteHeader(200)
 	payload := o.Payload
 	if err := producer.Produce(rw, payload); err != nil {
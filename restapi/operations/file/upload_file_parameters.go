@@ -34,11 +34,19 @@ type UploadFileParams struct {
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
 
-	/*Path where to upload file
+	/*Expected hex encoded MD5 checksum, the upload is rejected if the content does not match
+	  In: query
+	*/
+	Md5 *string
+	/*Path of the uploaded file, an existing file gets replaced
 	  Required: true
 	  In: query
 	*/
 	Path string
+	/*Expected hex encoded SHA-256 checksum, the upload is rejected if the content does not match
+	  In: query
+	*/
+	Sha256 *string
 	/*The file to upload.
 	  In: formData
 	*/
@@ -64,11 +72,21 @@ func (o *UploadFileParams) BindRequest(r *http.Request, route *middleware.Matche
 		}
 	}
 
+	qMd5, qhkMd5, _ := qs.GetOK("md5")
+	if err := o.bindMd5(qMd5, qhkMd5, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	qPath, qhkPath, _ := qs.GetOK("path")
 	if err := o.bindPath(qPath, qhkPath, route.Formats); err != nil {
 		res = append(res, err)
 	}
 
+	qSha256, qhkSha256, _ := qs.GetOK("sha256")
+	if err := o.bindSha256(qSha256, qhkSha256, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	upfile, upfileHeader, err := r.FormFile("upfile")
 	if err != nil && err != http.ErrMissingFile {
 		res = append(res, errors.New(400, "reading file %q failed: %v", "upfile", err))
@@ -86,6 +104,25 @@ func (o *UploadFileParams) BindRequest(r *http.Request, route *middleware.Matche
 	return nil
 }
 
+// bindMd5 binds and validates parameter Md5 from query.
+func (o *UploadFileParams) bindMd5(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewUploadFileParams()
+		return nil
+	}
+
+	o.Md5 = &raw
+
+	return nil
+}
+
 // bindPath binds and validates parameter Path from query.
 func (o *UploadFileParams) bindPath(rawData []string, hasKey bool, formats strfmt.Registry) error {
 	if !hasKey {
@@ -107,6 +144,25 @@ func (o *UploadFileParams) bindPath(rawData []string, hasKey bool, formats strfm
 	return nil
 }
 
+// bindSha256 binds and validates parameter Sha256 from query.
+func (o *UploadFileParams) bindSha256(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: false
+	// AllowEmptyValue: false
+	if raw == "" { // empty values pass all other validations
+		// Default values have been previously initialized by NewUploadFileParams()
+		return nil
+	}
+
+	o.Sha256 = &raw
+
+	return nil
+}
+
 // bindUpfile binds file parameter Upfile.
 //
 // The only supported validations on files are MinLength and MaxLength
@@ -16,11 +16,16 @@ import (
 // UploadFileOKCode is the HTTP code returned for type UploadFileOK
 const UploadFileOKCode int = 200
 
-/*UploadFileOK Success
+/*UploadFileOK Info of the uploaded file
 
 swagger:response uploadFileOK
 */
 type UploadFileOK struct {
+
+	/*
+	  In: Body
+	*/
+	Payload *models.FileInfo `json:"body,omitempty"`
 }
 
 // NewUploadFileOK creates UploadFileOK with default headers values
@@ -29,12 +34,27 @@ func NewUploadFileOK() *UploadFileOK {
 	return &UploadFileOK{}
 }
 
+// WithPayload adds the payload to the upload file o k response
+func (o *UploadFileOK) WithPayload(payload *models.FileInfo) *UploadFileOK {
+	o.Payload = payload
+	return o
+}
+
+// SetPayload sets the payload to the upload file o k response
+func (o *UploadFileOK) SetPayload(payload *models.FileInfo) {
+	o.Payload = payload
+}
+
 // WriteResponse to the client
 func (o *UploadFileOK) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
 
-	rw.Header().Del(runtime.HeaderContentType) //Remove Content-Type on empty responses
-
 	rw.WriteHeader(200)
+	if o.Payload != nil {
+		payload := o.Payload
+		if err := producer.Produce(rw, payload); err != nil {
+			panic(err) // let the recovery middleware deal with this
+		}
+	}
 }
 
 /*UploadFileDefault Unexpected error
@@ -13,7 +13,9 @@ import (
 
 // UploadFileURL generates an URL for the upload file operation
 type UploadFileURL struct {
-	Path string
+	Md5    *string
+	Path   string
+	Sha256 *string
 
 	_basePath string
 	// avoid unkeyed usage
@@ -49,11 +51,27 @@ func (o *UploadFileURL) Build() (*url.URL, error) {
 
 	qs := make(url.Values)
 
+	var md5 string
+	if o.Md5 != nil {
+		md5 = *o.Md5
+	}
+	if md5 != "" {
+		qs.Set("md5", md5)
+	}
+
 	path := o.Path
 	if path != "" {
 		qs.Set("path", path)
 	}
 
+	var sha256 string
+	if o.Sha256 != nil {
+		sha256 = *o.Sha256
+	}
+	if sha256 != "" {
+		qs.Set("sha256", sha256)
+	}
+
 	_result.RawQuery = qs.Encode()
 
 	return &_result, nil
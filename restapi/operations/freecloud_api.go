@@ -20,8 +20,10 @@ import (
 	strfmt "github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 
+	"github.com/freecloudio/server/restapi/operations/audit"
 	"github.com/freecloudio/server/restapi/operations/auth"
 	"github.com/freecloudio/server/restapi/operations/file"
+	"github.com/freecloudio/server/restapi/operations/policy"
 	"github.com/freecloudio/server/restapi/operations/system"
 	"github.com/freecloudio/server/restapi/operations/user"
 
@@ -49,30 +51,135 @@ func NewFreecloudAPI(spec *loads.Document) *FreecloudAPI {
 			return errors.NotImplemented("gzip producer has not yet been implemented")
 		}),
 		JSONProducer: runtime.JSONProducer(),
+		BinProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("bin producer has not yet been implemented")
+		}),
+		PngProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("png producer has not yet been implemented")
+		}),
+		TextEventStreamProducer: runtime.ProducerFunc(func(w io.Writer, data interface{}) error {
+			return errors.NotImplemented("texteventstream producer has not yet been implemented")
+		}),
+		UserAddSSHKeyHandler: user.AddSSHKeyHandlerFunc(func(params user.AddSSHKeyParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserAddSSHKey has not yet been implemented")
+		}),
+		UserApproveUserByIDHandler: user.ApproveUserByIDHandlerFunc(func(params user.ApproveUserByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserApproveUserByID has not yet been implemented")
+		}),
+		SystemCollectBlobsHandler: system.CollectBlobsHandlerFunc(func(params system.CollectBlobsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemCollectBlobs has not yet been implemented")
+		}),
+		UserCreateAccessKeyHandler: user.CreateAccessKeyHandlerFunc(func(params user.CreateAccessKeyParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserCreateAccessKey has not yet been implemented")
+		}),
+		UserCreateAppPasswordHandler: user.CreateAppPasswordHandlerFunc(func(params user.CreateAppPasswordParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserCreateAppPassword has not yet been implemented")
+		}),
 		FileCreateFileHandler: file.CreateFileHandlerFunc(func(params file.CreateFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileCreateFile has not yet been implemented")
 		}),
+		PolicyCreateGroupHandler: policy.CreateGroupHandlerFunc(func(params policy.CreateGroupParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyCreateGroup has not yet been implemented")
+		}),
+		AuthCreateInviteCodeHandler: auth.CreateInviteCodeHandlerFunc(func(params auth.CreateInviteCodeParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation AuthCreateInviteCode has not yet been implemented")
+		}),
+		SystemCreateMountHandler: system.CreateMountHandlerFunc(func(params system.CreateMountParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemCreateMount has not yet been implemented")
+		}),
+		UserDeleteAccessKeyHandler: user.DeleteAccessKeyHandlerFunc(func(params user.DeleteAccessKeyParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserDeleteAccessKey has not yet been implemented")
+		}),
+		UserDeleteAppPasswordHandler: user.DeleteAppPasswordHandlerFunc(func(params user.DeleteAppPasswordParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserDeleteAppPassword has not yet been implemented")
+		}),
+		UserDeleteAvatarHandler: user.DeleteAvatarHandlerFunc(func(params user.DeleteAvatarParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserDeleteAvatar has not yet been implemented")
+		}),
 		UserDeleteCurrentUserHandler: user.DeleteCurrentUserHandlerFunc(func(params user.DeleteCurrentUserParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserDeleteCurrentUser has not yet been implemented")
 		}),
 		FileDeleteFileHandler: file.DeleteFileHandlerFunc(func(params file.DeleteFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileDeleteFile has not yet been implemented")
 		}),
+		PolicyDeleteGroupHandler: policy.DeleteGroupHandlerFunc(func(params policy.DeleteGroupParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyDeleteGroup has not yet been implemented")
+		}),
+		SystemDeleteMountHandler: system.DeleteMountHandlerFunc(func(params system.DeleteMountParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemDeleteMount has not yet been implemented")
+		}),
+		UserDeleteSSHKeyHandler: user.DeleteSSHKeyHandlerFunc(func(params user.DeleteSSHKeyParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserDeleteSSHKey has not yet been implemented")
+		}),
 		FileDeleteShareEntryByIDHandler: file.DeleteShareEntryByIDHandlerFunc(func(params file.DeleteShareEntryByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileDeleteShareEntryByID has not yet been implemented")
 		}),
 		UserDeleteUserByIDHandler: user.DeleteUserByIDHandlerFunc(func(params user.DeleteUserByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserDeleteUserByID has not yet been implemented")
 		}),
+		UserDisableUserByIDHandler: user.DisableUserByIDHandlerFunc(func(params user.DisableUserByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserDisableUserByID has not yet been implemented")
+		}),
 		FileDownloadFileHandler: file.DownloadFileHandlerFunc(func(params file.DownloadFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileDownloadFile has not yet been implemented")
 		}),
+		UserEnableUserByIDHandler: user.EnableUserByIDHandlerFunc(func(params user.EnableUserByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserEnableUserByID has not yet been implemented")
+		}),
+		SystemEncryptStorageHandler: system.EncryptStorageHandlerFunc(func(params system.EncryptStorageParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemEncryptStorage has not yet been implemented")
+		}),
+		AuditExportAuditEntriesHandler: audit.ExportAuditEntriesHandlerFunc(func(params audit.ExportAuditEntriesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation AuditExportAuditEntries has not yet been implemented")
+		}),
+		UserGetAccessKeysHandler: user.GetAccessKeysHandlerFunc(func(params user.GetAccessKeysParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetAccessKeys has not yet been implemented")
+		}),
+		FileGetActivitiesHandler: file.GetActivitiesHandlerFunc(func(params file.GetActivitiesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation FileGetActivities has not yet been implemented")
+		}),
+		UserGetAppPasswordsHandler: user.GetAppPasswordsHandlerFunc(func(params user.GetAppPasswordsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetAppPasswords has not yet been implemented")
+		}),
+		AuditGetAuditEntriesHandler: audit.GetAuditEntriesHandlerFunc(func(params audit.GetAuditEntriesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation AuditGetAuditEntries has not yet been implemented")
+		}),
+		UserGetAvatarByIDHandler: user.GetAvatarByIDHandlerFunc(func(params user.GetAvatarByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetAvatarByID has not yet been implemented")
+		}),
 		UserGetCurrentUserHandler: user.GetCurrentUserHandlerFunc(func(params user.GetCurrentUserParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserGetCurrentUser has not yet been implemented")
 		}),
+		UserGetDataExportHandler: user.GetDataExportHandlerFunc(func(params user.GetDataExportParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetDataExport has not yet been implemented")
+		}),
+		FileGetFileChangesHandler: file.GetFileChangesHandlerFunc(func(params file.GetFileChangesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation FileGetFileChanges has not yet been implemented")
+		}),
+		FileGetFileEventsHandler: file.GetFileEventsHandlerFunc(func(params file.GetFileEventsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation FileGetFileEvents has not yet been implemented")
+		}),
+		PolicyGetGroupsHandler: policy.GetGroupsHandlerFunc(func(params policy.GetGroupsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetGroups has not yet been implemented")
+		}),
+		AuthGetInviteCodesHandler: auth.GetInviteCodesHandlerFunc(func(params auth.GetInviteCodesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation AuthGetInviteCodes has not yet been implemented")
+		}),
+		SystemGetMountsHandler: system.GetMountsHandlerFunc(func(params system.GetMountsParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemGetMounts has not yet been implemented")
+		}),
 		FileGetPathInfoHandler: file.GetPathInfoHandlerFunc(func(params file.GetPathInfoParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileGetPathInfo has not yet been implemented")
 		}),
+		PolicyGetRolesHandler: policy.GetRolesHandlerFunc(func(params policy.GetRolesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyGetRoles has not yet been implemented")
+		}),
+		UserGetSSHKeysHandler: user.GetSSHKeysHandlerFunc(func(params user.GetSSHKeysParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetSSHKeys has not yet been implemented")
+		}),
+		SystemGetScrubReportHandler: system.GetScrubReportHandlerFunc(func(params system.GetScrubReportParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemGetScrubReport has not yet been implemented")
+		}),
 		FileGetShareEntryByIDHandler: file.GetShareEntryByIDHandlerFunc(func(params file.GetShareEntryByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileGetShareEntryByID has not yet been implemented")
 		}),
@@ -85,36 +192,75 @@ func NewFreecloudAPI(spec *loads.Document) *FreecloudAPI {
 		UserGetUserByIDHandler: user.GetUserByIDHandlerFunc(func(params user.GetUserByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserGetUserByID has not yet been implemented")
 		}),
+		UserGetUsersHandler: user.GetUsersHandlerFunc(func(params user.GetUsersParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserGetUsers has not yet been implemented")
+		}),
+		UserImpersonateUserByIDHandler: user.ImpersonateUserByIDHandlerFunc(func(params user.ImpersonateUserByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserImpersonateUserByID has not yet been implemented")
+		}),
 		AuthLoginHandler: auth.LoginHandlerFunc(func(params auth.LoginParams) middleware.Responder {
 			return middleware.NotImplemented("operation AuthLogin has not yet been implemented")
 		}),
 		AuthLogoutHandler: auth.LogoutHandlerFunc(func(params auth.LogoutParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation AuthLogout has not yet been implemented")
 		}),
+		AuthOidcAuthorizeHandler: auth.OidcAuthorizeHandlerFunc(func(params auth.OidcAuthorizeParams) middleware.Responder {
+			return middleware.NotImplemented("operation AuthOidcAuthorize has not yet been implemented")
+		}),
+		AuthOidcLoginHandler: auth.OidcLoginHandlerFunc(func(params auth.OidcLoginParams) middleware.Responder {
+			return middleware.NotImplemented("operation AuthOidcLogin has not yet been implemented")
+		}),
+		UserRequestDataExportHandler: user.RequestDataExportHandlerFunc(func(params user.RequestDataExportParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserRequestDataExport has not yet been implemented")
+		}),
 		FileRescanCurrentUserHandler: file.RescanCurrentUserHandlerFunc(func(params file.RescanCurrentUserParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileRescanCurrentUser has not yet been implemented")
 		}),
 		FileRescanUserByIDHandler: file.RescanUserByIDHandlerFunc(func(params file.RescanUserByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileRescanUserByID has not yet been implemented")
 		}),
+		AuthRevokeInviteCodeHandler: auth.RevokeInviteCodeHandlerFunc(func(params auth.RevokeInviteCodeParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation AuthRevokeInviteCode has not yet been implemented")
+		}),
 		FileSearchFileHandler: file.SearchFileHandlerFunc(func(params file.SearchFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileSearchFile has not yet been implemented")
 		}),
+		PolicySetUserRolesHandler: policy.SetUserRolesHandlerFunc(func(params policy.SetUserRolesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicySetUserRoles has not yet been implemented")
+		}),
 		FileShareFilesHandler: file.ShareFilesHandlerFunc(func(params file.ShareFilesParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileShareFiles has not yet been implemented")
 		}),
 		AuthSignupHandler: auth.SignupHandlerFunc(func(params auth.SignupParams) middleware.Responder {
 			return middleware.NotImplemented("operation AuthSignup has not yet been implemented")
 		}),
+		SystemStartScrubHandler: system.StartScrubHandlerFunc(func(params system.StartScrubParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemStartScrub has not yet been implemented")
+		}),
+		UserTransferUserFilesHandler: user.TransferUserFilesHandlerFunc(func(params user.TransferUserFilesParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserTransferUserFiles has not yet been implemented")
+		}),
+		UserUnlockUserByIDHandler: user.UnlockUserByIDHandlerFunc(func(params user.UnlockUserByIDParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserUnlockUserByID has not yet been implemented")
+		}),
 		UserUpdateCurrentUserHandler: user.UpdateCurrentUserHandlerFunc(func(params user.UpdateCurrentUserParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserUpdateCurrentUser has not yet been implemented")
 		}),
 		FileUpdateFileHandler: file.UpdateFileHandlerFunc(func(params file.UpdateFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileUpdateFile has not yet been implemented")
 		}),
+		PolicyUpdateGroupHandler: policy.UpdateGroupHandlerFunc(func(params policy.UpdateGroupParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation PolicyUpdateGroup has not yet been implemented")
+		}),
+		SystemUpdateMountHandler: system.UpdateMountHandlerFunc(func(params system.UpdateMountParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation SystemUpdateMount has not yet been implemented")
+		}),
 		UserUpdateUserByIDHandler: user.UpdateUserByIDHandlerFunc(func(params user.UpdateUserByIDParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation UserUpdateUserByID has not yet been implemented")
 		}),
+		UserUploadAvatarHandler: user.UploadAvatarHandlerFunc(func(params user.UploadAvatarParams, principal *models.Principal) middleware.Responder {
+			return middleware.NotImplemented("operation UserUploadAvatar has not yet been implemented")
+		}),
 		FileUploadFileHandler: file.UploadFileHandlerFunc(func(params file.UploadFileParams, principal *models.Principal) middleware.Responder {
 			return middleware.NotImplemented("operation FileUploadFile has not yet been implemented")
 		}),
@@ -162,6 +308,12 @@ type FreecloudAPI struct {
 	GzipProducer runtime.Producer
 	// JSONProducer registers a producer for a "application/json" mime type
 	JSONProducer runtime.Producer
+	// BinProducer registers a producer for a "application/octet-stream" mime type
+	BinProducer runtime.Producer
+	// PngProducer registers a producer for a "image/png" mime type
+	PngProducer runtime.Producer
+	// TextEventStreamProducer registers a producer for a "text/event-stream" mime type
+	TextEventStreamProducer runtime.Producer
 
 	// TokenAuthAuth registers a function that takes an access token and a collection of required scopes and returns a principal
 	// it performs authentication based on an oauth2 bearer token provided in the request
@@ -170,22 +322,86 @@ type FreecloudAPI struct {
 	// APIAuthorizer provides access control (ACL/RBAC/ABAC) by providing access to the request and authenticated principal
 	APIAuthorizer runtime.Authorizer
 
+	// UserAddSSHKeyHandler sets the operation handler for the add SSH key operation
+	UserAddSSHKeyHandler user.AddSSHKeyHandler
+	// UserApproveUserByIDHandler sets the operation handler for the approve user by ID operation
+	UserApproveUserByIDHandler user.ApproveUserByIDHandler
+	// SystemCollectBlobsHandler sets the operation handler for the collect blobs operation
+	SystemCollectBlobsHandler system.CollectBlobsHandler
+	// UserCreateAccessKeyHandler sets the operation handler for the create access key operation
+	UserCreateAccessKeyHandler user.CreateAccessKeyHandler
+	// UserCreateAppPasswordHandler sets the operation handler for the create app password operation
+	UserCreateAppPasswordHandler user.CreateAppPasswordHandler
 	// FileCreateFileHandler sets the operation handler for the create file operation
 	FileCreateFileHandler file.CreateFileHandler
+	// PolicyCreateGroupHandler sets the operation handler for the create group operation
+	PolicyCreateGroupHandler policy.CreateGroupHandler
+	// AuthCreateInviteCodeHandler sets the operation handler for the create invite code operation
+	AuthCreateInviteCodeHandler auth.CreateInviteCodeHandler
+	// SystemCreateMountHandler sets the operation handler for the create mount operation
+	SystemCreateMountHandler system.CreateMountHandler
+	// UserDeleteAccessKeyHandler sets the operation handler for the delete access key operation
+	UserDeleteAccessKeyHandler user.DeleteAccessKeyHandler
+	// UserDeleteAppPasswordHandler sets the operation handler for the delete app password operation
+	UserDeleteAppPasswordHandler user.DeleteAppPasswordHandler
+	// UserDeleteAvatarHandler sets the operation handler for the delete avatar operation
+	UserDeleteAvatarHandler user.DeleteAvatarHandler
 	// UserDeleteCurrentUserHandler sets the operation handler for the delete current user operation
 	UserDeleteCurrentUserHandler user.DeleteCurrentUserHandler
 	// FileDeleteFileHandler sets the operation handler for the delete file operation
 	FileDeleteFileHandler file.DeleteFileHandler
+	// PolicyDeleteGroupHandler sets the operation handler for the delete group operation
+	PolicyDeleteGroupHandler policy.DeleteGroupHandler
+	// SystemDeleteMountHandler sets the operation handler for the delete mount operation
+	SystemDeleteMountHandler system.DeleteMountHandler
+	// UserDeleteSSHKeyHandler sets the operation handler for the delete SSH key operation
+	UserDeleteSSHKeyHandler user.DeleteSSHKeyHandler
 	// FileDeleteShareEntryByIDHandler sets the operation handler for the delete share entry by ID operation
 	FileDeleteShareEntryByIDHandler file.DeleteShareEntryByIDHandler
 	// UserDeleteUserByIDHandler sets the operation handler for the delete user by ID operation
 	UserDeleteUserByIDHandler user.DeleteUserByIDHandler
+	// UserDisableUserByIDHandler sets the operation handler for the disable user by ID operation
+	UserDisableUserByIDHandler user.DisableUserByIDHandler
 	// FileDownloadFileHandler sets the operation handler for the download file operation
 	FileDownloadFileHandler file.DownloadFileHandler
+	// UserEnableUserByIDHandler sets the operation handler for the enable user by ID operation
+	UserEnableUserByIDHandler user.EnableUserByIDHandler
+	// SystemEncryptStorageHandler sets the operation handler for the encrypt storage operation
+	SystemEncryptStorageHandler system.EncryptStorageHandler
+	// AuditExportAuditEntriesHandler sets the operation handler for the export audit entries operation
+	AuditExportAuditEntriesHandler audit.ExportAuditEntriesHandler
+	// UserGetAccessKeysHandler sets the operation handler for the get access keys operation
+	UserGetAccessKeysHandler user.GetAccessKeysHandler
+	// FileGetActivitiesHandler sets the operation handler for the get activities operation
+	FileGetActivitiesHandler file.GetActivitiesHandler
+	// UserGetAppPasswordsHandler sets the operation handler for the get app passwords operation
+	UserGetAppPasswordsHandler user.GetAppPasswordsHandler
+	// AuditGetAuditEntriesHandler sets the operation handler for the get audit entries operation
+	AuditGetAuditEntriesHandler audit.GetAuditEntriesHandler
+	// UserGetAvatarByIDHandler sets the operation handler for the get avatar by ID operation
+	UserGetAvatarByIDHandler user.GetAvatarByIDHandler
 	// UserGetCurrentUserHandler sets the operation handler for the get current user operation
 	UserGetCurrentUserHandler user.GetCurrentUserHandler
+	// UserGetDataExportHandler sets the operation handler for the get data export operation
+	UserGetDataExportHandler user.GetDataExportHandler
+	// FileGetFileChangesHandler sets the operation handler for the get file changes operation
+	FileGetFileChangesHandler file.GetFileChangesHandler
+	// FileGetFileEventsHandler sets the operation handler for the get file events operation
+	FileGetFileEventsHandler file.GetFileEventsHandler
+	// PolicyGetGroupsHandler sets the operation handler for the get groups operation
+	PolicyGetGroupsHandler policy.GetGroupsHandler
+	// AuthGetInviteCodesHandler sets the operation handler for the get invite codes operation
+	AuthGetInviteCodesHandler auth.GetInviteCodesHandler
+	// SystemGetMountsHandler sets the operation handler for the get mounts operation
+	SystemGetMountsHandler system.GetMountsHandler
 	// FileGetPathInfoHandler sets the operation handler for the get path info operation
 	FileGetPathInfoHandler file.GetPathInfoHandler
+	// PolicyGetRolesHandler sets the operation handler for the get roles operation
+	PolicyGetRolesHandler policy.GetRolesHandler
+	// UserGetSSHKeysHandler sets the operation handler for the get SSH keys operation
+	UserGetSSHKeysHandler user.GetSSHKeysHandler
+	// SystemGetScrubReportHandler sets the operation handler for the get scrub report operation
+	SystemGetScrubReportHandler system.GetScrubReportHandler
 	// FileGetShareEntryByIDHandler sets the operation handler for the get share entry by ID operation
 	FileGetShareEntryByIDHandler file.GetShareEntryByIDHandler
 	// FileGetStarredFileInfosHandler sets the operation handler for the get starred file infos operation
@@ -194,26 +410,52 @@ type FreecloudAPI struct {
 	SystemGetSystemStatsHandler system.GetSystemStatsHandler
 	// UserGetUserByIDHandler sets the operation handler for the get user by ID operation
 	UserGetUserByIDHandler user.GetUserByIDHandler
+	// UserGetUsersHandler sets the operation handler for the get users operation
+	UserGetUsersHandler user.GetUsersHandler
+	// UserImpersonateUserByIDHandler sets the operation handler for the impersonate user by ID operation
+	UserImpersonateUserByIDHandler user.ImpersonateUserByIDHandler
 	// AuthLoginHandler sets the operation handler for the login operation
 	AuthLoginHandler auth.LoginHandler
 	// AuthLogoutHandler sets the operation handler for the logout operation
 	AuthLogoutHandler auth.LogoutHandler
+	// AuthOidcAuthorizeHandler sets the operation handler for the oidc authorize operation
+	AuthOidcAuthorizeHandler auth.OidcAuthorizeHandler
+	// AuthOidcLoginHandler sets the operation handler for the oidc login operation
+	AuthOidcLoginHandler auth.OidcLoginHandler
+	// UserRequestDataExportHandler sets the operation handler for the request data export operation
+	UserRequestDataExportHandler user.RequestDataExportHandler
 	// FileRescanCurrentUserHandler sets the operation handler for the rescan current user operation
 	FileRescanCurrentUserHandler file.RescanCurrentUserHandler
 	// FileRescanUserByIDHandler sets the operation handler for the rescan user by ID operation
 	FileRescanUserByIDHandler file.RescanUserByIDHandler
+	// AuthRevokeInviteCodeHandler sets the operation handler for the revoke invite code operation
+	AuthRevokeInviteCodeHandler auth.RevokeInviteCodeHandler
 	// FileSearchFileHandler sets the operation handler for the search file operation
 	FileSearchFileHandler file.SearchFileHandler
+	// PolicySetUserRolesHandler sets the operation handler for the set user roles operation
+	PolicySetUserRolesHandler policy.SetUserRolesHandler
 	// FileShareFilesHandler sets the operation handler for the share files operation
 	FileShareFilesHandler file.ShareFilesHandler
 	// AuthSignupHandler sets the operation handler for the signup operation
 	AuthSignupHandler auth.SignupHandler
+	// SystemStartScrubHandler sets the operation handler for the start scrub operation
+	SystemStartScrubHandler system.StartScrubHandler
+	// UserTransferUserFilesHandler sets the operation handler for the transfer user files operation
+	UserTransferUserFilesHandler user.TransferUserFilesHandler
+	// UserUnlockUserByIDHandler sets the operation handler for the unlock user by ID operation
+	UserUnlockUserByIDHandler user.UnlockUserByIDHandler
 	// UserUpdateCurrentUserHandler sets the operation handler for the update current user operation
 	UserUpdateCurrentUserHandler user.UpdateCurrentUserHandler
 	// FileUpdateFileHandler sets the operation handler for the update file operation
 	FileUpdateFileHandler file.UpdateFileHandler
+	// PolicyUpdateGroupHandler sets the operation handler for the update group operation
+	PolicyUpdateGroupHandler policy.UpdateGroupHandler
+	// SystemUpdateMountHandler sets the operation handler for the update mount operation
+	SystemUpdateMountHandler system.UpdateMountHandler
 	// UserUpdateUserByIDHandler sets the operation handler for the update user by ID operation
 	UserUpdateUserByIDHandler user.UpdateUserByIDHandler
+	// UserUploadAvatarHandler sets the operation handler for the upload avatar operation
+	UserUploadAvatarHandler user.UploadAvatarHandler
 	// FileUploadFileHandler sets the operation handler for the upload file operation
 	FileUploadFileHandler file.UploadFileHandler
 	// FileZipFilesHandler sets the operation handler for the zip files operation
@@ -289,14 +531,70 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "JSONProducer")
 	}
 
+	if o.BinProducer == nil {
+		unregistered = append(unregistered, "BinProducer")
+	}
+
+	if o.PngProducer == nil {
+		unregistered = append(unregistered, "PngProducer")
+	}
+
+	if o.TextEventStreamProducer == nil {
+		unregistered = append(unregistered, "TextEventStreamProducer")
+	}
+
 	if o.TokenAuthAuth == nil {
 		unregistered = append(unregistered, "TokenAuthAuth")
 	}
 
+	if o.UserAddSSHKeyHandler == nil {
+		unregistered = append(unregistered, "user.AddSSHKeyHandler")
+	}
+
+	if o.UserApproveUserByIDHandler == nil {
+		unregistered = append(unregistered, "user.ApproveUserByIDHandler")
+	}
+
+	if o.SystemCollectBlobsHandler == nil {
+		unregistered = append(unregistered, "system.CollectBlobsHandler")
+	}
+
+	if o.UserCreateAccessKeyHandler == nil {
+		unregistered = append(unregistered, "user.CreateAccessKeyHandler")
+	}
+
+	if o.UserCreateAppPasswordHandler == nil {
+		unregistered = append(unregistered, "user.CreateAppPasswordHandler")
+	}
+
 	if o.FileCreateFileHandler == nil {
 		unregistered = append(unregistered, "file.CreateFileHandler")
 	}
 
+	if o.PolicyCreateGroupHandler == nil {
+		unregistered = append(unregistered, "policy.CreateGroupHandler")
+	}
+
+	if o.AuthCreateInviteCodeHandler == nil {
+		unregistered = append(unregistered, "auth.CreateInviteCodeHandler")
+	}
+
+	if o.SystemCreateMountHandler == nil {
+		unregistered = append(unregistered, "system.CreateMountHandler")
+	}
+
+	if o.UserDeleteAccessKeyHandler == nil {
+		unregistered = append(unregistered, "user.DeleteAccessKeyHandler")
+	}
+
+	if o.UserDeleteAppPasswordHandler == nil {
+		unregistered = append(unregistered, "user.DeleteAppPasswordHandler")
+	}
+
+	if o.UserDeleteAvatarHandler == nil {
+		unregistered = append(unregistered, "user.DeleteAvatarHandler")
+	}
+
 	if o.UserDeleteCurrentUserHandler == nil {
 		unregistered = append(unregistered, "user.DeleteCurrentUserHandler")
 	}
@@ -305,6 +603,18 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "file.DeleteFileHandler")
 	}
 
+	if o.PolicyDeleteGroupHandler == nil {
+		unregistered = append(unregistered, "policy.DeleteGroupHandler")
+	}
+
+	if o.SystemDeleteMountHandler == nil {
+		unregistered = append(unregistered, "system.DeleteMountHandler")
+	}
+
+	if o.UserDeleteSSHKeyHandler == nil {
+		unregistered = append(unregistered, "user.DeleteSSHKeyHandler")
+	}
+
 	if o.FileDeleteShareEntryByIDHandler == nil {
 		unregistered = append(unregistered, "file.DeleteShareEntryByIDHandler")
 	}
@@ -313,18 +623,90 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "user.DeleteUserByIDHandler")
 	}
 
+	if o.UserDisableUserByIDHandler == nil {
+		unregistered = append(unregistered, "user.DisableUserByIDHandler")
+	}
+
 	if o.FileDownloadFileHandler == nil {
 		unregistered = append(unregistered, "file.DownloadFileHandler")
 	}
 
+	if o.UserEnableUserByIDHandler == nil {
+		unregistered = append(unregistered, "user.EnableUserByIDHandler")
+	}
+
+	if o.SystemEncryptStorageHandler == nil {
+		unregistered = append(unregistered, "system.EncryptStorageHandler")
+	}
+
+	if o.AuditExportAuditEntriesHandler == nil {
+		unregistered = append(unregistered, "audit.ExportAuditEntriesHandler")
+	}
+
+	if o.UserGetAccessKeysHandler == nil {
+		unregistered = append(unregistered, "user.GetAccessKeysHandler")
+	}
+
+	if o.FileGetActivitiesHandler == nil {
+		unregistered = append(unregistered, "file.GetActivitiesHandler")
+	}
+
+	if o.UserGetAppPasswordsHandler == nil {
+		unregistered = append(unregistered, "user.GetAppPasswordsHandler")
+	}
+
+	if o.AuditGetAuditEntriesHandler == nil {
+		unregistered = append(unregistered, "audit.GetAuditEntriesHandler")
+	}
+
+	if o.UserGetAvatarByIDHandler == nil {
+		unregistered = append(unregistered, "user.GetAvatarByIDHandler")
+	}
+
 	if o.UserGetCurrentUserHandler == nil {
 		unregistered = append(unregistered, "user.GetCurrentUserHandler")
 	}
 
+	if o.UserGetDataExportHandler == nil {
+		unregistered = append(unregistered, "user.GetDataExportHandler")
+	}
+
+	if o.FileGetFileChangesHandler == nil {
+		unregistered = append(unregistered, "file.GetFileChangesHandler")
+	}
+
+	if o.FileGetFileEventsHandler == nil {
+		unregistered = append(unregistered, "file.GetFileEventsHandler")
+	}
+
+	if o.PolicyGetGroupsHandler == nil {
+		unregistered = append(unregistered, "policy.GetGroupsHandler")
+	}
+
+	if o.AuthGetInviteCodesHandler == nil {
+		unregistered = append(unregistered, "auth.GetInviteCodesHandler")
+	}
+
+	if o.SystemGetMountsHandler == nil {
+		unregistered = append(unregistered, "system.GetMountsHandler")
+	}
+
 	if o.FileGetPathInfoHandler == nil {
 		unregistered = append(unregistered, "file.GetPathInfoHandler")
 	}
 
+	if o.PolicyGetRolesHandler == nil {
+		unregistered = append(unregistered, "policy.GetRolesHandler")
+	}
+
+	if o.UserGetSSHKeysHandler == nil {
+		unregistered = append(unregistered, "user.GetSSHKeysHandler")
+	}
+
+	if o.SystemGetScrubReportHandler == nil {
+		unregistered = append(unregistered, "system.GetScrubReportHandler")
+	}
+
 	if o.FileGetShareEntryByIDHandler == nil {
 		unregistered = append(unregistered, "file.GetShareEntryByIDHandler")
 	}
@@ -341,6 +723,14 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "user.GetUserByIDHandler")
 	}
 
+	if o.UserGetUsersHandler == nil {
+		unregistered = append(unregistered, "user.GetUsersHandler")
+	}
+
+	if o.UserImpersonateUserByIDHandler == nil {
+		unregistered = append(unregistered, "user.ImpersonateUserByIDHandler")
+	}
+
 	if o.AuthLoginHandler == nil {
 		unregistered = append(unregistered, "auth.LoginHandler")
 	}
@@ -349,6 +739,18 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "auth.LogoutHandler")
 	}
 
+	if o.AuthOidcAuthorizeHandler == nil {
+		unregistered = append(unregistered, "auth.OidcAuthorizeHandler")
+	}
+
+	if o.AuthOidcLoginHandler == nil {
+		unregistered = append(unregistered, "auth.OidcLoginHandler")
+	}
+
+	if o.UserRequestDataExportHandler == nil {
+		unregistered = append(unregistered, "user.RequestDataExportHandler")
+	}
+
 	if o.FileRescanCurrentUserHandler == nil {
 		unregistered = append(unregistered, "file.RescanCurrentUserHandler")
 	}
@@ -357,10 +759,18 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "file.RescanUserByIDHandler")
 	}
 
+	if o.AuthRevokeInviteCodeHandler == nil {
+		unregistered = append(unregistered, "auth.RevokeInviteCodeHandler")
+	}
+
 	if o.FileSearchFileHandler == nil {
 		unregistered = append(unregistered, "file.SearchFileHandler")
 	}
 
+	if o.PolicySetUserRolesHandler == nil {
+		unregistered = append(unregistered, "policy.SetUserRolesHandler")
+	}
+
 	if o.FileShareFilesHandler == nil {
 		unregistered = append(unregistered, "file.ShareFilesHandler")
 	}
@@ -369,6 +779,18 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "auth.SignupHandler")
 	}
 
+	if o.SystemStartScrubHandler == nil {
+		unregistered = append(unregistered, "system.StartScrubHandler")
+	}
+
+	if o.UserTransferUserFilesHandler == nil {
+		unregistered = append(unregistered, "user.TransferUserFilesHandler")
+	}
+
+	if o.UserUnlockUserByIDHandler == nil {
+		unregistered = append(unregistered, "user.UnlockUserByIDHandler")
+	}
+
 	if o.UserUpdateCurrentUserHandler == nil {
 		unregistered = append(unregistered, "user.UpdateCurrentUserHandler")
 	}
@@ -377,10 +799,22 @@ func (o *FreecloudAPI) Validate() error {
 		unregistered = append(unregistered, "file.UpdateFileHandler")
 	}
 
+	if o.PolicyUpdateGroupHandler == nil {
+		unregistered = append(unregistered, "policy.UpdateGroupHandler")
+	}
+
+	if o.SystemUpdateMountHandler == nil {
+		unregistered = append(unregistered, "system.UpdateMountHandler")
+	}
+
 	if o.UserUpdateUserByIDHandler == nil {
 		unregistered = append(unregistered, "user.UpdateUserByIDHandler")
 	}
 
+	if o.UserUploadAvatarHandler == nil {
+		unregistered = append(unregistered, "user.UploadAvatarHandler")
+	}
+
 	if o.FileUploadFileHandler == nil {
 		unregistered = append(unregistered, "file.UploadFileHandler")
 	}
@@ -463,6 +897,15 @@ func (o *FreecloudAPI) ProducersFor(mediaTypes []string) map[string]runtime.Prod
 		case "application/json":
 			result["application/json"] = o.JSONProducer
 
+		case "application/octet-stream":
+			result["application/octet-stream"] = o.BinProducer
+
+		case "image/png":
+			result["image/png"] = o.PngProducer
+
+		case "text/event-stream":
+			result["text/event-stream"] = o.TextEventStreamProducer
+
 		}
 
 		if p, ok := o.customProducers[mt]; ok {
@@ -505,11 +948,66 @@ func (o *FreecloudAPI) initHandlerCache() {
 		o.handlers = make(map[string]map[string]http.Handler)
 	}
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/me/ssh-keys"] = user.NewAddSSHKey(o.context, o.UserAddSSHKeyHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/approve"] = user.NewApproveUserByID(o.context, o.UserApproveUserByIDHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/system/blobs/collect"] = system.NewCollectBlobs(o.context, o.SystemCollectBlobsHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/me/access-keys"] = user.NewCreateAccessKey(o.context, o.UserCreateAccessKeyHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/me/app-passwords"] = user.NewCreateAppPassword(o.context, o.UserCreateAppPasswordHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
 	o.handlers["POST"]["/file"] = file.NewCreateFile(o.context, o.FileCreateFileHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/group"] = policy.NewCreateGroup(o.context, o.PolicyCreateGroupHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/invite"] = auth.NewCreateInviteCode(o.context, o.AuthCreateInviteCodeHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/mount"] = system.NewCreateMount(o.context, o.SystemCreateMountHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/user/me/access-keys/{id}"] = user.NewDeleteAccessKey(o.context, o.UserDeleteAccessKeyHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/user/me/app-passwords/{id}"] = user.NewDeleteAppPassword(o.context, o.UserDeleteAppPasswordHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/user/me/avatar"] = user.NewDeleteAvatar(o.context, o.UserDeleteAvatarHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -520,6 +1018,21 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/file"] = file.NewDeleteFile(o.context, o.FileDeleteFileHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/group/{id}"] = policy.NewDeleteGroup(o.context, o.PolicyDeleteGroupHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/mount/{id}"] = system.NewDeleteMount(o.context, o.SystemDeleteMountHandler)
+
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/user/me/ssh-keys/{id}"] = user.NewDeleteSSHKey(o.context, o.UserDeleteSSHKeyHandler)
+
 	if o.handlers["DELETE"] == nil {
 		o.handlers["DELETE"] = make(map[string]http.Handler)
 	}
@@ -530,21 +1043,111 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["DELETE"]["/user/{id}"] = user.NewDeleteUserByID(o.context, o.UserDeleteUserByIDHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/disable"] = user.NewDisableUserByID(o.context, o.UserDisableUserByIDHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/file/download"] = file.NewDownloadFile(o.context, o.FileDownloadFileHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/enable"] = user.NewEnableUserByID(o.context, o.UserEnableUserByIDHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/system/encrypt"] = system.NewEncryptStorage(o.context, o.SystemEncryptStorageHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/audit/export"] = audit.NewExportAuditEntries(o.context, o.AuditExportAuditEntriesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/me/access-keys"] = user.NewGetAccessKeys(o.context, o.UserGetAccessKeysHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/file/activity"] = file.NewGetActivities(o.context, o.FileGetActivitiesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/me/app-passwords"] = user.NewGetAppPasswords(o.context, o.UserGetAppPasswordsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/audit"] = audit.NewGetAuditEntries(o.context, o.AuditGetAuditEntriesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/{id}/avatar"] = user.NewGetAvatarByID(o.context, o.UserGetAvatarByIDHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/user/me"] = user.NewGetCurrentUser(o.context, o.UserGetCurrentUserHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/me/export/{id}"] = user.NewGetDataExport(o.context, o.UserGetDataExportHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/file/changes"] = file.NewGetFileChanges(o.context, o.FileGetFileChangesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/file/events"] = file.NewGetFileEvents(o.context, o.FileGetFileEventsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/group"] = policy.NewGetGroups(o.context, o.PolicyGetGroupsHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/invite"] = auth.NewGetInviteCodes(o.context, o.AuthGetInviteCodesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/mount"] = system.NewGetMounts(o.context, o.SystemGetMountsHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/file"] = file.NewGetPathInfo(o.context, o.FileGetPathInfoHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/role"] = policy.NewGetRoles(o.context, o.PolicyGetRolesHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user/me/ssh-keys"] = user.NewGetSSHKeys(o.context, o.UserGetSSHKeysHandler)
+
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/system/scrub"] = system.NewGetScrubReport(o.context, o.SystemGetScrubReportHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
@@ -565,6 +1168,16 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["GET"]["/user/{id}"] = user.NewGetUserByID(o.context, o.UserGetUserByIDHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/user"] = user.NewGetUsers(o.context, o.UserGetUsersHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/impersonate"] = user.NewImpersonateUserByID(o.context, o.UserImpersonateUserByIDHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -575,6 +1188,21 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/auth/logout"] = auth.NewLogout(o.context, o.AuthLogoutHandler)
 
+	if o.handlers["GET"] == nil {
+		o.handlers["GET"] = make(map[string]http.Handler)
+	}
+	o.handlers["GET"]["/auth/oidc/authorize"] = auth.NewOidcAuthorize(o.context, o.AuthOidcAuthorizeHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/auth/oidc/callback"] = auth.NewOidcLogin(o.context, o.AuthOidcLoginHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/me/export"] = user.NewRequestDataExport(o.context, o.UserRequestDataExportHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -585,11 +1213,21 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/file/rescan/{id}"] = file.NewRescanUserByID(o.context, o.FileRescanUserByIDHandler)
 
+	if o.handlers["DELETE"] == nil {
+		o.handlers["DELETE"] = make(map[string]http.Handler)
+	}
+	o.handlers["DELETE"]["/invite/{id}"] = auth.NewRevokeInviteCode(o.context, o.AuthRevokeInviteCodeHandler)
+
 	if o.handlers["GET"] == nil {
 		o.handlers["GET"] = make(map[string]http.Handler)
 	}
 	o.handlers["GET"]["/file/search"] = file.NewSearchFile(o.context, o.FileSearchFileHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/user/{id}/roles"] = policy.NewSetUserRoles(o.context, o.PolicySetUserRolesHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -600,6 +1238,21 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["POST"]["/auth/signup"] = auth.NewSignup(o.context, o.AuthSignupHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/system/scrub"] = system.NewStartScrub(o.context, o.SystemStartScrubHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/transfer"] = user.NewTransferUserFiles(o.context, o.UserTransferUserFilesHandler)
+
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/{id}/unlock"] = user.NewUnlockUserByID(o.context, o.UserUnlockUserByIDHandler)
+
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
@@ -610,11 +1263,26 @@ func (o *FreecloudAPI) initHandlerCache() {
 	}
 	o.handlers["PATCH"]["/file"] = file.NewUpdateFile(o.context, o.FileUpdateFileHandler)
 
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/group/{id}"] = policy.NewUpdateGroup(o.context, o.PolicyUpdateGroupHandler)
+
+	if o.handlers["PUT"] == nil {
+		o.handlers["PUT"] = make(map[string]http.Handler)
+	}
+	o.handlers["PUT"]["/mount/{id}"] = system.NewUpdateMount(o.context, o.SystemUpdateMountHandler)
+
 	if o.handlers["PATCH"] == nil {
 		o.handlers["PATCH"] = make(map[string]http.Handler)
 	}
 	o.handlers["PATCH"]["/user/{id}"] = user.NewUpdateUserByID(o.context, o.UserUpdateUserByIDHandler)
 
+	if o.handlers["POST"] == nil {
+		o.handlers["POST"] = make(map[string]http.Handler)
+	}
+	o.handlers["POST"]["/user/me/avatar"] = user.NewUploadAvatar(o.context, o.UserUploadAvatarHandler)
+
 	if o.handlers["POST"] == nil {
 		o.handlers["POST"] = make(map[string]http.Handler)
 	}
@@ -9,7 +9,11 @@ import (
 	"net/http"
 
 	"github.com/go-openapi/errors"
+	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/runtime/middleware"
+	"github.com/go-openapi/swag"
+
+	strfmt "github.com/go-openapi/strfmt"
 )
 
 // NewDeleteCurrentUserParams creates a new DeleteCurrentUserParams object
@@ -27,6 +31,12 @@ type DeleteCurrentUserParams struct {
 
 	// HTTP Request Object
 	HTTPRequest *http.Request `json:"-"`
+
+	/*Whether files are kept after deletion, has to match retainFilesAfterDeletion of the user as confirmation
+	  Required: true
+	  In: query
+	*/
+	RetainFiles bool
 }
 
 // BindRequest both binds and validates a request, it assumes that complex things implement a Validatable(strfmt.Registry) error interface
@@ -38,8 +48,37 @@ func (o *DeleteCurrentUserParams) BindRequest(r *http.Request, route *middleware
 
 	o.HTTPRequest = r
 
+	qs := runtime.Values(r.URL.Query())
+
+	qRetainFiles, qhkRetainFiles, _ := qs.GetOK("retainFiles")
+	if err := o.bindRetainFiles(qRetainFiles, qhkRetainFiles, route.Formats); err != nil {
+		res = append(res, err)
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
 	return nil
 }
+
+// bindRetainFiles binds and validates parameter RetainFiles from query.
+func (o *DeleteCurrentUserParams) bindRetainFiles(rawData []string, hasKey bool, formats strfmt.Registry) error {
+	if !hasKey {
+		return errors.Required("retainFiles", "query")
+	}
+	var raw string
+	if len(rawData) > 0 {
+		raw = rawData[len(rawData)-1]
+	}
+
+	// Required: true
+	// AllowEmptyValue: false
+
+	value, err := swag.ConvertBool(raw)
+	if err != nil {
+		return errors.InvalidType("retainFiles", "query", "bool", raw)
+	}
+	o.RetainFiles = value
+
+	return nil
+}
@@ -9,11 +9,17 @@ import (
 	"errors"
 	"net/url"
 	golangswaggerpaths "path"
+
+	"github.com/go-openapi/swag"
 )
 
 // DeleteCurrentUserURL generates an URL for the delete current user operation
 type DeleteCurrentUserURL struct {
+	RetainFiles bool
+
 	_basePath string
+	// avoid unkeyed usage
+	_ struct{}
 }
 
 // WithBasePath sets the base path for this url builder, only required when it's different from the
@@ -43,6 +49,15 @@ func (o *DeleteCurrentUserURL) Build() (*url.URL, error) {
 	}
 	_result.Path = golangswaggerpaths.Join(_basePath, _path)
 
+	qs := make(url.Values)
+
+	retainFiles := swag.FormatBool(o.RetainFiles)
+	if retainFiles != "" {
+		qs.Set("retainFiles", retainFiles)
+	}
+
+	_result.RawQuery = qs.Encode()
+
 	return &_result, nil
 }
 
@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	// Register the decoders for all supported image types
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MaxImageFileSize is the maximum size in bytes of an image to decode
+	MaxImageFileSize = 10 * 1024 * 1024
+	// MaxImagePixels is the maximum amount of pixels of an image to decode, this prevents decompression bombs
+	MaxImagePixels = 40 * 1000 * 1000
+)
+
+var (
+	// ErrUnsupportedImage is returned when the data is not a JPEG, PNG or GIF image
+	ErrUnsupportedImage = errors.New("image is not a JPEG, PNG or GIF")
+	// ErrImageTooLarge is returned when the image exceeds MaxImageFileSize or MaxImagePixels
+	ErrImageTooLarge = errors.New("image is too large")
+)
+
+var supportedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// DecodeImage reads a JPEG, PNG or GIF image from reader.
+// The type is sniffed from the content, the file name or a declared content type are not trusted.
+func DecodeImage(reader io.Reader) (image.Image, error) {
+	data, err := ioutil.ReadAll(io.LimitReader(reader, MaxImageFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxImageFileSize {
+		return nil, ErrImageTooLarge
+	}
+
+	if !supportedImageTypes[http.DetectContentType(data)] {
+		return nil, ErrUnsupportedImage
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width <= 0 || config.Height <= 0 {
+		return nil, ErrUnsupportedImage
+	}
+	if int64(config.Width)*int64(config.Height) > MaxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	return img, nil
+}
+
+// CropAndResizeSquare cuts the largest square out of the center of img and scales it to size x size pixels
+func CropAndResizeSquare(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	edge := bounds.Dx()
+	if bounds.Dy() < edge {
+		edge = bounds.Dy()
+	}
+	minX := bounds.Min.X + (bounds.Dx()-edge)/2
+	minY := bounds.Min.Y + (bounds.Dy()-edge)/2
+	crop := image.Rect(minX, minY, minX+edge, minY+edge)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, crop, draw.Src, nil)
+	return dst
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func testImage(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	// Paint the left and right third red and the center green
+	for x := 0; x < width; x++ {
+		for y := 0; y < height; y++ {
+			c := color.RGBA{0, 255, 0, 255}
+			if x < width/3 || x >= width-width/3 {
+				c = color.RGBA{255, 0, 0, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDecodeImage(t *testing.T) {
+	img := testImage(30, 10)
+	encoders := map[string]func(*bytes.Buffer) error{
+		"png":  func(buf *bytes.Buffer) error { return png.Encode(buf, img) },
+		"jpeg": func(buf *bytes.Buffer) error { return jpeg.Encode(buf, img, nil) },
+		"gif":  func(buf *bytes.Buffer) error { return gif.Encode(buf, img, nil) },
+	}
+	for name, encode := range encoders {
+		var buf bytes.Buffer
+		if err := encode(&buf); err != nil {
+			t.Fatalf("Failed to encode %s test image: %v", name, err)
+		}
+		decoded, err := DecodeImage(&buf)
+		if err != nil {
+			t.Errorf("Failed to decode %s image: %v", name, err)
+			continue
+		}
+		if decoded.Bounds().Dx() != 30 || decoded.Bounds().Dy() != 10 {
+			t.Errorf("Expected decoded %s image of 30x10 but got %v", name, decoded.Bounds())
+		}
+	}
+
+	invalids := map[string]struct {
+		data   string
+		expErr error
+	}{
+		"text":      {"just some text", ErrUnsupportedImage},
+		"svg":       {`<svg xmlns="http://www.w3.org/2000/svg"></svg>`, ErrUnsupportedImage},
+		"truncated": {"\x89PNG\r\n\x1a\n", ErrUnsupportedImage},
+		"too large": {strings.Repeat("a", MaxImageFileSize+1), ErrImageTooLarge},
+	}
+	for name, invalid := range invalids {
+		_, err := DecodeImage(strings.NewReader(invalid.data))
+		if err != invalid.expErr {
+			t.Errorf("Expected error '%v' for %s but got: %v", invalid.expErr, name, err)
+		}
+	}
+}
+
+func TestCropAndResizeSquare(t *testing.T) {
+	img := CropAndResizeSquare(testImage(300, 100), 64)
+	if img.Bounds().Dx() != 64 || img.Bounds().Dy() != 64 {
+		t.Fatalf("Expected image of 64x64 but got %v", img.Bounds())
+	}
+
+	// Only the green center third should be left after cropping
+	for _, x := range []int{0, 32, 63} {
+		if c := img.RGBAAt(x, 32); c.R > 10 || c.G < 245 {
+			t.Errorf("Expected green pixel at %d after center crop but got %v", x, c)
+		}
+	}
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "math/rand"
+import (
+	cryptoRand "crypto/rand"
+	"math/rand"
+)
 
 const (
 	letterBytes   = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
@@ -28,3 +31,24 @@ func RandomString(length int) string {
 
 	return string(b)
 }
+
+// SecureRandomString returns a random string of the given length from a cryptographically secure source.
+// It uses the same characters as RandomString and should be used for secrets like OAuth states.
+func SecureRandomString(length int) (string, error) {
+	b := make([]byte, length)
+	buf := make([]byte, length)
+	for i := 0; i < length; {
+		if _, err := cryptoRand.Read(buf); err != nil {
+			return "", err
+		}
+		// Discard indices outside of the alphabet instead of wrapping them to avoid a biased distribution
+		for _, r := range buf {
+			if idx := int(r & letterIdxMask); idx < len(letterBytes) && i < length {
+				b[i] = letterBytes[idx]
+				i++
+			}
+		}
+	}
+
+	return string(b), nil
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "testing"
+import (
+	"strings"
+	"testing"
+)
 
 func TestRandomString(t *testing.T) {
 	var l = []int{1, 5, 10, 20}
@@ -14,3 +17,27 @@ func TestRandomString(t *testing.T) {
 		t.Error("Expected two different random strings but got two times the same")
 	}
 }
+
+func TestSecureRandomString(t *testing.T) {
+	var l = []int{1, 5, 10, 43, 128}
+	for _, v := range l {
+		str, err := SecureRandomString(v)
+		if err != nil {
+			t.Fatalf("Failed to generate secure random string: %v", err)
+		}
+		if len(str) != v {
+			t.Errorf("Expected string of length %d, but got %d", v, len(str))
+		}
+		for _, c := range str {
+			if !strings.ContainsRune(letterBytes, c) {
+				t.Errorf("Expected only letters and digits but got %q", c)
+			}
+		}
+	}
+
+	str0, _ := SecureRandomString(32)
+	str1, _ := SecureRandomString(32)
+	if str0 == str1 {
+		t.Error("Expected two different random strings but got two times the same")
+	}
+}